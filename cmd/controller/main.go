@@ -10,6 +10,7 @@ import (
 
 	internalclient "github.com/skupperproject/skupper/internal/kube/client"
 	"github.com/skupperproject/skupper/internal/kube/controller"
+	"github.com/skupperproject/skupper/internal/kube/metrics"
 	"github.com/skupperproject/skupper/internal/version"
 )
 
@@ -73,6 +74,12 @@ func main() {
 		log.Fatal("Error getting new site controller ", err.Error())
 	}
 
+	if config.MetricsEnabled() {
+		metricsServer := metrics.NewServer(config.MetricsAddress(), controller.Ready)
+		metricsServer.Start()
+		defer metricsServer.Stop()
+	}
+
 	if err = controller.Run(stopCh); err != nil {
 		log.Fatal("Error running site controller: ", err.Error())
 	}
@@ -53,6 +53,10 @@ func main() {
 	var configMapName string
 	iflag.StringVar(flags, &configDir, "config-dir", "SKUPPER_CONFIG_DIR", "/etc/skupper-router-certs", "The directory to which configuration should be saved")
 	iflag.StringVar(flags, &configMapName, "router-config", "SKUPPER_ROUTER_CONFIG", "skupper-router", "The name of the ConfigMap containing the router config")
+	var driftCheckInterval time.Duration
+	if err := iflag.DurationVar(flags, &driftCheckInterval, "drift-check-interval", "SKUPPER_DRIFT_CHECK_INTERVAL", adaptor.DefaultDriftCheckInterval, "How often the running router is checked against the ConfigMap for changes made to it directly. Zero disables the check."); err != nil {
+		log.Fatal(err)
+	}
 
 	// if -version used, report and exit
 	isVersion := flags.Bool("version", false, "Report the version of Config Sync")
@@ -98,6 +102,7 @@ func main() {
 	go http.ListenAndServe(":9191", nil)
 
 	configSync := adaptor.NewConfigSync(cli, cli.GetNamespace(), configDir, configMapName)
+	configSync.SetDriftCheckInterval(driftCheckInterval)
 	log.Println("Starting controller loop...")
 	configSync.Start(stopCh)
 
@@ -4,10 +4,16 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
+	"net/http"
 	"net/url"
 	"os"
+	"strings"
 	"time"
 
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/auth"
 	"github.com/skupperproject/skupper/internal/utils/tlscfg"
 )
 
@@ -24,12 +30,131 @@ type Config struct {
 	RouterTLS     TLSSpec
 	FlowRecordTTL time.Duration
 
+	// FlowStore selects where connection and request records are kept once
+	// their flows expire from memory: "memory" discards them, "bolt"
+	// archives them to the database at FlowStorePath.
+	FlowStore           string
+	FlowStorePath       string
+	FlowStoreRetention  time.Duration
+	FlowStoreMaxRecords int
+
+	// OTLPEndpoint is the URL of an OTLP receiver connections, requests and
+	// network topology metrics are exported to. Export is disabled when
+	// empty.
+	OTLPEndpoint       string
+	OTLPProtocol       string
+	OTLPHeaders        string
+	OTLPTLS            TLSSpec
+	OTLPExportInterval time.Duration
+
 	VanflowLoggingProfile string
 
+	// VanflowCapture is the path of a file the vanflow messages of the
+	// router network are captured to instead of running the observer.
+	VanflowCapture string
+	// VanflowReplay is the path of a capture file replayed in place of the
+	// router network, VanflowReplaySpeed times faster than captured.
+	VanflowReplay      string
+	VanflowReplaySpeed float64
+
+	// Auth configures the authentication of API and console requests,
+	// which is disabled when no authentication method is configured.
+	Auth AuthConfig
+
 	EnableProfile bool
 	CORSAllowAll  bool
 }
 
+type AuthConfig struct {
+	HtpasswdFile         string
+	TokenFile            string
+	TokenReview          bool
+	TokenReviewAudiences string
+	OIDCIssuer           string
+	OIDCClientID         string
+	OIDCUsernameClaim    string
+	OIDCGroupsClaim      string
+	OIDCCA               string
+	DefaultRole          string
+	FlowsSubjects        string
+}
+
+func (a AuthConfig) enabled() bool {
+	return a.HtpasswdFile != "" || a.TokenFile != "" || a.TokenReview || a.OIDCIssuer != ""
+}
+
+// authenticators returns the configured authenticators in the order
+// they are tried: htpasswd, static tokens, OIDC and TokenReview.
+func (a AuthConfig) authenticators() ([]auth.Authenticator, error) {
+	var authenticators []auth.Authenticator
+	if a.HtpasswdFile != "" {
+		htpasswd, err := auth.NewHtpasswd(a.HtpasswdFile)
+		if err != nil {
+			return nil, fmt.Errorf("error loading auth-htpasswd-file: %s", err)
+		}
+		authenticators = append(authenticators, htpasswd)
+	}
+	if a.TokenFile != "" {
+		tokens, err := auth.NewStaticTokens(a.TokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("error loading auth-token-file: %s", err)
+		}
+		authenticators = append(authenticators, tokens)
+	}
+	if a.OIDCIssuer != "" {
+		client := &http.Client{Timeout: 10 * time.Second}
+		if a.OIDCCA != "" {
+			tlsConfig, err := TLSSpec{CA: a.OIDCCA}.config()
+			if err != nil {
+				return nil, fmt.Errorf("error loading auth-oidc-ca: %s", err)
+			}
+			client.Transport = &http.Transport{TLSClientConfig: tlsConfig}
+		}
+		oidc, err := auth.NewOIDC(auth.OIDCConfig{
+			Issuer:        a.OIDCIssuer,
+			ClientID:      a.OIDCClientID,
+			UsernameClaim: a.OIDCUsernameClaim,
+			GroupsClaim:   a.OIDCGroupsClaim,
+			Client:        client,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error configuring oidc authentication: %s", err)
+		}
+		authenticators = append(authenticators, oidc)
+	}
+	if a.TokenReview {
+		restConfig, err := rest.InClusterConfig()
+		if err != nil {
+			return nil, fmt.Errorf("auth-token-review requires running in a kubernetes cluster: %s", err)
+		}
+		client, err := kubernetes.NewForConfig(restConfig)
+		if err != nil {
+			return nil, fmt.Errorf("error creating kubernetes client: %s", err)
+		}
+		authenticators = append(authenticators, auth.NewTokenReview(client, splitList(a.TokenReviewAudiences)))
+	}
+	return authenticators, nil
+}
+
+func (a AuthConfig) roles() (auth.Roles, error) {
+	defaultRole, err := auth.ParseRole(a.DefaultRole)
+	if err != nil {
+		return auth.Roles{}, fmt.Errorf("invalid auth-default-role: %s", err)
+	}
+	return auth.NewRoles(defaultRole, splitList(a.FlowsSubjects)), nil
+}
+
+// splitList splits a comma separated list, dropping empty items.
+func splitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 type TLSSpec struct {
 	CA         string
 	Cert       string
@@ -69,6 +194,23 @@ func (t TLSSpec) config() (*tls.Config, error) {
 	return config, nil
 }
 
+// parseOTLPHeaders parses a comma separated list of key=value pairs.
+func parseOTLPHeaders(headers string) (map[string]string, error) {
+	if headers == "" {
+		return nil, nil
+	}
+	parsed := make(map[string]string)
+	for _, header := range strings.Split(headers, ",") {
+		key, value, ok := strings.Cut(header, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid header %q: expected key=value", header)
+		}
+		parsed[key] = strings.TrimSpace(value)
+	}
+	return parsed, nil
+}
+
 func parsePrometheusAPI(base string) (*url.URL, error) {
 	targetPromAPI, err := url.Parse(base)
 	if err != nil {
@@ -10,12 +10,21 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/auth"
 )
 
 func handleMetrics(reg *prometheus.Registry) http.Handler {
 	return promhttp.HandlerFor(reg, promhttp.HandlerOpts{Registry: reg})
 }
 
+// handleSecuredMetrics serves the metrics of the registry only to users
+// with the flows role once authenticated: the metrics describe
+// connections and requests.
+func handleSecuredMetrics(reg *prometheus.Registry, authenticate func(http.Handler) http.Handler) http.Handler {
+	return authenticate(auth.RequireRole(auth.RoleFlows)(handleMetrics(reg)))
+}
+
 func handleSwagger(prefix string, content fs.FS) http.Handler {
 	return http.StripPrefix(prefix, http.FileServer(http.FS(content)))
 }
@@ -75,6 +84,12 @@ func handleGetUser() http.Handler {
 	handleEmpty := handleNoContent()
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		var response UserResponse
+		if user, ok := auth.UserFrom(r.Context()); ok {
+			response.Username = user.Name
+			response.AuthMode = user.Method
+			json.NewEncoder(w).Encode(response)
+			return
+		}
 		if cookie, err := r.Cookie("_oauth_proxy"); err == nil && cookie != nil {
 			if cookieDecoded, _ := base64.StdEncoding.DecodeString(cookie.Value); cookieDecoded != nil {
 				response.Username = string(cookieDecoded)
@@ -3,10 +3,15 @@ package main
 import (
 	"bytes"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/auth"
 )
 
 func TestHandleProxyPrometheusAPI(t *testing.T) {
@@ -119,3 +124,76 @@ func TestHandleProxyPrometheusAPI(t *testing.T) {
 		}
 	}
 }
+
+func TestHandleGetUser(t *testing.T) {
+	handler := handleGetUser()
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v2alpha1/user", nil))
+	if w.Code != http.StatusNoContent {
+		t.Errorf("expected status %d but got %d", http.StatusNoContent, w.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v2alpha1/user", nil)
+	req = req.WithContext(auth.WithUser(req.Context(), &auth.User{Name: "alice", Method: "basic"}))
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status %d but got %d", http.StatusOK, w.Code)
+	}
+	expected := `{"username":"alice","authType":"basic"}`
+	if actual := strings.TrimSpace(w.Body.String()); actual != expected {
+		t.Errorf("expected response body %q but got %q", expected, actual)
+	}
+}
+
+type headerAuthenticator struct{}
+
+func (headerAuthenticator) Authenticate(r *http.Request) (*auth.User, error) {
+	name := r.Header.Get("X-User")
+	if name == "" {
+		return nil, nil
+	}
+	return &auth.User{Name: name, Method: "header"}, nil
+}
+
+func TestHandleSecuredMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(prometheus.NewCounter(prometheus.CounterOpts{Name: "test_total"}))
+	request := func(handler http.Handler, user string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		if user != "" {
+			req.Header.Set("X-User", user)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	disabled := handleSecuredMetrics(reg, func(next http.Handler) http.Handler { return next })
+	if w := request(disabled, ""); w.Code != http.StatusOK {
+		t.Errorf("expected status %d without authentication but got %d", http.StatusOK, w.Code)
+	}
+
+	authenticate := auth.Middleware(slog.Default(), []auth.Authenticator{headerAuthenticator{}}, auth.NewRoles(auth.RoleTopology, []string{"alice"}))
+	handler := handleSecuredMetrics(reg, authenticate)
+	for _, tc := range []struct {
+		user     string
+		expected int
+	}{
+		{user: "", expected: http.StatusUnauthorized},
+		{user: "bob", expected: http.StatusForbidden},
+		{user: "alice", expected: http.StatusOK},
+	} {
+		w := request(handler, tc.user)
+		if w.Code != tc.expected {
+			t.Errorf("expected status %d for user %q but got %d", tc.expected, tc.user, w.Code)
+		}
+		if tc.expected == http.StatusOK && !strings.Contains(w.Body.String(), "test_total") {
+			t.Errorf("expected metrics for user %q but got %q", tc.user, w.Body.String())
+		}
+		if tc.expected != http.StatusOK && strings.Contains(w.Body.String(), "test_total") {
+			t.Errorf("expected no metrics for user %q", tc.user)
+		}
+	}
+}
@@ -134,6 +134,11 @@ func (r *ProcessResponse) SetResults(v ProcessRecord) {
 	r.Results = v
 }
 
+// SetResults
+func (r *RequestAggregateResponse) SetResults(v RequestAggregateRecord) {
+	r.Results = v
+}
+
 // SetCount
 func (r *RouterAccessListResponse) SetCount(v int64) {
 	r.Count = v
@@ -316,6 +321,16 @@ func (r ProcessRecord) GetStartTime() uint64 {
 	return r.StartTime
 }
 
+// GetEndTime
+func (r RequestAggregateRecord) GetEndTime() uint64 {
+	return r.EndTime
+}
+
+// GetStartTime
+func (r RequestAggregateRecord) GetStartTime() uint64 {
+	return r.StartTime
+}
+
 // GetEndTime
 func (r RouterAccessRecord) GetEndTime() uint64 {
 	return r.EndTime
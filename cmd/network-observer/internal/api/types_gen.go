@@ -168,6 +168,8 @@ type ConnectionRecord struct {
 	OctetCount        uint64  `json:"octetCount"`
 	OctetReverseCount uint64  `json:"octetReverseCount"`
 	ProcessPairId     *string `json:"processPairId"`
+
+	// Protocol transport protocol of the connection, tcp or udp
 	Protocol          string  `json:"protocol"`
 	ProxyHost         string  `json:"proxyHost"`
 	ProxyPort         string  `json:"proxyPort"`
@@ -364,6 +366,54 @@ type ProcessResponse struct {
 	Results ProcessRecord `json:"results"`
 }
 
+// RequestAggregateRecord defines model for RequestAggregateRecord.
+type RequestAggregateRecord struct {
+	// EndTime The end time in microseconds of the record in Unix timestamp format.
+	EndTime uint64 `json:"endTime"`
+
+	// Identity The unique identifier for the record.
+	Identity string `json:"identity"`
+
+	// LatencyP50 median time to first byte of the response in microseconds, over the most recent requests
+	LatencyP50 uint64 `json:"latencyP50"`
+
+	// LatencyP90 90th percentile of the time to first byte of the response in microseconds, over the most recent requests
+	LatencyP90 uint64 `json:"latencyP90"`
+
+	// LatencyP99 99th percentile of the time to first byte of the response in microseconds, over the most recent requests
+	LatencyP99 uint64 `json:"latencyP99"`
+
+	// Methods number of requests completed by request method
+	Methods  []RequestCount `json:"methods"`
+	Protocol string         `json:"protocol"`
+
+	// RequestCount number of requests completed
+	RequestCount uint64 `json:"requestCount"`
+
+	// RequestRate requests completed per second over the last minute
+	RequestRate float64 `json:"requestRate"`
+
+	// ResponseClasses number of requests completed by response status class (1xx to 5xx, or unknown)
+	ResponseClasses []RequestCount `json:"responseClasses"`
+
+	// ResponseCodes number of requests completed by response status code (or unknown)
+	ResponseCodes []RequestCount `json:"responseCodes"`
+
+	// StartTime The creation time in microseconds of the record in Unix timestamp format. The value 0 means that the record is not terminated
+	StartTime uint64 `json:"startTime"`
+}
+
+// RequestAggregateResponse defines model for RequestAggregateResponse.
+type RequestAggregateResponse struct {
+	Results RequestAggregateRecord `json:"results"`
+}
+
+// RequestCount defines model for RequestCount.
+type RequestCount struct {
+	Count uint64 `json:"count"`
+	Value string `json:"value"`
+}
+
 // RouterAccessListResponse defines model for RouterAccessListResponse.
 type RouterAccessListResponse struct {
 	// Count number of results in response
@@ -651,6 +701,9 @@ type GetProcessByID = ProcessResponse
 // GetProcesses defines model for getProcesses.
 type GetProcesses = ProcessListResponse
 
+// GetRequestAggregate defines model for getRequestAggregate.
+type GetRequestAggregate = RequestAggregateResponse
+
 // GetRouterAccess defines model for getRouterAccess.
 type GetRouterAccess = RouterAccessListResponse
 
@@ -805,6 +858,9 @@ type ClientInterface interface {
 	// ProcesspairByID request
 	ProcesspairByID(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// RequestsByProcessPair request
+	RequestsByProcessPair(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// Routeraccess request
 	Routeraccess(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -838,6 +894,9 @@ type ClientInterface interface {
 	// ProcessPairsByService request
 	ProcessPairsByService(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// RequestsByService request
+	RequestsByService(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// Sitepairs request
 	Sitepairs(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -1052,6 +1111,18 @@ func (c *Client) ProcesspairByID(ctx context.Context, id PathID, reqEditors ...R
 	return c.Client.Do(req)
 }
 
+func (c *Client) RequestsByProcessPair(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRequestsByProcessPairRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) Routeraccess(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewRouteraccessRequest(c.Server)
 	if err != nil {
@@ -1184,6 +1255,18 @@ func (c *Client) ProcessPairsByService(ctx context.Context, id PathID, reqEditor
 	return c.Client.Do(req)
 }
 
+func (c *Client) RequestsByService(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRequestsByServiceRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) Sitepairs(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewSitepairsRequest(c.Server)
 	if err != nil {
@@ -1749,6 +1832,40 @@ func NewProcesspairByIDRequest(server string, id PathID) (*http.Request, error)
 	return req, nil
 }
 
+// NewRequestsByProcessPairRequest generates requests for RequestsByProcessPair
+func NewRequestsByProcessPairRequest(server string, id PathID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v2alpha1/processpairs/%s/requests", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewRouteraccessRequest generates requests for Routeraccess
 func NewRouteraccessRequest(server string) (*http.Request, error) {
 	var err error
@@ -2095,6 +2212,40 @@ func NewProcessPairsByServiceRequest(server string, id PathID) (*http.Request, e
 	return req, nil
 }
 
+// NewRequestsByServiceRequest generates requests for RequestsByService
+func NewRequestsByServiceRequest(server string, id PathID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v2alpha1/services/%s/requests", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewSitepairsRequest generates requests for Sitepairs
 func NewSitepairsRequest(server string) (*http.Request, error) {
 	var err error
@@ -2410,6 +2561,9 @@ type ClientWithResponsesInterface interface {
 	// ProcesspairByIDWithResponse request
 	ProcesspairByIDWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*ProcesspairByIDResponse, error)
 
+	// RequestsByProcessPairWithResponse request
+	RequestsByProcessPairWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*RequestsByProcessPairResponse, error)
+
 	// RouteraccessWithResponse request
 	RouteraccessWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*RouteraccessResponse, error)
 
@@ -2443,6 +2597,9 @@ type ClientWithResponsesInterface interface {
 	// ProcessPairsByServiceWithResponse request
 	ProcessPairsByServiceWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*ProcessPairsByServiceResponse, error)
 
+	// RequestsByServiceWithResponse request
+	RequestsByServiceWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*RequestsByServiceResponse, error)
+
 	// SitepairsWithResponse request
 	SitepairsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*SitepairsResponse, error)
 
@@ -2830,6 +2987,29 @@ func (r ProcesspairByIDResponse) StatusCode() int {
 	return 0
 }
 
+type RequestsByProcessPairResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GetRequestAggregate
+	JSON404      *ErrorNotFound
+}
+
+// Status returns HTTPResponse.Status
+func (r RequestsByProcessPairResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RequestsByProcessPairResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type RouteraccessResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -3083,6 +3263,29 @@ func (r ProcessPairsByServiceResponse) StatusCode() int {
 	return 0
 }
 
+type RequestsByServiceResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GetRequestAggregate
+	JSON404      *ErrorNotFound
+}
+
+// Status returns HTTPResponse.Status
+func (r RequestsByServiceResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RequestsByServiceResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type SitepairsResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -3387,6 +3590,15 @@ func (c *ClientWithResponses) ProcesspairByIDWithResponse(ctx context.Context, i
 	return ParseProcesspairByIDResponse(rsp)
 }
 
+// RequestsByProcessPairWithResponse request returning *RequestsByProcessPairResponse
+func (c *ClientWithResponses) RequestsByProcessPairWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*RequestsByProcessPairResponse, error) {
+	rsp, err := c.RequestsByProcessPair(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRequestsByProcessPairResponse(rsp)
+}
+
 // RouteraccessWithResponse request returning *RouteraccessResponse
 func (c *ClientWithResponses) RouteraccessWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*RouteraccessResponse, error) {
 	rsp, err := c.Routeraccess(ctx, reqEditors...)
@@ -3486,6 +3698,15 @@ func (c *ClientWithResponses) ProcessPairsByServiceWithResponse(ctx context.Cont
 	return ParseProcessPairsByServiceResponse(rsp)
 }
 
+// RequestsByServiceWithResponse request returning *RequestsByServiceResponse
+func (c *ClientWithResponses) RequestsByServiceWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*RequestsByServiceResponse, error) {
+	rsp, err := c.RequestsByService(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRequestsByServiceResponse(rsp)
+}
+
 // SitepairsWithResponse request returning *SitepairsResponse
 func (c *ClientWithResponses) SitepairsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*SitepairsResponse, error) {
 	rsp, err := c.Sitepairs(ctx, reqEditors...)
@@ -4056,6 +4277,39 @@ func ParseProcesspairByIDResponse(rsp *http.Response) (*ProcesspairByIDResponse,
 	return response, nil
 }
 
+// ParseRequestsByProcessPairResponse parses an HTTP response from a RequestsByProcessPairWithResponse call
+func ParseRequestsByProcessPairResponse(rsp *http.Response) (*RequestsByProcessPairResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RequestsByProcessPairResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GetRequestAggregate
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorNotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseRouteraccessResponse parses an HTTP response from a RouteraccessWithResponse call
 func ParseRouteraccessResponse(rsp *http.Response) (*RouteraccessResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -4419,6 +4673,39 @@ func ParseProcessPairsByServiceResponse(rsp *http.Response) (*ProcessPairsByServ
 	return response, nil
 }
 
+// ParseRequestsByServiceResponse parses an HTTP response from a RequestsByServiceWithResponse call
+func ParseRequestsByServiceResponse(rsp *http.Response) (*RequestsByServiceResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RequestsByServiceResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GetRequestAggregate
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorNotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseSitepairsResponse parses an HTTP response from a SitepairsWithResponse call
 func ParseSitepairsResponse(rsp *http.Response) (*SitepairsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -4694,6 +4981,9 @@ type ServerInterface interface {
 	// (GET /api/v2alpha1/processpairs/{id})
 	ProcesspairByID(w http.ResponseWriter, r *http.Request, id PathID)
 
+	// (GET /api/v2alpha1/processpairs/{id}/requests)
+	RequestsByProcessPair(w http.ResponseWriter, r *http.Request, id PathID)
+
 	// (GET /api/v2alpha1/routeraccess)
 	Routeraccess(w http.ResponseWriter, r *http.Request)
 
@@ -4727,6 +5017,9 @@ type ServerInterface interface {
 	// (GET /api/v2alpha1/services/{id}/processpairs)
 	ProcessPairsByService(w http.ResponseWriter, r *http.Request, id PathID)
 
+	// (GET /api/v2alpha1/services/{id}/requests)
+	RequestsByService(w http.ResponseWriter, r *http.Request, id PathID)
+
 	// (GET /api/v2alpha1/sitepairs)
 	Sitepairs(w http.ResponseWriter, r *http.Request)
 
@@ -5075,6 +5368,32 @@ func (siw *ServerInterfaceWrapper) ProcesspairByID(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// RequestsByProcessPair operation middleware
+func (siw *ServerInterfaceWrapper) RequestsByProcessPair(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id PathID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", mux.Vars(r)["id"], &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RequestsByProcessPair(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // Routeraccess operation middleware
 func (siw *ServerInterfaceWrapper) Routeraccess(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -5317,6 +5636,32 @@ func (siw *ServerInterfaceWrapper) ProcessPairsByService(w http.ResponseWriter,
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// RequestsByService operation middleware
+func (siw *ServerInterfaceWrapper) RequestsByService(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id PathID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", mux.Vars(r)["id"], &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RequestsByService(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // Sitepairs operation middleware
 func (siw *ServerInterfaceWrapper) Sitepairs(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -5622,6 +5967,8 @@ func HandlerWithOptions(si ServerInterface, options GorillaServerOptions) http.H
 
 	r.HandleFunc(options.BaseURL+"/api/v2alpha1/processpairs/{id}", wrapper.ProcesspairByID).Methods("GET")
 
+	r.HandleFunc(options.BaseURL+"/api/v2alpha1/processpairs/{id}/requests", wrapper.RequestsByProcessPair).Methods("GET")
+
 	r.HandleFunc(options.BaseURL+"/api/v2alpha1/routeraccess", wrapper.Routeraccess).Methods("GET")
 
 	r.HandleFunc(options.BaseURL+"/api/v2alpha1/routeraccess/{id}", wrapper.RouteraccessByID).Methods("GET")
@@ -5644,6 +5991,8 @@ func HandlerWithOptions(si ServerInterface, options GorillaServerOptions) http.H
 
 	r.HandleFunc(options.BaseURL+"/api/v2alpha1/services/{id}/processpairs", wrapper.ProcessPairsByService).Methods("GET")
 
+	r.HandleFunc(options.BaseURL+"/api/v2alpha1/services/{id}/requests", wrapper.RequestsByService).Methods("GET")
+
 	r.HandleFunc(options.BaseURL+"/api/v2alpha1/sitepairs", wrapper.Sitepairs).Methods("GET")
 
 	r.HandleFunc(options.BaseURL+"/api/v2alpha1/sitepairs/{id}", wrapper.SitepairByID).Methods("GET")
@@ -0,0 +1,244 @@
+// Package auth authenticates the users of the network observer API and
+// assigns them a role scoping the records they can read.
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Role scopes the records of the API a user can read.
+type Role string
+
+const (
+	// RoleTopology grants read access to the sites, routers, links,
+	// processes and services of the network, but not to individual
+	// connections and requests.
+	RoleTopology Role = "topology"
+	// RoleFlows grants read access to everything, including connection
+	// and request details.
+	RoleFlows Role = "flows"
+)
+
+func ParseRole(value string) (Role, error) {
+	switch Role(value) {
+	case RoleTopology, RoleFlows:
+		return Role(value), nil
+	default:
+		return "", fmt.Errorf("unknown role %q: expected %s or %s", value, RoleTopology, RoleFlows)
+	}
+}
+
+// Allows reports whether a user with the role can read records
+// requiring the given role.
+func (r Role) Allows(required Role) bool {
+	return r == RoleFlows || required == RoleTopology
+}
+
+// User is an authenticated user of the API.
+type User struct {
+	Name   string
+	Groups []string
+	// Method is the way the user authenticated: basic, token,
+	// tokenreview or oidc.
+	Method string
+	Role   Role
+}
+
+// Authenticator identifies the user making a request.
+type Authenticator interface {
+	// Authenticate returns the user making the request. It returns a nil
+	// user and a nil error when the request does not carry credentials
+	// the authenticator handles, and an error when it does but they are
+	// not valid.
+	Authenticate(r *http.Request) (*User, error)
+}
+
+// Challenger is implemented by authenticators asking clients for
+// credentials through the WWW-Authenticate header.
+type Challenger interface {
+	Challenge() string
+}
+
+type contextKey struct{}
+
+func WithUser(ctx context.Context, user *User) context.Context {
+	return context.WithValue(ctx, contextKey{}, user)
+}
+
+// UserFrom returns the authenticated user of a request context. It
+// returns false when authentication is not enabled.
+func UserFrom(ctx context.Context) (*User, bool) {
+	user, ok := ctx.Value(contextKey{}).(*User)
+	return user, ok && user != nil
+}
+
+// Roles assigns a role to authenticated users: the flows role to the
+// listed users and groups, the default role to the others.
+type Roles struct {
+	Default Role
+	users   map[string]bool
+	groups  map[string]bool
+}
+
+// NewRoles returns the role assignment granting the flows role to the
+// given subjects, either user names or group names prefixed by
+// "group:".
+func NewRoles(defaultRole Role, flowsSubjects []string) Roles {
+	roles := Roles{
+		Default: defaultRole,
+		users:   map[string]bool{},
+		groups:  map[string]bool{},
+	}
+	for _, subject := range flowsSubjects {
+		subject = strings.TrimSpace(subject)
+		if group, ok := strings.CutPrefix(subject, "group:"); ok {
+			roles.groups[group] = true
+		} else if subject != "" {
+			roles.users[subject] = true
+		}
+	}
+	return roles
+}
+
+func (r Roles) For(user *User) Role {
+	if r.users[user.Name] {
+		return RoleFlows
+	}
+	for _, group := range user.Groups {
+		if r.groups[group] {
+			return RoleFlows
+		}
+	}
+	if r.Default == "" {
+		return RoleFlows
+	}
+	return r.Default
+}
+
+// Middleware rejects requests not authenticated by any of the
+// authenticators, tried in order, and adds the user with its role to
+// the context of the others.
+func Middleware(logger *slog.Logger, authenticators []Authenticator, roles Roles) func(http.Handler) http.Handler {
+	var challenges []string
+	for _, authenticator := range authenticators {
+		if challenger, ok := authenticator.(Challenger); ok {
+			challenges = append(challenges, challenger.Challenge())
+		}
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for _, authenticator := range authenticators {
+				user, err := authenticator.Authenticate(r)
+				if err != nil {
+					logger.Debug("Authentication failed",
+						slog.String("endpoint", r.URL.Path),
+						slog.Any("error", err))
+					unauthorized(w, challenges, "invalid credentials")
+					return
+				}
+				if user != nil {
+					user.Role = roles.For(user)
+					next.ServeHTTP(w, r.WithContext(WithUser(r.Context(), user)))
+					return
+				}
+			}
+			unauthorized(w, challenges, "authentication required")
+		})
+	}
+}
+
+// RequireRole rejects requests from users without the required role.
+// Requests without a user, when authentication is not enabled, are
+// allowed.
+func RequireRole(required Role) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if user, ok := UserFrom(r.Context()); ok && !user.Role.Allows(required) {
+				writeError(w, http.StatusForbidden, "ErrForbidden", "the "+string(required)+" role is required")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func unauthorized(w http.ResponseWriter, challenges []string, message string) {
+	for _, challenge := range challenges {
+		w.Header().Add("WWW-Authenticate", challenge)
+	}
+	writeError(w, http.StatusUnauthorized, "ErrUnauthorized", message)
+}
+
+func writeError(w http.ResponseWriter, status int, code string, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}{
+		Code:    code,
+		Message: message,
+	})
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// watchedFile holds the parsed content of a file, parsing it again
+// whenever it is modified, e.g. when the secret it is mounted from is
+// updated.
+type watchedFile[T any] struct {
+	path    string
+	parse   func([]byte) (T, error)
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+	content T
+}
+
+func newWatchedFile[T any](path string, parse func([]byte) (T, error)) (*watchedFile[T], error) {
+	f := &watchedFile[T]{
+		path:  path,
+		parse: parse,
+	}
+	if _, err := f.get(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *watchedFile[T]) get() (T, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return f.content, err
+	}
+	if info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return f.content, nil
+	}
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return f.content, err
+	}
+	content, err := f.parse(data)
+	if err != nil {
+		return f.content, fmt.Errorf("error parsing %s: %w", f.path, err)
+	}
+	f.content, f.modTime, f.size = content, info.ModTime(), info.Size()
+	return content, nil
+}
@@ -0,0 +1,173 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+type fakeAuthenticator struct {
+	user      *User
+	err       error
+	challenge string
+}
+
+func (f fakeAuthenticator) Authenticate(r *http.Request) (*User, error) {
+	if f.user == nil {
+		return nil, f.err
+	}
+	user := *f.user
+	return &user, f.err
+}
+
+func (f fakeAuthenticator) Challenge() string {
+	return f.challenge
+}
+
+func TestParseRole(t *testing.T) {
+	role, err := ParseRole("topology")
+	assert.Assert(t, err)
+	assert.Equal(t, role, RoleTopology)
+	role, err = ParseRole("flows")
+	assert.Assert(t, err)
+	assert.Equal(t, role, RoleFlows)
+	_, err = ParseRole("admin")
+	assert.ErrorContains(t, err, "unknown role")
+
+	assert.Assert(t, RoleFlows.Allows(RoleFlows))
+	assert.Assert(t, RoleFlows.Allows(RoleTopology))
+	assert.Assert(t, RoleTopology.Allows(RoleTopology))
+	assert.Assert(t, !RoleTopology.Allows(RoleFlows))
+}
+
+func TestRoles(t *testing.T) {
+	roles := NewRoles(RoleTopology, []string{"alice", " group:ops ", ""})
+	assert.Equal(t, roles.For(&User{Name: "alice"}), RoleFlows)
+	assert.Equal(t, roles.For(&User{Name: "bob", Groups: []string{"dev", "ops"}}), RoleFlows)
+	assert.Equal(t, roles.For(&User{Name: "bob", Groups: []string{"dev"}}), RoleTopology)
+	assert.Equal(t, roles.For(&User{Name: "ops"}), RoleTopology)
+	assert.Equal(t, NewRoles("", nil).For(&User{Name: "bob"}), RoleFlows)
+}
+
+func TestMiddleware(t *testing.T) {
+	testcases := []struct {
+		Name           string
+		Authenticators []Authenticator
+		ExpectStatus   int
+		ExpectUser     string
+		ExpectRole     Role
+	}{
+		{
+			Name: "no credentials",
+			Authenticators: []Authenticator{
+				fakeAuthenticator{challenge: "Basic"},
+				fakeAuthenticator{challenge: "Bearer"},
+			},
+			ExpectStatus: http.StatusUnauthorized,
+		}, {
+			Name: "invalid credentials",
+			Authenticators: []Authenticator{
+				fakeAuthenticator{challenge: "Basic", err: errors.New("bad password")},
+				fakeAuthenticator{challenge: "Bearer", user: &User{Name: "bob"}},
+			},
+			ExpectStatus: http.StatusUnauthorized,
+		}, {
+			Name: "second authenticator",
+			Authenticators: []Authenticator{
+				fakeAuthenticator{challenge: "Basic"},
+				fakeAuthenticator{challenge: "Bearer", user: &User{Name: "bob"}},
+			},
+			ExpectStatus: http.StatusOK,
+			ExpectUser:   "bob",
+			ExpectRole:   RoleTopology,
+		}, {
+			Name: "flows subject",
+			Authenticators: []Authenticator{
+				fakeAuthenticator{user: &User{Name: "alice"}},
+			},
+			ExpectStatus: http.StatusOK,
+			ExpectUser:   "alice",
+			ExpectRole:   RoleFlows,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			handler := Middleware(slog.Default(), tc.Authenticators, NewRoles(RoleTopology, []string{"alice"}))(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					user, ok := UserFrom(r.Context())
+					assert.Assert(t, ok)
+					assert.Equal(t, user.Name, tc.ExpectUser)
+					assert.Equal(t, user.Role, tc.ExpectRole)
+				}))
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v2alpha1/sites", nil))
+			assert.Equal(t, w.Code, tc.ExpectStatus)
+			if tc.ExpectStatus == http.StatusUnauthorized {
+				assert.DeepEqual(t, w.Header().Values("WWW-Authenticate"), []string{"Basic", "Bearer"})
+				var body map[string]string
+				assert.Assert(t, json.NewDecoder(w.Body).Decode(&body))
+				assert.Equal(t, body["code"], "ErrUnauthorized")
+			}
+		})
+	}
+}
+
+func TestRequireRole(t *testing.T) {
+	handler := RequireRole(RoleFlows)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	request := func(user *User) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/api/v2alpha1/internal/prom/query", nil)
+		if user != nil {
+			r = r.WithContext(WithUser(r.Context(), user))
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+	assert.Equal(t, request(nil).Code, http.StatusOK)
+	assert.Equal(t, request(&User{Name: "alice", Role: RoleFlows}).Code, http.StatusOK)
+	w := request(&User{Name: "bob", Role: RoleTopology})
+	assert.Equal(t, w.Code, http.StatusForbidden)
+	var body map[string]string
+	assert.Assert(t, json.NewDecoder(w.Body).Decode(&body))
+	assert.Equal(t, body["code"], "ErrForbidden")
+}
+
+func TestWatchedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file")
+	assert.Assert(t, os.WriteFile(path, []byte("one"), 0600))
+	file, err := newWatchedFile(path, func(data []byte) (string, error) {
+		if len(data) == 0 {
+			return "", errors.New("empty")
+		}
+		return string(data), nil
+	})
+	assert.Assert(t, err)
+	content, err := file.get()
+	assert.Assert(t, err)
+	assert.Equal(t, content, "one")
+
+	assert.Assert(t, os.WriteFile(path, []byte("three"), 0600))
+	content, err = file.get()
+	assert.Assert(t, err)
+	assert.Equal(t, content, "three")
+
+	// the last valid content is kept when the file cannot be parsed
+	assert.Assert(t, os.WriteFile(path, nil, 0600))
+	assert.Assert(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Minute)))
+	content, err = file.get()
+	assert.ErrorContains(t, err, "empty")
+	assert.Equal(t, content, "three")
+
+	_, err = newWatchedFile(filepath.Join(t.TempDir(), "missing"), func(data []byte) (string, error) {
+		return string(data), nil
+	})
+	assert.Assert(t, os.IsNotExist(err))
+}
@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Htpasswd authenticates users with basic auth against an htpasswd
+// file. Only bcrypt (htpasswd -B) and SHA1 (htpasswd -s) hashes are
+// supported, along with the {PLAIN} passwords of the secret generated by
+// the network-observer chart.
+type Htpasswd struct {
+	file *watchedFile[map[string]string]
+}
+
+func NewHtpasswd(path string) (*Htpasswd, error) {
+	file, err := newWatchedFile(path, parseHtpasswd)
+	if err != nil {
+		return nil, err
+	}
+	return &Htpasswd{file: file}, nil
+}
+
+func (h *Htpasswd) Authenticate(r *http.Request) (*User, error) {
+	name, password, ok := r.BasicAuth()
+	if !ok {
+		return nil, nil
+	}
+	users, err := h.file.get()
+	if err != nil {
+		return nil, err
+	}
+	hash, ok := users[name]
+	if !ok || !checkPassword(hash, password) {
+		return nil, fmt.Errorf("invalid password for user %q", name)
+	}
+	return &User{
+		Name:   name,
+		Method: "basic",
+	}, nil
+}
+
+func (h *Htpasswd) Challenge() string {
+	return `Basic realm="Skupper Network Observer"`
+}
+
+func parseHtpasswd(data []byte) (map[string]string, error) {
+	users := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		entry := strings.TrimSpace(scanner.Text())
+		if entry == "" || strings.HasPrefix(entry, "#") {
+			continue
+		}
+		name, hash, ok := strings.Cut(entry, ":")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("line %d: expected user:hash", line)
+		}
+		if !isBcrypt(hash) && !strings.HasPrefix(hash, "{SHA}") && !strings.HasPrefix(hash, "{PLAIN}") {
+			return nil, fmt.Errorf("line %d: unsupported hash for user %q, use bcrypt (htpasswd -B)", line, name)
+		}
+		users[name] = hash
+	}
+	return users, scanner.Err()
+}
+
+func isBcrypt(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") || strings.HasPrefix(hash, "$2b$") || strings.HasPrefix(hash, "$2y$")
+}
+
+func checkPassword(hash string, password string) bool {
+	if isBcrypt(hash) {
+		return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+	}
+	if plain, ok := strings.CutPrefix(hash, "{PLAIN}"); ok {
+		return subtle.ConstantTimeCompare([]byte(plain), []byte(password)) == 1
+	}
+	sum := sha1.Sum([]byte(password))
+	expected := "{SHA}" + base64.StdEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(hash), []byte(expected)) == 1
+}
@@ -0,0 +1,98 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+	"gotest.tools/v3/assert"
+)
+
+func TestHtpasswd(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	assert.Assert(t, err)
+	path := filepath.Join(t.TempDir(), "htpasswd")
+	content := "# users\n" +
+		"alice:" + string(hash) + "\n" +
+		"\n" +
+		"bob:{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g=\n" +
+		"skupper:{PLAIN}generated\n"
+	assert.Assert(t, os.WriteFile(path, []byte(content), 0600))
+	htpasswd, err := NewHtpasswd(path)
+	assert.Assert(t, err)
+	assert.Equal(t, htpasswd.Challenge(), `Basic realm="Skupper Network Observer"`)
+
+	testcases := []struct {
+		Name       string
+		Username   string
+		Password   string
+		NoAuth     bool
+		ExpectUser string
+		ExpectErr  string
+	}{
+		{
+			Name:   "no credentials",
+			NoAuth: true,
+		}, {
+			Name:       "bcrypt",
+			Username:   "alice",
+			Password:   "secret",
+			ExpectUser: "alice",
+		}, {
+			Name:       "sha",
+			Username:   "bob",
+			Password:   "password",
+			ExpectUser: "bob",
+		}, {
+			Name:       "plain",
+			Username:   "skupper",
+			Password:   "generated",
+			ExpectUser: "skupper",
+		}, {
+			Name:      "wrong plain password",
+			Username:  "skupper",
+			Password:  "{PLAIN}generated",
+			ExpectErr: `invalid password for user "skupper"`,
+		}, {
+			Name:      "wrong password",
+			Username:  "alice",
+			Password:  "password",
+			ExpectErr: `invalid password for user "alice"`,
+		}, {
+			Name:      "unknown user",
+			Username:  "carol",
+			Password:  "secret",
+			ExpectErr: `invalid password for user "carol"`,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if !tc.NoAuth {
+				req.SetBasicAuth(tc.Username, tc.Password)
+			}
+			user, err := htpasswd.Authenticate(req)
+			if tc.ExpectErr != "" {
+				assert.ErrorContains(t, err, tc.ExpectErr)
+				return
+			}
+			assert.Assert(t, err)
+			if tc.ExpectUser == "" {
+				assert.Assert(t, user == nil)
+				return
+			}
+			assert.Equal(t, user.Name, tc.ExpectUser)
+			assert.Equal(t, user.Method, "basic")
+		})
+	}
+}
+
+func TestParseHtpasswd(t *testing.T) {
+	_, err := parseHtpasswd([]byte("alice\n"))
+	assert.ErrorContains(t, err, "line 1: expected user:hash")
+	_, err = parseHtpasswd([]byte("alice:{SHA}x\nbob:$apr1$abc$def\n"))
+	assert.ErrorContains(t, err, `line 2: unsupported hash for user "bob"`)
+}
@@ -0,0 +1,384 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// keys are fetched again at most this often when a token is signed
+	// with an unknown key
+	jwksRefreshInterval = 30 * time.Second
+	// tolerated clock difference with the issuer
+	clockSkew = time.Minute
+)
+
+type OIDCConfig struct {
+	// Issuer is the URL of the OpenID Connect provider, which must serve
+	// its discovery document under /.well-known/openid-configuration.
+	Issuer string
+	// ClientID is the audience tokens must be issued for.
+	ClientID string
+	// UsernameClaim is the claim holding the user name. The sub claim is
+	// used when not set or when tokens do not carry it.
+	UsernameClaim string
+	// GroupsClaim is the claim holding the groups of the user, if any.
+	GroupsClaim string
+	// Client is the HTTP client used to reach the provider.
+	Client *http.Client
+}
+
+// OIDC authenticates JSON Web Tokens issued by an OpenID Connect
+// provider, passed as bearer tokens. Tokens are verified with the keys
+// the provider publishes.
+type OIDC struct {
+	config OIDCConfig
+	now    func() time.Time
+
+	mu      sync.Mutex
+	jwksURI string
+	keys    map[string]crypto.PublicKey
+	fetched time.Time
+}
+
+func NewOIDC(config OIDCConfig) (*OIDC, error) {
+	if config.Issuer == "" {
+		return nil, fmt.Errorf("an issuer is required")
+	}
+	if config.ClientID == "" {
+		return nil, fmt.Errorf("a client id is required")
+	}
+	if config.UsernameClaim == "" {
+		config.UsernameClaim = "sub"
+	}
+	if config.Client == nil {
+		config.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &OIDC{
+		config: config,
+		now:    time.Now,
+	}, nil
+}
+
+// Authenticate only handles tokens from the configured issuer, leaving
+// the others to the authenticators tried next.
+func (o *OIDC) Authenticate(r *http.Request) (*User, error) {
+	token, ok := bearerToken(r)
+	if !ok {
+		return nil, nil
+	}
+	jwt, err := parseJWT(token)
+	if err != nil || jwt.claims["iss"] != o.config.Issuer {
+		return nil, nil
+	}
+	if err := o.verifySignature(jwt); err != nil {
+		return nil, err
+	}
+	if err := o.verifyClaims(jwt.claims); err != nil {
+		return nil, err
+	}
+	name, _ := jwt.claims[o.config.UsernameClaim].(string)
+	if name == "" {
+		name, _ = jwt.claims["sub"].(string)
+	}
+	if name == "" {
+		return nil, fmt.Errorf("token has no %s claim", o.config.UsernameClaim)
+	}
+	user := &User{
+		Name:   name,
+		Method: "oidc",
+	}
+	if o.config.GroupsClaim != "" {
+		switch groups := jwt.claims[o.config.GroupsClaim].(type) {
+		case string:
+			user.Groups = []string{groups}
+		case []interface{}:
+			for _, group := range groups {
+				if value, ok := group.(string); ok {
+					user.Groups = append(user.Groups, value)
+				}
+			}
+		}
+	}
+	return user, nil
+}
+
+func (o *OIDC) Challenge() string {
+	return "Bearer"
+}
+
+func (o *OIDC) verifyClaims(claims map[string]interface{}) error {
+	now := o.now()
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return fmt.Errorf("token has no expiry")
+	}
+	if now.After(time.Unix(int64(exp), 0).Add(clockSkew)) {
+		return fmt.Errorf("token expired")
+	}
+	if nbf, ok := claims["nbf"].(float64); ok && now.Add(clockSkew).Before(time.Unix(int64(nbf), 0)) {
+		return fmt.Errorf("token not valid yet")
+	}
+	switch aud := claims["aud"].(type) {
+	case string:
+		if aud == o.config.ClientID {
+			return nil
+		}
+	case []interface{}:
+		for _, value := range aud {
+			if value == o.config.ClientID {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("token not issued for %s", o.config.ClientID)
+}
+
+func (o *OIDC) verifySignature(jwt *jsonWebToken) error {
+	hash, err := jwt.hash()
+	if err != nil {
+		return err
+	}
+	key, err := o.key(jwt.header.Kid)
+	if err != nil {
+		return err
+	}
+	switch jwt.header.Alg[:2] {
+	case "RS":
+		public, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("key %s is not an RSA key", jwt.header.Kid)
+		}
+		return rsa.VerifyPKCS1v15(public, hash, hashSum(hash, jwt.signed), jwt.signature)
+	case "PS":
+		public, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("key %s is not an RSA key", jwt.header.Kid)
+		}
+		return rsa.VerifyPSS(public, hash, hashSum(hash, jwt.signed), jwt.signature, nil)
+	case "ES":
+		public, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("key %s is not an EC key", jwt.header.Kid)
+		}
+		size := (public.Curve.Params().BitSize + 7) / 8
+		if len(jwt.signature) != 2*size {
+			return fmt.Errorf("invalid signature length")
+		}
+		r := new(big.Int).SetBytes(jwt.signature[:size])
+		s := new(big.Int).SetBytes(jwt.signature[size:])
+		if !ecdsa.Verify(public, hashSum(hash, jwt.signed), r, s) {
+			return fmt.Errorf("invalid signature")
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported signing algorithm %s", jwt.header.Alg)
+}
+
+// key returns the key of the provider with the given id, fetching the
+// keys again if it is not known.
+func (o *OIDC) key(kid string) (crypto.PublicKey, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if key, ok := o.lookup(kid); ok {
+		return key, nil
+	}
+	if !o.fetched.IsZero() && o.now().Sub(o.fetched) < jwksRefreshInterval {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	o.fetched = o.now()
+	if err := o.fetchKeys(); err != nil {
+		return nil, err
+	}
+	if key, ok := o.lookup(kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+func (o *OIDC) lookup(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(o.keys) == 1 {
+		for _, key := range o.keys {
+			return key, true
+		}
+	}
+	key, ok := o.keys[kid]
+	return key, ok
+}
+
+func (o *OIDC) fetchKeys() error {
+	if o.jwksURI == "" {
+		var discovery struct {
+			Issuer  string `json:"issuer"`
+			JWKSURI string `json:"jwks_uri"`
+		}
+		if err := o.getJSON(strings.TrimSuffix(o.config.Issuer, "/")+"/.well-known/openid-configuration", &discovery); err != nil {
+			return fmt.Errorf("error discovering the provider: %w", err)
+		}
+		if discovery.Issuer != o.config.Issuer {
+			return fmt.Errorf("provider reports issuer %q instead of %q", discovery.Issuer, o.config.Issuer)
+		}
+		if discovery.JWKSURI == "" {
+			return fmt.Errorf("provider reports no jwks_uri")
+		}
+		o.jwksURI = discovery.JWKSURI
+	}
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := o.getJSON(o.jwksURI, &jwks); err != nil {
+		return fmt.Errorf("error fetching the provider keys: %w", err)
+	}
+	keys := map[string]crypto.PublicKey{}
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			// skip keys of unsupported types
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	o.keys = keys
+	return nil
+}
+
+func (o *OIDC) getJSON(url string, v interface{}) error {
+	resp, err := o.config.Client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %s", url, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+type jsonWebToken struct {
+	header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	claims    map[string]interface{}
+	signed    []byte
+	signature []byte
+}
+
+func parseJWT(token string) (*jsonWebToken, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("not a JWT")
+	}
+	jwt := &jsonWebToken{
+		signed: []byte(parts[0] + "." + parts[1]),
+	}
+	header, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(header, &jwt.header); err != nil {
+		return nil, err
+	}
+	claims, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(claims, &jwt.claims); err != nil {
+		return nil, err
+	}
+	jwt.signature, err = base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, err
+	}
+	return jwt, nil
+}
+
+func (t *jsonWebToken) hash() (crypto.Hash, error) {
+	if len(t.header.Alg) != 5 {
+		return 0, fmt.Errorf("unsupported signing algorithm %q", t.header.Alg)
+	}
+	switch t.header.Alg[2:] {
+	case "256":
+		return crypto.SHA256, nil
+	case "384":
+		return crypto.SHA384, nil
+	case "512":
+		return crypto.SHA512, nil
+	}
+	return 0, fmt.Errorf("unsupported signing algorithm %q", t.header.Alg)
+}
+
+func hashSum(hash crypto.Hash, data []byte) []byte {
+	h := hash.New()
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+}
@@ -0,0 +1,215 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+type testIssuer struct {
+	*httptest.Server
+	rsaKey    *rsa.PrivateKey
+	ecKey     *ecdsa.PrivateKey
+	keyHits   int
+	publishEC bool
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	t.Helper()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Assert(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Assert(t, err)
+	issuer := &testIssuer{rsaKey: rsaKey, ecKey: ecKey}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   issuer.URL,
+			"jwks_uri": issuer.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		issuer.keyHits++
+		encode := base64.RawURLEncoding.EncodeToString
+		keys := []map[string]string{{
+			"kty": "RSA",
+			"kid": "rsa",
+			"use": "sig",
+			"n":   encode(rsaKey.N.Bytes()),
+			"e":   encode(big.NewInt(int64(rsaKey.E)).Bytes()),
+		}, {
+			"kty": "oct",
+			"kid": "symmetric",
+		}}
+		if issuer.publishEC {
+			keys = append(keys, map[string]string{
+				"kty": "EC",
+				"kid": "ec",
+				"crv": "P-256",
+				"x":   encode(ecKey.X.FillBytes(make([]byte, 32))),
+				"y":   encode(ecKey.Y.FillBytes(make([]byte, 32))),
+			})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": keys})
+	})
+	issuer.Server = httptest.NewServer(mux)
+	t.Cleanup(issuer.Close)
+	return issuer
+}
+
+func (i *testIssuer) sign(t *testing.T, alg string, kid string, claims map[string]interface{}) string {
+	t.Helper()
+	header, err := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	assert.Assert(t, err)
+	payload, err := json.Marshal(claims)
+	assert.Assert(t, err)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	var signature []byte
+	switch alg {
+	case "RS256":
+		signature, err = rsa.SignPKCS1v15(rand.Reader, i.rsaKey, crypto.SHA256, hashSum(crypto.SHA256, []byte(signed)))
+		assert.Assert(t, err)
+	case "ES256":
+		r, s, err := ecdsa.Sign(rand.Reader, i.ecKey, hashSum(crypto.SHA256, []byte(signed)))
+		assert.Assert(t, err)
+		signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// tamper replaces the claims of a token by those of another one.
+func tamper(token string, claimsFrom string) string {
+	parts, other := strings.Split(token, "."), strings.Split(claimsFrom, ".")
+	return parts[0] + "." + other[1] + "." + parts[2]
+}
+
+func TestOIDC(t *testing.T) {
+	issuer := newTestIssuer(t)
+	now := time.Now()
+	oidc, err := NewOIDC(OIDCConfig{
+		Issuer:        issuer.URL,
+		ClientID:      "observer",
+		UsernameClaim: "preferred_username",
+		GroupsClaim:   "groups",
+	})
+	assert.Assert(t, err)
+	oidc.now = func() time.Time { return now }
+
+	claims := func(overrides map[string]interface{}) map[string]interface{} {
+		claims := map[string]interface{}{
+			"iss":                issuer.URL,
+			"sub":                "1234",
+			"aud":                []string{"other", "observer"},
+			"exp":                now.Add(time.Hour).Unix(),
+			"preferred_username": "alice",
+			"groups":             []string{"ops"},
+		}
+		for k, v := range overrides {
+			if v == nil {
+				delete(claims, k)
+			} else {
+				claims[k] = v
+			}
+		}
+		return claims
+	}
+	authenticate := func(token string) (*User, error) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		return oidc.Authenticate(req)
+	}
+
+	user, err := authenticate(issuer.sign(t, "RS256", "rsa", claims(nil)))
+	assert.Assert(t, err)
+	assert.DeepEqual(t, user, &User{Name: "alice", Groups: []string{"ops"}, Method: "oidc"})
+
+	user, err = authenticate(issuer.sign(t, "RS256", "rsa", claims(map[string]interface{}{
+		"preferred_username": nil,
+		"aud":                "observer",
+		"groups":             nil,
+	})))
+	assert.Assert(t, err)
+	assert.DeepEqual(t, user, &User{Name: "1234", Method: "oidc"})
+
+	// tokens of other issuers and other tokens are left to other authenticators
+	for _, token := range []string{
+		"opaque-token",
+		issuer.sign(t, "RS256", "rsa", claims(map[string]interface{}{"iss": "https://other.example.com"})),
+	} {
+		user, err = authenticate(token)
+		assert.Assert(t, err)
+		assert.Assert(t, user == nil)
+	}
+
+	for _, tc := range []struct {
+		Name      string
+		Token     string
+		ExpectErr string
+	}{
+		{
+			Name:      "expired",
+			Token:     issuer.sign(t, "RS256", "rsa", claims(map[string]interface{}{"exp": now.Add(-time.Hour).Unix()})),
+			ExpectErr: "token expired",
+		}, {
+			Name:      "no expiry",
+			Token:     issuer.sign(t, "RS256", "rsa", claims(map[string]interface{}{"exp": nil})),
+			ExpectErr: "token has no expiry",
+		}, {
+			Name:      "not valid yet",
+			Token:     issuer.sign(t, "RS256", "rsa", claims(map[string]interface{}{"nbf": now.Add(time.Hour).Unix()})),
+			ExpectErr: "token not valid yet",
+		}, {
+			Name:      "wrong audience",
+			Token:     issuer.sign(t, "RS256", "rsa", claims(map[string]interface{}{"aud": "other"})),
+			ExpectErr: "token not issued for observer",
+		}, {
+			Name:      "unsupported algorithm",
+			Token:     issuer.sign(t, "HS256", "rsa", claims(nil)),
+			ExpectErr: "unsupported signing algorithm HS256",
+		}, {
+			Name:      "tampered",
+			Token:     tamper(issuer.sign(t, "RS256", "rsa", claims(nil)), issuer.sign(t, "RS256", "rsa", claims(map[string]interface{}{"preferred_username": "mallory"}))),
+			ExpectErr: "verification error",
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := authenticate(tc.Token)
+			assert.ErrorContains(t, err, tc.ExpectErr)
+		})
+	}
+	assert.Equal(t, issuer.keyHits, 1)
+
+	// unknown keys are fetched again, at most every jwksRefreshInterval
+	token := issuer.sign(t, "ES256", "ec", claims(nil))
+	_, err = authenticate(token)
+	assert.ErrorContains(t, err, `unknown signing key "ec"`)
+	assert.Equal(t, issuer.keyHits, 1)
+	issuer.publishEC = true
+	_, err = authenticate(token)
+	assert.ErrorContains(t, err, `unknown signing key "ec"`)
+	assert.Equal(t, issuer.keyHits, 1)
+	now = now.Add(jwksRefreshInterval)
+	user, err = authenticate(issuer.sign(t, "ES256", "ec", claims(nil)))
+	assert.Assert(t, err)
+	assert.Equal(t, user.Name, "alice")
+	assert.Equal(t, issuer.keyHits, 2)
+}
+
+func TestNewOIDC(t *testing.T) {
+	_, err := NewOIDC(OIDCConfig{ClientID: "observer"})
+	assert.ErrorContains(t, err, "an issuer is required")
+	_, err = NewOIDC(OIDCConfig{Issuer: "https://issuer.example.com"})
+	assert.ErrorContains(t, err, "a client id is required")
+}
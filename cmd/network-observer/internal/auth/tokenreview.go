@@ -0,0 +1,120 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	tokenReviewCacheTTL  = time.Minute
+	tokenReviewCacheSize = 1024
+)
+
+// TokenReview authenticates bearer tokens, such as service account
+// tokens, by submitting them to the Kubernetes TokenReview API. The
+// results are cached for a minute.
+type TokenReview struct {
+	client    kubernetes.Interface
+	audiences []string
+	now       func() time.Time
+
+	mu    sync.Mutex
+	cache map[[sha256.Size]byte]tokenReviewResult
+}
+
+type tokenReviewResult struct {
+	user    *User
+	err     error
+	expires time.Time
+}
+
+func NewTokenReview(client kubernetes.Interface, audiences []string) *TokenReview {
+	return &TokenReview{
+		client:    client,
+		audiences: audiences,
+		now:       time.Now,
+		cache:     map[[sha256.Size]byte]tokenReviewResult{},
+	}
+}
+
+func (t *TokenReview) Authenticate(r *http.Request) (*User, error) {
+	token, ok := bearerToken(r)
+	if !ok {
+		return nil, nil
+	}
+	key := sha256.Sum256([]byte(token))
+	if result, ok := t.cached(key); ok {
+		return result.user, result.err
+	}
+	review, err := t.client.AuthenticationV1().TokenReviews().Create(r.Context(), &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{
+			Token:     token,
+			Audiences: t.audiences,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		// only the outcome of reviews is cached, not failures to get one
+		return nil, fmt.Errorf("token review failed: %w", err)
+	}
+	result := tokenReviewResult{expires: t.now().Add(tokenReviewCacheTTL)}
+	if review.Status.Authenticated {
+		result.user = &User{
+			Name:   review.Status.User.Username,
+			Groups: review.Status.User.Groups,
+			Method: "tokenreview",
+		}
+	} else if review.Status.Error != "" {
+		result.err = fmt.Errorf("token not authenticated: %s", review.Status.Error)
+	} else {
+		result.err = fmt.Errorf("token not authenticated")
+	}
+	t.store(key, result)
+	return result.user, result.err
+}
+
+func (t *TokenReview) Challenge() string {
+	return "Bearer"
+}
+
+func (t *TokenReview) cached(key [sha256.Size]byte) (tokenReviewResult, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	result, ok := t.cache[key]
+	if !ok || t.now().After(result.expires) {
+		return result, false
+	}
+	if result.user != nil {
+		// hand out a copy, the role of which is set by the caller
+		user := *result.user
+		result.user = &user
+	}
+	return result, true
+}
+
+func (t *TokenReview) store(key [sha256.Size]byte, result tokenReviewResult) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.cache) >= tokenReviewCacheSize {
+		now := t.now()
+		for k, r := range t.cache {
+			if now.After(r.expires) {
+				delete(t.cache, k)
+			}
+		}
+		if len(t.cache) >= tokenReviewCacheSize {
+			t.cache = map[[sha256.Size]byte]tokenReviewResult{}
+		}
+	}
+	if result.user != nil {
+		user := *result.user
+		result.user = &user
+	}
+	t.cache[key] = result
+}
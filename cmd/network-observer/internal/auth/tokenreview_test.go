@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestTokenReview(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	reviews := 0
+	var failure error
+	client.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		reviews++
+		if failure != nil {
+			return true, nil, failure
+		}
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		assert.DeepEqual(t, review.Spec.Audiences, []string{"observer"})
+		switch review.Spec.Token {
+		case "valid":
+			review.Status = authenticationv1.TokenReviewStatus{
+				Authenticated: true,
+				User: authenticationv1.UserInfo{
+					Username: "system:serviceaccount:test:monitor",
+					Groups:   []string{"system:serviceaccounts"},
+				},
+			}
+		case "expired":
+			review.Status = authenticationv1.TokenReviewStatus{Error: "token expired"}
+		}
+		return true, review, nil
+	})
+	now := time.Now()
+	tokenReview := NewTokenReview(client, []string{"observer"})
+	tokenReview.now = func() time.Time { return now }
+
+	authenticate := func(token string) (*User, error) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		return tokenReview.Authenticate(req)
+	}
+
+	user, err := authenticate("")
+	assert.Assert(t, err)
+	assert.Assert(t, user == nil)
+	assert.Equal(t, reviews, 0)
+
+	user, err = authenticate("valid")
+	assert.Assert(t, err)
+	assert.DeepEqual(t, user, &User{
+		Name:   "system:serviceaccount:test:monitor",
+		Groups: []string{"system:serviceaccounts"},
+		Method: "tokenreview",
+	})
+	assert.Equal(t, reviews, 1)
+
+	// cached results are copies the caller can modify
+	user.Role = RoleFlows
+	user, err = authenticate("valid")
+	assert.Assert(t, err)
+	assert.Equal(t, user.Role, Role(""))
+	assert.Equal(t, reviews, 1)
+
+	_, err = authenticate("expired")
+	assert.ErrorContains(t, err, "token not authenticated: token expired")
+	_, err = authenticate("expired")
+	assert.ErrorContains(t, err, "token not authenticated: token expired")
+	assert.Equal(t, reviews, 2)
+
+	_, err = authenticate("unknown")
+	assert.ErrorContains(t, err, "token not authenticated")
+	assert.Equal(t, reviews, 3)
+
+	// failures to review tokens are not cached
+	failure = errors.New("api unavailable")
+	_, err = authenticate("other")
+	assert.ErrorContains(t, err, "token review failed: api unavailable")
+	failure = nil
+	user, err = authenticate("valid")
+	assert.Assert(t, err)
+	assert.Assert(t, user != nil)
+	assert.Equal(t, reviews, 4)
+
+	// results expire
+	now = now.Add(2 * tokenReviewCacheTTL)
+	_, err = authenticate("valid")
+	assert.Assert(t, err)
+	assert.Equal(t, reviews, 5)
+}
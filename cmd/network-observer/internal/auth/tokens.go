@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// StaticTokens authenticates bearer tokens listed in a CSV file, one
+// per line as token,user[,group...].
+type StaticTokens struct {
+	file *watchedFile[map[[sha256.Size]byte]User]
+}
+
+func NewStaticTokens(path string) (*StaticTokens, error) {
+	file, err := newWatchedFile(path, parseTokens)
+	if err != nil {
+		return nil, err
+	}
+	return &StaticTokens{file: file}, nil
+}
+
+// Authenticate only handles the tokens listed in the file, leaving the
+// others to the authenticators tried next.
+func (s *StaticTokens) Authenticate(r *http.Request) (*User, error) {
+	token, ok := bearerToken(r)
+	if !ok {
+		return nil, nil
+	}
+	tokens, err := s.file.get()
+	if err != nil {
+		return nil, err
+	}
+	user, ok := tokens[sha256.Sum256([]byte(token))]
+	if !ok {
+		return nil, nil
+	}
+	return &user, nil
+}
+
+func (s *StaticTokens) Challenge() string {
+	return "Bearer"
+}
+
+func parseTokens(data []byte) (map[[sha256.Size]byte]User, error) {
+	tokens := map[[sha256.Size]byte]User{}
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return tokens, nil
+		} else if err != nil {
+			return nil, err
+		}
+		if len(record) < 2 || strings.TrimSpace(record[0]) == "" || strings.TrimSpace(record[1]) == "" {
+			line, _ := reader.FieldPos(0)
+			return nil, fmt.Errorf("line %d: expected token,user[,group...]", line)
+		}
+		user := User{
+			Name:   strings.TrimSpace(record[1]),
+			Method: "token",
+		}
+		for _, group := range record[2:] {
+			if group = strings.TrimSpace(group); group != "" {
+				user.Groups = append(user.Groups, group)
+			}
+		}
+		tokens[sha256.Sum256([]byte(strings.TrimSpace(record[0])))] = user
+	}
+}
@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestStaticTokens(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens.csv")
+	content := "# token,user,groups\n" +
+		"token-a,alice,ops,dev\n" +
+		"token-b, bob\n"
+	assert.Assert(t, os.WriteFile(path, []byte(content), 0600))
+	tokens, err := NewStaticTokens(path)
+	assert.Assert(t, err)
+	assert.Equal(t, tokens.Challenge(), "Bearer")
+
+	authenticate := func(header string) (*User, error) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		return tokens.Authenticate(req)
+	}
+
+	user, err := authenticate("Bearer token-a")
+	assert.Assert(t, err)
+	assert.DeepEqual(t, user, &User{Name: "alice", Groups: []string{"ops", "dev"}, Method: "token"})
+	user, err = authenticate("bearer token-b")
+	assert.Assert(t, err)
+	assert.DeepEqual(t, user, &User{Name: "bob", Method: "token"})
+
+	for _, header := range []string{"", "Bearer token-c", "Basic dG9rZW4tYQ=="} {
+		user, err = authenticate(header)
+		assert.Assert(t, err)
+		assert.Assert(t, user == nil, header)
+	}
+
+	_, err = parseTokens([]byte("token-a,alice\ntoken-b\n"))
+	assert.ErrorContains(t, err, "line 2: expected token,user[,group...]")
+}
@@ -23,7 +23,11 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
-func New(logger *slog.Logger, factory session.ContainerFactory, reg *prometheus.Registry, flowRecordTTL time.Duration, flowLogger func(vanflow.RecordMessage)) *Collector {
+// New creates a Collector. When history is not nil, connection and request
+// records are archived to it once their flows are purged from memory. When
+// exporter is not nil, connection and request records are handed to it once
+// their flows terminate.
+func New(logger *slog.Logger, factory session.ContainerFactory, reg *prometheus.Registry, flowRecordTTL time.Duration, flowLogger func(vanflow.RecordMessage), history FlowHistory, exporter FlowExporter) *Collector {
 	sessionCtr := factory.Create()
 
 	collector := &Collector{
@@ -38,6 +42,9 @@ func New(logger *slog.Logger, factory session.ContainerFactory, reg *prometheus.
 		metrics:        register(reg),
 		metricsAdaptor: opmetrics.New(reg),
 		flowLogging:    flowLogger,
+		exporter:       exporter,
+		requests:       newRequestAggregator(),
+		stream:         NewEventStream(0),
 	}
 
 	collector.Records = store.NewSyncMapStore(store.SyncMapStoreConfig{
@@ -48,6 +55,10 @@ func New(logger *slog.Logger, factory session.ContainerFactory, reg *prometheus.
 		},
 		Indexers: RecordIndexers(),
 	})
+	if history != nil {
+		collector.history = newTieredStore(collector.Records, history)
+		collector.Records = collector.history
+	}
 	collector.graph = NewGraph(collector.Records).(*graph)
 	collector.processManager = newProcessManager(logger, collector.Records, collector.graph, newStableIdentityProvider(), collector.metrics)
 	collector.addressManager = newAddressManager(collector.logger, collector.Records)
@@ -71,6 +82,9 @@ type Collector struct {
 	sources map[string]eventSource
 
 	Records       store.Interface
+	history       *tieredStore
+	exporter      FlowExporter
+	requests      *requestAggregator
 	graph         *graph
 	recordRouting eventsource.RecordStoreMap
 
@@ -81,6 +95,7 @@ type Collector struct {
 
 	events     chan changeEvent
 	purgeQueue chan store.SourceRef
+	stream     *EventStream
 
 	metrics metrics
 }
@@ -94,6 +109,24 @@ func (c *Collector) GetGraph() Graph {
 	return c.graph
 }
 
+// TopologyMetrics returns a prometheus.Collector for the coarse metrics
+// describing the sites, routers, links, listeners and connectors of the
+// network.
+func (c *Collector) TopologyMetrics() prometheus.Collector {
+	return c.metricsAdaptor
+}
+
+// Requests returns the statistics of the requests handled by the network,
+// aggregated per service and process pair.
+func (c *Collector) Requests() RequestAggregates {
+	return c.requests
+}
+
+// Events returns the stream of changes to the records of the collector.
+func (c *Collector) Events() *EventStream {
+	return c.stream
+}
+
 func (c *Collector) Run(ctx context.Context) error {
 	c.session.Start(ctx)
 	g, ctx := errgroup.WithContext(ctx)
@@ -105,6 +138,9 @@ func (c *Collector) Run(ctx context.Context) error {
 	g.Go(c.processManager.run(ctx))
 	g.Go(c.addressManager.run(ctx))
 	g.Go(c.pairManager.run(ctx))
+	if c.history != nil {
+		g.Go(c.history.run(ctx, c.logger))
+	}
 	return g.Wait()
 }
 
@@ -191,9 +227,21 @@ func (c *Collector) runWorkQueue(ctx context.Context) func() error {
 				start := time.Now()
 				typ := event.GetTypeMeta()
 				event.ID()
+				var streamEvent Event
+				if event, ok := event.(deleteEvent); ok {
+					// scoped before the record is removed from the graph
+					streamEvent = c.newStreamEvent(EventDelete, event.Record)
+				}
 				for _, reactor := range reactors[typ] {
 					reactor(event, c.Records)
 				}
+				switch event := event.(type) {
+				case addEvent:
+					streamEvent = c.newStreamEvent(EventAdd, event.Record)
+				case updateEvent:
+					streamEvent = c.newStreamEvent(EventUpdate, event.Curr)
+				}
+				c.stream.Publish(streamEvent)
 				c.metrics.internal.flowProcessingTime.WithLabelValues(typ.String()).Observe(time.Since(start).Seconds())
 			}
 		}
@@ -265,6 +313,19 @@ func (c *Collector) runRecordCleanup(ctx context.Context) func() error {
 						slog.Int("count", ct),
 					)
 				}
+				if ct := c.requests.expire(time.Now().Add(-requestIdleTTL)); ct > 0 {
+					c.logger.Info("expired idle request statistics",
+						slog.Int("count", ct),
+					)
+				}
+				if c.history == nil {
+					continue
+				}
+				if ct := c.history.history.Expire(); ct > 0 {
+					c.logger.Info("expired records from flow history",
+						slog.Int("count", ct),
+					)
+				}
 			case source := <-c.purgeQueue:
 				ct := c.purge(source)
 				c.logger.Info("purged records from forgotten source",
@@ -278,9 +339,9 @@ func (c *Collector) runRecordCleanup(ctx context.Context) func() error {
 
 func (c *Collector) handleStoreAdd(e store.Entry) {
 	switch e.Record.(type) {
-	case RequestRecord:
-		return
-	case ConnectionRecord:
+	case RequestRecord, ConnectionRecord:
+		// flow records are not handled by the work queue
+		c.stream.Publish(c.newStreamEvent(EventAdd, e.Record))
 		return
 	}
 	select {
@@ -292,9 +353,9 @@ func (c *Collector) handleStoreAdd(e store.Entry) {
 
 func (c *Collector) handleStoreChange(p, e store.Entry) {
 	switch e.Record.(type) {
-	case RequestRecord:
-		return
-	case ConnectionRecord:
+	case RequestRecord, ConnectionRecord:
+		// flow records are not handled by the work queue
+		c.stream.Publish(c.newStreamEvent(EventUpdate, e.Record))
 		return
 	}
 	select {
@@ -305,9 +366,9 @@ func (c *Collector) handleStoreChange(p, e store.Entry) {
 }
 func (c *Collector) handleStoreDelete(e store.Entry) {
 	switch e.Record.(type) {
-	case RequestRecord:
-		return
-	case ConnectionRecord:
+	case RequestRecord, ConnectionRecord:
+		// flow records are not handled by the work queue
+		c.stream.Publish(c.newStreamEvent(EventDelete, e.Record))
 		return
 	}
 	select {
@@ -369,6 +430,9 @@ func (c *Collector) discoveryHandler(ctx context.Context) func(eventsource.Info)
 				c.logger.With(slog.String("eventsource", fmt.Sprintf("%d/%s", source.Version, source.ID))),
 				sourceRef(source),
 				c.Records,
+				c.history,
+				c.exporter,
+				c.requests,
 				c.graph,
 				c.metrics,
 				c.flowRecordTTL,
@@ -22,6 +22,9 @@ type connectionManager struct {
 	logger                *slog.Logger
 	flows                 store.Interface
 	records               store.Interface
+	history               *tieredStore
+	exporter              FlowExporter
+	requests              *requestAggregator
 	source                store.SourceRef
 	graph                 *graph
 	idp                   idProvider
@@ -47,10 +50,13 @@ type connectionManager struct {
 	routerCache     map[string]routerAttrs
 }
 
-func newConnectionmanager(ctx context.Context, log *slog.Logger, source store.SourceRef, records store.Interface, graph *graph, metrics metrics, ttl time.Duration) *connectionManager {
+func newConnectionmanager(ctx context.Context, log *slog.Logger, source store.SourceRef, records store.Interface, history *tieredStore, exporter FlowExporter, requests *requestAggregator, graph *graph, metrics metrics, ttl time.Duration) *connectionManager {
 	m := &connectionManager{
 		logger:                  log,
 		records:                 records,
+		history:                 history,
+		exporter:                exporter,
+		requests:                requests,
 		graph:                   graph,
 		source:                  source,
 		idp:                     newStableIdentityProvider(),
@@ -110,6 +116,7 @@ func (c *connectionManager) handleTransportFlow(record vanflow.TransportBiflowRe
 		if terminated {
 			state.Terminated = true
 			metrics.closed.Inc()
+			c.exportConnection(record)
 		}
 	}
 	if !state.LatencySet && record.Latency != nil && record.LatencyReverse != nil {
@@ -122,7 +129,9 @@ func (c *connectionManager) handleTransportFlow(record vanflow.TransportBiflowRe
 	bs, br := dref(record.Octets), dref(record.OctetsReverse)
 	sentInc := float64(bs - state.BytesSent)
 	receivedInc := float64(br - state.BytesReceived)
-	if receivedInc != 0 {
+	// udp flows are often one way (syslog, telemetry) and never receive
+	// anything back
+	if sentInc != 0 || receivedInc != 0 {
 		metrics.sent.Add(sentInc)
 		metrics.received.Add(receivedInc)
 		state.BytesSent = bs
@@ -149,10 +158,18 @@ func (c *connectionManager) handleAppFlow(record vanflow.AppBiflowRecord) {
 		terminated := record.EndTime.Compare(dref(record.StartTime).Time) >= 0
 		if terminated {
 			state.Terminated = true
-			metrics.requests.With(prometheus.Labels{
-				"method": normalizeHTTPMethod(record.Method),
-				"code":   normalizeHTTPResponseClass(record.Result),
-			}).Inc()
+			method, class := normalizeHTTPMethod(record.Method), normalizeHTTPResponseClass(record.Result)
+			labels := prometheus.Labels{
+				"method": method,
+				"code":   class,
+			}
+			metrics.requests.With(labels).Inc()
+			latency := time.Microsecond * time.Duration(dref(record.Latency))
+			if record.Latency != nil {
+				metrics.latency.With(labels).Observe(latency.Seconds())
+			}
+			c.aggregateRequest(record, method, normalizeHTTPResponseCode(record.Result), latency)
+			c.exportRequest(record)
 		}
 	}
 	c.appFlows.Push(record.ID, state)
@@ -206,15 +223,121 @@ func (c *connectionManager) handleDelete(e store.Entry) {
 	switch record := e.Record.(type) {
 	case vanflow.TransportBiflowRecord:
 		c.transportFlows.Pop(record.ID)
+		c.archiveConnection(record)
 		c.records.Delete(record.ID)
 	case vanflow.AppBiflowRecord:
 		c.appFlows.Pop(record.ID)
+		c.archiveRequest(record)
 		c.records.Delete(record.ID)
 	default:
 		// ignore
 	}
 }
 
+// archiveConnection moves the ConnectionRecord reconciled from a deleted
+// transport flow to the flow history, when there is one.
+func (c *connectionManager) archiveConnection(flow vanflow.TransportBiflowRecord) {
+	if c.history == nil {
+		return
+	}
+	entry, ok := c.history.Interface.Get(flow.ID)
+	if !ok {
+		return
+	}
+	conn, ok := entry.Record.(ConnectionRecord)
+	if !ok {
+		return
+	}
+	conn.Flow = &flow
+	conn.FlowStore = nil
+	c.history.archive(conn, entry.Source)
+}
+
+// archiveRequest moves the RequestRecord reconciled from a deleted app flow
+// to the flow history, when there is one.
+func (c *connectionManager) archiveRequest(flow vanflow.AppBiflowRecord) {
+	if c.history == nil {
+		return
+	}
+	entry, ok := c.history.Interface.Get(flow.ID)
+	if !ok {
+		return
+	}
+	request, ok := entry.Record.(RequestRecord)
+	if !ok {
+		return
+	}
+	request.Flow = &flow
+	if transport, ok := request.GetTransport(); ok {
+		request.Transport = &transport
+	} else if entry, ok := c.history.Get(request.TransportID); ok {
+		// the transport flow may have been purged first
+		if conn, ok := entry.Record.(ConnectionRecord); ok {
+			request.Transport = conn.Flow
+		}
+	}
+	request.stor = nil
+	c.history.archive(request, entry.Source)
+}
+
+// exportConnection hands the ConnectionRecord reconciled from a terminated
+// transport flow to the flow exporter, when there is one.
+func (c *connectionManager) exportConnection(flow vanflow.TransportBiflowRecord) {
+	if c.exporter == nil {
+		return
+	}
+	entry, ok := c.records.Get(flow.ID)
+	if !ok {
+		return
+	}
+	conn, ok := entry.Record.(ConnectionRecord)
+	if !ok {
+		return
+	}
+	conn.Flow = &flow
+	conn.FlowStore = nil
+	c.exporter.ExportConnection(conn)
+}
+
+// aggregateRequest adds a terminated app flow to the statistics of the
+// requests handled by its service and process pair.
+func (c *connectionManager) aggregateRequest(flow vanflow.AppBiflowRecord, method string, code string, latency time.Duration) {
+	if c.requests == nil {
+		return
+	}
+	entry, ok := c.records.Get(flow.ID)
+	if !ok {
+		return
+	}
+	request, ok := entry.Record.(RequestRecord)
+	if !ok {
+		return
+	}
+	c.requests.observe(request, method, code, latency)
+}
+
+// exportRequest hands the RequestRecord reconciled from a terminated app
+// flow to the flow exporter, when there is one.
+func (c *connectionManager) exportRequest(flow vanflow.AppBiflowRecord) {
+	if c.exporter == nil {
+		return
+	}
+	entry, ok := c.records.Get(flow.ID)
+	if !ok {
+		return
+	}
+	request, ok := entry.Record.(RequestRecord)
+	if !ok {
+		return
+	}
+	request.Flow = &flow
+	if transport, ok := request.GetTransport(); ok {
+		request.Transport = &transport
+	}
+	request.stor = nil
+	c.exporter.ExportRequest(request)
+}
+
 type reconcileReason int
 
 const (
@@ -296,6 +419,7 @@ func (c *connectionManager) getAppMetricSet(l labelSet) appMetrics {
 	labels := l.asLabels()
 	m := appMetrics{
 		requests: c.metrics.requestsCounter.MustCurryWith(labels),
+		latency:  c.metrics.requestLatency.MustCurryWith(labels),
 	}
 	c.requestMetricsCache[l] = m
 	return m
@@ -843,6 +967,7 @@ type transportMetrics struct {
 }
 type appMetrics struct {
 	requests *prometheus.CounterVec
+	latency  prometheus.ObserverVec
 }
 
 type appState struct {
@@ -1081,6 +1206,19 @@ func (c *connectionManager) routerAttrs(id string) (routerAttrs, bool) {
 	return attrs, complete
 }
 
+// normalizeHTTPResponseCode returns the status code of an HTTP response,
+// or "unknown" when it is missing or not a valid status code.
+func normalizeHTTPResponseCode(result *string) string {
+	if result == nil {
+		return "unknown"
+	}
+	code, err := strconv.Atoi(*result)
+	if err != nil || code < 100 || code >= 600 {
+		return "unknown"
+	}
+	return strconv.Itoa(code)
+}
+
 func normalizeHTTPResponseClass(result *string) string {
 	class := "unknown"
 	if result == nil {
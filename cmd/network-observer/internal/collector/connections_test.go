@@ -22,7 +22,7 @@ func TestConnectionManager(t *testing.T) {
 	// TODO(ck)  newConnectionmanager starts goroutines that can "steal" work
 	// from manually invoked manager methods (i.e. runReconcile). Write
 	// idempotent assertions.
-	manager := newConnectionmanager(tCtx, tlog, store.SourceRef{}, vanStor, graf, register(prometheus.NewRegistry()), time.Minute)
+	manager := newConnectionmanager(tCtx, tlog, store.SourceRef{}, vanStor, nil, nil, nil, graf, register(prometheus.NewRegistry()), time.Minute)
 	defer manager.Stop()
 	flowStor := manager.flows
 
@@ -87,13 +87,71 @@ func TestConnectionManager(t *testing.T) {
 	assert.Equal(t, requestRecord.Dest.Name, "server-east-06")
 }
 
+func TestConnectionManagerUdp(t *testing.T) {
+	tCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tlog := slog.Default()
+	vanStor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: RecordIndexers()})
+	graf := NewGraph(vanStor).(*graph)
+	manager := newConnectionmanager(tCtx, tlog, store.SourceRef{}, vanStor, nil, nil, nil, graf, register(prometheus.NewRegistry()), time.Minute)
+	defer manager.Stop()
+	flowStor := manager.flows
+
+	vanStor.Replace(wrapRecords(append(van,
+		vanflow.ListenerRecord{
+			BaseRecord: vanflow.NewBase("listener-syslog"),
+			Parent:     ptrTo("router-0-west"),
+			Address:    ptrTo("syslog"),
+			Protocol:   ptrTo("udp"),
+		},
+		vanflow.ConnectorRecord{
+			BaseRecord: vanflow.NewBase("connector-syslog-0-6"),
+			Parent:     ptrTo("router-0-east"),
+			Address:    ptrTo("syslog"),
+			Protocol:   ptrTo("udp"),
+			DestHost:   ptrTo("10.0.0.6"),
+			DestPort:   ptrTo("514"),
+		},
+	)...))
+	graf.Reset()
+
+	flowStor.Add(vanflow.TransportBiflowRecord{
+		BaseRecord:  vanflow.NewBase("uflow-01", time.Now()),
+		Parent:      ptrTo("listener-syslog"),
+		ConnectorID: ptrTo("connector-syslog-0-6"),
+		SourceHost:  ptrTo("10.111.0.111"),
+	}, store.SourceRef{})
+	result := manager.runReconcile()
+	pending := result.PendingConnectorCount + result.PendingSourceCount + result.PendingDestCount
+	assert.Equal(t, pending, 0)
+
+	entry, ok := vanStor.Get("uflow-01")
+	if !ok {
+		t.Fatal("missing ConnectionRecord for uflow-01")
+	}
+	connection := entry.Record.(ConnectionRecord)
+	assert.Equal(t, connection.Protocol, "udp")
+	assert.Equal(t, connection.RoutingKey, "syslog")
+	assert.Equal(t, connection.Dest.Name, "server-east-06")
+
+	// datagrams are only sent, nothing is received back
+	manager.handleTransportFlow(vanflow.TransportBiflowRecord{
+		BaseRecord: vanflow.NewBase("uflow-01", time.Now()),
+		Octets:     ptrTo(uint64(1024)),
+	})
+	state, ok := manager.transportFlows.Get("uflow-01")
+	assert.Assert(t, ok)
+	assert.Equal(t, state.BytesSent, uint64(1024))
+	assert.Equal(t, state.BytesReceived, uint64(0))
+}
+
 func benchmarkRunReconcile(b *testing.B, connections int) {
 	tCtx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	tlog := slog.Default()
 	vanStor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: RecordIndexers()})
 	graf := NewGraph(vanStor).(*graph)
-	manager := newConnectionmanager(tCtx, tlog, store.SourceRef{}, vanStor, graf, register(prometheus.NewRegistry()), time.Minute)
+	manager := newConnectionmanager(tCtx, tlog, store.SourceRef{}, vanStor, nil, nil, nil, graf, register(prometheus.NewRegistry()), time.Minute)
 	defer manager.Stop()
 	flowStor := manager.flows
 
@@ -132,7 +190,7 @@ func benchmarkRunAppReconcile(b *testing.B, connections int, requests int) {
 	tlog := slog.Default()
 	vanStor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: RecordIndexers()})
 	graf := NewGraph(vanStor).(*graph)
-	manager := newConnectionmanager(tCtx, tlog, store.SourceRef{}, vanStor, graf, register(prometheus.NewRegistry()), time.Minute)
+	manager := newConnectionmanager(tCtx, tlog, store.SourceRef{}, vanStor, nil, nil, nil, graf, register(prometheus.NewRegistry()), time.Minute)
 	defer manager.Stop()
 	flowStor := manager.flows
 
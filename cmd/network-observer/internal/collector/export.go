@@ -0,0 +1,9 @@
+package collector
+
+// FlowExporter receives connection and request records as soon as the flows
+// they were reconciled from terminate. Implementations must not block: they
+// are called from the goroutines handling flow records.
+type FlowExporter interface {
+	ExportConnection(ConnectionRecord)
+	ExportRequest(RequestRecord)
+}
@@ -0,0 +1,121 @@
+package collector
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/poll"
+)
+
+type recordingExporter struct {
+	mu          sync.Mutex
+	connections []ConnectionRecord
+	requests    []RequestRecord
+}
+
+func (e *recordingExporter) ExportConnection(conn ConnectionRecord) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.connections = append(e.connections, conn)
+}
+
+func (e *recordingExporter) ExportRequest(request RequestRecord) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.requests = append(e.requests, request)
+}
+
+func (e *recordingExporter) counts() (int, int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return len(e.connections), len(e.requests)
+}
+
+func TestConnectionManagerExport(t *testing.T) {
+	tCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tlog := slog.Default()
+	vanStor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: RecordIndexers()})
+	graf := NewGraph(vanStor).(*graph)
+	exporter := &recordingExporter{}
+	manager := newConnectionmanager(tCtx, tlog, store.SourceRef{}, vanStor, nil, exporter, nil, graf, register(prometheus.NewRegistry()), time.Minute)
+	defer manager.Stop()
+	flowStor := manager.flows
+
+	vanStor.Replace(wrapRecords(van...))
+	graf.Reset()
+
+	start := time.UnixMicro(time.Now().UnixMicro())
+	transport := vanflow.TransportBiflowRecord{
+		BaseRecord:  vanflow.NewBase("tflow-01", start),
+		Parent:      ptrTo("listener-backend"),
+		ConnectorID: ptrTo("connector-backend-1-6"),
+		SourceHost:  ptrTo("10.111.0.111"),
+		Octets:      ptrTo(uint64(512)),
+	}
+	request := vanflow.AppBiflowRecord{
+		BaseRecord: vanflow.NewBase("appflow-01", start),
+		Parent:     ptrTo("tflow-01"),
+		Protocol:   ptrTo("HTTP/1.1"),
+		Method:     ptrTo("GET"),
+		Result:     ptrTo("200"),
+	}
+	flowStor.Add(transport, store.SourceRef{})
+	flowStor.Add(request, store.SourceRef{})
+
+	manager.runReconcile()
+	manager.runAppReconcile()
+	poll.WaitOn(t, func(t poll.LogT) poll.Result {
+		_, connOK := vanStor.Get("tflow-01")
+		_, requestOK := vanStor.Get("appflow-01")
+		if !connOK || !requestOK {
+			manager.runReconcile()
+			manager.runAppReconcile()
+			return poll.Continue("waiting for reconciled records")
+		}
+		return poll.Success()
+	}, poll.WithDelay(time.Millisecond*10))
+
+	// nothing is exported until the flows terminate
+	connections, requests := exporter.counts()
+	assert.Equal(t, connections, 0)
+	assert.Equal(t, requests, 0)
+
+	transport.EndTime = &vanflow.Time{Time: start.Add(time.Second)}
+	request.EndTime = &vanflow.Time{Time: start.Add(time.Second)}
+	flowStor.Patch(transport, store.SourceRef{})
+	flowStor.Patch(request, store.SourceRef{})
+	// records are exported once
+	flowStor.Patch(vanflow.TransportBiflowRecord{
+		BaseRecord:    vanflow.NewBase("tflow-01"),
+		OctetsReverse: ptrTo(uint64(1024)),
+	}, store.SourceRef{})
+	connections, requests = exporter.counts()
+	assert.Equal(t, connections, 1)
+	assert.Equal(t, requests, 1)
+
+	exporter.mu.Lock()
+	defer exporter.mu.Unlock()
+	conn := exporter.connections[0]
+	assert.Equal(t, conn.Source.Name, "client-west-01")
+	assert.Equal(t, conn.Dest.Name, "server-east-06")
+	flow, ok := conn.GetFlow()
+	assert.Assert(t, ok)
+	assert.Equal(t, dref(flow.Octets), uint64(512))
+
+	req := exporter.requests[0]
+	assert.Equal(t, req.Protocol, "http1")
+	appFlow, ok := req.GetFlow()
+	assert.Assert(t, ok)
+	assert.Equal(t, dref(appFlow.Method), "GET")
+	parent, ok := req.GetTransport()
+	assert.Assert(t, ok)
+	assert.Equal(t, parent.ID, "tflow-01")
+}
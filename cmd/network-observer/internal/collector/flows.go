@@ -33,12 +33,21 @@ type ConnectionRecord struct {
 	// FlowStore is the backing store containing the Biflow records. This was
 	// split from the main record store to keep high volume flow producers from
 	// affecting the rest of the event sources.
-	FlowStore store.Interface
-	metrics   transportMetrics
+	FlowStore store.Interface `json:"-"`
+	// Flow is a snapshot of the Biflow record taken when the connection was
+	// archived to the flow history.
+	Flow    *vanflow.TransportBiflowRecord
+	metrics transportMetrics
 }
 
 func (cr *ConnectionRecord) GetFlow() (vanflow.TransportBiflowRecord, bool) {
 	var record vanflow.TransportBiflowRecord
+	if cr.Flow != nil {
+		return *cr.Flow, true
+	}
+	if cr.FlowStore == nil {
+		return record, false
+	}
 	ent, ok := cr.FlowStore.Get(cr.ID)
 	if !ok {
 		return record, false
@@ -97,12 +106,23 @@ type RequestRecord struct {
 	DestGroup    NamedReference
 	Trace        string
 
+	// Flow and Transport are snapshots of the Biflow records taken when the
+	// request was archived to the flow history.
+	Flow      *vanflow.AppBiflowRecord
+	Transport *vanflow.TransportBiflowRecord
+
 	stor    store.Interface
 	metrics appMetrics
 }
 
 func (cr *RequestRecord) GetFlow() (vanflow.AppBiflowRecord, bool) {
 	var record vanflow.AppBiflowRecord
+	if cr.Flow != nil {
+		return *cr.Flow, true
+	}
+	if cr.stor == nil {
+		return record, false
+	}
 	ent, ok := cr.stor.Get(cr.ID)
 	if !ok {
 		return record, false
@@ -112,6 +132,12 @@ func (cr *RequestRecord) GetFlow() (vanflow.AppBiflowRecord, bool) {
 }
 func (cr *RequestRecord) GetTransport() (vanflow.TransportBiflowRecord, bool) {
 	var record vanflow.TransportBiflowRecord
+	if cr.Transport != nil {
+		return *cr.Transport, true
+	}
+	if cr.stor == nil {
+		return record, false
+	}
 	ent, ok := cr.stor.Get(cr.TransportID)
 	if !ok {
 		return record, false
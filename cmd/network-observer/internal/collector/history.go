@@ -0,0 +1,209 @@
+package collector
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+)
+
+// FlowHistory is a persistent store of ConnectionRecords and
+// RequestRecords that outlive the flows they were reconciled from.
+type FlowHistory interface {
+	store.Interface
+	// Put adds the entries, or replaces the ones with the same identity,
+	// all at once.
+	Put(entries ...store.Entry) error
+	// IndexSince is like Index, but only returns the entries last updated
+	// at or after since.
+	IndexSince(index string, exemplar store.Entry, since time.Time) []store.Entry
+	// Expire removes the records exceeding the retention limits of the
+	// history, returning how many were removed.
+	Expire() int
+	Close() error
+}
+
+// NewFlowHistory opens (or creates) a FlowHistory backed by the bbolt
+// database at path. Records are kept for at most retention after they were
+// archived and no more than maxRecords are kept. Zero values do not limit
+// the history.
+func NewFlowHistory(path string, retention time.Duration, maxRecords int) (FlowHistory, error) {
+	return store.NewBoltStore(store.BoltStoreConfig{
+		Path: path,
+		Indexers: map[string]store.Indexer{
+			store.TypeIndex:    store.TypeIndexer,
+			IndexFlowByAddress: indexByTypeAndAddress,
+		},
+		RecordTypes: []vanflow.Record{ConnectionRecord{}, RequestRecord{}},
+		Retention:   retention,
+		MaxEntries:  maxRecords,
+	})
+}
+
+const (
+	// how often records archived since the last write are written to the
+	// history
+	archiveInterval = time.Second
+	// how many archived records are written to the history at once, if
+	// reached before the archive interval has elapsed
+	archiveBatchSize = 512
+)
+
+// tieredStore presents the records held in memory together with the
+// records archived to a FlowHistory. Writes only go to the live store,
+// records are moved to the history through archive. Archived records are
+// held in memory until run writes them to the history, in batches so that
+// each batch is written in a single transaction.
+type tieredStore struct {
+	store.Interface
+	history FlowHistory
+
+	mu sync.Mutex
+	// archived records not yet written to the history
+	pending      store.Interface
+	pendingCount int
+	flushes      chan struct{}
+}
+
+func newTieredStore(live store.Interface, history FlowHistory) *tieredStore {
+	return &tieredStore{
+		Interface: live,
+		history:   history,
+		pending: store.NewSyncMapStore(store.SyncMapStoreConfig{
+			Indexers: map[string]store.Indexer{
+				store.TypeIndex:    store.TypeIndexer,
+				IndexFlowByAddress: indexByTypeAndAddress,
+			},
+		}),
+		flushes: make(chan struct{}, 1),
+	}
+}
+
+// archive moves a record from the live store to the history.
+func (s *tieredStore) archive(record vanflow.Record, source store.SourceRef) {
+	id := record.Identity()
+	s.mu.Lock()
+	if _, ok := s.pending.Delete(id); !ok {
+		s.pendingCount++
+	}
+	s.pending.Add(record, source)
+	full := s.pendingCount >= archiveBatchSize
+	s.mu.Unlock()
+	s.Interface.Delete(id)
+	if full {
+		select {
+		case s.flushes <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// run writes the archived records to the history until ctx is cancelled.
+func (s *tieredStore) run(ctx context.Context, logger *slog.Logger) func() error {
+	return func() error {
+		ticker := time.NewTicker(archiveInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				if err := s.flush(); err != nil {
+					logger.Error("error writing flow history", slog.Any("error", err))
+				}
+				return nil
+			case <-ticker.C:
+			case <-s.flushes:
+			}
+			if err := s.flush(); err != nil {
+				logger.Error("error writing flow history", slog.Any("error", err))
+			}
+		}
+	}
+}
+
+// flush writes the archived records to the history in a single
+// transaction.
+func (s *tieredStore) flush() error {
+	s.mu.Lock()
+	entries := s.pending.List()
+	s.mu.Unlock()
+	if len(entries) == 0 {
+		return nil
+	}
+	if err := s.history.Put(entries...); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, entry := range entries {
+		id := entry.Record.Identity()
+		// unless archived again in the meantime
+		if current, ok := s.pending.Get(id); ok && current.LastUpdate.Equal(entry.LastUpdate) {
+			s.pending.Delete(id)
+			s.pendingCount--
+		}
+	}
+	return nil
+}
+
+func (s *tieredStore) Get(id string) (store.Entry, bool) {
+	if entry, ok := s.Interface.Get(id); ok {
+		return entry, true
+	}
+	if entry, ok := s.pending.Get(id); ok {
+		return entry, true
+	}
+	return s.history.Get(id)
+}
+
+func (s *tieredStore) List() []store.Entry {
+	return mergeEntries(s.Interface.List(), s.pending.List(), s.history.List())
+}
+
+func (s *tieredStore) Index(index string, exemplar store.Entry) []store.Entry {
+	return mergeEntries(s.Interface.Index(index, exemplar), s.pending.Index(index, exemplar), s.history.Index(index, exemplar))
+}
+
+// IndexSince is like Index, but skips the records archived to the history
+// before since.
+func (s *tieredStore) IndexSince(index string, exemplar store.Entry, since time.Time) []store.Entry {
+	return mergeEntries(s.Interface.Index(index, exemplar), s.pending.Index(index, exemplar), s.history.IndexSince(index, exemplar, since))
+}
+
+func (s *tieredStore) IndexValues(index string) []string {
+	values := s.Interface.IndexValues(index)
+	seen := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		seen[value] = struct{}{}
+	}
+	for _, archived := range [][]string{s.pending.IndexValues(index), s.history.IndexValues(index)} {
+		for _, value := range archived {
+			if _, ok := seen[value]; !ok {
+				seen[value] = struct{}{}
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// mergeEntries appends the archived entries to the live ones, skipping
+// those already seen.
+func mergeEntries(live []store.Entry, archived ...[]store.Entry) []store.Entry {
+	ids := make(map[string]struct{}, len(live))
+	for _, entry := range live {
+		ids[entry.Record.Identity()] = struct{}{}
+	}
+	for _, entries := range archived {
+		for _, entry := range entries {
+			id := entry.Record.Identity()
+			if _, ok := ids[id]; !ok {
+				ids[id] = struct{}{}
+				live = append(live, entry)
+			}
+		}
+	}
+	return live
+}
@@ -0,0 +1,101 @@
+package collector
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+	"gotest.tools/v3/assert"
+)
+
+func TestConnectionManagerHistory(t *testing.T) {
+	tCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tlog := slog.Default()
+	path := filepath.Join(t.TempDir(), "flows.db")
+	history, err := NewFlowHistory(path, time.Hour, 0)
+	assert.Assert(t, err)
+	liveStor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: RecordIndexers()})
+	vanStor := newTieredStore(liveStor, history)
+	graf := NewGraph(vanStor).(*graph)
+	manager := newConnectionmanager(tCtx, tlog, store.SourceRef{}, vanStor, vanStor, nil, nil, graf, register(prometheus.NewRegistry()), time.Minute)
+	defer manager.Stop()
+	flowStor := manager.flows
+
+	vanStor.Replace(wrapRecords(van...))
+	graf.Reset()
+
+	start := time.UnixMicro(time.Now().UnixMicro())
+	flowStor.Add(vanflow.TransportBiflowRecord{
+		BaseRecord:  vanflow.NewBase("tflow-01", start, start.Add(time.Second)),
+		Parent:      ptrTo("listener-backend"),
+		ConnectorID: ptrTo("connector-backend-1-6"),
+		SourceHost:  ptrTo("10.111.0.111"),
+		Octets:      ptrTo(uint64(512)),
+	}, store.SourceRef{})
+	flowStor.Add(vanflow.AppBiflowRecord{
+		BaseRecord: vanflow.NewBase("appflow-01", start, start.Add(time.Second)),
+		Parent:     ptrTo("tflow-01"),
+		Protocol:   ptrTo("HTTP/1.1"),
+		Method:     ptrTo("GET"),
+		Result:     ptrTo("200"),
+	}, store.SourceRef{})
+	manager.runReconcile()
+	manager.runAppReconcile()
+
+	// records are archived as their flows are purged
+	flowStor.Delete("tflow-01")
+	flowStor.Delete("appflow-01")
+	_, ok := liveStor.Get("tflow-01")
+	assert.Assert(t, !ok)
+	_, ok = liveStor.Get("appflow-01")
+	assert.Assert(t, !ok)
+
+	connections := vanStor.Index(store.TypeIndex, store.Entry{Record: ConnectionRecord{}})
+	assert.Equal(t, len(connections), 1)
+	requests := vanStor.Index(IndexFlowByAddress, store.Entry{Record: RequestRecord{RoutingKey: "backend", Protocol: "http1"}})
+	assert.Equal(t, len(requests), 1)
+	assert.Assert(t, len(vanStor.List()) > len(liveStor.List()))
+
+	// archived records are written to the history in a single batch
+	_, ok = history.Get("tflow-01")
+	assert.Assert(t, !ok)
+	assert.Assert(t, vanStor.flush())
+	assert.Equal(t, vanStor.pendingCount, 0)
+	_, ok = history.Get("tflow-01")
+	assert.Assert(t, ok)
+	assert.Equal(t, len(vanStor.Index(store.TypeIndex, store.Entry{Record: ConnectionRecord{}})), 1)
+	assert.Equal(t, len(vanStor.IndexSince(store.TypeIndex, store.Entry{Record: ConnectionRecord{}}, time.Now().Add(time.Minute))), 0)
+
+	// and survive reopening the history
+	assert.Assert(t, history.Close())
+	history, err = NewFlowHistory(path, time.Hour, 0)
+	assert.Assert(t, err)
+	defer history.Close()
+
+	entry, ok := history.Get("tflow-01")
+	assert.Assert(t, ok)
+	conn := entry.Record.(ConnectionRecord)
+	assert.Equal(t, conn.Source.Name, "client-west-01")
+	assert.Equal(t, conn.Dest.Name, "server-east-06")
+	flow, ok := conn.GetFlow()
+	assert.Assert(t, ok)
+	assert.Equal(t, dref(flow.Octets), uint64(512))
+	assert.Assert(t, flow.EndTime.Equal(start.Add(time.Second)))
+
+	entry, ok = history.Get("appflow-01")
+	assert.Assert(t, ok)
+	request := entry.Record.(RequestRecord)
+	assert.Equal(t, request.Protocol, "http1")
+	appFlow, ok := request.GetFlow()
+	assert.Assert(t, ok)
+	assert.Equal(t, dref(appFlow.Method), "GET")
+	transport, ok := request.GetTransport()
+	assert.Assert(t, ok)
+	assert.Equal(t, transport.ID, "tflow-01")
+}
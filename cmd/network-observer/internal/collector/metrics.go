@@ -10,6 +10,7 @@ type metrics struct {
 	flowBytesSentCounter     *prometheus.CounterVec
 	flowBytesReceivedCounter *prometheus.CounterVec
 	requestsCounter          *prometheus.CounterVec
+	requestLatency           *prometheus.HistogramVec
 
 	internal metricsInternal
 }
@@ -50,6 +51,12 @@ func register(reg *prometheus.Registry) metrics {
 			Name:      "requests_total",
 			Help:      "Counter incremented for each request handled through the skupper network",
 		}, appFlowMetricLables),
+		requestLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "skupper",
+			Name:      "request_latency_seconds",
+			Help:      "Time to first byte of the response to requests handled through the skupper network",
+			Buckets:   histBucketsRequest,
+		}, appFlowMetricLables),
 
 		internal: metricsInternal{
 			flowLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
@@ -100,6 +107,7 @@ func register(reg *prometheus.Registry) metrics {
 		m.flowBytesSentCounter,
 		m.flowBytesReceivedCounter,
 		m.requestsCounter,
+		m.requestLatency,
 		m.internal.legancyLatency,
 		m.internal.flowLatency,
 		m.internal.reconcileTime,
@@ -111,8 +119,9 @@ func register(reg *prometheus.Registry) metrics {
 }
 
 var (
-	histBucketsFast  = []float64{0.001, 0.002, .005, .01, .025, .05, .1, .25, .5, 1, 2.5}
-	flowMetricLabels = []string{
+	histBucketsFast    = []float64{0.001, 0.002, .005, .01, .025, .05, .1, .25, .5, 1, 2.5}
+	histBucketsRequest = []float64{0.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+	flowMetricLabels   = []string{
 		"source_site_id",
 		"dest_site_id",
 		"source_site_name",
@@ -68,14 +68,7 @@ func New(reg prometheus.Registerer) *Adaptor {
 		Help:      "Count of link connection errors across the application network",
 	}, linkErrorMetricLablels)
 
-	reg.MustRegister(
-		h.siteInfo,
-		h.routerInfo,
-		h.siteLinkInfo,
-		h.siteListenerInfo,
-		h.siteConnectorInfo,
-		h.siteLinkErrors,
-	)
+	reg.MustRegister(h.collectors()...)
 	return h
 }
 
@@ -101,6 +94,32 @@ type Adaptor struct {
 	routerSitesCache map[string]string
 }
 
+func (a *Adaptor) collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		a.siteInfo,
+		a.routerInfo,
+		a.siteLinkInfo,
+		a.siteListenerInfo,
+		a.siteConnectorInfo,
+		a.siteLinkErrors,
+	}
+}
+
+// Describe implements prometheus.Collector so that the metrics maintained by
+// the Adaptor can be gathered apart from the registry they are served from.
+func (a *Adaptor) Describe(ch chan<- *prometheus.Desc) {
+	for _, c := range a.collectors() {
+		c.Describe(ch)
+	}
+}
+
+// Collect implements prometheus.Collector
+func (a *Adaptor) Collect(ch chan<- prometheus.Metric) {
+	for _, c := range a.collectors() {
+		c.Collect(ch)
+	}
+}
+
 type counterMetricByItem struct {
 	Items   map[string]int
 	Counter prometheus.Counter
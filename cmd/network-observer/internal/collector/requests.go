@@ -0,0 +1,230 @@
+package collector
+
+import (
+	"maps"
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// requestRateWindow is the number of seconds over which the rate of
+	// completed requests is averaged.
+	requestRateWindow = 60
+	// requestLatencySamples is the number of most recent requests whose
+	// latency is kept to compute percentiles.
+	requestLatencySamples = 1024
+	// requestIdleTTL is how long the statistics of a service or process
+	// pair are kept after the last request completed for it.
+	requestIdleTTL = 30 * time.Minute
+)
+
+// RequestAggregates provides statistics about the requests handled by the
+// application network, aggregated per service and per process pair.
+type RequestAggregates interface {
+	// ByService returns the statistics of the requests sent to the service
+	// with the given routing key, whatever their application protocol.
+	ByService(routingKey string) (RequestStats, bool)
+	// ByProcessPair returns the statistics of the requests sent from the
+	// source to the destination process over the given protocol.
+	ByProcessPair(source string, dest string, protocol string) (RequestStats, bool)
+}
+
+// RequestStats summarizes the requests completed for a service or process
+// pair.
+type RequestStats struct {
+	FirstSeen time.Time
+	LastSeen  time.Time
+	Count     uint64
+	// Rate is the number of requests completed per second over the last
+	// minute.
+	Rate float64
+	// ResponseCodes counts requests by response status code, or
+	// "unknown" when the response had none.
+	ResponseCodes map[string]uint64
+	// ResponseClasses counts requests by response status class (2xx, 4xx,
+	// etc.), as derived from ResponseCodes.
+	ResponseClasses map[string]uint64
+	// Methods counts requests by (normalized) request method.
+	Methods map[string]uint64
+	// LatencyP50, LatencyP90 and LatencyP99 are percentiles of the time to
+	// first byte of the response, over the most recent requests.
+	LatencyP50 time.Duration
+	LatencyP90 time.Duration
+	LatencyP99 time.Duration
+}
+
+// requestAggregator maintains RequestStats for every service and process
+// pair requests were observed for. It is shared by all connection
+// managers.
+type requestAggregator struct {
+	mu       sync.Mutex
+	now      func() time.Time
+	services map[string]*requestAggregate
+	pairs    map[pair]*requestAggregate
+}
+
+func newRequestAggregator() *requestAggregator {
+	return &requestAggregator{
+		now:      time.Now,
+		services: make(map[string]*requestAggregate),
+		pairs:    make(map[pair]*requestAggregate),
+	}
+}
+
+// observe records a completed request. A nil requestAggregator ignores
+// observations.
+func (a *requestAggregator) observe(request RequestRecord, method string, code string, latency time.Duration) {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	now := a.now()
+	service, ok := a.services[request.RoutingKey]
+	if !ok {
+		service = newRequestAggregate(now)
+		a.services[request.RoutingKey] = service
+	}
+	service.observe(now, method, code, latency)
+
+	pkey := pair{Source: request.Source.ID, Dest: request.Dest.ID, Protocol: request.Protocol}
+	procPair, ok := a.pairs[pkey]
+	if !ok {
+		procPair = newRequestAggregate(now)
+		a.pairs[pkey] = procPair
+	}
+	procPair.observe(now, method, code, latency)
+}
+
+// expire removes the statistics of the services and process pairs that no
+// request completed for since the cutoff, and returns how many were
+// removed.
+func (a *requestAggregator) expire(cutoff time.Time) int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	var count int
+	for routingKey, agg := range a.services {
+		if agg.lastSeen.Before(cutoff) {
+			delete(a.services, routingKey)
+			count++
+		}
+	}
+	for pkey, agg := range a.pairs {
+		if agg.lastSeen.Before(cutoff) {
+			delete(a.pairs, pkey)
+			count++
+		}
+	}
+	return count
+}
+
+func (a *requestAggregator) ByService(routingKey string) (RequestStats, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	agg, ok := a.services[routingKey]
+	if !ok {
+		return RequestStats{}, false
+	}
+	return agg.stats(a.now()), true
+}
+
+func (a *requestAggregator) ByProcessPair(source string, dest string, protocol string) (RequestStats, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	agg, ok := a.pairs[pair{Source: source, Dest: dest, Protocol: protocol}]
+	if !ok {
+		return RequestStats{}, false
+	}
+	return agg.stats(a.now()), true
+}
+
+type requestAggregate struct {
+	firstSeen time.Time
+	lastSeen  time.Time
+	count     uint64
+	codes     map[string]uint64
+	methods   map[string]uint64
+
+	// latencies is a ring of the most recent request latencies
+	latencies []time.Duration
+	next      int
+
+	// completed counts requests per second for the last requestRateWindow
+	// seconds, indexed by unix time modulo the window size.
+	completed [requestRateWindow]uint64
+	seconds   [requestRateWindow]int64
+}
+
+func newRequestAggregate(now time.Time) *requestAggregate {
+	return &requestAggregate{
+		firstSeen: now,
+		codes:     make(map[string]uint64),
+		methods:   make(map[string]uint64),
+		latencies: make([]time.Duration, 0, requestLatencySamples),
+	}
+}
+
+func (r *requestAggregate) observe(now time.Time, method string, code string, latency time.Duration) {
+	r.lastSeen = now
+	r.count++
+	r.codes[code]++
+	r.methods[method]++
+
+	if len(r.latencies) < requestLatencySamples {
+		r.latencies = append(r.latencies, latency)
+	} else {
+		r.latencies[r.next] = latency
+	}
+	r.next = (r.next + 1) % requestLatencySamples
+
+	second := now.Unix()
+	slot := second % requestRateWindow
+	if r.seconds[slot] != second {
+		r.seconds[slot] = second
+		r.completed[slot] = 0
+	}
+	r.completed[slot]++
+}
+
+func (r *requestAggregate) stats(now time.Time) RequestStats {
+	out := RequestStats{
+		FirstSeen:       r.firstSeen,
+		LastSeen:        r.lastSeen,
+		Count:           r.count,
+		ResponseCodes:   maps.Clone(r.codes),
+		ResponseClasses: make(map[string]uint64),
+		Methods:         maps.Clone(r.methods),
+	}
+	for code, count := range r.codes {
+		out.ResponseClasses[normalizeHTTPResponseClass(&code)] += count
+	}
+
+	var recent uint64
+	cutoff := now.Unix() - requestRateWindow
+	for i, second := range r.seconds {
+		if second > cutoff {
+			recent += r.completed[i]
+		}
+	}
+	out.Rate = float64(recent) / requestRateWindow
+
+	if len(r.latencies) > 0 {
+		sorted := slices.Clone(r.latencies)
+		slices.Sort(sorted)
+		out.LatencyP50 = percentile(sorted, 0.50)
+		out.LatencyP90 = percentile(sorted, 0.90)
+		out.LatencyP99 = percentile(sorted, 0.99)
+	}
+	return out
+}
+
+// percentile returns the nearest-rank percentile p of sorted.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
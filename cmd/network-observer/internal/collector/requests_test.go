@@ -0,0 +1,101 @@
+package collector
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestRequestAggregator(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	agg := newRequestAggregator()
+	agg.now = func() time.Time { return now }
+
+	request := RequestRecord{
+		RoutingKey: "backend",
+		Protocol:   "http1",
+		Source:     NamedReference{ID: "proc-a"},
+		Dest:       NamedReference{ID: "proc-b"},
+	}
+	for i := 1; i <= 100; i++ {
+		code := "200"
+		if i%10 == 0 {
+			code = "503"
+		}
+		agg.observe(request, "GET", code, time.Duration(i)*time.Millisecond)
+	}
+	request.Protocol = "http2"
+	agg.observe(request, "POST", "201", time.Millisecond)
+
+	_, ok := agg.ByService("frontend")
+	assert.Assert(t, !ok)
+	_, ok = agg.ByProcessPair("proc-b", "proc-a", "http1")
+	assert.Assert(t, !ok)
+
+	service, ok := agg.ByService("backend")
+	assert.Assert(t, ok)
+	assert.Equal(t, service.Count, uint64(101))
+	assert.DeepEqual(t, service.Methods, map[string]uint64{"GET": 100, "POST": 1})
+	assert.DeepEqual(t, service.ResponseCodes, map[string]uint64{"200": 90, "201": 1, "503": 10})
+	assert.DeepEqual(t, service.ResponseClasses, map[string]uint64{"2xx": 91, "5xx": 10})
+	assert.Equal(t, service.FirstSeen, now)
+	assert.Equal(t, service.LastSeen, now)
+
+	pair, ok := agg.ByProcessPair("proc-a", "proc-b", "http1")
+	assert.Assert(t, ok)
+	assert.Equal(t, pair.Count, uint64(100))
+	assert.Equal(t, pair.LatencyP50, 50*time.Millisecond)
+	assert.Equal(t, pair.LatencyP90, 90*time.Millisecond)
+	assert.Equal(t, pair.LatencyP99, 99*time.Millisecond)
+	assert.Equal(t, pair.Rate, 100.0/requestRateWindow)
+
+	// requests older than the rate window no longer count towards the rate
+	now = now.Add(requestRateWindow * time.Second)
+	pair, _ = agg.ByProcessPair("proc-a", "proc-b", "http1")
+	assert.Equal(t, pair.Count, uint64(100))
+	assert.Equal(t, pair.Rate, 0.0)
+}
+
+func TestRequestAggregatorLatencySamples(t *testing.T) {
+	agg := newRequestAggregator()
+	request := RequestRecord{RoutingKey: "backend", Protocol: "http1"}
+	for i := 0; i < requestLatencySamples; i++ {
+		agg.observe(request, "GET", "200", time.Second)
+	}
+	// only the most recent samples are used for percentiles
+	for i := 0; i < requestLatencySamples; i++ {
+		agg.observe(request, "GET", "200", time.Millisecond)
+	}
+	stats, ok := agg.ByService("backend")
+	assert.Assert(t, ok)
+	assert.Equal(t, stats.Count, uint64(2*requestLatencySamples))
+	assert.Equal(t, stats.LatencyP99, time.Millisecond)
+}
+
+func TestRequestAggregatorExpire(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	agg := newRequestAggregator()
+	agg.now = func() time.Time { return now }
+
+	idle := RequestRecord{RoutingKey: "idle", Protocol: "http1", Source: NamedReference{ID: "proc-a"}, Dest: NamedReference{ID: "proc-b"}}
+	active := RequestRecord{RoutingKey: "active", Protocol: "http1", Source: NamedReference{ID: "proc-a"}, Dest: NamedReference{ID: "proc-c"}}
+	agg.observe(idle, "GET", "200", time.Millisecond)
+	agg.observe(active, "GET", "200", time.Millisecond)
+	now = now.Add(requestIdleTTL)
+	agg.observe(active, "GET", "unknown", time.Millisecond)
+
+	assert.Equal(t, agg.expire(now.Add(-requestIdleTTL/2)), 2)
+	_, ok := agg.ByService("idle")
+	assert.Assert(t, !ok)
+	_, ok = agg.ByProcessPair("proc-a", "proc-b", "http1")
+	assert.Assert(t, !ok)
+
+	stats, ok := agg.ByService("active")
+	assert.Assert(t, ok)
+	assert.DeepEqual(t, stats.ResponseCodes, map[string]uint64{"200": 1, "unknown": 1})
+	assert.DeepEqual(t, stats.ResponseClasses, map[string]uint64{"2xx": 1, "unknown": 1})
+	_, ok = agg.ByProcessPair("proc-a", "proc-c", "http1")
+	assert.Assert(t, ok)
+	assert.Equal(t, agg.expire(now.Add(-requestIdleTTL/2)), 0)
+}
@@ -0,0 +1,274 @@
+package collector
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/skupperproject/skupper/pkg/vanflow"
+)
+
+const (
+	// number of past events kept for subscribers resuming from a cursor
+	defaultEventStreamSize = 4096
+	// number of events buffered for each subscriber before it is dropped
+	subscriptionBufferSize = 256
+)
+
+type EventType string
+
+const (
+	EventAdd    EventType = "add"
+	EventUpdate EventType = "update"
+	EventDelete EventType = "delete"
+)
+
+// Event describes a change to a record of the collector.
+type Event struct {
+	// Cursor identifies the event in the stream. Subscribers resume from
+	// the event following it.
+	Cursor string
+	Type   EventType
+	// Record is the record after an add or update, and the deleted record
+	// after a delete.
+	Record vanflow.Record
+	// Sites are the IDs of the sites the record belongs to, if known.
+	Sites []string
+	// RoutingKey is the routing key of the record, if any.
+	RoutingKey string
+
+	seq uint64
+}
+
+// EventFilter selects the events delivered to a subscription. Empty
+// fields match all events.
+type EventFilter struct {
+	// Types are record types, as in vanflow.TypeMeta.Type.
+	Types      []string
+	Site       string
+	RoutingKey string
+}
+
+func (f EventFilter) Matches(event Event) bool {
+	if len(f.Types) > 0 {
+		typ := event.Record.GetTypeMeta().Type
+		found := false
+		for _, t := range f.Types {
+			if t == typ {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if f.Site != "" {
+		found := false
+		for _, site := range event.Sites {
+			if site == f.Site {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if f.RoutingKey != "" && f.RoutingKey != event.RoutingKey {
+		return false
+	}
+	return true
+}
+
+// EventStream broadcasts record change events to subscribers, keeping
+// the most recent ones so that subscribers can resume from a cursor
+// after reconnecting.
+type EventStream struct {
+	// epoch distinguishes the cursors of the stream from those handed
+	// out before a restart
+	epoch string
+
+	mu          sync.Mutex
+	seq         uint64
+	events      []Event
+	next        int
+	subscribers map[*Subscription]struct{}
+}
+
+func NewEventStream(size int) *EventStream {
+	if size <= 0 {
+		size = defaultEventStreamSize
+	}
+	return &EventStream{
+		epoch:       strconv.FormatInt(time.Now().UnixNano(), 36),
+		events:      make([]Event, 0, size),
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+// Publish adds an event to the stream, assigning its cursor. It never
+// blocks: subscribers not keeping up are dropped.
+func (s *EventStream) Publish(event Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.seq++
+	event.seq = s.seq
+	event.Cursor = s.cursor(s.seq)
+	if len(s.events) < cap(s.events) {
+		s.events = append(s.events, event)
+	} else {
+		s.events[s.next] = event
+		s.next = (s.next + 1) % len(s.events)
+	}
+	for sub := range s.subscribers {
+		if !sub.filter.Matches(event) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			s.drop(sub)
+		}
+	}
+}
+
+// Subscribe returns a subscription to the events matching filter. When
+// cursor is not empty, the events following it that are still kept are
+// returned to be delivered first. When they are not, the subscription
+// is reset: the subscriber missed events and should list the records
+// again, then resume from the cursor of the subscription.
+func (s *EventStream) Subscribe(filter EventFilter, cursor string) (sub *Subscription, replay []Event, reset bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sub = &Subscription{
+		stream: s,
+		filter: filter,
+		events: make(chan Event, subscriptionBufferSize),
+		cursor: s.cursor(s.seq),
+	}
+	s.subscribers[sub] = struct{}{}
+	if cursor == "" {
+		return sub, nil, false
+	}
+	seq, err := s.parseCursor(cursor)
+	if err != nil || seq > s.seq {
+		return sub, nil, true
+	}
+	oldest := s.seq - uint64(len(s.events)) + 1
+	if seq+1 < oldest {
+		return sub, nil, true
+	}
+	for i := 0; i < len(s.events); i++ {
+		event := s.events[(s.next+i)%len(s.events)]
+		if event.seq > seq && filter.Matches(event) {
+			replay = append(replay, event)
+		}
+	}
+	return sub, replay, false
+}
+
+func (s *EventStream) cursor(seq uint64) string {
+	return s.epoch + "-" + strconv.FormatUint(seq, 10)
+}
+
+func (s *EventStream) parseCursor(cursor string) (uint64, error) {
+	epoch, seq, ok := strings.Cut(cursor, "-")
+	if !ok || epoch != s.epoch {
+		return 0, fmt.Errorf("unknown cursor %q", cursor)
+	}
+	return strconv.ParseUint(seq, 10, 64)
+}
+
+func (s *EventStream) drop(sub *Subscription) {
+	if _, ok := s.subscribers[sub]; !ok {
+		return
+	}
+	delete(s.subscribers, sub)
+	close(sub.events)
+}
+
+// Subscription delivers the events of a stream matching a filter.
+type Subscription struct {
+	stream *EventStream
+	filter EventFilter
+	events chan Event
+	cursor string
+}
+
+// Cursor returns the cursor of the last event published before the
+// subscription.
+func (s *Subscription) Cursor() string {
+	return s.cursor
+}
+
+// Events returns the channel events are delivered on. It is closed when
+// the subscription is cancelled, or when the subscriber does not keep up
+// with the events, in which case it should subscribe again from the
+// cursor of the last event it received.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+func (s *Subscription) Cancel() {
+	s.stream.mu.Lock()
+	defer s.stream.mu.Unlock()
+	s.stream.drop(s)
+}
+
+// newStreamEvent returns an event for a change to record, scoped to the
+// sites and routing key it belongs to.
+func (c *Collector) newStreamEvent(typ EventType, record vanflow.Record) Event {
+	event := Event{
+		Type:   typ,
+		Record: record,
+	}
+	addSite := func(id string) {
+		if id == "" {
+			return
+		}
+		for _, site := range event.Sites {
+			if site == id {
+				return
+			}
+		}
+		event.Sites = append(event.Sites, id)
+	}
+	switch record := record.(type) {
+	case vanflow.SiteRecord:
+		addSite(record.ID)
+	case vanflow.RouterRecord:
+		addSite(dref(record.Parent))
+	case vanflow.LinkRecord:
+		addSite(c.graph.Link(record.ID).Parent().Parent().ID())
+	case vanflow.RouterAccessRecord:
+		addSite(c.graph.RouterAccess(record.ID).Parent().Parent().ID())
+	case vanflow.ListenerRecord:
+		addSite(c.graph.Listener(record.ID).Parent().Parent().ID())
+		event.RoutingKey = dref(record.Address)
+	case vanflow.ConnectorRecord:
+		addSite(c.graph.Connector(record.ID).Parent().Parent().ID())
+		event.RoutingKey = dref(record.Address)
+	case vanflow.ProcessRecord:
+		addSite(dref(record.Parent))
+	case AddressRecord:
+		event.RoutingKey = record.Name
+	case SitePairRecord:
+		addSite(record.Source)
+		addSite(record.Dest)
+	case ProcPairRecord:
+		addSite(c.graph.Process(record.Source).Parent().ID())
+		addSite(c.graph.Process(record.Dest).Parent().ID())
+	case ConnectionRecord:
+		addSite(record.SourceSite.ID)
+		addSite(record.DestSite.ID)
+		event.RoutingKey = record.RoutingKey
+	case RequestRecord:
+		addSite(record.SourceSite.ID)
+		addSite(record.DestSite.ID)
+		event.RoutingKey = record.RoutingKey
+	}
+	return event
+}
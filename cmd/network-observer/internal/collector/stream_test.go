@@ -0,0 +1,161 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+	"gotest.tools/v3/assert"
+)
+
+func TestEventFilter(t *testing.T) {
+	event := Event{
+		Type:       EventAdd,
+		Record:     vanflow.ListenerRecord{BaseRecord: vanflow.NewBase("l1")},
+		Sites:      []string{"s1"},
+		RoutingKey: "backend",
+	}
+	testcases := []struct {
+		Filter  EventFilter
+		Matches bool
+	}{
+		{Filter: EventFilter{}, Matches: true},
+		{Filter: EventFilter{Types: []string{"SiteRecord", "ListenerRecord"}}, Matches: true},
+		{Filter: EventFilter{Types: []string{"SiteRecord"}}, Matches: false},
+		{Filter: EventFilter{Site: "s1"}, Matches: true},
+		{Filter: EventFilter{Site: "s2"}, Matches: false},
+		{Filter: EventFilter{RoutingKey: "backend"}, Matches: true},
+		{Filter: EventFilter{RoutingKey: "frontend"}, Matches: false},
+		{Filter: EventFilter{Types: []string{"ListenerRecord"}, Site: "s1", RoutingKey: "backend"}, Matches: true},
+	}
+	for _, tc := range testcases {
+		assert.Equal(t, tc.Filter.Matches(event), tc.Matches, "%+v", tc.Filter)
+	}
+}
+
+func siteEvent(id string) Event {
+	return Event{Type: EventAdd, Record: vanflow.SiteRecord{BaseRecord: vanflow.NewBase(id)}, Sites: []string{id}}
+}
+
+func eventIDs(events []Event) []string {
+	var ids []string
+	for _, event := range events {
+		ids = append(ids, event.Record.Identity())
+	}
+	return ids
+}
+
+func TestEventStream(t *testing.T) {
+	stream := NewEventStream(4)
+
+	sub, replay, reset := stream.Subscribe(EventFilter{Site: "s2"}, "")
+	assert.Assert(t, !reset)
+	assert.Equal(t, len(replay), 0)
+	for _, id := range []string{"s1", "s2", "s3"} {
+		stream.Publish(siteEvent(id))
+	}
+	event := <-sub.Events()
+	assert.Equal(t, event.Record.Identity(), "s2")
+	assert.Equal(t, len(sub.Events()), 0)
+	sub.Cancel()
+	_, ok := <-sub.Events()
+	assert.Assert(t, !ok)
+	// cancelling twice is harmless
+	sub.Cancel()
+
+	// resuming from a cursor replays the events that followed it
+	sub, replay, reset = stream.Subscribe(EventFilter{}, event.Cursor)
+	assert.Assert(t, !reset)
+	assert.DeepEqual(t, eventIDs(replay), []string{"s3"})
+	assert.Equal(t, sub.Cursor(), replay[0].Cursor)
+	sub.Cancel()
+
+	// until the events following it are no longer kept
+	for _, id := range []string{"s4", "s5", "s6", "s7"} {
+		stream.Publish(siteEvent(id))
+	}
+	sub, replay, reset = stream.Subscribe(EventFilter{}, event.Cursor)
+	assert.Assert(t, reset)
+	assert.Equal(t, len(replay), 0)
+	sub.Cancel()
+
+	latest := stream.cursor(stream.seq)
+	_, replay, reset = stream.Subscribe(EventFilter{}, latest)
+	assert.Assert(t, !reset)
+	assert.Equal(t, len(replay), 0)
+
+	// cursors of other streams, e.g. before a restart, are reset
+	for _, cursor := range []string{NewEventStream(4).cursor(1), "garbage", stream.epoch + "-100"} {
+		_, _, reset = stream.Subscribe(EventFilter{}, cursor)
+		assert.Assert(t, reset, cursor)
+	}
+}
+
+func TestEventStreamDropsSlowSubscribers(t *testing.T) {
+	stream := NewEventStream(0)
+	slow, _, _ := stream.Subscribe(EventFilter{}, "")
+	filtered, _, _ := stream.Subscribe(EventFilter{Site: "other"}, "")
+	var last Event
+	for i := 0; i < subscriptionBufferSize+1; i++ {
+		stream.Publish(siteEvent("s1"))
+	}
+	for event := range slow.Events() {
+		last = event
+	}
+	assert.Equal(t, last.seq, uint64(subscriptionBufferSize))
+	select {
+	case <-filtered.Events():
+		t.Fatal("unexpected event for filtered subscription")
+	default:
+	}
+
+	// the dropped subscriber resumes from the last event it received
+	_, replay, reset := stream.Subscribe(EventFilter{}, last.Cursor)
+	assert.Assert(t, !reset)
+	assert.Equal(t, len(replay), 1)
+}
+
+func TestNewStreamEvent(t *testing.T) {
+	stor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: RecordIndexers()})
+	c := &Collector{graph: NewGraph(stor).(*graph)}
+	records := []vanflow.Record{
+		vanflow.SiteRecord{BaseRecord: vanflow.NewBase("s1")},
+		vanflow.RouterRecord{BaseRecord: vanflow.NewBase("r1"), Parent: ptrTo("s1")},
+		vanflow.ListenerRecord{BaseRecord: vanflow.NewBase("l1"), Parent: ptrTo("r1"), Address: ptrTo("backend")},
+		vanflow.ProcessRecord{BaseRecord: vanflow.NewBase("p1"), Parent: ptrTo("s1")},
+	}
+	for _, record := range records {
+		stor.Add(record, store.SourceRef{ID: "test"})
+		c.graph.Index(record)
+	}
+
+	testcases := []struct {
+		Record           vanflow.Record
+		ExpectSites      []string
+		ExpectRoutingKey string
+	}{
+		{Record: records[0], ExpectSites: []string{"s1"}},
+		{Record: records[1], ExpectSites: []string{"s1"}},
+		{Record: records[2], ExpectSites: []string{"s1"}, ExpectRoutingKey: "backend"},
+		{Record: records[3], ExpectSites: []string{"s1"}},
+		{Record: AddressRecord{ID: "a1", Name: "backend"}, ExpectRoutingKey: "backend"},
+		{Record: SitePairRecord{ID: "sp1", Source: "s1", Dest: "s2"}, ExpectSites: []string{"s1", "s2"}},
+		{
+			Record: ConnectionRecord{
+				ID:         "c1",
+				SourceSite: NamedReference{ID: "s1"},
+				DestSite:   NamedReference{ID: "s1"},
+				RoutingKey: "backend",
+			},
+			ExpectSites:      []string{"s1"},
+			ExpectRoutingKey: "backend",
+		},
+		{Record: vanflow.ListenerRecord{BaseRecord: vanflow.NewBase("l2")}},
+	}
+	for _, tc := range testcases {
+		event := c.newStreamEvent(EventUpdate, tc.Record)
+		assert.Equal(t, event.Type, EventUpdate)
+		assert.DeepEqual(t, event.Sites, tc.ExpectSites)
+		assert.Equal(t, event.RoutingKey, tc.ExpectRoutingKey)
+	}
+}
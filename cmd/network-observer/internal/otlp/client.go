@@ -0,0 +1,154 @@
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	ProtocolGRPC         = "grpc"
+	ProtocolHTTPProtobuf = "http/protobuf"
+)
+
+// client sends export requests to an OTLP receiver.
+type client interface {
+	exportTraces(ctx context.Context, req *coltracepb.ExportTraceServiceRequest) error
+	exportMetrics(ctx context.Context, req *colmetricspb.ExportMetricsServiceRequest) error
+	close() error
+}
+
+func newClient(cfg Config) (client, error) {
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid otlp endpoint %q: %w", cfg.Endpoint, err)
+	}
+	var tlsConfig *tls.Config
+	switch endpoint.Scheme {
+	case "http":
+	case "https":
+		tlsConfig = cfg.TLSConfig
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{}
+		}
+	default:
+		return nil, fmt.Errorf("invalid otlp endpoint %q: scheme must be http or https", cfg.Endpoint)
+	}
+	if endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid otlp endpoint %q: host is required", cfg.Endpoint)
+	}
+
+	switch cfg.Protocol {
+	case ProtocolGRPC, "":
+		creds := insecure.NewCredentials()
+		if tlsConfig != nil {
+			creds = credentials.NewTLS(tlsConfig)
+		}
+		conn, err := grpc.NewClient(endpoint.Host, grpc.WithTransportCredentials(creds))
+		if err != nil {
+			return nil, fmt.Errorf("could not create otlp grpc client: %w", err)
+		}
+		return &grpcClient{
+			conn:    conn,
+			traces:  coltracepb.NewTraceServiceClient(conn),
+			metrics: colmetricspb.NewMetricsServiceClient(conn),
+			headers: cfg.Headers,
+		}, nil
+	case ProtocolHTTPProtobuf:
+		return &httpClient{
+			client: &http.Client{
+				Transport: &http.Transport{TLSClientConfig: tlsConfig},
+			},
+			tracesURL:  endpoint.JoinPath("v1/traces").String(),
+			metricsURL: endpoint.JoinPath("v1/metrics").String(),
+			headers:    cfg.Headers,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown otlp protocol %q: options are %s and %s", cfg.Protocol, ProtocolGRPC, ProtocolHTTPProtobuf)
+	}
+}
+
+type grpcClient struct {
+	conn    *grpc.ClientConn
+	traces  coltracepb.TraceServiceClient
+	metrics colmetricspb.MetricsServiceClient
+	headers map[string]string
+}
+
+func (c *grpcClient) withHeaders(ctx context.Context) context.Context {
+	if len(c.headers) == 0 {
+		return ctx
+	}
+	return metadata.NewOutgoingContext(ctx, metadata.New(c.headers))
+}
+
+func (c *grpcClient) exportTraces(ctx context.Context, req *coltracepb.ExportTraceServiceRequest) error {
+	_, err := c.traces.Export(c.withHeaders(ctx), req)
+	return err
+}
+
+func (c *grpcClient) exportMetrics(ctx context.Context, req *colmetricspb.ExportMetricsServiceRequest) error {
+	_, err := c.metrics.Export(c.withHeaders(ctx), req)
+	return err
+}
+
+func (c *grpcClient) close() error {
+	return c.conn.Close()
+}
+
+type httpClient struct {
+	client     *http.Client
+	tracesURL  string
+	metricsURL string
+	headers    map[string]string
+}
+
+func (c *httpClient) exportTraces(ctx context.Context, req *coltracepb.ExportTraceServiceRequest) error {
+	return c.post(ctx, c.tracesURL, req)
+}
+
+func (c *httpClient) exportMetrics(ctx context.Context, req *colmetricspb.ExportMetricsServiceRequest) error {
+	return c.post(ctx, c.metricsURL, req)
+}
+
+func (c *httpClient) post(ctx context.Context, url string, msg proto.Message) error {
+	body, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	for key, value := range c.headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("otlp receiver at %s responded with %s", url, resp.Status)
+	}
+	return nil
+}
+
+func (c *httpClient) close() error {
+	c.client.CloseIdleConnections()
+	return nil
+}
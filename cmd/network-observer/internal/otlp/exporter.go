@@ -0,0 +1,225 @@
+// Package otlp implements the export of network observer telemetry to an
+// OpenTelemetry Protocol (OTLP) receiver. Connections and requests are
+// exported as spans, and the metrics describing the network topology as
+// OTLP metrics.
+package otlp
+
+import (
+	"context"
+	"crypto/tls"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/internal/version"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+const (
+	scopeName   = "github.com/skupperproject/skupper/cmd/network-observer"
+	serviceName = "skupper-network-observer"
+)
+
+type Config struct {
+	// Endpoint is the URL of the OTLP receiver. TLS is used when its scheme
+	// is https.
+	Endpoint string
+	// Protocol is either grpc or http/protobuf.
+	Protocol  string
+	TLSConfig *tls.Config
+	// Headers are sent along with each export request.
+	Headers map[string]string
+	// Interval between exports.
+	Interval time.Duration
+	// QueueSize is the maximum number of spans waiting to be exported.
+	// Spans are dropped when the queue is full.
+	QueueSize int
+	// Metrics gathers the metrics exported on each interval. No metrics
+	// are exported when nil.
+	Metrics prometheus.Gatherer
+}
+
+// Exporter batches connection and request spans as they are handed to it by
+// the collector, and pushes them to an OTLP receiver along with the gathered
+// metrics on each interval.
+type Exporter struct {
+	logger   *slog.Logger
+	client   client
+	metrics  prometheus.Gatherer
+	interval time.Duration
+	start    time.Time
+
+	spans   chan siteSpan
+	dropped atomic.Int64
+}
+
+var _ collector.FlowExporter = (*Exporter)(nil)
+
+func New(logger *slog.Logger, cfg Config) (*Exporter, error) {
+	client, err := newClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = 10 * time.Second
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = 8192
+	}
+	return &Exporter{
+		logger:   logger,
+		client:   client,
+		metrics:  cfg.Metrics,
+		interval: cfg.Interval,
+		start:    time.Now(),
+		spans:    make(chan siteSpan, cfg.QueueSize),
+	}, nil
+}
+
+func (e *Exporter) ExportConnection(conn collector.ConnectionRecord) {
+	for _, span := range connectionSpans(conn) {
+		e.enqueue(span)
+	}
+}
+
+func (e *Exporter) ExportRequest(request collector.RequestRecord) {
+	e.enqueue(requestSpan(request))
+}
+
+func (e *Exporter) enqueue(span siteSpan) {
+	select {
+	case e.spans <- span:
+	default:
+		e.dropped.Add(1)
+	}
+}
+
+// Run exports on each interval until ctx is cancelled, flushing the queued
+// spans before returning. Export errors are logged and do not stop the
+// exporter.
+func (e *Exporter) Run(ctx context.Context) error {
+	defer func() {
+		if err := e.client.close(); err != nil {
+			e.logger.Error("error closing otlp client", slog.Any("error", err))
+		}
+		e.logger.Info("otlp exporter shutdown complete")
+	}()
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			e.export(flushCtx)
+			return nil
+		case <-ticker.C:
+			exportCtx, cancel := context.WithTimeout(ctx, e.interval)
+			e.export(exportCtx)
+			cancel()
+		}
+	}
+}
+
+func (e *Exporter) export(ctx context.Context) {
+	if ct := e.dropped.Swap(0); ct > 0 {
+		e.logger.Error("otlp span queue full: dropped spans", slog.Int64("count", ct))
+	}
+	if req := e.tracesRequest(); req != nil {
+		if err := e.client.exportTraces(ctx, req); err != nil {
+			e.logger.Error("error exporting spans", slog.Any("error", err))
+		}
+	}
+	if req := e.metricsRequest(); req != nil {
+		if err := e.client.exportMetrics(ctx, req); err != nil {
+			e.logger.Error("error exporting metrics", slog.Any("error", err))
+		}
+	}
+}
+
+// tracesRequest drains the queued spans into a request with one resource per
+// site. Returns nil when there are no spans to export.
+func (e *Exporter) tracesRequest() *coltracepb.ExportTraceServiceRequest {
+	var (
+		req    coltracepb.ExportTraceServiceRequest
+		bySite = make(map[string]*tracepb.ScopeSpans)
+	)
+	for ct := len(e.spans); ct > 0; ct-- {
+		span := <-e.spans
+		scope, ok := bySite[span.Site.ID]
+		if !ok {
+			scope = &tracepb.ScopeSpans{Scope: instrumentationScope()}
+			bySite[span.Site.ID] = scope
+			req.ResourceSpans = append(req.ResourceSpans, &tracepb.ResourceSpans{
+				Resource:   siteResource(span.Site),
+				ScopeSpans: []*tracepb.ScopeSpans{scope},
+			})
+		}
+		scope.Spans = append(scope.Spans, span.Span)
+	}
+	if len(req.ResourceSpans) == 0 {
+		return nil
+	}
+	return &req
+}
+
+// metricsRequest gathers the metrics to export. Returns nil when there are
+// none.
+func (e *Exporter) metricsRequest() *colmetricspb.ExportMetricsServiceRequest {
+	if e.metrics == nil {
+		return nil
+	}
+	families, err := e.metrics.Gather()
+	if err != nil {
+		e.logger.Error("error gathering metrics", slog.Any("error", err))
+	}
+	metrics := toMetrics(families, e.start, time.Now())
+	if len(metrics) == 0 {
+		return nil
+	}
+	return &colmetricspb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*metricspb.ResourceMetrics{
+			{
+				Resource: &resourcepb.Resource{
+					Attributes: appendAttributes(nil,
+						stringAttribute("service.name", serviceName),
+						stringAttribute("service.version", version.Version),
+					),
+				},
+				ScopeMetrics: []*metricspb.ScopeMetrics{
+					{
+						Scope:   instrumentationScope(),
+						Metrics: metrics,
+					},
+				},
+			},
+		},
+	}
+}
+
+// siteResource describes the site spans were observed in. The site name is
+// used as the service name so that traces read as a path across sites.
+func siteResource(site collector.NamedReference) *resourcepb.Resource {
+	return &resourcepb.Resource{
+		Attributes: appendAttributes(nil,
+			stringAttribute("service.name", site.Name),
+			stringAttribute("service.namespace", "skupper"),
+			stringAttribute("skupper.site.id", site.ID),
+			stringAttribute("skupper.site.name", site.Name),
+		),
+	}
+}
+
+func instrumentationScope() *commonpb.InstrumentationScope {
+	return &commonpb.InstrumentationScope{
+		Name:    scopeName,
+		Version: version.Version,
+	}
+}
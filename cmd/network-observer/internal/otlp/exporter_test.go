@@ -0,0 +1,410 @@
+package otlp
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/poll"
+)
+
+// testCollector is an in-process OTLP receiver recording what it is sent.
+type testCollector struct {
+	coltracepb.UnimplementedTraceServiceServer
+
+	mu      sync.Mutex
+	spans   map[string]*tracepb.Span
+	sites   map[string]string
+	metrics map[string]*metricspb.Metric
+	headers map[string]string
+}
+
+func newTestCollector() *testCollector {
+	return &testCollector{
+		spans:   make(map[string]*tracepb.Span),
+		sites:   make(map[string]string),
+		metrics: make(map[string]*metricspb.Metric),
+		headers: make(map[string]string),
+	}
+}
+
+func (c *testCollector) Export(ctx context.Context, req *coltracepb.ExportTraceServiceRequest) (*coltracepb.ExportTraceServiceResponse, error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		c.recordHeaders(func(key string) string {
+			if values := md.Get(key); len(values) > 0 {
+				return values[0]
+			}
+			return ""
+		})
+	}
+	c.recordTraces(req)
+	return &coltracepb.ExportTraceServiceResponse{}, nil
+}
+
+func (c *testCollector) recordHeaders(get func(string) string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if value := get("authorization"); value != "" {
+		c.headers["authorization"] = value
+	}
+}
+
+func (c *testCollector) recordTraces(req *coltracepb.ExportTraceServiceRequest) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, rs := range req.ResourceSpans {
+		site := attribute(rs.Resource.Attributes, "skupper.site.name")
+		for _, ss := range rs.ScopeSpans {
+			for _, span := range ss.Spans {
+				key := string(span.SpanId)
+				c.spans[key] = span
+				c.sites[key] = site
+			}
+		}
+	}
+}
+
+func (c *testCollector) recordMetrics(req *colmetricspb.ExportMetricsServiceRequest) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, rm := range req.ResourceMetrics {
+		for _, sm := range rm.ScopeMetrics {
+			for _, metric := range sm.Metrics {
+				c.metrics[metric.Name] = metric
+			}
+		}
+	}
+}
+
+// metricsService adapts the collector to the metrics service, whose Export
+// method would otherwise clash with the one of the trace service.
+type metricsService struct {
+	colmetricspb.UnimplementedMetricsServiceServer
+	collector *testCollector
+}
+
+func (s metricsService) Export(ctx context.Context, req *colmetricspb.ExportMetricsServiceRequest) (*colmetricspb.ExportMetricsServiceResponse, error) {
+	s.collector.recordMetrics(req)
+	return &colmetricspb.ExportMetricsServiceResponse{}, nil
+}
+
+func (c *testCollector) serveGRPC(t *testing.T) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Assert(t, err)
+	srv := grpc.NewServer()
+	coltracepb.RegisterTraceServiceServer(srv, c)
+	colmetricspb.RegisterMetricsServiceServer(srv, metricsService{collector: c})
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+	return "http://" + lis.Addr().String()
+}
+
+func (c *testCollector) serveHTTP(t *testing.T) string {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/traces", func(w http.ResponseWriter, r *http.Request) {
+		var req coltracepb.ExportTraceServiceRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		c.recordHeaders(r.Header.Get)
+		c.recordTraces(&req)
+	})
+	mux.HandleFunc("POST /v1/metrics", func(w http.ResponseWriter, r *http.Request) {
+		var req colmetricspb.ExportMetricsServiceRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		c.recordMetrics(&req)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func decode(w http.ResponseWriter, r *http.Request, msg proto.Message) bool {
+	if r.Header.Get("Content-Type") != "application/x-protobuf" {
+		http.Error(w, "unsupported content type", http.StatusUnsupportedMediaType)
+		return false
+	}
+	body, err := io.ReadAll(r.Body)
+	if err == nil {
+		err = proto.Unmarshal(body, msg)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+func (c *testCollector) span(id []byte) (*tracepb.Span, string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	span, ok := c.spans[string(id)]
+	return span, c.sites[string(id)], ok
+}
+
+func (c *testCollector) metric(name string) (*metricspb.Metric, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	metric, ok := c.metrics[name]
+	return metric, ok
+}
+
+func attribute(attrs []*commonpb.KeyValue, key string) string {
+	for _, kv := range attrs {
+		if kv.Key == key {
+			return kv.Value.GetStringValue()
+		}
+	}
+	return ""
+}
+
+func intAttributeValue(attrs []*commonpb.KeyValue, key string) int64 {
+	for _, kv := range attrs {
+		if kv.Key == key {
+			return kv.Value.GetIntValue()
+		}
+	}
+	return -1
+}
+
+func TestExporter(t *testing.T) {
+	start := time.UnixMicro(time.Now().UnixMicro())
+	conn := collector.ConnectionRecord{
+		ID:            "tflow-01",
+		RoutingKey:    "backend",
+		Protocol:      "tcp",
+		ConnectorHost: "10.0.0.6",
+		ConnectorPort: "8080",
+		Listener:      collector.NamedReference{ID: "listener-01", Name: "backend"},
+		Connector:     collector.NamedReference{ID: "connector-01", Name: "backend"},
+		Source:        collector.NamedReference{ID: "process-01", Name: "client"},
+		Dest:          collector.NamedReference{ID: "process-02", Name: "server"},
+		SourceSite:    collector.NamedReference{ID: "site-01", Name: "west"},
+		DestSite:      collector.NamedReference{ID: "site-02", Name: "east"},
+		Flow: &vanflow.TransportBiflowRecord{
+			BaseRecord:     vanflow.NewBase("tflow-01", start, start.Add(time.Second)),
+			SourceHost:     ptrTo("10.0.0.1"),
+			SourcePort:     ptrTo("41414"),
+			Octets:         ptrTo(uint64(512)),
+			OctetsReverse:  ptrTo(uint64(1024)),
+			ErrorConnector: ptrTo("connection refused"),
+		},
+	}
+	request := collector.RequestRecord{
+		ID:          "appflow-01",
+		TransportID: "tflow-01",
+		RoutingKey:  "backend",
+		Protocol:    "http1",
+		Source:      collector.NamedReference{ID: "process-01", Name: "client"},
+		Dest:        collector.NamedReference{ID: "process-02", Name: "server"},
+		SourceSite:  collector.NamedReference{ID: "site-01", Name: "west"},
+		DestSite:    collector.NamedReference{ID: "site-02", Name: "east"},
+		Flow: &vanflow.AppBiflowRecord{
+			BaseRecord: vanflow.NewBase("appflow-01", start.Add(time.Millisecond), start.Add(2*time.Millisecond)),
+			Parent:     ptrTo("tflow-01"),
+			Protocol:   ptrTo("HTTP/1.1"),
+			Method:     ptrTo("get"),
+			Result:     ptrTo("503"),
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		protocol string
+		serve    func(c *testCollector, t *testing.T) string
+	}{
+		{
+			name:     "grpc",
+			protocol: ProtocolGRPC,
+			serve:    (*testCollector).serveGRPC,
+		}, {
+			name:     "http",
+			protocol: ProtocolHTTPProtobuf,
+			serve:    (*testCollector).serveHTTP,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			receiver := newTestCollector()
+			reg := prometheus.NewRegistry()
+			sites := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "skupper_site_info",
+				Help: "Metadata about the active sites that make up the application network",
+			}, []string{"site_id", "name"})
+			linkErrors := prometheus.NewCounterVec(prometheus.CounterOpts{
+				Name: "skupper_site_link_errors_total",
+				Help: "Count of link connection errors across the application network",
+			}, []string{"site_id"})
+			reg.MustRegister(sites, linkErrors)
+			sites.WithLabelValues("site-01", "west").Set(1)
+			linkErrors.WithLabelValues("site-01").Add(3)
+
+			exporter, err := New(slog.Default(), Config{
+				Endpoint: tc.serve(receiver, t),
+				Protocol: tc.protocol,
+				Headers:  map[string]string{"Authorization": "Bearer token"},
+				Interval: 10 * time.Millisecond,
+				Metrics:  reg,
+			})
+			assert.Assert(t, err)
+			ctx, cancel := context.WithCancel(t.Context())
+			done := make(chan error)
+			go func() {
+				done <- exporter.Run(ctx)
+			}()
+
+			exporter.ExportConnection(conn)
+			exporter.ExportRequest(request)
+
+			listenerID := spanID("tflow-01", sideListener)
+			connectorID := spanID("tflow-01", sideConnector)
+			requestID := spanID("appflow-01", sideRequest)
+			poll.WaitOn(t, func(t poll.LogT) poll.Result {
+				for _, id := range [][]byte{listenerID, connectorID, requestID} {
+					if _, _, ok := receiver.span(id); !ok {
+						return poll.Continue("waiting for spans")
+					}
+				}
+				if _, ok := receiver.metric("skupper_site_link_errors_total"); !ok {
+					return poll.Continue("waiting for metrics")
+				}
+				return poll.Success()
+			}, poll.WithDelay(10*time.Millisecond))
+			cancel()
+			assert.Assert(t, <-done)
+
+			listener, site, _ := receiver.span(listenerID)
+			assert.Equal(t, site, "west")
+			assert.Equal(t, listener.Name, "backend")
+			assert.Equal(t, listener.Kind, tracepb.Span_SPAN_KIND_SERVER)
+			assert.Equal(t, len(listener.ParentSpanId), 0)
+			assert.Equal(t, listener.StartTimeUnixNano, uint64(start.UnixNano()))
+			assert.Equal(t, listener.EndTimeUnixNano, uint64(start.Add(time.Second).UnixNano()))
+			assert.Equal(t, listener.Status.Code, tracepb.Status_STATUS_CODE_UNSET)
+			assert.Equal(t, attribute(listener.Attributes, "client.address"), "10.0.0.1")
+			assert.Equal(t, intAttributeValue(listener.Attributes, "client.port"), int64(41414))
+			assert.Equal(t, intAttributeValue(listener.Attributes, "skupper.bytes_sent"), int64(512))
+			assert.Equal(t, attribute(listener.Attributes, "skupper.process.name"), "client")
+
+			connector, site, _ := receiver.span(connectorID)
+			assert.Equal(t, site, "east")
+			assert.Equal(t, connector.Kind, tracepb.Span_SPAN_KIND_CLIENT)
+			assert.DeepEqual(t, connector.TraceId, listener.TraceId)
+			assert.DeepEqual(t, connector.ParentSpanId, listener.SpanId)
+			assert.Equal(t, connector.Status.Code, tracepb.Status_STATUS_CODE_ERROR)
+			assert.Equal(t, connector.Status.Message, "connection refused")
+			assert.Equal(t, attribute(connector.Attributes, "server.address"), "10.0.0.6")
+			assert.Equal(t, intAttributeValue(connector.Attributes, "server.port"), int64(8080))
+			assert.Equal(t, attribute(connector.Attributes, "skupper.process.name"), "server")
+
+			req, site, _ := receiver.span(requestID)
+			assert.Equal(t, site, "west")
+			assert.Equal(t, req.Name, "GET")
+			assert.DeepEqual(t, req.TraceId, listener.TraceId)
+			assert.DeepEqual(t, req.ParentSpanId, listener.SpanId)
+			assert.Equal(t, req.Status.Code, tracepb.Status_STATUS_CODE_ERROR)
+			assert.Equal(t, intAttributeValue(req.Attributes, "http.response.status_code"), int64(503))
+			assert.Equal(t, attribute(req.Attributes, "network.protocol.name"), "http")
+			assert.Equal(t, attribute(req.Attributes, "network.protocol.version"), "1.1")
+
+			siteInfo, ok := receiver.metric("skupper_site_info")
+			assert.Assert(t, ok)
+			points := siteInfo.GetGauge().GetDataPoints()
+			assert.Equal(t, len(points), 1)
+			assert.Equal(t, points[0].GetAsDouble(), 1.0)
+			assert.Equal(t, attribute(points[0].Attributes, "name"), "west")
+
+			errors, _ := receiver.metric("skupper_site_link_errors_total")
+			sum := errors.GetSum()
+			assert.Assert(t, sum.IsMonotonic)
+			assert.Equal(t, sum.AggregationTemporality, metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE)
+			assert.Equal(t, sum.DataPoints[0].GetAsDouble(), 3.0)
+			assert.Assert(t, sum.DataPoints[0].StartTimeUnixNano > 0)
+
+			receiver.mu.Lock()
+			defer receiver.mu.Unlock()
+			assert.Equal(t, receiver.headers["authorization"], "Bearer token")
+		})
+	}
+}
+
+func TestExporterQueueFull(t *testing.T) {
+	exporter, err := New(slog.Default(), Config{
+		Endpoint:  "http://127.0.0.1:4317",
+		QueueSize: 1,
+	})
+	assert.Assert(t, err)
+	exporter.ExportConnection(collector.ConnectionRecord{ID: "tflow-01"})
+	assert.Equal(t, len(exporter.spans), 1)
+	assert.Equal(t, exporter.dropped.Load(), int64(1))
+}
+
+func TestNewClient(t *testing.T) {
+	testCases := []struct {
+		name     string
+		endpoint string
+		protocol string
+		err      string
+	}{
+		{
+			name:     "grpc",
+			endpoint: "http://otel-collector:4317",
+			protocol: ProtocolGRPC,
+		}, {
+			name:     "http",
+			endpoint: "https://otel-collector:4318/otlp",
+			protocol: ProtocolHTTPProtobuf,
+		}, {
+			name:     "default protocol",
+			endpoint: "https://otel-collector:4317",
+		}, {
+			name:     "unknown scheme",
+			endpoint: "grpc://otel-collector:4317",
+			err:      `invalid otlp endpoint "grpc://otel-collector:4317": scheme must be http or https`,
+		}, {
+			name:     "missing host",
+			endpoint: "http://",
+			err:      `invalid otlp endpoint "http://": host is required`,
+		}, {
+			name:     "unknown protocol",
+			endpoint: "http://otel-collector:4317",
+			protocol: "http/json",
+			err:      `unknown otlp protocol "http/json": options are grpc and http/protobuf`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client, err := newClient(Config{Endpoint: tc.endpoint, Protocol: tc.protocol})
+			if tc.err != "" {
+				assert.Error(t, err, tc.err)
+				return
+			}
+			assert.Assert(t, err)
+			assert.Assert(t, client.close())
+		})
+	}
+}
+
+func ptrTo[T any](obj T) *T { return &obj }
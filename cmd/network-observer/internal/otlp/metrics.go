@@ -0,0 +1,54 @@
+package otlp
+
+import (
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+)
+
+// toMetrics converts gathered prometheus gauges and counters into OTLP
+// gauges and cumulative sums. Counters are reported as started at start.
+// Other metric types are skipped.
+func toMetrics(families []*dto.MetricFamily, start time.Time, now time.Time) []*metricspb.Metric {
+	var metrics []*metricspb.Metric
+	for _, family := range families {
+		metric := &metricspb.Metric{
+			Name:        family.GetName(),
+			Description: family.GetHelp(),
+		}
+		switch family.GetType() {
+		case dto.MetricType_GAUGE:
+			gauge := &metricspb.Gauge{}
+			for _, m := range family.GetMetric() {
+				gauge.DataPoints = append(gauge.DataPoints, dataPoint(m, m.GetGauge().GetValue(), time.Time{}, now))
+			}
+			metric.Data = &metricspb.Metric_Gauge{Gauge: gauge}
+		case dto.MetricType_COUNTER:
+			sum := &metricspb.Sum{
+				AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+				IsMonotonic:            true,
+			}
+			for _, m := range family.GetMetric() {
+				sum.DataPoints = append(sum.DataPoints, dataPoint(m, m.GetCounter().GetValue(), start, now))
+			}
+			metric.Data = &metricspb.Metric_Sum{Sum: sum}
+		default:
+			continue
+		}
+		metrics = append(metrics, metric)
+	}
+	return metrics
+}
+
+func dataPoint(m *dto.Metric, value float64, start time.Time, now time.Time) *metricspb.NumberDataPoint {
+	point := &metricspb.NumberDataPoint{
+		StartTimeUnixNano: unixNano(start),
+		TimeUnixNano:      unixNano(now),
+		Value:             &metricspb.NumberDataPoint_AsDouble{AsDouble: value},
+	}
+	for _, label := range m.GetLabel() {
+		point.Attributes = appendAttributes(point.Attributes, stringAttribute(label.GetName(), label.GetValue()))
+	}
+	return point
+}
@@ -0,0 +1,239 @@
+package otlp
+
+import (
+	"crypto/sha256"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+const (
+	sideListener  = "listener"
+	sideConnector = "connector"
+	sideRequest   = "request"
+)
+
+// siteSpan is a span along with the site it was observed in. Spans are
+// grouped into one OTLP resource per site.
+type siteSpan struct {
+	Site collector.NamedReference
+	Span *tracepb.Span
+}
+
+// connectionSpans converts a connection into a pair of spans sharing a trace
+// derived from the connection ID: a server span for the listener side of the
+// connection and a client span for the connector side, child of the first.
+func connectionSpans(conn collector.ConnectionRecord) []siteSpan {
+	flow, _ := conn.GetFlow()
+	start, end := spanTimes(flow.BaseRecord, conn.StartTime, conn.EndTime)
+	trace := traceID(conn.ID)
+
+	listener := &tracepb.Span{
+		TraceId:           trace,
+		SpanId:            spanID(conn.ID, sideListener),
+		Name:              conn.RoutingKey,
+		Kind:              tracepb.Span_SPAN_KIND_SERVER,
+		StartTimeUnixNano: unixNano(start),
+		EndTimeUnixNano:   unixNano(end),
+		Status:            flowStatus(flow.ErrorListener),
+	}
+	listener.Attributes = appendAttributes(listener.Attributes,
+		stringAttribute("skupper.routing_key", conn.RoutingKey),
+		stringAttribute("skupper.protocol", conn.Protocol),
+		stringAttribute("skupper.listener.name", conn.Listener.Name),
+		stringAttribute("skupper.process.name", conn.Source.Name),
+		stringAttribute("skupper.component.name", conn.SourceGroup.Name),
+		stringAttribute("skupper.router.name", conn.SourceRouter.Name),
+		stringAttribute("skupper.router.trace", deref(flow.Trace)),
+		stringAttribute("client.address", deref(flow.SourceHost)),
+		numericAttribute("client.port", deref(flow.SourcePort)),
+		intAttribute("skupper.bytes_sent", flow.Octets),
+		intAttribute("skupper.bytes_received", flow.OctetsReverse),
+		intAttribute("skupper.latency_us", flow.Latency),
+	)
+
+	connector := &tracepb.Span{
+		TraceId:           trace,
+		SpanId:            spanID(conn.ID, sideConnector),
+		ParentSpanId:      listener.SpanId,
+		Name:              conn.RoutingKey,
+		Kind:              tracepb.Span_SPAN_KIND_CLIENT,
+		StartTimeUnixNano: unixNano(start),
+		EndTimeUnixNano:   unixNano(end),
+		Status:            flowStatus(flow.ErrorConnector),
+	}
+	connector.Attributes = appendAttributes(connector.Attributes,
+		stringAttribute("skupper.routing_key", conn.RoutingKey),
+		stringAttribute("skupper.protocol", conn.Protocol),
+		stringAttribute("skupper.connector.name", conn.Connector.Name),
+		stringAttribute("skupper.process.name", conn.Dest.Name),
+		stringAttribute("skupper.component.name", conn.DestGroup.Name),
+		stringAttribute("skupper.router.name", conn.DestRouter.Name),
+		stringAttribute("server.address", conn.ConnectorHost),
+		numericAttribute("server.port", conn.ConnectorPort),
+		intAttribute("skupper.latency_us", flow.LatencyReverse),
+	)
+
+	return []siteSpan{
+		{Site: conn.SourceSite, Span: listener},
+		{Site: conn.DestSite, Span: connector},
+	}
+}
+
+// requestSpan converts a request into a server span, child of the listener
+// side span of the connection carrying it.
+func requestSpan(request collector.RequestRecord) siteSpan {
+	flow, _ := request.GetFlow()
+	start, end := spanTimes(flow.BaseRecord, request.StartTime, request.EndTime)
+	method := deref(flow.Method)
+
+	span := &tracepb.Span{
+		TraceId:           traceID(request.TransportID),
+		SpanId:            spanID(request.ID, sideRequest),
+		ParentSpanId:      spanID(request.TransportID, sideListener),
+		Name:              requestName(method),
+		Kind:              tracepb.Span_SPAN_KIND_SERVER,
+		StartTimeUnixNano: unixNano(start),
+		EndTimeUnixNano:   unixNano(end),
+		Status:            &tracepb.Status{},
+	}
+	if status, err := strconv.Atoi(deref(flow.Result)); err == nil && status >= 500 {
+		span.Status = &tracepb.Status{
+			Code:    tracepb.Status_STATUS_CODE_ERROR,
+			Message: http.StatusText(status),
+		}
+	}
+	span.Attributes = appendAttributes(span.Attributes,
+		stringAttribute("skupper.routing_key", request.RoutingKey),
+		stringAttribute("skupper.protocol", request.Protocol),
+		stringAttribute("skupper.listener.name", request.Listener.Name),
+		stringAttribute("skupper.connector.name", request.Connector.Name),
+		stringAttribute("skupper.process.name", request.Source.Name),
+		stringAttribute("skupper.destination.process.name", request.Dest.Name),
+		stringAttribute("skupper.destination.site.name", request.DestSite.Name),
+		stringAttribute("http.request.method", method),
+		numericAttribute("http.response.status_code", deref(flow.Result)),
+		stringAttribute("network.protocol.name", protocolName(deref(flow.Protocol))),
+		stringAttribute("network.protocol.version", protocolVersion(deref(flow.Protocol))),
+		intAttribute("skupper.bytes_sent", flow.Octets),
+		intAttribute("skupper.bytes_received", flow.OctetsReverse),
+		intAttribute("skupper.latency_us", flow.Latency),
+	)
+	return siteSpan{Site: request.SourceSite, Span: span}
+}
+
+// traceID derives the trace of a connection and of the requests it carries
+// from the connection ID, so that spans exported separately join the same
+// trace.
+func traceID(connectionID string) []byte {
+	sum := sha256.Sum256([]byte(connectionID))
+	return sum[:16]
+}
+
+func spanID(id string, side string) []byte {
+	sum := sha256.Sum256([]byte(id + "/" + side))
+	return sum[:8]
+}
+
+// spanTimes prefers the times reported in the flow record, falling back to
+// the ones of the reconciled record.
+func spanTimes(base vanflow.BaseRecord, start time.Time, end time.Time) (time.Time, time.Time) {
+	if base.StartTime != nil {
+		start = base.StartTime.Time
+	}
+	if base.EndTime != nil {
+		end = base.EndTime.Time
+	}
+	if end.Before(start) {
+		end = start
+	}
+	return start, end
+}
+
+func flowStatus(err *string) *tracepb.Status {
+	if err == nil || *err == "" {
+		return &tracepb.Status{}
+	}
+	return &tracepb.Status{
+		Code:    tracepb.Status_STATUS_CODE_ERROR,
+		Message: *err,
+	}
+}
+
+func requestName(method string) string {
+	if method == "" {
+		return "HTTP"
+	}
+	return strings.ToUpper(method)
+}
+
+// protocolName and protocolVersion split a protocol as reported by the
+// router, i.e. "HTTP/1.1", into its name and version.
+func protocolName(protocol string) string {
+	name, _, _ := strings.Cut(protocol, "/")
+	return strings.ToLower(name)
+}
+
+func protocolVersion(protocol string) string {
+	_, version, _ := strings.Cut(protocol, "/")
+	return version
+}
+
+func unixNano(t time.Time) uint64 {
+	if t.IsZero() {
+		return 0
+	}
+	return uint64(t.UnixNano())
+}
+
+// appendAttributes appends the attributes that are set.
+func appendAttributes(attrs []*commonpb.KeyValue, kvs ...*commonpb.KeyValue) []*commonpb.KeyValue {
+	for _, kv := range kvs {
+		if kv != nil {
+			attrs = append(attrs, kv)
+		}
+	}
+	return attrs
+}
+
+func stringAttribute(key string, value string) *commonpb.KeyValue {
+	if value == "" {
+		return nil
+	}
+	return &commonpb.KeyValue{
+		Key:   key,
+		Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}},
+	}
+}
+
+func intAttribute(key string, value *uint64) *commonpb.KeyValue {
+	if value == nil {
+		return nil
+	}
+	return &commonpb.KeyValue{
+		Key:   key,
+		Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: int64(*value)}},
+	}
+}
+
+func numericAttribute(key string, value string) *commonpb.KeyValue {
+	port, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return nil
+	}
+	return intAttribute(key, &port)
+}
+
+func deref[T any](p *T) T {
+	var t T
+	if p != nil {
+		t = *p
+	}
+	return t
+}
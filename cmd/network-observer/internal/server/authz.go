@@ -0,0 +1,63 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/auth"
+)
+
+// authorizedServer restricts the endpoints exposing individual
+// connections and requests to users with the flows role.
+type authorizedServer struct {
+	api.ServerInterface
+}
+
+func authorized(next api.ServerInterface) api.ServerInterface {
+	return authorizedServer{ServerInterface: next}
+}
+
+// allowed writes a forbidden response when the user of the request does
+// not have the required role. All requests are allowed when
+// authentication is not enabled.
+func allowed(w http.ResponseWriter, r *http.Request, required auth.Role) bool {
+	user, ok := auth.UserFrom(r.Context())
+	if !ok || user.Role.Allows(required) {
+		return true
+	}
+	encodeResponse(w, http.StatusForbidden, api.ErrorResponse{
+		Code:    "ErrForbidden",
+		Message: "the " + string(required) + " role is required",
+	})
+	return false
+}
+
+func (s authorizedServer) Applicationflows(w http.ResponseWriter, r *http.Request) {
+	if allowed(w, r, auth.RoleFlows) {
+		s.ServerInterface.Applicationflows(w, r)
+	}
+}
+
+func (s authorizedServer) Connections(w http.ResponseWriter, r *http.Request) {
+	if allowed(w, r, auth.RoleFlows) {
+		s.ServerInterface.Connections(w, r)
+	}
+}
+
+func (s authorizedServer) ConnectionsByService(w http.ResponseWriter, r *http.Request, id api.PathID) {
+	if allowed(w, r, auth.RoleFlows) {
+		s.ServerInterface.ConnectionsByService(w, r, id)
+	}
+}
+
+func (s authorizedServer) RequestsByService(w http.ResponseWriter, r *http.Request, id api.PathID) {
+	if allowed(w, r, auth.RoleFlows) {
+		s.ServerInterface.RequestsByService(w, r, id)
+	}
+}
+
+func (s authorizedServer) RequestsByProcessPair(w http.ResponseWriter, r *http.Request, id api.PathID) {
+	if allowed(w, r, auth.RoleFlows) {
+		s.ServerInterface.RequestsByProcessPair(w, r, id)
+	}
+}
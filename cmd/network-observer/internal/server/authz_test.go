@@ -0,0 +1,86 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/auth"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+	"gotest.tools/v3/assert"
+)
+
+func TestAuthorizedServer(t *testing.T) {
+	stor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: collector.RecordIndexers()})
+	handler := api.Handler(New(slog.Default(), stor, collector.NewGraph(stor), nil))
+
+	testcases := []struct {
+		Name   string
+		User   *auth.User
+		Path   string
+		Status int
+	}{
+		{
+			Name:   "unauthenticated sites",
+			Path:   "/api/v2alpha1/sites",
+			Status: http.StatusOK,
+		}, {
+			Name:   "unauthenticated connections",
+			Path:   "/api/v2alpha1/connections",
+			Status: http.StatusOK,
+		}, {
+			Name:   "topology sites",
+			User:   &auth.User{Name: "alice", Role: auth.RoleTopology},
+			Path:   "/api/v2alpha1/sites",
+			Status: http.StatusOK,
+		}, {
+			Name:   "topology connections",
+			User:   &auth.User{Name: "alice", Role: auth.RoleTopology},
+			Path:   "/api/v2alpha1/connections",
+			Status: http.StatusForbidden,
+		}, {
+			Name:   "topology applicationflows",
+			User:   &auth.User{Name: "alice", Role: auth.RoleTopology},
+			Path:   "/api/v2alpha1/applicationflows",
+			Status: http.StatusForbidden,
+		}, {
+			Name:   "topology service connections",
+			User:   &auth.User{Name: "alice", Role: auth.RoleTopology},
+			Path:   "/api/v2alpha1/services/svc/connections",
+			Status: http.StatusForbidden,
+		}, {
+			Name:   "topology service requests",
+			User:   &auth.User{Name: "alice", Role: auth.RoleTopology},
+			Path:   "/api/v2alpha1/services/svc/requests",
+			Status: http.StatusForbidden,
+		}, {
+			Name:   "topology processpair requests",
+			User:   &auth.User{Name: "alice", Role: auth.RoleTopology},
+			Path:   "/api/v2alpha1/processpairs/pp/requests",
+			Status: http.StatusForbidden,
+		}, {
+			Name:   "flows connections",
+			User:   &auth.User{Name: "bob", Role: auth.RoleFlows},
+			Path:   "/api/v2alpha1/connections",
+			Status: http.StatusOK,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tc.Path, nil)
+			if tc.User != nil {
+				req = req.WithContext(auth.WithUser(req.Context(), tc.User))
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+			assert.Equal(t, w.Code, tc.Status)
+			if tc.Status == http.StatusForbidden {
+				assert.Assert(t, w.Body.String() != "")
+				assert.Equal(t, w.Header().Get("Content-Type"), "application/json")
+			}
+		})
+	}
+}
@@ -19,7 +19,7 @@ func TestConnections(t *testing.T) {
 	stor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: collector.RecordIndexers()})
 	flowStor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: collector.RecordIndexers()})
 	graph := collector.NewGraph(stor)
-	srv, c := requireTestClient(t, New(tlog, stor, graph))
+	srv, c := requireTestClient(t, New(tlog, stor, graph, nil))
 	defer srv.Close()
 
 	van := []vanflow.Record{
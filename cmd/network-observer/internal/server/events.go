@@ -0,0 +1,359 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/auth"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/server/views"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+)
+
+const eventsKeepAliveInterval = 15 * time.Second
+
+// eventRecordTypes maps the record types accepted by the types parameter
+// of the events endpoint, named after the collections of the API, to
+// their vanflow record type.
+var eventRecordTypes = map[string]string{
+	"site":            vanflow.SiteRecord{}.GetTypeMeta().Type,
+	"router":          vanflow.RouterRecord{}.GetTypeMeta().Type,
+	"routerlink":      vanflow.LinkRecord{}.GetTypeMeta().Type,
+	"routeraccess":    vanflow.RouterAccessRecord{}.GetTypeMeta().Type,
+	"listener":        vanflow.ListenerRecord{}.GetTypeMeta().Type,
+	"connector":       vanflow.ConnectorRecord{}.GetTypeMeta().Type,
+	"process":         vanflow.ProcessRecord{}.GetTypeMeta().Type,
+	"component":       collector.ProcessGroupRecord{}.GetTypeMeta().Type,
+	"service":         collector.AddressRecord{}.GetTypeMeta().Type,
+	"sitepair":        collector.SitePairRecord{}.GetTypeMeta().Type,
+	"processpair":     collector.ProcPairRecord{}.GetTypeMeta().Type,
+	"componentpair":   collector.ProcGroupPairRecord{}.GetTypeMeta().Type,
+	"connection":      collector.ConnectionRecord{}.GetTypeMeta().Type,
+	"applicationflow": collector.RequestRecord{}.GetTypeMeta().Type,
+}
+
+// flowRecordTypes are the record types requiring the flows role.
+var flowRecordTypes = map[string]bool{
+	"connection":      true,
+	"applicationflow": true,
+}
+
+type eventMessage struct {
+	// Type is add, update or delete, or reset when events were missed
+	// since the requested cursor.
+	Type       string `json:"type"`
+	Cursor     string `json:"cursor"`
+	RecordType string `json:"recordType,omitempty"`
+	ID         string `json:"id,omitempty"`
+	Record     any    `json:"record,omitempty"`
+}
+
+// NewEventsHandler returns the handler streaming the changes to the
+// records of the collector, either as server-sent events or over a
+// WebSocket. The records are rendered as in the collections of the API.
+func NewEventsHandler(logger *slog.Logger, stream *collector.EventStream, records store.Interface, graph collector.Graph) http.Handler {
+	return &eventsHandler{
+		logger:   logger,
+		stream:   stream,
+		names:    eventRecordNames(),
+		upgrader: websocket.Upgrader{},
+
+		site:          views.NewSiteProvider(graph),
+		routerLink:    views.NewRouterLinkProvider(graph),
+		listener:      views.NewListenerProvider(graph),
+		connector:     views.NewConnectorProvider(graph),
+		process:       views.NewProcessProvider(records, graph),
+		component:     views.NewComponentProvider(records),
+		service:       views.NewServiceProvider(records, graph),
+		sitePair:      views.NewSitePairProvider(graph),
+		processPair:   views.NewProcessPairProvider(graph),
+		componentPair: views.NewComponentPairProvider(),
+		connection:    views.NewConnectionsProvider(records),
+		request:       views.NewRequestProvider(records),
+	}
+}
+
+type eventsHandler struct {
+	logger   *slog.Logger
+	stream   *collector.EventStream
+	names    map[string]string
+	upgrader websocket.Upgrader
+
+	site          func(vanflow.SiteRecord) api.SiteRecord
+	routerLink    func(vanflow.LinkRecord) (api.RouterLinkRecord, bool)
+	listener      func(vanflow.ListenerRecord) api.ListenerRecord
+	connector     func(vanflow.ConnectorRecord) api.ConnectorRecord
+	process       func(vanflow.ProcessRecord) (api.ProcessRecord, bool)
+	component     func(collector.ProcessGroupRecord) api.ComponentRecord
+	service       func(collector.AddressRecord) api.ServiceRecord
+	sitePair      func(collector.SitePairRecord) api.FlowAggregateRecord
+	processPair   func(collector.ProcPairRecord) api.FlowAggregateRecord
+	componentPair func(collector.ProcGroupPairRecord) api.FlowAggregateRecord
+	connection    func(collector.ConnectionRecord) (api.ConnectionRecord, bool)
+	request       func(collector.RequestRecord) (api.ApplicationFlowRecord, bool)
+}
+
+func eventRecordNames() map[string]string {
+	names := make(map[string]string, len(eventRecordTypes))
+	for name, typ := range eventRecordTypes {
+		names[typ] = name
+	}
+	return names
+}
+
+func (h *eventsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	filter, err := h.filter(r)
+	if err == errFlowsRoleRequired {
+		encodeResponse(w, http.StatusForbidden, api.ErrorResponse{
+			Code:    "ErrForbidden",
+			Message: err.Error(),
+		})
+		return
+	} else if err != nil {
+		encodeResponse(w, http.StatusBadRequest, api.ErrorBadRequest{
+			Message: err.Error(),
+		})
+		return
+	}
+	cursor := r.URL.Query().Get("cursor")
+	if cursor == "" {
+		cursor = r.Header.Get("Last-Event-ID")
+	}
+	if websocket.IsWebSocketUpgrade(r) {
+		h.serveWebSocket(w, r, filter, cursor)
+		return
+	}
+	h.serveEventStream(w, r, filter, cursor)
+}
+
+var errFlowsRoleRequired = fmt.Errorf("the %s role is required for connection and applicationflow events", auth.RoleFlows)
+
+// filter returns the events requested with the types, site and
+// routingKey parameters. Users without the flows role only get the
+// events of topology records.
+func (h *eventsHandler) filter(r *http.Request) (collector.EventFilter, error) {
+	query := r.URL.Query()
+	filter := collector.EventFilter{
+		Site:       query.Get("site"),
+		RoutingKey: query.Get("routingKey"),
+	}
+	flowsAllowed := true
+	if user, ok := auth.UserFrom(r.Context()); ok {
+		flowsAllowed = user.Role.Allows(auth.RoleFlows)
+	}
+	var names []string
+	for _, value := range query["types"] {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+	if len(names) == 0 {
+		for name := range eventRecordTypes {
+			if flowsAllowed || !flowRecordTypes[name] {
+				filter.Types = append(filter.Types, eventRecordTypes[name])
+			}
+		}
+		return filter, nil
+	}
+	for _, name := range names {
+		typ, ok := eventRecordTypes[name]
+		if !ok {
+			return filter, fmt.Errorf("unknown record type %q", name)
+		}
+		if !flowsAllowed && flowRecordTypes[name] {
+			return filter, errFlowsRoleRequired
+		}
+		filter.Types = append(filter.Types, typ)
+	}
+	return filter, nil
+}
+
+func (h *eventsHandler) serveEventStream(w http.ResponseWriter, r *http.Request, filter collector.EventFilter, cursor string) {
+	logger := requestLogger(h.logger, r)
+	rc := http.NewResponseController(w)
+	// the stream outlives the write timeout of the server
+	rc.SetWriteDeadline(time.Time{})
+
+	sub, replay, reset := h.stream.Subscribe(filter, cursor)
+	defer sub.Cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	write := func(message eventMessage) error {
+		data, err := json.Marshal(message)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "id: %s\ndata: %s\n\n", message.Cursor, data)
+		return err
+	}
+	if reset {
+		if err := write(eventMessage{Type: "reset", Cursor: sub.Cursor()}); err != nil {
+			return
+		}
+	}
+	for _, event := range replay {
+		if err := write(h.message(event)); err != nil {
+			return
+		}
+	}
+	if err := rc.Flush(); err != nil {
+		logger.Debug("events stream does not support flushing", slog.Any("error", err))
+		return
+	}
+
+	keepAlive := time.NewTicker(eventsKeepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-sub.Events():
+			if !ok {
+				// dropped for falling behind, the client reconnects from
+				// the last event it received
+				return
+			}
+			if err := write(h.message(event)); err != nil {
+				return
+			}
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func (h *eventsHandler) serveWebSocket(w http.ResponseWriter, r *http.Request, filter collector.EventFilter, cursor string) {
+	logger := requestLogger(h.logger, r)
+	// subscribed first so that no event is missed once connected
+	sub, replay, reset := h.stream.Subscribe(filter, cursor)
+	defer sub.Cancel()
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		logger.Debug("websocket upgrade failed", slog.Any("error", err))
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	go func() {
+		// the client is not expected to send messages: read until the
+		// connection is closed to handle control frames
+		defer cancel()
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	write := func(message eventMessage) error {
+		conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+		return conn.WriteJSON(message)
+	}
+	if reset {
+		if err := write(eventMessage{Type: "reset", Cursor: sub.Cursor()}); err != nil {
+			return
+		}
+	}
+	for _, event := range replay {
+		if err := write(h.message(event)); err != nil {
+			return
+		}
+	}
+
+	keepAlive := time.NewTicker(eventsKeepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-sub.Events():
+			if !ok {
+				conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "events dropped, resume from the last cursor"),
+					time.Now().Add(time.Second))
+				return
+			}
+			if err := write(h.message(event)); err != nil {
+				return
+			}
+		case <-keepAlive.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(10*time.Second)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (h *eventsHandler) message(event collector.Event) eventMessage {
+	return eventMessage{
+		Type:       string(event.Type),
+		Cursor:     event.Cursor,
+		RecordType: h.names[event.Record.GetTypeMeta().Type],
+		ID:         event.Record.Identity(),
+		Record:     h.render(event.Record),
+	}
+}
+
+// render returns the API representation of a record. Records that can
+// not be fully resolved, e.g. once deleted, are rendered with the
+// defaults of the API.
+func (h *eventsHandler) render(record vanflow.Record) any {
+	switch record := record.(type) {
+	case vanflow.SiteRecord:
+		return h.site(record)
+	case vanflow.RouterRecord:
+		return views.Router(record)
+	case vanflow.LinkRecord:
+		out, _ := h.routerLink(record)
+		return out
+	case vanflow.RouterAccessRecord:
+		return views.RouterAccess(record)
+	case vanflow.ListenerRecord:
+		return h.listener(record)
+	case vanflow.ConnectorRecord:
+		return h.connector(record)
+	case vanflow.ProcessRecord:
+		out, _ := h.process(record)
+		return out
+	case collector.ProcessGroupRecord:
+		return h.component(record)
+	case collector.AddressRecord:
+		return h.service(record)
+	case collector.SitePairRecord:
+		return h.sitePair(record)
+	case collector.ProcPairRecord:
+		return h.processPair(record)
+	case collector.ProcGroupPairRecord:
+		return h.componentPair(record)
+	case collector.ConnectionRecord:
+		out, _ := h.connection(record)
+		return out
+	case collector.RequestRecord:
+		out, _ := h.request(record)
+		return out
+	}
+	return nil
+}
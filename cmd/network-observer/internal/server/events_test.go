@@ -0,0 +1,198 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/auth"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+	"gotest.tools/v3/assert"
+)
+
+func newTestEventsServer(t *testing.T, user *auth.User) (*httptest.Server, *collector.EventStream) {
+	t.Helper()
+	stor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: collector.RecordIndexers()})
+	stream := collector.NewEventStream(16)
+	handler := NewEventsHandler(slog.Default(), stream, stor, collector.NewGraph(stor))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user != nil {
+			r = r.WithContext(auth.WithUser(r.Context(), user))
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, stream
+}
+
+type sseReader struct {
+	scanner *bufio.Scanner
+}
+
+// next returns the id and decoded data of the next event of the stream.
+func (s sseReader) next(t *testing.T) (string, eventMessage) {
+	t.Helper()
+	var (
+		id      string
+		message eventMessage
+	)
+	for s.scanner.Scan() {
+		line := s.scanner.Text()
+		switch {
+		case line == "":
+			return id, message
+		case strings.HasPrefix(line, "id: "):
+			id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "data: "):
+			assert.Assert(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &message))
+		}
+	}
+	t.Fatalf("event stream ended: %v", s.scanner.Err())
+	return id, message
+}
+
+func openEventStream(t *testing.T, ctx context.Context, url string, lastEventID string) (*http.Response, sseReader) {
+	t.Helper()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	assert.Assert(t, err)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	assert.Assert(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp, sseReader{scanner: bufio.NewScanner(resp.Body)}
+}
+
+func TestEventsServerSentEvents(t *testing.T) {
+	srv, stream := newTestEventsServer(t, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, events := openEventStream(t, ctx, srv.URL+"?types=site,listener&routingKey=backend", "")
+	assert.Equal(t, resp.StatusCode, http.StatusOK)
+	assert.Equal(t, resp.Header.Get("Content-Type"), "text/event-stream")
+
+	// the handler subscribes before responding, no event is missed
+	stream.Publish(collector.Event{Type: collector.EventUpdate, Record: vanflow.ProcessRecord{BaseRecord: vanflow.NewBase("p1")}, RoutingKey: "backend"})
+	stream.Publish(collector.Event{Type: collector.EventUpdate, Record: vanflow.ListenerRecord{BaseRecord: vanflow.NewBase("l2")}, RoutingKey: "other"})
+	stream.Publish(collector.Event{Type: collector.EventUpdate, Record: vanflow.ListenerRecord{BaseRecord: vanflow.NewBase("l1"), Name: ptrTo("frontdoor")}, RoutingKey: "backend"})
+	lastID, message := events.next(t)
+	assert.Equal(t, message.Type, "update")
+	assert.Equal(t, message.RecordType, "listener")
+	assert.Equal(t, message.ID, "l1")
+	assert.Equal(t, message.Cursor, lastID)
+	record := message.Record.(map[string]interface{})
+	assert.Equal(t, record["identity"], "l1")
+	assert.Equal(t, record["name"], "frontdoor")
+
+	stream.Publish(collector.Event{Type: collector.EventDelete, Record: vanflow.ListenerRecord{BaseRecord: vanflow.NewBase("l3")}, RoutingKey: "backend"})
+	_, message = events.next(t)
+	assert.Equal(t, message.Type, "delete")
+	assert.Equal(t, message.ID, "l3")
+
+	// reconnecting with the last event id replays the events that followed
+	_, events = openEventStream(t, ctx, srv.URL+"?types=listener&routingKey=backend", lastID)
+	_, message = events.next(t)
+	assert.Equal(t, message.Type, "delete")
+	assert.Equal(t, message.ID, "l3")
+
+	// unless they are no longer kept
+	for i := 0; i < 20; i++ {
+		stream.Publish(collector.Event{Type: collector.EventAdd, Record: vanflow.SiteRecord{BaseRecord: vanflow.NewBase("s1")}})
+	}
+	_, events = openEventStream(t, ctx, srv.URL, lastID)
+	id, message := events.next(t)
+	assert.Equal(t, message.Type, "reset")
+	assert.Equal(t, message.Cursor, id)
+	assert.Assert(t, id != lastID)
+}
+
+func TestEventsWebSocket(t *testing.T) {
+	srv, stream := newTestEventsServer(t, nil)
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "?types=site&site=s1"
+	conn, resp, err := websocket.DefaultDialer.Dial(url, nil)
+	assert.Assert(t, err)
+	defer conn.Close()
+	assert.Equal(t, resp.StatusCode, http.StatusSwitchingProtocols)
+
+	stream.Publish(collector.Event{Type: collector.EventAdd, Record: vanflow.SiteRecord{BaseRecord: vanflow.NewBase("s2")}, Sites: []string{"s2"}})
+	stream.Publish(collector.Event{Type: collector.EventAdd, Record: vanflow.SiteRecord{BaseRecord: vanflow.NewBase("s1"), Name: ptrTo("west")}, Sites: []string{"s1"}})
+	var message eventMessage
+	conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+	assert.Assert(t, conn.ReadJSON(&message))
+	assert.Equal(t, message.Type, "add")
+	assert.Equal(t, message.ID, "s1")
+	assert.Equal(t, message.RecordType, "site")
+	assert.Equal(t, message.Record.(map[string]interface{})["name"], "west")
+}
+
+func TestEventsFilter(t *testing.T) {
+	testcases := []struct {
+		Name         string
+		User         *auth.User
+		Query        string
+		ExpectStatus int
+		ExpectTypes  []string
+	}{
+		{
+			Name:         "unknown type",
+			Query:        "?types=site,bogus",
+			ExpectStatus: http.StatusBadRequest,
+		}, {
+			Name:         "topology user requesting connections",
+			User:         &auth.User{Name: "alice", Role: auth.RoleTopology},
+			Query:        "?types=site&types=connection",
+			ExpectStatus: http.StatusForbidden,
+		}, {
+			Name:        "topology user",
+			User:        &auth.User{Name: "alice", Role: auth.RoleTopology},
+			ExpectTypes: []string{"SiteRecord", "ListenerRecord"},
+		}, {
+			Name:        "flows user",
+			User:        &auth.User{Name: "bob", Role: auth.RoleFlows},
+			ExpectTypes: []string{"SiteRecord", "ConnectionRecord", "RequestRecord"},
+		}, {
+			Name:        "requested types",
+			Query:       "?types=Site,%20connection",
+			ExpectTypes: []string{"SiteRecord", "ConnectionRecord"},
+		},
+	}
+	h := &eventsHandler{}
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v2alpha1/events"+tc.Query, nil)
+			if tc.User != nil {
+				req = req.WithContext(auth.WithUser(req.Context(), tc.User))
+			}
+			if tc.ExpectStatus != 0 {
+				w := httptest.NewRecorder()
+				h.ServeHTTP(w, req)
+				assert.Equal(t, w.Code, tc.ExpectStatus)
+				return
+			}
+			filter, err := h.filter(req)
+			assert.Assert(t, err)
+			types := map[string]bool{}
+			for _, typ := range filter.Types {
+				types[typ] = true
+			}
+			for _, typ := range tc.ExpectTypes {
+				assert.Assert(t, types[typ], typ)
+			}
+			if tc.User != nil && tc.User.Role == auth.RoleTopology {
+				assert.Assert(t, !types["ConnectionRecord"])
+				assert.Assert(t, !types["RequestRecord"])
+			}
+		})
+	}
+}
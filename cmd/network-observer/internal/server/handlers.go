@@ -14,7 +14,7 @@ var _ api.ServerInterface = (*server)(nil)
 
 // (GET /api/v2alpha1/connections)
 func (s *server) Connections(w http.ResponseWriter, r *http.Request) {
-	results := views.NewConnectionsSliceProvider(s.records)(listByType[collector.ConnectionRecord](s.records))
+	results := views.NewConnectionsSliceProvider(s.records)(indexInTimeRange(s.records, r, store.TypeIndex, store.Entry{Record: collector.ConnectionRecord{}}))
 	if err := handleCollection(w, r, &api.ConnectionListResponse{}, results); err != nil {
 		s.logWriteError(r, err)
 	}
@@ -26,14 +26,14 @@ func (s *server) ConnectionsByService(w http.ResponseWriter, r *http.Request, id
 		return store.Entry{Record: collector.ConnectionRecord{RoutingKey: a.Name, Protocol: a.Protocol}}
 	}, id)
 	if err := handleSubCollection(w, r, &api.ConnectionListResponse{}, getExemplar, func(exemplar store.Entry) []api.ConnectionRecord {
-		return views.NewConnectionsSliceProvider(s.records)(index(s.records, collector.IndexFlowByAddress, exemplar))
+		return views.NewConnectionsSliceProvider(s.records)(indexInTimeRange(s.records, r, collector.IndexFlowByAddress, exemplar))
 	}); err != nil {
 		s.logWriteError(r, err)
 	}
 }
 
 func (s *server) Applicationflows(w http.ResponseWriter, r *http.Request) {
-	results := views.NewRequestSliceProvider(s.records)(listByType[collector.RequestRecord](s.records))
+	results := views.NewRequestSliceProvider(s.records)(indexInTimeRange(s.records, r, store.TypeIndex, store.Entry{Record: collector.RequestRecord{}}))
 	if err := handleCollection(w, r, &api.ApplicationFlowResponse{}, results); err != nil {
 		s.logWriteError(r, err)
 	}
@@ -55,6 +55,20 @@ func (s *server) ServiceByID(w http.ResponseWriter, r *http.Request, id string)
 	}
 }
 
+// (GET /api/v2alpha1/services/{id}/requests)
+func (s *server) RequestsByService(w http.ResponseWriter, r *http.Request, id string) {
+	getRecord := fetchAndMap(s.records, func(addr collector.AddressRecord) api.RequestAggregateRecord {
+		var stats collector.RequestStats
+		if s.requests != nil {
+			stats, _ = s.requests.ByService(addr.Name)
+		}
+		return views.NewRequestAggregate(addr.ID, addr.Protocol, stats)
+	}, id)
+	if err := handleSingle(w, r, &api.RequestAggregateResponse{}, getRecord); err != nil {
+		s.logWriteError(r, err)
+	}
+}
+
 // (GET /api/v2alpha1/services/{id}/processes)
 func (s *server) ProcessesByService(w http.ResponseWriter, r *http.Request, id string) {
 	//todo(ck) find a way to more directly index this
@@ -236,6 +250,20 @@ func (s *server) ProcesspairByID(w http.ResponseWriter, r *http.Request, id stri
 	}
 }
 
+// (GET /api/v2alpha1/processpairs/{id}/requests)
+func (s *server) RequestsByProcessPair(w http.ResponseWriter, r *http.Request, id string) {
+	getRecord := fetchAndMap(s.records, func(procPair collector.ProcPairRecord) api.RequestAggregateRecord {
+		var stats collector.RequestStats
+		if s.requests != nil {
+			stats, _ = s.requests.ByProcessPair(procPair.Source, procPair.Dest, procPair.Protocol)
+		}
+		return views.NewRequestAggregate(procPair.ID, procPair.Protocol, stats)
+	}, id)
+	if err := handleSingle(w, r, &api.RequestAggregateResponse{}, getRecord); err != nil {
+		s.logWriteError(r, err)
+	}
+}
+
 // (GET /api/v2alpha1/routeraccess)
 func (s *server) Routeraccess(w http.ResponseWriter, r *http.Request) {
 	results := views.RouterAccessList(listByType[vanflow.RouterAccessRecord](s.records))
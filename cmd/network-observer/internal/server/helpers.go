@@ -1,8 +1,10 @@
 package server
 
 import (
+	"net/http"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/skupperproject/skupper/pkg/vanflow"
 	"github.com/skupperproject/skupper/pkg/vanflow/store"
@@ -47,6 +49,30 @@ func index(stor store.Interface, index string, exemplar store.Entry) []store.Ent
 	return ordered(stor.Index(index, exemplar))
 }
 
+// sinceIndexer is implemented by stores able to skip the archived records
+// last updated before a point in time, such as the records of a collector
+// keeping a flow history.
+type sinceIndexer interface {
+	IndexSince(index string, exemplar store.Entry, since time.Time) []store.Entry
+}
+
+// indexInTimeRange is like index, but does not read the archived records
+// that terminated before the time range of the request. Records are
+// archived once terminated, so those last updated before the start of
+// the range cannot match it.
+func indexInTimeRange(stor store.Interface, r *http.Request, idx string, exemplar store.Entry) []store.Entry {
+	ranged, ok := stor.(sinceIndexer)
+	if !ok {
+		return index(stor, idx, exemplar)
+	}
+	qp := getQueryParams(r)
+	since := time.UnixMicro(int64(qp.TimeRangeStart))
+	if qp.State == active {
+		since = time.Now()
+	}
+	return ordered(ranged.IndexSince(idx, exemplar, since))
+}
+
 func ordered(entries []store.Entry) []store.Entry {
 	sort.Slice(entries, func(i, j int) bool {
 		return strings.Compare(entries[i].Record.Identity(), entries[j].Record.Identity()) < 0
@@ -16,7 +16,7 @@ func TestRouterlinks(t *testing.T) {
 	tlog := slog.Default()
 	stor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: collector.RecordIndexers()})
 	graph := collector.NewGraph(stor)
-	srv, c := requireTestClient(t, New(tlog, stor, graph))
+	srv, c := requireTestClient(t, New(tlog, stor, graph, nil))
 	defer srv.Close()
 
 	van := []vanflow.Record{
@@ -0,0 +1,103 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+	"gotest.tools/v3/assert"
+)
+
+type fakeRequestAggregates struct {
+	services map[string]collector.RequestStats
+	pairs    map[string]collector.RequestStats
+}
+
+func (f fakeRequestAggregates) ByService(routingKey string) (collector.RequestStats, bool) {
+	stats, ok := f.services[routingKey]
+	return stats, ok
+}
+
+func (f fakeRequestAggregates) ByProcessPair(source string, dest string, protocol string) (collector.RequestStats, bool) {
+	stats, ok := f.pairs[source+"/"+dest+"/"+protocol]
+	return stats, ok
+}
+
+func TestRequestAggregates(t *testing.T) {
+	tlog := slog.Default()
+	stor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: collector.RecordIndexers()})
+	graph := collector.NewGraph(stor)
+	start := time.UnixMicro(1_700_000_000_000_000)
+	stats := collector.RequestStats{
+		FirstSeen:       start,
+		LastSeen:        start.Add(time.Minute),
+		Count:           12,
+		Rate:            0.2,
+		ResponseCodes:   map[string]uint64{"503": 2, "200": 9, "204": 1},
+		ResponseClasses: map[string]uint64{"5xx": 2, "2xx": 10},
+		Methods:         map[string]uint64{"POST": 4, "GET": 8},
+		LatencyP50:      2 * time.Millisecond,
+		LatencyP90:      10 * time.Millisecond,
+		LatencyP99:      time.Second,
+	}
+	requests := fakeRequestAggregates{
+		services: map[string]collector.RequestStats{"backend": stats},
+		pairs:    map[string]collector.RequestStats{"proc-a/proc-b/http1": stats},
+	}
+	srv, c := requireTestClient(t, New(tlog, stor, graph, requests))
+	defer srv.Close()
+
+	stor.Replace(wrapRecords(
+		collector.AddressRecord{ID: "address1", Name: "backend", Protocol: "tcp"},
+		collector.AddressRecord{ID: "address2", Name: "idle", Protocol: "tcp"},
+		collector.ProcPairRecord{ID: "pair1", Source: "proc-a", Dest: "proc-b", Protocol: "http1"},
+	))
+	expected := api.RequestAggregateRecord{
+		Identity:        "address1",
+		Protocol:        "tcp",
+		StartTime:       uint64(start.UnixMicro()),
+		RequestCount:    12,
+		RequestRate:     0.2,
+		ResponseClasses: []api.RequestCount{{Value: "2xx", Count: 10}, {Value: "5xx", Count: 2}},
+		ResponseCodes:   []api.RequestCount{{Value: "200", Count: 9}, {Value: "204", Count: 1}, {Value: "503", Count: 2}},
+		Methods:         []api.RequestCount{{Value: "GET", Count: 8}, {Value: "POST", Count: 4}},
+		LatencyP50:      2000,
+		LatencyP90:      10000,
+		LatencyP99:      1000000,
+	}
+
+	t.Run("service", func(t *testing.T) {
+		resp, err := c.RequestsByServiceWithResponse(context.TODO(), "address1")
+		assert.Assert(t, err)
+		assert.Equal(t, resp.StatusCode(), 200)
+		assert.DeepEqual(t, resp.JSON200.Results, expected)
+	})
+	t.Run("service without requests", func(t *testing.T) {
+		resp, err := c.RequestsByServiceWithResponse(context.TODO(), "address2")
+		assert.Assert(t, err)
+		assert.Equal(t, resp.StatusCode(), 200)
+		assert.Equal(t, resp.JSON200.Results.RequestCount, uint64(0))
+		assert.Equal(t, len(resp.JSON200.Results.Methods), 0)
+	})
+	t.Run("process pair", func(t *testing.T) {
+		resp, err := c.RequestsByProcessPairWithResponse(context.TODO(), "pair1")
+		assert.Assert(t, err)
+		assert.Equal(t, resp.StatusCode(), 200)
+		pairExpected := expected
+		pairExpected.Identity = "pair1"
+		pairExpected.Protocol = "http1"
+		assert.DeepEqual(t, resp.JSON200.Results, pairExpected)
+	})
+	t.Run("not found", func(t *testing.T) {
+		resp, err := c.RequestsByServiceWithResponse(context.TODO(), "pair1")
+		assert.Assert(t, err)
+		assert.Check(t, resp.JSON404 != nil)
+		presp, err := c.RequestsByProcessPairWithResponse(context.TODO(), "dne")
+		assert.Assert(t, err)
+		assert.Check(t, presp.JSON404 != nil)
+	})
+}
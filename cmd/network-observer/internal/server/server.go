@@ -12,18 +12,20 @@ import (
 	"github.com/skupperproject/skupper/pkg/vanflow/store"
 )
 
-func New(logger *slog.Logger, records store.Interface, graph collector.Graph) api.ServerInterface {
-	return &server{
-		logger:  logger,
-		records: records,
-		graph:   graph,
-	}
+func New(logger *slog.Logger, records store.Interface, graph collector.Graph, requests collector.RequestAggregates) api.ServerInterface {
+	return authorized(&server{
+		logger:   logger,
+		records:  records,
+		graph:    graph,
+		requests: requests,
+	})
 }
 
 type server struct {
-	logger  *slog.Logger
-	records store.Interface
-	graph   collector.Graph
+	logger   *slog.Logger
+	records  store.Interface
+	graph    collector.Graph
+	requests collector.RequestAggregates
 }
 
 func (c *server) logWriteError(r *http.Request, err error) {
@@ -17,7 +17,7 @@ func TestSites(t *testing.T) {
 	tlog := slog.Default()
 	stor := store.NewSyncMapStore(store.SyncMapStoreConfig{})
 	graph := collector.NewGraph(stor)
-	srv, c := requireTestClient(t, New(tlog, stor, graph))
+	srv, c := requireTestClient(t, New(tlog, stor, graph, nil))
 	defer srv.Close()
 
 	testcases := []collectionTestCase[api.SiteRecord]{
@@ -96,7 +96,7 @@ func TestSiteByID(t *testing.T) {
 	tlog := slog.Default()
 	stor := store.NewSyncMapStore(store.SyncMapStoreConfig{})
 	graph := collector.NewGraph(stor)
-	srv, c := requireTestClient(t, New(tlog, stor, graph))
+	srv, c := requireTestClient(t, New(tlog, stor, graph, nil))
 	defer srv.Close()
 
 	testcases := []struct {
@@ -1,6 +1,7 @@
 package views
 
 import (
+	"sort"
 	"strings"
 	"time"
 
@@ -236,3 +237,35 @@ func defaultRequest(id string) api.ApplicationFlowRecord {
 		Identity: id,
 	}
 }
+
+// NewRequestAggregate maps the request statistics of the service or process
+// pair with the given id to an api.RequestAggregateRecord.
+func NewRequestAggregate(id string, protocol string, stats collector.RequestStats) api.RequestAggregateRecord {
+	out := api.RequestAggregateRecord{
+		Identity:        id,
+		Protocol:        protocol,
+		RequestCount:    stats.Count,
+		RequestRate:     stats.Rate,
+		ResponseClasses: requestCounts(stats.ResponseClasses),
+		ResponseCodes:   requestCounts(stats.ResponseCodes),
+		Methods:         requestCounts(stats.Methods),
+		LatencyP50:      uint64(stats.LatencyP50.Microseconds()),
+		LatencyP90:      uint64(stats.LatencyP90.Microseconds()),
+		LatencyP99:      uint64(stats.LatencyP99.Microseconds()),
+	}
+	if !stats.FirstSeen.IsZero() {
+		out.StartTime = uint64(stats.FirstSeen.UnixMicro())
+	}
+	return out
+}
+
+func requestCounts(counts map[string]uint64) []api.RequestCount {
+	out := make([]api.RequestCount, 0, len(counts))
+	for value, count := range counts {
+		out = append(out, api.RequestCount{Value: value, Count: count})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Value < out[j].Value
+	})
+	return out
+}
@@ -18,9 +18,11 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/auth"
 	"github.com/skupperproject/skupper/cmd/network-observer/internal/cmd"
 	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
 	"github.com/skupperproject/skupper/cmd/network-observer/internal/flowlog"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/otlp"
 	"github.com/skupperproject/skupper/cmd/network-observer/internal/server"
 	"github.com/skupperproject/skupper/internal/version"
 	"github.com/skupperproject/skupper/pkg/vanflow"
@@ -46,6 +48,17 @@ func run(cfg Config) error {
 	if err != nil {
 		return fmt.Errorf("failed to load router tls configuration: %s", err)
 	}
+	if cfg.VanflowCapture != "" {
+		return runCapture(ctx, logger, cfg, sessionConfig, cfg.VanflowCapture)
+	}
+	containerFactory := session.NewContainerFactory(cfg.RouterURL, sessionConfig)
+	var replay func(ctx context.Context) error
+	if cfg.VanflowReplay != "" {
+		containerFactory, replay, err = newReplay(logger.With(slog.String("component", "vanflow.replay")), cfg.VanflowReplay, cfg.VanflowReplaySpeed)
+		if err != nil {
+			return fmt.Errorf("could not replay vanflow capture: %s", err)
+		}
+	}
 
 	flowLogger := func(vanflow.RecordMessage) {}
 	vanflowSLog := logger.With(slog.String("component", "vanflow"))
@@ -61,31 +74,109 @@ func run(cfg Config) error {
 		return fmt.Errorf("unknown logging profile: %s", cfg.VanflowLoggingProfile)
 	}
 
+	var history collector.FlowHistory
+	switch cfg.FlowStore {
+	case "memory":
+	case "bolt":
+		history, err = collector.NewFlowHistory(cfg.FlowStorePath, cfg.FlowStoreRetention, cfg.FlowStoreMaxRecords)
+		if err != nil {
+			return fmt.Errorf("could not open flow store: %s", err)
+		}
+		defer history.Close()
+		logger.Info("Archiving flow records",
+			slog.String("path", cfg.FlowStorePath),
+			slog.Duration("retention", cfg.FlowStoreRetention),
+			slog.Int("max_records", cfg.FlowStoreMaxRecords))
+	default:
+		return fmt.Errorf("unknown flow store: %s", cfg.FlowStore)
+	}
+
+	var (
+		exporter     *otlp.Exporter
+		flowExporter collector.FlowExporter
+		topology     = prometheus.NewRegistry()
+	)
+	if cfg.OTLPEndpoint != "" {
+		headers, err := parseOTLPHeaders(cfg.OTLPHeaders)
+		if err != nil {
+			return fmt.Errorf("error parsing otlp-headers: %s", err)
+		}
+		tlsConfig, err := cfg.OTLPTLS.config()
+		if err != nil {
+			return fmt.Errorf("could not set up certs for otlp exporter: %s", err)
+		}
+		exporter, err = otlp.New(logger.With(slog.String("component", "otlp")), otlp.Config{
+			Endpoint:  cfg.OTLPEndpoint,
+			Protocol:  cfg.OTLPProtocol,
+			TLSConfig: tlsConfig,
+			Headers:   headers,
+			Interval:  cfg.OTLPExportInterval,
+			Metrics:   topology,
+		})
+		if err != nil {
+			return fmt.Errorf("could not create otlp exporter: %s", err)
+		}
+		flowExporter = exporter
+		logger.Info("Exporting telemetry to OTLP receiver",
+			slog.String("endpoint", cfg.OTLPEndpoint),
+			slog.String("protocol", cfg.OTLPProtocol),
+			slog.Duration("interval", cfg.OTLPExportInterval))
+	}
+
 	collector := collector.New(
 		logger.With(slog.String("component", "collector")),
-		session.NewContainerFactory(cfg.RouterURL, sessionConfig),
+		containerFactory,
 		reg,
 		cfg.FlowRecordTTL,
 		flowLogger,
+		history,
+		flowExporter,
 	)
+	if exporter != nil {
+		topology.MustRegister(collector.TopologyMetrics())
+	}
 
 	collectorAPI := server.New(
 		logger.With(slog.String("component", "api")),
 		collector.Records,
 		collector.GetGraph(),
+		collector.Requests(),
 	)
 
+	authenticate := func(next http.Handler) http.Handler { return next }
+	if cfg.Auth.enabled() {
+		authenticators, err := cfg.Auth.authenticators()
+		if err != nil {
+			return err
+		}
+		roles, err := cfg.Auth.roles()
+		if err != nil {
+			return err
+		}
+		authenticate = auth.Middleware(logger.With(slog.String("component", "auth")), authenticators, roles)
+		logger.Info("API authentication enabled",
+			slog.Int("methods", len(authenticators)),
+			slog.String("defaultRole", string(roles.Default)))
+	}
+
 	var mux = mux.NewRouter().StrictSlash(true)
 	promSubrouter := mux.PathPrefix("/api/v2alpha1/internal/prom")
-	mux.Handle("/metrics", handleMetrics(reg))
+	mux.Handle("/metrics", handleSecuredMetrics(reg, authenticate))
 	mux.PathPrefix("/swagger").Handler(handleSwagger("/swagger", specFS))
 	apiMux := mux.PathPrefix("/").Subrouter()
 	if cfg.CORSAllowAll {
 		apiMux.Use(handlers.CORS())
 	}
+	apiMux.Use(authenticate)
 	api.HandlerWithOptions(collectorAPI, api.GorillaServerOptions{
 		BaseRouter: apiMux,
 	})
+	apiMux.Path("/api/v2alpha1/events").Handler(server.NewEventsHandler(
+		logger.With(slog.String("component", "events")),
+		collector.Events(),
+		collector.Records,
+		collector.GetGraph(),
+	))
 
 	if cfg.EnableConsole {
 		promAPI, err := parsePrometheusAPI(cfg.PrometheusAPI)
@@ -95,7 +186,10 @@ func run(cfg Config) error {
 		// add unspec'd api routes
 		apiMux.Path("/api/v2alpha1/user").Handler(handleGetUser())
 		apiMux.Path("/api/v2alpha1/logout").Handler(handleUserLogout())
-		promSubrouter.Handler(handleProxyPrometheusAPI("/api/v2alpha1/internal/prom", promAPI))
+		// the metrics describe connections and requests, which users with
+		// only the topology role may not see
+		requireFlows := auth.RequireRole(auth.RoleFlows)
+		promSubrouter.Handler(authenticate(requireFlows(handleProxyPrometheusAPI("/api/v2alpha1/internal/prom", promAPI))))
 
 		apiMux.PathPrefix("/").Handler(handleSecuredConsoleAssets(cfg.ConsoleLocation))
 	}
@@ -178,6 +272,19 @@ func run(cfg Config) error {
 		})
 	}
 
+	if exporter != nil {
+		g.Go(func() error {
+			logger.Debug("Starting Network Observer OTLP Exporter")
+			return exporter.Run(runCtx)
+		})
+	}
+
+	if replay != nil {
+		g.Go(func() error {
+			return replay(runCtx)
+		})
+	}
+
 	g.Go(func() error {
 		logger.Debug("Starting Network Observer Collector")
 		if err := collector.Run(runCtx); err != nil {
@@ -214,10 +321,38 @@ func main() {
 	flags.StringVar(&cfg.PrometheusAPI, "prometheus-api", "http://127.0.0.1:9090", "Prometheus API HTTP endpoint for console")
 
 	flags.DurationVar(&cfg.FlowRecordTTL, "flow-record-ttl", 15*time.Minute, "How long to retain flow records in memory")
+	flags.StringVar(&cfg.FlowStore, "flow-store", "memory", "Where to keep connection and request records once they expire from memory. Options are memory (discard them) and bolt (archive them to disk)")
+	flags.StringVar(&cfg.FlowStorePath, "flow-store-path", "/var/lib/network-observer/flows.db", "Path to the database file used by the bolt flow store")
+	flags.DurationVar(&cfg.FlowStoreRetention, "flow-store-retention", 7*24*time.Hour, "How long to retain archived flow records. Zero retains them indefinitely")
+	flags.IntVar(&cfg.FlowStoreMaxRecords, "flow-store-max-records", 0, "Maximum number of archived flow records, the oldest being removed first. Zero does not limit the number of records")
 	flags.BoolVar(&cfg.CORSAllowAll, "cors-allow-all", false, "Development option to allow all origins")
 	flags.BoolVar(&cfg.EnableProfile, "profile", false, "Exposes the runtime profiling facilities from net/http/pprof on http://localhost:9970")
 
+	flags.StringVar(&cfg.OTLPEndpoint, "otlp-endpoint", "", "URL of an OTLP receiver to export connection and request spans and network topology metrics to, for example http://otel-collector:4317. Export is disabled when empty")
+	flags.StringVar(&cfg.OTLPProtocol, "otlp-protocol", otlp.ProtocolGRPC, "Protocol used to export to the OTLP receiver. Options are grpc and http/protobuf")
+	flags.StringVar(&cfg.OTLPHeaders, "otlp-headers", "", "Comma separated list of key=value headers sent along with each OTLP export")
+	flags.DurationVar(&cfg.OTLPExportInterval, "otlp-export-interval", 10*time.Second, "How often spans and metrics are exported to the OTLP receiver")
+	flags.StringVar(&cfg.OTLPTLS.CA, "otlp-tls-ca", "", "Path to the CA certificate file for an https OTLP endpoint")
+	flags.StringVar(&cfg.OTLPTLS.Cert, "otlp-tls-cert", "", "Path to the client certificate for an https OTLP endpoint")
+	flags.StringVar(&cfg.OTLPTLS.Key, "otlp-tls-key", "", "Path to the client key for an https OTLP endpoint")
+	flags.BoolVar(&cfg.OTLPTLS.SkipVerify, "otlp-tls-insecure", false, "Set to skip verification of the OTLP endpoint certificate and host name")
+
+	flags.StringVar(&cfg.Auth.HtpasswdFile, "auth-htpasswd-file", "", "Path to an htpasswd file of users allowed to authenticate with basic auth. Only bcrypt, SHA1 and {PLAIN} entries are supported")
+	flags.StringVar(&cfg.Auth.TokenFile, "auth-token-file", "", "Path to a CSV file of bearer tokens allowed to authenticate, one token,user[,group...] per line")
+	flags.BoolVar(&cfg.Auth.TokenReview, "auth-token-review", false, "Authenticate bearer tokens with the Kubernetes TokenReview API. Requires running in a cluster")
+	flags.StringVar(&cfg.Auth.TokenReviewAudiences, "auth-token-review-audiences", "", "Comma separated list of audiences bearer tokens reviewed by Kubernetes must be issued for")
+	flags.StringVar(&cfg.Auth.OIDCIssuer, "auth-oidc-issuer", "", "URL of an OpenID Connect issuer whose ID tokens are accepted as bearer tokens")
+	flags.StringVar(&cfg.Auth.OIDCClientID, "auth-oidc-client-id", "", "Client ID OpenID Connect tokens must be issued for")
+	flags.StringVar(&cfg.Auth.OIDCUsernameClaim, "auth-oidc-username-claim", "preferred_username", "OpenID Connect claim holding the user name")
+	flags.StringVar(&cfg.Auth.OIDCGroupsClaim, "auth-oidc-groups-claim", "groups", "OpenID Connect claim holding the groups of the user")
+	flags.StringVar(&cfg.Auth.OIDCCA, "auth-oidc-ca", "", "Path to the CA certificate file for the OpenID Connect issuer")
+	flags.StringVar(&cfg.Auth.DefaultRole, "auth-default-role", string(auth.RoleFlows), "Role of authenticated users not listed in auth-flows-subjects. Options are topology (sites, processes and services only) and flows (also connections and requests)")
+	flags.StringVar(&cfg.Auth.FlowsSubjects, "auth-flows-subjects", "", "Comma separated list of users, or groups prefixed by group:, granted the flows role")
+
 	flags.StringVar(&cfg.VanflowLoggingProfile, "vanflow-logging-profile", "silent", "Controls low level vanflow record logging. Options are silent, minimal, moderate and all")
+	flags.StringVar(&cfg.VanflowCapture, "vanflow-capture", "", "Path to a file to capture the vanflow messages of the router network to, until interrupted, instead of running the Network Observer")
+	flags.StringVar(&cfg.VanflowReplay, "vanflow-replay", "", "Path to a vanflow capture file replayed in place of the router network")
+	flags.Float64Var(&cfg.VanflowReplaySpeed, "vanflow-replay-speed", 1, "How many times faster than captured the vanflow capture is replayed. Zero replays it as fast as possible")
 
 	flags.Parse(os.Args[1:])
 	if *isVersion {
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/skupperproject/skupper/pkg/vanflow/capture"
+	"github.com/skupperproject/skupper/pkg/vanflow/session"
+)
+
+// runCapture writes the vanflow messages of the router network to the
+// capture file at path until ctx is cancelled.
+func runCapture(ctx context.Context, logger *slog.Logger, cfg Config, sessionConfig session.ContainerConfig, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("could not create capture file: %s", err)
+	}
+	defer file.Close()
+	writer, err := capture.NewWriter(file, capture.Header{Source: cfg.RouterURL})
+	if err != nil {
+		return err
+	}
+
+	container := session.NewContainer(cfg.RouterURL, sessionConfig)
+	container.OnSessionError(func(err error) {
+		logger.Error("router session error", slog.Any("error", err))
+	})
+	container.Start(ctx)
+
+	logger.Info("Capturing vanflow messages",
+		slog.String("router", cfg.RouterURL),
+		slog.String("path", path))
+	captureErr := capture.Capture(ctx, container, writer, capture.CaptureOptions{
+		Logger: logger.With(slog.String("component", "vanflow.capture")),
+	})
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("error writing capture file: %s", err)
+	}
+	if captureErr != nil {
+		return captureErr
+	}
+	return file.Close()
+}
+
+// newReplay returns a function replaying the capture file at path to the
+// containers of the factory it also returns, in place of the router
+// network.
+func newReplay(logger *slog.Logger, path string, speed float64) (session.ContainerFactory, func(ctx context.Context) error, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not open capture file: %s", err)
+	}
+	reader, err := capture.NewReader(file)
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+	router := session.NewMockRouter()
+	replay := func(ctx context.Context) error {
+		defer file.Close()
+		target := capture.NewContainerTarget(session.NewMockContainer(router))
+		defer target.Close()
+		header := reader.Header()
+		logger.Info("Replaying vanflow capture",
+			slog.String("path", path),
+			slog.String("source", header.Source),
+			slog.Time("start", header.Start),
+			slog.Float64("speed", speed))
+		if err := capture.Replay(ctx, reader, target, capture.ReplayOptions{Speed: speed}); err != nil {
+			return fmt.Errorf("error replaying capture: %w", err)
+		}
+		logger.Info("Finished replaying vanflow capture",
+			slog.Int64("dropped_messages", target.Dropped()))
+		// keep answering the flush requests of the collector
+		<-ctx.Done()
+		return nil
+	}
+	return session.NewMockRouterContainerFactory(router), replay, nil
+}
@@ -11,12 +11,13 @@ import (
 	"time"
 
 	"github.com/skupperproject/skupper/internal/nonkube/controller"
+	"github.com/skupperproject/skupper/internal/nonkube/grants"
 	"github.com/skupperproject/skupper/internal/version"
 	"github.com/skupperproject/skupper/pkg/nonkube/api"
 )
 
 func main() {
-	parseFlags()
+	grantConfig := parseFlags()
 	log.Printf("Version: %s", version.Version)
 	namespacesPath := api.GetDefaultOutputNamespacesPath()
 	log.Printf("Skupper System Controller watching %s", namespacesPath)
@@ -27,7 +28,7 @@ func main() {
 		log.Fatalf("Error creating skupper namespaces directory %q: %v", namespacesPath, err)
 	}
 
-	c, err := controller.NewController()
+	c, err := controller.NewController(grantConfig)
 	if err != nil {
 		log.Fatalf("Error creating controller: %v", err)
 	}
@@ -67,12 +68,17 @@ func handleShutdown(stop chan struct{}, wait *sync.WaitGroup) {
 	}
 }
 
-func parseFlags() {
+func parseFlags() *grants.Config {
 	flags := flag.NewFlagSet("", flag.ExitOnError)
 	isVersion := flags.Bool("version", false, "Report the version of the Skupper System Controller")
+	grantConfig, err := grants.BoundConfig(flags)
+	if err != nil {
+		log.Fatal(err)
+	}
 	flags.Parse(os.Args[1:])
 	if *isVersion {
 		fmt.Println(version.Version)
 		os.Exit(0)
 	}
+	return grantConfig
 }
@@ -0,0 +1,519 @@
+// Package chart turns a set of site resources into a Helm chart or a
+// Kustomize base, so that the same topology can be deployed to many
+// clusters changing only the site name, namespace, link access type,
+// routing keys and ports.
+package chart
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/skupperproject/skupper/internal/lint"
+	"github.com/skupperproject/skupper/internal/version"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+type Format string
+
+const (
+	FormatHelm      Format = "helm"
+	FormatKustomize Format = "kustomize"
+)
+
+var Formats = []string{string(FormatHelm), string(FormatKustomize)}
+
+// Options of the generated chart.
+type Options struct {
+	// Name of the chart, defaults to the site name
+	Name   string
+	Format Format
+	// ControllerConfig is the directory holding the controller
+	// configuration (config/ in the source tree). When set, its CRDs and
+	// namespace scoped RBAC are included.
+	ControllerConfig string
+}
+
+// Files maps the path of each generated file, relative to the output
+// directory, to its content.
+type Files map[string][]byte
+
+// Names returns the sorted paths of the files.
+func (f Files) Names() []string {
+	var names []string
+	for name := range f {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Write stores the files under the given directory.
+func (f Files) Write(dir string) error {
+	for _, name := range f.Names() {
+		filename := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filename, f[name], 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Values are the parameters of the generated chart, their defaults
+// being taken from the given resources.
+type Values struct {
+	Namespace  string                   `json:"namespace"`
+	Site       SiteValues               `json:"site"`
+	Listeners  map[string]RoutingValues `json:"listeners,omitempty"`
+	Connectors map[string]RoutingValues `json:"connectors,omitempty"`
+}
+
+type SiteValues struct {
+	Name       string `json:"name"`
+	LinkAccess string `json:"linkAccess"`
+}
+
+type RoutingValues struct {
+	RoutingKey string `json:"routingKey"`
+	Port       int    `json:"port"`
+}
+
+// parameter is a field of a resource whose value comes from the values
+// of the chart.
+type parameter struct {
+	path  []string
+	value interface{}
+	// helm template expression for the value
+	expression string
+}
+
+type resource struct {
+	kind       string
+	name       string
+	content    map[string]interface{}
+	parameters []parameter
+}
+
+func (r *resource) filename() string {
+	return fmt.Sprintf("%s-%s.yaml", strings.ToLower(r.kind), r.name)
+}
+
+type controllerFile struct {
+	name    string
+	content []byte
+}
+
+type generator struct {
+	options   Options
+	values    Values
+	resources []*resource
+	crds      []controllerFile
+	rbac      []controllerFile
+}
+
+// Generate returns the files of a chart holding the resources of the
+// bundle, which must belong to a single site.
+func Generate(bundle *lint.Bundle, options Options) (Files, error) {
+	if len(bundle.Sites) != 1 {
+		return nil, fmt.Errorf("resources of a single site are expected, found %d sites", len(bundle.Sites))
+	}
+	site := bundle.Sites[0].Object
+	g := &generator{
+		options: options,
+		values: Values{
+			Namespace: site.Namespace,
+			Site: SiteValues{
+				Name:       site.Name,
+				LinkAccess: site.Spec.LinkAccess,
+			},
+		},
+	}
+	if g.options.Name == "" {
+		g.options.Name = site.Name
+	}
+
+	siteResource, err := g.add("Site", site)
+	if err != nil {
+		return nil, err
+	}
+	siteResource.parameters = []parameter{
+		{path: []string{"metadata", "name"}, value: site.Name, expression: "{{ .Values.site.name | quote }}"},
+		{path: []string{"spec", "linkAccess"}, value: site.Spec.LinkAccess, expression: "{{ .Values.site.linkAccess | quote }}"},
+	}
+	for _, listener := range bundle.Listeners {
+		r, err := g.add("Listener", listener.Object)
+		if err != nil {
+			return nil, err
+		}
+		if g.values.Listeners == nil {
+			g.values.Listeners = map[string]RoutingValues{}
+		}
+		g.values.Listeners[r.name] = RoutingValues{
+			RoutingKey: listener.Object.Spec.RoutingKey,
+			Port:       listener.Object.Spec.Port,
+		}
+		r.parameters = routingParameters("listeners", r.name, listener.Object.Spec.RoutingKey, listener.Object.Spec.Port)
+	}
+	for _, connector := range bundle.Connectors {
+		r, err := g.add("Connector", connector.Object)
+		if err != nil {
+			return nil, err
+		}
+		if g.values.Connectors == nil {
+			g.values.Connectors = map[string]RoutingValues{}
+		}
+		g.values.Connectors[r.name] = RoutingValues{
+			RoutingKey: connector.Object.Spec.RoutingKey,
+			Port:       connector.Object.Spec.Port,
+		}
+		r.parameters = routingParameters("connectors", r.name, connector.Object.Spec.RoutingKey, connector.Object.Spec.Port)
+	}
+	// the remaining resources are kept as they are, apart from the namespace
+	if err = addAll(g, "Link", bundle.Links); err != nil {
+		return nil, err
+	}
+	if err = addAll(g, "RouterAccess", bundle.RouterAccesses); err != nil {
+		return nil, err
+	}
+	if err = addAll(g, "AccessGrant", bundle.AccessGrants); err != nil {
+		return nil, err
+	}
+	if err = addAll(g, "AccessToken", bundle.AccessTokens); err != nil {
+		return nil, err
+	}
+	if err = addAll(g, "Certificate", bundle.Certificates); err != nil {
+		return nil, err
+	}
+	if err = addAll(g, "AttachedConnectorBinding", bundle.AttachedConnectorBindings); err != nil {
+		return nil, err
+	}
+	if err = addAll(g, "Secret", bundle.Secrets); err != nil {
+		return nil, err
+	}
+
+	if options.ControllerConfig != "" {
+		if err = g.loadControllerConfig(); err != nil {
+			return nil, err
+		}
+	}
+
+	switch options.Format {
+	case FormatHelm, "":
+		return g.helm()
+	case FormatKustomize:
+		return g.kustomize()
+	default:
+		return nil, fmt.Errorf("invalid format %q (valid formats: %v)", options.Format, Formats)
+	}
+}
+
+func routingParameters(values string, name string, routingKey string, port int) []parameter {
+	prefix := fmt.Sprintf("(index .Values.%s %q)", values, name)
+	return []parameter{
+		{path: []string{"spec", "routingKey"}, value: routingKey, expression: "{{ " + prefix + ".routingKey | quote }}"},
+		{path: []string{"spec", "port"}, value: int64(port), expression: "{{ " + prefix + ".port }}"},
+	}
+}
+
+type object interface {
+	metav1.Object
+	runtime.Object
+}
+
+func addAll[T object](g *generator, kind string, resources []lint.Resource[T]) error {
+	for _, r := range resources {
+		if _, err := g.add(kind, r.Object); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *generator) add(kind string, obj object) (*resource, error) {
+	if obj.GetNamespace() != "" && obj.GetNamespace() != g.values.Namespace {
+		return nil, fmt.Errorf("%s %q belongs to namespace %q, not to the namespace of the site %q", kind, obj.GetName(), obj.GetNamespace(), g.values.Namespace)
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q: %w", kind, obj.GetName(), err)
+	}
+	delete(content, "status")
+	if metadata, ok := content["metadata"].(map[string]interface{}); ok {
+		for _, key := range []string{"namespace", "creationTimestamp", "resourceVersion", "uid", "generation", "managedFields"} {
+			delete(metadata, key)
+		}
+	}
+	r := &resource{
+		kind:    kind,
+		name:    obj.GetName(),
+		content: content,
+	}
+	g.resources = append(g.resources, r)
+	return r, nil
+}
+
+func (g *generator) loadControllerConfig() error {
+	crds, err := filepath.Glob(filepath.Join(g.options.ControllerConfig, "crd", "bases", "*.yaml"))
+	if err != nil {
+		return err
+	}
+	if len(crds) == 0 {
+		return fmt.Errorf("no CRDs found under %s", filepath.Join(g.options.ControllerConfig, "crd", "bases"))
+	}
+	rbac, err := filepath.Glob(filepath.Join(g.options.ControllerConfig, "rbac", "namespace", "*.yaml"))
+	if err != nil {
+		return err
+	}
+	read := func(files []string) ([]controllerFile, error) {
+		var result []controllerFile
+		for _, file := range files {
+			if filepath.Base(file) == "kustomization.yaml" {
+				continue
+			}
+			content, err := os.ReadFile(file)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, controllerFile{name: filepath.Base(file), content: content})
+		}
+		return result, nil
+	}
+	if g.crds, err = read(crds); err != nil {
+		return err
+	}
+	if g.rbac, err = read(rbac); err != nil {
+		return err
+	}
+	return nil
+}
+
+const helmNamespace = "{{ .Values.namespace | default .Release.Namespace }}"
+
+func (g *generator) helm() (Files, error) {
+	files := Files{}
+	chart := map[string]interface{}{
+		"apiVersion":  "v2",
+		"name":        g.options.Name,
+		"description": fmt.Sprintf("Skupper site %s", g.values.Site.Name),
+		"type":        "application",
+		"version":     "0.1.0",
+		"appVersion":  version.Version,
+	}
+	content, err := yaml.Marshal(chart)
+	if err != nil {
+		return nil, err
+	}
+	files["Chart.yaml"] = content
+
+	content, err = yaml.Marshal(g.values)
+	if err != nil {
+		return nil, err
+	}
+	files["values.yaml"] = append([]byte(`# Parameters of the site, set namespace to an empty string to use the
+# namespace of the release.
+`), content...)
+
+	for _, r := range g.resources {
+		t := &templater{}
+		content := deepCopy(r.content)
+		setNested(content, t.placeholder(helmNamespace), "metadata", "namespace")
+		for _, p := range r.parameters {
+			setNested(content, t.placeholder(p.expression), p.path...)
+		}
+		rendered, err := t.render(content)
+		if err != nil {
+			return nil, fmt.Errorf("unable to render %s %q: %w", r.kind, r.name, err)
+		}
+		files[path.Join("templates", r.filename())] = rendered
+	}
+	for _, crd := range g.crds {
+		files[path.Join("crds", crd.name)] = crd.content
+	}
+	for _, rbac := range g.rbac {
+		documents, err := decodeDocuments(rbac.content)
+		if err != nil {
+			return nil, fmt.Errorf("invalid controller configuration %s: %w", rbac.name, err)
+		}
+		var rendered []string
+		for _, document := range documents {
+			t := &templater{}
+			setNested(document, t.placeholder(helmNamespace), "metadata", "namespace")
+			content, err := t.render(document)
+			if err != nil {
+				return nil, err
+			}
+			rendered = append(rendered, string(content))
+		}
+		files[path.Join("templates", "controller-"+rbac.name)] = []byte(strings.Join(rendered, "---\n"))
+	}
+	return files, nil
+}
+
+type kustomization struct {
+	APIVersion string   `json:"apiVersion"`
+	Kind       string   `json:"kind"`
+	Namespace  string   `json:"namespace,omitempty"`
+	Resources  []string `json:"resources"`
+	Patches    []patch  `json:"patches,omitempty"`
+}
+
+type patch struct {
+	Target patchTarget `json:"target"`
+	Patch  string      `json:"patch"`
+}
+
+type patchTarget struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+type patchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+// kustomize returns a base holding the resources and an overlay setting
+// the values, to be copied and edited for each target cluster.
+func (g *generator) kustomize() (Files, error) {
+	files := Files{}
+	base := kustomization{
+		APIVersion: "kustomize.config.k8s.io/v1beta1",
+		Kind:       "Kustomization",
+		Namespace:  g.values.Namespace,
+	}
+	for _, crd := range g.crds {
+		base.Resources = append(base.Resources, path.Join("crds", crd.name))
+		files[path.Join("base", "crds", crd.name)] = crd.content
+	}
+	for _, rbac := range g.rbac {
+		base.Resources = append(base.Resources, path.Join("rbac", rbac.name))
+		files[path.Join("base", "rbac", rbac.name)] = rbac.content
+	}
+	overlay := kustomization{
+		APIVersion: "kustomize.config.k8s.io/v1beta1",
+		Kind:       "Kustomization",
+		Namespace:  g.values.Namespace,
+		Resources:  []string{"../../base"},
+	}
+	for _, r := range g.resources {
+		content, err := yaml.Marshal(r.content)
+		if err != nil {
+			return nil, fmt.Errorf("unable to render %s %q: %w", r.kind, r.name, err)
+		}
+		base.Resources = append(base.Resources, r.filename())
+		files[path.Join("base", r.filename())] = content
+		if len(r.parameters) == 0 {
+			continue
+		}
+		var operations []patchOperation
+		for _, p := range r.parameters {
+			operations = append(operations, patchOperation{
+				Op:    "add",
+				Path:  "/" + strings.Join(p.path, "/"),
+				Value: p.value,
+			})
+		}
+		content, err = yaml.Marshal(operations)
+		if err != nil {
+			return nil, err
+		}
+		overlay.Patches = append(overlay.Patches, patch{
+			Target: patchTarget{Kind: r.kind, Name: r.name},
+			Patch:  string(content),
+		})
+	}
+	content, err := yaml.Marshal(base)
+	if err != nil {
+		return nil, err
+	}
+	files[path.Join("base", "kustomization.yaml")] = content
+	content, err = yaml.Marshal(overlay)
+	if err != nil {
+		return nil, err
+	}
+	files[path.Join("overlays", g.values.Site.Name, "kustomization.yaml")] = content
+	return files, nil
+}
+
+// templater replaces fields of a resource by helm template expressions,
+// which can not be marshalled as they are since they would be quoted.
+type templater struct {
+	expressions []string
+}
+
+func (t *templater) placeholder(expression string) string {
+	t.expressions = append(t.expressions, expression)
+	return fmt.Sprintf("__skupper_value_%d__", len(t.expressions)-1)
+}
+
+func (t *templater) render(content map[string]interface{}) ([]byte, error) {
+	rendered, err := yaml.Marshal(content)
+	if err != nil {
+		return nil, err
+	}
+	result := string(rendered)
+	for i, expression := range t.expressions {
+		placeholder := fmt.Sprintf("__skupper_value_%d__", i)
+		result = strings.NewReplacer(
+			"'"+placeholder+"'", expression,
+			"\""+placeholder+"\"", expression,
+			placeholder, expression,
+		).Replace(result)
+	}
+	return []byte(result), nil
+}
+
+func setNested(content map[string]interface{}, value interface{}, fields ...string) {
+	current := content
+	for _, field := range fields[:len(fields)-1] {
+		next, ok := current[field].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			current[field] = next
+		}
+		current = next
+	}
+	current[fields[len(fields)-1]] = value
+}
+
+func deepCopy(content map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(content))
+	for key, value := range content {
+		if nested, ok := value.(map[string]interface{}); ok {
+			result[key] = deepCopy(nested)
+		} else {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+func decodeDocuments(content []byte) ([]map[string]interface{}, error) {
+	var documents []map[string]interface{}
+	decoder := yamlutil.NewYAMLOrJSONDecoder(strings.NewReader(string(content)), 4096)
+	for {
+		document := map[string]interface{}{}
+		err := decoder.Decode(&document)
+		if errors.Is(err, io.EOF) {
+			return documents, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(document) > 0 {
+			documents = append(documents, document)
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package chart
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/lint"
+	"gotest.tools/v3/assert"
+	"sigs.k8s.io/yaml"
+)
+
+const resources = `apiVersion: skupper.io/v2alpha1
+kind: Site
+metadata:
+  name: west
+  namespace: west
+spec:
+  linkAccess: default
+---
+apiVersion: skupper.io/v2alpha1
+kind: Listener
+metadata:
+  name: backend
+  namespace: west
+spec:
+  routingKey: backend
+  host: backend
+  port: 8080
+---
+apiVersion: skupper.io/v2alpha1
+kind: Connector
+metadata:
+  name: db
+  namespace: west
+spec:
+  routingKey: db
+  host: db.local
+  port: 5432
+---
+apiVersion: skupper.io/v2alpha1
+kind: Link
+metadata:
+  name: to-east
+  namespace: west
+spec:
+  tlsCredentials: to-east
+  cost: 1
+  endpoints:
+  - name: inter-router
+    host: 10.0.0.1
+    port: "55671"
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: to-east
+  namespace: west
+data:
+  tls.crt: Y2VydA==
+`
+
+func readBundle(t *testing.T, content string) *lint.Bundle {
+	bundle := &lint.Bundle{}
+	findings := bundle.Read("test.yaml", strings.NewReader(content))
+	assert.Equal(t, len(findings), 0, "%v", findings)
+	return bundle
+}
+
+func writeControllerConfig(t *testing.T) string {
+	dir := t.TempDir()
+	assert.Assert(t, os.MkdirAll(filepath.Join(dir, "crd", "bases"), 0755))
+	assert.Assert(t, os.MkdirAll(filepath.Join(dir, "rbac", "namespace"), 0755))
+	assert.Assert(t, os.WriteFile(filepath.Join(dir, "crd", "bases", "skupper_site_crd.yaml"), []byte("kind: CustomResourceDefinition\n"), 0644))
+	assert.Assert(t, os.WriteFile(filepath.Join(dir, "rbac", "namespace", "kustomization.yaml"), []byte("resources:\n- role.yaml\n"), 0644))
+	assert.Assert(t, os.WriteFile(filepath.Join(dir, "rbac", "namespace", "role.yaml"), []byte(`---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  name: skupper-controller
+`), 0644))
+	return dir
+}
+
+func TestGenerateHelm(t *testing.T) {
+	files, err := Generate(readBundle(t, resources), Options{
+		Format:           FormatHelm,
+		ControllerConfig: writeControllerConfig(t),
+	})
+	assert.Assert(t, err)
+	assert.DeepEqual(t, files.Names(), []string{
+		"Chart.yaml",
+		"crds/skupper_site_crd.yaml",
+		"templates/connector-db.yaml",
+		"templates/controller-role.yaml",
+		"templates/link-to-east.yaml",
+		"templates/listener-backend.yaml",
+		"templates/secret-to-east.yaml",
+		"templates/site-west.yaml",
+		"values.yaml",
+	})
+
+	values := Values{}
+	assert.Assert(t, yaml.Unmarshal(files["values.yaml"], &values))
+	assert.DeepEqual(t, values, Values{
+		Namespace: "west",
+		Site:      SiteValues{Name: "west", LinkAccess: "default"},
+		Listeners: map[string]RoutingValues{
+			"backend": {RoutingKey: "backend", Port: 8080},
+		},
+		Connectors: map[string]RoutingValues{
+			"db": {RoutingKey: "db", Port: 5432},
+		},
+	})
+	assert.Assert(t, strings.Contains(string(files["Chart.yaml"]), "name: west"))
+
+	site := string(files["templates/site-west.yaml"])
+	assert.Assert(t, strings.Contains(site, "name: {{ .Values.site.name | quote }}"), site)
+	assert.Assert(t, strings.Contains(site, "namespace: {{ .Values.namespace | default .Release.Namespace }}"), site)
+	assert.Assert(t, strings.Contains(site, "linkAccess: {{ .Values.site.linkAccess | quote }}"), site)
+	assert.Assert(t, !strings.Contains(site, "status"), site)
+
+	listener := string(files["templates/listener-backend.yaml"])
+	assert.Assert(t, strings.Contains(listener, `port: {{ (index .Values.listeners "backend").port }}`), listener)
+	assert.Assert(t, strings.Contains(listener, `routingKey: {{ (index .Values.listeners "backend").routingKey | quote }}`), listener)
+	assert.Assert(t, strings.Contains(listener, "host: backend"), listener)
+
+	connector := string(files["templates/connector-db.yaml"])
+	assert.Assert(t, strings.Contains(connector, `port: {{ (index .Values.connectors "db").port }}`), connector)
+
+	link := string(files["templates/link-to-east.yaml"])
+	assert.Assert(t, strings.Contains(link, "namespace: {{ .Values.namespace | default .Release.Namespace }}"), link)
+	assert.Assert(t, strings.Contains(link, "host: 10.0.0.1"), link)
+
+	role := string(files["templates/controller-role.yaml"])
+	assert.Assert(t, strings.Contains(role, "namespace: {{ .Values.namespace | default .Release.Namespace }}"), role)
+}
+
+func TestGenerateKustomize(t *testing.T) {
+	files, err := Generate(readBundle(t, resources), Options{
+		Format:           FormatKustomize,
+		ControllerConfig: writeControllerConfig(t),
+	})
+	assert.Assert(t, err)
+	assert.DeepEqual(t, files.Names(), []string{
+		"base/connector-db.yaml",
+		"base/crds/skupper_site_crd.yaml",
+		"base/kustomization.yaml",
+		"base/link-to-east.yaml",
+		"base/listener-backend.yaml",
+		"base/rbac/role.yaml",
+		"base/secret-to-east.yaml",
+		"base/site-west.yaml",
+		"overlays/west/kustomization.yaml",
+	})
+
+	base := kustomization{}
+	assert.Assert(t, yaml.Unmarshal(files["base/kustomization.yaml"], &base))
+	assert.Equal(t, base.Namespace, "west")
+	assert.DeepEqual(t, base.Resources, []string{
+		"crds/skupper_site_crd.yaml",
+		"rbac/role.yaml",
+		"site-west.yaml",
+		"listener-backend.yaml",
+		"connector-db.yaml",
+		"link-to-east.yaml",
+		"secret-to-east.yaml",
+	})
+	assert.Assert(t, !strings.Contains(string(files["base/site-west.yaml"]), "namespace"))
+
+	overlay := kustomization{}
+	assert.Assert(t, yaml.Unmarshal(files["overlays/west/kustomization.yaml"], &overlay))
+	assert.DeepEqual(t, overlay.Resources, []string{"../../base"})
+	assert.Equal(t, len(overlay.Patches), 3)
+	assert.Equal(t, overlay.Patches[1].Target, patchTarget{Kind: "Listener", Name: "backend"})
+	var operations []patchOperation
+	assert.Assert(t, yaml.Unmarshal([]byte(overlay.Patches[1].Patch), &operations))
+	assert.DeepEqual(t, operations, []patchOperation{
+		{Op: "add", Path: "/spec/routingKey", Value: "backend"},
+		{Op: "add", Path: "/spec/port", Value: float64(8080)},
+	})
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		options       Options
+		expectedError string
+	}{
+		{
+			name:          "no site",
+			input:         resources[strings.Index(resources, "---"):],
+			expectedError: "resources of a single site are expected, found 0 sites",
+		},
+		{
+			name:          "several sites",
+			input:         resources + "---\n" + strings.ReplaceAll(resources[:strings.Index(resources, "---")], "west", "east"),
+			expectedError: "resources of a single site are expected, found 2 sites",
+		},
+		{
+			name:          "resource of another namespace",
+			input:         strings.Replace(resources, "name: db\n  namespace: west", "name: db\n  namespace: east", 1),
+			expectedError: `Connector "db" belongs to namespace "east", not to the namespace of the site "west"`,
+		},
+		{
+			name:          "invalid format",
+			input:         resources,
+			options:       Options{Format: "ansible"},
+			expectedError: `invalid format "ansible" (valid formats: [helm kustomize])`,
+		},
+		{
+			name:          "no controller configuration",
+			input:         resources,
+			options:       Options{ControllerConfig: "/nonexistent"},
+			expectedError: "no CRDs found under /nonexistent/crd/bases",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Generate(readBundle(t, test.input), test.options)
+			assert.Error(t, err, test.expectedError)
+		})
+	}
+}
+
+func TestFilesWrite(t *testing.T) {
+	dir := t.TempDir()
+	files := Files{
+		"Chart.yaml":               []byte("name: west\n"),
+		"templates/site-west.yaml": []byte("kind: Site\n"),
+	}
+	assert.Assert(t, files.Write(dir))
+	content, err := os.ReadFile(filepath.Join(dir, "templates", "site-west.yaml"))
+	assert.Assert(t, err)
+	assert.Equal(t, string(content), "kind: Site\n")
+}
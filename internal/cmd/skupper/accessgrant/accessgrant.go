@@ -0,0 +1,176 @@
+package accessgrant
+
+import (
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/accessgrant/kube"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/accessgrant/nonkube"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdAccessGrant() *cobra.Command {
+
+	cmd := &cobra.Command{
+		Use:   "access-grant",
+		Short: "Permission for remote sites to obtain a link to the local site",
+		Long: `An access grant allows a remote site to redeem an access token for the credentials it needs to link to the local site.
+A grant can only be redeemed a limited number of times, within its expiration window.`,
+		Example: `skupper access-grant create my-grant --redemptions-allowed 3
+skupper access-grant status my-grant`,
+	}
+
+	platform := common.Platform(config.GetPlatform())
+	cmd.AddCommand(CmdAccessGrantCreateFactory(platform))
+	cmd.AddCommand(CmdAccessGrantStatusFactory(platform))
+	cmd.AddCommand(CmdAccessGrantUpdateFactory(platform))
+	cmd.AddCommand(CmdAccessGrantDeleteFactory(platform))
+	cmd.AddCommand(CmdAccessGrantGenerateFactory(platform))
+
+	return cmd
+}
+
+func CmdAccessGrantCreateFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdAccessGrantCreate()
+	nonKubeCommand := nonkube.NewCmdAccessGrantCreate()
+
+	cmdAccessGrantCreateDesc := common.SkupperCmdDescription{
+		Use:     "create <name>",
+		Short:   "create an access grant",
+		Long:    "Create an access grant that remote sites can redeem to link to this site.",
+		Example: "skupper access-grant create my-grant --redemptions-allowed 3 --expiration-window 1h",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdAccessGrantCreateDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandAccessGrantCreateFlags{}
+
+	cmd.Flags().IntVar(&cmdFlags.RedemptionsAllowed, common.FlagNameRedemptionsAllowed, 1, common.FlagDescRedemptionsAllowed)
+	cmd.Flags().DurationVar(&cmdFlags.ExpirationWindow, common.FlagNameExpirationWindow, 15*time.Minute, common.FlagDescExpirationWindow)
+
+	if configuredPlatform == common.PlatformKubernetes {
+		cmd.Flags().DurationVar(&cmdFlags.Timeout, common.FlagNameTimeout, 60*time.Second, common.FlagDescTimeout)
+		cmd.Flags().StringVar(&cmdFlags.Wait, common.FlagNameWait, "ready", common.FlagDescWait)
+	}
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
+
+func CmdAccessGrantUpdateFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdAccessGrantUpdate()
+	nonKubeCommand := nonkube.NewCmdAccessGrantUpdate()
+
+	cmdAccessGrantUpdateDesc := common.SkupperCmdDescription{
+		Use:   "update <name>",
+		Short: "update an access grant",
+		Long: `Update the number of redemptions allowed or the expiration window of an access grant.
+	Options that are not specified keep their current value.`,
+		Example: "skupper access-grant update my-grant --redemptions-allowed 5",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdAccessGrantUpdateDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandAccessGrantUpdateFlags{}
+
+	cmd.Flags().IntVar(&cmdFlags.RedemptionsAllowed, common.FlagNameRedemptionsAllowed, 0, common.FlagDescRedemptionsAllowed)
+	cmd.Flags().DurationVar(&cmdFlags.ExpirationWindow, common.FlagNameExpirationWindow, 0, common.FlagDescExpirationWindow)
+
+	if configuredPlatform == common.PlatformKubernetes {
+		cmd.Flags().DurationVar(&cmdFlags.Timeout, common.FlagNameTimeout, 60*time.Second, common.FlagDescTimeout)
+		cmd.Flags().StringVar(&cmdFlags.Wait, common.FlagNameWait, "ready", common.FlagDescWait)
+	}
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
+
+func CmdAccessGrantStatusFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdAccessGrantStatus()
+	nonKubeCommand := nonkube.NewCmdAccessGrantStatus()
+
+	cmdAccessGrantStatusDesc := common.SkupperCmdDescription{
+		Use:     "status <name>",
+		Short:   "get status of access grants",
+		Long:    "Display status of all access grants or a specific access grant",
+		Example: "skupper access-grant status my-grant",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdAccessGrantStatusDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandAccessGrantStatusFlags{}
+
+	cmd.Flags().StringVarP(&cmdFlags.Output, common.FlagNameOutput, "o", "", common.FlagDescOutput)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
+
+func CmdAccessGrantDeleteFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdAccessGrantDelete()
+	nonKubeCommand := nonkube.NewCmdAccessGrantDelete()
+
+	cmdAccessGrantDeleteDesc := common.SkupperCmdDescription{
+		Use:     "delete <name>",
+		Short:   "delete an access grant",
+		Long:    "Delete an access grant <name>. Tokens issued for the grant can no longer be redeemed.",
+		Example: "skupper access-grant delete my-grant",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdAccessGrantDeleteDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandAccessGrantDeleteFlags{}
+
+	if configuredPlatform == common.PlatformKubernetes {
+		cmd.Flags().DurationVar(&cmdFlags.Timeout, common.FlagNameTimeout, 60*time.Second, common.FlagDescTimeout)
+		cmd.Flags().BoolVar(&cmdFlags.Wait, common.FlagNameWait, true, common.FlagDescDeleteWait)
+	}
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
+
+func CmdAccessGrantGenerateFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdAccessGrantGenerate()
+	nonKubeCommand := nonkube.NewCmdAccessGrantGenerate()
+
+	cmdAccessGrantGenerateDesc := common.SkupperCmdDescription{
+		Use:   "generate <name>",
+		Short: "generate an access grant resource and output it to a file or screen",
+		Long: `Create an access grant that remote sites can redeem to link to this site.
+	generate an access grant to evaluate what will be created with access-grant create command`,
+		Example: "skupper access-grant generate my-grant --redemptions-allowed 3",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdAccessGrantGenerateDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandAccessGrantGenerateFlags{}
+
+	cmd.Flags().IntVar(&cmdFlags.RedemptionsAllowed, common.FlagNameRedemptionsAllowed, 1, common.FlagDescRedemptionsAllowed)
+	cmd.Flags().DurationVar(&cmdFlags.ExpirationWindow, common.FlagNameExpirationWindow, 15*time.Minute, common.FlagDescExpirationWindow)
+	cmd.Flags().StringVarP(&cmdFlags.Output, common.FlagNameOutput, "o", "yaml", common.FlagDescOutput)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
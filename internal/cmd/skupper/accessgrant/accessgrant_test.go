@@ -0,0 +1,97 @@
+package accessgrant
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gotest.tools/v3/assert"
+)
+
+func TestCmdAccessGrantFactory(t *testing.T) {
+
+	type test struct {
+		name                          string
+		expectedFlagsWithDefaultValue map[string]interface{}
+		command                       *cobra.Command
+	}
+
+	testTable := []test{
+		{
+			name: "CmdAccessGrantCreateFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameRedemptionsAllowed: "1",
+				common.FlagNameExpirationWindow:   "15m0s",
+				common.FlagNameTimeout:            "1m0s",
+				common.FlagNameWait:               "ready",
+			},
+			command: CmdAccessGrantCreateFactory(common.PlatformKubernetes),
+		},
+		{
+			name: "CmdAccessGrantUpdateFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameRedemptionsAllowed: "0",
+				common.FlagNameExpirationWindow:   "0s",
+				common.FlagNameTimeout:            "1m0s",
+				common.FlagNameWait:               "ready",
+			},
+			command: CmdAccessGrantUpdateFactory(common.PlatformKubernetes),
+		},
+		{
+			name: "CmdAccessGrantStatusFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameOutput: "",
+			},
+			command: CmdAccessGrantStatusFactory(common.PlatformKubernetes),
+		},
+		{
+			name: "CmdAccessGrantDeleteFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameTimeout: "1m0s",
+				common.FlagNameWait:    "true",
+			},
+			command: CmdAccessGrantDeleteFactory(common.PlatformKubernetes),
+		},
+		{
+			name: "CmdAccessGrantGenerateFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameRedemptionsAllowed: "1",
+				common.FlagNameExpirationWindow:   "15m0s",
+				common.FlagNameOutput:             "yaml",
+			},
+			command: CmdAccessGrantGenerateFactory(common.PlatformKubernetes),
+		},
+		{
+			name: "CmdAccessGrantCreateFactory on podman",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameRedemptionsAllowed: "1",
+				common.FlagNameExpirationWindow:   "15m0s",
+			},
+			command: CmdAccessGrantCreateFactory(common.PlatformPodman),
+		},
+	}
+
+	for _, test := range testTable {
+
+		var flagList []string
+		t.Run(test.name, func(t *testing.T) {
+
+			test.command.Flags().VisitAll(func(flag *pflag.Flag) {
+				flagList = append(flagList, flag.Name)
+				assert.Check(t, test.expectedFlagsWithDefaultValue[flag.Name] != nil, fmt.Sprintf("flag %q not expected", flag.Name))
+				assert.Check(t, test.expectedFlagsWithDefaultValue[flag.Name] == flag.DefValue, fmt.Sprintf("default value %q for flag %q not expected", flag.DefValue, flag.Name))
+			})
+
+			assert.Check(t, len(flagList) == len(test.expectedFlagsWithDefaultValue))
+
+			assert.Assert(t, test.command.PreRunE != nil)
+			assert.Assert(t, test.command.Run != nil)
+			assert.Assert(t, test.command.PostRun != nil)
+			assert.Assert(t, test.command.Use != "")
+			assert.Assert(t, test.command.Short != "")
+			assert.Assert(t, test.command.Long != "")
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	"github.com/skupperproject/skupper/internal/kube/client"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/generated/client/clientset/versioned/typed/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	k8serrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CmdAccessGrantCreate struct {
+	client             skupperv2alpha1.SkupperV2alpha1Interface
+	CobraCmd           *cobra.Command
+	Flags              *common.CommandAccessGrantCreateFlags
+	namespace          string
+	name               string
+	redemptionsAllowed int
+	expirationWindow   string
+	timeout            time.Duration
+	status             string
+}
+
+func NewCmdAccessGrantCreate() *CmdAccessGrantCreate {
+
+	return &CmdAccessGrantCreate{}
+
+}
+
+func (cmd *CmdAccessGrantCreate) NewClient(cobraCommand *cobra.Command, args []string) {
+	cli, err := client.NewClient(cobraCommand.Flag("namespace").Value.String(), cobraCommand.Flag("context").Value.String(), cobraCommand.Flag("kubeconfig").Value.String())
+	utils.HandleError(utils.GenericError, err)
+
+	cmd.client = cli.GetSkupperClient().SkupperV2alpha1()
+	cmd.namespace = cli.Namespace
+}
+
+func (cmd *CmdAccessGrantCreate) ValidateInput(args []string) error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	numberValidator := validator.NewNumberValidator()
+	expirationValidator := validator.NewExpirationInSecondsValidator()
+	timeoutValidator := validator.NewTimeoutInSecondsValidator()
+	statusValidator := validator.NewOptionValidator(common.WaitStatusTypes)
+
+	// Check if AccessGrant CRD is installed
+	_, err := cmd.client.AccessGrants(cmd.namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		validationErrors = append(validationErrors, utils.HandleMissingCrds(err))
+		return errors.Join(validationErrors...)
+	}
+
+	// Validate arguments name
+	if len(args) < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("access grant name must be configured"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("access grant name must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("access grant name is not valid: %s", err))
+		} else {
+			cmd.name = args[0]
+		}
+	}
+
+	// Validate if there is already an access grant with this name in the namespace
+	if cmd.name != "" {
+		grant, err := cmd.client.AccessGrants(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if grant != nil && !k8serrs.IsNotFound(err) {
+			validationErrors = append(validationErrors, fmt.Errorf("There is already an access grant %s created for namespace %s", cmd.name, cmd.namespace))
+		}
+	}
+
+	// Validate flags
+	if cmd.Flags != nil {
+		ok, err := numberValidator.Evaluate(cmd.Flags.RedemptionsAllowed)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("number of redemptions is not valid: %s", err))
+		}
+	}
+
+	if cmd.Flags != nil {
+		ok, err := expirationValidator.Evaluate(cmd.Flags.ExpirationWindow)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("expiration time is not valid: %s", err))
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Timeout.String() != "" {
+		ok, err := timeoutValidator.Evaluate(cmd.Flags.Timeout)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("timeout is not valid: %s", err))
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Wait != "" {
+		ok, err := statusValidator.Evaluate(cmd.Flags.Wait)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("status is not valid: %s", err))
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdAccessGrantCreate) InputToOptions() {
+	cmd.redemptionsAllowed = cmd.Flags.RedemptionsAllowed
+	cmd.expirationWindow = cmd.Flags.ExpirationWindow.String()
+	cmd.timeout = cmd.Flags.Timeout
+	cmd.status = cmd.Flags.Wait
+}
+
+func (cmd *CmdAccessGrantCreate) Run() error {
+
+	resource := v2alpha1.AccessGrant{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "AccessGrant",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cmd.name,
+			Namespace: cmd.namespace,
+		},
+		Spec: v2alpha1.AccessGrantSpec{
+			RedemptionsAllowed: cmd.redemptionsAllowed,
+			ExpirationWindow:   cmd.expirationWindow,
+		},
+	}
+
+	_, err := cmd.client.AccessGrants(cmd.namespace).Create(context.TODO(), &resource, metav1.CreateOptions{})
+	return err
+}
+
+func (cmd *CmdAccessGrantCreate) WaitUntil() error {
+
+	if cmd.status == "none" {
+		return nil
+	}
+
+	waitTime := int(cmd.timeout.Seconds())
+	var grantCondition *metav1.Condition
+
+	err := utils.NewSpinnerWithTimeout("Waiting for create to complete...", waitTime, func() error {
+
+		resource, err := cmd.client.AccessGrants(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		isConditionFound := false
+		isConditionTrue := false
+
+		switch cmd.status {
+		case "configured":
+			// an access grant is configured once it has been processed
+			// by the grant server
+			grantCondition = meta.FindStatusCondition(resource.Status.Conditions, v2alpha1.CONDITION_TYPE_PROCESSED)
+		default:
+			grantCondition = meta.FindStatusCondition(resource.Status.Conditions, v2alpha1.CONDITION_TYPE_READY)
+		}
+
+		if grantCondition != nil {
+			isConditionFound = true
+			isConditionTrue = grantCondition.Status == metav1.ConditionTrue
+		}
+
+		if resource != nil && isConditionFound && isConditionTrue {
+			return nil
+		}
+
+		if resource != nil && isConditionFound && !isConditionTrue {
+			return fmt.Errorf("error in the condition")
+		}
+
+		return fmt.Errorf("error getting the resource")
+	})
+
+	if err != nil && grantCondition == nil {
+		return fmt.Errorf("AccessGrant %q is not yet %s, check the status for more information\n", cmd.name, cmd.status)
+	} else if err != nil && grantCondition.Status == metav1.ConditionFalse {
+		return fmt.Errorf("AccessGrant %q is not yet %s: %s\n", cmd.name, cmd.status, grantCondition.Message)
+	}
+
+	fmt.Printf("AccessGrant %q is %s.\n", cmd.name, cmd.status)
+	return nil
+}
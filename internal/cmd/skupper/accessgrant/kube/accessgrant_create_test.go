@@ -0,0 +1,338 @@
+package kube
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdAccessGrantCreate_ValidateInput(t *testing.T) {
+	type test struct {
+		name                string
+		args                []string
+		flags               common.CommandAccessGrantCreateFlags
+		k8sObjects          []runtime.Object
+		skupperObjects      []runtime.Object
+		skupperErrorMessage string
+		expectedError       string
+	}
+
+	validFlags := common.CommandAccessGrantCreateFlags{
+		RedemptionsAllowed: 1,
+		ExpirationWindow:   15 * time.Minute,
+		Timeout:            time.Minute,
+		Wait:               "ready",
+	}
+
+	testTable := []test{
+		{
+			name:                "missing CRD",
+			flags:               validFlags,
+			skupperErrorMessage: utils.CrdErr,
+			expectedError:       utils.CrdHelpErr,
+		},
+		{
+			name:  "access grant already exists",
+			args:  []string{"my-grant"},
+			flags: validFlags,
+			skupperObjects: []runtime.Object{
+				&v2alpha1.AccessGrant{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-grant",
+						Namespace: "test",
+					},
+				},
+			},
+			expectedError: "There is already an access grant my-grant created for namespace test",
+		},
+		{
+			name:          "access grant name is not specified",
+			flags:         validFlags,
+			expectedError: "access grant name must be configured",
+		},
+		{
+			name:          "access grant name empty",
+			args:          []string{""},
+			flags:         validFlags,
+			expectedError: "access grant name must not be empty",
+		},
+		{
+			name:          "more than one argument is specified",
+			args:          []string{"my", "grant"},
+			flags:         validFlags,
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:          "access grant name is not valid",
+			args:          []string{"my grant"},
+			flags:         validFlags,
+			expectedError: "access grant name is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])*(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])*)*$",
+		},
+		{
+			name: "redemptions allowed is not valid",
+			args: []string{"my-grant"},
+			flags: common.CommandAccessGrantCreateFlags{
+				RedemptionsAllowed: -1,
+				ExpirationWindow:   15 * time.Minute,
+				Timeout:            time.Minute,
+			},
+			expectedError: "number of redemptions is not valid: value is not positive",
+		},
+		{
+			name: "expiration window is not valid",
+			args: []string{"my-grant"},
+			flags: common.CommandAccessGrantCreateFlags{
+				RedemptionsAllowed: 1,
+				ExpirationWindow:   time.Second,
+				Timeout:            time.Minute,
+			},
+			expectedError: "expiration time is not valid: duration must not be less than 1m0s; got 1s",
+		},
+		{
+			name: "timeout is not valid",
+			args: []string{"my-grant"},
+			flags: common.CommandAccessGrantCreateFlags{
+				RedemptionsAllowed: 1,
+				ExpirationWindow:   15 * time.Minute,
+				Timeout:            time.Second,
+			},
+			expectedError: "timeout is not valid: duration must not be less than 10s; got 1s",
+		},
+		{
+			name: "wait status is not valid",
+			args: []string{"my-grant"},
+			flags: common.CommandAccessGrantCreateFlags{
+				RedemptionsAllowed: 1,
+				ExpirationWindow:   15 * time.Minute,
+				Timeout:            time.Minute,
+				Wait:               "redeemed",
+			},
+			expectedError: "status is not valid: value redeemed not allowed. It should be one of this options: [ready configured none]",
+		},
+		{
+			name:  "flags all valid",
+			args:  []string{"my-grant"},
+			flags: validFlags,
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdAccessGrantCreateWithMocks("test", test.k8sObjects, test.skupperObjects, test.skupperErrorMessage)
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdAccessGrantCreate_InputToOptions(t *testing.T) {
+
+	cmd, err := newCmdAccessGrantCreateWithMocks("test", nil, nil, "")
+	assert.Assert(t, err)
+
+	cmd.Flags = &common.CommandAccessGrantCreateFlags{
+		RedemptionsAllowed: 3,
+		ExpirationWindow:   time.Hour,
+		Timeout:            20 * time.Second,
+		Wait:               "configured",
+	}
+	cmd.InputToOptions()
+
+	assert.Equal(t, cmd.redemptionsAllowed, 3)
+	assert.Equal(t, cmd.expirationWindow, "1h0m0s")
+	assert.Equal(t, cmd.timeout, 20*time.Second)
+	assert.Equal(t, cmd.status, "configured")
+}
+
+func TestCmdAccessGrantCreate_Run(t *testing.T) {
+	type test struct {
+		name                string
+		skupperErrorMessage string
+		errorMessage        string
+	}
+
+	testTable := []test{
+		{
+			name: "runs ok",
+		},
+		{
+			name:                "run fails",
+			skupperErrorMessage: "error",
+			errorMessage:        "error",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdAccessGrantCreateWithMocks("test", nil, nil, test.skupperErrorMessage)
+		assert.Assert(t, err)
+		cmd.name = "my-grant"
+		cmd.redemptionsAllowed = 2
+		cmd.expirationWindow = "1h0m0s"
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Error(t, err, test.errorMessage)
+			} else {
+				assert.Assert(t, err)
+				grant, err := cmd.client.AccessGrants("test").Get(t.Context(), "my-grant", v1.GetOptions{})
+				assert.Assert(t, err)
+				assert.Equal(t, grant.Spec.RedemptionsAllowed, 2)
+				assert.Equal(t, grant.Spec.ExpirationWindow, "1h0m0s")
+			}
+		})
+	}
+}
+
+func TestCmdAccessGrantCreate_WaitUntil(t *testing.T) {
+	type test struct {
+		name           string
+		status         string
+		skupperObjects []runtime.Object
+		expectError    bool
+	}
+
+	testTable := []test{
+		{
+			name:   "access grant is not ready",
+			status: "ready",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.AccessGrant{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-grant",
+						Namespace: "test",
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name:        "access grant is not returned",
+			status:      "ready",
+			expectError: true,
+		},
+		{
+			name:   "access grant is ready",
+			status: "ready",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.AccessGrant{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-grant",
+						Namespace: "test",
+					},
+					Status: v2alpha1.AccessGrantStatus{
+						Status: v2alpha1.Status{
+							Conditions: []v1.Condition{
+								{
+									Type:   "Ready",
+									Status: "True",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "access grant is processed",
+			status: "configured",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.AccessGrant{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-grant",
+						Namespace: "test",
+					},
+					Status: v2alpha1.AccessGrantStatus{
+						Status: v2alpha1.Status{
+							Conditions: []v1.Condition{
+								{
+									Type:   "Processed",
+									Status: "True",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "access grant could not be processed",
+			status: "configured",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.AccessGrant{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-grant",
+						Namespace: "test",
+					},
+					Status: v2alpha1.AccessGrantStatus{
+						Status: v2alpha1.Status{
+							Conditions: []v1.Condition{
+								{
+									Message: "Invalid duration",
+									Reason:  "Error",
+									Type:    "Processed",
+									Status:  "False",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name:        "user does not wait",
+			status:      "none",
+			expectError: false,
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdAccessGrantCreateWithMocks("test", nil, test.skupperObjects, "")
+		assert.Assert(t, err)
+
+		cmd.name = "my-grant"
+		cmd.timeout = 1 * time.Second
+		cmd.status = test.status
+
+		t.Run(test.name, func(t *testing.T) {
+
+			err := cmd.WaitUntil()
+			if test.expectError {
+				assert.Check(t, err != nil)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdAccessGrantCreateWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdAccessGrantCreate, error) {
+
+	// We make sure the interval is appropriate
+	utils.SetRetryProfile(utils.TestRetryProfile)
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdAccessGrantCreate := &CmdAccessGrantCreate{
+		client:    client.GetSkupperClient().SkupperV2alpha1(),
+		namespace: namespace,
+	}
+	return cmdAccessGrantCreate, nil
+}
@@ -0,0 +1,175 @@
+package kube
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"gotest.tools/v3/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdAccessGrantDelete_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		flags          common.CommandAccessGrantDeleteFlags
+		skupperObjects []runtime.Object
+		skupperError   string
+		expectedError  string
+	}
+
+	testTable := []test{
+		{
+			name:          "missing CRD",
+			args:          []string{"my-grant"},
+			skupperError:  utils.CrdErr,
+			expectedError: utils.CrdHelpErr,
+		},
+		{
+			name:          "access grant is not specified",
+			expectedError: "access grant name must be specified",
+		},
+		{
+			name:          "access grant name is nil",
+			args:          []string{""},
+			expectedError: "access grant name must not be empty",
+		},
+		{
+			name:          "more than one argument is specified",
+			args:          []string{"my", "grant"},
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:          "access grant does not exist",
+			args:          []string{"my-grant"},
+			flags:         common.CommandAccessGrantDeleteFlags{Timeout: time.Minute},
+			expectedError: "access grant my-grant does not exist in namespace test",
+		},
+		{
+			name:           "timeout is not valid",
+			args:           []string{"my-grant"},
+			flags:          common.CommandAccessGrantDeleteFlags{Timeout: 0},
+			skupperObjects: []runtime.Object{existingAccessGrant()},
+			expectedError:  "timeout is not valid: duration must not be less than 10s; got 0s",
+		},
+		{
+			name:           "access grant exists",
+			args:           []string{"my-grant"},
+			flags:          common.CommandAccessGrantDeleteFlags{Timeout: time.Minute},
+			skupperObjects: []runtime.Object{existingAccessGrant()},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdAccessGrantDeleteWithMocks("test", nil, test.skupperObjects, test.skupperError)
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdAccessGrantDelete_Run(t *testing.T) {
+	type test struct {
+		name                string
+		skupperObjects      []runtime.Object
+		skupperErrorMessage string
+		errorMessage        string
+	}
+
+	testTable := []test{
+		{
+			name:           "runs ok",
+			skupperObjects: []runtime.Object{existingAccessGrant()},
+		},
+		{
+			name:                "run fails",
+			skupperErrorMessage: "error",
+			errorMessage:        "error",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdAccessGrantDeleteWithMocks("test", nil, test.skupperObjects, test.skupperErrorMessage)
+		assert.Assert(t, err)
+		cmd.name = "my-grant"
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Error(t, err, test.errorMessage)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+func TestCmdAccessGrantDelete_WaitUntil(t *testing.T) {
+	type test struct {
+		name           string
+		wait           bool
+		skupperObjects []runtime.Object
+		expectError    bool
+	}
+
+	testTable := []test{
+		{
+			name:           "access grant is not deleted",
+			wait:           true,
+			skupperObjects: []runtime.Object{existingAccessGrant()},
+			expectError:    true,
+		},
+		{
+			name: "access grant is deleted",
+			wait: true,
+		},
+		{
+			name:           "user does not wait",
+			wait:           false,
+			skupperObjects: []runtime.Object{existingAccessGrant()},
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdAccessGrantDeleteWithMocks("test", nil, test.skupperObjects, "")
+		assert.Assert(t, err)
+		cmd.name = "my-grant"
+		cmd.Flags = &common.CommandAccessGrantDeleteFlags{Timeout: time.Second, Wait: test.wait}
+		cmd.InputToOptions()
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.WaitUntil()
+			if test.expectError {
+				assert.Check(t, err != nil)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdAccessGrantDeleteWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdAccessGrantDelete, error) {
+
+	// We make sure the interval is appropriate
+	utils.SetRetryProfile(utils.TestRetryProfile)
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdAccessGrantDelete := &CmdAccessGrantDelete{
+		client:    client.GetSkupperClient().SkupperV2alpha1(),
+		namespace: namespace,
+	}
+	return cmdAccessGrantDelete, nil
+}
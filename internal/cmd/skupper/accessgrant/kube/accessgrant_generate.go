@@ -0,0 +1,112 @@
+package kube
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CmdAccessGrantGenerate struct {
+	CobraCmd           *cobra.Command
+	Flags              *common.CommandAccessGrantGenerateFlags
+	namespace          string
+	name               string
+	redemptionsAllowed int
+	expirationWindow   string
+	output             string
+}
+
+func NewCmdAccessGrantGenerate() *CmdAccessGrantGenerate {
+
+	return &CmdAccessGrantGenerate{}
+
+}
+
+func (cmd *CmdAccessGrantGenerate) NewClient(cobraCommand *cobra.Command, args []string) {
+
+	cmd.namespace = cobraCommand.Flag("namespace").Value.String()
+}
+
+func (cmd *CmdAccessGrantGenerate) ValidateInput(args []string) error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	numberValidator := validator.NewNumberValidator()
+	expirationValidator := validator.NewExpirationInSecondsValidator()
+	outputTypeValidator := validator.NewOptionValidator(common.OutputTypes)
+
+	// Validate arguments name
+	if len(args) < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("access grant name must be configured"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("access grant name must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("access grant name is not valid: %s", err))
+		} else {
+			cmd.name = args[0]
+		}
+	}
+
+	// Validate flags
+	if cmd.Flags != nil {
+		ok, err := numberValidator.Evaluate(cmd.Flags.RedemptionsAllowed)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("number of redemptions is not valid: %s", err))
+		}
+	}
+
+	if cmd.Flags != nil {
+		ok, err := expirationValidator.Evaluate(cmd.Flags.ExpirationWindow)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("expiration time is not valid: %s", err))
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		}
+	}
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdAccessGrantGenerate) InputToOptions() {
+	cmd.redemptionsAllowed = cmd.Flags.RedemptionsAllowed
+	cmd.expirationWindow = cmd.Flags.ExpirationWindow.String()
+	cmd.output = cmd.Flags.Output
+}
+
+func (cmd *CmdAccessGrantGenerate) Run() error {
+
+	resource := v2alpha1.AccessGrant{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "AccessGrant",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cmd.name,
+			Namespace: cmd.namespace,
+		},
+		Spec: v2alpha1.AccessGrantSpec{
+			RedemptionsAllowed: cmd.redemptionsAllowed,
+			ExpirationWindow:   cmd.expirationWindow,
+		},
+	}
+
+	encodedOutput, err := utils.Encode(cmd.output, resource)
+	fmt.Println(encodedOutput)
+	return err
+}
+
+func (cmd *CmdAccessGrantGenerate) WaitUntil() error { return nil }
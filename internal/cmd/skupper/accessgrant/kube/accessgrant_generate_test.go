@@ -0,0 +1,104 @@
+package kube
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"gotest.tools/v3/assert"
+)
+
+func TestCmdAccessGrantGenerate_ValidateInput(t *testing.T) {
+	type test struct {
+		name          string
+		args          []string
+		flags         common.CommandAccessGrantGenerateFlags
+		expectedError string
+	}
+
+	testTable := []test{
+		{
+			name:          "access grant name is not specified",
+			flags:         common.CommandAccessGrantGenerateFlags{RedemptionsAllowed: 1, ExpirationWindow: time.Hour},
+			expectedError: "access grant name must be configured",
+		},
+		{
+			name:          "access grant name is not valid",
+			args:          []string{"my grant"},
+			flags:         common.CommandAccessGrantGenerateFlags{RedemptionsAllowed: 1, ExpirationWindow: time.Hour},
+			expectedError: "access grant name is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])*(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])*)*$",
+		},
+		{
+			name:          "redemptions allowed is not valid",
+			args:          []string{"my-grant"},
+			flags:         common.CommandAccessGrantGenerateFlags{RedemptionsAllowed: -1, ExpirationWindow: time.Hour},
+			expectedError: "number of redemptions is not valid: value is not positive",
+		},
+		{
+			name:          "output format is not valid",
+			args:          []string{"my-grant"},
+			flags:         common.CommandAccessGrantGenerateFlags{RedemptionsAllowed: 1, ExpirationWindow: time.Hour, Output: "not-supported"},
+			expectedError: "output type is not valid: value not-supported not allowed. It should be one of this options: [json yaml]",
+		},
+		{
+			name:  "flags all valid",
+			args:  []string{"my-grant"},
+			flags: common.CommandAccessGrantGenerateFlags{RedemptionsAllowed: 1, ExpirationWindow: time.Hour, Output: "json"},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdAccessGrantGenerate{Flags: &test.flags}
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdAccessGrantGenerate_Run(t *testing.T) {
+	type test struct {
+		name         string
+		output       string
+		errorMessage string
+	}
+
+	testTable := []test{
+		{
+			name:   "runs ok with yaml output",
+			output: "yaml",
+		},
+		{
+			name:   "runs ok with json output",
+			output: "json",
+		},
+		{
+			name:         "run fails with unsupported output",
+			output:       "unsupported",
+			errorMessage: "format unsupported not supported",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd := &CmdAccessGrantGenerate{
+			Flags: &common.CommandAccessGrantGenerateFlags{
+				RedemptionsAllowed: 2,
+				ExpirationWindow:   time.Hour,
+				Output:             test.output,
+			},
+			name:      "my-grant",
+			namespace: "test",
+		}
+		cmd.InputToOptions()
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Error(t, err, test.errorMessage)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
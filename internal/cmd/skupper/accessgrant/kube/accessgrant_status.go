@@ -0,0 +1,142 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	"github.com/skupperproject/skupper/internal/kube/client"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/generated/client/clientset/versioned/typed/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	k8serrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CmdAccessGrantStatus struct {
+	client    skupperv2alpha1.SkupperV2alpha1Interface
+	CobraCmd  *cobra.Command
+	Flags     *common.CommandAccessGrantStatusFlags
+	namespace string
+	name      string
+	output    string
+}
+
+func NewCmdAccessGrantStatus() *CmdAccessGrantStatus {
+
+	return &CmdAccessGrantStatus{}
+}
+
+func (cmd *CmdAccessGrantStatus) NewClient(cobraCommand *cobra.Command, args []string) {
+	cli, err := client.NewClient(cobraCommand.Flag("namespace").Value.String(), cobraCommand.Flag("context").Value.String(), cobraCommand.Flag("kubeconfig").Value.String())
+	utils.HandleError(utils.GenericError, err)
+
+	cmd.client = cli.GetSkupperClient().SkupperV2alpha1()
+	cmd.namespace = cli.Namespace
+}
+
+func (cmd *CmdAccessGrantStatus) ValidateInput(args []string) error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	outputTypeValidator := validator.NewOptionValidator(common.OutputTypes)
+
+	// Check if AccessGrant CRD is installed
+	_, err := cmd.client.AccessGrants(cmd.namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		validationErrors = append(validationErrors, utils.HandleMissingCrds(err))
+		return errors.Join(validationErrors...)
+	}
+
+	// Validate arguments name if specified
+	if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if len(args) == 1 {
+		if args[0] == "" {
+			validationErrors = append(validationErrors, fmt.Errorf("access grant name must not be empty"))
+		} else {
+			ok, err := resourceStringValidator.Evaluate(args[0])
+			if !ok {
+				validationErrors = append(validationErrors, fmt.Errorf("access grant name is not valid: %s", err))
+			} else {
+				cmd.name = args[0]
+			}
+		}
+	}
+
+	// Validate that there is an access grant with this name in the namespace
+	if cmd.name != "" {
+		grant, err := cmd.client.AccessGrants(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if err != nil || grant == nil {
+			validationErrors = append(validationErrors, fmt.Errorf("access grant %s does not exist in namespace %s", cmd.name, cmd.namespace))
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		} else {
+			cmd.output = cmd.Flags.Output
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdAccessGrantStatus) Run() error {
+	if cmd.name == "" {
+		resources, err := cmd.client.AccessGrants(cmd.namespace).List(context.TODO(), metav1.ListOptions{})
+		if err != nil || resources == nil || len(resources.Items) == 0 {
+			fmt.Println("No access grants found")
+			return err
+		}
+		if cmd.output != "" {
+			for _, resource := range resources.Items {
+				encodedOutput, err := utils.Encode(cmd.output, resource)
+				if err != nil {
+					return err
+				}
+				fmt.Println(encodedOutput)
+			}
+		} else {
+			tw := tabwriter.NewWriter(os.Stdout, 8, 8, 1, '\t', tabwriter.TabIndent)
+			_, _ = fmt.Fprintln(tw, fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s",
+				"NAME", "STATUS", "REDEMPTIONS-ALLOWED", "REDEMPTIONS", "EXPIRATION", "MESSAGE"))
+			for _, resource := range resources.Items {
+				fmt.Fprintln(tw, fmt.Sprintf("%s\t%s\t%d\t%d\t%s\t%s",
+					resource.Name, resource.Status.StatusType, resource.Spec.RedemptionsAllowed, resource.Status.Redemptions,
+					resource.Status.ExpirationTime, resource.Status.Message))
+			}
+			_ = tw.Flush()
+		}
+	} else {
+		resource, err := cmd.client.AccessGrants(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if err != nil || resource == nil || k8serrs.IsNotFound(err) {
+			fmt.Println("No access grants found")
+			return err
+		}
+		if cmd.output != "" {
+			encodedOutput, err := utils.Encode(cmd.output, resource)
+			if err != nil {
+				return err
+			}
+			fmt.Println(encodedOutput)
+		} else {
+			tw := tabwriter.NewWriter(os.Stdout, 8, 8, 1, '\t', tabwriter.TabIndent)
+			fmt.Fprintln(tw, fmt.Sprintf("Name:\t%s\nStatus:\t%s\nRedemptions Allowed:\t%d\nRedemptions:\t%d\nExpiration Window:\t%s\nExpiration:\t%s\nURL:\t%s\nMessage:\t%s\n",
+				resource.Name, resource.Status.StatusType, resource.Spec.RedemptionsAllowed, resource.Status.Redemptions,
+				resource.Spec.ExpirationWindow, resource.Status.ExpirationTime, resource.Status.Url, resource.Status.Message))
+			_ = tw.Flush()
+		}
+	}
+
+	return nil
+}
+
+func (cmd *CmdAccessGrantStatus) InputToOptions()  {}
+func (cmd *CmdAccessGrantStatus) WaitUntil() error { return nil }
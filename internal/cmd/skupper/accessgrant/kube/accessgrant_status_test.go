@@ -0,0 +1,150 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"gotest.tools/v3/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdAccessGrantStatus_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		flags          common.CommandAccessGrantStatusFlags
+		skupperObjects []runtime.Object
+		expectedError  string
+		skupperError   string
+	}
+
+	testTable := []test{
+		{
+			name:          "missing CRD",
+			args:          []string{"my-grant"},
+			skupperError:  utils.CrdErr,
+			expectedError: utils.CrdHelpErr,
+		},
+		{
+			name:          "access grant does not exist in the namespace",
+			args:          []string{"my-grant"},
+			expectedError: "access grant my-grant does not exist in namespace test",
+		},
+		{
+			name:          "access grant name is nil",
+			args:          []string{""},
+			expectedError: "access grant name must not be empty",
+		},
+		{
+			name:          "more than one argument is specified",
+			args:          []string{"my", "grant"},
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:          "no args",
+			expectedError: "",
+		},
+		{
+			name:           "bad output status",
+			args:           []string{"my-grant"},
+			flags:          common.CommandAccessGrantStatusFlags{Output: "not-supported"},
+			skupperObjects: []runtime.Object{existingAccessGrant()},
+			expectedError:  "output type is not valid: value not-supported not allowed. It should be one of this options: [json yaml]",
+		},
+		{
+			name:           "good output status",
+			args:           []string{"my-grant"},
+			flags:          common.CommandAccessGrantStatusFlags{Output: "json"},
+			skupperObjects: []runtime.Object{existingAccessGrant()},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdAccessGrantStatusWithMocks("test", nil, test.skupperObjects, test.skupperError)
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdAccessGrantStatus_Run(t *testing.T) {
+	type test struct {
+		name                string
+		grantName           string
+		output              string
+		skupperObjects      []runtime.Object
+		skupperErrorMessage string
+		errorMessage        string
+	}
+
+	testTable := []test{
+		{
+			name:                "run fails",
+			grantName:           "my-grant",
+			skupperErrorMessage: "error",
+			errorMessage:        "error",
+		},
+		{
+			name:           "runs ok",
+			grantName:      "my-grant",
+			skupperObjects: []runtime.Object{existingAccessGrant()},
+		},
+		{
+			name:           "runs ok with yaml output",
+			grantName:      "my-grant",
+			output:         "yaml",
+			skupperObjects: []runtime.Object{existingAccessGrant()},
+		},
+		{
+			name:           "runs ok listing access grants",
+			skupperObjects: []runtime.Object{existingAccessGrant()},
+		},
+		{
+			name:           "runs ok listing access grants with json output",
+			output:         "json",
+			skupperObjects: []runtime.Object{existingAccessGrant()},
+		},
+		{
+			name: "runs ok with no access grants",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdAccessGrantStatusWithMocks("test", nil, test.skupperObjects, test.skupperErrorMessage)
+		assert.Assert(t, err)
+		cmd.name = test.grantName
+		cmd.output = test.output
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Error(t, err, test.errorMessage)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdAccessGrantStatusWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdAccessGrantStatus, error) {
+
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdAccessGrantStatus := &CmdAccessGrantStatus{
+		client:    client.GetSkupperClient().SkupperV2alpha1(),
+		namespace: namespace,
+	}
+	return cmdAccessGrantStatus, nil
+}
@@ -0,0 +1,213 @@
+package kube
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func existingAccessGrant() *v2alpha1.AccessGrant {
+	return &v2alpha1.AccessGrant{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "my-grant",
+			Namespace: "test",
+		},
+		Spec: v2alpha1.AccessGrantSpec{
+			RedemptionsAllowed: 1,
+			ExpirationWindow:   "15m0s",
+			Code:               "secret",
+		},
+		Status: v2alpha1.AccessGrantStatus{
+			Status: v2alpha1.Status{
+				Conditions: []v1.Condition{
+					{
+						Type:   "Ready",
+						Status: "True",
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestCmdAccessGrantUpdate_ValidateInput(t *testing.T) {
+	type test struct {
+		name                string
+		args                []string
+		flags               common.CommandAccessGrantUpdateFlags
+		skupperObjects      []runtime.Object
+		skupperErrorMessage string
+		expectedError       string
+		expectedSettings    AccessGrantUpdates
+	}
+
+	testTable := []test{
+		{
+			name:                "missing CRD",
+			args:                []string{"my-grant"},
+			skupperErrorMessage: utils.CrdErr,
+			expectedError:       utils.CrdHelpErr,
+		},
+		{
+			name:          "access grant does not exist",
+			args:          []string{"my-grant"},
+			flags:         common.CommandAccessGrantUpdateFlags{Timeout: time.Minute},
+			expectedError: "access grant my-grant must exist in namespace test to be updated",
+		},
+		{
+			name:           "access grant name is not specified",
+			flags:          common.CommandAccessGrantUpdateFlags{Timeout: time.Minute},
+			skupperObjects: []runtime.Object{existingAccessGrant()},
+			expectedError:  "access grant name must be configured",
+		},
+		{
+			name:           "more than one argument is specified",
+			args:           []string{"my", "grant"},
+			flags:          common.CommandAccessGrantUpdateFlags{Timeout: time.Minute},
+			skupperObjects: []runtime.Object{existingAccessGrant()},
+			expectedError:  "only one argument is allowed for this command",
+		},
+		{
+			name:           "redemptions allowed is not valid",
+			args:           []string{"my-grant"},
+			flags:          common.CommandAccessGrantUpdateFlags{RedemptionsAllowed: -2, Timeout: time.Minute},
+			skupperObjects: []runtime.Object{existingAccessGrant()},
+			expectedError:  "number of redemptions is not valid: value is not positive",
+		},
+		{
+			name:           "expiration window is not valid",
+			args:           []string{"my-grant"},
+			flags:          common.CommandAccessGrantUpdateFlags{ExpirationWindow: time.Second, Timeout: time.Minute},
+			skupperObjects: []runtime.Object{existingAccessGrant()},
+			expectedError:  "expiration time is not valid: duration must not be less than 1m0s; got 1s",
+		},
+		{
+			name:           "wait status is not valid",
+			args:           []string{"my-grant"},
+			flags:          common.CommandAccessGrantUpdateFlags{Timeout: time.Minute, Wait: "redeemed"},
+			skupperObjects: []runtime.Object{existingAccessGrant()},
+			expectedError:  "status is not valid: value redeemed not allowed. It should be one of this options: [ready configured none]",
+		},
+		{
+			name:           "unspecified options keep their value",
+			args:           []string{"my-grant"},
+			flags:          common.CommandAccessGrantUpdateFlags{RedemptionsAllowed: 5, Timeout: time.Minute},
+			skupperObjects: []runtime.Object{existingAccessGrant()},
+			expectedSettings: AccessGrantUpdates{
+				redemptionsAllowed: 5,
+				expirationWindow:   "15m0s",
+			},
+		},
+		{
+			name:           "all options updated",
+			args:           []string{"my-grant"},
+			flags:          common.CommandAccessGrantUpdateFlags{RedemptionsAllowed: 2, ExpirationWindow: time.Hour, Timeout: time.Minute},
+			skupperObjects: []runtime.Object{existingAccessGrant()},
+			expectedSettings: AccessGrantUpdates{
+				redemptionsAllowed: 2,
+				expirationWindow:   "1h0m0s",
+			},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdAccessGrantUpdateWithMocks("test", nil, test.skupperObjects, test.skupperErrorMessage)
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+			if test.expectedError == "" {
+				assert.Equal(t, command.newSettings, test.expectedSettings)
+			}
+		})
+	}
+}
+
+func TestCmdAccessGrantUpdate_Run(t *testing.T) {
+	command, err := newCmdAccessGrantUpdateWithMocks("test", nil, []runtime.Object{existingAccessGrant()}, "")
+	assert.Assert(t, err)
+	command.Flags = &common.CommandAccessGrantUpdateFlags{RedemptionsAllowed: 4, Timeout: time.Minute}
+	assert.Assert(t, command.ValidateInput([]string{"my-grant"}))
+	command.InputToOptions()
+
+	assert.Assert(t, command.Run())
+
+	grant, err := command.client.AccessGrants("test").Get(t.Context(), "my-grant", v1.GetOptions{})
+	assert.Assert(t, err)
+	assert.Equal(t, grant.Spec.RedemptionsAllowed, 4)
+	assert.Equal(t, grant.Spec.ExpirationWindow, "15m0s")
+	assert.Equal(t, grant.Spec.Code, "secret")
+}
+
+func TestCmdAccessGrantUpdate_WaitUntil(t *testing.T) {
+	type test struct {
+		name           string
+		status         string
+		skupperObjects []runtime.Object
+		expectError    bool
+	}
+
+	testTable := []test{
+		{
+			name:           "access grant is ready",
+			status:         "ready",
+			skupperObjects: []runtime.Object{existingAccessGrant()},
+		},
+		{
+			name:        "access grant is not returned",
+			status:      "ready",
+			expectError: true,
+		},
+		{
+			name:   "user does not wait",
+			status: "none",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdAccessGrantUpdateWithMocks("test", nil, test.skupperObjects, "")
+		assert.Assert(t, err)
+
+		cmd.name = "my-grant"
+		cmd.Flags = &common.CommandAccessGrantUpdateFlags{Timeout: time.Second}
+		cmd.status = test.status
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.WaitUntil()
+			if test.expectError {
+				assert.Check(t, err != nil)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdAccessGrantUpdateWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdAccessGrantUpdate, error) {
+
+	// We make sure the interval is appropriate
+	utils.SetRetryProfile(utils.TestRetryProfile)
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdAccessGrantUpdate := &CmdAccessGrantUpdate{
+		client:    client.GetSkupperClient().SkupperV2alpha1(),
+		namespace: namespace,
+	}
+	return cmdAccessGrantUpdate, nil
+}
@@ -0,0 +1,25 @@
+package nonkube
+
+import (
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/spf13/cobra"
+)
+
+type CmdAccessGrantCreate struct {
+	CobraCmd *cobra.Command
+	Flags    *common.CommandAccessGrantCreateFlags
+}
+
+func NewCmdAccessGrantCreate() *CmdAccessGrantCreate {
+	return &CmdAccessGrantCreate{}
+}
+
+func (cmd *CmdAccessGrantCreate) NewClient(cobraCommand *cobra.Command, args []string) {}
+func (cmd *CmdAccessGrantCreate) ValidateInput(args []string) error                    { return nil }
+func (cmd *CmdAccessGrantCreate) InputToOptions()                                      {}
+func (cmd *CmdAccessGrantCreate) Run() error {
+	return fmt.Errorf("command not supported by the selected platform")
+}
+func (cmd *CmdAccessGrantCreate) WaitUntil() error { return nil }
@@ -0,0 +1,25 @@
+package nonkube
+
+import (
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/spf13/cobra"
+)
+
+type CmdAccessGrantDelete struct {
+	CobraCmd *cobra.Command
+	Flags    *common.CommandAccessGrantDeleteFlags
+}
+
+func NewCmdAccessGrantDelete() *CmdAccessGrantDelete {
+	return &CmdAccessGrantDelete{}
+}
+
+func (cmd *CmdAccessGrantDelete) NewClient(cobraCommand *cobra.Command, args []string) {}
+func (cmd *CmdAccessGrantDelete) ValidateInput(args []string) error                    { return nil }
+func (cmd *CmdAccessGrantDelete) InputToOptions()                                      {}
+func (cmd *CmdAccessGrantDelete) Run() error {
+	return fmt.Errorf("command not supported by the selected platform")
+}
+func (cmd *CmdAccessGrantDelete) WaitUntil() error { return nil }
@@ -0,0 +1,110 @@
+package nonkube
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+)
+
+func TestNonKubeCmdAccessGrantGenerate_ValidateInput(t *testing.T) {
+	type test struct {
+		name              string
+		args              []string
+		flags             *common.CommandAccessGrantGenerateFlags
+		cobraGenericFlags map[string]string
+		expectedError     string
+	}
+
+	testTable := []test{
+		{
+			name:          "access grant name is not specified",
+			flags:         &common.CommandAccessGrantGenerateFlags{RedemptionsAllowed: 1, ExpirationWindow: time.Hour},
+			expectedError: "access grant name must be configured",
+		},
+		{
+			name:          "access grant name is empty",
+			args:          []string{""},
+			flags:         &common.CommandAccessGrantGenerateFlags{RedemptionsAllowed: 1, ExpirationWindow: time.Hour},
+			expectedError: "access grant name must not be empty",
+		},
+		{
+			name:          "expiration window is not valid",
+			args:          []string{"my-grant"},
+			flags:         &common.CommandAccessGrantGenerateFlags{RedemptionsAllowed: 1, ExpirationWindow: time.Second},
+			expectedError: "expiration time is not valid: duration must not be less than 1m0s; got 1s",
+		},
+		{
+			name:          "output format is not valid",
+			args:          []string{"my-grant"},
+			flags:         &common.CommandAccessGrantGenerateFlags{RedemptionsAllowed: 1, ExpirationWindow: time.Hour, Output: "not-supported"},
+			expectedError: "output type is not valid: value not-supported not allowed. It should be one of this options: [json yaml]",
+		},
+		{
+			name:  "kubernetes flags are not valid on this platform",
+			args:  []string{"my-grant"},
+			flags: &common.CommandAccessGrantGenerateFlags{RedemptionsAllowed: 1, ExpirationWindow: time.Hour},
+			cobraGenericFlags: map[string]string{
+				common.FlagNameContext:    "test",
+				common.FlagNameKubeconfig: "test",
+			},
+		},
+	}
+
+	for _, test := range testTable {
+		command := &CmdAccessGrantGenerate{Flags: test.flags}
+		command.CobraCmd = &cobra.Command{Use: "test"}
+		for name, value := range test.cobraGenericFlags {
+			command.CobraCmd.Flags().String(name, value, "")
+		}
+
+		t.Run(test.name, func(t *testing.T) {
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestNonKubeCmdAccessGrantGenerate_Run(t *testing.T) {
+	type test struct {
+		name         string
+		output       string
+		errorMessage string
+	}
+
+	testTable := []test{
+		{
+			name:   "runs ok",
+			output: "yaml",
+		},
+		{
+			name:         "runs fails because the output format is not supported",
+			output:       "unsupported",
+			errorMessage: "format unsupported not supported",
+		},
+	}
+
+	for _, test := range testTable {
+		command := &CmdAccessGrantGenerate{
+			Flags: &common.CommandAccessGrantGenerateFlags{
+				RedemptionsAllowed: 1,
+				ExpirationWindow:   time.Hour,
+				Output:             test.output,
+			},
+			grantName: "my-grant",
+		}
+		command.InputToOptions()
+		assert.Equal(t, command.namespace, "default")
+
+		t.Run(test.name, func(t *testing.T) {
+			err := command.Run()
+			if test.errorMessage != "" {
+				assert.Error(t, err, test.errorMessage)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
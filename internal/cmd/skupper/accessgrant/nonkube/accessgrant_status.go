@@ -0,0 +1,25 @@
+package nonkube
+
+import (
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/spf13/cobra"
+)
+
+type CmdAccessGrantStatus struct {
+	CobraCmd *cobra.Command
+	Flags    *common.CommandAccessGrantStatusFlags
+}
+
+func NewCmdAccessGrantStatus() *CmdAccessGrantStatus {
+	return &CmdAccessGrantStatus{}
+}
+
+func (cmd *CmdAccessGrantStatus) NewClient(cobraCommand *cobra.Command, args []string) {}
+func (cmd *CmdAccessGrantStatus) ValidateInput(args []string) error                    { return nil }
+func (cmd *CmdAccessGrantStatus) InputToOptions()                                      {}
+func (cmd *CmdAccessGrantStatus) Run() error {
+	return fmt.Errorf("command not supported by the selected platform")
+}
+func (cmd *CmdAccessGrantStatus) WaitUntil() error { return nil }
@@ -0,0 +1,25 @@
+package nonkube
+
+import (
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/spf13/cobra"
+)
+
+type CmdAccessGrantUpdate struct {
+	CobraCmd *cobra.Command
+	Flags    *common.CommandAccessGrantUpdateFlags
+}
+
+func NewCmdAccessGrantUpdate() *CmdAccessGrantUpdate {
+	return &CmdAccessGrantUpdate{}
+}
+
+func (cmd *CmdAccessGrantUpdate) NewClient(cobraCommand *cobra.Command, args []string) {}
+func (cmd *CmdAccessGrantUpdate) ValidateInput(args []string) error                    { return nil }
+func (cmd *CmdAccessGrantUpdate) InputToOptions()                                      {}
+func (cmd *CmdAccessGrantUpdate) Run() error {
+	return fmt.Errorf("command not supported by the selected platform")
+}
+func (cmd *CmdAccessGrantUpdate) WaitUntil() error { return nil }
@@ -0,0 +1,357 @@
+package attachedconnector
+
+import (
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/attachedconnector/kube"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/attachedconnector/nonkube"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdAttachedConnector() *cobra.Command {
+
+	cmd := &cobra.Command{
+		Use:   "attached-connector",
+		Short: "A connector in a namespace other than the one where the site is running",
+		Long: `An attached connector binds server pods in its own namespace to a site running in another namespace.
+It only takes effect when the site namespace has a matching attached connector binding.`,
+		Example: `skupper attached-connector create backend 8080 --site-namespace west
+skupper attached-connector status backend`,
+	}
+
+	platform := common.Platform(config.GetPlatform())
+	cmd.AddCommand(CmdAttachedConnectorCreateFactory(platform))
+	cmd.AddCommand(CmdAttachedConnectorStatusFactory(platform))
+	cmd.AddCommand(CmdAttachedConnectorUpdateFactory(platform))
+	cmd.AddCommand(CmdAttachedConnectorDeleteFactory(platform))
+	cmd.AddCommand(CmdAttachedConnectorGenerateFactory(platform))
+
+	return cmd
+}
+
+func NewCmdAttachedConnectorBinding() *cobra.Command {
+
+	cmd := &cobra.Command{
+		Use:   "attached-connector-binding",
+		Short: "Accept an attached connector from another namespace into the site",
+		Long: `An attached connector binding is created in the site namespace and exposes the pods
+selected by an attached connector in another namespace under a routing key.`,
+		Example: `skupper attached-connector-binding create backend --connector-namespace east
+skupper attached-connector-binding status backend`,
+	}
+
+	platform := common.Platform(config.GetPlatform())
+	cmd.AddCommand(CmdAttachedConnectorBindingCreateFactory(platform))
+	cmd.AddCommand(CmdAttachedConnectorBindingStatusFactory(platform))
+	cmd.AddCommand(CmdAttachedConnectorBindingUpdateFactory(platform))
+	cmd.AddCommand(CmdAttachedConnectorBindingDeleteFactory(platform))
+	cmd.AddCommand(CmdAttachedConnectorBindingGenerateFactory(platform))
+
+	return cmd
+}
+
+func CmdAttachedConnectorCreateFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdAttachedConnectorCreate()
+	nonKubeCommand := nonkube.NewCmdAttachedConnectorCreate()
+
+	cmdAttachedConnectorCreateDesc := common.SkupperCmdDescription{
+		Use:   "create <name> <port>",
+		Short: "create an attached connector",
+		Long: `Create an attached connector for the pods in the current namespace that are selected by the selector.
+The site namespace must contain an attached connector binding with the same name.`,
+		Example: "skupper attached-connector create backend 8080 --site-namespace west --selector app=backend",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdAttachedConnectorCreateDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandAttachedConnectorCreateFlags{}
+
+	cmd.Flags().StringVar(&cmdFlags.SiteNamespace, common.FlagNameSiteNamespace, "", common.FlagDescSiteNamespace)
+	cmd.Flags().StringVar(&cmdFlags.Selector, common.FlagNameSelector, "", common.FlagDescSelector)
+	cmd.Flags().StringVar(&cmdFlags.TlsCredentials, common.FlagNameTlsCredentials, "", common.FlagDescTlsCredentials)
+	cmd.Flags().BoolVar(&cmdFlags.UseClientCert, common.FlagNameUseClientCert, false, common.FlagDescUseClientCert)
+	cmd.Flags().StringVar(&cmdFlags.ConnectorType, common.FlagNameConnectorType, "tcp", common.FlagDescConnectorType)
+	cmd.Flags().BoolVar(&cmdFlags.IncludeNotReadyPods, common.FlagNameIncludeNotReadyPods, false, common.FlagDescIncludeNotRead)
+
+	if configuredPlatform == common.PlatformKubernetes {
+		cmd.Flags().DurationVar(&cmdFlags.Timeout, common.FlagNameTimeout, 60*time.Second, common.FlagDescTimeout)
+		cmd.Flags().StringVar(&cmdFlags.Wait, common.FlagNameWait, "ready", common.FlagDescWait)
+	}
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
+
+func CmdAttachedConnectorUpdateFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdAttachedConnectorUpdate()
+	nonKubeCommand := nonkube.NewCmdAttachedConnectorUpdate()
+
+	cmdAttachedConnectorUpdateDesc := common.SkupperCmdDescription{
+		Use:   "update <name>",
+		Short: "update an attached connector",
+		Long: `Change attached connector parameters.
+	Options that are not specified keep their current value.`,
+		Example: "skupper attached-connector update backend --port 9090",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdAttachedConnectorUpdateDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandAttachedConnectorUpdateFlags{}
+
+	cmd.Flags().StringVar(&cmdFlags.Selector, common.FlagNameSelector, "", common.FlagDescSelector)
+	cmd.Flags().IntVar(&cmdFlags.Port, common.FlagNameAttachedPort, 0, common.FlagDescAttachedPort)
+	cmd.Flags().StringVar(&cmdFlags.TlsCredentials, common.FlagNameTlsCredentials, "", common.FlagDescTlsCredentials)
+	cmd.Flags().BoolVar(&cmdFlags.UseClientCert, common.FlagNameUseClientCert, false, common.FlagDescUseClientCert)
+	cmd.Flags().StringVar(&cmdFlags.ConnectorType, common.FlagNameConnectorType, "", common.FlagDescConnectorType)
+	cmd.Flags().BoolVar(&cmdFlags.IncludeNotReadyPods, common.FlagNameIncludeNotReadyPods, false, common.FlagDescIncludeNotRead)
+
+	if configuredPlatform == common.PlatformKubernetes {
+		cmd.Flags().DurationVar(&cmdFlags.Timeout, common.FlagNameTimeout, 60*time.Second, common.FlagDescTimeout)
+		cmd.Flags().StringVar(&cmdFlags.Wait, common.FlagNameWait, "ready", common.FlagDescWait)
+	}
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
+
+func CmdAttachedConnectorStatusFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdAttachedConnectorStatus()
+	nonKubeCommand := nonkube.NewCmdAttachedConnectorStatus()
+
+	cmdAttachedConnectorStatusDesc := common.SkupperCmdDescription{
+		Use:     "status <name>",
+		Short:   "get status of attached connectors",
+		Long:    "Display status of all attached connectors or a specific attached connector",
+		Example: "skupper attached-connector status backend",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdAttachedConnectorStatusDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandAttachedConnectorStatusFlags{}
+
+	cmd.Flags().StringVarP(&cmdFlags.Output, common.FlagNameOutput, "o", "", common.FlagDescOutput)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
+
+func CmdAttachedConnectorDeleteFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdAttachedConnectorDelete()
+	nonKubeCommand := nonkube.NewCmdAttachedConnectorDelete()
+
+	cmdAttachedConnectorDeleteDesc := common.SkupperCmdDescription{
+		Use:     "delete <name>",
+		Short:   "delete an attached connector",
+		Long:    "Delete an attached connector <name>",
+		Example: "skupper attached-connector delete backend",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdAttachedConnectorDeleteDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandAttachedConnectorDeleteFlags{}
+
+	if configuredPlatform == common.PlatformKubernetes {
+		cmd.Flags().DurationVar(&cmdFlags.Timeout, common.FlagNameTimeout, 60*time.Second, common.FlagDescTimeout)
+		cmd.Flags().BoolVar(&cmdFlags.Wait, common.FlagNameWait, true, common.FlagDescDeleteWait)
+	}
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
+
+func CmdAttachedConnectorGenerateFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdAttachedConnectorGenerate()
+	nonKubeCommand := nonkube.NewCmdAttachedConnectorGenerate()
+
+	cmdAttachedConnectorGenerateDesc := common.SkupperCmdDescription{
+		Use:   "generate <name> <port>",
+		Short: "generate an attached connector resource and output it to a file or screen",
+		Long: `Create an attached connector for the pods in the current namespace that are selected by the selector.
+	generate an attached connector to evaluate what will be created with attached-connector create command`,
+		Example: "skupper attached-connector generate backend 8080 --site-namespace west",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdAttachedConnectorGenerateDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandAttachedConnectorGenerateFlags{}
+
+	cmd.Flags().StringVar(&cmdFlags.SiteNamespace, common.FlagNameSiteNamespace, "", common.FlagDescSiteNamespace)
+	cmd.Flags().StringVar(&cmdFlags.Selector, common.FlagNameSelector, "", common.FlagDescSelector)
+	cmd.Flags().StringVar(&cmdFlags.TlsCredentials, common.FlagNameTlsCredentials, "", common.FlagDescTlsCredentials)
+	cmd.Flags().BoolVar(&cmdFlags.UseClientCert, common.FlagNameUseClientCert, false, common.FlagDescUseClientCert)
+	cmd.Flags().StringVar(&cmdFlags.ConnectorType, common.FlagNameConnectorType, "tcp", common.FlagDescConnectorType)
+	cmd.Flags().BoolVar(&cmdFlags.IncludeNotReadyPods, common.FlagNameIncludeNotReadyPods, false, common.FlagDescIncludeNotRead)
+	cmd.Flags().StringVarP(&cmdFlags.Output, common.FlagNameOutput, "o", "yaml", common.FlagDescOutput)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
+
+func CmdAttachedConnectorBindingCreateFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdAttachedConnectorBindingCreate()
+	nonKubeCommand := nonkube.NewCmdAttachedConnectorBindingCreate()
+
+	cmdAttachedConnectorBindingCreateDesc := common.SkupperCmdDescription{
+		Use:   "create <name>",
+		Short: "create an attached connector binding",
+		Long: `Create an attached connector binding in the site namespace for the attached connector with the same name
+in the connector namespace. If no routing key is set, the name of the binding is used.`,
+		Example: "skupper attached-connector-binding create backend --connector-namespace east --routing-key backend",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdAttachedConnectorBindingCreateDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandAttachedConnectorBindingCreateFlags{}
+
+	cmd.Flags().StringVar(&cmdFlags.ConnectorNamespace, common.FlagNameConnectorNamespace, "", common.FlagDescConnectorNamespace)
+	cmd.Flags().StringVarP(&cmdFlags.RoutingKey, common.FlagNameRoutingKey, "r", "", common.FlagDescRoutingKey)
+	cmd.Flags().BoolVar(&cmdFlags.ExposePodsByName, common.FlagNameExposePodsByName, false, common.FlagDescExposePodsByName)
+
+	if configuredPlatform == common.PlatformKubernetes {
+		cmd.Flags().DurationVar(&cmdFlags.Timeout, common.FlagNameTimeout, 60*time.Second, common.FlagDescTimeout)
+		cmd.Flags().StringVar(&cmdFlags.Wait, common.FlagNameWait, "ready", common.FlagDescWait)
+	}
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
+
+func CmdAttachedConnectorBindingUpdateFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdAttachedConnectorBindingUpdate()
+	nonKubeCommand := nonkube.NewCmdAttachedConnectorBindingUpdate()
+
+	cmdAttachedConnectorBindingUpdateDesc := common.SkupperCmdDescription{
+		Use:   "update <name>",
+		Short: "update an attached connector binding",
+		Long: `Change attached connector binding parameters.
+	Options that are not specified keep their current value.`,
+		Example: "skupper attached-connector-binding update backend --routing-key backend-v2",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdAttachedConnectorBindingUpdateDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandAttachedConnectorBindingUpdateFlags{}
+
+	cmd.Flags().StringVarP(&cmdFlags.RoutingKey, common.FlagNameRoutingKey, "r", "", common.FlagDescRoutingKey)
+	cmd.Flags().BoolVar(&cmdFlags.ExposePodsByName, common.FlagNameExposePodsByName, false, common.FlagDescExposePodsByName)
+
+	if configuredPlatform == common.PlatformKubernetes {
+		cmd.Flags().DurationVar(&cmdFlags.Timeout, common.FlagNameTimeout, 60*time.Second, common.FlagDescTimeout)
+		cmd.Flags().StringVar(&cmdFlags.Wait, common.FlagNameWait, "ready", common.FlagDescWait)
+	}
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
+
+func CmdAttachedConnectorBindingStatusFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdAttachedConnectorBindingStatus()
+	nonKubeCommand := nonkube.NewCmdAttachedConnectorBindingStatus()
+
+	cmdAttachedConnectorBindingStatusDesc := common.SkupperCmdDescription{
+		Use:     "status <name>",
+		Short:   "get status of attached connector bindings",
+		Long:    "Display status of all attached connector bindings or a specific attached connector binding",
+		Example: "skupper attached-connector-binding status backend",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdAttachedConnectorBindingStatusDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandAttachedConnectorBindingStatusFlags{}
+
+	cmd.Flags().StringVarP(&cmdFlags.Output, common.FlagNameOutput, "o", "", common.FlagDescOutput)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
+
+func CmdAttachedConnectorBindingDeleteFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdAttachedConnectorBindingDelete()
+	nonKubeCommand := nonkube.NewCmdAttachedConnectorBindingDelete()
+
+	cmdAttachedConnectorBindingDeleteDesc := common.SkupperCmdDescription{
+		Use:     "delete <name>",
+		Short:   "delete an attached connector binding",
+		Long:    "Delete an attached connector binding <name>",
+		Example: "skupper attached-connector-binding delete backend",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdAttachedConnectorBindingDeleteDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandAttachedConnectorBindingDeleteFlags{}
+
+	if configuredPlatform == common.PlatformKubernetes {
+		cmd.Flags().DurationVar(&cmdFlags.Timeout, common.FlagNameTimeout, 60*time.Second, common.FlagDescTimeout)
+		cmd.Flags().BoolVar(&cmdFlags.Wait, common.FlagNameWait, true, common.FlagDescDeleteWait)
+	}
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
+
+func CmdAttachedConnectorBindingGenerateFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdAttachedConnectorBindingGenerate()
+	nonKubeCommand := nonkube.NewCmdAttachedConnectorBindingGenerate()
+
+	cmdAttachedConnectorBindingGenerateDesc := common.SkupperCmdDescription{
+		Use:   "generate <name>",
+		Short: "generate an attached connector binding resource and output it to a file or screen",
+		Long: `Create an attached connector binding in the site namespace for the attached connector with the same name.
+	generate an attached connector binding to evaluate what will be created with attached-connector-binding create command`,
+		Example: "skupper attached-connector-binding generate backend --connector-namespace east",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdAttachedConnectorBindingGenerateDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandAttachedConnectorBindingGenerateFlags{}
+
+	cmd.Flags().StringVar(&cmdFlags.ConnectorNamespace, common.FlagNameConnectorNamespace, "", common.FlagDescConnectorNamespace)
+	cmd.Flags().StringVarP(&cmdFlags.RoutingKey, common.FlagNameRoutingKey, "r", "", common.FlagDescRoutingKey)
+	cmd.Flags().BoolVar(&cmdFlags.ExposePodsByName, common.FlagNameExposePodsByName, false, common.FlagDescExposePodsByName)
+	cmd.Flags().StringVarP(&cmdFlags.Output, common.FlagNameOutput, "o", "yaml", common.FlagDescOutput)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
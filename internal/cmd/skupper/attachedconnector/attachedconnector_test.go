@@ -0,0 +1,147 @@
+package attachedconnector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gotest.tools/v3/assert"
+)
+
+func TestCmdAttachedConnectorFactory(t *testing.T) {
+
+	type test struct {
+		name                          string
+		expectedFlagsWithDefaultValue map[string]interface{}
+		command                       *cobra.Command
+	}
+
+	testTable := []test{
+		{
+			name: "CmdAttachedConnectorCreateFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameSiteNamespace:       "",
+				common.FlagNameSelector:            "",
+				common.FlagNameTlsCredentials:      "",
+				common.FlagNameUseClientCert:       "false",
+				common.FlagNameConnectorType:       "tcp",
+				common.FlagNameIncludeNotReadyPods: "false",
+				common.FlagNameTimeout:             "1m0s",
+				common.FlagNameWait:                "ready",
+			},
+			command: CmdAttachedConnectorCreateFactory(common.PlatformKubernetes),
+		},
+		{
+			name: "CmdAttachedConnectorUpdateFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameSelector:            "",
+				common.FlagNameAttachedPort:        "0",
+				common.FlagNameTlsCredentials:      "",
+				common.FlagNameUseClientCert:       "false",
+				common.FlagNameConnectorType:       "",
+				common.FlagNameIncludeNotReadyPods: "false",
+				common.FlagNameTimeout:             "1m0s",
+				common.FlagNameWait:                "ready",
+			},
+			command: CmdAttachedConnectorUpdateFactory(common.PlatformKubernetes),
+		},
+		{
+			name: "CmdAttachedConnectorStatusFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameOutput: "",
+			},
+			command: CmdAttachedConnectorStatusFactory(common.PlatformKubernetes),
+		},
+		{
+			name: "CmdAttachedConnectorDeleteFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameTimeout: "1m0s",
+				common.FlagNameWait:    "true",
+			},
+			command: CmdAttachedConnectorDeleteFactory(common.PlatformKubernetes),
+		},
+		{
+			name: "CmdAttachedConnectorGenerateFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameSiteNamespace:       "",
+				common.FlagNameSelector:            "",
+				common.FlagNameTlsCredentials:      "",
+				common.FlagNameUseClientCert:       "false",
+				common.FlagNameConnectorType:       "tcp",
+				common.FlagNameIncludeNotReadyPods: "false",
+				common.FlagNameOutput:              "yaml",
+			},
+			command: CmdAttachedConnectorGenerateFactory(common.PlatformKubernetes),
+		},
+		{
+			name: "CmdAttachedConnectorBindingCreateFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameConnectorNamespace: "",
+				common.FlagNameRoutingKey:         "",
+				common.FlagNameExposePodsByName:   "false",
+				common.FlagNameTimeout:            "1m0s",
+				common.FlagNameWait:               "ready",
+			},
+			command: CmdAttachedConnectorBindingCreateFactory(common.PlatformKubernetes),
+		},
+		{
+			name: "CmdAttachedConnectorBindingUpdateFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameRoutingKey:       "",
+				common.FlagNameExposePodsByName: "false",
+				common.FlagNameTimeout:          "1m0s",
+				common.FlagNameWait:             "ready",
+			},
+			command: CmdAttachedConnectorBindingUpdateFactory(common.PlatformKubernetes),
+		},
+		{
+			name: "CmdAttachedConnectorBindingStatusFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameOutput: "",
+			},
+			command: CmdAttachedConnectorBindingStatusFactory(common.PlatformKubernetes),
+		},
+		{
+			name: "CmdAttachedConnectorBindingDeleteFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameTimeout: "1m0s",
+				common.FlagNameWait:    "true",
+			},
+			command: CmdAttachedConnectorBindingDeleteFactory(common.PlatformKubernetes),
+		},
+		{
+			name: "CmdAttachedConnectorBindingGenerateFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameConnectorNamespace: "",
+				common.FlagNameRoutingKey:         "",
+				common.FlagNameExposePodsByName:   "false",
+				common.FlagNameOutput:             "yaml",
+			},
+			command: CmdAttachedConnectorBindingGenerateFactory(common.PlatformKubernetes),
+		},
+	}
+
+	for _, test := range testTable {
+
+		var flagList []string
+		t.Run(test.name, func(t *testing.T) {
+
+			test.command.Flags().VisitAll(func(flag *pflag.Flag) {
+				flagList = append(flagList, flag.Name)
+				assert.Check(t, test.expectedFlagsWithDefaultValue[flag.Name] != nil, fmt.Sprintf("flag %q not expected", flag.Name))
+				assert.Check(t, test.expectedFlagsWithDefaultValue[flag.Name] == flag.DefValue, fmt.Sprintf("default value %q for flag %q not expected", flag.DefValue, flag.Name))
+			})
+
+			assert.Check(t, len(flagList) == len(test.expectedFlagsWithDefaultValue))
+
+			assert.Assert(t, test.command.PreRunE != nil)
+			assert.Assert(t, test.command.Run != nil)
+			assert.Assert(t, test.command.PostRun != nil)
+			assert.Assert(t, test.command.Use != "")
+			assert.Assert(t, test.command.Short != "")
+			assert.Assert(t, test.command.Long != "")
+		})
+	}
+}
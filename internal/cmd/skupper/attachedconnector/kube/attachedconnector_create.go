@@ -0,0 +1,248 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	"github.com/skupperproject/skupper/internal/kube/client"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/generated/client/clientset/versioned/typed/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	k8serrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CmdAttachedConnectorCreate struct {
+	client              skupperv2alpha1.SkupperV2alpha1Interface
+	CobraCmd            *cobra.Command
+	Flags               *common.CommandAttachedConnectorCreateFlags
+	namespace           string
+	name                string
+	port                int
+	siteNamespace       string
+	selector            string
+	tlsCredentials      string
+	useClientCert       bool
+	connectorType       string
+	includeNotReadyPods bool
+	timeout             time.Duration
+	status              string
+}
+
+func NewCmdAttachedConnectorCreate() *CmdAttachedConnectorCreate {
+
+	return &CmdAttachedConnectorCreate{}
+}
+
+func (cmd *CmdAttachedConnectorCreate) NewClient(cobraCommand *cobra.Command, args []string) {
+	cli, err := client.NewClient(cobraCommand.Flag("namespace").Value.String(), cobraCommand.Flag("context").Value.String(), cobraCommand.Flag("kubeconfig").Value.String())
+	utils.HandleError(utils.GenericError, err)
+
+	cmd.client = cli.GetSkupperClient().SkupperV2alpha1()
+	cmd.namespace = cli.Namespace
+}
+
+func (cmd *CmdAttachedConnectorCreate) ValidateInput(args []string) error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	numberValidator := validator.NewNumberValidator()
+	connectorTypeValidator := validator.NewOptionValidator(common.ConnectorTypes)
+	selectorStringValidator := validator.NewSelectorStringValidator()
+	timeoutValidator := validator.NewTimeoutInSecondsValidator()
+	statusValidator := validator.NewOptionValidator(common.WaitStatusTypes)
+
+	// Check if AttachedConnector CRD is installed
+	_, err := cmd.client.AttachedConnectors(cmd.namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		validationErrors = append(validationErrors, utils.HandleMissingCrds(err))
+		return errors.Join(validationErrors...)
+	}
+
+	// Validate arguments name and port
+	if len(args) < 2 {
+		validationErrors = append(validationErrors, fmt.Errorf("attached connector name and port must be configured"))
+	} else if len(args) > 2 {
+		validationErrors = append(validationErrors, fmt.Errorf("only two arguments are allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("attached connector name must not be empty"))
+	} else if args[1] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("attached connector port must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("attached connector name is not valid: %s", err))
+		} else {
+			cmd.name = args[0]
+		}
+
+		cmd.port, err = strconv.Atoi(args[1])
+		if err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("attached connector port is not valid: %s", err))
+		}
+		ok, err = numberValidator.Evaluate(cmd.port)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("attached connector port is not valid: %s", err))
+		}
+	}
+
+	// Validate if there is already an attached connector with this name in the namespace
+	if cmd.name != "" {
+		attachedConnector, err := cmd.client.AttachedConnectors(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if attachedConnector != nil && !k8serrs.IsNotFound(err) {
+			validationErrors = append(validationErrors, fmt.Errorf("There is already an attached connector %s created for namespace %s", cmd.name, cmd.namespace))
+		}
+	}
+
+	// Validate flags
+	if cmd.Flags != nil {
+		if cmd.Flags.SiteNamespace == "" {
+			validationErrors = append(validationErrors, fmt.Errorf("site namespace must be configured"))
+		} else {
+			ok, err := resourceStringValidator.Evaluate(cmd.Flags.SiteNamespace)
+			if !ok {
+				validationErrors = append(validationErrors, fmt.Errorf("site namespace is not valid: %s", err))
+			}
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.Selector != "" {
+		ok, err := selectorStringValidator.Evaluate(cmd.Flags.Selector)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("selector is not valid: %s", err))
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.TlsCredentials != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.TlsCredentials)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("tls-credentials is not valid: %s", err))
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.UseClientCert && cmd.Flags.TlsCredentials == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("tls-credentials must be configured to use a client certificate"))
+	}
+	if cmd.Flags != nil && cmd.Flags.ConnectorType != "" {
+		ok, err := connectorTypeValidator.Evaluate(cmd.Flags.ConnectorType)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("connector type is not valid: %s", err))
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.Timeout.String() != "" {
+		ok, err := timeoutValidator.Evaluate(cmd.Flags.Timeout)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("timeout is not valid: %s", err))
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Wait != "" {
+		ok, err := statusValidator.Evaluate(cmd.Flags.Wait)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("status is not valid: %s", err))
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdAttachedConnectorCreate) InputToOptions() {
+
+	// default selector to name of attached connector
+	if cmd.Flags.Selector == "" {
+		cmd.selector = "app=" + cmd.name
+	} else {
+		cmd.selector = cmd.Flags.Selector
+	}
+
+	cmd.siteNamespace = cmd.Flags.SiteNamespace
+	cmd.tlsCredentials = cmd.Flags.TlsCredentials
+	cmd.useClientCert = cmd.Flags.UseClientCert
+	cmd.connectorType = cmd.Flags.ConnectorType
+	cmd.includeNotReadyPods = cmd.Flags.IncludeNotReadyPods
+	cmd.timeout = cmd.Flags.Timeout
+	cmd.status = cmd.Flags.Wait
+}
+
+func (cmd *CmdAttachedConnectorCreate) Run() error {
+
+	resource := v2alpha1.AttachedConnector{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "AttachedConnector",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cmd.name,
+			Namespace: cmd.namespace,
+		},
+		Spec: v2alpha1.AttachedConnectorSpec{
+			SiteNamespace:       cmd.siteNamespace,
+			Selector:            cmd.selector,
+			Port:                cmd.port,
+			TlsCredentials:      cmd.tlsCredentials,
+			UseClientCert:       cmd.useClientCert,
+			Type:                cmd.connectorType,
+			IncludeNotReadyPods: cmd.includeNotReadyPods,
+		},
+	}
+
+	_, err := cmd.client.AttachedConnectors(cmd.namespace).Create(context.TODO(), &resource, metav1.CreateOptions{})
+	return err
+}
+
+func (cmd *CmdAttachedConnectorCreate) WaitUntil() error {
+
+	if cmd.status == "none" {
+		return nil
+	}
+
+	waitTime := int(cmd.timeout.Seconds())
+	var attachedConnectorCondition *metav1.Condition
+
+	err := utils.NewSpinnerWithTimeout("Waiting for create to complete...", waitTime, func() error {
+
+		resource, err := cmd.client.AttachedConnectors(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		isConditionFound := false
+		isConditionTrue := false
+
+		switch cmd.status {
+		case "ready":
+			attachedConnectorCondition = meta.FindStatusCondition(resource.Status.Conditions, v2alpha1.CONDITION_TYPE_READY)
+		default:
+			attachedConnectorCondition = meta.FindStatusCondition(resource.Status.Conditions, v2alpha1.CONDITION_TYPE_CONFIGURED)
+		}
+
+		if attachedConnectorCondition != nil {
+			isConditionFound = true
+			isConditionTrue = attachedConnectorCondition.Status == metav1.ConditionTrue
+		}
+
+		if resource != nil && isConditionFound && isConditionTrue {
+			return nil
+		}
+
+		if resource != nil && isConditionFound && !isConditionTrue {
+			return fmt.Errorf("error in the condition")
+		}
+
+		return fmt.Errorf("error getting the resource")
+	})
+
+	if err != nil && attachedConnectorCondition == nil {
+		return fmt.Errorf("AttachedConnector %q is not yet %s, check the status for more information\n", cmd.name, cmd.status)
+	} else if err != nil && attachedConnectorCondition.Status == metav1.ConditionFalse {
+		return fmt.Errorf("AttachedConnector %q is not yet %s: %s\n", cmd.name, cmd.status, attachedConnectorCondition.Message)
+	}
+
+	fmt.Printf("AttachedConnector %q is %s.\n", cmd.name, cmd.status)
+	return nil
+}
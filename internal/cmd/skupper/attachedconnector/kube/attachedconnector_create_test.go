@@ -0,0 +1,399 @@
+package kube
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdAttachedConnectorCreate_ValidateInput(t *testing.T) {
+	type test struct {
+		name                string
+		args                []string
+		flags               common.CommandAttachedConnectorCreateFlags
+		skupperObjects      []runtime.Object
+		skupperErrorMessage string
+		expectedError       string
+	}
+
+	validFlags := common.CommandAttachedConnectorCreateFlags{
+		SiteNamespace: "site",
+		Timeout:       time.Minute,
+		Wait:          "ready",
+	}
+
+	testTable := []test{
+		{
+			name:                "missing CRD",
+			args:                []string{"my-connector", "8080"},
+			flags:               validFlags,
+			skupperErrorMessage: utils.CrdErr,
+			expectedError:       utils.CrdHelpErr,
+		},
+		{
+			name:  "attached connector already exists",
+			args:  []string{"my-connector", "8080"},
+			flags: validFlags,
+			skupperObjects: []runtime.Object{
+				&v2alpha1.AttachedConnector{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-connector",
+						Namespace: "test",
+					},
+				},
+			},
+			expectedError: "There is already an attached connector my-connector created for namespace test",
+		},
+		{
+			name:          "attached connector name and port are not specified",
+			flags:         validFlags,
+			expectedError: "attached connector name and port must be configured",
+		},
+		{
+			name:          "attached connector name empty",
+			args:          []string{"", "8080"},
+			flags:         validFlags,
+			expectedError: "attached connector name must not be empty",
+		},
+		{
+			name:          "attached connector port empty",
+			args:          []string{"my-connector", ""},
+			flags:         validFlags,
+			expectedError: "attached connector port must not be empty",
+		},
+		{
+			name:          "more than two arguments are specified",
+			args:          []string{"my", "connector", "8080"},
+			flags:         validFlags,
+			expectedError: "only two arguments are allowed for this command",
+		},
+		{
+			name:          "attached connector name is not valid",
+			args:          []string{"my connector", "8080"},
+			flags:         validFlags,
+			expectedError: "attached connector name is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])*(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])*)*$",
+		},
+		{
+			name:          "attached connector port is not a number",
+			args:          []string{"my-connector", "abc"},
+			flags:         validFlags,
+			expectedError: "attached connector port is not valid: strconv.Atoi: parsing \"abc\": invalid syntax",
+		},
+		{
+			name: "site namespace is missing",
+			args: []string{"my-connector", "8080"},
+			flags: common.CommandAttachedConnectorCreateFlags{
+				Timeout: time.Minute,
+			},
+			expectedError: "site namespace must be configured",
+		},
+		{
+			name: "selector is not valid",
+			args: []string{"my-connector", "8080"},
+			flags: common.CommandAttachedConnectorCreateFlags{
+				SiteNamespace: "site",
+				Selector:      "@#$%",
+				Timeout:       time.Minute,
+			},
+			expectedError: "selector is not valid: value does not match this regular expression: ^[A-Za-z0-9=:./-]+$",
+		},
+		{
+			name: "client certificate without tls credentials",
+			args: []string{"my-connector", "8080"},
+			flags: common.CommandAttachedConnectorCreateFlags{
+				SiteNamespace: "site",
+				UseClientCert: true,
+				Timeout:       time.Minute,
+			},
+			expectedError: "tls-credentials must be configured to use a client certificate",
+		},
+		{
+			name: "connector type is not valid",
+			args: []string{"my-connector", "8080"},
+			flags: common.CommandAttachedConnectorCreateFlags{
+				SiteNamespace: "site",
+				ConnectorType: "not-valid",
+				Timeout:       time.Minute,
+			},
+			expectedError: "connector type is not valid: value not-valid not allowed. It should be one of this options: [tcp http http2 udp]",
+		},
+		{
+			name: "timeout is not valid",
+			args: []string{"my-connector", "8080"},
+			flags: common.CommandAttachedConnectorCreateFlags{
+				SiteNamespace: "site",
+				Timeout:       time.Second,
+			},
+			expectedError: "timeout is not valid: duration must not be less than 10s; got 1s",
+		},
+		{
+			name: "wait status is not valid",
+			args: []string{"my-connector", "8080"},
+			flags: common.CommandAttachedConnectorCreateFlags{
+				SiteNamespace: "site",
+				Timeout:       time.Minute,
+				Wait:          "pending",
+			},
+			expectedError: "status is not valid: value pending not allowed. It should be one of this options: [ready configured none]",
+		},
+		{
+			name:  "flags all valid",
+			args:  []string{"my-connector", "8080"},
+			flags: validFlags,
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdAttachedConnectorCreateWithMocks("test", nil, test.skupperObjects, test.skupperErrorMessage)
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdAttachedConnectorCreate_InputToOptions(t *testing.T) {
+	type test struct {
+		name             string
+		flags            common.CommandAttachedConnectorCreateFlags
+		expectedSelector string
+	}
+
+	testTable := []test{
+		{
+			name: "selector defaults to the name",
+			flags: common.CommandAttachedConnectorCreateFlags{
+				SiteNamespace: "site",
+				Wait:          "ready",
+			},
+			expectedSelector: "app=my-connector",
+		},
+		{
+			name: "selector is set",
+			flags: common.CommandAttachedConnectorCreateFlags{
+				SiteNamespace: "site",
+				Selector:      "app=backend",
+				Wait:          "ready",
+			},
+			expectedSelector: "app=backend",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			cmd, err := newCmdAttachedConnectorCreateWithMocks("test", nil, nil, "")
+			assert.Assert(t, err)
+			cmd.name = "my-connector"
+			cmd.Flags = &test.flags
+
+			cmd.InputToOptions()
+
+			assert.Equal(t, cmd.selector, test.expectedSelector)
+			assert.Equal(t, cmd.siteNamespace, "site")
+			assert.Equal(t, cmd.status, "ready")
+		})
+	}
+}
+
+func TestCmdAttachedConnectorCreate_Run(t *testing.T) {
+	type test struct {
+		name                string
+		skupperErrorMessage string
+		errorMessage        string
+	}
+
+	testTable := []test{
+		{
+			name: "runs ok",
+		},
+		{
+			name:                "run fails",
+			skupperErrorMessage: "error",
+			errorMessage:        "error",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdAttachedConnectorCreateWithMocks("test", nil, nil, test.skupperErrorMessage)
+		assert.Assert(t, err)
+		cmd.name = "my-connector"
+		cmd.port = 8080
+		cmd.siteNamespace = "site"
+		cmd.selector = "app=backend"
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Error(t, err, test.errorMessage)
+			} else {
+				assert.Assert(t, err)
+				attachedConnector, err := cmd.client.AttachedConnectors("test").Get(t.Context(), "my-connector", v1.GetOptions{})
+				assert.Assert(t, err)
+				assert.Equal(t, attachedConnector.Spec.SiteNamespace, "site")
+				assert.Equal(t, attachedConnector.Spec.Selector, "app=backend")
+				assert.Equal(t, attachedConnector.Spec.Port, 8080)
+			}
+		})
+	}
+}
+
+func TestCmdAttachedConnectorCreate_WaitUntil(t *testing.T) {
+	type test struct {
+		name           string
+		status         string
+		skupperObjects []runtime.Object
+		expectError    bool
+	}
+
+	testTable := []test{
+		{
+			name:   "attached connector is not ready",
+			status: "ready",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.AttachedConnector{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-connector",
+						Namespace: "test",
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name:        "attached connector is not returned",
+			status:      "ready",
+			expectError: true,
+		},
+		{
+			name:           "attached connector is ready",
+			status:         "ready",
+			skupperObjects: []runtime.Object{existingAttachedConnector()},
+		},
+		{
+			name:   "attached connector is configured",
+			status: "configured",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.AttachedConnector{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-connector",
+						Namespace: "test",
+					},
+					Status: v2alpha1.AttachedConnectorStatus{
+						Status: v2alpha1.Status{
+							Conditions: []v1.Condition{
+								{
+									Type:   "Configured",
+									Status: "True",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "attached connector could not be configured",
+			status: "configured",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.AttachedConnector{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-connector",
+						Namespace: "test",
+					},
+					Status: v2alpha1.AttachedConnectorStatus{
+						Status: v2alpha1.Status{
+							Conditions: []v1.Condition{
+								{
+									Message: "No site in namespace site",
+									Reason:  "Error",
+									Type:    "Configured",
+									Status:  "False",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name:        "user does not wait",
+			status:      "none",
+			expectError: false,
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdAttachedConnectorCreateWithMocks("test", nil, test.skupperObjects, "")
+		assert.Assert(t, err)
+
+		cmd.name = "my-connector"
+		cmd.timeout = 1 * time.Second
+		cmd.status = test.status
+
+		t.Run(test.name, func(t *testing.T) {
+
+			err := cmd.WaitUntil()
+			if test.expectError {
+				assert.Check(t, err != nil)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdAttachedConnectorCreateWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdAttachedConnectorCreate, error) {
+
+	// We make sure the interval is appropriate
+	utils.SetRetryProfile(utils.TestRetryProfile)
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdAttachedConnectorCreate := &CmdAttachedConnectorCreate{
+		client:    client.GetSkupperClient().SkupperV2alpha1(),
+		namespace: namespace,
+	}
+	return cmdAttachedConnectorCreate, nil
+}
+
+func existingAttachedConnector() *v2alpha1.AttachedConnector {
+	return &v2alpha1.AttachedConnector{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "my-connector",
+			Namespace: "test",
+		},
+		Spec: v2alpha1.AttachedConnectorSpec{
+			SiteNamespace: "site",
+			Selector:      "app=backend",
+			Port:          8080,
+		},
+		Status: v2alpha1.AttachedConnectorStatus{
+			Status: v2alpha1.Status{
+				StatusType: "Ready",
+				Conditions: []v1.Condition{
+					{
+						Type:   "Ready",
+						Status: "True",
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,163 @@
+package kube
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CmdAttachedConnectorGenerate struct {
+	CobraCmd            *cobra.Command
+	Flags               *common.CommandAttachedConnectorGenerateFlags
+	namespace           string
+	name                string
+	port                int
+	siteNamespace       string
+	selector            string
+	tlsCredentials      string
+	useClientCert       bool
+	connectorType       string
+	includeNotReadyPods bool
+	output              string
+}
+
+func NewCmdAttachedConnectorGenerate() *CmdAttachedConnectorGenerate {
+
+	return &CmdAttachedConnectorGenerate{}
+}
+
+func (cmd *CmdAttachedConnectorGenerate) NewClient(cobraCommand *cobra.Command, args []string) {
+
+	cmd.namespace = cobraCommand.Flag("namespace").Value.String()
+}
+
+func (cmd *CmdAttachedConnectorGenerate) ValidateInput(args []string) error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	numberValidator := validator.NewNumberValidator()
+	connectorTypeValidator := validator.NewOptionValidator(common.ConnectorTypes)
+	selectorStringValidator := validator.NewSelectorStringValidator()
+	outputTypeValidator := validator.NewOptionValidator(common.OutputTypes)
+
+	// Validate arguments name and port
+	if len(args) < 2 {
+		validationErrors = append(validationErrors, fmt.Errorf("attached connector name and port must be configured"))
+	} else if len(args) > 2 {
+		validationErrors = append(validationErrors, fmt.Errorf("only two arguments are allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("attached connector name must not be empty"))
+	} else if args[1] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("attached connector port must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("attached connector name is not valid: %s", err))
+		} else {
+			cmd.name = args[0]
+		}
+
+		cmd.port, err = strconv.Atoi(args[1])
+		if err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("attached connector port is not valid: %s", err))
+		}
+		ok, err = numberValidator.Evaluate(cmd.port)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("attached connector port is not valid: %s", err))
+		}
+	}
+
+	// Validate flags
+	if cmd.Flags != nil {
+		if cmd.Flags.SiteNamespace == "" {
+			validationErrors = append(validationErrors, fmt.Errorf("site namespace must be configured"))
+		} else {
+			ok, err := resourceStringValidator.Evaluate(cmd.Flags.SiteNamespace)
+			if !ok {
+				validationErrors = append(validationErrors, fmt.Errorf("site namespace is not valid: %s", err))
+			}
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.Selector != "" {
+		ok, err := selectorStringValidator.Evaluate(cmd.Flags.Selector)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("selector is not valid: %s", err))
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.TlsCredentials != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.TlsCredentials)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("tls-credentials is not valid: %s", err))
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.UseClientCert && cmd.Flags.TlsCredentials == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("tls-credentials must be configured to use a client certificate"))
+	}
+	if cmd.Flags != nil && cmd.Flags.ConnectorType != "" {
+		ok, err := connectorTypeValidator.Evaluate(cmd.Flags.ConnectorType)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("connector type is not valid: %s", err))
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdAttachedConnectorGenerate) InputToOptions() {
+
+	// default selector to name of attached connector
+	if cmd.Flags.Selector == "" {
+		cmd.selector = "app=" + cmd.name
+	} else {
+		cmd.selector = cmd.Flags.Selector
+	}
+
+	cmd.siteNamespace = cmd.Flags.SiteNamespace
+	cmd.tlsCredentials = cmd.Flags.TlsCredentials
+	cmd.useClientCert = cmd.Flags.UseClientCert
+	cmd.connectorType = cmd.Flags.ConnectorType
+	cmd.includeNotReadyPods = cmd.Flags.IncludeNotReadyPods
+	cmd.output = cmd.Flags.Output
+}
+
+func (cmd *CmdAttachedConnectorGenerate) Run() error {
+
+	resource := v2alpha1.AttachedConnector{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "AttachedConnector",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cmd.name,
+			Namespace: cmd.namespace,
+		},
+		Spec: v2alpha1.AttachedConnectorSpec{
+			SiteNamespace:       cmd.siteNamespace,
+			Selector:            cmd.selector,
+			Port:                cmd.port,
+			TlsCredentials:      cmd.tlsCredentials,
+			UseClientCert:       cmd.useClientCert,
+			Type:                cmd.connectorType,
+			IncludeNotReadyPods: cmd.includeNotReadyPods,
+		},
+	}
+
+	encodedOutput, err := utils.Encode(cmd.output, resource)
+	fmt.Println(encodedOutput)
+	return err
+}
+
+func (cmd *CmdAttachedConnectorGenerate) WaitUntil() error { return nil }
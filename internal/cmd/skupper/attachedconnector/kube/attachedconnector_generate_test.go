@@ -0,0 +1,102 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"gotest.tools/v3/assert"
+)
+
+func TestCmdAttachedConnectorGenerate_ValidateInput(t *testing.T) {
+	type test struct {
+		name          string
+		args          []string
+		flags         common.CommandAttachedConnectorGenerateFlags
+		expectedError string
+	}
+
+	testTable := []test{
+		{
+			name:          "attached connector name and port are not specified",
+			flags:         common.CommandAttachedConnectorGenerateFlags{SiteNamespace: "site"},
+			expectedError: "attached connector name and port must be configured",
+		},
+		{
+			name:          "attached connector name is not valid",
+			args:          []string{"my connector", "8080"},
+			flags:         common.CommandAttachedConnectorGenerateFlags{SiteNamespace: "site"},
+			expectedError: "attached connector name is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])*(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])*)*$",
+		},
+		{
+			name:          "site namespace is missing",
+			args:          []string{"my-connector", "8080"},
+			expectedError: "site namespace must be configured",
+		},
+		{
+			name:          "output format is not valid",
+			args:          []string{"my-connector", "8080"},
+			flags:         common.CommandAttachedConnectorGenerateFlags{SiteNamespace: "site", Output: "not-supported"},
+			expectedError: "output type is not valid: value not-supported not allowed. It should be one of this options: [json yaml]",
+		},
+		{
+			name:  "flags all valid",
+			args:  []string{"my-connector", "8080"},
+			flags: common.CommandAttachedConnectorGenerateFlags{SiteNamespace: "site", Selector: "app=backend", Output: "json"},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdAttachedConnectorGenerate{Flags: &test.flags}
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdAttachedConnectorGenerate_Run(t *testing.T) {
+	type test struct {
+		name         string
+		output       string
+		errorMessage string
+	}
+
+	testTable := []test{
+		{
+			name:   "runs ok with yaml output",
+			output: "yaml",
+		},
+		{
+			name:   "runs ok with json output",
+			output: "json",
+		},
+		{
+			name:         "run fails with unsupported output",
+			output:       "unsupported",
+			errorMessage: "format unsupported not supported",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd := &CmdAttachedConnectorGenerate{
+			Flags: &common.CommandAttachedConnectorGenerateFlags{
+				SiteNamespace: "site",
+				Output:        test.output,
+			},
+			name:      "my-connector",
+			namespace: "test",
+			port:      8080,
+		}
+		cmd.InputToOptions()
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Error(t, err, test.errorMessage)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
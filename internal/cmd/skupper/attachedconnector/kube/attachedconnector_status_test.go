@@ -0,0 +1,150 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"gotest.tools/v3/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdAttachedConnectorStatus_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		flags          common.CommandAttachedConnectorStatusFlags
+		skupperObjects []runtime.Object
+		expectedError  string
+		skupperError   string
+	}
+
+	testTable := []test{
+		{
+			name:          "missing CRD",
+			args:          []string{"my-connector"},
+			skupperError:  utils.CrdErr,
+			expectedError: utils.CrdHelpErr,
+		},
+		{
+			name:          "attached connector does not exist in the namespace",
+			args:          []string{"my-connector"},
+			expectedError: "attached connector my-connector does not exist in namespace test",
+		},
+		{
+			name:          "attached connector name is nil",
+			args:          []string{""},
+			expectedError: "attached connector name must not be empty",
+		},
+		{
+			name:          "more than one argument is specified",
+			args:          []string{"my", "connector"},
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:          "no args",
+			expectedError: "",
+		},
+		{
+			name:           "bad output status",
+			args:           []string{"my-connector"},
+			flags:          common.CommandAttachedConnectorStatusFlags{Output: "not-supported"},
+			skupperObjects: []runtime.Object{existingAttachedConnector()},
+			expectedError:  "output type is not valid: value not-supported not allowed. It should be one of this options: [json yaml]",
+		},
+		{
+			name:           "good output status",
+			args:           []string{"my-connector"},
+			flags:          common.CommandAttachedConnectorStatusFlags{Output: "json"},
+			skupperObjects: []runtime.Object{existingAttachedConnector()},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdAttachedConnectorStatusWithMocks("test", nil, test.skupperObjects, test.skupperError)
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdAttachedConnectorStatus_Run(t *testing.T) {
+	type test struct {
+		name                string
+		connectorName       string
+		output              string
+		skupperObjects      []runtime.Object
+		skupperErrorMessage string
+		errorMessage        string
+	}
+
+	testTable := []test{
+		{
+			name:                "run fails",
+			connectorName:       "my-connector",
+			skupperErrorMessage: "error",
+			errorMessage:        "error",
+		},
+		{
+			name:           "runs ok",
+			connectorName:  "my-connector",
+			skupperObjects: []runtime.Object{existingAttachedConnector()},
+		},
+		{
+			name:           "runs ok with yaml output",
+			connectorName:  "my-connector",
+			output:         "yaml",
+			skupperObjects: []runtime.Object{existingAttachedConnector()},
+		},
+		{
+			name:           "runs ok listing attached connectors",
+			skupperObjects: []runtime.Object{existingAttachedConnector()},
+		},
+		{
+			name:           "runs ok listing attached connectors with json output",
+			output:         "json",
+			skupperObjects: []runtime.Object{existingAttachedConnector()},
+		},
+		{
+			name: "runs ok with no attached connectors",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdAttachedConnectorStatusWithMocks("test", nil, test.skupperObjects, test.skupperErrorMessage)
+		assert.Assert(t, err)
+		cmd.name = test.connectorName
+		cmd.output = test.output
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Error(t, err, test.errorMessage)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdAttachedConnectorStatusWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdAttachedConnectorStatus, error) {
+
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdAttachedConnectorStatus := &CmdAttachedConnectorStatus{
+		client:    client.GetSkupperClient().SkupperV2alpha1(),
+		namespace: namespace,
+	}
+	return cmdAttachedConnectorStatus, nil
+}
@@ -0,0 +1,220 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	"github.com/skupperproject/skupper/internal/kube/client"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/generated/client/clientset/versioned/typed/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	k8serrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CmdAttachedConnectorUpdate struct {
+	client          skupperv2alpha1.SkupperV2alpha1Interface
+	CobraCmd        *cobra.Command
+	Flags           *common.CommandAttachedConnectorUpdateFlags
+	namespace       string
+	name            string
+	resourceVersion string
+	newSettings     v2alpha1.AttachedConnectorSpec
+	status          string
+}
+
+func NewCmdAttachedConnectorUpdate() *CmdAttachedConnectorUpdate {
+
+	return &CmdAttachedConnectorUpdate{}
+}
+
+func (cmd *CmdAttachedConnectorUpdate) NewClient(cobraCommand *cobra.Command, args []string) {
+	cli, err := client.NewClient(cobraCommand.Flag("namespace").Value.String(), cobraCommand.Flag("context").Value.String(), cobraCommand.Flag("kubeconfig").Value.String())
+	utils.HandleError(utils.GenericError, err)
+
+	cmd.client = cli.GetSkupperClient().SkupperV2alpha1()
+	cmd.namespace = cli.Namespace
+}
+
+func (cmd *CmdAttachedConnectorUpdate) ValidateInput(args []string) error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	numberValidator := validator.NewNumberValidator()
+	connectorTypeValidator := validator.NewOptionValidator(common.ConnectorTypes)
+	selectorStringValidator := validator.NewSelectorStringValidator()
+	timeoutValidator := validator.NewTimeoutInSecondsValidator()
+	statusValidator := validator.NewOptionValidator(common.WaitStatusTypes)
+
+	// Check if AttachedConnector CRD is installed
+	_, err := cmd.client.AttachedConnectors(cmd.namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		validationErrors = append(validationErrors, utils.HandleMissingCrds(err))
+		return errors.Join(validationErrors...)
+	}
+
+	// Validate arguments name
+	if len(args) < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("attached connector name must be configured"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("attached connector name must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("attached connector name is not valid: %s", err))
+		} else {
+			cmd.name = args[0]
+		}
+	}
+
+	// Validate that there is already an attached connector with this name in the namespace
+	if cmd.name != "" {
+		attachedConnector, err := cmd.client.AttachedConnectors(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if attachedConnector == nil || k8serrs.IsNotFound(err) {
+			validationErrors = append(validationErrors, fmt.Errorf("attached connector %s must exist in namespace %s to be updated", cmd.name, cmd.namespace))
+		} else {
+			// save existing values
+			cmd.resourceVersion = attachedConnector.ResourceVersion
+			cmd.newSettings = attachedConnector.Spec
+		}
+	}
+
+	// Validate flags
+	if cmd.Flags != nil && cmd.Flags.Selector != "" {
+		ok, err := selectorStringValidator.Evaluate(cmd.Flags.Selector)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("selector is not valid: %s", err))
+		} else {
+			cmd.newSettings.Selector = cmd.Flags.Selector
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.Port != 0 {
+		ok, err := numberValidator.Evaluate(cmd.Flags.Port)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("attached connector port is not valid: %s", err))
+		} else {
+			cmd.newSettings.Port = cmd.Flags.Port
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.TlsCredentials != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.TlsCredentials)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("tls-credentials is not valid: %s", err))
+		} else {
+			cmd.newSettings.TlsCredentials = cmd.Flags.TlsCredentials
+		}
+	}
+	if cmd.Flags != nil && cmd.CobraCmd != nil && cmd.CobraCmd.Flags().Changed(common.FlagNameUseClientCert) {
+		cmd.newSettings.UseClientCert = cmd.Flags.UseClientCert
+	}
+	if cmd.newSettings.UseClientCert && cmd.newSettings.TlsCredentials == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("tls-credentials must be configured to use a client certificate"))
+	}
+	if cmd.Flags != nil && cmd.Flags.ConnectorType != "" {
+		ok, err := connectorTypeValidator.Evaluate(cmd.Flags.ConnectorType)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("connector type is not valid: %s", err))
+		} else {
+			cmd.newSettings.Type = cmd.Flags.ConnectorType
+		}
+	}
+	if cmd.Flags != nil && cmd.CobraCmd != nil && cmd.CobraCmd.Flags().Changed(common.FlagNameIncludeNotReadyPods) {
+		cmd.newSettings.IncludeNotReadyPods = cmd.Flags.IncludeNotReadyPods
+	}
+	if cmd.Flags != nil && cmd.Flags.Timeout.String() != "" {
+		ok, err := timeoutValidator.Evaluate(cmd.Flags.Timeout)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("timeout is not valid: %s", err))
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Wait != "" {
+		ok, err := statusValidator.Evaluate(cmd.Flags.Wait)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("status is not valid: %s", err))
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdAttachedConnectorUpdate) Run() error {
+
+	resource := v2alpha1.AttachedConnector{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "AttachedConnector",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            cmd.name,
+			Namespace:       cmd.namespace,
+			ResourceVersion: cmd.resourceVersion,
+		},
+		Spec: cmd.newSettings,
+	}
+
+	_, err := cmd.client.AttachedConnectors(cmd.namespace).Update(context.TODO(), &resource, metav1.UpdateOptions{})
+	return err
+}
+
+func (cmd *CmdAttachedConnectorUpdate) WaitUntil() error {
+
+	if cmd.status == "none" {
+		return nil
+	}
+
+	waitTime := int(cmd.Flags.Timeout.Seconds())
+	var attachedConnectorCondition *metav1.Condition
+	err := utils.NewSpinnerWithTimeout("Waiting for update to complete...", waitTime, func() error {
+
+		resource, err := cmd.client.AttachedConnectors(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		isConditionFound := false
+		isConditionTrue := false
+
+		switch cmd.status {
+		case "ready":
+			attachedConnectorCondition = meta.FindStatusCondition(resource.Status.Conditions, v2alpha1.CONDITION_TYPE_READY)
+		default:
+			attachedConnectorCondition = meta.FindStatusCondition(resource.Status.Conditions, v2alpha1.CONDITION_TYPE_CONFIGURED)
+		}
+
+		if attachedConnectorCondition != nil {
+			isConditionFound = true
+			isConditionTrue = attachedConnectorCondition.Status == metav1.ConditionTrue
+		}
+
+		if resource != nil && isConditionFound && isConditionTrue {
+			return nil
+		}
+
+		if resource != nil && isConditionFound && !isConditionTrue {
+			return fmt.Errorf("error in the condition")
+		}
+
+		return fmt.Errorf("error getting the resource")
+	})
+
+	if err != nil && attachedConnectorCondition == nil {
+		return fmt.Errorf("AttachedConnector %q is not yet %s, check the status for more information\n", cmd.name, cmd.status)
+	} else if err != nil && attachedConnectorCondition.Status == metav1.ConditionFalse {
+		return fmt.Errorf("AttachedConnector %q is not yet %s: %s\n", cmd.name, cmd.status, attachedConnectorCondition.Message)
+	}
+
+	fmt.Printf("AttachedConnector %q is updated\n", cmd.name)
+	return nil
+}
+
+func (cmd *CmdAttachedConnectorUpdate) InputToOptions() {
+	cmd.status = cmd.Flags.Wait
+}
@@ -0,0 +1,222 @@
+package kube
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdAttachedConnectorUpdate_ValidateInput(t *testing.T) {
+	type test struct {
+		name                string
+		args                []string
+		flags               common.CommandAttachedConnectorUpdateFlags
+		changedFlags        map[string]string
+		skupperObjects      []runtime.Object
+		skupperErrorMessage string
+		expectedError       string
+		expectedSettings    *v2alpha1.AttachedConnectorSpec
+	}
+
+	testTable := []test{
+		{
+			name:                "missing CRD",
+			args:                []string{"my-connector"},
+			skupperErrorMessage: utils.CrdErr,
+			expectedError:       utils.CrdHelpErr,
+		},
+		{
+			name:          "attached connector does not exist",
+			args:          []string{"my-connector"},
+			flags:         common.CommandAttachedConnectorUpdateFlags{Timeout: time.Minute},
+			expectedError: "attached connector my-connector must exist in namespace test to be updated",
+		},
+		{
+			name:           "attached connector name is not specified",
+			flags:          common.CommandAttachedConnectorUpdateFlags{Timeout: time.Minute},
+			skupperObjects: []runtime.Object{existingAttachedConnector()},
+			expectedError:  "attached connector name must be configured",
+		},
+		{
+			name:           "more than one argument is specified",
+			args:           []string{"my", "connector"},
+			flags:          common.CommandAttachedConnectorUpdateFlags{Timeout: time.Minute},
+			skupperObjects: []runtime.Object{existingAttachedConnector()},
+			expectedError:  "only one argument is allowed for this command",
+		},
+		{
+			name:           "port is not valid",
+			args:           []string{"my-connector"},
+			flags:          common.CommandAttachedConnectorUpdateFlags{Port: -1, Timeout: time.Minute},
+			skupperObjects: []runtime.Object{existingAttachedConnector()},
+			expectedError:  "attached connector port is not valid: value is not positive",
+		},
+		{
+			name:           "client certificate without tls credentials",
+			args:           []string{"my-connector"},
+			flags:          common.CommandAttachedConnectorUpdateFlags{UseClientCert: true, Timeout: time.Minute},
+			changedFlags:   map[string]string{common.FlagNameUseClientCert: "true"},
+			skupperObjects: []runtime.Object{existingAttachedConnector()},
+			expectedError:  "tls-credentials must be configured to use a client certificate",
+		},
+		{
+			name:           "wait status is not valid",
+			args:           []string{"my-connector"},
+			flags:          common.CommandAttachedConnectorUpdateFlags{Timeout: time.Minute, Wait: "pending"},
+			skupperObjects: []runtime.Object{existingAttachedConnector()},
+			expectedError:  "status is not valid: value pending not allowed. It should be one of this options: [ready configured none]",
+		},
+		{
+			name:           "unspecified options keep their value",
+			args:           []string{"my-connector"},
+			flags:          common.CommandAttachedConnectorUpdateFlags{Port: 9090, Timeout: time.Minute},
+			skupperObjects: []runtime.Object{existingAttachedConnector()},
+			expectedSettings: &v2alpha1.AttachedConnectorSpec{
+				SiteNamespace: "site",
+				Selector:      "app=backend",
+				Port:          9090,
+			},
+		},
+		{
+			name: "all options updated",
+			args: []string{"my-connector"},
+			flags: common.CommandAttachedConnectorUpdateFlags{
+				Selector:            "app=frontend",
+				Port:                9090,
+				TlsCredentials:      "my-secret",
+				UseClientCert:       true,
+				ConnectorType:       "tcp",
+				IncludeNotReadyPods: true,
+				Timeout:             time.Minute,
+			},
+			changedFlags: map[string]string{
+				common.FlagNameUseClientCert:       "true",
+				common.FlagNameIncludeNotReadyPods: "true",
+			},
+			skupperObjects: []runtime.Object{existingAttachedConnector()},
+			expectedSettings: &v2alpha1.AttachedConnectorSpec{
+				SiteNamespace:       "site",
+				Selector:            "app=frontend",
+				Port:                9090,
+				TlsCredentials:      "my-secret",
+				UseClientCert:       true,
+				Type:                "tcp",
+				IncludeNotReadyPods: true,
+			},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdAttachedConnectorUpdateWithMocks("test", nil, test.skupperObjects, test.skupperErrorMessage)
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+			command.CobraCmd = &cobra.Command{Use: "update"}
+			command.CobraCmd.Flags().BoolVar(&command.Flags.UseClientCert, common.FlagNameUseClientCert, false, "")
+			command.CobraCmd.Flags().BoolVar(&command.Flags.IncludeNotReadyPods, common.FlagNameIncludeNotReadyPods, false, "")
+			for name, value := range test.changedFlags {
+				assert.Assert(t, command.CobraCmd.Flags().Set(name, value))
+			}
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+			if test.expectedSettings != nil {
+				assert.DeepEqual(t, command.newSettings, *test.expectedSettings)
+			}
+		})
+	}
+}
+
+func TestCmdAttachedConnectorUpdate_Run(t *testing.T) {
+	command, err := newCmdAttachedConnectorUpdateWithMocks("test", nil, []runtime.Object{existingAttachedConnector()}, "")
+	assert.Assert(t, err)
+	command.Flags = &common.CommandAttachedConnectorUpdateFlags{Selector: "app=frontend", Timeout: time.Minute}
+	assert.Assert(t, command.ValidateInput([]string{"my-connector"}))
+	command.InputToOptions()
+
+	assert.Assert(t, command.Run())
+
+	attachedConnector, err := command.client.AttachedConnectors("test").Get(t.Context(), "my-connector", v1.GetOptions{})
+	assert.Assert(t, err)
+	assert.Equal(t, attachedConnector.Spec.Selector, "app=frontend")
+	assert.Equal(t, attachedConnector.Spec.SiteNamespace, "site")
+	assert.Equal(t, attachedConnector.Spec.Port, 8080)
+}
+
+func TestCmdAttachedConnectorUpdate_WaitUntil(t *testing.T) {
+	type test struct {
+		name           string
+		status         string
+		skupperObjects []runtime.Object
+		expectError    bool
+	}
+
+	testTable := []test{
+		{
+			name:           "attached connector is ready",
+			status:         "ready",
+			skupperObjects: []runtime.Object{existingAttachedConnector()},
+		},
+		{
+			name:           "attached connector is not configured",
+			status:         "configured",
+			skupperObjects: []runtime.Object{existingAttachedConnector()},
+			expectError:    true,
+		},
+		{
+			name:        "attached connector is not returned",
+			status:      "ready",
+			expectError: true,
+		},
+		{
+			name:   "user does not wait",
+			status: "none",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdAttachedConnectorUpdateWithMocks("test", nil, test.skupperObjects, "")
+		assert.Assert(t, err)
+
+		cmd.name = "my-connector"
+		cmd.Flags = &common.CommandAttachedConnectorUpdateFlags{Timeout: time.Second}
+		cmd.status = test.status
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.WaitUntil()
+			if test.expectError {
+				assert.Check(t, err != nil)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdAttachedConnectorUpdateWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdAttachedConnectorUpdate, error) {
+
+	// We make sure the interval is appropriate
+	utils.SetRetryProfile(utils.TestRetryProfile)
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdAttachedConnectorUpdate := &CmdAttachedConnectorUpdate{
+		client:    client.GetSkupperClient().SkupperV2alpha1(),
+		namespace: namespace,
+	}
+	return cmdAttachedConnectorUpdate, nil
+}
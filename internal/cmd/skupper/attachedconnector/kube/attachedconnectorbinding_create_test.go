@@ -0,0 +1,336 @@
+package kube
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdAttachedConnectorBindingCreate_ValidateInput(t *testing.T) {
+	type test struct {
+		name                string
+		args                []string
+		flags               common.CommandAttachedConnectorBindingCreateFlags
+		skupperObjects      []runtime.Object
+		skupperErrorMessage string
+		expectedError       string
+	}
+
+	validFlags := common.CommandAttachedConnectorBindingCreateFlags{
+		ConnectorNamespace: "backend",
+		Timeout:            time.Minute,
+		Wait:               "ready",
+	}
+
+	testTable := []test{
+		{
+			name:                "missing CRD",
+			args:                []string{"my-binding"},
+			flags:               validFlags,
+			skupperErrorMessage: utils.CrdErr,
+			expectedError:       utils.CrdHelpErr,
+		},
+		{
+			name:           "attached connector binding already exists",
+			args:           []string{"my-binding"},
+			flags:          validFlags,
+			skupperObjects: []runtime.Object{existingAttachedConnectorBinding()},
+			expectedError:  "There is already an attached connector binding my-binding created for namespace test",
+		},
+		{
+			name:          "attached connector binding name is not specified",
+			flags:         validFlags,
+			expectedError: "attached connector binding name must be configured",
+		},
+		{
+			name:          "attached connector binding name empty",
+			args:          []string{""},
+			flags:         validFlags,
+			expectedError: "attached connector binding name must not be empty",
+		},
+		{
+			name:          "more than one argument is specified",
+			args:          []string{"my", "binding"},
+			flags:         validFlags,
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:          "attached connector binding name is not valid",
+			args:          []string{"my binding"},
+			flags:         validFlags,
+			expectedError: "attached connector binding name is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])*(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])*)*$",
+		},
+		{
+			name:          "connector namespace is missing",
+			args:          []string{"my-binding"},
+			flags:         common.CommandAttachedConnectorBindingCreateFlags{Timeout: time.Minute},
+			expectedError: "connector namespace must be configured",
+		},
+		{
+			name: "routing key is not valid",
+			args: []string{"my-binding"},
+			flags: common.CommandAttachedConnectorBindingCreateFlags{
+				ConnectorNamespace: "backend",
+				RoutingKey:         "not valid",
+				Timeout:            time.Minute,
+			},
+			expectedError: "routing key is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])*(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])*)*$",
+		},
+		{
+			name: "timeout is not valid",
+			args: []string{"my-binding"},
+			flags: common.CommandAttachedConnectorBindingCreateFlags{
+				ConnectorNamespace: "backend",
+				Timeout:            time.Second,
+			},
+			expectedError: "timeout is not valid: duration must not be less than 10s; got 1s",
+		},
+		{
+			name: "wait status is not valid",
+			args: []string{"my-binding"},
+			flags: common.CommandAttachedConnectorBindingCreateFlags{
+				ConnectorNamespace: "backend",
+				Timeout:            time.Minute,
+				Wait:               "pending",
+			},
+			expectedError: "status is not valid: value pending not allowed. It should be one of this options: [ready configured none]",
+		},
+		{
+			name:  "flags all valid",
+			args:  []string{"my-binding"},
+			flags: validFlags,
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdAttachedConnectorBindingCreateWithMocks("test", nil, test.skupperObjects, test.skupperErrorMessage)
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdAttachedConnectorBindingCreate_InputToOptions(t *testing.T) {
+	type test struct {
+		name               string
+		flags              common.CommandAttachedConnectorBindingCreateFlags
+		expectedRoutingKey string
+	}
+
+	testTable := []test{
+		{
+			name: "routing key defaults to the name",
+			flags: common.CommandAttachedConnectorBindingCreateFlags{
+				ConnectorNamespace: "backend",
+				Wait:               "ready",
+			},
+			expectedRoutingKey: "my-binding",
+		},
+		{
+			name: "routing key is set",
+			flags: common.CommandAttachedConnectorBindingCreateFlags{
+				ConnectorNamespace: "backend",
+				RoutingKey:         "backend",
+				Wait:               "ready",
+			},
+			expectedRoutingKey: "backend",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			cmd, err := newCmdAttachedConnectorBindingCreateWithMocks("test", nil, nil, "")
+			assert.Assert(t, err)
+			cmd.name = "my-binding"
+			cmd.Flags = &test.flags
+
+			cmd.InputToOptions()
+
+			assert.Equal(t, cmd.routingKey, test.expectedRoutingKey)
+			assert.Equal(t, cmd.connectorNamespace, "backend")
+			assert.Equal(t, cmd.status, "ready")
+		})
+	}
+}
+
+func TestCmdAttachedConnectorBindingCreate_Run(t *testing.T) {
+	type test struct {
+		name                string
+		skupperErrorMessage string
+		errorMessage        string
+	}
+
+	testTable := []test{
+		{
+			name: "runs ok",
+		},
+		{
+			name:                "run fails",
+			skupperErrorMessage: "error",
+			errorMessage:        "error",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdAttachedConnectorBindingCreateWithMocks("test", nil, nil, test.skupperErrorMessage)
+		assert.Assert(t, err)
+		cmd.name = "my-binding"
+		cmd.connectorNamespace = "backend"
+		cmd.routingKey = "backend"
+		cmd.exposePodsByName = true
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Error(t, err, test.errorMessage)
+			} else {
+				assert.Assert(t, err)
+				binding, err := cmd.client.AttachedConnectorBindings("test").Get(t.Context(), "my-binding", v1.GetOptions{})
+				assert.Assert(t, err)
+				assert.Equal(t, binding.Spec.ConnectorNamespace, "backend")
+				assert.Equal(t, binding.Spec.RoutingKey, "backend")
+				assert.Assert(t, binding.Spec.ExposePodsByName)
+			}
+		})
+	}
+}
+
+func TestCmdAttachedConnectorBindingCreate_WaitUntil(t *testing.T) {
+	type test struct {
+		name           string
+		status         string
+		skupperObjects []runtime.Object
+		expectError    bool
+	}
+
+	testTable := []test{
+		{
+			name:   "attached connector binding is not ready",
+			status: "ready",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.AttachedConnectorBinding{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-binding",
+						Namespace: "test",
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name:        "attached connector binding is not returned",
+			status:      "ready",
+			expectError: true,
+		},
+		{
+			name:           "attached connector binding is ready",
+			status:         "ready",
+			skupperObjects: []runtime.Object{existingAttachedConnectorBinding()},
+		},
+		{
+			name:   "attached connector binding could not be configured",
+			status: "configured",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.AttachedConnectorBinding{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-binding",
+						Namespace: "test",
+					},
+					Status: v2alpha1.AttachedConnectorBindingStatus{
+						Status: v2alpha1.Status{
+							Conditions: []v1.Condition{
+								{
+									Message: "No matching attached connector",
+									Reason:  "Error",
+									Type:    "Configured",
+									Status:  "False",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name:        "user does not wait",
+			status:      "none",
+			expectError: false,
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdAttachedConnectorBindingCreateWithMocks("test", nil, test.skupperObjects, "")
+		assert.Assert(t, err)
+
+		cmd.name = "my-binding"
+		cmd.timeout = 1 * time.Second
+		cmd.status = test.status
+
+		t.Run(test.name, func(t *testing.T) {
+
+			err := cmd.WaitUntil()
+			if test.expectError {
+				assert.Check(t, err != nil)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdAttachedConnectorBindingCreateWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdAttachedConnectorBindingCreate, error) {
+
+	// We make sure the interval is appropriate
+	utils.SetRetryProfile(utils.TestRetryProfile)
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdAttachedConnectorBindingCreate := &CmdAttachedConnectorBindingCreate{
+		client:    client.GetSkupperClient().SkupperV2alpha1(),
+		namespace: namespace,
+	}
+	return cmdAttachedConnectorBindingCreate, nil
+}
+
+func existingAttachedConnectorBinding() *v2alpha1.AttachedConnectorBinding {
+	return &v2alpha1.AttachedConnectorBinding{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "my-binding",
+			Namespace: "test",
+		},
+		Spec: v2alpha1.AttachedConnectorBindingSpec{
+			ConnectorNamespace: "backend",
+			RoutingKey:         "backend",
+		},
+		Status: v2alpha1.AttachedConnectorBindingStatus{
+			Status: v2alpha1.Status{
+				StatusType: "Ready",
+				Conditions: []v1.Condition{
+					{
+						Type:   "Ready",
+						Status: "True",
+					},
+				},
+			},
+			HasMatchingListener: true,
+		},
+	}
+}
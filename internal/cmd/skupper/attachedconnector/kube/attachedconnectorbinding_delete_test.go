@@ -0,0 +1,175 @@
+package kube
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"gotest.tools/v3/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdAttachedConnectorBindingDelete_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		flags          common.CommandAttachedConnectorBindingDeleteFlags
+		skupperObjects []runtime.Object
+		skupperError   string
+		expectedError  string
+	}
+
+	testTable := []test{
+		{
+			name:          "missing CRD",
+			args:          []string{"my-binding"},
+			skupperError:  utils.CrdErr,
+			expectedError: utils.CrdHelpErr,
+		},
+		{
+			name:          "attached connector binding is not specified",
+			expectedError: "attached connector binding name must be specified",
+		},
+		{
+			name:          "attached connector binding name is nil",
+			args:          []string{""},
+			expectedError: "attached connector binding name must not be empty",
+		},
+		{
+			name:          "more than one argument is specified",
+			args:          []string{"my", "binding"},
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:          "attached connector binding does not exist",
+			args:          []string{"my-binding"},
+			flags:         common.CommandAttachedConnectorBindingDeleteFlags{Timeout: time.Minute},
+			expectedError: "attached connector binding my-binding does not exist in namespace test",
+		},
+		{
+			name:           "timeout is not valid",
+			args:           []string{"my-binding"},
+			flags:          common.CommandAttachedConnectorBindingDeleteFlags{Timeout: 0},
+			skupperObjects: []runtime.Object{existingAttachedConnectorBinding()},
+			expectedError:  "timeout is not valid: duration must not be less than 10s; got 0s",
+		},
+		{
+			name:           "attached connector binding exists",
+			args:           []string{"my-binding"},
+			flags:          common.CommandAttachedConnectorBindingDeleteFlags{Timeout: time.Minute},
+			skupperObjects: []runtime.Object{existingAttachedConnectorBinding()},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdAttachedConnectorBindingDeleteWithMocks("test", nil, test.skupperObjects, test.skupperError)
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdAttachedConnectorBindingDelete_Run(t *testing.T) {
+	type test struct {
+		name                string
+		skupperObjects      []runtime.Object
+		skupperErrorMessage string
+		errorMessage        string
+	}
+
+	testTable := []test{
+		{
+			name:           "runs ok",
+			skupperObjects: []runtime.Object{existingAttachedConnectorBinding()},
+		},
+		{
+			name:                "run fails",
+			skupperErrorMessage: "error",
+			errorMessage:        "error",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdAttachedConnectorBindingDeleteWithMocks("test", nil, test.skupperObjects, test.skupperErrorMessage)
+		assert.Assert(t, err)
+		cmd.name = "my-binding"
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Error(t, err, test.errorMessage)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+func TestCmdAttachedConnectorBindingDelete_WaitUntil(t *testing.T) {
+	type test struct {
+		name           string
+		wait           bool
+		skupperObjects []runtime.Object
+		expectError    bool
+	}
+
+	testTable := []test{
+		{
+			name:           "attached connector binding is not deleted",
+			wait:           true,
+			skupperObjects: []runtime.Object{existingAttachedConnectorBinding()},
+			expectError:    true,
+		},
+		{
+			name: "attached connector binding is deleted",
+			wait: true,
+		},
+		{
+			name:           "user does not wait",
+			wait:           false,
+			skupperObjects: []runtime.Object{existingAttachedConnectorBinding()},
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdAttachedConnectorBindingDeleteWithMocks("test", nil, test.skupperObjects, "")
+		assert.Assert(t, err)
+		cmd.name = "my-binding"
+		cmd.Flags = &common.CommandAttachedConnectorBindingDeleteFlags{Timeout: time.Second, Wait: test.wait}
+		cmd.InputToOptions()
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.WaitUntil()
+			if test.expectError {
+				assert.Check(t, err != nil)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdAttachedConnectorBindingDeleteWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdAttachedConnectorBindingDelete, error) {
+
+	// We make sure the interval is appropriate
+	utils.SetRetryProfile(utils.TestRetryProfile)
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdAttachedConnectorBindingDelete := &CmdAttachedConnectorBindingDelete{
+		client:    client.GetSkupperClient().SkupperV2alpha1(),
+		namespace: namespace,
+	}
+	return cmdAttachedConnectorBindingDelete, nil
+}
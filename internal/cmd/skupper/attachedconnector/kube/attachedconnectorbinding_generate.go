@@ -0,0 +1,122 @@
+package kube
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CmdAttachedConnectorBindingGenerate struct {
+	CobraCmd           *cobra.Command
+	Flags              *common.CommandAttachedConnectorBindingGenerateFlags
+	namespace          string
+	name               string
+	connectorNamespace string
+	routingKey         string
+	exposePodsByName   bool
+	output             string
+}
+
+func NewCmdAttachedConnectorBindingGenerate() *CmdAttachedConnectorBindingGenerate {
+
+	return &CmdAttachedConnectorBindingGenerate{}
+}
+
+func (cmd *CmdAttachedConnectorBindingGenerate) NewClient(cobraCommand *cobra.Command, args []string) {
+
+	cmd.namespace = cobraCommand.Flag("namespace").Value.String()
+}
+
+func (cmd *CmdAttachedConnectorBindingGenerate) ValidateInput(args []string) error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	outputTypeValidator := validator.NewOptionValidator(common.OutputTypes)
+
+	// Validate arguments name
+	if len(args) < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("attached connector binding name must be configured"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("attached connector binding name must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("attached connector binding name is not valid: %s", err))
+		} else {
+			cmd.name = args[0]
+		}
+	}
+
+	// Validate flags
+	if cmd.Flags != nil {
+		if cmd.Flags.ConnectorNamespace == "" {
+			validationErrors = append(validationErrors, fmt.Errorf("connector namespace must be configured"))
+		} else {
+			ok, err := resourceStringValidator.Evaluate(cmd.Flags.ConnectorNamespace)
+			if !ok {
+				validationErrors = append(validationErrors, fmt.Errorf("connector namespace is not valid: %s", err))
+			}
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.RoutingKey != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.RoutingKey)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("routing key is not valid: %s", err))
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdAttachedConnectorBindingGenerate) InputToOptions() {
+
+	// default routingkey to name of attached connector binding
+	if cmd.Flags.RoutingKey == "" {
+		cmd.routingKey = cmd.name
+	} else {
+		cmd.routingKey = cmd.Flags.RoutingKey
+	}
+
+	cmd.connectorNamespace = cmd.Flags.ConnectorNamespace
+	cmd.exposePodsByName = cmd.Flags.ExposePodsByName
+	cmd.output = cmd.Flags.Output
+}
+
+func (cmd *CmdAttachedConnectorBindingGenerate) Run() error {
+
+	resource := v2alpha1.AttachedConnectorBinding{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "AttachedConnectorBinding",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cmd.name,
+			Namespace: cmd.namespace,
+		},
+		Spec: v2alpha1.AttachedConnectorBindingSpec{
+			ConnectorNamespace: cmd.connectorNamespace,
+			RoutingKey:         cmd.routingKey,
+			ExposePodsByName:   cmd.exposePodsByName,
+		},
+	}
+
+	encodedOutput, err := utils.Encode(cmd.output, resource)
+	fmt.Println(encodedOutput)
+	return err
+}
+
+func (cmd *CmdAttachedConnectorBindingGenerate) WaitUntil() error { return nil }
@@ -0,0 +1,95 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"gotest.tools/v3/assert"
+)
+
+func TestCmdAttachedConnectorBindingGenerate_ValidateInput(t *testing.T) {
+	type test struct {
+		name          string
+		args          []string
+		flags         common.CommandAttachedConnectorBindingGenerateFlags
+		expectedError string
+	}
+
+	testTable := []test{
+		{
+			name:          "attached connector binding name is not specified",
+			flags:         common.CommandAttachedConnectorBindingGenerateFlags{ConnectorNamespace: "backend"},
+			expectedError: "attached connector binding name must be configured",
+		},
+		{
+			name:          "connector namespace is missing",
+			args:          []string{"my-binding"},
+			expectedError: "connector namespace must be configured",
+		},
+		{
+			name:          "output format is not valid",
+			args:          []string{"my-binding"},
+			flags:         common.CommandAttachedConnectorBindingGenerateFlags{ConnectorNamespace: "backend", Output: "not-supported"},
+			expectedError: "output type is not valid: value not-supported not allowed. It should be one of this options: [json yaml]",
+		},
+		{
+			name:  "flags all valid",
+			args:  []string{"my-binding"},
+			flags: common.CommandAttachedConnectorBindingGenerateFlags{ConnectorNamespace: "backend", RoutingKey: "backend", Output: "json"},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdAttachedConnectorBindingGenerate{Flags: &test.flags}
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdAttachedConnectorBindingGenerate_Run(t *testing.T) {
+	type test struct {
+		name         string
+		output       string
+		errorMessage string
+	}
+
+	testTable := []test{
+		{
+			name:   "runs ok with yaml output",
+			output: "yaml",
+		},
+		{
+			name:   "runs ok with json output",
+			output: "json",
+		},
+		{
+			name:         "run fails with unsupported output",
+			output:       "unsupported",
+			errorMessage: "format unsupported not supported",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd := &CmdAttachedConnectorBindingGenerate{
+			Flags: &common.CommandAttachedConnectorBindingGenerateFlags{
+				ConnectorNamespace: "backend",
+				Output:             test.output,
+			},
+			name:      "my-binding",
+			namespace: "test",
+		}
+		cmd.InputToOptions()
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Error(t, err, test.errorMessage)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
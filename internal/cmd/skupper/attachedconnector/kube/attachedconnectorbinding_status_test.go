@@ -0,0 +1,150 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"gotest.tools/v3/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdAttachedConnectorBindingStatus_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		flags          common.CommandAttachedConnectorBindingStatusFlags
+		skupperObjects []runtime.Object
+		expectedError  string
+		skupperError   string
+	}
+
+	testTable := []test{
+		{
+			name:          "missing CRD",
+			args:          []string{"my-binding"},
+			skupperError:  utils.CrdErr,
+			expectedError: utils.CrdHelpErr,
+		},
+		{
+			name:          "attached connector binding does not exist in the namespace",
+			args:          []string{"my-binding"},
+			expectedError: "attached connector binding my-binding does not exist in namespace test",
+		},
+		{
+			name:          "attached connector binding name is nil",
+			args:          []string{""},
+			expectedError: "attached connector binding name must not be empty",
+		},
+		{
+			name:          "more than one argument is specified",
+			args:          []string{"my", "binding"},
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:          "no args",
+			expectedError: "",
+		},
+		{
+			name:           "bad output status",
+			args:           []string{"my-binding"},
+			flags:          common.CommandAttachedConnectorBindingStatusFlags{Output: "not-supported"},
+			skupperObjects: []runtime.Object{existingAttachedConnectorBinding()},
+			expectedError:  "output type is not valid: value not-supported not allowed. It should be one of this options: [json yaml]",
+		},
+		{
+			name:           "good output status",
+			args:           []string{"my-binding"},
+			flags:          common.CommandAttachedConnectorBindingStatusFlags{Output: "json"},
+			skupperObjects: []runtime.Object{existingAttachedConnectorBinding()},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdAttachedConnectorBindingStatusWithMocks("test", nil, test.skupperObjects, test.skupperError)
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdAttachedConnectorBindingStatus_Run(t *testing.T) {
+	type test struct {
+		name                string
+		bindingName         string
+		output              string
+		skupperObjects      []runtime.Object
+		skupperErrorMessage string
+		errorMessage        string
+	}
+
+	testTable := []test{
+		{
+			name:                "run fails",
+			bindingName:         "my-binding",
+			skupperErrorMessage: "error",
+			errorMessage:        "error",
+		},
+		{
+			name:           "runs ok",
+			bindingName:    "my-binding",
+			skupperObjects: []runtime.Object{existingAttachedConnectorBinding()},
+		},
+		{
+			name:           "runs ok with yaml output",
+			bindingName:    "my-binding",
+			output:         "yaml",
+			skupperObjects: []runtime.Object{existingAttachedConnectorBinding()},
+		},
+		{
+			name:           "runs ok listing attached connector binding bindings",
+			skupperObjects: []runtime.Object{existingAttachedConnectorBinding()},
+		},
+		{
+			name:           "runs ok listing attached connector binding bindings with json output",
+			output:         "json",
+			skupperObjects: []runtime.Object{existingAttachedConnectorBinding()},
+		},
+		{
+			name: "runs ok with no attached connector binding bindings",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdAttachedConnectorBindingStatusWithMocks("test", nil, test.skupperObjects, test.skupperErrorMessage)
+		assert.Assert(t, err)
+		cmd.name = test.bindingName
+		cmd.output = test.output
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Error(t, err, test.errorMessage)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdAttachedConnectorBindingStatusWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdAttachedConnectorBindingStatus, error) {
+
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdAttachedConnectorBindingStatus := &CmdAttachedConnectorBindingStatus{
+		client:    client.GetSkupperClient().SkupperV2alpha1(),
+		namespace: namespace,
+	}
+	return cmdAttachedConnectorBindingStatus, nil
+}
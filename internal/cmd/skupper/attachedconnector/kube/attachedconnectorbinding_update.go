@@ -0,0 +1,187 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	"github.com/skupperproject/skupper/internal/kube/client"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/generated/client/clientset/versioned/typed/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	k8serrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CmdAttachedConnectorBindingUpdate struct {
+	client          skupperv2alpha1.SkupperV2alpha1Interface
+	CobraCmd        *cobra.Command
+	Flags           *common.CommandAttachedConnectorBindingUpdateFlags
+	namespace       string
+	name            string
+	resourceVersion string
+	newSettings     v2alpha1.AttachedConnectorBindingSpec
+	status          string
+}
+
+func NewCmdAttachedConnectorBindingUpdate() *CmdAttachedConnectorBindingUpdate {
+
+	return &CmdAttachedConnectorBindingUpdate{}
+}
+
+func (cmd *CmdAttachedConnectorBindingUpdate) NewClient(cobraCommand *cobra.Command, args []string) {
+	cli, err := client.NewClient(cobraCommand.Flag("namespace").Value.String(), cobraCommand.Flag("context").Value.String(), cobraCommand.Flag("kubeconfig").Value.String())
+	utils.HandleError(utils.GenericError, err)
+
+	cmd.client = cli.GetSkupperClient().SkupperV2alpha1()
+	cmd.namespace = cli.Namespace
+}
+
+func (cmd *CmdAttachedConnectorBindingUpdate) ValidateInput(args []string) error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	timeoutValidator := validator.NewTimeoutInSecondsValidator()
+	statusValidator := validator.NewOptionValidator(common.WaitStatusTypes)
+
+	// Check if AttachedConnectorBinding CRD is installed
+	_, err := cmd.client.AttachedConnectorBindings(cmd.namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		validationErrors = append(validationErrors, utils.HandleMissingCrds(err))
+		return errors.Join(validationErrors...)
+	}
+
+	// Validate arguments name
+	if len(args) < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("attached connector binding name must be configured"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("attached connector binding name must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("attached connector binding name is not valid: %s", err))
+		} else {
+			cmd.name = args[0]
+		}
+	}
+
+	// Validate that there is already an attached connector binding with this name in the namespace
+	if cmd.name != "" {
+		binding, err := cmd.client.AttachedConnectorBindings(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if binding == nil || k8serrs.IsNotFound(err) {
+			validationErrors = append(validationErrors, fmt.Errorf("attached connector binding %s must exist in namespace %s to be updated", cmd.name, cmd.namespace))
+		} else {
+			// save existing values
+			cmd.resourceVersion = binding.ResourceVersion
+			cmd.newSettings = binding.Spec
+		}
+	}
+
+	// Validate flags
+	if cmd.Flags != nil && cmd.Flags.RoutingKey != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.RoutingKey)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("routing key is not valid: %s", err))
+		} else {
+			cmd.newSettings.RoutingKey = cmd.Flags.RoutingKey
+		}
+	}
+	if cmd.Flags != nil && cmd.CobraCmd != nil && cmd.CobraCmd.Flags().Changed(common.FlagNameExposePodsByName) {
+		cmd.newSettings.ExposePodsByName = cmd.Flags.ExposePodsByName
+	}
+	if cmd.Flags != nil && cmd.Flags.Timeout.String() != "" {
+		ok, err := timeoutValidator.Evaluate(cmd.Flags.Timeout)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("timeout is not valid: %s", err))
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Wait != "" {
+		ok, err := statusValidator.Evaluate(cmd.Flags.Wait)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("status is not valid: %s", err))
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdAttachedConnectorBindingUpdate) Run() error {
+
+	resource := v2alpha1.AttachedConnectorBinding{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "AttachedConnectorBinding",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            cmd.name,
+			Namespace:       cmd.namespace,
+			ResourceVersion: cmd.resourceVersion,
+		},
+		Spec: cmd.newSettings,
+	}
+
+	_, err := cmd.client.AttachedConnectorBindings(cmd.namespace).Update(context.TODO(), &resource, metav1.UpdateOptions{})
+	return err
+}
+
+func (cmd *CmdAttachedConnectorBindingUpdate) WaitUntil() error {
+
+	if cmd.status == "none" {
+		return nil
+	}
+
+	waitTime := int(cmd.Flags.Timeout.Seconds())
+	var bindingCondition *metav1.Condition
+	err := utils.NewSpinnerWithTimeout("Waiting for update to complete...", waitTime, func() error {
+
+		resource, err := cmd.client.AttachedConnectorBindings(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		isConditionFound := false
+		isConditionTrue := false
+
+		switch cmd.status {
+		case "ready":
+			bindingCondition = meta.FindStatusCondition(resource.Status.Conditions, v2alpha1.CONDITION_TYPE_READY)
+		default:
+			bindingCondition = meta.FindStatusCondition(resource.Status.Conditions, v2alpha1.CONDITION_TYPE_CONFIGURED)
+		}
+
+		if bindingCondition != nil {
+			isConditionFound = true
+			isConditionTrue = bindingCondition.Status == metav1.ConditionTrue
+		}
+
+		if resource != nil && isConditionFound && isConditionTrue {
+			return nil
+		}
+
+		if resource != nil && isConditionFound && !isConditionTrue {
+			return fmt.Errorf("error in the condition")
+		}
+
+		return fmt.Errorf("error getting the resource")
+	})
+
+	if err != nil && bindingCondition == nil {
+		return fmt.Errorf("AttachedConnectorBinding %q is not yet %s, check the status for more information\n", cmd.name, cmd.status)
+	} else if err != nil && bindingCondition.Status == metav1.ConditionFalse {
+		return fmt.Errorf("AttachedConnectorBinding %q is not yet %s: %s\n", cmd.name, cmd.status, bindingCondition.Message)
+	}
+
+	fmt.Printf("AttachedConnectorBinding %q is updated\n", cmd.name)
+	return nil
+}
+
+func (cmd *CmdAttachedConnectorBindingUpdate) InputToOptions() {
+	cmd.status = cmd.Flags.Wait
+}
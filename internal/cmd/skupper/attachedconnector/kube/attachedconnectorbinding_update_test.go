@@ -0,0 +1,193 @@
+package kube
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdAttachedConnectorBindingUpdate_ValidateInput(t *testing.T) {
+	type test struct {
+		name                string
+		args                []string
+		flags               common.CommandAttachedConnectorBindingUpdateFlags
+		changedFlags        map[string]string
+		skupperObjects      []runtime.Object
+		skupperErrorMessage string
+		expectedError       string
+		expectedSettings    *v2alpha1.AttachedConnectorBindingSpec
+	}
+
+	testTable := []test{
+		{
+			name:                "missing CRD",
+			args:                []string{"my-binding"},
+			skupperErrorMessage: utils.CrdErr,
+			expectedError:       utils.CrdHelpErr,
+		},
+		{
+			name:          "attached connector binding does not exist",
+			args:          []string{"my-binding"},
+			flags:         common.CommandAttachedConnectorBindingUpdateFlags{Timeout: time.Minute},
+			expectedError: "attached connector binding my-binding must exist in namespace test to be updated",
+		},
+		{
+			name:           "attached connector binding name is not specified",
+			flags:          common.CommandAttachedConnectorBindingUpdateFlags{Timeout: time.Minute},
+			skupperObjects: []runtime.Object{existingAttachedConnectorBinding()},
+			expectedError:  "attached connector binding name must be configured",
+		},
+		{
+			name:           "routing key is not valid",
+			args:           []string{"my-binding"},
+			flags:          common.CommandAttachedConnectorBindingUpdateFlags{RoutingKey: "not valid", Timeout: time.Minute},
+			skupperObjects: []runtime.Object{existingAttachedConnectorBinding()},
+			expectedError:  "routing key is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])*(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])*)*$",
+		},
+		{
+			name:           "wait status is not valid",
+			args:           []string{"my-binding"},
+			flags:          common.CommandAttachedConnectorBindingUpdateFlags{Timeout: time.Minute, Wait: "pending"},
+			skupperObjects: []runtime.Object{existingAttachedConnectorBinding()},
+			expectedError:  "status is not valid: value pending not allowed. It should be one of this options: [ready configured none]",
+		},
+		{
+			name:           "unspecified options keep their value",
+			args:           []string{"my-binding"},
+			flags:          common.CommandAttachedConnectorBindingUpdateFlags{Timeout: time.Minute},
+			skupperObjects: []runtime.Object{existingAttachedConnectorBinding()},
+			expectedSettings: &v2alpha1.AttachedConnectorBindingSpec{
+				ConnectorNamespace: "backend",
+				RoutingKey:         "backend",
+			},
+		},
+		{
+			name: "all options updated",
+			args: []string{"my-binding"},
+			flags: common.CommandAttachedConnectorBindingUpdateFlags{
+				RoutingKey:       "frontend",
+				ExposePodsByName: true,
+				Timeout:          time.Minute,
+			},
+			changedFlags:   map[string]string{common.FlagNameExposePodsByName: "true"},
+			skupperObjects: []runtime.Object{existingAttachedConnectorBinding()},
+			expectedSettings: &v2alpha1.AttachedConnectorBindingSpec{
+				ConnectorNamespace: "backend",
+				RoutingKey:         "frontend",
+				ExposePodsByName:   true,
+			},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdAttachedConnectorBindingUpdateWithMocks("test", nil, test.skupperObjects, test.skupperErrorMessage)
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+			command.CobraCmd = &cobra.Command{Use: "update"}
+			command.CobraCmd.Flags().BoolVar(&command.Flags.ExposePodsByName, common.FlagNameExposePodsByName, false, "")
+			for name, value := range test.changedFlags {
+				assert.Assert(t, command.CobraCmd.Flags().Set(name, value))
+			}
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+			if test.expectedSettings != nil {
+				assert.DeepEqual(t, command.newSettings, *test.expectedSettings)
+			}
+		})
+	}
+}
+
+func TestCmdAttachedConnectorBindingUpdate_Run(t *testing.T) {
+	command, err := newCmdAttachedConnectorBindingUpdateWithMocks("test", nil, []runtime.Object{existingAttachedConnectorBinding()}, "")
+	assert.Assert(t, err)
+	command.Flags = &common.CommandAttachedConnectorBindingUpdateFlags{RoutingKey: "frontend", Timeout: time.Minute}
+	assert.Assert(t, command.ValidateInput([]string{"my-binding"}))
+	command.InputToOptions()
+
+	assert.Assert(t, command.Run())
+
+	binding, err := command.client.AttachedConnectorBindings("test").Get(t.Context(), "my-binding", v1.GetOptions{})
+	assert.Assert(t, err)
+	assert.Equal(t, binding.Spec.RoutingKey, "frontend")
+	assert.Equal(t, binding.Spec.ConnectorNamespace, "backend")
+}
+
+func TestCmdAttachedConnectorBindingUpdate_WaitUntil(t *testing.T) {
+	type test struct {
+		name           string
+		status         string
+		skupperObjects []runtime.Object
+		expectError    bool
+	}
+
+	testTable := []test{
+		{
+			name:           "attached connector binding is ready",
+			status:         "ready",
+			skupperObjects: []runtime.Object{existingAttachedConnectorBinding()},
+		},
+		{
+			name:           "attached connector binding is not configured",
+			status:         "configured",
+			skupperObjects: []runtime.Object{existingAttachedConnectorBinding()},
+			expectError:    true,
+		},
+		{
+			name:        "attached connector binding is not returned",
+			status:      "ready",
+			expectError: true,
+		},
+		{
+			name:   "user does not wait",
+			status: "none",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdAttachedConnectorBindingUpdateWithMocks("test", nil, test.skupperObjects, "")
+		assert.Assert(t, err)
+
+		cmd.name = "my-binding"
+		cmd.Flags = &common.CommandAttachedConnectorBindingUpdateFlags{Timeout: time.Second}
+		cmd.status = test.status
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.WaitUntil()
+			if test.expectError {
+				assert.Check(t, err != nil)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdAttachedConnectorBindingUpdateWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdAttachedConnectorBindingUpdate, error) {
+
+	// We make sure the interval is appropriate
+	utils.SetRetryProfile(utils.TestRetryProfile)
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdAttachedConnectorBindingUpdate := &CmdAttachedConnectorBindingUpdate{
+		client:    client.GetSkupperClient().SkupperV2alpha1(),
+		namespace: namespace,
+	}
+	return cmdAttachedConnectorBindingUpdate, nil
+}
@@ -0,0 +1,25 @@
+package nonkube
+
+import (
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/spf13/cobra"
+)
+
+type CmdAttachedConnectorCreate struct {
+	CobraCmd *cobra.Command
+	Flags    *common.CommandAttachedConnectorCreateFlags
+}
+
+func NewCmdAttachedConnectorCreate() *CmdAttachedConnectorCreate {
+	return &CmdAttachedConnectorCreate{}
+}
+
+func (cmd *CmdAttachedConnectorCreate) NewClient(cobraCommand *cobra.Command, args []string) {}
+func (cmd *CmdAttachedConnectorCreate) ValidateInput(args []string) error                    { return nil }
+func (cmd *CmdAttachedConnectorCreate) InputToOptions()                                      {}
+func (cmd *CmdAttachedConnectorCreate) Run() error {
+	return fmt.Errorf("command not supported by the selected platform")
+}
+func (cmd *CmdAttachedConnectorCreate) WaitUntil() error { return nil }
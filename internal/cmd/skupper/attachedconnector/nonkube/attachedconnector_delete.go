@@ -0,0 +1,25 @@
+package nonkube
+
+import (
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/spf13/cobra"
+)
+
+type CmdAttachedConnectorDelete struct {
+	CobraCmd *cobra.Command
+	Flags    *common.CommandAttachedConnectorDeleteFlags
+}
+
+func NewCmdAttachedConnectorDelete() *CmdAttachedConnectorDelete {
+	return &CmdAttachedConnectorDelete{}
+}
+
+func (cmd *CmdAttachedConnectorDelete) NewClient(cobraCommand *cobra.Command, args []string) {}
+func (cmd *CmdAttachedConnectorDelete) ValidateInput(args []string) error                    { return nil }
+func (cmd *CmdAttachedConnectorDelete) InputToOptions()                                      {}
+func (cmd *CmdAttachedConnectorDelete) Run() error {
+	return fmt.Errorf("command not supported by the selected platform")
+}
+func (cmd *CmdAttachedConnectorDelete) WaitUntil() error { return nil }
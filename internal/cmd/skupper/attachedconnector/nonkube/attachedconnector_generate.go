@@ -0,0 +1,25 @@
+package nonkube
+
+import (
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/spf13/cobra"
+)
+
+type CmdAttachedConnectorGenerate struct {
+	CobraCmd *cobra.Command
+	Flags    *common.CommandAttachedConnectorGenerateFlags
+}
+
+func NewCmdAttachedConnectorGenerate() *CmdAttachedConnectorGenerate {
+	return &CmdAttachedConnectorGenerate{}
+}
+
+func (cmd *CmdAttachedConnectorGenerate) NewClient(cobraCommand *cobra.Command, args []string) {}
+func (cmd *CmdAttachedConnectorGenerate) ValidateInput(args []string) error                    { return nil }
+func (cmd *CmdAttachedConnectorGenerate) InputToOptions()                                      {}
+func (cmd *CmdAttachedConnectorGenerate) Run() error {
+	return fmt.Errorf("command not supported by the selected platform")
+}
+func (cmd *CmdAttachedConnectorGenerate) WaitUntil() error { return nil }
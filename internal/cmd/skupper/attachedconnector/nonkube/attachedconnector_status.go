@@ -0,0 +1,25 @@
+package nonkube
+
+import (
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/spf13/cobra"
+)
+
+type CmdAttachedConnectorStatus struct {
+	CobraCmd *cobra.Command
+	Flags    *common.CommandAttachedConnectorStatusFlags
+}
+
+func NewCmdAttachedConnectorStatus() *CmdAttachedConnectorStatus {
+	return &CmdAttachedConnectorStatus{}
+}
+
+func (cmd *CmdAttachedConnectorStatus) NewClient(cobraCommand *cobra.Command, args []string) {}
+func (cmd *CmdAttachedConnectorStatus) ValidateInput(args []string) error                    { return nil }
+func (cmd *CmdAttachedConnectorStatus) InputToOptions()                                      {}
+func (cmd *CmdAttachedConnectorStatus) Run() error {
+	return fmt.Errorf("command not supported by the selected platform")
+}
+func (cmd *CmdAttachedConnectorStatus) WaitUntil() error { return nil }
@@ -0,0 +1,25 @@
+package nonkube
+
+import (
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/spf13/cobra"
+)
+
+type CmdAttachedConnectorUpdate struct {
+	CobraCmd *cobra.Command
+	Flags    *common.CommandAttachedConnectorUpdateFlags
+}
+
+func NewCmdAttachedConnectorUpdate() *CmdAttachedConnectorUpdate {
+	return &CmdAttachedConnectorUpdate{}
+}
+
+func (cmd *CmdAttachedConnectorUpdate) NewClient(cobraCommand *cobra.Command, args []string) {}
+func (cmd *CmdAttachedConnectorUpdate) ValidateInput(args []string) error                    { return nil }
+func (cmd *CmdAttachedConnectorUpdate) InputToOptions()                                      {}
+func (cmd *CmdAttachedConnectorUpdate) Run() error {
+	return fmt.Errorf("command not supported by the selected platform")
+}
+func (cmd *CmdAttachedConnectorUpdate) WaitUntil() error { return nil }
@@ -0,0 +1,25 @@
+package nonkube
+
+import (
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/spf13/cobra"
+)
+
+type CmdAttachedConnectorBindingCreate struct {
+	CobraCmd *cobra.Command
+	Flags    *common.CommandAttachedConnectorBindingCreateFlags
+}
+
+func NewCmdAttachedConnectorBindingCreate() *CmdAttachedConnectorBindingCreate {
+	return &CmdAttachedConnectorBindingCreate{}
+}
+
+func (cmd *CmdAttachedConnectorBindingCreate) NewClient(cobraCommand *cobra.Command, args []string) {}
+func (cmd *CmdAttachedConnectorBindingCreate) ValidateInput(args []string) error                    { return nil }
+func (cmd *CmdAttachedConnectorBindingCreate) InputToOptions()                                      {}
+func (cmd *CmdAttachedConnectorBindingCreate) Run() error {
+	return fmt.Errorf("command not supported by the selected platform")
+}
+func (cmd *CmdAttachedConnectorBindingCreate) WaitUntil() error { return nil }
@@ -0,0 +1,25 @@
+package nonkube
+
+import (
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/spf13/cobra"
+)
+
+type CmdAttachedConnectorBindingDelete struct {
+	CobraCmd *cobra.Command
+	Flags    *common.CommandAttachedConnectorBindingDeleteFlags
+}
+
+func NewCmdAttachedConnectorBindingDelete() *CmdAttachedConnectorBindingDelete {
+	return &CmdAttachedConnectorBindingDelete{}
+}
+
+func (cmd *CmdAttachedConnectorBindingDelete) NewClient(cobraCommand *cobra.Command, args []string) {}
+func (cmd *CmdAttachedConnectorBindingDelete) ValidateInput(args []string) error                    { return nil }
+func (cmd *CmdAttachedConnectorBindingDelete) InputToOptions()                                      {}
+func (cmd *CmdAttachedConnectorBindingDelete) Run() error {
+	return fmt.Errorf("command not supported by the selected platform")
+}
+func (cmd *CmdAttachedConnectorBindingDelete) WaitUntil() error { return nil }
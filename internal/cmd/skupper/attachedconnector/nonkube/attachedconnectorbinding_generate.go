@@ -0,0 +1,26 @@
+package nonkube
+
+import (
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/spf13/cobra"
+)
+
+type CmdAttachedConnectorBindingGenerate struct {
+	CobraCmd *cobra.Command
+	Flags    *common.CommandAttachedConnectorBindingGenerateFlags
+}
+
+func NewCmdAttachedConnectorBindingGenerate() *CmdAttachedConnectorBindingGenerate {
+	return &CmdAttachedConnectorBindingGenerate{}
+}
+
+func (cmd *CmdAttachedConnectorBindingGenerate) NewClient(cobraCommand *cobra.Command, args []string) {
+}
+func (cmd *CmdAttachedConnectorBindingGenerate) ValidateInput(args []string) error { return nil }
+func (cmd *CmdAttachedConnectorBindingGenerate) InputToOptions()                   {}
+func (cmd *CmdAttachedConnectorBindingGenerate) Run() error {
+	return fmt.Errorf("command not supported by the selected platform")
+}
+func (cmd *CmdAttachedConnectorBindingGenerate) WaitUntil() error { return nil }
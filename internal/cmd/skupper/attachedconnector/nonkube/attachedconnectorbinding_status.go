@@ -0,0 +1,25 @@
+package nonkube
+
+import (
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/spf13/cobra"
+)
+
+type CmdAttachedConnectorBindingStatus struct {
+	CobraCmd *cobra.Command
+	Flags    *common.CommandAttachedConnectorBindingStatusFlags
+}
+
+func NewCmdAttachedConnectorBindingStatus() *CmdAttachedConnectorBindingStatus {
+	return &CmdAttachedConnectorBindingStatus{}
+}
+
+func (cmd *CmdAttachedConnectorBindingStatus) NewClient(cobraCommand *cobra.Command, args []string) {}
+func (cmd *CmdAttachedConnectorBindingStatus) ValidateInput(args []string) error                    { return nil }
+func (cmd *CmdAttachedConnectorBindingStatus) InputToOptions()                                      {}
+func (cmd *CmdAttachedConnectorBindingStatus) Run() error {
+	return fmt.Errorf("command not supported by the selected platform")
+}
+func (cmd *CmdAttachedConnectorBindingStatus) WaitUntil() error { return nil }
@@ -0,0 +1,25 @@
+package nonkube
+
+import (
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/spf13/cobra"
+)
+
+type CmdAttachedConnectorBindingUpdate struct {
+	CobraCmd *cobra.Command
+	Flags    *common.CommandAttachedConnectorBindingUpdateFlags
+}
+
+func NewCmdAttachedConnectorBindingUpdate() *CmdAttachedConnectorBindingUpdate {
+	return &CmdAttachedConnectorBindingUpdate{}
+}
+
+func (cmd *CmdAttachedConnectorBindingUpdate) NewClient(cobraCommand *cobra.Command, args []string) {}
+func (cmd *CmdAttachedConnectorBindingUpdate) ValidateInput(args []string) error                    { return nil }
+func (cmd *CmdAttachedConnectorBindingUpdate) InputToOptions()                                      {}
+func (cmd *CmdAttachedConnectorBindingUpdate) Run() error {
+	return fmt.Errorf("command not supported by the selected platform")
+}
+func (cmd *CmdAttachedConnectorBindingUpdate) WaitUntil() error { return nil }
@@ -0,0 +1,189 @@
+package certificate
+
+import (
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/certificate/kube"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/certificate/nonkube"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdCertificate() *cobra.Command {
+
+	cmd := &cobra.Command{
+		Use:   "certificate",
+		Short: "TLS credentials issued by the Skupper controller",
+		Long: `A certificate requests TLS credentials for the given hosts, signed by a certificate authority.
+The generated credentials are stored in a secret with the same name as the certificate.`,
+		Example: `skupper certificate create my-ca --signing
+skupper certificate create backend-tls --ca my-ca --hosts backend.example.com --server`,
+	}
+
+	platform := common.Platform(config.GetPlatform())
+	cmd.AddCommand(CmdCertificateCreateFactory(platform))
+	cmd.AddCommand(CmdCertificateStatusFactory(platform))
+	cmd.AddCommand(CmdCertificateUpdateFactory(platform))
+	cmd.AddCommand(CmdCertificateDeleteFactory(platform))
+	cmd.AddCommand(CmdCertificateGenerateFactory(platform))
+
+	return cmd
+}
+
+func CmdCertificateCreateFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdCertificateCreate()
+	nonKubeCommand := nonkube.NewCmdCertificateCreate()
+
+	cmdCertificateCreateDesc := common.SkupperCmdDescription{
+		Use:   "create <name>",
+		Short: "create a certificate",
+		Long: `Create a certificate signed by the given certificate authority.
+A signing certificate is self-signed and can be used as the certificate authority of other certificates.`,
+		Example: "skupper certificate create backend-tls --ca my-ca --hosts backend.example.com --server",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdCertificateCreateDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandCertificateCreateFlags{}
+
+	cmd.Flags().StringVar(&cmdFlags.Ca, common.FlagNameCa, "", common.FlagDescCa)
+	cmd.Flags().StringVar(&cmdFlags.Subject, common.FlagNameSubject, "", common.FlagDescSubject)
+	cmd.Flags().StringSliceVar(&cmdFlags.Hosts, common.FlagNameHosts, []string{}, common.FlagDescHosts)
+	cmd.Flags().BoolVar(&cmdFlags.Client, common.FlagNameClient, false, common.FlagDescClient)
+	cmd.Flags().BoolVar(&cmdFlags.Server, common.FlagNameServer, false, common.FlagDescServer)
+	cmd.Flags().BoolVar(&cmdFlags.Signing, common.FlagNameSigning, false, common.FlagDescSigning)
+
+	if configuredPlatform == common.PlatformKubernetes {
+		cmd.Flags().DurationVar(&cmdFlags.Timeout, common.FlagNameTimeout, 60*time.Second, common.FlagDescTimeout)
+		cmd.Flags().StringVar(&cmdFlags.Wait, common.FlagNameWait, "ready", common.FlagDescReadyWait)
+	}
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
+
+func CmdCertificateUpdateFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdCertificateUpdate()
+	nonKubeCommand := nonkube.NewCmdCertificateUpdate()
+
+	cmdCertificateUpdateDesc := common.SkupperCmdDescription{
+		Use:   "update <name>",
+		Short: "update a certificate",
+		Long: `Change certificate parameters. The Skupper controller reissues the credentials.
+	Options that are not specified keep their current value.`,
+		Example: "skupper certificate update backend-tls --hosts backend.example.com,10.0.0.1",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdCertificateUpdateDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandCertificateUpdateFlags{}
+
+	cmd.Flags().StringVar(&cmdFlags.Ca, common.FlagNameCa, "", common.FlagDescCa)
+	cmd.Flags().StringVar(&cmdFlags.Subject, common.FlagNameSubject, "", common.FlagDescSubject)
+	cmd.Flags().StringSliceVar(&cmdFlags.Hosts, common.FlagNameHosts, []string{}, common.FlagDescHosts)
+	cmd.Flags().BoolVar(&cmdFlags.Client, common.FlagNameClient, false, common.FlagDescClient)
+	cmd.Flags().BoolVar(&cmdFlags.Server, common.FlagNameServer, false, common.FlagDescServer)
+	cmd.Flags().BoolVar(&cmdFlags.Signing, common.FlagNameSigning, false, common.FlagDescSigning)
+
+	if configuredPlatform == common.PlatformKubernetes {
+		cmd.Flags().DurationVar(&cmdFlags.Timeout, common.FlagNameTimeout, 60*time.Second, common.FlagDescTimeout)
+		cmd.Flags().StringVar(&cmdFlags.Wait, common.FlagNameWait, "ready", common.FlagDescReadyWait)
+	}
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
+
+func CmdCertificateStatusFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdCertificateStatus()
+	nonKubeCommand := nonkube.NewCmdCertificateStatus()
+
+	cmdCertificateStatusDesc := common.SkupperCmdDescription{
+		Use:     "status <name>",
+		Short:   "get status of certificates",
+		Long:    "Display status of all certificates or a specific certificate",
+		Example: "skupper certificate status backend-tls",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdCertificateStatusDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandCertificateStatusFlags{}
+
+	cmd.Flags().StringVarP(&cmdFlags.Output, common.FlagNameOutput, "o", "", common.FlagDescOutput)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
+
+func CmdCertificateDeleteFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdCertificateDelete()
+	nonKubeCommand := nonkube.NewCmdCertificateDelete()
+
+	cmdCertificateDeleteDesc := common.SkupperCmdDescription{
+		Use:     "delete <name>",
+		Short:   "delete a certificate",
+		Long:    "Delete a certificate <name>",
+		Example: "skupper certificate delete backend-tls",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdCertificateDeleteDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandCertificateDeleteFlags{}
+
+	if configuredPlatform == common.PlatformKubernetes {
+		cmd.Flags().DurationVar(&cmdFlags.Timeout, common.FlagNameTimeout, 60*time.Second, common.FlagDescTimeout)
+		cmd.Flags().BoolVar(&cmdFlags.Wait, common.FlagNameWait, true, common.FlagDescDeleteWait)
+	}
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
+
+func CmdCertificateGenerateFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdCertificateGenerate()
+	nonKubeCommand := nonkube.NewCmdCertificateGenerate()
+
+	cmdCertificateGenerateDesc := common.SkupperCmdDescription{
+		Use:   "generate <name>",
+		Short: "generate a certificate resource and output it to a file or screen",
+		Long: `Create a certificate signed by the given certificate authority.
+	generate a certificate to evaluate what will be created with certificate create command`,
+		Example: "skupper certificate generate backend-tls --ca my-ca --hosts backend.example.com --server",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdCertificateGenerateDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandCertificateGenerateFlags{}
+
+	cmd.Flags().StringVar(&cmdFlags.Ca, common.FlagNameCa, "", common.FlagDescCa)
+	cmd.Flags().StringVar(&cmdFlags.Subject, common.FlagNameSubject, "", common.FlagDescSubject)
+	cmd.Flags().StringSliceVar(&cmdFlags.Hosts, common.FlagNameHosts, []string{}, common.FlagDescHosts)
+	cmd.Flags().BoolVar(&cmdFlags.Client, common.FlagNameClient, false, common.FlagDescClient)
+	cmd.Flags().BoolVar(&cmdFlags.Server, common.FlagNameServer, false, common.FlagDescServer)
+	cmd.Flags().BoolVar(&cmdFlags.Signing, common.FlagNameSigning, false, common.FlagDescSigning)
+	cmd.Flags().StringVarP(&cmdFlags.Output, common.FlagNameOutput, "o", "yaml", common.FlagDescOutput)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
@@ -0,0 +1,118 @@
+package certificate
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gotest.tools/v3/assert"
+)
+
+func TestCmdCertificateFactory(t *testing.T) {
+
+	type test struct {
+		name                          string
+		expectedFlagsWithDefaultValue map[string]interface{}
+		command                       *cobra.Command
+	}
+
+	testTable := []test{
+		{
+			name: "CmdCertificateCreateFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameCa:      "",
+				common.FlagNameSubject: "",
+				common.FlagNameHosts:   "[]",
+				common.FlagNameClient:  "false",
+				common.FlagNameServer:  "false",
+				common.FlagNameSigning: "false",
+				common.FlagNameTimeout: "1m0s",
+				common.FlagNameWait:    "ready",
+			},
+			command: CmdCertificateCreateFactory(common.PlatformKubernetes),
+		},
+		{
+			name: "CmdCertificateUpdateFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameCa:      "",
+				common.FlagNameSubject: "",
+				common.FlagNameHosts:   "[]",
+				common.FlagNameClient:  "false",
+				common.FlagNameServer:  "false",
+				common.FlagNameSigning: "false",
+				common.FlagNameTimeout: "1m0s",
+				common.FlagNameWait:    "ready",
+			},
+			command: CmdCertificateUpdateFactory(common.PlatformKubernetes),
+		},
+		{
+			name: "CmdCertificateStatusFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameOutput: "",
+			},
+			command: CmdCertificateStatusFactory(common.PlatformKubernetes),
+		},
+		{
+			name: "CmdCertificateDeleteFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameTimeout: "1m0s",
+				common.FlagNameWait:    "true",
+			},
+			command: CmdCertificateDeleteFactory(common.PlatformKubernetes),
+		},
+		{
+			name: "CmdCertificateGenerateFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameCa:      "",
+				common.FlagNameSubject: "",
+				common.FlagNameHosts:   "[]",
+				common.FlagNameClient:  "false",
+				common.FlagNameServer:  "false",
+				common.FlagNameSigning: "false",
+				common.FlagNameOutput:  "yaml",
+			},
+			command: CmdCertificateGenerateFactory(common.PlatformKubernetes),
+		},
+		{
+			name: "CmdCertificateCreateFactory on podman",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameCa:      "",
+				common.FlagNameSubject: "",
+				common.FlagNameHosts:   "[]",
+				common.FlagNameClient:  "false",
+				common.FlagNameServer:  "false",
+				common.FlagNameSigning: "false",
+			},
+			command: CmdCertificateCreateFactory(common.PlatformPodman),
+		},
+		{
+			name:                          "CmdCertificateDeleteFactory on podman",
+			expectedFlagsWithDefaultValue: map[string]interface{}{},
+			command:                       CmdCertificateDeleteFactory(common.PlatformPodman),
+		},
+	}
+
+	for _, test := range testTable {
+
+		var flagList []string
+		t.Run(test.name, func(t *testing.T) {
+
+			test.command.Flags().VisitAll(func(flag *pflag.Flag) {
+				flagList = append(flagList, flag.Name)
+				assert.Check(t, test.expectedFlagsWithDefaultValue[flag.Name] != nil, fmt.Sprintf("flag %q not expected", flag.Name))
+				assert.Check(t, test.expectedFlagsWithDefaultValue[flag.Name] == flag.DefValue, fmt.Sprintf("default value %q for flag %q not expected", flag.DefValue, flag.Name))
+			})
+
+			assert.Check(t, len(flagList) == len(test.expectedFlagsWithDefaultValue))
+
+			assert.Assert(t, test.command.PreRunE != nil)
+			assert.Assert(t, test.command.Run != nil)
+			assert.Assert(t, test.command.PostRun != nil)
+			assert.Assert(t, test.command.Use != "")
+			assert.Assert(t, test.command.Short != "")
+			assert.Assert(t, test.command.Long != "")
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	"github.com/skupperproject/skupper/internal/kube/client"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/generated/client/clientset/versioned/typed/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	k8serrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CmdCertificateCreate struct {
+	client     skupperv2alpha1.SkupperV2alpha1Interface
+	CobraCmd   *cobra.Command
+	Flags      *common.CommandCertificateCreateFlags
+	namespace  string
+	name       string
+	ca         string
+	subject    string
+	hosts      []string
+	clientAuth bool
+	serverAuth bool
+	signing    bool
+	timeout    time.Duration
+	status     string
+}
+
+func NewCmdCertificateCreate() *CmdCertificateCreate {
+
+	return &CmdCertificateCreate{}
+}
+
+func (cmd *CmdCertificateCreate) NewClient(cobraCommand *cobra.Command, args []string) {
+	cli, err := client.NewClient(cobraCommand.Flag("namespace").Value.String(), cobraCommand.Flag("context").Value.String(), cobraCommand.Flag("kubeconfig").Value.String())
+	utils.HandleError(utils.GenericError, err)
+
+	cmd.client = cli.GetSkupperClient().SkupperV2alpha1()
+	cmd.namespace = cli.Namespace
+}
+
+func (cmd *CmdCertificateCreate) ValidateInput(args []string) error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	hostStringValidator := validator.NewHostStringValidator()
+	timeoutValidator := validator.NewTimeoutInSecondsValidator()
+	statusValidator := validator.NewOptionValidator(common.ReadyWaitTypes)
+
+	// Check if Certificate CRD is installed
+	_, err := cmd.client.Certificates(cmd.namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		validationErrors = append(validationErrors, utils.HandleMissingCrds(err))
+		return errors.Join(validationErrors...)
+	}
+
+	// Validate arguments name
+	if len(args) < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("certificate name must be configured"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("certificate name must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("certificate name is not valid: %s", err))
+		} else {
+			cmd.name = args[0]
+		}
+	}
+
+	// Validate if there is already a certificate with this name in the namespace
+	if cmd.name != "" {
+		certificate, err := cmd.client.Certificates(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if certificate != nil && !k8serrs.IsNotFound(err) {
+			validationErrors = append(validationErrors, fmt.Errorf("There is already a certificate %s created for namespace %s", cmd.name, cmd.namespace))
+		}
+	}
+
+	// Validate flags
+	if cmd.Flags != nil {
+		// signing certificates are self-signed, all others need a CA
+		if cmd.Flags.Ca == "" && !cmd.Flags.Signing {
+			validationErrors = append(validationErrors, fmt.Errorf("ca must be configured unless the certificate is a signing certificate"))
+		} else if cmd.Flags.Ca != "" {
+			ok, err := resourceStringValidator.Evaluate(cmd.Flags.Ca)
+			if !ok {
+				validationErrors = append(validationErrors, fmt.Errorf("ca is not valid: %s", err))
+			}
+		}
+		for _, host := range cmd.Flags.Hosts {
+			ok, _ := hostStringValidator.Evaluate(host)
+			if !ok {
+				validationErrors = append(validationErrors, fmt.Errorf("host %q is not valid: a valid IP address or hostname is expected", host))
+			}
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.Timeout.String() != "" {
+		ok, err := timeoutValidator.Evaluate(cmd.Flags.Timeout)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("timeout is not valid: %s", err))
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Wait != "" {
+		ok, err := statusValidator.Evaluate(cmd.Flags.Wait)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("status is not valid: %s", err))
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdCertificateCreate) InputToOptions() {
+
+	// default subject to name of certificate
+	if cmd.Flags.Subject == "" {
+		cmd.subject = cmd.name
+	} else {
+		cmd.subject = cmd.Flags.Subject
+	}
+
+	cmd.ca = cmd.Flags.Ca
+	cmd.hosts = cmd.Flags.Hosts
+	cmd.clientAuth = cmd.Flags.Client
+	cmd.serverAuth = cmd.Flags.Server
+	cmd.signing = cmd.Flags.Signing
+	cmd.timeout = cmd.Flags.Timeout
+	cmd.status = cmd.Flags.Wait
+}
+
+func (cmd *CmdCertificateCreate) Run() error {
+
+	resource := v2alpha1.Certificate{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "Certificate",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cmd.name,
+			Namespace: cmd.namespace,
+		},
+		Spec: v2alpha1.CertificateSpec{
+			Ca:      cmd.ca,
+			Subject: cmd.subject,
+			Hosts:   cmd.hosts,
+			Client:  cmd.clientAuth,
+			Server:  cmd.serverAuth,
+			Signing: cmd.signing,
+		},
+	}
+
+	_, err := cmd.client.Certificates(cmd.namespace).Create(context.TODO(), &resource, metav1.CreateOptions{})
+	return err
+}
+
+func (cmd *CmdCertificateCreate) WaitUntil() error {
+
+	if cmd.status == "none" {
+		return nil
+	}
+
+	waitTime := int(cmd.timeout.Seconds())
+	var certificateCondition *metav1.Condition
+
+	err := utils.NewSpinnerWithTimeout("Waiting for create to complete...", waitTime, func() error {
+
+		resource, err := cmd.client.Certificates(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		certificateCondition = meta.FindStatusCondition(resource.Status.Conditions, v2alpha1.CONDITION_TYPE_READY)
+
+		if certificateCondition != nil && certificateCondition.Status == metav1.ConditionTrue {
+			return nil
+		}
+
+		if certificateCondition != nil {
+			return fmt.Errorf("error in the condition")
+		}
+
+		return fmt.Errorf("error getting the resource")
+	})
+
+	if err != nil && certificateCondition == nil {
+		return fmt.Errorf("Certificate %q is not yet %s, check the status for more information\n", cmd.name, cmd.status)
+	} else if err != nil && certificateCondition.Status == metav1.ConditionFalse {
+		return fmt.Errorf("Certificate %q is not yet %s: %s\n", cmd.name, cmd.status, certificateCondition.Message)
+	}
+
+	fmt.Printf("Certificate %q is %s.\n", cmd.name, cmd.status)
+	return nil
+}
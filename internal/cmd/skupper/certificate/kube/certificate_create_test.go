@@ -0,0 +1,348 @@
+package kube
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdCertificateCreate_ValidateInput(t *testing.T) {
+	type test struct {
+		name                string
+		args                []string
+		flags               common.CommandCertificateCreateFlags
+		skupperObjects      []runtime.Object
+		skupperErrorMessage string
+		expectedError       string
+	}
+
+	validFlags := common.CommandCertificateCreateFlags{
+		Ca:      "skupper-site-ca",
+		Hosts:   []string{"backend.example.com"},
+		Server:  true,
+		Timeout: time.Minute,
+		Wait:    "ready",
+	}
+
+	testTable := []test{
+		{
+			name:                "missing CRD",
+			args:                []string{"my-cert"},
+			flags:               validFlags,
+			skupperErrorMessage: utils.CrdErr,
+			expectedError:       utils.CrdHelpErr,
+		},
+		{
+			name:           "certificate already exists",
+			args:           []string{"my-cert"},
+			flags:          validFlags,
+			skupperObjects: []runtime.Object{existingCertificate()},
+			expectedError:  "There is already a certificate my-cert created for namespace test",
+		},
+		{
+			name:          "certificate name is not specified",
+			flags:         validFlags,
+			expectedError: "certificate name must be configured",
+		},
+		{
+			name:          "certificate name empty",
+			args:          []string{""},
+			flags:         validFlags,
+			expectedError: "certificate name must not be empty",
+		},
+		{
+			name:          "more than one argument is specified",
+			args:          []string{"my", "cert"},
+			flags:         validFlags,
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:          "certificate name is not valid",
+			args:          []string{"my cert"},
+			flags:         validFlags,
+			expectedError: "certificate name is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])*(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])*)*$",
+		},
+		{
+			name:          "ca is missing",
+			args:          []string{"my-cert"},
+			flags:         common.CommandCertificateCreateFlags{Server: true, Timeout: time.Minute},
+			expectedError: "ca must be configured unless the certificate is a signing certificate",
+		},
+		{
+			name:  "signing certificate without ca",
+			args:  []string{"my-cert"},
+			flags: common.CommandCertificateCreateFlags{Signing: true, Timeout: time.Minute},
+		},
+		{
+			name: "host is not valid",
+			args: []string{"my-cert"},
+			flags: common.CommandCertificateCreateFlags{
+				Ca:      "skupper-site-ca",
+				Hosts:   []string{"example.com", "not@valid"},
+				Timeout: time.Minute,
+			},
+			expectedError: "host \"not@valid\" is not valid: a valid IP address or hostname is expected",
+		},
+		{
+			name: "timeout is not valid",
+			args: []string{"my-cert"},
+			flags: common.CommandCertificateCreateFlags{
+				Ca:      "skupper-site-ca",
+				Timeout: time.Second,
+			},
+			expectedError: "timeout is not valid: duration must not be less than 10s; got 1s",
+		},
+		{
+			name: "wait status is not valid",
+			args: []string{"my-cert"},
+			flags: common.CommandCertificateCreateFlags{
+				Ca:      "skupper-site-ca",
+				Timeout: time.Minute,
+				Wait:    "configured",
+			},
+			expectedError: "status is not valid: value configured not allowed. It should be one of this options: [ready none]",
+		},
+		{
+			name:  "flags all valid",
+			args:  []string{"my-cert"},
+			flags: validFlags,
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdCertificateCreateWithMocks("test", nil, test.skupperObjects, test.skupperErrorMessage)
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdCertificateCreate_InputToOptions(t *testing.T) {
+	type test struct {
+		name            string
+		flags           common.CommandCertificateCreateFlags
+		expectedSubject string
+	}
+
+	testTable := []test{
+		{
+			name: "subject defaults to the name",
+			flags: common.CommandCertificateCreateFlags{
+				Ca:   "skupper-site-ca",
+				Wait: "ready",
+			},
+			expectedSubject: "my-cert",
+		},
+		{
+			name: "subject is set",
+			flags: common.CommandCertificateCreateFlags{
+				Ca:      "skupper-site-ca",
+				Subject: "backend",
+				Wait:    "ready",
+			},
+			expectedSubject: "backend",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			cmd, err := newCmdCertificateCreateWithMocks("test", nil, nil, "")
+			assert.Assert(t, err)
+			cmd.name = "my-cert"
+			cmd.Flags = &test.flags
+
+			cmd.InputToOptions()
+
+			assert.Equal(t, cmd.subject, test.expectedSubject)
+			assert.Equal(t, cmd.ca, "skupper-site-ca")
+			assert.Equal(t, cmd.status, "ready")
+		})
+	}
+}
+
+func TestCmdCertificateCreate_Run(t *testing.T) {
+	type test struct {
+		name                string
+		skupperErrorMessage string
+		errorMessage        string
+	}
+
+	testTable := []test{
+		{
+			name: "runs ok",
+		},
+		{
+			name:                "run fails",
+			skupperErrorMessage: "error",
+			errorMessage:        "error",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdCertificateCreateWithMocks("test", nil, nil, test.skupperErrorMessage)
+		assert.Assert(t, err)
+		cmd.name = "my-cert"
+		cmd.ca = "skupper-site-ca"
+		cmd.subject = "backend"
+		cmd.hosts = []string{"backend.example.com"}
+		cmd.serverAuth = true
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Error(t, err, test.errorMessage)
+			} else {
+				assert.Assert(t, err)
+				certificate, err := cmd.client.Certificates("test").Get(t.Context(), "my-cert", v1.GetOptions{})
+				assert.Assert(t, err)
+				assert.Equal(t, certificate.Spec.Ca, "skupper-site-ca")
+				assert.Equal(t, certificate.Spec.Subject, "backend")
+				assert.DeepEqual(t, certificate.Spec.Hosts, []string{"backend.example.com"})
+				assert.Assert(t, certificate.Spec.Server)
+				assert.Assert(t, !certificate.Spec.Client)
+			}
+		})
+	}
+}
+
+func TestCmdCertificateCreate_WaitUntil(t *testing.T) {
+	type test struct {
+		name           string
+		status         string
+		skupperObjects []runtime.Object
+		expectError    bool
+	}
+
+	testTable := []test{
+		{
+			name:   "certificate is not ready",
+			status: "ready",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.Certificate{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-cert",
+						Namespace: "test",
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name:        "certificate is not returned",
+			status:      "ready",
+			expectError: true,
+		},
+		{
+			name:           "certificate is ready",
+			status:         "ready",
+			skupperObjects: []runtime.Object{existingCertificate()},
+		},
+		{
+			name:   "certificate could not be issued",
+			status: "ready",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.Certificate{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-cert",
+						Namespace: "test",
+					},
+					Status: v2alpha1.CertificateStatus{
+						Status: v2alpha1.Status{
+							Conditions: []v1.Condition{
+								{
+									Message: "CA \"test/skupper-site-ca\" not found",
+									Reason:  "Error",
+									Type:    "Ready",
+									Status:  "False",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name:        "user does not wait",
+			status:      "none",
+			expectError: false,
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdCertificateCreateWithMocks("test", nil, test.skupperObjects, "")
+		assert.Assert(t, err)
+
+		cmd.name = "my-cert"
+		cmd.timeout = 1 * time.Second
+		cmd.status = test.status
+
+		t.Run(test.name, func(t *testing.T) {
+
+			err := cmd.WaitUntil()
+			if test.expectError {
+				assert.Check(t, err != nil)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdCertificateCreateWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdCertificateCreate, error) {
+
+	// We make sure the interval is appropriate
+	utils.SetRetryProfile(utils.TestRetryProfile)
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdCertificateCreate := &CmdCertificateCreate{
+		client:    client.GetSkupperClient().SkupperV2alpha1(),
+		namespace: namespace,
+	}
+	return cmdCertificateCreate, nil
+}
+
+func existingCertificate() *v2alpha1.Certificate {
+	return &v2alpha1.Certificate{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "my-cert",
+			Namespace: "test",
+		},
+		Spec: v2alpha1.CertificateSpec{
+			Ca:      "skupper-site-ca",
+			Subject: "my-cert",
+			Hosts:   []string{"backend.example.com"},
+			Server:  true,
+		},
+		Status: v2alpha1.CertificateStatus{
+			Status: v2alpha1.Status{
+				StatusType: "Ready",
+				Conditions: []v1.Condition{
+					{
+						Type:   "Ready",
+						Status: "True",
+					},
+				},
+			},
+			Expiration: "2031-10-18T00:00:00Z",
+		},
+	}
+}
@@ -0,0 +1,175 @@
+package kube
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"gotest.tools/v3/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdCertificateDelete_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		flags          common.CommandCertificateDeleteFlags
+		skupperObjects []runtime.Object
+		skupperError   string
+		expectedError  string
+	}
+
+	testTable := []test{
+		{
+			name:          "missing CRD",
+			args:          []string{"my-cert"},
+			skupperError:  utils.CrdErr,
+			expectedError: utils.CrdHelpErr,
+		},
+		{
+			name:          "certificate is not specified",
+			expectedError: "certificate name must be specified",
+		},
+		{
+			name:          "certificate name is nil",
+			args:          []string{""},
+			expectedError: "certificate name must not be empty",
+		},
+		{
+			name:          "more than one argument is specified",
+			args:          []string{"my", "cert"},
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:          "certificate does not exist",
+			args:          []string{"my-cert"},
+			flags:         common.CommandCertificateDeleteFlags{Timeout: time.Minute},
+			expectedError: "certificate my-cert does not exist in namespace test",
+		},
+		{
+			name:           "timeout is not valid",
+			args:           []string{"my-cert"},
+			flags:          common.CommandCertificateDeleteFlags{Timeout: 0},
+			skupperObjects: []runtime.Object{existingCertificate()},
+			expectedError:  "timeout is not valid: duration must not be less than 10s; got 0s",
+		},
+		{
+			name:           "certificate exists",
+			args:           []string{"my-cert"},
+			flags:          common.CommandCertificateDeleteFlags{Timeout: time.Minute},
+			skupperObjects: []runtime.Object{existingCertificate()},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdCertificateDeleteWithMocks("test", nil, test.skupperObjects, test.skupperError)
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdCertificateDelete_Run(t *testing.T) {
+	type test struct {
+		name                string
+		skupperObjects      []runtime.Object
+		skupperErrorMessage string
+		errorMessage        string
+	}
+
+	testTable := []test{
+		{
+			name:           "runs ok",
+			skupperObjects: []runtime.Object{existingCertificate()},
+		},
+		{
+			name:                "run fails",
+			skupperErrorMessage: "error",
+			errorMessage:        "error",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdCertificateDeleteWithMocks("test", nil, test.skupperObjects, test.skupperErrorMessage)
+		assert.Assert(t, err)
+		cmd.name = "my-cert"
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Error(t, err, test.errorMessage)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+func TestCmdCertificateDelete_WaitUntil(t *testing.T) {
+	type test struct {
+		name           string
+		wait           bool
+		skupperObjects []runtime.Object
+		expectError    bool
+	}
+
+	testTable := []test{
+		{
+			name:           "certificate is not deleted",
+			wait:           true,
+			skupperObjects: []runtime.Object{existingCertificate()},
+			expectError:    true,
+		},
+		{
+			name: "certificate is deleted",
+			wait: true,
+		},
+		{
+			name:           "user does not wait",
+			wait:           false,
+			skupperObjects: []runtime.Object{existingCertificate()},
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdCertificateDeleteWithMocks("test", nil, test.skupperObjects, "")
+		assert.Assert(t, err)
+		cmd.name = "my-cert"
+		cmd.Flags = &common.CommandCertificateDeleteFlags{Timeout: time.Second, Wait: test.wait}
+		cmd.InputToOptions()
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.WaitUntil()
+			if test.expectError {
+				assert.Check(t, err != nil)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdCertificateDeleteWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdCertificateDelete, error) {
+
+	// We make sure the interval is appropriate
+	utils.SetRetryProfile(utils.TestRetryProfile)
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdCertificateDelete := &CmdCertificateDelete{
+		client:    client.GetSkupperClient().SkupperV2alpha1(),
+		namespace: namespace,
+	}
+	return cmdCertificateDelete, nil
+}
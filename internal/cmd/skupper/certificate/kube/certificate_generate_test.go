@@ -0,0 +1,102 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"gotest.tools/v3/assert"
+)
+
+func TestCmdCertificateGenerate_ValidateInput(t *testing.T) {
+	type test struct {
+		name          string
+		args          []string
+		flags         common.CommandCertificateGenerateFlags
+		expectedError string
+	}
+
+	testTable := []test{
+		{
+			name:          "certificate name is not specified",
+			flags:         common.CommandCertificateGenerateFlags{Ca: "skupper-site-ca"},
+			expectedError: "certificate name must be configured",
+		},
+		{
+			name:          "certificate name is not valid",
+			args:          []string{"my cert"},
+			flags:         common.CommandCertificateGenerateFlags{Ca: "skupper-site-ca"},
+			expectedError: "certificate name is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])*(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])*)*$",
+		},
+		{
+			name:          "ca is missing",
+			args:          []string{"my-cert"},
+			expectedError: "ca must be configured unless the certificate is a signing certificate",
+		},
+		{
+			name:          "output format is not valid",
+			args:          []string{"my-cert"},
+			flags:         common.CommandCertificateGenerateFlags{Ca: "skupper-site-ca", Output: "not-supported"},
+			expectedError: "output type is not valid: value not-supported not allowed. It should be one of this options: [json yaml]",
+		},
+		{
+			name:  "flags all valid",
+			args:  []string{"my-cert"},
+			flags: common.CommandCertificateGenerateFlags{Signing: true, Output: "json"},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdCertificateGenerate{Flags: &test.flags}
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdCertificateGenerate_Run(t *testing.T) {
+	type test struct {
+		name         string
+		output       string
+		errorMessage string
+	}
+
+	testTable := []test{
+		{
+			name:   "runs ok with yaml output",
+			output: "yaml",
+		},
+		{
+			name:   "runs ok with json output",
+			output: "json",
+		},
+		{
+			name:         "run fails with unsupported output",
+			output:       "unsupported",
+			errorMessage: "format unsupported not supported",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd := &CmdCertificateGenerate{
+			Flags: &common.CommandCertificateGenerateFlags{
+				Ca:     "skupper-site-ca",
+				Hosts:  []string{"backend.example.com"},
+				Output: test.output,
+			},
+			name:      "my-cert",
+			namespace: "test",
+		}
+		cmd.InputToOptions()
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Error(t, err, test.errorMessage)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
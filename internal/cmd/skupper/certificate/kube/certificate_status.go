@@ -0,0 +1,143 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	"github.com/skupperproject/skupper/internal/kube/client"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/generated/client/clientset/versioned/typed/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	k8serrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CmdCertificateStatus struct {
+	client    skupperv2alpha1.SkupperV2alpha1Interface
+	CobraCmd  *cobra.Command
+	Flags     *common.CommandCertificateStatusFlags
+	namespace string
+	name      string
+	output    string
+}
+
+func NewCmdCertificateStatus() *CmdCertificateStatus {
+
+	return &CmdCertificateStatus{}
+}
+
+func (cmd *CmdCertificateStatus) NewClient(cobraCommand *cobra.Command, args []string) {
+	cli, err := client.NewClient(cobraCommand.Flag("namespace").Value.String(), cobraCommand.Flag("context").Value.String(), cobraCommand.Flag("kubeconfig").Value.String())
+	utils.HandleError(utils.GenericError, err)
+
+	cmd.client = cli.GetSkupperClient().SkupperV2alpha1()
+	cmd.namespace = cli.Namespace
+}
+
+func (cmd *CmdCertificateStatus) ValidateInput(args []string) error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	outputTypeValidator := validator.NewOptionValidator(common.OutputTypes)
+
+	// Check if Certificate CRD is installed
+	_, err := cmd.client.Certificates(cmd.namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		validationErrors = append(validationErrors, utils.HandleMissingCrds(err))
+		return errors.Join(validationErrors...)
+	}
+
+	// Validate arguments name if specified
+	if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if len(args) == 1 {
+		if args[0] == "" {
+			validationErrors = append(validationErrors, fmt.Errorf("certificate name must not be empty"))
+		} else {
+			ok, err := resourceStringValidator.Evaluate(args[0])
+			if !ok {
+				validationErrors = append(validationErrors, fmt.Errorf("certificate name is not valid: %s", err))
+			} else {
+				cmd.name = args[0]
+			}
+		}
+	}
+
+	// Validate that there is a certificate with this name in the namespace
+	if cmd.name != "" {
+		certificate, err := cmd.client.Certificates(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if err != nil || certificate == nil {
+			validationErrors = append(validationErrors, fmt.Errorf("certificate %s does not exist in namespace %s", cmd.name, cmd.namespace))
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		} else {
+			cmd.output = cmd.Flags.Output
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdCertificateStatus) Run() error {
+	if cmd.name == "" {
+		resources, err := cmd.client.Certificates(cmd.namespace).List(context.TODO(), metav1.ListOptions{})
+		if err != nil || resources == nil || len(resources.Items) == 0 {
+			fmt.Println("No certificates found")
+			return err
+		}
+		if cmd.output != "" {
+			for _, resource := range resources.Items {
+				encodedOutput, err := utils.Encode(cmd.output, resource)
+				if err != nil {
+					return err
+				}
+				fmt.Println(encodedOutput)
+			}
+		} else {
+			tw := tabwriter.NewWriter(os.Stdout, 8, 8, 1, '\t', tabwriter.TabIndent)
+			_, _ = fmt.Fprintln(tw, fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s\t%s",
+				"NAME", "STATUS", "CA", "SUBJECT", "HOSTS", "EXPIRATION", "MESSAGE"))
+			for _, resource := range resources.Items {
+				fmt.Fprintln(tw, fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s\t%s",
+					resource.Name, resource.Status.StatusType, resource.Spec.Ca, resource.Spec.Subject,
+					strings.Join(resource.Spec.Hosts, ","), resource.Status.Expiration, resource.Status.Message))
+			}
+			_ = tw.Flush()
+		}
+	} else {
+		resource, err := cmd.client.Certificates(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if err != nil || resource == nil || k8serrs.IsNotFound(err) {
+			fmt.Println("No certificates found")
+			return err
+		}
+		if cmd.output != "" {
+			encodedOutput, err := utils.Encode(cmd.output, resource)
+			if err != nil {
+				return err
+			}
+			fmt.Println(encodedOutput)
+		} else {
+			tw := tabwriter.NewWriter(os.Stdout, 8, 8, 1, '\t', tabwriter.TabIndent)
+			fmt.Fprintln(tw, fmt.Sprintf("Name:\t%s\nStatus:\t%s\nCA:\t%s\nSubject:\t%s\nHosts:\t%s\nClient:\t%t\nServer:\t%t\nSigning:\t%t\nExpiration:\t%s\nMessage:\t%s\n",
+				resource.Name, resource.Status.StatusType, resource.Spec.Ca, resource.Spec.Subject, strings.Join(resource.Spec.Hosts, ", "),
+				resource.Spec.Client, resource.Spec.Server, resource.Spec.Signing, resource.Status.Expiration, resource.Status.Message))
+			_ = tw.Flush()
+		}
+	}
+
+	return nil
+}
+
+func (cmd *CmdCertificateStatus) InputToOptions()  {}
+func (cmd *CmdCertificateStatus) WaitUntil() error { return nil }
@@ -0,0 +1,150 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"gotest.tools/v3/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdCertificateStatus_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		flags          common.CommandCertificateStatusFlags
+		skupperObjects []runtime.Object
+		expectedError  string
+		skupperError   string
+	}
+
+	testTable := []test{
+		{
+			name:          "missing CRD",
+			args:          []string{"my-cert"},
+			skupperError:  utils.CrdErr,
+			expectedError: utils.CrdHelpErr,
+		},
+		{
+			name:          "certificate does not exist in the namespace",
+			args:          []string{"my-cert"},
+			expectedError: "certificate my-cert does not exist in namespace test",
+		},
+		{
+			name:          "certificate name is nil",
+			args:          []string{""},
+			expectedError: "certificate name must not be empty",
+		},
+		{
+			name:          "more than one argument is specified",
+			args:          []string{"my", "cert"},
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:          "no args",
+			expectedError: "",
+		},
+		{
+			name:           "bad output status",
+			args:           []string{"my-cert"},
+			flags:          common.CommandCertificateStatusFlags{Output: "not-supported"},
+			skupperObjects: []runtime.Object{existingCertificate()},
+			expectedError:  "output type is not valid: value not-supported not allowed. It should be one of this options: [json yaml]",
+		},
+		{
+			name:           "good output status",
+			args:           []string{"my-cert"},
+			flags:          common.CommandCertificateStatusFlags{Output: "json"},
+			skupperObjects: []runtime.Object{existingCertificate()},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdCertificateStatusWithMocks("test", nil, test.skupperObjects, test.skupperError)
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdCertificateStatus_Run(t *testing.T) {
+	type test struct {
+		name                string
+		certName            string
+		output              string
+		skupperObjects      []runtime.Object
+		skupperErrorMessage string
+		errorMessage        string
+	}
+
+	testTable := []test{
+		{
+			name:                "run fails",
+			certName:            "my-cert",
+			skupperErrorMessage: "error",
+			errorMessage:        "error",
+		},
+		{
+			name:           "runs ok",
+			certName:       "my-cert",
+			skupperObjects: []runtime.Object{existingCertificate()},
+		},
+		{
+			name:           "runs ok with yaml output",
+			certName:       "my-cert",
+			output:         "yaml",
+			skupperObjects: []runtime.Object{existingCertificate()},
+		},
+		{
+			name:           "runs ok listing certificates",
+			skupperObjects: []runtime.Object{existingCertificate()},
+		},
+		{
+			name:           "runs ok listing certificates with json output",
+			output:         "json",
+			skupperObjects: []runtime.Object{existingCertificate()},
+		},
+		{
+			name: "runs ok with no certificates",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdCertificateStatusWithMocks("test", nil, test.skupperObjects, test.skupperErrorMessage)
+		assert.Assert(t, err)
+		cmd.name = test.certName
+		cmd.output = test.output
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Error(t, err, test.errorMessage)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdCertificateStatusWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdCertificateStatus, error) {
+
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdCertificateStatus := &CmdCertificateStatus{
+		client:    client.GetSkupperClient().SkupperV2alpha1(),
+		namespace: namespace,
+	}
+	return cmdCertificateStatus, nil
+}
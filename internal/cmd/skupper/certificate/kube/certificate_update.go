@@ -0,0 +1,203 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	"github.com/skupperproject/skupper/internal/kube/client"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/generated/client/clientset/versioned/typed/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	k8serrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CmdCertificateUpdate struct {
+	client          skupperv2alpha1.SkupperV2alpha1Interface
+	CobraCmd        *cobra.Command
+	Flags           *common.CommandCertificateUpdateFlags
+	namespace       string
+	name            string
+	resourceVersion string
+	newSettings     v2alpha1.CertificateSpec
+	status          string
+}
+
+func NewCmdCertificateUpdate() *CmdCertificateUpdate {
+
+	return &CmdCertificateUpdate{}
+}
+
+func (cmd *CmdCertificateUpdate) NewClient(cobraCommand *cobra.Command, args []string) {
+	cli, err := client.NewClient(cobraCommand.Flag("namespace").Value.String(), cobraCommand.Flag("context").Value.String(), cobraCommand.Flag("kubeconfig").Value.String())
+	utils.HandleError(utils.GenericError, err)
+
+	cmd.client = cli.GetSkupperClient().SkupperV2alpha1()
+	cmd.namespace = cli.Namespace
+}
+
+func (cmd *CmdCertificateUpdate) ValidateInput(args []string) error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	hostStringValidator := validator.NewHostStringValidator()
+	timeoutValidator := validator.NewTimeoutInSecondsValidator()
+	statusValidator := validator.NewOptionValidator(common.ReadyWaitTypes)
+
+	// Check if Certificate CRD is installed
+	_, err := cmd.client.Certificates(cmd.namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		validationErrors = append(validationErrors, utils.HandleMissingCrds(err))
+		return errors.Join(validationErrors...)
+	}
+
+	// Validate arguments name
+	if len(args) < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("certificate name must be configured"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("certificate name must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("certificate name is not valid: %s", err))
+		} else {
+			cmd.name = args[0]
+		}
+	}
+
+	// Validate that there is already a certificate with this name in the namespace
+	exists := false
+	if cmd.name != "" {
+		certificate, err := cmd.client.Certificates(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if certificate == nil || k8serrs.IsNotFound(err) {
+			validationErrors = append(validationErrors, fmt.Errorf("certificate %s must exist in namespace %s to be updated", cmd.name, cmd.namespace))
+		} else {
+			// save existing values
+			cmd.resourceVersion = certificate.ResourceVersion
+			cmd.newSettings = certificate.Spec
+			exists = true
+		}
+	}
+
+	// Validate flags
+	if cmd.Flags != nil && cmd.Flags.Ca != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.Ca)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("ca is not valid: %s", err))
+		} else {
+			cmd.newSettings.Ca = cmd.Flags.Ca
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.Subject != "" {
+		cmd.newSettings.Subject = cmd.Flags.Subject
+	}
+	if cmd.Flags != nil && len(cmd.Flags.Hosts) != 0 {
+		var hostErrors []error
+		for _, host := range cmd.Flags.Hosts {
+			ok, _ := hostStringValidator.Evaluate(host)
+			if !ok {
+				hostErrors = append(hostErrors, fmt.Errorf("host %q is not valid: a valid IP address or hostname is expected", host))
+			}
+		}
+		if len(hostErrors) == 0 {
+			cmd.newSettings.Hosts = cmd.Flags.Hosts
+		}
+		validationErrors = append(validationErrors, hostErrors...)
+	}
+	if cmd.Flags != nil && cmd.CobraCmd != nil && cmd.CobraCmd.Flags().Changed(common.FlagNameClient) {
+		cmd.newSettings.Client = cmd.Flags.Client
+	}
+	if cmd.Flags != nil && cmd.CobraCmd != nil && cmd.CobraCmd.Flags().Changed(common.FlagNameServer) {
+		cmd.newSettings.Server = cmd.Flags.Server
+	}
+	if cmd.Flags != nil && cmd.CobraCmd != nil && cmd.CobraCmd.Flags().Changed(common.FlagNameSigning) {
+		cmd.newSettings.Signing = cmd.Flags.Signing
+	}
+	if exists && cmd.newSettings.Ca == "" && !cmd.newSettings.Signing {
+		validationErrors = append(validationErrors, fmt.Errorf("ca must be configured unless the certificate is a signing certificate"))
+	}
+	if cmd.Flags != nil && cmd.Flags.Timeout.String() != "" {
+		ok, err := timeoutValidator.Evaluate(cmd.Flags.Timeout)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("timeout is not valid: %s", err))
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Wait != "" {
+		ok, err := statusValidator.Evaluate(cmd.Flags.Wait)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("status is not valid: %s", err))
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdCertificateUpdate) Run() error {
+
+	resource := v2alpha1.Certificate{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "Certificate",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            cmd.name,
+			Namespace:       cmd.namespace,
+			ResourceVersion: cmd.resourceVersion,
+		},
+		Spec: cmd.newSettings,
+	}
+
+	_, err := cmd.client.Certificates(cmd.namespace).Update(context.TODO(), &resource, metav1.UpdateOptions{})
+	return err
+}
+
+func (cmd *CmdCertificateUpdate) WaitUntil() error {
+
+	if cmd.status == "none" {
+		return nil
+	}
+
+	waitTime := int(cmd.Flags.Timeout.Seconds())
+	var certificateCondition *metav1.Condition
+	err := utils.NewSpinnerWithTimeout("Waiting for update to complete...", waitTime, func() error {
+
+		resource, err := cmd.client.Certificates(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		certificateCondition = meta.FindStatusCondition(resource.Status.Conditions, v2alpha1.CONDITION_TYPE_READY)
+
+		if certificateCondition != nil && certificateCondition.Status == metav1.ConditionTrue {
+			return nil
+		}
+
+		if certificateCondition != nil {
+			return fmt.Errorf("error in the condition")
+		}
+
+		return fmt.Errorf("error getting the resource")
+	})
+
+	if err != nil && certificateCondition == nil {
+		return fmt.Errorf("Certificate %q is not yet %s, check the status for more information\n", cmd.name, cmd.status)
+	} else if err != nil && certificateCondition.Status == metav1.ConditionFalse {
+		return fmt.Errorf("Certificate %q is not yet %s: %s\n", cmd.name, cmd.status, certificateCondition.Message)
+	}
+
+	fmt.Printf("Certificate %q is updated\n", cmd.name)
+	return nil
+}
+
+func (cmd *CmdCertificateUpdate) InputToOptions() {
+	cmd.status = cmd.Flags.Wait
+}
@@ -0,0 +1,198 @@
+package kube
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdCertificateUpdate_ValidateInput(t *testing.T) {
+	type test struct {
+		name                string
+		args                []string
+		flags               common.CommandCertificateUpdateFlags
+		changedFlags        map[string]string
+		skupperObjects      []runtime.Object
+		skupperErrorMessage string
+		expectedError       string
+		expectedSettings    *v2alpha1.CertificateSpec
+	}
+
+	testTable := []test{
+		{
+			name:                "missing CRD",
+			args:                []string{"my-cert"},
+			skupperErrorMessage: utils.CrdErr,
+			expectedError:       utils.CrdHelpErr,
+		},
+		{
+			name:          "certificate does not exist",
+			args:          []string{"my-cert"},
+			flags:         common.CommandCertificateUpdateFlags{Timeout: time.Minute},
+			expectedError: "certificate my-cert must exist in namespace test to be updated",
+		},
+		{
+			name:           "certificate name is not specified",
+			flags:          common.CommandCertificateUpdateFlags{Timeout: time.Minute},
+			skupperObjects: []runtime.Object{existingCertificate()},
+			expectedError:  "certificate name must be configured",
+		},
+		{
+			name:           "host is not valid",
+			args:           []string{"my-cert"},
+			flags:          common.CommandCertificateUpdateFlags{Hosts: []string{"not@valid"}, Timeout: time.Minute},
+			skupperObjects: []runtime.Object{existingCertificate()},
+			expectedError:  "host \"not@valid\" is not valid: a valid IP address or hostname is expected",
+		},
+		{
+			name:           "wait status is not valid",
+			args:           []string{"my-cert"},
+			flags:          common.CommandCertificateUpdateFlags{Timeout: time.Minute, Wait: "configured"},
+			skupperObjects: []runtime.Object{existingCertificate()},
+			expectedError:  "status is not valid: value configured not allowed. It should be one of this options: [ready none]",
+		},
+		{
+			name:           "unspecified options keep their value",
+			args:           []string{"my-cert"},
+			flags:          common.CommandCertificateUpdateFlags{Hosts: []string{"10.0.0.1"}, Timeout: time.Minute},
+			skupperObjects: []runtime.Object{existingCertificate()},
+			expectedSettings: &v2alpha1.CertificateSpec{
+				Ca:      "skupper-site-ca",
+				Subject: "my-cert",
+				Hosts:   []string{"10.0.0.1"},
+				Server:  true,
+			},
+		},
+		{
+			name: "all options updated",
+			args: []string{"my-cert"},
+			flags: common.CommandCertificateUpdateFlags{
+				Ca:      "other-ca",
+				Subject: "backend",
+				Hosts:   []string{"backend.example.com", "10.0.0.1"},
+				Client:  true,
+				Timeout: time.Minute,
+			},
+			changedFlags: map[string]string{
+				common.FlagNameClient: "true",
+				common.FlagNameServer: "false",
+			},
+			skupperObjects: []runtime.Object{existingCertificate()},
+			expectedSettings: &v2alpha1.CertificateSpec{
+				Ca:      "other-ca",
+				Subject: "backend",
+				Hosts:   []string{"backend.example.com", "10.0.0.1"},
+				Client:  true,
+			},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdCertificateUpdateWithMocks("test", nil, test.skupperObjects, test.skupperErrorMessage)
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+			command.CobraCmd = &cobra.Command{Use: "update"}
+			command.CobraCmd.Flags().BoolVar(&command.Flags.Client, common.FlagNameClient, false, "")
+			command.CobraCmd.Flags().BoolVar(&command.Flags.Server, common.FlagNameServer, false, "")
+			command.CobraCmd.Flags().BoolVar(&command.Flags.Signing, common.FlagNameSigning, false, "")
+			for name, value := range test.changedFlags {
+				assert.Assert(t, command.CobraCmd.Flags().Set(name, value))
+			}
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+			if test.expectedSettings != nil {
+				assert.DeepEqual(t, command.newSettings, *test.expectedSettings)
+			}
+		})
+	}
+}
+
+func TestCmdCertificateUpdate_Run(t *testing.T) {
+	command, err := newCmdCertificateUpdateWithMocks("test", nil, []runtime.Object{existingCertificate()}, "")
+	assert.Assert(t, err)
+	command.Flags = &common.CommandCertificateUpdateFlags{Subject: "backend", Timeout: time.Minute}
+	assert.Assert(t, command.ValidateInput([]string{"my-cert"}))
+	command.InputToOptions()
+
+	assert.Assert(t, command.Run())
+
+	certificate, err := command.client.Certificates("test").Get(t.Context(), "my-cert", v1.GetOptions{})
+	assert.Assert(t, err)
+	assert.Equal(t, certificate.Spec.Subject, "backend")
+	assert.Equal(t, certificate.Spec.Ca, "skupper-site-ca")
+	assert.DeepEqual(t, certificate.Spec.Hosts, []string{"backend.example.com"})
+}
+
+func TestCmdCertificateUpdate_WaitUntil(t *testing.T) {
+	type test struct {
+		name           string
+		status         string
+		skupperObjects []runtime.Object
+		expectError    bool
+	}
+
+	testTable := []test{
+		{
+			name:           "certificate is ready",
+			status:         "ready",
+			skupperObjects: []runtime.Object{existingCertificate()},
+		},
+		{
+			name:        "certificate is not returned",
+			status:      "ready",
+			expectError: true,
+		},
+		{
+			name:   "user does not wait",
+			status: "none",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdCertificateUpdateWithMocks("test", nil, test.skupperObjects, "")
+		assert.Assert(t, err)
+
+		cmd.name = "my-cert"
+		cmd.Flags = &common.CommandCertificateUpdateFlags{Timeout: time.Second}
+		cmd.status = test.status
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.WaitUntil()
+			if test.expectError {
+				assert.Check(t, err != nil)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdCertificateUpdateWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdCertificateUpdate, error) {
+
+	// We make sure the interval is appropriate
+	utils.SetRetryProfile(utils.TestRetryProfile)
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdCertificateUpdate := &CmdCertificateUpdate{
+		client:    client.GetSkupperClient().SkupperV2alpha1(),
+		namespace: namespace,
+	}
+	return cmdCertificateUpdate, nil
+}
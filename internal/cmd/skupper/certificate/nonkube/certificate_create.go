@@ -0,0 +1,138 @@
+package nonkube
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CmdCertificateCreate struct {
+	certificateHandler *fs.CertificateHandler
+	CobraCmd           *cobra.Command
+	Flags              *common.CommandCertificateCreateFlags
+	namespace          string
+	certificateName    string
+	ca                 string
+	subject            string
+	hosts              []string
+	clientAuth         bool
+	serverAuth         bool
+	signing            bool
+}
+
+func NewCmdCertificateCreate() *CmdCertificateCreate {
+	return &CmdCertificateCreate{}
+}
+
+func (cmd *CmdCertificateCreate) NewClient(cobraCommand *cobra.Command, args []string) {
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace) != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String() != "" {
+		cmd.namespace = cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String()
+	}
+
+	cmd.certificateHandler = fs.NewCertificateHandler(cmd.namespace)
+}
+
+func (cmd *CmdCertificateCreate) ValidateInput(args []string) error {
+	var validationErrors []error
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameContext) != nil && cmd.CobraCmd.Flag(common.FlagNameContext).Value.String() != "" {
+		fmt.Println("Warning: --context flag is not supported on this platform")
+	}
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig) != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig).Value.String() != "" {
+		fmt.Println("Warning: --kubeconfig flag is not supported on this platform")
+	}
+
+	resourceStringValidator := validator.NewResourceStringValidator()
+	hostStringValidator := validator.NewHostStringValidator()
+
+	// Validate arguments name
+	if len(args) < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("certificate name must be configured"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("certificate name must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("certificate name is not valid: %s", err))
+		} else {
+			cmd.certificateName = args[0]
+		}
+	}
+
+	// Validate flags
+	// signing certificates are self-signed, all others need a CA
+	if cmd.Flags.Ca == "" && !cmd.Flags.Signing {
+		validationErrors = append(validationErrors, fmt.Errorf("ca must be configured unless the certificate is a signing certificate"))
+	} else if cmd.Flags.Ca != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.Ca)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("ca is not valid: %s", err))
+		}
+	}
+
+	for _, host := range cmd.Flags.Hosts {
+		ok, _ := hostStringValidator.Evaluate(host)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("host %q is not valid: a valid IP address or hostname is expected", host))
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdCertificateCreate) InputToOptions() {
+	if cmd.namespace == "" {
+		cmd.namespace = "default"
+	}
+
+	// default subject to name of certificate
+	if cmd.Flags.Subject == "" {
+		cmd.subject = cmd.certificateName
+	} else {
+		cmd.subject = cmd.Flags.Subject
+	}
+
+	cmd.ca = cmd.Flags.Ca
+	cmd.hosts = cmd.Flags.Hosts
+	cmd.clientAuth = cmd.Flags.Client
+	cmd.serverAuth = cmd.Flags.Server
+	cmd.signing = cmd.Flags.Signing
+}
+
+func (cmd *CmdCertificateCreate) Run() error {
+	certificateResource := v2alpha1.Certificate{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "Certificate",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cmd.certificateName,
+			Namespace: cmd.namespace,
+		},
+		Spec: v2alpha1.CertificateSpec{
+			Ca:      cmd.ca,
+			Subject: cmd.subject,
+			Hosts:   cmd.hosts,
+			Client:  cmd.clientAuth,
+			Server:  cmd.serverAuth,
+			Signing: cmd.signing,
+		},
+	}
+
+	err := cmd.certificateHandler.Add(certificateResource)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *CmdCertificateCreate) WaitUntil() error { return nil }
@@ -0,0 +1,117 @@
+package nonkube
+
+import (
+	"os"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+)
+
+func TestCmdCertificateCreate_ValidateInput(t *testing.T) {
+	type test struct {
+		name              string
+		args              []string
+		flags             *common.CommandCertificateCreateFlags
+		cobraGenericFlags map[string]string
+		expectedError     string
+	}
+
+	testTable := []test{
+		{
+			name:          "certificate name is not specified",
+			args:          []string{},
+			flags:         &common.CommandCertificateCreateFlags{Ca: "skupper-site-ca"},
+			expectedError: "certificate name must be configured",
+		},
+		{
+			name:          "certificate name is empty",
+			args:          []string{""},
+			flags:         &common.CommandCertificateCreateFlags{Ca: "skupper-site-ca"},
+			expectedError: "certificate name must not be empty",
+		},
+		{
+			name:          "certificate name is not valid",
+			args:          []string{"my cert"},
+			flags:         &common.CommandCertificateCreateFlags{Ca: "skupper-site-ca"},
+			expectedError: "certificate name is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])*(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])*)*$",
+		},
+		{
+			name:          "ca is missing",
+			args:          []string{"my-cert"},
+			flags:         &common.CommandCertificateCreateFlags{Server: true},
+			expectedError: "ca must be configured unless the certificate is a signing certificate",
+		},
+		{
+			name:          "host is not valid",
+			args:          []string{"my-cert"},
+			flags:         &common.CommandCertificateCreateFlags{Ca: "skupper-site-ca", Hosts: []string{"not valid"}},
+			expectedError: "host \"not valid\" is not valid: a valid IP address or hostname is expected",
+		},
+		{
+			name:              "kubernetes flags are not valid on this platform",
+			args:              []string{"my-cert"},
+			flags:             &common.CommandCertificateCreateFlags{Signing: true},
+			cobraGenericFlags: map[string]string{common.FlagNameContext: "test", common.FlagNameKubeconfig: "test"},
+		},
+		{
+			name: "flags all valid",
+			args: []string{"my-cert"},
+			flags: &common.CommandCertificateCreateFlags{
+				Ca:     "skupper-site-ca",
+				Hosts:  []string{"example.com", "10.0.0.1"},
+				Server: true,
+			},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdCertificateCreate{Flags: &common.CommandCertificateCreateFlags{}}
+			command.CobraCmd = &cobra.Command{Use: "test"}
+
+			if test.flags != nil {
+				command.Flags = test.flags
+			}
+
+			for name, value := range test.cobraGenericFlags {
+				command.CobraCmd.Flags().String(name, value, "")
+			}
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdCertificateCreate_Run(t *testing.T) {
+	if os.Getuid() == 0 {
+		api.DefaultRootDataHome = t.TempDir()
+	} else {
+		t.Setenv("XDG_DATA_HOME", t.TempDir())
+	}
+
+	command := &CmdCertificateCreate{
+		Flags: &common.CommandCertificateCreateFlags{
+			Ca:     "skupper-site-ca",
+			Hosts:  []string{"10.0.0.1"},
+			Server: true,
+		},
+		namespace: "test",
+	}
+	command.certificateHandler = fs.NewCertificateHandler(command.namespace)
+	assert.Assert(t, command.ValidateInput([]string{"my-cert"}))
+	command.InputToOptions()
+
+	assert.Assert(t, command.Run())
+
+	certificate, err := command.certificateHandler.Get("my-cert", fs.GetOptions{})
+	assert.Assert(t, err)
+	assert.Equal(t, certificate.Spec.Ca, "skupper-site-ca")
+	assert.Equal(t, certificate.Spec.Subject, "my-cert")
+	assert.DeepEqual(t, certificate.Spec.Hosts, []string{"10.0.0.1"})
+	assert.Assert(t, certificate.Spec.Server)
+}
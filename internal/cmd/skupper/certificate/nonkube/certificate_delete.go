@@ -0,0 +1,87 @@
+package nonkube
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/spf13/cobra"
+)
+
+type CmdCertificateDelete struct {
+	certificateHandler *fs.CertificateHandler
+	CobraCmd           *cobra.Command
+	Flags              *common.CommandCertificateDeleteFlags
+	namespace          string
+	certificateName    string
+}
+
+func NewCmdCertificateDelete() *CmdCertificateDelete {
+	return &CmdCertificateDelete{}
+}
+
+func (cmd *CmdCertificateDelete) NewClient(cobraCommand *cobra.Command, args []string) {
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace) != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String() != "" {
+		cmd.namespace = cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String()
+	}
+
+	cmd.certificateHandler = fs.NewCertificateHandler(cmd.namespace)
+}
+
+func (cmd *CmdCertificateDelete) ValidateInput(args []string) error {
+	var validationErrors []error
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameContext) != nil && cmd.CobraCmd.Flag(common.FlagNameContext).Value.String() != "" {
+		fmt.Println("Warning: --context flag is not supported on this platform")
+	}
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig) != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig).Value.String() != "" {
+		fmt.Println("Warning: --kubeconfig flag is not supported on this platform")
+	}
+
+	resourceStringValidator := validator.NewResourceStringValidator()
+
+	// Validate arguments name
+	if len(args) < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("certificate name must be specified"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("certificate name must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("certificate name is not valid: %s", err))
+		} else {
+			cmd.certificateName = args[0]
+		}
+	}
+
+	if cmd.certificateName != "" {
+		// Validate that there is already a certificate with this name
+		certificate, err := cmd.certificateHandler.Get(cmd.certificateName, fs.GetOptions{})
+		if certificate == nil || err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("certificate %s does not exist", cmd.certificateName))
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdCertificateDelete) InputToOptions() {
+	if cmd.namespace == "" {
+		cmd.namespace = "default"
+	}
+}
+
+func (cmd *CmdCertificateDelete) Run() error {
+	err := cmd.certificateHandler.Delete(cmd.certificateName)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *CmdCertificateDelete) WaitUntil() error { return nil }
@@ -0,0 +1,75 @@
+package nonkube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+)
+
+func TestCmdCertificateDelete_ValidateInput(t *testing.T) {
+	type test struct {
+		name          string
+		args          []string
+		expectedError string
+	}
+
+	testTable := []test{
+		{
+			name:          "certificate name is not specified",
+			args:          []string{},
+			expectedError: "certificate name must be specified",
+		},
+		{
+			name:          "certificate name is empty",
+			args:          []string{""},
+			expectedError: "certificate name must not be empty",
+		},
+		{
+			name:          "more than one argument is specified",
+			args:          []string{"my", "cert"},
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:          "certificate does not exist",
+			args:          []string{"no-cert"},
+			expectedError: "certificate no-cert does not exist",
+		},
+		{
+			name: "certificate exists",
+			args: []string{"my-cert"},
+		},
+	}
+
+	handler := addCertificate(t, "test")
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdCertificateDelete{
+				Flags:              &common.CommandCertificateDeleteFlags{},
+				CobraCmd:           &cobra.Command{Use: "test"},
+				namespace:          "test",
+				certificateHandler: handler,
+			}
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdCertificateDelete_Run(t *testing.T) {
+	handler := addCertificate(t, "test")
+	command := &CmdCertificateDelete{
+		namespace:          "test",
+		certificateName:    "my-cert",
+		certificateHandler: handler,
+	}
+	command.InputToOptions()
+
+	assert.Assert(t, command.Run())
+	_, err := handler.Get("my-cert", fs.GetOptions{})
+	assert.Assert(t, err != nil)
+	assert.Assert(t, command.Run() != nil)
+}
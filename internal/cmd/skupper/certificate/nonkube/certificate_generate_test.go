@@ -0,0 +1,64 @@
+package nonkube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+)
+
+func TestCmdCertificateGenerate_ValidateInput(t *testing.T) {
+	type test struct {
+		name          string
+		args          []string
+		flags         *common.CommandCertificateGenerateFlags
+		expectedError string
+	}
+
+	testTable := []test{
+		{
+			name:          "certificate name is not specified",
+			flags:         &common.CommandCertificateGenerateFlags{Ca: "skupper-site-ca"},
+			expectedError: "certificate name must be configured",
+		},
+		{
+			name:          "ca is missing",
+			args:          []string{"my-cert"},
+			flags:         &common.CommandCertificateGenerateFlags{},
+			expectedError: "ca must be configured unless the certificate is a signing certificate",
+		},
+		{
+			name:          "output format is not valid",
+			args:          []string{"my-cert"},
+			flags:         &common.CommandCertificateGenerateFlags{Ca: "skupper-site-ca", Output: "not-supported"},
+			expectedError: "output type is not valid: value not-supported not allowed. It should be one of this options: [json yaml]",
+		},
+		{
+			name:  "flags all valid",
+			args:  []string{"my-cert"},
+			flags: &common.CommandCertificateGenerateFlags{Ca: "skupper-site-ca", Hosts: []string{"127.0.0.1"}, Output: "yaml"},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdCertificateGenerate{Flags: test.flags, CobraCmd: &cobra.Command{Use: "test"}}
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdCertificateGenerate_Run(t *testing.T) {
+	command := &CmdCertificateGenerate{
+		Flags:    &common.CommandCertificateGenerateFlags{Ca: "skupper-site-ca", Output: "yaml"},
+		CobraCmd: &cobra.Command{Use: "test"},
+	}
+	assert.Assert(t, command.ValidateInput([]string{"my-cert"}))
+	command.InputToOptions()
+	assert.Equal(t, command.namespace, "default")
+
+	assert.Assert(t, command.Run())
+}
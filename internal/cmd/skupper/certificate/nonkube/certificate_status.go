@@ -0,0 +1,126 @@
+package nonkube
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/spf13/cobra"
+)
+
+type CmdCertificateStatus struct {
+	certificateHandler *fs.CertificateHandler
+	CobraCmd           *cobra.Command
+	Flags              *common.CommandCertificateStatusFlags
+	namespace          string
+	certificateName    string
+	output             string
+}
+
+func NewCmdCertificateStatus() *CmdCertificateStatus {
+	return &CmdCertificateStatus{}
+}
+
+func (cmd *CmdCertificateStatus) NewClient(cobraCommand *cobra.Command, args []string) {
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace) != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String() != "" {
+		cmd.namespace = cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String()
+	}
+
+	cmd.certificateHandler = fs.NewCertificateHandler(cmd.namespace)
+}
+
+func (cmd *CmdCertificateStatus) ValidateInput(args []string) error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	outputTypeValidator := validator.NewOptionValidator(common.OutputTypes)
+
+	// Validate arguments name if specified
+	if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if len(args) == 1 {
+		if args[0] == "" {
+			validationErrors = append(validationErrors, fmt.Errorf("certificate name must not be empty"))
+		} else {
+			ok, err := resourceStringValidator.Evaluate(args[0])
+			if !ok {
+				validationErrors = append(validationErrors, fmt.Errorf("certificate name is not valid: %s", err))
+			} else {
+				cmd.certificateName = args[0]
+			}
+		}
+	}
+
+	if cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		} else {
+			cmd.output = cmd.Flags.Output
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdCertificateStatus) Run() error {
+	if cmd.certificateName == "" {
+		resources, err := cmd.certificateHandler.List()
+		if err != nil || resources == nil || len(resources) == 0 {
+			fmt.Println("No certificates found")
+			return err
+		}
+		if cmd.output != "" {
+			for _, resource := range resources {
+				encodedOutput, err := utils.Encode(cmd.output, resource)
+				if err != nil {
+					return err
+				}
+				fmt.Println(encodedOutput)
+			}
+		} else {
+			tw := tabwriter.NewWriter(os.Stdout, 8, 8, 1, '\t', tabwriter.TabIndent)
+			_, _ = fmt.Fprintln(tw, fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s",
+				"NAME", "STATUS", "CA", "SUBJECT", "HOSTS", "MESSAGE"))
+			for _, resource := range resources {
+				fmt.Fprintln(tw, fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s",
+					resource.Name, resource.Status.StatusType, resource.Spec.Ca, resource.Spec.Subject,
+					strings.Join(resource.Spec.Hosts, ","), resource.Status.Message))
+			}
+			_ = tw.Flush()
+		}
+	} else {
+		resource, err := cmd.certificateHandler.Get(cmd.certificateName, fs.GetOptions{RuntimeFirst: true, LogWarning: true})
+		if err != nil || resource == nil {
+			return fmt.Errorf("certificate %s does not exist in namespace %s", cmd.certificateName, cmd.namespace)
+		}
+		if cmd.output != "" {
+			encodedOutput, err := utils.Encode(cmd.output, resource)
+			if err != nil {
+				return err
+			}
+			fmt.Println(encodedOutput)
+		} else {
+			tw := tabwriter.NewWriter(os.Stdout, 8, 8, 1, '\t', tabwriter.TabIndent)
+			fmt.Fprintln(tw, fmt.Sprintf("Name:\t%s\nStatus:\t%s\nCA:\t%s\nSubject:\t%s\nHosts:\t%s\nClient:\t%t\nServer:\t%t\nSigning:\t%t\nMessage:\t%s\n",
+				resource.Name, resource.Status.StatusType, resource.Spec.Ca, resource.Spec.Subject, strings.Join(resource.Spec.Hosts, ","),
+				resource.Spec.Client, resource.Spec.Server, resource.Spec.Signing, resource.Status.Message))
+			_ = tw.Flush()
+		}
+	}
+
+	return nil
+}
+
+func (cmd *CmdCertificateStatus) InputToOptions() {
+	if cmd.namespace == "" {
+		cmd.namespace = "default"
+	}
+}
+
+func (cmd *CmdCertificateStatus) WaitUntil() error { return nil }
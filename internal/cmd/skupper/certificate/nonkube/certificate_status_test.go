@@ -0,0 +1,123 @@
+package nonkube
+
+import (
+	"os"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+)
+
+func TestCmdCertificateStatus_ValidateInput(t *testing.T) {
+	type test struct {
+		name          string
+		args          []string
+		flags         *common.CommandCertificateStatusFlags
+		expectedError string
+	}
+
+	testTable := []test{
+		{
+			name:          "certificate name is empty",
+			args:          []string{""},
+			flags:         &common.CommandCertificateStatusFlags{},
+			expectedError: "certificate name must not be empty",
+		},
+		{
+			name:          "more than one argument is specified",
+			args:          []string{"my", "cert"},
+			flags:         &common.CommandCertificateStatusFlags{},
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:          "bad output type",
+			args:          []string{"my-cert"},
+			flags:         &common.CommandCertificateStatusFlags{Output: "not-supported"},
+			expectedError: "output type is not valid: value not-supported not allowed. It should be one of this options: [json yaml]",
+		},
+		{
+			name:  "no args",
+			flags: &common.CommandCertificateStatusFlags{},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdCertificateStatus{
+				Flags:    test.flags,
+				CobraCmd: &cobra.Command{Use: "test"},
+			}
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdCertificateStatus_Run(t *testing.T) {
+	type test struct {
+		name            string
+		certificateName string
+		output          string
+		errorMessage    string
+	}
+
+	testTable := []test{
+		{
+			name: "runs ok listing certificates",
+		},
+		{
+			name:   "runs ok listing certificates with yaml output",
+			output: "yaml",
+		},
+		{
+			name:            "runs ok showing a certificate",
+			certificateName: "my-cert",
+		},
+		{
+			name:            "runs ok showing a certificate with json output",
+			certificateName: "my-cert",
+			output:          "json",
+		},
+		{
+			name:            "certificate does not exist",
+			certificateName: "no-cert",
+			errorMessage:    "certificate no-cert does not exist in namespace test",
+		},
+	}
+
+	handler := addCertificate(t, "test")
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdCertificateStatus{
+				namespace:          "test",
+				certificateName:    test.certificateName,
+				output:             test.output,
+				certificateHandler: handler,
+			}
+
+			err := command.Run()
+			if test.errorMessage != "" {
+				assert.Error(t, err, test.errorMessage)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+func TestCmdCertificateStatus_RunNoDirectory(t *testing.T) {
+	if os.Getuid() == 0 {
+		api.DefaultRootDataHome = t.TempDir()
+	} else {
+		t.Setenv("XDG_DATA_HOME", t.TempDir())
+	}
+	command := &CmdCertificateStatus{
+		namespace:          "empty",
+		certificateHandler: fs.NewCertificateHandler("empty"),
+	}
+	assert.Assert(t, command.Run() != nil)
+}
@@ -0,0 +1,147 @@
+package nonkube
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CmdCertificateUpdate struct {
+	certificateHandler *fs.CertificateHandler
+	CobraCmd           *cobra.Command
+	Flags              *common.CommandCertificateUpdateFlags
+	namespace          string
+	certificateName    string
+	newSettings        v2alpha1.CertificateSpec
+}
+
+func NewCmdCertificateUpdate() *CmdCertificateUpdate {
+	return &CmdCertificateUpdate{}
+}
+
+func (cmd *CmdCertificateUpdate) NewClient(cobraCommand *cobra.Command, args []string) {
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace) != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String() != "" {
+		cmd.namespace = cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String()
+	}
+
+	cmd.certificateHandler = fs.NewCertificateHandler(cmd.namespace)
+}
+
+func (cmd *CmdCertificateUpdate) ValidateInput(args []string) error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	hostStringValidator := validator.NewHostStringValidator()
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameContext) != nil && cmd.CobraCmd.Flag(common.FlagNameContext).Value.String() != "" {
+		fmt.Println("Warning: --context flag is not supported on this platform")
+	}
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig) != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig).Value.String() != "" {
+		fmt.Println("Warning: --kubeconfig flag is not supported on this platform")
+	}
+
+	// Validate arguments name
+	if len(args) < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("certificate name must be configured"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("certificate name must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("certificate name is not valid: %s", err))
+		} else {
+			cmd.certificateName = args[0]
+		}
+	}
+
+	// Validate that there is already a certificate with this name in the namespace
+	exists := false
+	if cmd.certificateName != "" {
+		// read the latest configuration from the input directory
+		certificate, err := cmd.certificateHandler.Get(cmd.certificateName, fs.GetOptions{})
+		if certificate == nil || err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("certificate %s must exist in namespace %s to be updated", cmd.certificateName, cmd.namespace))
+		} else {
+			// save existing values
+			cmd.newSettings = certificate.Spec
+			exists = true
+		}
+	}
+
+	// Validate flags
+	if cmd.Flags.Ca != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.Ca)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("ca is not valid: %s", err))
+		} else {
+			cmd.newSettings.Ca = cmd.Flags.Ca
+		}
+	}
+	if cmd.Flags.Subject != "" {
+		cmd.newSettings.Subject = cmd.Flags.Subject
+	}
+	if len(cmd.Flags.Hosts) > 0 {
+		valid := true
+		for _, host := range cmd.Flags.Hosts {
+			ok, _ := hostStringValidator.Evaluate(host)
+			if !ok {
+				valid = false
+				validationErrors = append(validationErrors, fmt.Errorf("host %q is not valid: a valid IP address or hostname is expected", host))
+			}
+		}
+		if valid {
+			cmd.newSettings.Hosts = cmd.Flags.Hosts
+		}
+	}
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flags().Changed(common.FlagNameClient) {
+		cmd.newSettings.Client = cmd.Flags.Client
+	}
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flags().Changed(common.FlagNameServer) {
+		cmd.newSettings.Server = cmd.Flags.Server
+	}
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flags().Changed(common.FlagNameSigning) {
+		cmd.newSettings.Signing = cmd.Flags.Signing
+	}
+	if exists && cmd.newSettings.Ca == "" && !cmd.newSettings.Signing {
+		validationErrors = append(validationErrors, fmt.Errorf("ca must be configured unless the certificate is a signing certificate"))
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdCertificateUpdate) InputToOptions() {
+	if cmd.namespace == "" {
+		cmd.namespace = "default"
+	}
+}
+
+func (cmd *CmdCertificateUpdate) Run() error {
+	certificateResource := v2alpha1.Certificate{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "Certificate",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cmd.certificateName,
+			Namespace: cmd.namespace,
+		},
+		Spec: cmd.newSettings,
+	}
+
+	err := cmd.certificateHandler.Add(certificateResource)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (cmd *CmdCertificateUpdate) WaitUntil() error { return nil }
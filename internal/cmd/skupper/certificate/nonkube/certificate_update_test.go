@@ -0,0 +1,145 @@
+package nonkube
+
+import (
+	"os"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func addCertificate(t *testing.T, namespace string) *fs.CertificateHandler {
+	t.Helper()
+	if os.Getuid() == 0 {
+		api.DefaultRootDataHome = t.TempDir()
+	} else {
+		t.Setenv("XDG_DATA_HOME", t.TempDir())
+	}
+	handler := fs.NewCertificateHandler(namespace)
+	assert.Assert(t, handler.Add(v2alpha1.Certificate{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "Certificate",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cert",
+			Namespace: namespace,
+		},
+		Spec: v2alpha1.CertificateSpec{
+			Ca:      "skupper-site-ca",
+			Subject: "my-cert",
+			Hosts:   []string{"10.0.0.1"},
+			Server:  true,
+		},
+	}))
+	return handler
+}
+
+func TestCmdCertificateUpdate_ValidateInput(t *testing.T) {
+	type test struct {
+		name             string
+		args             []string
+		flags            *common.CommandCertificateUpdateFlags
+		changedFlags     map[string]string
+		expectedError    string
+		expectedSettings *v2alpha1.CertificateSpec
+	}
+
+	testTable := []test{
+		{
+			name:          "certificate does not exist",
+			args:          []string{"no-cert"},
+			flags:         &common.CommandCertificateUpdateFlags{},
+			expectedError: "certificate no-cert must exist in namespace test to be updated",
+		},
+		{
+			name:          "certificate name is not specified",
+			args:          []string{},
+			flags:         &common.CommandCertificateUpdateFlags{},
+			expectedError: "certificate name must be configured",
+		},
+		{
+			name:          "more than one argument is specified",
+			args:          []string{"my", "cert"},
+			flags:         &common.CommandCertificateUpdateFlags{},
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:          "host is not valid",
+			args:          []string{"my-cert"},
+			flags:         &common.CommandCertificateUpdateFlags{Hosts: []string{"not valid"}},
+			expectedError: "host \"not valid\" is not valid: a valid IP address or hostname is expected",
+		},
+		{
+			name:  "unspecified options keep their value",
+			args:  []string{"my-cert"},
+			flags: &common.CommandCertificateUpdateFlags{Subject: "backend"},
+			expectedSettings: &v2alpha1.CertificateSpec{
+				Ca:      "skupper-site-ca",
+				Subject: "backend",
+				Hosts:   []string{"10.0.0.1"},
+				Server:  true,
+			},
+		},
+		{
+			name:         "boolean options are only updated when set",
+			args:         []string{"my-cert"},
+			flags:        &common.CommandCertificateUpdateFlags{Client: true},
+			changedFlags: map[string]string{common.FlagNameClient: "true", common.FlagNameServer: "false"},
+			expectedSettings: &v2alpha1.CertificateSpec{
+				Ca:      "skupper-site-ca",
+				Subject: "my-cert",
+				Hosts:   []string{"10.0.0.1"},
+				Client:  true,
+			},
+		},
+	}
+
+	handler := addCertificate(t, "test")
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdCertificateUpdate{
+				Flags:              test.flags,
+				CobraCmd:           &cobra.Command{Use: "test"},
+				namespace:          "test",
+				certificateHandler: handler,
+			}
+			command.CobraCmd.Flags().BoolVar(&command.Flags.Client, common.FlagNameClient, false, "")
+			command.CobraCmd.Flags().BoolVar(&command.Flags.Server, common.FlagNameServer, false, "")
+			command.CobraCmd.Flags().BoolVar(&command.Flags.Signing, common.FlagNameSigning, false, "")
+			for name, value := range test.changedFlags {
+				assert.Assert(t, command.CobraCmd.Flags().Set(name, value))
+			}
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+			if test.expectedSettings != nil {
+				assert.DeepEqual(t, command.newSettings, *test.expectedSettings)
+			}
+		})
+	}
+}
+
+func TestCmdCertificateUpdate_Run(t *testing.T) {
+	handler := addCertificate(t, "test")
+	command := &CmdCertificateUpdate{
+		Flags:              &common.CommandCertificateUpdateFlags{Hosts: []string{"backend.example.com"}},
+		CobraCmd:           &cobra.Command{Use: "test"},
+		namespace:          "test",
+		certificateHandler: handler,
+	}
+	assert.Assert(t, command.ValidateInput([]string{"my-cert"}))
+	command.InputToOptions()
+
+	assert.Assert(t, command.Run())
+
+	certificate, err := handler.Get("my-cert", fs.GetOptions{})
+	assert.Assert(t, err)
+	assert.DeepEqual(t, certificate.Spec.Hosts, []string{"backend.example.com"})
+	assert.Equal(t, certificate.Spec.Ca, "skupper-site-ca")
+}
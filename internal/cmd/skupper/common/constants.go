@@ -3,11 +3,16 @@ package common
 var (
 	LinkAccessTypes = []string{"route", "loadbalancer", "default"}
 	OutputTypes     = []string{"json", "yaml"}
-	ListenerTypes   = []string{"tcp"}
-	ConnectorTypes  = []string{"tcp"}
+	ListenerTypes   = []string{"tcp", "http", "http2", "udp"}
+	ConnectorTypes  = []string{"tcp", "http", "http2", "udp"}
 	WorkloadTypes   = []string{"deployment", "service", "daemonset", "statefulset"}
 	WaitStatusTypes = []string{"ready", "configured", "none"}
+	ReadyWaitTypes  = []string{"ready", "none"}
+	RouterRoles     = []string{"inter-router", "edge"}
+	AccessTypes     = []string{"local", "loadbalancer", "route", "nodeport", "ingress-nginx", "contour-http-proxy", "gateway"}
 	BundleTypes     = []string{"tarball", "shell-script"}
+
+	NetworkStatusOutputTypes = []string{"json", "yaml", "dot"}
 )
 
 const (
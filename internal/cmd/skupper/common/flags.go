@@ -47,7 +47,7 @@ for other Kubernetes flavors, loadbalancer is the default.`
 	FlagNameHost                = "host"
 	FlagDescHost                = "The hostname or IP address of the local connector"
 	FlagNameConnectorType       = "type"
-	FlagDescConnectorType       = "The connector type. Choices: [tcp]."
+	FlagDescConnectorType       = "The connector type. Choices: [tcp|http|http2|udp]."
 	FlagNameIncludeNotReadyPods = "include-not-ready"
 	FlagDescIncludeNotRead      = "If true, include server pods that are not in the ready state."
 	FlagNameSelector            = "selector"
@@ -62,7 +62,7 @@ for other Kubernetes flavors, loadbalancer is the default.`
 	FlagDescConnectorStatusOutput = "print status of connectors Choices: json, yaml"
 
 	FlagNameListenerType = "type"
-	FlagDescListenerType = "The listener type. Choices: [tcp]."
+	FlagDescListenerType = "The listener type. Choices: [tcp|http|http2|udp]."
 	FlagNameListenerPort = "port"
 	FlagDescListenerPort = "The port of the local listener"
 	FlagNameListenerHost = "host"
@@ -89,6 +89,76 @@ for other Kubernetes flavors, loadbalancer is the default.`
 
 	FlagNameFileName = "filename"
 	FlagDescFileName = "The name of the file with custom resources"
+
+	FlagDescLintFileName = "a file or directory holding the custom resources to lint, may be repeated"
+	FlagDescLintOutput   = "print the findings in the given format instead of a table. Choices: json, yaml"
+
+	FlagDescChartFileName    = "a file or directory holding the resources of the site, as produced by the generate commands, may be repeated"
+	FlagDescChartType        = "The kind of chart to be produced. Choices: helm, kustomize"
+	FlagNameChartName        = "name"
+	FlagDescChartName        = "The name of the chart. If not set, the site name is used."
+	FlagNameOutputDir        = "output-dir"
+	FlagDescOutputDir        = "The directory the chart is written to. If not set, <name>-<type> is created in the current directory."
+	FlagNameControllerConfig = "controller-config"
+	FlagDescControllerConfig = "The directory of the controller configuration (config/ in the skupper source tree). If set, its CRDs and namespace scoped RBAC are included in the chart."
+
+	FlagDescNetworkStatusOutput = "print the network status in the given format instead of tables. Choices: json, yaml, dot"
+
+	FlagDescSiteDiffOutput = "print the differences in the given format instead of a list. Choices: json, yaml"
+
+	FlagDescRouterOutput  = "print the router state in the given format instead of a table. Choices: json, yaml"
+	FlagNameWatch         = "watch"
+	FlagDescWatch         = "keep refreshing the output periodically until interrupted"
+	FlagNameWatchInterval = "interval"
+	FlagDescWatchInterval = "the period between two refreshes of the output in watch mode"
+	FlagNameRouterPod     = "pod"
+	FlagDescRouterPod     = "the router pod to query. If not set, the first ready router pod of the site is used"
+
+	FlagDescReadyWait = "Wait for the given status before exiting. Choices: ready, none"
+
+	FlagNameRoles                   = "roles"
+	FlagDescRoles                   = "The router roles to expose and the port for each, expressed as role:port. Choices for role: [inter-router|edge]."
+	FlagNameAccessType              = "access-type"
+	FlagDescAccessType              = "configure external access for the router. Choices: [local|loadbalancer|route|nodeport|ingress-nginx|contour-http-proxy|gateway]. If not set, the default access type of the controller is used."
+	FlagNameGenerateTlsCredentials  = "generate-tls-credentials"
+	FlagDescGenerateTlsCredentials  = "If true, the TLS credentials are generated by the Skupper controller, signed by the issuer."
+	FlagNameIssuer                  = "issuer"
+	FlagDescIssuer                  = "The name of the Certificate authority used to sign generated TLS credentials. If not set, the site CA is used."
+	FlagNameBindHost                = "bind-host"
+	FlagDescBindHost                = "The hostname or IP address the router listens on"
+	FlagNameSubjectAlternativeNames = "subject-alternative-names"
+	FlagDescSubjectAlternativeNames = "Additional hostnames or IP addresses to include in generated TLS credentials"
+
+	FlagNameSiteNamespace      = "site-namespace"
+	FlagDescSiteNamespace      = "The namespace of the site the attached connector is bound to"
+	FlagNameUseClientCert      = "use-client-cert"
+	FlagDescUseClientCert      = "If true, the router presents a client certificate from the TLS credentials when connecting to the target pods"
+	FlagNameAttachedPort       = "port"
+	FlagDescAttachedPort       = "The port on the target pods"
+	FlagNameConnectorNamespace = "connector-namespace"
+	FlagDescConnectorNamespace = "The namespace of the attached connector that the binding refers to"
+	FlagNameExposePodsByName   = "expose-pods-by-name"
+	FlagDescExposePodsByName   = "If true, expose each pod selected by the attached connector as an individual address"
+
+	FlagNameCa      = "ca"
+	FlagDescCa      = "The name of the Certificate used as the certificate authority to sign this certificate"
+	FlagNameSubject = "subject"
+	FlagDescSubject = "The subject of the certificate. If not set, the name of the certificate is used"
+	FlagNameHosts   = "hosts"
+	FlagDescHosts   = "The hostnames or IP addresses the certificate is valid for"
+	FlagNameClient  = "client"
+	FlagDescClient  = "If true, the certificate can be used for client authentication"
+	FlagNameServer  = "server"
+	FlagDescServer  = "If true, the certificate can be used for server authentication"
+	FlagNameSigning = "signing"
+	FlagDescSigning = "If true, the certificate is a certificate authority that can sign other certificates"
+
+	FlagNamePassphraseFile       = "passphrase-file"
+	FlagDescExportPassphraseFile = "A file holding the passphrase used to encrypt the secrets in the archive. If not set, secrets are stored unencrypted"
+	FlagDescImportPassphraseFile = "A file holding the passphrase used to decrypt the secrets in the archive"
+
+	FlagNameIncludeSecrets = "include-secrets"
+	FlagDescIncludeSecrets = "Include private keys, secret data and access codes in the dump instead of redacting them (non-kubernetes platforms only)"
 )
 
 type CommandSiteCreateFlags struct {
@@ -124,6 +194,18 @@ type CommandSiteGenerateFlags struct {
 	Output           string
 }
 
+type CommandSiteExportFlags struct {
+	PassphraseFile string
+}
+
+type CommandSiteImportFlags struct {
+	PassphraseFile string
+}
+
+type CommandSiteDiffFlags struct {
+	Output string
+}
+
 type CommandLinkGenerateFlags struct {
 	TlsCredentials     string
 	Cost               string
@@ -247,6 +329,31 @@ type CommandVersionFlags struct {
 }
 
 type CommandDebugFlags struct {
+	IncludeSecrets bool
+}
+
+type CommandLintFlags struct {
+	Filenames []string
+	Output    string
+}
+
+type CommandGenerateChartFlags struct {
+	Filenames        []string
+	Type             string
+	Name             string
+	OutputDir        string
+	ControllerConfig string
+}
+
+type CommandNetworkStatusFlags struct {
+	Output string
+}
+
+type CommandRouterFlags struct {
+	Output   string
+	Watch    bool
+	Interval time.Duration
+	Pod      string
 }
 
 type CommandSystemUninstallFlags struct {
@@ -265,3 +372,189 @@ type CommandSystemApplyFlags struct {
 type CommandSystemDeleteFlags struct {
 	Filename string
 }
+
+type CommandAccessGrantCreateFlags struct {
+	RedemptionsAllowed int
+	ExpirationWindow   time.Duration
+	Timeout            time.Duration
+	Wait               string
+}
+
+type CommandAccessGrantUpdateFlags struct {
+	RedemptionsAllowed int
+	ExpirationWindow   time.Duration
+	Timeout            time.Duration
+	Wait               string
+}
+
+type CommandAccessGrantStatusFlags struct {
+	Output string
+}
+
+type CommandAccessGrantDeleteFlags struct {
+	Timeout time.Duration
+	Wait    bool
+}
+
+type CommandAccessGrantGenerateFlags struct {
+	RedemptionsAllowed int
+	ExpirationWindow   time.Duration
+	Output             string
+}
+
+type CommandRouterAccessCreateFlags struct {
+	Roles                   []string
+	AccessType              string
+	TlsCredentials          string
+	GenerateTlsCredentials  bool
+	Issuer                  string
+	BindHost                string
+	SubjectAlternativeNames []string
+	Timeout                 time.Duration
+	Wait                    string
+}
+
+type CommandRouterAccessUpdateFlags struct {
+	Roles                   []string
+	AccessType              string
+	TlsCredentials          string
+	GenerateTlsCredentials  bool
+	Issuer                  string
+	BindHost                string
+	SubjectAlternativeNames []string
+	Timeout                 time.Duration
+	Wait                    string
+}
+
+type CommandRouterAccessStatusFlags struct {
+	Output string
+}
+
+type CommandRouterAccessDeleteFlags struct {
+	Timeout time.Duration
+	Wait    bool
+}
+
+type CommandRouterAccessGenerateFlags struct {
+	Roles                   []string
+	AccessType              string
+	TlsCredentials          string
+	GenerateTlsCredentials  bool
+	Issuer                  string
+	BindHost                string
+	SubjectAlternativeNames []string
+	Output                  string
+}
+
+type CommandAttachedConnectorCreateFlags struct {
+	SiteNamespace       string
+	Selector            string
+	TlsCredentials      string
+	UseClientCert       bool
+	ConnectorType       string
+	IncludeNotReadyPods bool
+	Timeout             time.Duration
+	Wait                string
+}
+
+type CommandAttachedConnectorUpdateFlags struct {
+	Selector            string
+	Port                int
+	TlsCredentials      string
+	UseClientCert       bool
+	ConnectorType       string
+	IncludeNotReadyPods bool
+	Timeout             time.Duration
+	Wait                string
+}
+
+type CommandAttachedConnectorStatusFlags struct {
+	Output string
+}
+
+type CommandAttachedConnectorDeleteFlags struct {
+	Timeout time.Duration
+	Wait    bool
+}
+
+type CommandAttachedConnectorGenerateFlags struct {
+	SiteNamespace       string
+	Selector            string
+	TlsCredentials      string
+	UseClientCert       bool
+	ConnectorType       string
+	IncludeNotReadyPods bool
+	Output              string
+}
+
+type CommandAttachedConnectorBindingCreateFlags struct {
+	ConnectorNamespace string
+	RoutingKey         string
+	ExposePodsByName   bool
+	Timeout            time.Duration
+	Wait               string
+}
+
+type CommandAttachedConnectorBindingUpdateFlags struct {
+	RoutingKey       string
+	ExposePodsByName bool
+	Timeout          time.Duration
+	Wait             string
+}
+
+type CommandAttachedConnectorBindingStatusFlags struct {
+	Output string
+}
+
+type CommandAttachedConnectorBindingDeleteFlags struct {
+	Timeout time.Duration
+	Wait    bool
+}
+
+type CommandAttachedConnectorBindingGenerateFlags struct {
+	ConnectorNamespace string
+	RoutingKey         string
+	ExposePodsByName   bool
+	Output             string
+}
+
+type CommandCertificateCreateFlags struct {
+	Ca      string
+	Subject string
+	Hosts   []string
+	Client  bool
+	Server  bool
+	Signing bool
+	Timeout time.Duration
+	Wait    string
+}
+
+type CommandCertificateUpdateFlags struct {
+	Ca      string
+	Subject string
+	Hosts   []string
+	Client  bool
+	Server  bool
+	Signing bool
+	Timeout time.Duration
+	Wait    string
+}
+
+type CommandCertificateStatusFlags struct {
+	Output string
+}
+
+type CommandCertificateDeleteFlags struct {
+	Timeout time.Duration
+	Wait    bool
+}
+
+type CommandCertificateGenerateFlags struct {
+	Ca      string
+	Subject string
+	Hosts   []string
+	Client  bool
+	Server  bool
+	Signing bool
+	Output  string
+}
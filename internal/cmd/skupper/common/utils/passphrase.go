@@ -0,0 +1,22 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ReadPassphraseFile returns the passphrase held by the given file,
+// ignoring any trailing line break.
+func ReadPassphraseFile(fileName string) (string, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return "", fmt.Errorf("unable to read passphrase file: %w", err)
+	}
+	passphrase := strings.TrimRight(string(data), "\r\n")
+	if passphrase == "" {
+		return "", errors.New("passphrase file is empty")
+	}
+	return passphrase, nil
+}
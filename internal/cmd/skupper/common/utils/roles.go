@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+)
+
+// ParseRouterAccessRoles converts a list of role:port values into the
+// roles of a RouterAccess, checking that each role is one of the
+// allowed values and appears only once.
+func ParseRouterAccessRoles(values []string, allowedRoles []string) ([]v2alpha1.RouterAccessRole, error) {
+	roleValidator := validator.NewOptionValidator(allowedRoles)
+	numberValidator := validator.NewNumberValidator()
+	var roles []v2alpha1.RouterAccessRole
+	seen := map[string]bool{}
+	for _, value := range values {
+		name, portString, found := strings.Cut(value, ":")
+		if !found {
+			return nil, fmt.Errorf("role %q is not valid: expected role:port", value)
+		}
+		if ok, err := roleValidator.Evaluate(name); !ok {
+			return nil, fmt.Errorf("role %q is not valid: %s", value, err)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("role %q is specified more than once", name)
+		}
+		seen[name] = true
+		port, err := strconv.Atoi(portString)
+		if err != nil {
+			return nil, fmt.Errorf("port for role %q is not valid: %s", name, err)
+		}
+		if ok, err := numberValidator.Evaluate(port); !ok {
+			return nil, fmt.Errorf("port for role %q is not valid: %s", name, err)
+		} else if port == 0 || port > 65535 {
+			return nil, fmt.Errorf("port for role %q is not valid: must be between 1 and 65535", name)
+		}
+		roles = append(roles, v2alpha1.RouterAccessRole{
+			Name: name,
+			Port: port,
+		})
+	}
+	return roles, nil
+}
+
+// FormatRouterAccessRoles is the inverse of ParseRouterAccessRoles.
+func FormatRouterAccessRoles(roles []v2alpha1.RouterAccessRole) []string {
+	var values []string
+	for _, role := range roles {
+		values = append(values, fmt.Sprintf("%s:%d", role.Name, role.Port))
+	}
+	return values
+}
@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+)
+
+func TestParseRouterAccessRoles(t *testing.T) {
+	allowed := []string{"inter-router", "edge"}
+	testTable := []struct {
+		name          string
+		values        []string
+		expected      []v2alpha1.RouterAccessRole
+		expectedError string
+	}{
+		{
+			name:   "valid roles",
+			values: []string{"inter-router:55671", "edge:45671"},
+			expected: []v2alpha1.RouterAccessRole{
+				{Name: "inter-router", Port: 55671},
+				{Name: "edge", Port: 45671},
+			},
+		},
+		{
+			name:          "missing port",
+			values:        []string{"inter-router"},
+			expectedError: "role \"inter-router\" is not valid: expected role:port",
+		},
+		{
+			name:          "unknown role",
+			values:        []string{"normal:8080"},
+			expectedError: "role \"normal:8080\" is not valid: value normal not allowed. It should be one of this options: [inter-router edge]",
+		},
+		{
+			name:          "duplicate role",
+			values:        []string{"edge:8080", "edge:8081"},
+			expectedError: "role \"edge\" is specified more than once",
+		},
+		{
+			name:          "port not a number",
+			values:        []string{"edge:abc"},
+			expectedError: "port for role \"edge\" is not valid: strconv.Atoi: parsing \"abc\": invalid syntax",
+		},
+		{
+			name:          "port not positive",
+			values:        []string{"edge:-1"},
+			expectedError: "port for role \"edge\" is not valid: value is not positive",
+		},
+		{
+			name:          "port out of range",
+			values:        []string{"edge:70000"},
+			expectedError: "port for role \"edge\" is not valid: must be between 1 and 65535",
+		},
+	}
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			roles, err := ParseRouterAccessRoles(test.values, allowed)
+			if test.expectedError != "" {
+				assert.Error(t, err, test.expectedError)
+			} else {
+				assert.Assert(t, err)
+				assert.DeepEqual(t, roles, test.expected)
+				assert.DeepEqual(t, FormatRouterAccessRoles(roles), test.values)
+			}
+		})
+	}
+}
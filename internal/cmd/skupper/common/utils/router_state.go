@@ -0,0 +1,165 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/qdr"
+)
+
+const (
+	RouterConnections = "connections"
+	RouterLinks       = "links"
+	RouterAddresses   = "addresses"
+	RouterTcpFlows    = "tcp-flows"
+)
+
+// RouterStateReader queries the management agent of a router.
+type RouterStateReader interface {
+	GetConnections() ([]qdr.Connection, error)
+	GetLinks() ([]qdr.RouterLink, error)
+	GetAddresses() ([]qdr.RouterAddress, error)
+	GetLocalTcpConnections() ([]qdr.TcpConnection, error)
+}
+
+// QueryRouterState retrieves the entities of the router for the given
+// query, sorted for a stable output.
+func QueryRouterState(agent RouterStateReader, query string) (interface{}, error) {
+	switch query {
+	case RouterConnections:
+		connections, err := agent.GetConnections()
+		if err != nil {
+			return nil, err
+		}
+		sort.Slice(connections, func(i, j int) bool {
+			if connections[i].Role != connections[j].Role {
+				return connections[i].Role < connections[j].Role
+			}
+			return connections[i].Host < connections[j].Host
+		})
+		return connections, nil
+	case RouterLinks:
+		links, err := agent.GetLinks()
+		if err != nil {
+			return nil, err
+		}
+		sort.Slice(links, func(i, j int) bool {
+			if links[i].ConnectionId != links[j].ConnectionId {
+				return links[i].ConnectionId < links[j].ConnectionId
+			}
+			return links[i].Identity < links[j].Identity
+		})
+		return links, nil
+	case RouterAddresses:
+		addresses, err := agent.GetAddresses()
+		if err != nil {
+			return nil, err
+		}
+		sort.Slice(addresses, func(i, j int) bool {
+			if addresses[i].Class != addresses[j].Class {
+				return addresses[i].Class < addresses[j].Class
+			}
+			return addresses[i].Name < addresses[j].Name
+		})
+		return addresses, nil
+	case RouterTcpFlows:
+		flows, err := agent.GetLocalTcpConnections()
+		if err != nil {
+			return nil, err
+		}
+		sort.Slice(flows, func(i, j int) bool {
+			return flows[i].Name < flows[j].Name
+		})
+		return flows, nil
+	default:
+		return nil, fmt.Errorf("unknown router query %q", query)
+	}
+}
+
+// WriteRouterState writes the result of a query either as a table or,
+// if an output type is given, encoded in that format.
+func WriteRouterState(out io.Writer, query string, output string, state interface{}) error {
+	if output != "" {
+		encoded, err := Encode(output, map[string]interface{}{query: state})
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(out, encoded)
+		return err
+	}
+	writer := tabwriter.NewWriter(out, 0, 8, 1, '\t', tabwriter.AlignRight)
+	switch items := state.(type) {
+	case []qdr.Connection:
+		if len(items) == 0 {
+			fmt.Fprintln(writer, "There are no connections")
+			break
+		}
+		fmt.Fprintln(writer, "ID\tHOST\tCONTAINER\tROLE\tDIR\tSECURITY\tAUTHENTICATION\tUPTIME")
+		for _, c := range items {
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", c.Identity, c.Host, c.Container, c.Role, c.Dir, c.Security, c.Authentication, seconds(c.Uptime))
+		}
+	case []qdr.RouterLink:
+		if len(items) == 0 {
+			fmt.Fprintln(writer, "There are no links")
+			break
+		}
+		fmt.Fprintln(writer, "TYPE\tDIR\tCONN ID\tID\tPEER\tADDRESS\tCAPACITY\tUNDELIVERED\tUNSETTLED\tDELIVERED\tSTATUS")
+		for _, l := range items {
+			fmt.Fprintf(writer, "%s\t%s\t%d\t%s\t%s\t%s\t%d\t%d\t%d\t%d\t%s\n", l.LinkType, l.LinkDir, l.ConnectionId, l.Identity, l.Peer, l.OwningAddr, l.Capacity, l.UndeliveredCount, l.UnsettledCount, l.DeliveryCount, l.OperStatus)
+		}
+	case []qdr.RouterAddress:
+		if len(items) == 0 {
+			fmt.Fprintln(writer, "There are no addresses")
+			break
+		}
+		fmt.Fprintln(writer, "CLASS\tADDRESS\tPHASE\tDISTRIBUTION\tIN-PROC\tLOCAL\tREMOTE\tIN\tOUT\tTHRU")
+		for _, a := range items {
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%d\t%d\t%d\t%d\t%d\t%d\n", a.Class, a.Address, a.Phase, a.Distribution, a.InProcess, a.SubscriberCount, a.RemoteCount, a.DeliveriesIngress, a.DeliveriesEgress, a.DeliveriesTransit)
+		}
+	case []qdr.TcpConnection:
+		if len(items) == 0 {
+			fmt.Fprintln(writer, "There are no tcp flows")
+			break
+		}
+		fmt.Fprintln(writer, "NAME\tADDRESS\tHOST\tDIRECTION\tBYTES IN\tBYTES OUT\tUPTIME\tLAST IN\tLAST OUT")
+		for _, f := range items {
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%d\t%d\t%s\t%s\t%s\n", f.Name, f.Address, f.Host, f.Direction, f.BytesIn, f.BytesOut, seconds(f.Uptime), seconds(f.LastIn), seconds(f.LastOut))
+		}
+	default:
+		return fmt.Errorf("unsupported router state %T", state)
+	}
+	return writer.Flush()
+}
+
+// WatchRouterState writes the result of a query, then refreshes it
+// after each interval until the context is cancelled. Tables replace
+// the previous output on the terminal, encoded outputs are appended.
+func WatchRouterState(ctx context.Context, out io.Writer, agent RouterStateReader, query string, output string, interval time.Duration) error {
+	for {
+		state, err := QueryRouterState(agent, query)
+		if err != nil {
+			return err
+		}
+		if output == "" {
+			fmt.Fprintf(out, "\033[H\033[2J%s (every %s)\n\n", time.Now().Format(time.RFC1123), interval)
+		} else if output == "yaml" {
+			fmt.Fprintln(out, "---")
+		}
+		if err := WriteRouterState(out, query, output, state); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+func seconds(value uint64) string {
+	return (time.Duration(value) * time.Second).String()
+}
@@ -0,0 +1,141 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/qdr"
+	"gotest.tools/v3/assert"
+)
+
+type fakeRouterState struct {
+	connections []qdr.Connection
+	links       []qdr.RouterLink
+	addresses   []qdr.RouterAddress
+	flows       []qdr.TcpConnection
+	err         error
+	queries     int
+}
+
+func (f *fakeRouterState) GetConnections() ([]qdr.Connection, error) {
+	f.queries++
+	return f.connections, f.err
+}
+
+func (f *fakeRouterState) GetLinks() ([]qdr.RouterLink, error) {
+	f.queries++
+	return f.links, f.err
+}
+
+func (f *fakeRouterState) GetAddresses() ([]qdr.RouterAddress, error) {
+	f.queries++
+	return f.addresses, f.err
+}
+
+func (f *fakeRouterState) GetLocalTcpConnections() ([]qdr.TcpConnection, error) {
+	f.queries++
+	return f.flows, f.err
+}
+
+func TestWriteRouterState(t *testing.T) {
+	agent := &fakeRouterState{
+		connections: []qdr.Connection{
+			{Identity: "4", Host: "10.0.0.2:55672", Container: "west-router", Role: "inter-router", Dir: "out", Security: "TLSv1.3", Uptime: 90},
+			{Identity: "2", Host: "127.0.0.1:43210", Container: "controller", Role: "normal", Dir: "in"},
+		},
+		links: []qdr.RouterLink{
+			{Identity: "7", LinkType: "endpoint", LinkDir: "in", ConnectionId: 2, OwningAddr: "Mbackend", OperStatus: "up"},
+		},
+		addresses: []qdr.RouterAddress{
+			{Name: "M0backend", Class: "mobile", Address: "backend", Phase: "0", Distribution: "balanced", RemoteCount: 1},
+			{Name: "L$management", Class: "local", Address: "$management", Distribution: "closest", InProcess: 1},
+		},
+		flows: []qdr.TcpConnection{
+			{Name: "backend:8080-1", Address: "backend", Host: "10.0.0.3:8080", Direction: "out", BytesIn: 10, BytesOut: 20, Uptime: 5},
+		},
+	}
+	testTable := []struct {
+		query    string
+		output   string
+		expected []string
+	}{
+		{
+			query:    RouterConnections,
+			expected: []string{"ID", "SECURITY", "west-router", "TLSv1.3", "1m30s"},
+		},
+		{
+			query:    RouterLinks,
+			expected: []string{"CONN ID", "endpoint", "Mbackend"},
+		},
+		{
+			query:    RouterAddresses,
+			expected: []string{"DISTRIBUTION", "backend", "$management"},
+		},
+		{
+			query:    RouterTcpFlows,
+			expected: []string{"BYTES IN", "backend:8080-1", "10.0.0.3:8080"},
+		},
+		{
+			query:    RouterConnections,
+			output:   "json",
+			expected: []string{"\"connections\": [", "\"container\": \"west-router\""},
+		},
+		{
+			query:    RouterTcpFlows,
+			output:   "yaml",
+			expected: []string{"tcp-flows:", "bytesOut: 20"},
+		},
+	}
+	for _, test := range testTable {
+		t.Run(test.query+"-"+test.output, func(t *testing.T) {
+			state, err := QueryRouterState(agent, test.query)
+			assert.Assert(t, err)
+			out := &bytes.Buffer{}
+			assert.Assert(t, WriteRouterState(out, test.query, test.output, state))
+			for _, expected := range test.expected {
+				assert.Assert(t, strings.Contains(out.String(), expected), "%q not in %s", expected, out.String())
+			}
+		})
+	}
+}
+
+func TestQueryRouterState(t *testing.T) {
+	agent := &fakeRouterState{
+		addresses: []qdr.RouterAddress{
+			{Name: "M0backend", Class: "mobile"},
+			{Name: "L$management", Class: "local"},
+		},
+	}
+	state, err := QueryRouterState(agent, RouterAddresses)
+	assert.Assert(t, err)
+	addresses := state.([]qdr.RouterAddress)
+	assert.Equal(t, addresses[0].Name, "L$management")
+
+	empty := &bytes.Buffer{}
+	assert.Assert(t, WriteRouterState(empty, RouterLinks, "", []qdr.RouterLink{}))
+	assert.Equal(t, strings.TrimSpace(empty.String()), "There are no links")
+
+	_, err = QueryRouterState(agent, "sessions")
+	assert.Error(t, err, "unknown router query \"sessions\"")
+
+	agent.err = fmt.Errorf("connection closed")
+	_, err = QueryRouterState(agent, RouterConnections)
+	assert.Error(t, err, "connection closed")
+}
+
+func TestWatchRouterState(t *testing.T) {
+	agent := &fakeRouterState{}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	out := &bytes.Buffer{}
+	assert.Assert(t, WatchRouterState(ctx, out, agent, RouterTcpFlows, "yaml", 10*time.Millisecond))
+	assert.Assert(t, agent.queries > 1)
+	assert.Equal(t, strings.Count(out.String(), "---\n"), agent.queries)
+
+	agent.err = fmt.Errorf("connection closed")
+	assert.Error(t, WatchRouterState(context.Background(), out, agent, RouterTcpFlows, "", time.Second), "connection closed")
+}
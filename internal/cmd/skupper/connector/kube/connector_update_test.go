@@ -91,7 +91,7 @@ func TestCmdConnectorUpdate_ValidateInput(t *testing.T) {
 					},
 				},
 			},
-			expectedError: "connector type is not valid: value not-valid not allowed. It should be one of this options: [tcp]",
+			expectedError: "connector type is not valid: value not-valid not allowed. It should be one of this options: [tcp http http2 udp]",
 		},
 		{
 			name: "routing key is not valid",
@@ -38,6 +38,8 @@ func CmdDebugDumpFactory(configuredPlatform common.Platform) *cobra.Command {
 
 	cmdFlags := common.CommandDebugFlags{}
 
+	cmd.Flags().BoolVar(&cmdFlags.IncludeSecrets, common.FlagNameIncludeSecrets, false, common.FlagDescIncludeSecrets)
+
 	kubeCommand.CobraCmd = cmd
 	kubeCommand.Flags = &cmdFlags
 	nonKubeCommand.CobraCmd = cmd
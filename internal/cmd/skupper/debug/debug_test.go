@@ -20,9 +20,11 @@ func TestCmdLinkFactory(t *testing.T) {
 
 	testTable := []test{
 		{
-			name:                          "CmdDebugDumpFactory",
-			expectedFlagsWithDefaultValue: map[string]interface{}{},
-			command:                       CmdDebugDumpFactory(common.PlatformKubernetes),
+			name: "CmdDebugDumpFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameIncludeSecrets: "false",
+			},
+			command: CmdDebugDumpFactory(common.PlatformKubernetes),
 		},
 	}
 
@@ -1,33 +1,514 @@
 package nonkube
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
 	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
 
+	"sigs.k8s.io/yaml"
+
+	"github.com/skupperproject/skupper/api/types"
+	"github.com/skupperproject/skupper/internal/certs"
 	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/config"
+	"github.com/skupperproject/skupper/internal/nonkube/client/compat"
+	"github.com/skupperproject/skupper/internal/nonkube/client/runtime"
+	nonkubecommon "github.com/skupperproject/skupper/internal/nonkube/common"
+	"github.com/skupperproject/skupper/internal/qdr"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/skupperproject/skupper/pkg/container"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
 	"github.com/spf13/cobra"
 )
 
+const redacted = "REDACTED"
+
+// Directories under the namespace home holding private keys, which
+// are only included in the dump along with the other secrets.
+var keyDirectories = []api.InternalPath{
+	api.InputCertificatesPath,
+	api.InputIssuersPath,
+	api.CertificatesPath,
+	api.IssuersPath,
+}
+
+type ContainerClient interface {
+	ContainerInspect(id string) (*container.Container, error)
+	ContainerLogs(id string) (string, error)
+}
+
+type RouterManagement interface {
+	GetLocalRouter() (*qdr.Router, error)
+	GetConnections() ([]qdr.Connection, error)
+	GetLocalListeners() (map[string]qdr.Listener, error)
+	GetLocalConnectors() (map[string]qdr.Connector, error)
+	GetLocalBridgeConfig() (*qdr.BridgeConfig, error)
+	GetLocalTcpConnections() ([]qdr.TcpConnection, error)
+	Close() error
+}
+
 type CmdDebug struct {
-	CobraCmd  *cobra.Command
-	Flags     *common.CommandDebugFlags
-	Namespace string
+	CobraCmd        *cobra.Command
+	Flags           *common.CommandDebugFlags
+	Namespace       string
+	fileName        string
+	platform        string
+	runCommand      func(name string, args ...string) ([]byte, error)
+	containerClient func(platform string) (ContainerClient, error)
+	connectRouter   func(namespace string) (RouterManagement, error)
+	getUid          func() int
+	errors          []string
 }
 
 func NewCmdDebug() *CmdDebug {
 
-	skupperCmd := CmdDebug{}
+	skupperCmd := CmdDebug{
+		runCommand:      runCommand,
+		containerClient: newContainerClient,
+		connectRouter:   connectLocalRouter,
+		getUid:          os.Getuid,
+	}
 
 	return &skupperCmd
 }
 
 func (cmd *CmdDebug) NewClient(cobraCommand *cobra.Command, args []string) {
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace) != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String() != "" {
+		cmd.Namespace = cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String()
+	}
+}
+
+func (cmd *CmdDebug) ValidateInput(args []string) error {
+	var validationErrors []error
+	fileStringValidator := validator.NewFilePathStringValidator()
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameContext) != nil && cmd.CobraCmd.Flag(common.FlagNameContext).Value.String() != "" {
+		fmt.Println("Warning: --context flag is not supported on this platform")
+	}
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig) != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig).Value.String() != "" {
+		fmt.Println("Warning: --kubeconfig flag is not supported on this platform")
+	}
+
+	// Validate dump file name
+	if len(args) < 1 {
+		cmd.fileName = "skupper-dump"
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("filename must not be empty"))
+	} else {
+		ok, err := fileStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("filename is not valid: %s", err))
+		} else {
+			cmd.fileName = args[0]
+		}
+	}
+
+	namespace := cmd.Namespace
+	if namespace == "" {
+		namespace = "default"
+	}
+	if _, err := os.Stat(api.GetHostNamespaceHome(namespace)); err != nil {
+		validationErrors = append(validationErrors, fmt.Errorf("there is no site defined in namespace %q", namespace))
+	}
 
+	return errors.Join(validationErrors...)
 }
 
-func (cmd *CmdDebug) ValidateInput(args []string) error { return nil }
+func (cmd *CmdDebug) InputToOptions() {
+	if cmd.Namespace == "" {
+		cmd.Namespace = "default"
+	}
+	datetime := time.Now().Format("20060102150405")
+	cmd.fileName = fmt.Sprintf("%s-%s-%s", cmd.fileName, cmd.Namespace, datetime)
 
-func (cmd *CmdDebug) InputToOptions() {}
+	cmd.platform = string(config.GetPlatform())
+	platformLoader := &nonkubecommon.NamespacePlatformLoader{}
+	if platform, err := platformLoader.Load(cmd.Namespace); err == nil {
+		cmd.platform = platform
+	}
+}
+
+func (cmd *CmdDebug) Run() error {
+	dumpFile := cmd.fileName
+
+	// Add extension if not present
+	if filepath.Ext(dumpFile) == "" {
+		dumpFile = dumpFile + ".tar.gz"
+	}
+
+	tarFile, err := os.Create(dumpFile)
+	if err != nil {
+		return fmt.Errorf("Unable to save skupper dump details: %w", err)
+	}
+	defer tarFile.Close()
+
+	// compress tar
+	gz := gzip.NewWriter(tarFile)
+	defer gz.Close()
+	tw := tar.NewWriter(gz)
+	defer tw.Close()
+
+	manifest, err := cmd.runCommand("skupper", "version", "-o", "yaml")
+	if err == nil {
+		writeTar("/versions/skupper.yaml", manifest, time.Now(), tw)
+		writeTar("/versions/skupper.yaml.txt", manifest, time.Now(), tw)
+	}
+	writeTar("/versions/platform.txt", []byte(cmd.platform+"\n"), time.Now(), tw)
+
+	path := "/site-namespace/"
+	if err := cmd.writeNamespaceFiles(path, tw); err != nil {
+		return err
+	}
+	if summary := certificateSummary(cmd.Namespace); len(summary) > 0 {
+		writeTar(path+"certificates.txt", summary, time.Now(), tw)
+	}
+	cmd.writeServiceStatus(path, tw)
+	if cmd.platform != string(types.PlatformLinux) {
+		cmd.writeContainer(path, tw)
+	}
+	cmd.writeRouterState(path+"router/", tw)
 
-func (cmd *CmdDebug) Run() error { fmt.Println("not yet implemented"); return nil }
+	if len(cmd.errors) > 0 {
+		writeTar(path+"collection-errors.txt", []byte(strings.Join(cmd.errors, "\n")+"\n"), time.Now(), tw)
+	}
+
+	fmt.Println("Skupper dump details written to compressed archive: ", dumpFile)
+	if cmd.Flags != nil && cmd.Flags.IncludeSecrets {
+		fmt.Println("Warning: the archive holds the private keys and secrets of the site")
+	}
+	return nil
+}
 
 func (cmd *CmdDebug) WaitUntil() error { return nil }
+
+func (cmd *CmdDebug) includeSecrets() bool {
+	return cmd.Flags != nil && cmd.Flags.IncludeSecrets
+}
+
+func (cmd *CmdDebug) collectionError(item string, err error) {
+	cmd.errors = append(cmd.errors, fmt.Sprintf("%s: %s", item, err))
+}
+
+// writeNamespaceFiles adds the input, runtime and internal files of the
+// namespace, redacting secrets unless asked not to.
+func (cmd *CmdDebug) writeNamespaceFiles(prefix string, tw *tar.Writer) error {
+	home := api.GetHostNamespaceHome(cmd.Namespace)
+	skipped := map[string]bool{}
+	if !cmd.includeSecrets() {
+		for _, dir := range keyDirectories {
+			skipped[api.GetInternalOutputPath(cmd.Namespace, dir)] = true
+		}
+	}
+	return filepath.WalkDir(home, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			cmd.collectionError(file, err)
+			return nil
+		}
+		if entry.IsDir() {
+			if skipped[file] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		relative, err := filepath.Rel(home, file)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			cmd.collectionError(relative, err)
+			return nil
+		}
+		if !cmd.includeSecrets() {
+			if strings.HasSuffix(file, ".key") {
+				return nil
+			}
+			if ext := filepath.Ext(file); ext == ".yaml" || ext == ".yml" {
+				data = redactYaml(data)
+			}
+		}
+		info, err := entry.Info()
+		modTime := time.Now()
+		if err == nil {
+			modTime = info.ModTime()
+		}
+		return writeTar(prefix+filepath.ToSlash(relative), data, modTime, tw)
+	})
+}
+
+// redactYaml replaces the data of Secrets and the codes of
+// AccessGrants and AccessTokens held in a (multi-document) YAML file.
+func redactYaml(data []byte) []byte {
+	documents := strings.Split(string(data), "\n---")
+	for i, document := range documents {
+		var resource map[string]interface{}
+		if err := yaml.Unmarshal([]byte(document), &resource); err != nil || resource == nil {
+			continue
+		}
+		changed := false
+		switch resource["kind"] {
+		case "Secret":
+			changed = redactValues(resource, "data") || changed
+			changed = redactValues(resource, "stringData") || changed
+		case "AccessGrant":
+			changed = redactField(resource, "status", "code")
+		case "AccessToken":
+			changed = redactField(resource, "spec", "code")
+		}
+		if !changed {
+			continue
+		}
+		if encoded, err := yaml.Marshal(resource); err == nil {
+			if i > 0 {
+				documents[i] = "\n" + string(encoded)
+			} else {
+				documents[i] = string(encoded)
+			}
+		}
+	}
+	return []byte(strings.Join(documents, "\n---"))
+}
+
+func redactValues(resource map[string]interface{}, field string) bool {
+	values, ok := resource[field].(map[string]interface{})
+	if !ok || len(values) == 0 {
+		return false
+	}
+	for key := range values {
+		values[key] = redacted
+	}
+	return true
+}
+
+func redactField(resource map[string]interface{}, parent string, field string) bool {
+	values, ok := resource[parent].(map[string]interface{})
+	if !ok {
+		return false
+	}
+	if value, ok := values[field]; !ok || value == "" {
+		return false
+	}
+	values[field] = redacted
+	return true
+}
+
+// certificateSummary lists the subject and expiry of the certificates
+// and certificate authorities of the namespace.
+func certificateSummary(namespace string) []byte {
+	type entry struct {
+		name     string
+		file     string
+		subject  string
+		notAfter time.Time
+	}
+	var entries []entry
+	for _, dir := range []api.InternalPath{api.CertificatesPath, api.IssuersPath} {
+		base := api.GetInternalOutputPath(namespace, dir)
+		names, err := os.ReadDir(base)
+		if err != nil {
+			continue
+		}
+		for _, name := range names {
+			if !name.IsDir() {
+				continue
+			}
+			for _, file := range []string{"tls.crt", "ca.crt"} {
+				data, err := os.ReadFile(path.Join(base, name.Name(), file))
+				if err != nil {
+					continue
+				}
+				cert, err := certs.DecodeCertificate(data)
+				if err != nil {
+					continue
+				}
+				entries = append(entries, entry{
+					name:     path.Join(string(dir), name.Name()),
+					file:     file,
+					subject:  cert.Subject.CommonName,
+					notAfter: cert.NotAfter,
+				})
+			}
+		}
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].name != entries[j].name {
+			return entries[i].name < entries[j].name
+		}
+		return entries[i].file < entries[j].file
+	})
+	var buffer bytes.Buffer
+	tw := tabwriter.NewWriter(&buffer, 8, 8, 1, '\t', tabwriter.TabIndent)
+	fmt.Fprintln(tw, "NAME\tFILE\tSUBJECT\tNOT AFTER\tSTATUS")
+	for _, e := range entries {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", e.name, e.file, e.subject, e.notAfter.UTC().Format(time.RFC3339), expiryStatus(e.notAfter, time.Now()))
+	}
+	tw.Flush()
+	return buffer.Bytes()
+}
+
+func expiryStatus(notAfter time.Time, now time.Time) string {
+	if now.After(notAfter) {
+		return "expired"
+	}
+	return fmt.Sprintf("expires in %dd", int(notAfter.Sub(now).Hours()/24))
+}
+
+// writeServiceStatus adds the status of the systemd unit of the site,
+// along with an excerpt of its journal.
+func (cmd *CmdDebug) writeServiceStatus(prefix string, tw *tar.Writer) {
+	if api.IsRunningInContainer() {
+		return
+	}
+	unit := fmt.Sprintf("skupper-%s.service", cmd.Namespace)
+	var userFlag []string
+	if cmd.getUid() != 0 {
+		userFlag = []string{"--user"}
+	}
+	// systemctl status exits with a non-zero code for units not running
+	status, _ := cmd.runCommand("systemctl", append(userFlag, "status", "--no-pager", unit)...)
+	if len(status) > 0 {
+		writeTar(prefix+"systemd/"+unit+"-status.txt", status, time.Now(), tw)
+	}
+	journal, err := cmd.runCommand("journalctl", append(userFlag, "--no-pager", "-n", "2000", "-u", unit)...)
+	if err != nil {
+		cmd.collectionError("journal of "+unit, err)
+	} else {
+		writeTar(prefix+"logs/"+unit+".txt", journal, time.Now(), tw)
+	}
+}
+
+// writeContainer adds the details and logs of the router container of
+// the site.
+func (cmd *CmdDebug) writeContainer(prefix string, tw *tar.Writer) {
+	cli, err := cmd.containerClient(cmd.platform)
+	if err != nil {
+		cmd.collectionError("container client", err)
+		return
+	}
+	name := cmd.Namespace + "-skupper-router"
+	ct, err := cli.ContainerInspect(name)
+	if err != nil {
+		cmd.collectionError("container "+name, err)
+		return
+	}
+	if !cmd.includeSecrets() {
+		for key := range ct.Env {
+			ct.Env[key] = redacted
+		}
+	}
+	if encoded, err := yaml.Marshal(ct); err == nil {
+		writeTar(prefix+"resources/Container-"+name+".yaml", encoded, time.Now(), tw)
+	}
+	logs, err := cli.ContainerLogs(name)
+	if err != nil {
+		cmd.collectionError("logs of container "+name, err)
+		return
+	}
+	writeTar(prefix+"logs/"+name+".txt", []byte(logs), time.Now(), tw)
+}
+
+// writeRouterState adds the entities of the running router, queried
+// over its local management endpoint.
+func (cmd *CmdDebug) writeRouterState(prefix string, tw *tar.Writer) {
+	agent, err := cmd.connectRouter(cmd.Namespace)
+	if err != nil {
+		cmd.collectionError("router management", err)
+		return
+	}
+	defer agent.Close()
+	queries := []struct {
+		name  string
+		query func() (interface{}, error)
+	}{
+		{"router", func() (interface{}, error) { return agent.GetLocalRouter() }},
+		{"connections", func() (interface{}, error) { return agent.GetConnections() }},
+		{"listeners", func() (interface{}, error) { return agent.GetLocalListeners() }},
+		{"connectors", func() (interface{}, error) { return agent.GetLocalConnectors() }},
+		{"bridges", func() (interface{}, error) { return agent.GetLocalBridgeConfig() }},
+		{"tcp-connections", func() (interface{}, error) { return agent.GetLocalTcpConnections() }},
+	}
+	for _, q := range queries {
+		result, err := q.query()
+		if err != nil {
+			cmd.collectionError("router "+q.name, err)
+			continue
+		}
+		encoded, err := yaml.Marshal(result)
+		if err != nil {
+			cmd.collectionError("router "+q.name, err)
+			continue
+		}
+		writeTar(prefix+q.name+".yaml", encoded, time.Now(), tw)
+	}
+}
+
+func newContainerClient(platform string) (ContainerClient, error) {
+	endpoint := os.Getenv("CONTAINER_ENDPOINT")
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("unix://%s/podman/podman.sock", api.GetRuntimeDir())
+		if platform == string(types.PlatformDocker) {
+			endpoint = "unix:///run/docker.sock"
+		}
+	}
+	return compat.NewCompatClient(endpoint, "")
+}
+
+func connectLocalRouter(namespace string) (RouterManagement, error) {
+	url, err := runtime.GetLocalRouterAddress(namespace)
+	if err != nil {
+		return nil, err
+	}
+	return qdr.Connect(url, runtime.GetRuntimeTlsCert(namespace, "skupper-local-client"))
+}
+
+func runCommand(name string, args ...string) ([]byte, error) {
+	cmd := exec.Command(name, args...)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	err := cmd.Run()
+	if err != nil {
+		return out.Bytes(), err
+	}
+	return out.Bytes(), nil
+}
+
+// helper functions
+
+func writeTar(name string, data []byte, ts time.Time, tw *tar.Writer) error {
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    int64(len(data)),
+		ModTime: ts,
+	}
+	err := tw.WriteHeader(hdr)
+	if err != nil {
+		return fmt.Errorf("Failed to write tar file header: %w", err)
+	}
+	_, err = tw.Write(data)
+	if err != nil {
+		return fmt.Errorf("Failed to write to tar archive: %w", err)
+	}
+	return nil
+}
@@ -1 +1,206 @@
 package nonkube
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"gotest.tools/v3/assert"
+)
+
+const testSecret = `---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: my-secret
+data:
+  password: c2VjcmV0
+---
+apiVersion: v2alpha1
+kind: AccessToken
+metadata:
+  name: my-token
+spec:
+  code: abcdefgh
+  url: https://10.0.0.1:9090/12345
+`
+
+func setupNamespace(t *testing.T, namespace string) string {
+	if os.Getuid() == 0 {
+		api.DefaultRootDataHome = t.TempDir()
+	} else {
+		t.Setenv("XDG_DATA_HOME", t.TempDir())
+	}
+	home := api.GetHostNamespaceHome(namespace)
+	files := map[string]string{
+		filepath.Join(api.GetInternalOutputPath(namespace, api.InputSiteStatePath), "resources.yaml"):               testSecret,
+		filepath.Join(api.GetInternalOutputPath(namespace, api.RouterConfigPath), "skrouterd.json"):                 "[]",
+		filepath.Join(api.GetInternalOutputPath(namespace, api.CertificatesPath), "skupper-site-server", "tls.key"): "private",
+		filepath.Join(api.GetInternalOutputPath(namespace, api.InternalBasePath), "platform.yaml"):                  "platform: podman\n",
+	}
+	for file, content := range files {
+		assert.Assert(t, os.MkdirAll(filepath.Dir(file), 0755))
+		assert.Assert(t, os.WriteFile(file, []byte(content), 0644))
+	}
+	return home
+}
+
+func TestCmdDebug_ValidateInput(t *testing.T) {
+	type test struct {
+		name          string
+		namespace     string
+		args          []string
+		expectedError string
+	}
+
+	setupNamespace(t, "test")
+
+	testTable := []test{
+		{
+			name:          "too many args",
+			namespace:     "test",
+			args:          []string{"test", "not-valid"},
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:          "empty name",
+			namespace:     "test",
+			args:          []string{""},
+			expectedError: "filename must not be empty",
+		},
+		{
+			name:          "no site",
+			namespace:     "other",
+			args:          []string{"test"},
+			expectedError: "there is no site defined in namespace \"other\"",
+		},
+		{
+			name:      "ok",
+			namespace: "test",
+			args:      []string{"test"},
+		},
+		{
+			name:      "ok default name",
+			namespace: "test",
+			args:      []string{},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			cmd := NewCmdDebug()
+			cmd.Namespace = test.namespace
+			cmd.Flags = &common.CommandDebugFlags{}
+
+			testutils.CheckValidateInput(t, cmd, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdDebug_Run(t *testing.T) {
+	type test struct {
+		name           string
+		includeSecrets bool
+		expected       []string
+		unexpected     []string
+		redacted       bool
+	}
+
+	setupNamespace(t, "test")
+
+	testTable := []test{
+		{
+			name: "redacted",
+			expected: []string{
+				"/versions/skupper.yaml",
+				"/site-namespace/input/resources/resources.yaml",
+				"/site-namespace/runtime/router/skrouterd.json",
+				"/site-namespace/internal/platform.yaml",
+				"/site-namespace/collection-errors.txt",
+			},
+			unexpected: []string{
+				"/site-namespace/runtime/certs/skupper-site-server/tls.key",
+			},
+			redacted: true,
+		},
+		{
+			name:           "include secrets",
+			includeSecrets: true,
+			expected: []string{
+				"/site-namespace/input/resources/resources.yaml",
+				"/site-namespace/runtime/certs/skupper-site-server/tls.key",
+			},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			cmd := NewCmdDebug()
+			cmd.Namespace = "test"
+			cmd.fileName = filepath.Join(t.TempDir(), "dump")
+			cmd.platform = "podman"
+			cmd.Flags = &common.CommandDebugFlags{IncludeSecrets: test.includeSecrets}
+			cmd.runCommand = func(name string, args ...string) ([]byte, error) {
+				return []byte(name + " " + strings.Join(args, " ")), nil
+			}
+			cmd.containerClient = func(platform string) (ContainerClient, error) {
+				return nil, fmt.Errorf("no container engine")
+			}
+			cmd.connectRouter = func(namespace string) (RouterManagement, error) {
+				return nil, fmt.Errorf("router not running")
+			}
+
+			assert.Assert(t, cmd.Run())
+			contents := readArchive(t, cmd.fileName+".tar.gz")
+			for _, name := range test.expected {
+				_, ok := contents[name]
+				assert.Assert(t, ok, name)
+			}
+			for _, name := range test.unexpected {
+				_, ok := contents[name]
+				assert.Assert(t, !ok, name)
+			}
+			resources := contents["/site-namespace/input/resources/resources.yaml"]
+			assert.Equal(t, strings.Contains(resources, "c2VjcmV0"), !test.redacted)
+			assert.Equal(t, strings.Contains(resources, "abcdefgh"), !test.redacted)
+			assert.Assert(t, strings.Contains(resources, "https://10.0.0.1:9090/12345"))
+		})
+	}
+}
+
+func TestRedactYaml(t *testing.T) {
+	out := string(redactYaml([]byte(testSecret)))
+	assert.Assert(t, strings.Contains(out, "password: REDACTED"))
+	assert.Assert(t, strings.Contains(out, "code: REDACTED"))
+	assert.Assert(t, strings.Contains(out, "name: my-token"))
+	assert.Equal(t, strings.Count(out, "\n---"), strings.Count(testSecret, "\n---"))
+}
+
+func readArchive(t *testing.T, name string) map[string]string {
+	file, err := os.Open(name)
+	assert.Assert(t, err)
+	defer file.Close()
+	gz, err := gzip.NewReader(file)
+	assert.Assert(t, err)
+	tr := tar.NewReader(gz)
+	contents := map[string]string{}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		assert.Assert(t, err)
+		data, err := io.ReadAll(tr)
+		assert.Assert(t, err)
+		contents[hdr.Name] = string(data)
+	}
+	return contents
+}
@@ -0,0 +1,118 @@
+package generate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/skupperproject/skupper/internal/chart"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/lint"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/spf13/cobra"
+)
+
+type CmdGenerateChart struct {
+	CobraCmd  *cobra.Command
+	Flags     *common.CommandGenerateChartFlags
+	filenames []string
+	options   chart.Options
+	outputDir string
+}
+
+func newCmdGenerateChart() *CmdGenerateChart {
+	return &CmdGenerateChart{}
+}
+
+func (cmd *CmdGenerateChart) NewClient(cobraCommand *cobra.Command, args []string) {}
+
+func (cmd *CmdGenerateChart) ValidateInput(args []string) error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	chartTypeValidator := validator.NewOptionValidator(chart.Formats)
+
+	if len(args) > 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("this command does not need any arguments, use --%s", common.FlagNameFileName))
+	}
+	if cmd.Flags == nil || len(cmd.Flags.Filenames) == 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("at least one file or directory must be specified with --%s", common.FlagNameFileName))
+		return errors.Join(validationErrors...)
+	}
+	for _, filename := range cmd.Flags.Filenames {
+		if _, err := os.Stat(filename); err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("unable to read %s: %w", filename, err))
+		}
+	}
+	ok, err := chartTypeValidator.Evaluate(cmd.Flags.Type)
+	if !ok {
+		validationErrors = append(validationErrors, fmt.Errorf("chart type is not valid: %s", err))
+	}
+	if cmd.Flags.Name != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.Name)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("chart name is not valid: %s", err))
+		}
+	}
+	if cmd.Flags.ControllerConfig != "" {
+		if _, err := os.Stat(cmd.Flags.ControllerConfig); err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("unable to read %s: %w", cmd.Flags.ControllerConfig, err))
+		}
+	}
+	if cmd.Flags.OutputDir != "" {
+		if entries, err := os.ReadDir(cmd.Flags.OutputDir); err == nil && len(entries) > 0 {
+			validationErrors = append(validationErrors, fmt.Errorf("output directory %s is not empty", cmd.Flags.OutputDir))
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdGenerateChart) InputToOptions() {
+	cmd.filenames = cmd.Flags.Filenames
+	cmd.options = chart.Options{
+		Name:             cmd.Flags.Name,
+		Format:           chart.Format(cmd.Flags.Type),
+		ControllerConfig: cmd.Flags.ControllerConfig,
+	}
+	cmd.outputDir = cmd.Flags.OutputDir
+}
+
+func (cmd *CmdGenerateChart) Run() error {
+	bundle, findings, err := lint.Load(cmd.filenames...)
+	if err != nil {
+		return err
+	}
+	findings = append(findings, lint.Lint(bundle, string(common.PlatformKubernetes))...)
+	if lint.HasErrors(findings) {
+		var lintErrors []error
+		for _, finding := range findings {
+			if finding.Severity == lint.SeverityError {
+				lintErrors = append(lintErrors, fmt.Errorf("%s: %s", finding.File, finding.Message))
+			}
+		}
+		return fmt.Errorf("the resources are not valid, run skupper lint for details:\n%w", errors.Join(lintErrors...))
+	}
+
+	files, err := chart.Generate(bundle, cmd.options)
+	if err != nil {
+		return err
+	}
+	outputDir := cmd.outputDir
+	if outputDir == "" {
+		name := cmd.options.Name
+		if name == "" {
+			name = bundle.Sites[0].Object.Name
+		}
+		outputDir = fmt.Sprintf("%s-%s", name, cmd.options.Format)
+		if entries, err := os.ReadDir(outputDir); err == nil && len(entries) > 0 {
+			return fmt.Errorf("output directory %s is not empty", outputDir)
+		}
+	}
+	if err = files.Write(outputDir); err != nil {
+		return fmt.Errorf("unable to write chart: %w", err)
+	}
+	fmt.Printf("%s chart written to %s\n", cmd.options.Format, outputDir)
+	return nil
+}
+
+func (cmd *CmdGenerateChart) WaitUntil() error { return nil }
@@ -0,0 +1,160 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/chart"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"gotest.tools/v3/assert"
+)
+
+const siteResources = `apiVersion: skupper.io/v2alpha1
+kind: Site
+metadata:
+  name: west
+spec:
+  linkAccess: default
+---
+apiVersion: skupper.io/v2alpha1
+kind: Listener
+metadata:
+  name: backend
+spec:
+  routingKey: backend
+  host: backend
+  port: 8080
+`
+
+const invalidResources = `apiVersion: skupper.io/v2alpha1
+kind: Site
+metadata:
+  name: west
+---
+apiVersion: skupper.io/v2alpha1
+kind: Listener
+metadata:
+  name: backend
+spec:
+  host: backend
+  port: 8080
+`
+
+func TestCmdGenerateChart_ValidateInput(t *testing.T) {
+	type test struct {
+		name          string
+		args          []string
+		flags         *common.CommandGenerateChartFlags
+		expectedError string
+	}
+
+	dir := t.TempDir()
+	outputDir := t.TempDir()
+	assert.Assert(t, os.WriteFile(filepath.Join(outputDir, "Chart.yaml"), []byte("name: west\n"), 0644))
+
+	testTable := []test{
+		{
+			name:          "no file",
+			flags:         &common.CommandGenerateChartFlags{Type: "helm"},
+			expectedError: "at least one file or directory must be specified with --filename",
+		},
+		{
+			name:          "arguments are not accepted",
+			args:          []string{dir},
+			flags:         &common.CommandGenerateChartFlags{Filenames: []string{dir}, Type: "helm"},
+			expectedError: "this command does not need any arguments, use --filename",
+		},
+		{
+			name:          "file does not exist",
+			flags:         &common.CommandGenerateChartFlags{Filenames: []string{filepath.Join(dir, "missing.yaml")}, Type: "helm"},
+			expectedError: "unable to read " + filepath.Join(dir, "missing.yaml") + ": stat " + filepath.Join(dir, "missing.yaml") + ": no such file or directory",
+		},
+		{
+			name:          "invalid type",
+			flags:         &common.CommandGenerateChartFlags{Filenames: []string{dir}, Type: "ansible"},
+			expectedError: "chart type is not valid: value ansible not allowed. It should be one of this options: [helm kustomize]",
+		},
+		{
+			name:          "invalid name",
+			flags:         &common.CommandGenerateChartFlags{Filenames: []string{dir}, Type: "helm", Name: "My Chart"},
+			expectedError: "chart name is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])*(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])*)*$",
+		},
+		{
+			name:          "controller config does not exist",
+			flags:         &common.CommandGenerateChartFlags{Filenames: []string{dir}, Type: "helm", ControllerConfig: filepath.Join(dir, "config")},
+			expectedError: "unable to read " + filepath.Join(dir, "config") + ": stat " + filepath.Join(dir, "config") + ": no such file or directory",
+		},
+		{
+			name:          "output directory is not empty",
+			flags:         &common.CommandGenerateChartFlags{Filenames: []string{dir}, Type: "helm", OutputDir: outputDir},
+			expectedError: "output directory " + outputDir + " is not empty",
+		},
+		{
+			name:  "valid input",
+			flags: &common.CommandGenerateChartFlags{Filenames: []string{dir}, Type: "kustomize", Name: "west", OutputDir: filepath.Join(dir, "out")},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdGenerateChart{Flags: test.flags}
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdGenerateChart_Run(t *testing.T) {
+	type test struct {
+		name          string
+		content       string
+		format        chart.Format
+		expectedFiles []string
+		errorMessage  string
+	}
+
+	testTable := []test{
+		{
+			name:          "helm chart",
+			content:       siteResources,
+			format:        chart.FormatHelm,
+			expectedFiles: []string{"Chart.yaml", "values.yaml", "templates/site-west.yaml", "templates/listener-backend.yaml"},
+		},
+		{
+			name:          "kustomize base",
+			content:       siteResources,
+			format:        chart.FormatKustomize,
+			expectedFiles: []string{"base/kustomization.yaml", "base/site-west.yaml", "overlays/west/kustomization.yaml"},
+		},
+		{
+			name:         "invalid resources",
+			content:      invalidResources,
+			format:       chart.FormatHelm,
+			errorMessage: "the resources are not valid, run skupper lint for details:\n",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			fileName := filepath.Join(t.TempDir(), "site.yaml")
+			assert.Assert(t, os.WriteFile(fileName, []byte(test.content), 0644))
+			outputDir := filepath.Join(t.TempDir(), "chart")
+			command := &CmdGenerateChart{
+				filenames: []string{fileName},
+				options:   chart.Options{Format: test.format},
+				outputDir: outputDir,
+			}
+			err := command.Run()
+			if test.errorMessage != "" {
+				assert.ErrorContains(t, err, test.errorMessage)
+				return
+			}
+			assert.Assert(t, err)
+			for _, name := range test.expectedFiles {
+				_, err := os.Stat(filepath.Join(outputDir, filepath.FromSlash(name)))
+				assert.Assert(t, err, name)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package generate
+
+import (
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdGenerate() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "generate",
+		Short: "Generate deployable artifacts from custom resources",
+		Long: `Generate artifacts, such as Helm charts or Kustomize bases, from the custom
+resources produced by the generate subcommands of site, listener, connector and link.`,
+		Example: `skupper generate chart -f ./west --type helm`,
+	}
+	platform := common.Platform(config.GetPlatform())
+	cmd.AddCommand(CmdGenerateChartFactory(platform))
+
+	return cmd
+}
+
+func CmdGenerateChartFactory(configuredPlatform common.Platform) *cobra.Command {
+	// charts are deployed to kubernetes, the configured platform does not
+	// change the generated files
+	chartCommand := newCmdGenerateChart()
+
+	cmdGenerateChartDesc := common.SkupperCmdDescription{
+		Use:   "chart",
+		Short: "Generate a Helm chart or a Kustomize base for a site",
+		Long: `Generate a parameterised Helm chart or Kustomize base from the resources of a site.
+The site name, namespace, link access type and the routing keys and ports of
+listeners and connectors become values, so that the same topology can be rolled
+out to many clusters. With Kustomize, an overlay holding the values is generated
+next to the base.`,
+		Example: `skupper site generate west --enable-link-access > west/site.yaml
+skupper listener generate backend 8080 > west/listener.yaml
+skupper generate chart -f ./west --controller-config ./config
+skupper generate chart -f ./west --type kustomize --output-dir ./deploy`,
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdGenerateChartDesc, chartCommand, chartCommand)
+
+	cmdFlags := common.CommandGenerateChartFlags{}
+	cmd.Flags().StringSliceVarP(&cmdFlags.Filenames, common.FlagNameFileName, "f", []string{}, common.FlagDescChartFileName)
+	cmd.Flags().StringVar(&cmdFlags.Type, common.FlagNameType, "helm", common.FlagDescChartType)
+	cmd.Flags().StringVar(&cmdFlags.Name, common.FlagNameChartName, "", common.FlagDescChartName)
+	cmd.Flags().StringVar(&cmdFlags.OutputDir, common.FlagNameOutputDir, "", common.FlagDescOutputDir)
+	cmd.Flags().StringVar(&cmdFlags.ControllerConfig, common.FlagNameControllerConfig, "", common.FlagDescControllerConfig)
+
+	chartCommand.CobraCmd = cmd
+	chartCommand.Flags = &cmdFlags
+
+	return cmd
+}
@@ -0,0 +1,67 @@
+package generate
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gotest.tools/v3/assert"
+)
+
+func TestCmdGenerateChartFactory(t *testing.T) {
+
+	type test struct {
+		name                          string
+		expectedFlagsWithDefaultValue map[string]interface{}
+		command                       *cobra.Command
+	}
+
+	testTable := []test{
+		{
+			name: "CmdGenerateChartFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameFileName:         "[]",
+				common.FlagNameType:             "helm",
+				common.FlagNameChartName:        "",
+				common.FlagNameOutputDir:        "",
+				common.FlagNameControllerConfig: "",
+			},
+			command: CmdGenerateChartFactory(common.PlatformKubernetes),
+		},
+		{
+			name: "CmdGenerateChartFactoryNonKube",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameFileName:         "[]",
+				common.FlagNameType:             "helm",
+				common.FlagNameChartName:        "",
+				common.FlagNameOutputDir:        "",
+				common.FlagNameControllerConfig: "",
+			},
+			command: CmdGenerateChartFactory(common.PlatformPodman),
+		},
+	}
+
+	for _, test := range testTable {
+
+		var flagList []string
+		t.Run(test.name, func(t *testing.T) {
+
+			test.command.Flags().VisitAll(func(flag *pflag.Flag) {
+				flagList = append(flagList, flag.Name)
+				assert.Check(t, test.expectedFlagsWithDefaultValue[flag.Name] != nil, fmt.Sprintf("flag %q not expected", flag.Name))
+				assert.Check(t, test.expectedFlagsWithDefaultValue[flag.Name] == flag.DefValue, fmt.Sprintf("default value %q for flag %q not expected", flag.DefValue, flag.Name))
+			})
+
+			assert.Check(t, len(flagList) == len(test.expectedFlagsWithDefaultValue))
+
+			assert.Assert(t, test.command.PreRunE != nil)
+			assert.Assert(t, test.command.Run != nil)
+			assert.Assert(t, test.command.PostRun != nil)
+			assert.Assert(t, test.command.Use != "")
+			assert.Assert(t, test.command.Short != "")
+			assert.Assert(t, test.command.Long != "")
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/config"
+	"github.com/skupperproject/skupper/internal/lint"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/spf13/cobra"
+)
+
+type CmdLint struct {
+	CobraCmd  *cobra.Command
+	Flags     *common.CommandLintFlags
+	platform  string
+	filenames []string
+	output    string
+}
+
+func newCmdLint() *CmdLint {
+	return &CmdLint{}
+}
+
+func (cmd *CmdLint) NewClient(cobraCommand *cobra.Command, args []string) {
+	cmd.platform = string(config.GetPlatform())
+	if cobraCommand != nil && cobraCommand.Flag(common.FlagNamePlatform) != nil && cobraCommand.Flag(common.FlagNamePlatform).Value.String() != "" {
+		cmd.platform = cobraCommand.Flag(common.FlagNamePlatform).Value.String()
+	}
+}
+
+func (cmd *CmdLint) ValidateInput(args []string) error {
+	var validationErrors []error
+	outputTypeValidator := validator.NewOptionValidator(common.OutputTypes)
+
+	if len(args) > 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("this command does not need any arguments, use --%s", common.FlagNameFileName))
+	}
+	if cmd.Flags == nil || len(cmd.Flags.Filenames) == 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("at least one file or directory must be specified with --%s", common.FlagNameFileName))
+	} else {
+		for _, filename := range cmd.Flags.Filenames {
+			if _, err := os.Stat(filename); err != nil {
+				validationErrors = append(validationErrors, fmt.Errorf("unable to read %s: %w", filename, err))
+			}
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdLint) InputToOptions() {
+	cmd.filenames = cmd.Flags.Filenames
+	cmd.output = cmd.Flags.Output
+}
+
+type lintResult struct {
+	Platform string         `json:"platform"`
+	Errors   int            `json:"errors"`
+	Warnings int            `json:"warnings"`
+	Findings []lint.Finding `json:"findings"`
+}
+
+func (cmd *CmdLint) Run() error {
+	bundle, findings, err := lint.Load(cmd.filenames...)
+	if err != nil {
+		return err
+	}
+	findings = append(findings, lint.Lint(bundle, cmd.platform)...)
+
+	result := lintResult{
+		Platform: cmd.platform,
+		Findings: findings,
+	}
+	for _, finding := range findings {
+		if finding.Severity == lint.SeverityError {
+			result.Errors++
+		} else {
+			result.Warnings++
+		}
+	}
+
+	if cmd.output != "" {
+		encodedOutput, err := utils.Encode(cmd.output, result)
+		if err != nil {
+			return err
+		}
+		fmt.Println(encodedOutput)
+	} else if len(findings) > 0 {
+		tw := tabwriter.NewWriter(os.Stdout, 8, 8, 1, '\t', tabwriter.TabIndent)
+		_, _ = fmt.Fprintln(tw, fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s",
+			"SEVERITY", "RULE", "FILE", "KIND", "NAME", "MESSAGE"))
+		for _, finding := range findings {
+			name := finding.Name
+			if finding.Namespace != "" && name != "" {
+				name = finding.Namespace + "/" + name
+			} else if finding.Namespace != "" {
+				name = finding.Namespace
+			}
+			_, _ = fmt.Fprintln(tw, fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s",
+				finding.Severity, finding.Rule, finding.File, finding.Kind, name, finding.Message))
+		}
+		_ = tw.Flush()
+		fmt.Printf("\n%d error(s), %d warning(s) found for platform %s\n", result.Errors, result.Warnings, cmd.platform)
+	} else {
+		fmt.Printf("No issues found for platform %s\n", cmd.platform)
+	}
+
+	if result.Errors > 0 {
+		return fmt.Errorf("lint failed with %d error(s)", result.Errors)
+	}
+	return nil
+}
+
+func (cmd *CmdLint) WaitUntil() error { return nil }
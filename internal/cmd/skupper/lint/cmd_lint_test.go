@@ -0,0 +1,152 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"gotest.tools/v3/assert"
+)
+
+const validBundle = `apiVersion: skupper.io/v2alpha1
+kind: Site
+metadata:
+  name: west
+---
+apiVersion: skupper.io/v2alpha1
+kind: Listener
+metadata:
+  name: backend
+spec:
+  routingKey: backend
+  host: backend
+  port: 8080
+---
+apiVersion: skupper.io/v2alpha1
+kind: Connector
+metadata:
+  name: backend
+spec:
+  routingKey: backend
+  host: backend.local
+  port: 8080
+`
+
+const invalidBundle = `apiVersion: skupper.io/v2alpha1
+kind: Site
+metadata:
+  name: west
+---
+apiVersion: skupper.io/v2alpha1
+kind: Link
+metadata:
+  name: to-east
+spec:
+  tlsCredentials: to-east
+  endpoints:
+  - name: inter-router
+    host: 10.0.0.1
+    port: "55671"
+`
+
+func TestCmdLint_ValidateInput(t *testing.T) {
+	type test struct {
+		name          string
+		args          []string
+		flags         *common.CommandLintFlags
+		expectedError string
+	}
+
+	dir := t.TempDir()
+
+	testTable := []test{
+		{
+			name:          "no file",
+			flags:         &common.CommandLintFlags{},
+			expectedError: "at least one file or directory must be specified with --filename",
+		},
+		{
+			name:          "arguments are not accepted",
+			args:          []string{dir},
+			flags:         &common.CommandLintFlags{Filenames: []string{dir}},
+			expectedError: "this command does not need any arguments, use --filename",
+		},
+		{
+			name:          "file does not exist",
+			flags:         &common.CommandLintFlags{Filenames: []string{filepath.Join(dir, "missing.yaml")}},
+			expectedError: "unable to read " + filepath.Join(dir, "missing.yaml") + ": stat " + filepath.Join(dir, "missing.yaml") + ": no such file or directory",
+		},
+		{
+			name:          "invalid output",
+			flags:         &common.CommandLintFlags{Filenames: []string{dir}, Output: "dot"},
+			expectedError: "output type is not valid: value dot not allowed. It should be one of this options: [json yaml]",
+		},
+		{
+			name:  "valid input",
+			flags: &common.CommandLintFlags{Filenames: []string{dir}, Output: "json"},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdLint{Flags: test.flags}
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdLint_Run(t *testing.T) {
+	type test struct {
+		name         string
+		platform     string
+		content      string
+		output       string
+		errorMessage string
+	}
+
+	testTable := []test{
+		{
+			name:     "valid bundle",
+			platform: "podman",
+			content:  validBundle,
+		},
+		{
+			name:     "valid bundle as json",
+			platform: "kubernetes",
+			content:  validBundle,
+			output:   "json",
+		},
+		{
+			name:         "missing link secret",
+			platform:     "podman",
+			content:      invalidBundle,
+			errorMessage: "lint failed with 1 error(s)",
+		},
+		{
+			name:     "missing link secret on kubernetes is a warning",
+			platform: "kubernetes",
+			content:  invalidBundle,
+			output:   "yaml",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			fileName := filepath.Join(t.TempDir(), "bundle.yaml")
+			assert.Assert(t, os.WriteFile(fileName, []byte(test.content), 0644))
+			command := &CmdLint{
+				platform:  test.platform,
+				filenames: []string{fileName},
+				output:    test.output,
+			}
+			err := command.Run()
+			if test.errorMessage != "" {
+				assert.Error(t, err, test.errorMessage)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package lint
+
+import (
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdLint() *cobra.Command {
+
+	platform := common.Platform(config.GetPlatform())
+	cmd := CmdLintFactory(platform)
+
+	return cmd
+}
+
+func CmdLintFactory(configuredPlatform common.Platform) *cobra.Command {
+	// linting does not need access to the cluster or to the site, the
+	// target platform only selects the checks to run
+	lintCommand := newCmdLint()
+
+	cmdLintDesc := common.SkupperCmdDescription{
+		Use:   "lint",
+		Short: "Validate custom resources offline",
+		Long: `Validate a set of custom resources without a cluster or a running site, running the
+checks of the target platform and cross-checking the references between resources.
+Findings are printed as a table or, for continuous integration, as json or yaml.
+The command fails if any error is found.`,
+		Example: `skupper lint -f ./resources
+skupper lint -f site.yaml -f listeners.yaml -p podman -o json`,
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdLintDesc, lintCommand, lintCommand)
+
+	cmdFlags := common.CommandLintFlags{}
+	cmd.Flags().StringSliceVarP(&cmdFlags.Filenames, common.FlagNameFileName, "f", []string{}, common.FlagDescLintFileName)
+	cmd.Flags().StringVarP(&cmdFlags.Output, common.FlagNameOutput, "o", "", common.FlagDescLintOutput)
+
+	lintCommand.CobraCmd = cmd
+	lintCommand.Flags = &cmdFlags
+
+	return cmd
+}
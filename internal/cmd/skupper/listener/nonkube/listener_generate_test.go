@@ -70,7 +70,7 @@ func TestNonKubeCmdListenerGenerate_ValidateInput(t *testing.T) {
 			name:          "type is not valid",
 			args:          []string{"my-listener", "8080"},
 			flags:         &common.CommandListenerGenerateFlags{ListenerType: "not-valid", Host: "1.2.3.4"},
-			expectedError: "listener type is not valid: value not-valid not allowed. It should be one of this options: [tcp]",
+			expectedError: "listener type is not valid: value not-valid not allowed. It should be one of this options: [tcp http http2 udp]",
 		},
 		{
 			name:          "routing key is not valid",
@@ -68,7 +68,7 @@ func TestCmdListenerUpdate_ValidateInput(t *testing.T) {
 			name:          "listener type is not valid",
 			args:          []string{"my-listener"},
 			flags:         &common.CommandListenerUpdateFlags{ListenerType: "not-valid"},
-			expectedError: "listener type is not valid: value not-valid not allowed. It should be one of this options: [tcp]",
+			expectedError: "listener type is not valid: value not-valid not allowed. It should be one of this options: [tcp http http2 udp]",
 		},
 		{
 			name:          "routing key is not valid",
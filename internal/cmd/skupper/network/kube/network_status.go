@@ -0,0 +1,97 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/skupperproject/skupper/api/types"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/kube/client"
+	"github.com/skupperproject/skupper/internal/network"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/spf13/cobra"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type CmdNetworkStatus struct {
+	KubeClient kubernetes.Interface
+	CobraCmd   *cobra.Command
+	Flags      *common.CommandNetworkStatusFlags
+	Namespace  string
+	output     string
+}
+
+func NewCmdNetworkStatus() *CmdNetworkStatus {
+	return &CmdNetworkStatus{}
+}
+
+func (cmd *CmdNetworkStatus) NewClient(cobraCommand *cobra.Command, args []string) {
+	cli, err := client.NewClient(cobraCommand.Flag("namespace").Value.String(), cobraCommand.Flag("context").Value.String(), cobraCommand.Flag("kubeconfig").Value.String())
+	utils.HandleError(utils.GenericError, err)
+
+	cmd.KubeClient = cli.GetKubeClient()
+	cmd.Namespace = cli.Namespace
+}
+
+func (cmd *CmdNetworkStatus) ValidateInput(args []string) error {
+	var validationErrors []error
+	outputTypeValidator := validator.NewOptionValidator(common.NetworkStatusOutputTypes)
+
+	if len(args) > 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("this command does not need any arguments"))
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		} else {
+			cmd.output = cmd.Flags.Output
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdNetworkStatus) InputToOptions() {}
+
+func (cmd *CmdNetworkStatus) Run() error {
+	cm, err := cmd.KubeClient.CoreV1().ConfigMaps(cmd.Namespace).Get(context.TODO(), types.NetworkStatusConfigMapName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return fmt.Errorf("there is no network status available in namespace %q, make sure there is an active site", cmd.Namespace)
+	} else if err != nil {
+		return err
+	}
+	if cm.Data["NetworkStatus"] == "" {
+		fmt.Println("There is no network status available yet")
+		return nil
+	}
+	status, err := network.UnmarshalSkupperStatus(cm.Data)
+	if err != nil {
+		return fmt.Errorf("could not read the network status: %s", err)
+	} else if status == nil {
+		status = &network.NetworkStatusInfo{}
+	}
+
+	summary := network.Summarize(*status)
+	switch cmd.output {
+	case "":
+		return summary.WriteTable(os.Stdout)
+	case "dot":
+		return summary.WriteDot(os.Stdout)
+	default:
+		encodedOutput, err := utils.Encode(cmd.output, summary)
+		if err != nil {
+			return err
+		}
+		fmt.Println(encodedOutput)
+	}
+	return nil
+}
+
+func (cmd *CmdNetworkStatus) WaitUntil() error { return nil }
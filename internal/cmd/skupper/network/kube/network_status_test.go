@@ -0,0 +1,149 @@
+package kube
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/skupperproject/skupper/api/types"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/internal/network"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdNetworkStatus_ValidateInput(t *testing.T) {
+	type test struct {
+		name          string
+		args          []string
+		flags         *common.CommandNetworkStatusFlags
+		expectedError string
+	}
+
+	testTable := []test{
+		{
+			name:          "arguments are not accepted",
+			args:          []string{"my-site"},
+			expectedError: "this command does not need any arguments",
+		},
+		{
+			name:          "bad output",
+			flags:         &common.CommandNetworkStatusFlags{Output: "svg"},
+			expectedError: "output type is not valid: value svg not allowed. It should be one of this options: [json yaml dot]",
+		},
+		{
+			name:          "dot output",
+			flags:         &common.CommandNetworkStatusFlags{Output: "dot"},
+			expectedError: "",
+		},
+		{
+			name:          "no flags",
+			expectedError: "",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command, err := newCmdNetworkStatusWithMocks("test", nil)
+			assert.Assert(t, err)
+			command.Flags = test.flags
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdNetworkStatus_Run(t *testing.T) {
+	type test struct {
+		name          string
+		output        string
+		k8sObjects    []runtime.Object
+		expectedError string
+	}
+
+	status, err := json.Marshal(network.NetworkStatusInfo{
+		SiteStatus: []network.SiteStatusInfo{
+			{
+				Site: network.SiteInfo{Identity: "site-id", Name: "my-site", Namespace: "test"},
+				RouterStatus: []network.RouterStatusInfo{
+					{
+						Listeners: []network.ListenerInfo{{Name: "backend", Address: "backend", DestPort: "8080"}},
+					},
+				},
+			},
+		},
+	})
+	assert.Assert(t, err)
+	networkStatus := func(data map[string]string) []runtime.Object {
+		return []runtime.Object{
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      types.NetworkStatusConfigMapName,
+					Namespace: "test",
+				},
+				Data: data,
+			},
+		}
+	}
+
+	testTable := []test{
+		{
+			name:          "no network status",
+			expectedError: "there is no network status available in namespace \"test\", make sure there is an active site",
+		},
+		{
+			name:       "network status not collected yet",
+			k8sObjects: networkStatus(nil),
+		},
+		{
+			name:          "network status is not valid",
+			k8sObjects:    networkStatus(map[string]string{"NetworkStatus": "{"}),
+			expectedError: "could not read the network status: unexpected end of JSON input",
+		},
+		{
+			name:       "table",
+			k8sObjects: networkStatus(map[string]string{"NetworkStatus": string(status)}),
+		},
+		{
+			name:       "yaml",
+			output:     "yaml",
+			k8sObjects: networkStatus(map[string]string{"NetworkStatus": string(status)}),
+		},
+		{
+			name:       "dot",
+			output:     "dot",
+			k8sObjects: networkStatus(map[string]string{"NetworkStatus": string(status)}),
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command, err := newCmdNetworkStatusWithMocks("test", test.k8sObjects)
+			assert.Assert(t, err)
+			command.output = test.output
+
+			err = command.Run()
+			if test.expectedError != "" {
+				assert.Error(t, err, test.expectedError)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdNetworkStatusWithMocks(namespace string, k8sObjects []runtime.Object) (*CmdNetworkStatus, error) {
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	return &CmdNetworkStatus{
+		KubeClient: client.GetKubeClient(),
+		Namespace:  namespace,
+	}, nil
+}
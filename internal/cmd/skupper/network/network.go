@@ -0,0 +1,50 @@
+package network
+
+import (
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/network/kube"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/network/nonkube"
+	"github.com/skupperproject/skupper/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdNetwork() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "network",
+		Short: "Inspect the application network the site belongs to",
+		Long:  `The application network is formed by all the sites linked together, directly or through other sites.`,
+		Example: `skupper network status
+skupper network status -o dot | dot -Tsvg > network.svg`,
+	}
+	platform := common.Platform(config.GetPlatform())
+	cmd.AddCommand(CmdNetworkStatusFactory(platform))
+
+	return cmd
+}
+
+func CmdNetworkStatusFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdNetworkStatus()
+	nonKubeCommand := nonkube.NewCmdNetworkStatus()
+
+	cmdNetworkStatusDesc := common.SkupperCmdDescription{
+		Use:   "status",
+		Short: "Get the status of the application network",
+		Long: `Display the sites of the application network, the links between them
+and the listeners and connectors of each routing key, reporting mismatches
+such as listeners without connectors.`,
+		Example: `skupper network status
+skupper network status -o yaml`,
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdNetworkStatusDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandNetworkStatusFlags{}
+	cmd.Flags().StringVarP(&cmdFlags.Output, common.FlagNameOutput, "o", "", common.FlagDescNetworkStatusOutput)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
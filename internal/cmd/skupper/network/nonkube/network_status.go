@@ -0,0 +1,93 @@
+package nonkube
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/skupperproject/skupper/api/types"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/network"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/spf13/cobra"
+)
+
+type CmdNetworkStatus struct {
+	configMapHandler *fs.ConfigMapHandler
+	CobraCmd         *cobra.Command
+	Flags            *common.CommandNetworkStatusFlags
+	namespace        string
+	output           string
+}
+
+func NewCmdNetworkStatus() *CmdNetworkStatus {
+	return &CmdNetworkStatus{}
+}
+
+func (cmd *CmdNetworkStatus) NewClient(cobraCommand *cobra.Command, args []string) {
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace) != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String() != "" {
+		cmd.namespace = cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String()
+	}
+
+	cmd.configMapHandler = fs.NewConfigMapHandler(cmd.namespace)
+}
+
+func (cmd *CmdNetworkStatus) ValidateInput(args []string) error {
+	var validationErrors []error
+	outputTypeValidator := validator.NewOptionValidator(common.NetworkStatusOutputTypes)
+
+	if len(args) > 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("this command does not need any arguments"))
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		} else {
+			cmd.output = cmd.Flags.Output
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdNetworkStatus) InputToOptions() {}
+
+func (cmd *CmdNetworkStatus) Run() error {
+	// the network status is only written to the runtime directory by the
+	// system controller of an active site
+	cm, err := cmd.configMapHandler.Get(types.NetworkStatusConfigMapName, fs.GetOptions{RuntimeFirst: true})
+	if err != nil || cm == nil {
+		return fmt.Errorf("there is no network status available in namespace %q, make sure there is an active site", cmd.namespace)
+	}
+	if cm.Data["NetworkStatus"] == "" {
+		fmt.Println("There is no network status available yet")
+		return nil
+	}
+	status, err := network.UnmarshalSkupperStatus(cm.Data)
+	if err != nil {
+		return fmt.Errorf("could not read the network status: %s", err)
+	} else if status == nil {
+		status = &network.NetworkStatusInfo{}
+	}
+
+	summary := network.Summarize(*status)
+	switch cmd.output {
+	case "":
+		return summary.WriteTable(os.Stdout)
+	case "dot":
+		return summary.WriteDot(os.Stdout)
+	default:
+		encodedOutput, err := utils.Encode(cmd.output, summary)
+		if err != nil {
+			return err
+		}
+		fmt.Println(encodedOutput)
+	}
+	return nil
+}
+
+func (cmd *CmdNetworkStatus) WaitUntil() error { return nil }
@@ -0,0 +1,102 @@
+package nonkube
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/skupperproject/skupper/api/types"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/network"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCmdNetworkStatus_ValidateInput(t *testing.T) {
+	type test struct {
+		name          string
+		args          []string
+		flags         *common.CommandNetworkStatusFlags
+		expectedError string
+	}
+
+	testTable := []test{
+		{
+			name:          "arguments are not accepted",
+			args:          []string{"my-site"},
+			expectedError: "this command does not need any arguments",
+		},
+		{
+			name:          "bad output",
+			flags:         &common.CommandNetworkStatusFlags{Output: "svg"},
+			expectedError: "output type is not valid: value svg not allowed. It should be one of this options: [json yaml dot]",
+		},
+		{
+			name:          "json output",
+			flags:         &common.CommandNetworkStatusFlags{Output: "json"},
+			expectedError: "",
+		},
+		{
+			name:          "no flags",
+			expectedError: "",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdNetworkStatus{Flags: test.flags}
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdNetworkStatus_Run(t *testing.T) {
+	if os.Getuid() == 0 {
+		api.DefaultRootDataHome = t.TempDir()
+	} else {
+		t.Setenv("XDG_DATA_HOME", t.TempDir())
+	}
+	command := &CmdNetworkStatus{namespace: "test"}
+	command.NewClient(nil, nil)
+
+	err := command.Run()
+	assert.Error(t, err, "there is no network status available in namespace \"test\", make sure there is an active site")
+
+	status, err := json.Marshal(network.NetworkStatusInfo{
+		SiteStatus: []network.SiteStatusInfo{
+			{
+				Site: network.SiteInfo{Identity: "site-id", Name: "my-site", Namespace: "test"},
+				RouterStatus: []network.RouterStatusInfo{
+					{
+						Connectors: []network.ConnectorInfo{{Address: "backend", DestHost: "127.0.0.1", DestPort: "8080"}},
+					},
+				},
+			},
+		},
+	})
+	assert.Assert(t, err)
+	cm := corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      types.NetworkStatusConfigMapName,
+			Namespace: "test",
+		},
+	}
+	handler := fs.NewConfigMapHandler("test")
+	assert.Assert(t, handler.Add(cm, true))
+	assert.Assert(t, command.Run())
+
+	cm.Data = map[string]string{"NetworkStatus": string(status)}
+	assert.Assert(t, handler.Add(cm, true))
+	for _, output := range []string{"", "json", "yaml", "dot"} {
+		command.output = output
+		assert.Assert(t, command.Run())
+	}
+}
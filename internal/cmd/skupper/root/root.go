@@ -1,11 +1,19 @@
 package root
 
 import (
+	"github.com/skupperproject/skupper/internal/cmd/skupper/accessgrant"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/attachedconnector"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/certificate"
 	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
 	"github.com/skupperproject/skupper/internal/cmd/skupper/connector"
 	"github.com/skupperproject/skupper/internal/cmd/skupper/debug"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/generate"
 	"github.com/skupperproject/skupper/internal/cmd/skupper/link"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/lint"
 	"github.com/skupperproject/skupper/internal/cmd/skupper/listener"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/network"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/router"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/routeraccess"
 	"github.com/skupperproject/skupper/internal/cmd/skupper/site"
 	"github.com/skupperproject/skupper/internal/cmd/skupper/system"
 	"github.com/skupperproject/skupper/internal/cmd/skupper/token"
@@ -32,9 +40,18 @@ func NewSkupperRootCommand() *cobra.Command {
 	rootCmd.AddCommand(listener.NewCmdListener())
 	rootCmd.AddCommand(link.NewCmdLink())
 	rootCmd.AddCommand(connector.NewCmdConnector())
+	rootCmd.AddCommand(attachedconnector.NewCmdAttachedConnector())
+	rootCmd.AddCommand(attachedconnector.NewCmdAttachedConnectorBinding())
+	rootCmd.AddCommand(accessgrant.NewCmdAccessGrant())
+	rootCmd.AddCommand(routeraccess.NewCmdRouterAccess())
+	rootCmd.AddCommand(certificate.NewCmdCertificate())
 	rootCmd.AddCommand(version.NewCmdVersion())
 	rootCmd.AddCommand(debug.NewCmdDebug())
 	rootCmd.AddCommand(system.NewCmdSystem())
+	rootCmd.AddCommand(network.NewCmdNetwork())
+	rootCmd.AddCommand(router.NewCmdRouter())
+	rootCmd.AddCommand(lint.NewCmdLint())
+	rootCmd.AddCommand(generate.NewCmdGenerate())
 
 	rootCmd.SetHelpCommand(&cobra.Command{Hidden: true})
 
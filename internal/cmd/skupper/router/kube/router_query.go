@@ -0,0 +1,183 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/kube/client"
+	"github.com/skupperproject/skupper/internal/qdr"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+)
+
+// The router only accepts unauthenticated management connections on
+// this port from within its pod, which is where port forwarding
+// connects to.
+const routerManagementPort = 5672
+
+type RouterAgent interface {
+	utils.RouterStateReader
+	Close() error
+}
+
+type CmdRouterQuery struct {
+	KubeClient    kubernetes.Interface
+	Rest          *restclient.Config
+	CobraCmd      *cobra.Command
+	Flags         *common.CommandRouterFlags
+	Namespace     string
+	query         string
+	output        string
+	pod           string
+	connectRouter func(pod string) (RouterAgent, error)
+}
+
+func NewCmdRouterQuery(query string) *CmdRouterQuery {
+	cmd := &CmdRouterQuery{
+		query: query,
+	}
+	cmd.connectRouter = cmd.portForward
+	return cmd
+}
+
+func (cmd *CmdRouterQuery) NewClient(cobraCommand *cobra.Command, args []string) {
+	cli, err := client.NewClient(cobraCommand.Flag("namespace").Value.String(), cobraCommand.Flag("context").Value.String(), cobraCommand.Flag("kubeconfig").Value.String())
+	utils.HandleError(utils.GenericError, err)
+
+	cmd.KubeClient = cli.GetKubeClient()
+	cmd.Rest = cli.Rest
+	cmd.Namespace = cli.Namespace
+}
+
+func (cmd *CmdRouterQuery) ValidateInput(args []string) error {
+	var validationErrors []error
+	outputTypeValidator := validator.NewOptionValidator(common.OutputTypes)
+
+	if len(args) > 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("this command does not need any arguments"))
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		} else {
+			cmd.output = cmd.Flags.Output
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Watch && cmd.Flags.Interval <= 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("interval must be greater than zero"))
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Pod != "" {
+		pod, err := cmd.KubeClient.CoreV1().Pods(cmd.Namespace).Get(context.TODO(), cmd.Flags.Pod, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			validationErrors = append(validationErrors, fmt.Errorf("pod %q does not exist in namespace %q", cmd.Flags.Pod, cmd.Namespace))
+		} else if err != nil {
+			validationErrors = append(validationErrors, err)
+		} else if pod.Labels["skupper.io/component"] != "router" {
+			validationErrors = append(validationErrors, fmt.Errorf("pod %q is not a router pod", cmd.Flags.Pod))
+		} else {
+			cmd.pod = pod.Name
+		}
+	} else {
+		pod, err := cmd.readyRouterPod()
+		if err != nil {
+			validationErrors = append(validationErrors, err)
+		} else {
+			cmd.pod = pod
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdRouterQuery) InputToOptions() {}
+
+func (cmd *CmdRouterQuery) Run() error {
+	agent, err := cmd.connectRouter(cmd.pod)
+	if err != nil {
+		return fmt.Errorf("could not connect to router %s: %s", cmd.pod, err)
+	}
+	defer agent.Close()
+
+	if cmd.Flags != nil && cmd.Flags.Watch {
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer cancel()
+		return utils.WatchRouterState(ctx, os.Stdout, agent, cmd.query, cmd.output, cmd.Flags.Interval)
+	}
+	state, err := utils.QueryRouterState(agent, cmd.query)
+	if err != nil {
+		return err
+	}
+	return utils.WriteRouterState(os.Stdout, cmd.query, cmd.output, state)
+}
+
+func (cmd *CmdRouterQuery) WaitUntil() error { return nil }
+
+// readyRouterPod returns the first ready router pod of the site,
+// by name.
+func (cmd *CmdRouterQuery) readyRouterPod() (string, error) {
+	pods, err := cmd.KubeClient.CoreV1().Pods(cmd.Namespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: "skupper.io/component=router",
+	})
+	if err != nil {
+		return "", err
+	}
+	var ready []string
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
+				ready = append(ready, pod.Name)
+			}
+		}
+	}
+	if len(ready) == 0 {
+		return "", fmt.Errorf("there are no ready router pods in namespace %q, make sure there is an active site", cmd.Namespace)
+	}
+	sort.Strings(ready)
+	return ready[0], nil
+}
+
+type forwardedAgent struct {
+	*qdr.Agent
+	stop chan struct{}
+}
+
+func (a *forwardedAgent) Close() error {
+	defer close(a.stop)
+	return a.Agent.Close()
+}
+
+func (cmd *CmdRouterQuery) portForward(pod string) (RouterAgent, error) {
+	stop := make(chan struct{})
+	port, err := client.PortForward(pod, cmd.Namespace, routerManagementPort, cmd.Rest, stop)
+	if err != nil {
+		close(stop)
+		return nil, err
+	}
+	agent, err := qdr.Connect(fmt.Sprintf("amqp://127.0.0.1:%d", port), nil)
+	if err != nil {
+		if agent != nil {
+			agent.Close()
+		}
+		close(stop)
+		return nil, err
+	}
+	return &forwardedAgent{Agent: agent, stop: stop}, nil
+}
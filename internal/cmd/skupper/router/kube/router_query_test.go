@@ -0,0 +1,221 @@
+package kube
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/internal/qdr"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdRouterQuery_ValidateInput(t *testing.T) {
+	type test struct {
+		name          string
+		args          []string
+		flags         *common.CommandRouterFlags
+		k8sObjects    []runtime.Object
+		expectedError string
+		expectedPod   string
+	}
+
+	testTable := []test{
+		{
+			name:          "arguments are not accepted",
+			args:          []string{"my-router"},
+			k8sObjects:    []runtime.Object{routerPod("router-a", true)},
+			expectedError: "this command does not need any arguments",
+		},
+		{
+			name:          "bad output",
+			flags:         &common.CommandRouterFlags{Output: "table"},
+			k8sObjects:    []runtime.Object{routerPod("router-a", true)},
+			expectedError: "output type is not valid: value table not allowed. It should be one of this options: [json yaml]",
+		},
+		{
+			name:          "bad interval",
+			flags:         &common.CommandRouterFlags{Watch: true},
+			k8sObjects:    []runtime.Object{routerPod("router-a", true)},
+			expectedError: "interval must be greater than zero",
+		},
+		{
+			name:          "no ready router",
+			k8sObjects:    []runtime.Object{routerPod("router-a", false)},
+			expectedError: "there are no ready router pods in namespace \"test\", make sure there is an active site",
+		},
+		{
+			name:          "pod does not exist",
+			flags:         &common.CommandRouterFlags{Pod: "router-c"},
+			k8sObjects:    []runtime.Object{routerPod("router-a", true)},
+			expectedError: "pod \"router-c\" does not exist in namespace \"test\"",
+		},
+		{
+			name:  "pod is not a router",
+			flags: &common.CommandRouterFlags{Pod: "backend"},
+			k8sObjects: []runtime.Object{
+				&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: "test"}},
+			},
+			expectedError: "pod \"backend\" is not a router pod",
+		},
+		{
+			name:        "first ready router",
+			flags:       &common.CommandRouterFlags{Watch: true, Interval: time.Second},
+			k8sObjects:  []runtime.Object{routerPod("router-c", true), routerPod("router-a", false), routerPod("router-b", true)},
+			expectedPod: "router-b",
+		},
+		{
+			name:        "selected router",
+			flags:       &common.CommandRouterFlags{Pod: "router-a"},
+			k8sObjects:  []runtime.Object{routerPod("router-a", false), routerPod("router-b", true)},
+			expectedPod: "router-a",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command, err := newCmdRouterQueryWithMocks("test", test.k8sObjects, nil)
+			assert.Assert(t, err)
+			command.Flags = test.flags
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+			if test.expectedPod != "" {
+				assert.Equal(t, command.pod, test.expectedPod)
+			}
+		})
+	}
+}
+
+func TestCmdRouterQuery_Run(t *testing.T) {
+	type test struct {
+		name          string
+		query         string
+		output        string
+		connectError  error
+		queryError    error
+		expectedError string
+	}
+
+	testTable := []test{
+		{
+			name:          "router not reachable",
+			query:         utils.RouterConnections,
+			connectError:  fmt.Errorf("pod not running"),
+			expectedError: "could not connect to router router-a: pod not running",
+		},
+		{
+			name:          "query fails",
+			query:         utils.RouterLinks,
+			queryError:    fmt.Errorf("connection closed"),
+			expectedError: "connection closed",
+		},
+		{
+			name:  "connections",
+			query: utils.RouterConnections,
+		},
+		{
+			name:   "addresses as yaml",
+			query:  utils.RouterAddresses,
+			output: "yaml",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			agent := &fakeRouterAgent{err: test.queryError}
+			command, err := newCmdRouterQueryWithMocks("test", nil, agent)
+			assert.Assert(t, err)
+			command.query = test.query
+			command.output = test.output
+			command.pod = "router-a"
+			if test.connectError != nil {
+				command.connectRouter = func(pod string) (RouterAgent, error) {
+					return nil, test.connectError
+				}
+			}
+
+			err = command.Run()
+			if test.expectedError != "" {
+				assert.Error(t, err, test.expectedError)
+			} else {
+				assert.Assert(t, err)
+			}
+			if test.connectError == nil {
+				assert.Assert(t, agent.closed)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+type fakeRouterAgent struct {
+	err    error
+	closed bool
+}
+
+func (f *fakeRouterAgent) GetConnections() ([]qdr.Connection, error) {
+	return []qdr.Connection{{Identity: "1", Container: "west", Role: "inter-router"}}, f.err
+}
+
+func (f *fakeRouterAgent) GetLinks() ([]qdr.RouterLink, error) {
+	return []qdr.RouterLink{{Identity: "1", LinkType: "router-control"}}, f.err
+}
+
+func (f *fakeRouterAgent) GetAddresses() ([]qdr.RouterAddress, error) {
+	return []qdr.RouterAddress{{Name: "M0backend", Class: "mobile", Address: "backend"}}, f.err
+}
+
+func (f *fakeRouterAgent) GetLocalTcpConnections() ([]qdr.TcpConnection, error) {
+	return nil, f.err
+}
+
+func (f *fakeRouterAgent) Close() error {
+	f.closed = true
+	return nil
+}
+
+func routerPod(name string, ready bool) *corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test",
+			Labels: map[string]string{
+				"skupper.io/component": "router",
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			Conditions: []corev1.PodCondition{
+				{
+					Type:   corev1.PodReady,
+					Status: status,
+				},
+			},
+		},
+	}
+}
+
+func newCmdRouterQueryWithMocks(namespace string, k8sObjects []runtime.Object, agent RouterAgent) (*CmdRouterQuery, error) {
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	return &CmdRouterQuery{
+		KubeClient: client.GetKubeClient(),
+		Namespace:  namespace,
+		connectRouter: func(pod string) (RouterAgent, error) {
+			return agent, nil
+		},
+	}, nil
+}
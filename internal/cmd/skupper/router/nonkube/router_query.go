@@ -0,0 +1,109 @@
+package nonkube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/runtime"
+	"github.com/skupperproject/skupper/internal/qdr"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/spf13/cobra"
+)
+
+type RouterAgent interface {
+	utils.RouterStateReader
+	Close() error
+}
+
+type CmdRouterQuery struct {
+	CobraCmd      *cobra.Command
+	Flags         *common.CommandRouterFlags
+	namespace     string
+	query         string
+	output        string
+	connectRouter func(namespace string) (RouterAgent, error)
+}
+
+func NewCmdRouterQuery(query string) *CmdRouterQuery {
+	return &CmdRouterQuery{
+		query:         query,
+		connectRouter: connectLocalRouter,
+	}
+}
+
+func (cmd *CmdRouterQuery) NewClient(cobraCommand *cobra.Command, args []string) {
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace) != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String() != "" {
+		cmd.namespace = cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String()
+	}
+}
+
+func (cmd *CmdRouterQuery) ValidateInput(args []string) error {
+	var validationErrors []error
+	outputTypeValidator := validator.NewOptionValidator(common.OutputTypes)
+
+	if len(args) > 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("this command does not need any arguments"))
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		} else {
+			cmd.output = cmd.Flags.Output
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Watch && cmd.Flags.Interval <= 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("interval must be greater than zero"))
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdRouterQuery) InputToOptions() {
+	if cmd.namespace == "" {
+		cmd.namespace = "default"
+	}
+}
+
+func (cmd *CmdRouterQuery) Run() error {
+	agent, err := cmd.connectRouter(cmd.namespace)
+	if err != nil {
+		return fmt.Errorf("could not connect to the router of namespace %q, make sure there is an active site: %s", cmd.namespace, err)
+	}
+	defer agent.Close()
+
+	if cmd.Flags != nil && cmd.Flags.Watch {
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer cancel()
+		return utils.WatchRouterState(ctx, os.Stdout, agent, cmd.query, cmd.output, cmd.Flags.Interval)
+	}
+	state, err := utils.QueryRouterState(agent, cmd.query)
+	if err != nil {
+		return err
+	}
+	return utils.WriteRouterState(os.Stdout, cmd.query, cmd.output, state)
+}
+
+func (cmd *CmdRouterQuery) WaitUntil() error { return nil }
+
+func connectLocalRouter(namespace string) (RouterAgent, error) {
+	url, err := runtime.GetLocalRouterAddress(namespace)
+	if err != nil {
+		return nil, err
+	}
+	agent, err := qdr.Connect(url, runtime.GetRuntimeTlsCert(namespace, "skupper-local-client"))
+	if err != nil {
+		if agent != nil {
+			agent.Close()
+		}
+		return nil, err
+	}
+	return agent, nil
+}
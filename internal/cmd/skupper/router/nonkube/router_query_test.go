@@ -0,0 +1,143 @@
+package nonkube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/qdr"
+	"gotest.tools/v3/assert"
+)
+
+func TestCmdRouterQuery_ValidateInput(t *testing.T) {
+	type test struct {
+		name          string
+		args          []string
+		flags         *common.CommandRouterFlags
+		expectedError string
+	}
+
+	testTable := []test{
+		{
+			name:          "arguments are not accepted",
+			args:          []string{"my-router"},
+			expectedError: "this command does not need any arguments",
+		},
+		{
+			name:          "bad output",
+			flags:         &common.CommandRouterFlags{Output: "table"},
+			expectedError: "output type is not valid: value table not allowed. It should be one of this options: [json yaml]",
+		},
+		{
+			name:          "bad interval",
+			flags:         &common.CommandRouterFlags{Watch: true},
+			expectedError: "interval must be greater than zero",
+		},
+		{
+			name:  "json output",
+			flags: &common.CommandRouterFlags{Output: "json"},
+		},
+		{
+			name: "no flags",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := NewCmdRouterQuery(utils.RouterConnections)
+			command.Flags = test.flags
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdRouterQuery_Run(t *testing.T) {
+	type test struct {
+		name          string
+		query         string
+		output        string
+		connectError  error
+		queryError    error
+		expectedError string
+	}
+
+	testTable := []test{
+		{
+			name:          "no site",
+			query:         utils.RouterConnections,
+			connectError:  fmt.Errorf("unable to determine router port"),
+			expectedError: "could not connect to the router of namespace \"default\", make sure there is an active site: unable to determine router port",
+		},
+		{
+			name:          "query fails",
+			query:         utils.RouterTcpFlows,
+			queryError:    fmt.Errorf("connection closed"),
+			expectedError: "connection closed",
+		},
+		{
+			name:  "links",
+			query: utils.RouterLinks,
+		},
+		{
+			name:   "tcp flows as json",
+			query:  utils.RouterTcpFlows,
+			output: "json",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			agent := &fakeRouterAgent{err: test.queryError}
+			command := NewCmdRouterQuery(test.query)
+			command.output = test.output
+			command.InputToOptions()
+			command.connectRouter = func(namespace string) (RouterAgent, error) {
+				if test.connectError != nil {
+					return nil, test.connectError
+				}
+				return agent, nil
+			}
+
+			err := command.Run()
+			if test.expectedError != "" {
+				assert.Error(t, err, test.expectedError)
+			} else {
+				assert.Assert(t, err)
+			}
+			if test.connectError == nil {
+				assert.Assert(t, agent.closed)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+type fakeRouterAgent struct {
+	err    error
+	closed bool
+}
+
+func (f *fakeRouterAgent) GetConnections() ([]qdr.Connection, error) {
+	return []qdr.Connection{{Identity: "1", Container: "west", Role: "inter-router"}}, f.err
+}
+
+func (f *fakeRouterAgent) GetLinks() ([]qdr.RouterLink, error) {
+	return []qdr.RouterLink{{Identity: "1", LinkType: "router-control"}}, f.err
+}
+
+func (f *fakeRouterAgent) GetAddresses() ([]qdr.RouterAddress, error) {
+	return nil, f.err
+}
+
+func (f *fakeRouterAgent) GetLocalTcpConnections() ([]qdr.TcpConnection, error) {
+	return []qdr.TcpConnection{{Name: "backend:8080-1", Address: "backend", BytesIn: 10}}, f.err
+}
+
+func (f *fakeRouterAgent) Close() error {
+	f.closed = true
+	return nil
+}
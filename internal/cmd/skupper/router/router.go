@@ -0,0 +1,96 @@
+package router
+
+import (
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/router/kube"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/router/nonkube"
+	"github.com/skupperproject/skupper/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdRouter() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "router",
+		Short: "Inspect the live state of the router of the site",
+		Long: `Query the management agent of the router of the site for its
+connections, links, addresses and tcp flows.`,
+		Example: `skupper router connections
+skupper router tcp-flows --watch`,
+	}
+	platform := common.Platform(config.GetPlatform())
+	cmd.AddCommand(CmdRouterConnectionsFactory(platform))
+	cmd.AddCommand(CmdRouterLinksFactory(platform))
+	cmd.AddCommand(CmdRouterAddressesFactory(platform))
+	cmd.AddCommand(CmdRouterTcpFlowsFactory(platform))
+
+	return cmd
+}
+
+func CmdRouterConnectionsFactory(configuredPlatform common.Platform) *cobra.Command {
+	return cmdRouterQueryFactory(configuredPlatform, utils.RouterConnections, common.SkupperCmdDescription{
+		Use:   "connections",
+		Short: "List the connections of the router",
+		Long: `List the AMQP connections of the router: those to and from other
+routers, for links between sites, and those of local clients such as the controller.`,
+		Example: `skupper router connections
+skupper router connections -o yaml`,
+	})
+}
+
+func CmdRouterLinksFactory(configuredPlatform common.Platform) *cobra.Command {
+	return cmdRouterQueryFactory(configuredPlatform, utils.RouterLinks, common.SkupperCmdDescription{
+		Use:   "links",
+		Short: "List the AMQP links attached to the router",
+		Long: `List the AMQP links attached to the router over its connections,
+with their delivery counters.`,
+		Example: `skupper router links
+skupper router links --watch --interval 5s`,
+	})
+}
+
+func CmdRouterAddressesFactory(configuredPlatform common.Platform) *cobra.Command {
+	return cmdRouterQueryFactory(configuredPlatform, utils.RouterAddresses, common.SkupperCmdDescription{
+		Use:   "addresses",
+		Short: "List the addresses known to the router",
+		Long: `List the addresses known to the router, with their local and remote
+consumers and delivery counters. The addresses of routing keys are mobile addresses.`,
+		Example: `skupper router addresses
+skupper router addresses -o json`,
+	})
+}
+
+func CmdRouterTcpFlowsFactory(configuredPlatform common.Platform) *cobra.Command {
+	return cmdRouterQueryFactory(configuredPlatform, utils.RouterTcpFlows, common.SkupperCmdDescription{
+		Use:   "tcp-flows",
+		Short: "List the tcp connections handled by the router",
+		Long: `List the tcp connections the router accepted for listeners or opened
+for connectors, with the number of bytes transferred in each direction.`,
+		Example: `skupper router tcp-flows
+skupper router tcp-flows --watch`,
+	})
+}
+
+func cmdRouterQueryFactory(configuredPlatform common.Platform, query string, description common.SkupperCmdDescription) *cobra.Command {
+	kubeCommand := kube.NewCmdRouterQuery(query)
+	nonKubeCommand := nonkube.NewCmdRouterQuery(query)
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, description, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandRouterFlags{}
+	cmd.Flags().StringVarP(&cmdFlags.Output, common.FlagNameOutput, "o", "", common.FlagDescRouterOutput)
+	cmd.Flags().BoolVarP(&cmdFlags.Watch, common.FlagNameWatch, "w", false, common.FlagDescWatch)
+	cmd.Flags().DurationVar(&cmdFlags.Interval, common.FlagNameWatchInterval, 2*time.Second, common.FlagDescWatchInterval)
+	if configuredPlatform == common.PlatformKubernetes {
+		cmd.Flags().StringVar(&cmdFlags.Pod, common.FlagNameRouterPod, "", common.FlagDescRouterPod)
+	}
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
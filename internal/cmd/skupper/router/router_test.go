@@ -0,0 +1,83 @@
+package router
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gotest.tools/v3/assert"
+)
+
+func TestCmdRouterFactory(t *testing.T) {
+
+	type test struct {
+		name                          string
+		expectedFlagsWithDefaultValue map[string]interface{}
+		command                       *cobra.Command
+	}
+
+	testTable := []test{
+		{
+			name: "CmdRouterConnectionsFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameOutput:        "",
+				common.FlagNameWatch:         "false",
+				common.FlagNameWatchInterval: "2s",
+				common.FlagNameRouterPod:     "",
+			},
+			command: CmdRouterConnectionsFactory(common.PlatformKubernetes),
+		},
+		{
+			name: "CmdRouterLinksFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameOutput:        "",
+				common.FlagNameWatch:         "false",
+				common.FlagNameWatchInterval: "2s",
+				common.FlagNameRouterPod:     "",
+			},
+			command: CmdRouterLinksFactory(common.PlatformKubernetes),
+		},
+		{
+			name: "CmdRouterAddressesFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameOutput:        "",
+				common.FlagNameWatch:         "false",
+				common.FlagNameWatchInterval: "2s",
+			},
+			command: CmdRouterAddressesFactory(common.PlatformPodman),
+		},
+		{
+			name: "CmdRouterTcpFlowsFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameOutput:        "",
+				common.FlagNameWatch:         "false",
+				common.FlagNameWatchInterval: "2s",
+			},
+			command: CmdRouterTcpFlowsFactory(common.PlatformLinux),
+		},
+	}
+
+	for _, test := range testTable {
+
+		var flagList []string
+		t.Run(test.name, func(t *testing.T) {
+
+			test.command.Flags().VisitAll(func(flag *pflag.Flag) {
+				flagList = append(flagList, flag.Name)
+				assert.Check(t, test.expectedFlagsWithDefaultValue[flag.Name] != nil, fmt.Sprintf("flag %q not expected", flag.Name))
+				assert.Check(t, test.expectedFlagsWithDefaultValue[flag.Name] == flag.DefValue, fmt.Sprintf("default value %q for flag %q not expected", flag.DefValue, flag.Name))
+			})
+
+			assert.Check(t, len(flagList) == len(test.expectedFlagsWithDefaultValue))
+
+			assert.Assert(t, test.command.PreRunE != nil)
+			assert.Assert(t, test.command.Run != nil)
+			assert.Assert(t, test.command.PostRun != nil)
+			assert.Assert(t, test.command.Use != "")
+			assert.Assert(t, test.command.Short != "")
+			assert.Assert(t, test.command.Long != "")
+		})
+	}
+}
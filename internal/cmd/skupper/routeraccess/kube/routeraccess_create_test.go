@@ -0,0 +1,426 @@
+package kube
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdRouterAccessCreate_ValidateInput(t *testing.T) {
+	type test struct {
+		name                string
+		args                []string
+		flags               common.CommandRouterAccessCreateFlags
+		k8sObjects          []runtime.Object
+		skupperObjects      []runtime.Object
+		skupperErrorMessage string
+		expectedError       string
+	}
+
+	validFlags := common.CommandRouterAccessCreateFlags{
+		Roles:                  []string{"inter-router:55671", "edge:45671"},
+		GenerateTlsCredentials: true,
+		Timeout:                time.Minute,
+		Wait:                   "ready",
+	}
+
+	testTable := []test{
+		{
+			name:                "missing CRD",
+			flags:               validFlags,
+			skupperErrorMessage: utils.CrdErr,
+			expectedError:       utils.CrdHelpErr,
+		},
+		{
+			name:  "router access already exists",
+			args:  []string{"my-access"},
+			flags: validFlags,
+			skupperObjects: []runtime.Object{
+				&v2alpha1.RouterAccess{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-access",
+						Namespace: "test",
+					},
+				},
+			},
+			expectedError: "There is already a router access my-access created for namespace test",
+		},
+		{
+			name:          "router access name is not specified",
+			flags:         validFlags,
+			expectedError: "router access name must be configured",
+		},
+		{
+			name:          "router access name empty",
+			args:          []string{""},
+			flags:         validFlags,
+			expectedError: "router access name must not be empty",
+		},
+		{
+			name:          "more than one argument is specified",
+			args:          []string{"my", "access"},
+			flags:         validFlags,
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:          "router access name is not valid",
+			args:          []string{"my access"},
+			flags:         validFlags,
+			expectedError: "router access name is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])*(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])*)*$",
+		},
+		{
+			name: "no roles",
+			args: []string{"my-access"},
+			flags: common.CommandRouterAccessCreateFlags{
+				GenerateTlsCredentials: true,
+				Timeout:                time.Minute,
+			},
+			expectedError: "at least one role must be configured",
+		},
+		{
+			name: "role is not valid",
+			args: []string{"my-access"},
+			flags: common.CommandRouterAccessCreateFlags{
+				Roles:                  []string{"normal:8080"},
+				GenerateTlsCredentials: true,
+				Timeout:                time.Minute,
+			},
+			expectedError: "role \"normal:8080\" is not valid: value normal not allowed. It should be one of this options: [inter-router edge]",
+		},
+		{
+			name: "access type is not valid",
+			args: []string{"my-access"},
+			flags: common.CommandRouterAccessCreateFlags{
+				Roles:                  []string{"edge:45671"},
+				AccessType:             "tunnel",
+				GenerateTlsCredentials: true,
+				Timeout:                time.Minute,
+			},
+			expectedError: "access type is not valid: value tunnel not allowed. It should be one of this options: [local loadbalancer route nodeport ingress-nginx contour-http-proxy gateway]",
+		},
+		{
+			name: "tls credentials missing and not generated",
+			args: []string{"my-access"},
+			flags: common.CommandRouterAccessCreateFlags{
+				Roles:   []string{"edge:45671"},
+				Timeout: time.Minute,
+			},
+			expectedError: "tls-credentials must be configured if they are not generated",
+		},
+		{
+			name: "tls secret does not exist",
+			args: []string{"my-access"},
+			flags: common.CommandRouterAccessCreateFlags{
+				Roles:          []string{"edge:45671"},
+				TlsCredentials: "my-secret",
+				Timeout:        time.Minute,
+			},
+			expectedError: "tls-secret is not valid: does not exist",
+		},
+		{
+			name: "tls secret exists",
+			args: []string{"my-access"},
+			flags: common.CommandRouterAccessCreateFlags{
+				Roles:          []string{"edge:45671"},
+				TlsCredentials: "my-secret",
+				Timeout:        time.Minute,
+			},
+			k8sObjects: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-secret",
+						Namespace: "test",
+					},
+				},
+			},
+		},
+		{
+			name: "bind host and subject alternative names are not valid",
+			args: []string{"my-access"},
+			flags: common.CommandRouterAccessCreateFlags{
+				Roles:                   []string{"edge:45671"},
+				GenerateTlsCredentials:  true,
+				BindHost:                "not a host",
+				SubjectAlternativeNames: []string{"example.com", "not@valid"},
+				Timeout:                 time.Minute,
+			},
+			expectedError: "bind host is not valid: a valid IP address or hostname is expected\n" +
+				"subject alternative name \"not@valid\" is not valid: a valid IP address or hostname is expected",
+		},
+		{
+			name: "timeout is not valid",
+			args: []string{"my-access"},
+			flags: common.CommandRouterAccessCreateFlags{
+				Roles:                  []string{"edge:45671"},
+				GenerateTlsCredentials: true,
+				Timeout:                time.Second,
+			},
+			expectedError: "timeout is not valid: duration must not be less than 10s; got 1s",
+		},
+		{
+			name: "wait status is not valid",
+			args: []string{"my-access"},
+			flags: common.CommandRouterAccessCreateFlags{
+				Roles:                  []string{"edge:45671"},
+				GenerateTlsCredentials: true,
+				Timeout:                time.Minute,
+				Wait:                   "pending",
+			},
+			expectedError: "status is not valid: value pending not allowed. It should be one of this options: [ready configured none]",
+		},
+		{
+			name:  "flags all valid",
+			args:  []string{"my-access"},
+			flags: validFlags,
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdRouterAccessCreateWithMocks("test", test.k8sObjects, test.skupperObjects, test.skupperErrorMessage)
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdRouterAccessCreate_InputToOptions(t *testing.T) {
+	type test struct {
+		name                   string
+		flags                  common.CommandRouterAccessCreateFlags
+		expectedTlsCredentials string
+	}
+
+	testTable := []test{
+		{
+			name: "tls credentials default to the name",
+			flags: common.CommandRouterAccessCreateFlags{
+				GenerateTlsCredentials: true,
+				Wait:                   "ready",
+			},
+			expectedTlsCredentials: "my-access",
+		},
+		{
+			name: "tls credentials are set",
+			flags: common.CommandRouterAccessCreateFlags{
+				TlsCredentials: "my-secret",
+				Wait:           "ready",
+			},
+			expectedTlsCredentials: "my-secret",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			cmd, err := newCmdRouterAccessCreateWithMocks("test", nil, nil, "")
+			assert.Assert(t, err)
+			cmd.name = "my-access"
+			cmd.Flags = &test.flags
+
+			cmd.InputToOptions()
+
+			assert.Equal(t, cmd.tlsCredentials, test.expectedTlsCredentials)
+			assert.Equal(t, cmd.generateTlsCredentials, test.flags.GenerateTlsCredentials)
+			assert.Equal(t, cmd.status, "ready")
+		})
+	}
+}
+
+func TestCmdRouterAccessCreate_Run(t *testing.T) {
+	type test struct {
+		name                string
+		skupperErrorMessage string
+		errorMessage        string
+	}
+
+	testTable := []test{
+		{
+			name: "runs ok",
+		},
+		{
+			name:                "run fails",
+			skupperErrorMessage: "error",
+			errorMessage:        "error",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdRouterAccessCreateWithMocks("test", nil, nil, test.skupperErrorMessage)
+		assert.Assert(t, err)
+		cmd.name = "my-access"
+		cmd.accessType = "loadbalancer"
+		cmd.roles = []v2alpha1.RouterAccessRole{{Name: "inter-router", Port: 55671}}
+		cmd.tlsCredentials = "my-access"
+		cmd.generateTlsCredentials = true
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Error(t, err, test.errorMessage)
+			} else {
+				assert.Assert(t, err)
+				routerAccess, err := cmd.client.RouterAccesses("test").Get(t.Context(), "my-access", v1.GetOptions{})
+				assert.Assert(t, err)
+				assert.Equal(t, routerAccess.Spec.AccessType, "loadbalancer")
+				assert.DeepEqual(t, routerAccess.Spec.Roles, cmd.roles)
+				assert.Equal(t, routerAccess.Spec.TlsCredentials, "my-access")
+				assert.Assert(t, routerAccess.Spec.GenerateTlsCredentials)
+			}
+		})
+	}
+}
+
+func TestCmdRouterAccessCreate_WaitUntil(t *testing.T) {
+	type test struct {
+		name           string
+		status         string
+		skupperObjects []runtime.Object
+		expectError    bool
+	}
+
+	testTable := []test{
+		{
+			name:   "router access is not ready",
+			status: "ready",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.RouterAccess{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-access",
+						Namespace: "test",
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name:        "router access is not returned",
+			status:      "ready",
+			expectError: true,
+		},
+		{
+			name:   "router access is ready",
+			status: "ready",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.RouterAccess{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-access",
+						Namespace: "test",
+					},
+					Status: v2alpha1.RouterAccessStatus{
+						Status: v2alpha1.Status{
+							Conditions: []v1.Condition{
+								{
+									Type:   "Ready",
+									Status: "True",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "router access is configured",
+			status: "configured",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.RouterAccess{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-access",
+						Namespace: "test",
+					},
+					Status: v2alpha1.RouterAccessStatus{
+						Status: v2alpha1.Status{
+							Conditions: []v1.Condition{
+								{
+									Type:   "Configured",
+									Status: "True",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "router access could not be configured",
+			status: "configured",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.RouterAccess{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-access",
+						Namespace: "test",
+					},
+					Status: v2alpha1.RouterAccessStatus{
+						Status: v2alpha1.Status{
+							Conditions: []v1.Condition{
+								{
+									Message: "Port conflict",
+									Reason:  "Error",
+									Type:    "Configured",
+									Status:  "False",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name:        "user does not wait",
+			status:      "none",
+			expectError: false,
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdRouterAccessCreateWithMocks("test", nil, test.skupperObjects, "")
+		assert.Assert(t, err)
+
+		cmd.name = "my-access"
+		cmd.timeout = 1 * time.Second
+		cmd.status = test.status
+
+		t.Run(test.name, func(t *testing.T) {
+
+			err := cmd.WaitUntil()
+			if test.expectError {
+				assert.Check(t, err != nil)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdRouterAccessCreateWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdRouterAccessCreate, error) {
+
+	// We make sure the interval is appropriate
+	utils.SetRetryProfile(utils.TestRetryProfile)
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdRouterAccessCreate := &CmdRouterAccessCreate{
+		client:     client.GetSkupperClient().SkupperV2alpha1(),
+		KubeClient: client.GetKubeClient(),
+		namespace:  namespace,
+	}
+	return cmdRouterAccessCreate, nil
+}
@@ -0,0 +1,175 @@
+package kube
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"gotest.tools/v3/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdRouterAccessDelete_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		flags          common.CommandRouterAccessDeleteFlags
+		skupperObjects []runtime.Object
+		skupperError   string
+		expectedError  string
+	}
+
+	testTable := []test{
+		{
+			name:          "missing CRD",
+			args:          []string{"my-access"},
+			skupperError:  utils.CrdErr,
+			expectedError: utils.CrdHelpErr,
+		},
+		{
+			name:          "router access is not specified",
+			expectedError: "router access name must be specified",
+		},
+		{
+			name:          "router access name is nil",
+			args:          []string{""},
+			expectedError: "router access name must not be empty",
+		},
+		{
+			name:          "more than one argument is specified",
+			args:          []string{"my", "access"},
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:          "router access does not exist",
+			args:          []string{"my-access"},
+			flags:         common.CommandRouterAccessDeleteFlags{Timeout: time.Minute},
+			expectedError: "router access my-access does not exist in namespace test",
+		},
+		{
+			name:           "timeout is not valid",
+			args:           []string{"my-access"},
+			flags:          common.CommandRouterAccessDeleteFlags{Timeout: 0},
+			skupperObjects: []runtime.Object{existingRouterAccess()},
+			expectedError:  "timeout is not valid: duration must not be less than 10s; got 0s",
+		},
+		{
+			name:           "router access exists",
+			args:           []string{"my-access"},
+			flags:          common.CommandRouterAccessDeleteFlags{Timeout: time.Minute},
+			skupperObjects: []runtime.Object{existingRouterAccess()},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdRouterAccessDeleteWithMocks("test", nil, test.skupperObjects, test.skupperError)
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdRouterAccessDelete_Run(t *testing.T) {
+	type test struct {
+		name                string
+		skupperObjects      []runtime.Object
+		skupperErrorMessage string
+		errorMessage        string
+	}
+
+	testTable := []test{
+		{
+			name:           "runs ok",
+			skupperObjects: []runtime.Object{existingRouterAccess()},
+		},
+		{
+			name:                "run fails",
+			skupperErrorMessage: "error",
+			errorMessage:        "error",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdRouterAccessDeleteWithMocks("test", nil, test.skupperObjects, test.skupperErrorMessage)
+		assert.Assert(t, err)
+		cmd.name = "my-access"
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Error(t, err, test.errorMessage)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+func TestCmdRouterAccessDelete_WaitUntil(t *testing.T) {
+	type test struct {
+		name           string
+		wait           bool
+		skupperObjects []runtime.Object
+		expectError    bool
+	}
+
+	testTable := []test{
+		{
+			name:           "router access is not deleted",
+			wait:           true,
+			skupperObjects: []runtime.Object{existingRouterAccess()},
+			expectError:    true,
+		},
+		{
+			name: "router access is deleted",
+			wait: true,
+		},
+		{
+			name:           "user does not wait",
+			wait:           false,
+			skupperObjects: []runtime.Object{existingRouterAccess()},
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdRouterAccessDeleteWithMocks("test", nil, test.skupperObjects, "")
+		assert.Assert(t, err)
+		cmd.name = "my-access"
+		cmd.Flags = &common.CommandRouterAccessDeleteFlags{Timeout: time.Second, Wait: test.wait}
+		cmd.InputToOptions()
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.WaitUntil()
+			if test.expectError {
+				assert.Check(t, err != nil)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdRouterAccessDeleteWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdRouterAccessDelete, error) {
+
+	// We make sure the interval is appropriate
+	utils.SetRetryProfile(utils.TestRetryProfile)
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdRouterAccessDelete := &CmdRouterAccessDelete{
+		client:    client.GetSkupperClient().SkupperV2alpha1(),
+		namespace: namespace,
+	}
+	return cmdRouterAccessDelete, nil
+}
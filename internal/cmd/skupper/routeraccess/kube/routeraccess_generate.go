@@ -0,0 +1,167 @@
+package kube
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CmdRouterAccessGenerate struct {
+	CobraCmd                *cobra.Command
+	Flags                   *common.CommandRouterAccessGenerateFlags
+	namespace               string
+	name                    string
+	roles                   []v2alpha1.RouterAccessRole
+	accessType              string
+	tlsCredentials          string
+	generateTlsCredentials  bool
+	issuer                  string
+	bindHost                string
+	subjectAlternativeNames []string
+	output                  string
+}
+
+func NewCmdRouterAccessGenerate() *CmdRouterAccessGenerate {
+
+	return &CmdRouterAccessGenerate{}
+
+}
+
+func (cmd *CmdRouterAccessGenerate) NewClient(cobraCommand *cobra.Command, args []string) {
+
+	cmd.namespace = cobraCommand.Flag("namespace").Value.String()
+}
+
+func (cmd *CmdRouterAccessGenerate) ValidateInput(args []string) error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	hostStringValidator := validator.NewHostStringValidator()
+	accessTypeValidator := validator.NewOptionValidator(common.AccessTypes)
+	outputTypeValidator := validator.NewOptionValidator(common.OutputTypes)
+
+	// Validate arguments name
+	if len(args) < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("router access name must be configured"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("router access name must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("router access name is not valid: %s", err))
+		} else {
+			cmd.name = args[0]
+		}
+	}
+
+	// Validate flags
+	if cmd.Flags != nil {
+		if len(cmd.Flags.Roles) == 0 {
+			validationErrors = append(validationErrors, fmt.Errorf("at least one role must be configured"))
+		} else if roles, err := utils.ParseRouterAccessRoles(cmd.Flags.Roles, common.RouterRoles); err != nil {
+			validationErrors = append(validationErrors, err)
+		} else {
+			cmd.roles = roles
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.AccessType != "" {
+		ok, err := accessTypeValidator.Evaluate(cmd.Flags.AccessType)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("access type is not valid: %s", err))
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.TlsCredentials != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.TlsCredentials)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("tls-credentials is not valid: %s", err))
+		}
+	} else if cmd.Flags != nil && !cmd.Flags.GenerateTlsCredentials {
+		validationErrors = append(validationErrors, fmt.Errorf("tls-credentials must be configured if they are not generated"))
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Issuer != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.Issuer)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("issuer is not valid: %s", err))
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.BindHost != "" {
+		ok, _ := hostStringValidator.Evaluate(cmd.Flags.BindHost)
+		if !ok && net.ParseIP(cmd.Flags.BindHost) == nil {
+			validationErrors = append(validationErrors, fmt.Errorf("bind host is not valid: a valid IP address or hostname is expected"))
+		}
+	}
+
+	if cmd.Flags != nil {
+		for _, san := range cmd.Flags.SubjectAlternativeNames {
+			ok, _ := hostStringValidator.Evaluate(san)
+			if !ok && net.ParseIP(san) == nil {
+				validationErrors = append(validationErrors, fmt.Errorf("subject alternative name %q is not valid: a valid IP address or hostname is expected", san))
+			}
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		}
+	}
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdRouterAccessGenerate) InputToOptions() {
+	// default tls credentials to name of router access
+	if cmd.Flags.TlsCredentials == "" {
+		cmd.tlsCredentials = cmd.name
+	} else {
+		cmd.tlsCredentials = cmd.Flags.TlsCredentials
+	}
+	cmd.accessType = cmd.Flags.AccessType
+	cmd.generateTlsCredentials = cmd.Flags.GenerateTlsCredentials
+	cmd.issuer = cmd.Flags.Issuer
+	cmd.bindHost = cmd.Flags.BindHost
+	cmd.subjectAlternativeNames = cmd.Flags.SubjectAlternativeNames
+	cmd.output = cmd.Flags.Output
+}
+
+func (cmd *CmdRouterAccessGenerate) Run() error {
+
+	resource := v2alpha1.RouterAccess{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "RouterAccess",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cmd.name,
+			Namespace: cmd.namespace,
+		},
+		Spec: v2alpha1.RouterAccessSpec{
+			AccessType:              cmd.accessType,
+			Roles:                   cmd.roles,
+			TlsCredentials:          cmd.tlsCredentials,
+			GenerateTlsCredentials:  cmd.generateTlsCredentials,
+			Issuer:                  cmd.issuer,
+			BindHost:                cmd.bindHost,
+			SubjectAlternativeNames: cmd.subjectAlternativeNames,
+		},
+	}
+
+	encodedOutput, err := utils.Encode(cmd.output, resource)
+	fmt.Println(encodedOutput)
+	return err
+}
+
+func (cmd *CmdRouterAccessGenerate) WaitUntil() error { return nil }
@@ -0,0 +1,111 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+)
+
+func TestCmdRouterAccessGenerate_ValidateInput(t *testing.T) {
+	type test struct {
+		name          string
+		args          []string
+		flags         common.CommandRouterAccessGenerateFlags
+		expectedError string
+	}
+
+	testTable := []test{
+		{
+			name:          "router access name is not specified",
+			flags:         common.CommandRouterAccessGenerateFlags{Roles: []string{"edge:45671"}, GenerateTlsCredentials: true},
+			expectedError: "router access name must be configured",
+		},
+		{
+			name:          "router access name is not valid",
+			args:          []string{"my access"},
+			flags:         common.CommandRouterAccessGenerateFlags{Roles: []string{"edge:45671"}, GenerateTlsCredentials: true},
+			expectedError: "router access name is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])*(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])*)*$",
+		},
+		{
+			name:          "role is specified twice",
+			args:          []string{"my-access"},
+			flags:         common.CommandRouterAccessGenerateFlags{Roles: []string{"edge:45671", "edge:8080"}, GenerateTlsCredentials: true},
+			expectedError: "role \"edge\" is specified more than once",
+		},
+		{
+			name:          "tls credentials missing and not generated",
+			args:          []string{"my-access"},
+			flags:         common.CommandRouterAccessGenerateFlags{Roles: []string{"edge:45671"}},
+			expectedError: "tls-credentials must be configured if they are not generated",
+		},
+		{
+			name:          "output format is not valid",
+			args:          []string{"my-access"},
+			flags:         common.CommandRouterAccessGenerateFlags{Roles: []string{"edge:45671"}, GenerateTlsCredentials: true, Output: "not-supported"},
+			expectedError: "output type is not valid: value not-supported not allowed. It should be one of this options: [json yaml]",
+		},
+		{
+			name:  "flags all valid",
+			args:  []string{"my-access"},
+			flags: common.CommandRouterAccessGenerateFlags{Roles: []string{"edge:45671"}, TlsCredentials: "my-secret", Output: "json"},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdRouterAccessGenerate{Flags: &test.flags}
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdRouterAccessGenerate_Run(t *testing.T) {
+	type test struct {
+		name         string
+		output       string
+		errorMessage string
+	}
+
+	testTable := []test{
+		{
+			name:   "runs ok with yaml output",
+			output: "yaml",
+		},
+		{
+			name:   "runs ok with json output",
+			output: "json",
+		},
+		{
+			name:         "run fails with unsupported output",
+			output:       "unsupported",
+			errorMessage: "format unsupported not supported",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd := &CmdRouterAccessGenerate{
+			Flags: &common.CommandRouterAccessGenerateFlags{
+				Roles:                  []string{"edge:45671"},
+				GenerateTlsCredentials: true,
+				Output:                 test.output,
+			},
+			name:      "my-access",
+			namespace: "test",
+			roles:     []v2alpha1.RouterAccessRole{{Name: "edge", Port: 45671}},
+		}
+		cmd.InputToOptions()
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Error(t, err, test.errorMessage)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
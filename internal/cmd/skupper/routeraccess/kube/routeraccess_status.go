@@ -0,0 +1,154 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	"github.com/skupperproject/skupper/internal/kube/client"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/generated/client/clientset/versioned/typed/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	k8serrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CmdRouterAccessStatus struct {
+	client    skupperv2alpha1.SkupperV2alpha1Interface
+	CobraCmd  *cobra.Command
+	Flags     *common.CommandRouterAccessStatusFlags
+	namespace string
+	name      string
+	output    string
+}
+
+func NewCmdRouterAccessStatus() *CmdRouterAccessStatus {
+
+	return &CmdRouterAccessStatus{}
+}
+
+func (cmd *CmdRouterAccessStatus) NewClient(cobraCommand *cobra.Command, args []string) {
+	cli, err := client.NewClient(cobraCommand.Flag("namespace").Value.String(), cobraCommand.Flag("context").Value.String(), cobraCommand.Flag("kubeconfig").Value.String())
+	utils.HandleError(utils.GenericError, err)
+
+	cmd.client = cli.GetSkupperClient().SkupperV2alpha1()
+	cmd.namespace = cli.Namespace
+}
+
+func (cmd *CmdRouterAccessStatus) ValidateInput(args []string) error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	outputTypeValidator := validator.NewOptionValidator(common.OutputTypes)
+
+	// Check if RouterAccess CRD is installed
+	_, err := cmd.client.RouterAccesses(cmd.namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		validationErrors = append(validationErrors, utils.HandleMissingCrds(err))
+		return errors.Join(validationErrors...)
+	}
+
+	// Validate arguments name if specified
+	if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if len(args) == 1 {
+		if args[0] == "" {
+			validationErrors = append(validationErrors, fmt.Errorf("router access name must not be empty"))
+		} else {
+			ok, err := resourceStringValidator.Evaluate(args[0])
+			if !ok {
+				validationErrors = append(validationErrors, fmt.Errorf("router access name is not valid: %s", err))
+			} else {
+				cmd.name = args[0]
+			}
+		}
+	}
+
+	// Validate that there is a router access with this name in the namespace
+	if cmd.name != "" {
+		routerAccess, err := cmd.client.RouterAccesses(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if err != nil || routerAccess == nil {
+			validationErrors = append(validationErrors, fmt.Errorf("router access %s does not exist in namespace %s", cmd.name, cmd.namespace))
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		} else {
+			cmd.output = cmd.Flags.Output
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdRouterAccessStatus) Run() error {
+	if cmd.name == "" {
+		resources, err := cmd.client.RouterAccesses(cmd.namespace).List(context.TODO(), metav1.ListOptions{})
+		if err != nil || resources == nil || len(resources.Items) == 0 {
+			fmt.Println("No router accesses found")
+			return err
+		}
+		if cmd.output != "" {
+			for _, resource := range resources.Items {
+				encodedOutput, err := utils.Encode(cmd.output, resource)
+				if err != nil {
+					return err
+				}
+				fmt.Println(encodedOutput)
+			}
+		} else {
+			tw := tabwriter.NewWriter(os.Stdout, 8, 8, 1, '\t', tabwriter.TabIndent)
+			_, _ = fmt.Fprintln(tw, fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s",
+				"NAME", "STATUS", "ACCESS-TYPE", "ROLES", "TLS-CREDENTIALS", "MESSAGE"))
+			for _, resource := range resources.Items {
+				fmt.Fprintln(tw, fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s",
+					resource.Name, resource.Status.StatusType, resource.Spec.AccessType,
+					strings.Join(utils.FormatRouterAccessRoles(resource.Spec.Roles), ","),
+					resource.Spec.TlsCredentials, resource.Status.Message))
+			}
+			_ = tw.Flush()
+		}
+	} else {
+		resource, err := cmd.client.RouterAccesses(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if err != nil || resource == nil || k8serrs.IsNotFound(err) {
+			fmt.Println("No router accesses found")
+			return err
+		}
+		if cmd.output != "" {
+			encodedOutput, err := utils.Encode(cmd.output, resource)
+			if err != nil {
+				return err
+			}
+			fmt.Println(encodedOutput)
+		} else {
+			tw := tabwriter.NewWriter(os.Stdout, 8, 8, 1, '\t', tabwriter.TabIndent)
+			fmt.Fprintln(tw, fmt.Sprintf("Name:\t%s\nStatus:\t%s\nAccess Type:\t%s\nRoles:\t%s\nTLS Credentials:\t%s\nEndpoints:\t%s\nMessage:\t%s\n",
+				resource.Name, resource.Status.StatusType, resource.Spec.AccessType,
+				strings.Join(utils.FormatRouterAccessRoles(resource.Spec.Roles), ","),
+				resource.Spec.TlsCredentials, formatEndpoints(resource.Status.Endpoints), resource.Status.Message))
+			_ = tw.Flush()
+		}
+	}
+
+	return nil
+}
+
+func (cmd *CmdRouterAccessStatus) InputToOptions()  {}
+func (cmd *CmdRouterAccessStatus) WaitUntil() error { return nil }
+
+func formatEndpoints(endpoints []v2alpha1.Endpoint) string {
+	var values []string
+	for _, endpoint := range endpoints {
+		values = append(values, fmt.Sprintf("%s %s:%s", endpoint.Name, endpoint.Host, endpoint.Port))
+	}
+	return strings.Join(values, ", ")
+}
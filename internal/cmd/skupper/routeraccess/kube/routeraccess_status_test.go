@@ -0,0 +1,150 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"gotest.tools/v3/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdRouterAccessStatus_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		flags          common.CommandRouterAccessStatusFlags
+		skupperObjects []runtime.Object
+		expectedError  string
+		skupperError   string
+	}
+
+	testTable := []test{
+		{
+			name:          "missing CRD",
+			args:          []string{"my-access"},
+			skupperError:  utils.CrdErr,
+			expectedError: utils.CrdHelpErr,
+		},
+		{
+			name:          "router access does not exist in the namespace",
+			args:          []string{"my-access"},
+			expectedError: "router access my-access does not exist in namespace test",
+		},
+		{
+			name:          "router access name is nil",
+			args:          []string{""},
+			expectedError: "router access name must not be empty",
+		},
+		{
+			name:          "more than one argument is specified",
+			args:          []string{"my", "access"},
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:          "no args",
+			expectedError: "",
+		},
+		{
+			name:           "bad output status",
+			args:           []string{"my-access"},
+			flags:          common.CommandRouterAccessStatusFlags{Output: "not-supported"},
+			skupperObjects: []runtime.Object{existingRouterAccess()},
+			expectedError:  "output type is not valid: value not-supported not allowed. It should be one of this options: [json yaml]",
+		},
+		{
+			name:           "good output status",
+			args:           []string{"my-access"},
+			flags:          common.CommandRouterAccessStatusFlags{Output: "json"},
+			skupperObjects: []runtime.Object{existingRouterAccess()},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdRouterAccessStatusWithMocks("test", nil, test.skupperObjects, test.skupperError)
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdRouterAccessStatus_Run(t *testing.T) {
+	type test struct {
+		name                string
+		accessName          string
+		output              string
+		skupperObjects      []runtime.Object
+		skupperErrorMessage string
+		errorMessage        string
+	}
+
+	testTable := []test{
+		{
+			name:                "run fails",
+			accessName:          "my-access",
+			skupperErrorMessage: "error",
+			errorMessage:        "error",
+		},
+		{
+			name:           "runs ok",
+			accessName:     "my-access",
+			skupperObjects: []runtime.Object{existingRouterAccess()},
+		},
+		{
+			name:           "runs ok with yaml output",
+			accessName:     "my-access",
+			output:         "yaml",
+			skupperObjects: []runtime.Object{existingRouterAccess()},
+		},
+		{
+			name:           "runs ok listing router accesses",
+			skupperObjects: []runtime.Object{existingRouterAccess()},
+		},
+		{
+			name:           "runs ok listing router accesses with json output",
+			output:         "json",
+			skupperObjects: []runtime.Object{existingRouterAccess()},
+		},
+		{
+			name: "runs ok with no router accesses",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdRouterAccessStatusWithMocks("test", nil, test.skupperObjects, test.skupperErrorMessage)
+		assert.Assert(t, err)
+		cmd.name = test.accessName
+		cmd.output = test.output
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Error(t, err, test.errorMessage)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdRouterAccessStatusWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdRouterAccessStatus, error) {
+
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdRouterAccessStatus := &CmdRouterAccessStatus{
+		client:    client.GetSkupperClient().SkupperV2alpha1(),
+		namespace: namespace,
+	}
+	return cmdRouterAccessStatus, nil
+}
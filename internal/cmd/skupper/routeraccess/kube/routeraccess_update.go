@@ -0,0 +1,244 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	"github.com/skupperproject/skupper/internal/kube/client"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/generated/client/clientset/versioned/typed/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	k8serrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type CmdRouterAccessUpdate struct {
+	client          skupperv2alpha1.SkupperV2alpha1Interface
+	CobraCmd        *cobra.Command
+	Flags           *common.CommandRouterAccessUpdateFlags
+	namespace       string
+	name            string
+	resourceVersion string
+	newSettings     v2alpha1.RouterAccessSpec
+	KubeClient      kubernetes.Interface
+	status          string
+}
+
+func NewCmdRouterAccessUpdate() *CmdRouterAccessUpdate {
+
+	return &CmdRouterAccessUpdate{}
+}
+
+func (cmd *CmdRouterAccessUpdate) NewClient(cobraCommand *cobra.Command, args []string) {
+	cli, err := client.NewClient(cobraCommand.Flag("namespace").Value.String(), cobraCommand.Flag("context").Value.String(), cobraCommand.Flag("kubeconfig").Value.String())
+	utils.HandleError(utils.GenericError, err)
+
+	cmd.client = cli.GetSkupperClient().SkupperV2alpha1()
+	cmd.namespace = cli.Namespace
+	cmd.KubeClient = cli.Kube
+}
+
+func (cmd *CmdRouterAccessUpdate) ValidateInput(args []string) error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	hostStringValidator := validator.NewHostStringValidator()
+	accessTypeValidator := validator.NewOptionValidator(common.AccessTypes)
+	timeoutValidator := validator.NewTimeoutInSecondsValidator()
+	statusValidator := validator.NewOptionValidator(common.WaitStatusTypes)
+
+	// Check if RouterAccess CRD is installed
+	_, err := cmd.client.RouterAccesses(cmd.namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		validationErrors = append(validationErrors, utils.HandleMissingCrds(err))
+		return errors.Join(validationErrors...)
+	}
+
+	// Validate arguments name
+	if len(args) < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("router access name must be configured"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("router access name must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("router access name is not valid: %s", err))
+		} else {
+			cmd.name = args[0]
+		}
+	}
+
+	// Validate that there is already a router access with this name in the namespace
+	if cmd.name != "" {
+		routerAccess, err := cmd.client.RouterAccesses(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if routerAccess == nil || k8serrs.IsNotFound(err) {
+			validationErrors = append(validationErrors, fmt.Errorf("router access %s must exist in namespace %s to be updated", cmd.name, cmd.namespace))
+		} else {
+			// save existing values
+			cmd.resourceVersion = routerAccess.ResourceVersion
+			cmd.newSettings = routerAccess.Spec
+		}
+	}
+
+	// Validate flags
+	if cmd.Flags != nil && len(cmd.Flags.Roles) > 0 {
+		roles, err := utils.ParseRouterAccessRoles(cmd.Flags.Roles, common.RouterRoles)
+		if err != nil {
+			validationErrors = append(validationErrors, err)
+		} else {
+			cmd.newSettings.Roles = roles
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.AccessType != "" {
+		ok, err := accessTypeValidator.Evaluate(cmd.Flags.AccessType)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("access type is not valid: %s", err))
+		} else {
+			cmd.newSettings.AccessType = cmd.Flags.AccessType
+		}
+	}
+	if cmd.Flags != nil && cmd.CobraCmd != nil && cmd.CobraCmd.Flags().Changed(common.FlagNameGenerateTlsCredentials) {
+		cmd.newSettings.GenerateTlsCredentials = cmd.Flags.GenerateTlsCredentials
+	}
+	if cmd.Flags != nil && cmd.Flags.TlsCredentials != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.TlsCredentials)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("tls-credentials is not valid: %s", err))
+		} else {
+			cmd.newSettings.TlsCredentials = cmd.Flags.TlsCredentials
+		}
+	}
+	if !cmd.newSettings.GenerateTlsCredentials && cmd.newSettings.TlsCredentials != "" {
+		// check that the secret exists, as it will not be generated
+		_, err := cmd.KubeClient.CoreV1().Secrets(cmd.namespace).Get(context.TODO(), cmd.newSettings.TlsCredentials, metav1.GetOptions{})
+		if err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("tls-secret is not valid: does not exist"))
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.Issuer != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.Issuer)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("issuer is not valid: %s", err))
+		} else {
+			cmd.newSettings.Issuer = cmd.Flags.Issuer
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.BindHost != "" {
+		ok, _ := hostStringValidator.Evaluate(cmd.Flags.BindHost)
+		if !ok && net.ParseIP(cmd.Flags.BindHost) == nil {
+			validationErrors = append(validationErrors, fmt.Errorf("bind host is not valid: a valid IP address or hostname is expected"))
+		} else {
+			cmd.newSettings.BindHost = cmd.Flags.BindHost
+		}
+	}
+	if cmd.Flags != nil && len(cmd.Flags.SubjectAlternativeNames) > 0 {
+		valid := true
+		for _, san := range cmd.Flags.SubjectAlternativeNames {
+			ok, _ := hostStringValidator.Evaluate(san)
+			if !ok && net.ParseIP(san) == nil {
+				valid = false
+				validationErrors = append(validationErrors, fmt.Errorf("subject alternative name %q is not valid: a valid IP address or hostname is expected", san))
+			}
+		}
+		if valid {
+			cmd.newSettings.SubjectAlternativeNames = cmd.Flags.SubjectAlternativeNames
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.Timeout.String() != "" {
+		ok, err := timeoutValidator.Evaluate(cmd.Flags.Timeout)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("timeout is not valid: %s", err))
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Wait != "" {
+		ok, err := statusValidator.Evaluate(cmd.Flags.Wait)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("status is not valid: %s", err))
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdRouterAccessUpdate) Run() error {
+
+	resource := v2alpha1.RouterAccess{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "RouterAccess",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            cmd.name,
+			Namespace:       cmd.namespace,
+			ResourceVersion: cmd.resourceVersion},
+		Spec: cmd.newSettings,
+	}
+
+	_, err := cmd.client.RouterAccesses(cmd.namespace).Update(context.TODO(), &resource, metav1.UpdateOptions{})
+	return err
+}
+
+func (cmd *CmdRouterAccessUpdate) WaitUntil() error {
+
+	if cmd.status == "none" {
+		return nil
+	}
+
+	waitTime := int(cmd.Flags.Timeout.Seconds())
+	var routerAccessCondition *metav1.Condition
+	err := utils.NewSpinnerWithTimeout("Waiting for update to complete...", waitTime, func() error {
+
+		resource, err := cmd.client.RouterAccesses(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		isConditionFound := false
+		isConditionTrue := false
+
+		switch cmd.status {
+		case "ready":
+			routerAccessCondition = meta.FindStatusCondition(resource.Status.Conditions, v2alpha1.CONDITION_TYPE_READY)
+		default:
+			routerAccessCondition = meta.FindStatusCondition(resource.Status.Conditions, v2alpha1.CONDITION_TYPE_CONFIGURED)
+		}
+
+		if routerAccessCondition != nil {
+			isConditionFound = true
+			isConditionTrue = routerAccessCondition.Status == metav1.ConditionTrue
+		}
+
+		if resource != nil && isConditionFound && isConditionTrue {
+			return nil
+		}
+
+		if resource != nil && isConditionFound && !isConditionTrue {
+			return fmt.Errorf("error in the condition")
+		}
+
+		return fmt.Errorf("error getting the resource")
+	})
+
+	if err != nil && routerAccessCondition == nil {
+		return fmt.Errorf("RouterAccess %q is not yet %s, check the status for more information\n", cmd.name, cmd.status)
+	} else if err != nil && routerAccessCondition.Status == metav1.ConditionFalse {
+		return fmt.Errorf("RouterAccess %q is not yet %s: %s\n", cmd.name, cmd.status, routerAccessCondition.Message)
+	}
+
+	fmt.Printf("RouterAccess %q is updated\n", cmd.name)
+	return nil
+}
+
+func (cmd *CmdRouterAccessUpdate) InputToOptions() {
+	cmd.status = cmd.Flags.Wait
+}
@@ -0,0 +1,267 @@
+package kube
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func existingRouterAccess() *v2alpha1.RouterAccess {
+	return &v2alpha1.RouterAccess{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "my-access",
+			Namespace: "test",
+		},
+		Spec: v2alpha1.RouterAccessSpec{
+			AccessType: "loadbalancer",
+			Roles: []v2alpha1.RouterAccessRole{
+				{Name: "inter-router", Port: 55671},
+				{Name: "edge", Port: 45671},
+			},
+			TlsCredentials:         "my-access",
+			GenerateTlsCredentials: true,
+		},
+		Status: v2alpha1.RouterAccessStatus{
+			Status: v2alpha1.Status{
+				Conditions: []v1.Condition{
+					{
+						Type:   "Ready",
+						Status: "True",
+					},
+				},
+			},
+			Endpoints: []v2alpha1.Endpoint{
+				{Name: "inter-router", Host: "10.0.0.1", Port: "55671"},
+			},
+		},
+	}
+}
+
+func TestCmdRouterAccessUpdate_ValidateInput(t *testing.T) {
+	type test struct {
+		name                string
+		args                []string
+		flags               common.CommandRouterAccessUpdateFlags
+		changedFlags        map[string]string
+		k8sObjects          []runtime.Object
+		skupperObjects      []runtime.Object
+		skupperErrorMessage string
+		expectedError       string
+		expectedSettings    *v2alpha1.RouterAccessSpec
+	}
+
+	testTable := []test{
+		{
+			name:                "missing CRD",
+			args:                []string{"my-access"},
+			skupperErrorMessage: utils.CrdErr,
+			expectedError:       utils.CrdHelpErr,
+		},
+		{
+			name:          "router access does not exist",
+			args:          []string{"my-access"},
+			flags:         common.CommandRouterAccessUpdateFlags{Timeout: time.Minute},
+			expectedError: "router access my-access must exist in namespace test to be updated",
+		},
+		{
+			name:           "router access name is not specified",
+			flags:          common.CommandRouterAccessUpdateFlags{Timeout: time.Minute},
+			skupperObjects: []runtime.Object{existingRouterAccess()},
+			expectedError:  "router access name must be configured",
+		},
+		{
+			name:           "more than one argument is specified",
+			args:           []string{"my", "access"},
+			flags:          common.CommandRouterAccessUpdateFlags{Timeout: time.Minute},
+			skupperObjects: []runtime.Object{existingRouterAccess()},
+			expectedError:  "only one argument is allowed for this command",
+		},
+		{
+			name:           "role port is not valid",
+			args:           []string{"my-access"},
+			flags:          common.CommandRouterAccessUpdateFlags{Roles: []string{"edge:abc"}, Timeout: time.Minute},
+			skupperObjects: []runtime.Object{existingRouterAccess()},
+			expectedError:  "port for role \"edge\" is not valid: strconv.Atoi: parsing \"abc\": invalid syntax",
+		},
+		{
+			name:           "access type is not valid",
+			args:           []string{"my-access"},
+			flags:          common.CommandRouterAccessUpdateFlags{AccessType: "tunnel", Timeout: time.Minute},
+			skupperObjects: []runtime.Object{existingRouterAccess()},
+			expectedError:  "access type is not valid: value tunnel not allowed. It should be one of this options: [local loadbalancer route nodeport ingress-nginx contour-http-proxy gateway]",
+		},
+		{
+			name:           "tls credentials no longer generated and secret does not exist",
+			args:           []string{"my-access"},
+			flags:          common.CommandRouterAccessUpdateFlags{Timeout: time.Minute},
+			changedFlags:   map[string]string{common.FlagNameGenerateTlsCredentials: "false"},
+			skupperObjects: []runtime.Object{existingRouterAccess()},
+			expectedError:  "tls-secret is not valid: does not exist",
+		},
+		{
+			name:           "wait status is not valid",
+			args:           []string{"my-access"},
+			flags:          common.CommandRouterAccessUpdateFlags{Timeout: time.Minute, Wait: "pending"},
+			skupperObjects: []runtime.Object{existingRouterAccess()},
+			expectedError:  "status is not valid: value pending not allowed. It should be one of this options: [ready configured none]",
+		},
+		{
+			name:           "unspecified options keep their value",
+			args:           []string{"my-access"},
+			flags:          common.CommandRouterAccessUpdateFlags{AccessType: "nodeport", Timeout: time.Minute},
+			skupperObjects: []runtime.Object{existingRouterAccess()},
+			expectedSettings: &v2alpha1.RouterAccessSpec{
+				AccessType:             "nodeport",
+				Roles:                  existingRouterAccess().Spec.Roles,
+				TlsCredentials:         "my-access",
+				GenerateTlsCredentials: true,
+			},
+		},
+		{
+			name: "all options updated",
+			args: []string{"my-access"},
+			flags: common.CommandRouterAccessUpdateFlags{
+				Roles:                   []string{"edge:8443"},
+				AccessType:              "route",
+				TlsCredentials:          "my-secret",
+				Issuer:                  "my-ca",
+				BindHost:                "0.0.0.0",
+				SubjectAlternativeNames: []string{"example.com"},
+				Timeout:                 time.Minute,
+			},
+			changedFlags: map[string]string{common.FlagNameGenerateTlsCredentials: "false"},
+			k8sObjects: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-secret",
+						Namespace: "test",
+					},
+				},
+			},
+			skupperObjects: []runtime.Object{existingRouterAccess()},
+			expectedSettings: &v2alpha1.RouterAccessSpec{
+				AccessType:              "route",
+				Roles:                   []v2alpha1.RouterAccessRole{{Name: "edge", Port: 8443}},
+				TlsCredentials:          "my-secret",
+				Issuer:                  "my-ca",
+				BindHost:                "0.0.0.0",
+				SubjectAlternativeNames: []string{"example.com"},
+			},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdRouterAccessUpdateWithMocks("test", test.k8sObjects, test.skupperObjects, test.skupperErrorMessage)
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+			command.CobraCmd = &cobra.Command{Use: "update"}
+			command.CobraCmd.Flags().BoolVar(&command.Flags.GenerateTlsCredentials, common.FlagNameGenerateTlsCredentials, false, "")
+			for name, value := range test.changedFlags {
+				assert.Assert(t, command.CobraCmd.Flags().Set(name, value))
+			}
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+			if test.expectedSettings != nil {
+				assert.DeepEqual(t, command.newSettings, *test.expectedSettings)
+			}
+		})
+	}
+}
+
+func TestCmdRouterAccessUpdate_Run(t *testing.T) {
+	command, err := newCmdRouterAccessUpdateWithMocks("test", nil, []runtime.Object{existingRouterAccess()}, "")
+	assert.Assert(t, err)
+	command.Flags = &common.CommandRouterAccessUpdateFlags{Roles: []string{"edge:8443"}, Timeout: time.Minute}
+	assert.Assert(t, command.ValidateInput([]string{"my-access"}))
+	command.InputToOptions()
+
+	assert.Assert(t, command.Run())
+
+	routerAccess, err := command.client.RouterAccesses("test").Get(t.Context(), "my-access", v1.GetOptions{})
+	assert.Assert(t, err)
+	assert.DeepEqual(t, routerAccess.Spec.Roles, []v2alpha1.RouterAccessRole{{Name: "edge", Port: 8443}})
+	assert.Equal(t, routerAccess.Spec.AccessType, "loadbalancer")
+	assert.Equal(t, routerAccess.Spec.TlsCredentials, "my-access")
+}
+
+func TestCmdRouterAccessUpdate_WaitUntil(t *testing.T) {
+	type test struct {
+		name           string
+		status         string
+		skupperObjects []runtime.Object
+		expectError    bool
+	}
+
+	testTable := []test{
+		{
+			name:           "router access is ready",
+			status:         "ready",
+			skupperObjects: []runtime.Object{existingRouterAccess()},
+		},
+		{
+			name:           "router access is not configured",
+			status:         "configured",
+			skupperObjects: []runtime.Object{existingRouterAccess()},
+			expectError:    true,
+		},
+		{
+			name:        "router access is not returned",
+			status:      "ready",
+			expectError: true,
+		},
+		{
+			name:   "user does not wait",
+			status: "none",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdRouterAccessUpdateWithMocks("test", nil, test.skupperObjects, "")
+		assert.Assert(t, err)
+
+		cmd.name = "my-access"
+		cmd.Flags = &common.CommandRouterAccessUpdateFlags{Timeout: time.Second}
+		cmd.status = test.status
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.WaitUntil()
+			if test.expectError {
+				assert.Check(t, err != nil)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdRouterAccessUpdateWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdRouterAccessUpdate, error) {
+
+	// We make sure the interval is appropriate
+	utils.SetRetryProfile(utils.TestRetryProfile)
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdRouterAccessUpdate := &CmdRouterAccessUpdate{
+		client:     client.GetSkupperClient().SkupperV2alpha1(),
+		KubeClient: client.GetKubeClient(),
+		namespace:  namespace,
+	}
+	return cmdRouterAccessUpdate, nil
+}
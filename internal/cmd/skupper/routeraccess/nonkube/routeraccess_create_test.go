@@ -0,0 +1,131 @@
+package nonkube
+
+import (
+	"os"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+)
+
+func TestCmdRouterAccessCreate_ValidateInput(t *testing.T) {
+	type test struct {
+		name              string
+		args              []string
+		flags             *common.CommandRouterAccessCreateFlags
+		cobraGenericFlags map[string]string
+		expectedError     string
+	}
+
+	testTable := []test{
+		{
+			name:          "router access name is not specified",
+			args:          []string{},
+			flags:         &common.CommandRouterAccessCreateFlags{Roles: []string{"edge:45671"}},
+			expectedError: "router access name must be configured",
+		},
+		{
+			name:          "router access name is empty",
+			args:          []string{""},
+			flags:         &common.CommandRouterAccessCreateFlags{Roles: []string{"edge:45671"}},
+			expectedError: "router access name must not be empty",
+		},
+		{
+			name:          "router access name is not valid",
+			args:          []string{"my access"},
+			flags:         &common.CommandRouterAccessCreateFlags{Roles: []string{"edge:45671"}},
+			expectedError: "router access name is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])*(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])*)*$",
+		},
+		{
+			name:          "no roles",
+			args:          []string{"my-access"},
+			flags:         &common.CommandRouterAccessCreateFlags{},
+			expectedError: "at least one role must be configured",
+		},
+		{
+			name:          "role without port",
+			args:          []string{"my-access"},
+			flags:         &common.CommandRouterAccessCreateFlags{Roles: []string{"edge"}},
+			expectedError: "role \"edge\" is not valid: expected role:port",
+		},
+		{
+			name:          "tls credentials are not valid",
+			args:          []string{"my-access"},
+			flags:         &common.CommandRouterAccessCreateFlags{Roles: []string{"edge:45671"}, TlsCredentials: "my secret"},
+			expectedError: "tls-credentials is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])*(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])*)*$",
+		},
+		{
+			name:          "bind host is not valid",
+			args:          []string{"my-access"},
+			flags:         &common.CommandRouterAccessCreateFlags{Roles: []string{"edge:45671"}, BindHost: "not valid"},
+			expectedError: "bind host is not valid: a valid IP address or hostname is expected",
+		},
+		{
+			name:              "kubernetes flags are not valid on this platform",
+			args:              []string{"my-access"},
+			flags:             &common.CommandRouterAccessCreateFlags{Roles: []string{"edge:45671"}},
+			cobraGenericFlags: map[string]string{common.FlagNameContext: "test", common.FlagNameKubeconfig: "test"},
+		},
+		{
+			name: "flags all valid",
+			args: []string{"my-access"},
+			flags: &common.CommandRouterAccessCreateFlags{
+				Roles:                   []string{"inter-router:55671", "edge:45671"},
+				TlsCredentials:          "my-secret",
+				BindHost:                "0.0.0.0",
+				SubjectAlternativeNames: []string{"example.com", "10.0.0.1"},
+			},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdRouterAccessCreate{Flags: &common.CommandRouterAccessCreateFlags{}}
+			command.CobraCmd = &cobra.Command{Use: "test"}
+
+			if test.flags != nil {
+				command.Flags = test.flags
+			}
+
+			for name, value := range test.cobraGenericFlags {
+				command.CobraCmd.Flags().String(name, value, "")
+			}
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdRouterAccessCreate_Run(t *testing.T) {
+	if os.Getuid() == 0 {
+		api.DefaultRootDataHome = t.TempDir()
+	} else {
+		t.Setenv("XDG_DATA_HOME", t.TempDir())
+	}
+
+	command := &CmdRouterAccessCreate{
+		Flags: &common.CommandRouterAccessCreateFlags{
+			Roles:    []string{"inter-router:55671", "edge:45671"},
+			BindHost: "0.0.0.0",
+		},
+		namespace: "test",
+	}
+	command.routerAccessHandler = fs.NewRouterAccessHandler(command.namespace)
+	assert.Assert(t, command.ValidateInput([]string{"my-access"}))
+	command.InputToOptions()
+
+	assert.Assert(t, command.Run())
+
+	routerAccess, err := command.routerAccessHandler.Update("my-access")
+	assert.Assert(t, err)
+	assert.DeepEqual(t, routerAccess.Spec.Roles, []v2alpha1.RouterAccessRole{
+		{Name: "inter-router", Port: 55671},
+		{Name: "edge", Port: 45671},
+	})
+	assert.Equal(t, routerAccess.Spec.BindHost, "0.0.0.0")
+}
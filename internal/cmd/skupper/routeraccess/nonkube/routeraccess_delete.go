@@ -0,0 +1,87 @@
+package nonkube
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/spf13/cobra"
+)
+
+type CmdRouterAccessDelete struct {
+	routerAccessHandler *fs.RouterAccessHandler
+	CobraCmd            *cobra.Command
+	Flags               *common.CommandRouterAccessDeleteFlags
+	namespace           string
+	routerAccessName    string
+}
+
+func NewCmdRouterAccessDelete() *CmdRouterAccessDelete {
+	return &CmdRouterAccessDelete{}
+}
+
+func (cmd *CmdRouterAccessDelete) NewClient(cobraCommand *cobra.Command, args []string) {
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace) != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String() != "" {
+		cmd.namespace = cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String()
+	}
+
+	cmd.routerAccessHandler = fs.NewRouterAccessHandler(cmd.namespace)
+}
+
+func (cmd *CmdRouterAccessDelete) ValidateInput(args []string) error {
+	var validationErrors []error
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameContext) != nil && cmd.CobraCmd.Flag(common.FlagNameContext).Value.String() != "" {
+		fmt.Println("Warning: --context flag is not supported on this platform")
+	}
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig) != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig).Value.String() != "" {
+		fmt.Println("Warning: --kubeconfig flag is not supported on this platform")
+	}
+
+	resourceStringValidator := validator.NewResourceStringValidator()
+
+	// Validate arguments name
+	if len(args) < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("router access name must be specified"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("router access name must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("router access name is not valid: %s", err))
+		} else {
+			cmd.routerAccessName = args[0]
+		}
+	}
+
+	if cmd.routerAccessName != "" {
+		// Validate that there is already a router access with this name
+		routerAccess, err := cmd.routerAccessHandler.Update(cmd.routerAccessName)
+		if routerAccess == nil || err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("router access %s does not exist", cmd.routerAccessName))
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdRouterAccessDelete) InputToOptions() {
+	if cmd.namespace == "" {
+		cmd.namespace = "default"
+	}
+}
+
+func (cmd *CmdRouterAccessDelete) Run() error {
+	err := cmd.routerAccessHandler.Delete(cmd.routerAccessName)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *CmdRouterAccessDelete) WaitUntil() error { return nil }
@@ -0,0 +1,74 @@
+package nonkube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+)
+
+func TestCmdRouterAccessDelete_ValidateInput(t *testing.T) {
+	type test struct {
+		name          string
+		args          []string
+		expectedError string
+	}
+
+	testTable := []test{
+		{
+			name:          "router access name is not specified",
+			args:          []string{},
+			expectedError: "router access name must be specified",
+		},
+		{
+			name:          "router access name is empty",
+			args:          []string{""},
+			expectedError: "router access name must not be empty",
+		},
+		{
+			name:          "more than one argument is specified",
+			args:          []string{"my", "access"},
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:          "router access does not exist",
+			args:          []string{"no-access"},
+			expectedError: "router access no-access does not exist",
+		},
+		{
+			name: "router access exists",
+			args: []string{"my-access"},
+		},
+	}
+
+	handler := addRouterAccess(t, "test")
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdRouterAccessDelete{
+				Flags:               &common.CommandRouterAccessDeleteFlags{},
+				CobraCmd:            &cobra.Command{Use: "test"},
+				namespace:           "test",
+				routerAccessHandler: handler,
+			}
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdRouterAccessDelete_Run(t *testing.T) {
+	handler := addRouterAccess(t, "test")
+	command := &CmdRouterAccessDelete{
+		namespace:           "test",
+		routerAccessName:    "my-access",
+		routerAccessHandler: handler,
+	}
+	command.InputToOptions()
+
+	assert.Assert(t, command.Run())
+	_, err := handler.Update("my-access")
+	assert.Assert(t, err != nil)
+	assert.Assert(t, command.Run() != nil)
+}
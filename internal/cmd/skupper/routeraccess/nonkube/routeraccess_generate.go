@@ -0,0 +1,142 @@
+package nonkube
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CmdRouterAccessGenerate struct {
+	CobraCmd                *cobra.Command
+	Flags                   *common.CommandRouterAccessGenerateFlags
+	namespace               string
+	routerAccessName        string
+	roles                   []v2alpha1.RouterAccessRole
+	tlsCredentials          string
+	bindHost                string
+	subjectAlternativeNames []string
+	output                  string
+}
+
+func NewCmdRouterAccessGenerate() *CmdRouterAccessGenerate {
+	return &CmdRouterAccessGenerate{}
+}
+
+func (cmd *CmdRouterAccessGenerate) NewClient(cobraCommand *cobra.Command, args []string) {
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace) != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String() != "" {
+		cmd.namespace = cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String()
+	}
+}
+
+func (cmd *CmdRouterAccessGenerate) ValidateInput(args []string) error {
+	var validationErrors []error
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameContext) != nil && cmd.CobraCmd.Flag(common.FlagNameContext).Value.String() != "" {
+		fmt.Println("Warning: --context flag is not supported on this platform")
+	}
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig) != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig).Value.String() != "" {
+		fmt.Println("Warning: --kubeconfig flag is not supported on this platform")
+	}
+
+	resourceStringValidator := validator.NewResourceStringValidator()
+	hostStringValidator := validator.NewHostStringValidator()
+	outputTypeValidator := validator.NewOptionValidator(common.OutputTypes)
+
+	// Validate arguments name
+	if len(args) < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("router access name must be configured"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("router access name must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("router access name is not valid: %s", err))
+		} else {
+			cmd.routerAccessName = args[0]
+		}
+	}
+
+	// Validate flags
+	if len(cmd.Flags.Roles) == 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("at least one role must be configured"))
+	} else if roles, err := utils.ParseRouterAccessRoles(cmd.Flags.Roles, common.RouterRoles); err != nil {
+		validationErrors = append(validationErrors, err)
+	} else {
+		cmd.roles = roles
+	}
+
+	if cmd.Flags.TlsCredentials != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.TlsCredentials)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("tls-credentials is not valid: %s", err))
+		}
+	}
+
+	if cmd.Flags.BindHost != "" {
+		ok, _ := hostStringValidator.Evaluate(cmd.Flags.BindHost)
+		if !ok && net.ParseIP(cmd.Flags.BindHost) == nil {
+			validationErrors = append(validationErrors, fmt.Errorf("bind host is not valid: a valid IP address or hostname is expected"))
+		}
+	}
+
+	for _, san := range cmd.Flags.SubjectAlternativeNames {
+		ok, _ := hostStringValidator.Evaluate(san)
+		if !ok && net.ParseIP(san) == nil {
+			validationErrors = append(validationErrors, fmt.Errorf("subject alternative name %q is not valid: a valid IP address or hostname is expected", san))
+		}
+	}
+
+	if cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		}
+	}
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdRouterAccessGenerate) InputToOptions() {
+	if cmd.namespace == "" {
+		cmd.namespace = "default"
+	}
+
+	cmd.tlsCredentials = cmd.Flags.TlsCredentials
+	cmd.bindHost = cmd.Flags.BindHost
+	cmd.subjectAlternativeNames = cmd.Flags.SubjectAlternativeNames
+	cmd.output = cmd.Flags.Output
+}
+
+func (cmd *CmdRouterAccessGenerate) Run() error {
+	routerAccessResource := v2alpha1.RouterAccess{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "RouterAccess",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cmd.routerAccessName,
+			Namespace: cmd.namespace,
+		},
+		Spec: v2alpha1.RouterAccessSpec{
+			Roles:                   cmd.roles,
+			TlsCredentials:          cmd.tlsCredentials,
+			BindHost:                cmd.bindHost,
+			SubjectAlternativeNames: cmd.subjectAlternativeNames,
+		},
+	}
+
+	encodedOutput, err := utils.Encode(cmd.output, routerAccessResource)
+	fmt.Println(encodedOutput)
+	return err
+}
+
+func (cmd *CmdRouterAccessGenerate) WaitUntil() error { return nil }
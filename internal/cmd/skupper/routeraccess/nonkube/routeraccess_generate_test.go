@@ -0,0 +1,64 @@
+package nonkube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+)
+
+func TestCmdRouterAccessGenerate_ValidateInput(t *testing.T) {
+	type test struct {
+		name          string
+		args          []string
+		flags         *common.CommandRouterAccessGenerateFlags
+		expectedError string
+	}
+
+	testTable := []test{
+		{
+			name:          "router access name is not specified",
+			flags:         &common.CommandRouterAccessGenerateFlags{Roles: []string{"edge:45671"}},
+			expectedError: "router access name must be configured",
+		},
+		{
+			name:          "no roles",
+			args:          []string{"my-access"},
+			flags:         &common.CommandRouterAccessGenerateFlags{},
+			expectedError: "at least one role must be configured",
+		},
+		{
+			name:          "output format is not valid",
+			args:          []string{"my-access"},
+			flags:         &common.CommandRouterAccessGenerateFlags{Roles: []string{"edge:45671"}, Output: "not-supported"},
+			expectedError: "output type is not valid: value not-supported not allowed. It should be one of this options: [json yaml]",
+		},
+		{
+			name:  "flags all valid",
+			args:  []string{"my-access"},
+			flags: &common.CommandRouterAccessGenerateFlags{Roles: []string{"edge:45671"}, BindHost: "127.0.0.1", Output: "yaml"},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdRouterAccessGenerate{Flags: test.flags, CobraCmd: &cobra.Command{Use: "test"}}
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdRouterAccessGenerate_Run(t *testing.T) {
+	command := &CmdRouterAccessGenerate{
+		Flags:    &common.CommandRouterAccessGenerateFlags{Roles: []string{"edge:45671"}, Output: "yaml"},
+		CobraCmd: &cobra.Command{Use: "test"},
+	}
+	assert.Assert(t, command.ValidateInput([]string{"my-access"}))
+	command.InputToOptions()
+	assert.Equal(t, command.namespace, "default")
+
+	assert.Assert(t, command.Run())
+}
@@ -0,0 +1,129 @@
+package nonkube
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/spf13/cobra"
+)
+
+type CmdRouterAccessStatus struct {
+	routerAccessHandler *fs.RouterAccessHandler
+	CobraCmd            *cobra.Command
+	Flags               *common.CommandRouterAccessStatusFlags
+	namespace           string
+	routerAccessName    string
+	output              string
+}
+
+func NewCmdRouterAccessStatus() *CmdRouterAccessStatus {
+	return &CmdRouterAccessStatus{}
+}
+
+func (cmd *CmdRouterAccessStatus) NewClient(cobraCommand *cobra.Command, args []string) {
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace) != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String() != "" {
+		cmd.namespace = cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String()
+	}
+
+	cmd.routerAccessHandler = fs.NewRouterAccessHandler(cmd.namespace)
+}
+
+func (cmd *CmdRouterAccessStatus) ValidateInput(args []string) error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	outputTypeValidator := validator.NewOptionValidator(common.OutputTypes)
+
+	// Validate arguments name if specified
+	if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if len(args) == 1 {
+		if args[0] == "" {
+			validationErrors = append(validationErrors, fmt.Errorf("router access name must not be empty"))
+		} else {
+			ok, err := resourceStringValidator.Evaluate(args[0])
+			if !ok {
+				validationErrors = append(validationErrors, fmt.Errorf("router access name is not valid: %s", err))
+			} else {
+				cmd.routerAccessName = args[0]
+			}
+		}
+	}
+
+	if cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		} else {
+			cmd.output = cmd.Flags.Output
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdRouterAccessStatus) Run() error {
+	if cmd.routerAccessName == "" {
+		resources, err := cmd.routerAccessHandler.List()
+		if err != nil || resources == nil || len(resources) == 0 {
+			fmt.Println("No router accesses found")
+			return err
+		}
+		if cmd.output != "" {
+			for _, resource := range resources {
+				encodedOutput, err := utils.Encode(cmd.output, resource)
+				if err != nil {
+					return err
+				}
+				fmt.Println(encodedOutput)
+			}
+		} else {
+			tw := tabwriter.NewWriter(os.Stdout, 8, 8, 1, '\t', tabwriter.TabIndent)
+			_, _ = fmt.Fprintln(tw, fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s",
+				"NAME", "STATUS", "ROLES", "BIND-HOST", "TLS-CREDENTIALS", "MESSAGE"))
+			for _, resource := range resources {
+				fmt.Fprintln(tw, fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s",
+					resource.Name, resource.Status.StatusType,
+					strings.Join(utils.FormatRouterAccessRoles(resource.Spec.Roles), ","),
+					resource.Spec.BindHost, resource.Spec.TlsCredentials, resource.Status.Message))
+			}
+			_ = tw.Flush()
+		}
+	} else {
+		resource, err := cmd.routerAccessHandler.Get(cmd.routerAccessName)
+		if err != nil || resource == nil {
+			return fmt.Errorf("router access %s does not exist in namespace %s", cmd.routerAccessName, cmd.namespace)
+		}
+		if cmd.output != "" {
+			encodedOutput, err := utils.Encode(cmd.output, resource)
+			if err != nil {
+				return err
+			}
+			fmt.Println(encodedOutput)
+		} else {
+			tw := tabwriter.NewWriter(os.Stdout, 8, 8, 1, '\t', tabwriter.TabIndent)
+			fmt.Fprintln(tw, fmt.Sprintf("Name:\t%s\nStatus:\t%s\nRoles:\t%s\nBind Host:\t%s\nTLS Credentials:\t%s\nSubject Alternative Names:\t%s\nMessage:\t%s\n",
+				resource.Name, resource.Status.StatusType,
+				strings.Join(utils.FormatRouterAccessRoles(resource.Spec.Roles), ","),
+				resource.Spec.BindHost, resource.Spec.TlsCredentials,
+				strings.Join(resource.Spec.SubjectAlternativeNames, ","), resource.Status.Message))
+			_ = tw.Flush()
+		}
+	}
+
+	return nil
+}
+
+func (cmd *CmdRouterAccessStatus) InputToOptions() {
+	if cmd.namespace == "" {
+		cmd.namespace = "default"
+	}
+}
+
+func (cmd *CmdRouterAccessStatus) WaitUntil() error { return nil }
@@ -0,0 +1,123 @@
+package nonkube
+
+import (
+	"os"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+)
+
+func TestCmdRouterAccessStatus_ValidateInput(t *testing.T) {
+	type test struct {
+		name          string
+		args          []string
+		flags         *common.CommandRouterAccessStatusFlags
+		expectedError string
+	}
+
+	testTable := []test{
+		{
+			name:          "router access name is empty",
+			args:          []string{""},
+			flags:         &common.CommandRouterAccessStatusFlags{},
+			expectedError: "router access name must not be empty",
+		},
+		{
+			name:          "more than one argument is specified",
+			args:          []string{"my", "access"},
+			flags:         &common.CommandRouterAccessStatusFlags{},
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:          "bad output type",
+			args:          []string{"my-access"},
+			flags:         &common.CommandRouterAccessStatusFlags{Output: "not-supported"},
+			expectedError: "output type is not valid: value not-supported not allowed. It should be one of this options: [json yaml]",
+		},
+		{
+			name:  "no args",
+			flags: &common.CommandRouterAccessStatusFlags{},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdRouterAccessStatus{
+				Flags:    test.flags,
+				CobraCmd: &cobra.Command{Use: "test"},
+			}
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdRouterAccessStatus_Run(t *testing.T) {
+	type test struct {
+		name             string
+		routerAccessName string
+		output           string
+		errorMessage     string
+	}
+
+	testTable := []test{
+		{
+			name: "runs ok listing router accesses",
+		},
+		{
+			name:   "runs ok listing router accesses with yaml output",
+			output: "yaml",
+		},
+		{
+			name:             "runs ok showing a router access",
+			routerAccessName: "my-access",
+		},
+		{
+			name:             "runs ok showing a router access with json output",
+			routerAccessName: "my-access",
+			output:           "json",
+		},
+		{
+			name:             "router access does not exist",
+			routerAccessName: "no-access",
+			errorMessage:     "router access no-access does not exist in namespace test",
+		},
+	}
+
+	handler := addRouterAccess(t, "test")
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdRouterAccessStatus{
+				namespace:           "test",
+				routerAccessName:    test.routerAccessName,
+				output:              test.output,
+				routerAccessHandler: handler,
+			}
+
+			err := command.Run()
+			if test.errorMessage != "" {
+				assert.Error(t, err, test.errorMessage)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+func TestCmdRouterAccessStatus_RunNoDirectory(t *testing.T) {
+	if os.Getuid() == 0 {
+		api.DefaultRootDataHome = t.TempDir()
+	} else {
+		t.Setenv("XDG_DATA_HOME", t.TempDir())
+	}
+	command := &CmdRouterAccessStatus{
+		namespace:           "empty",
+		routerAccessHandler: fs.NewRouterAccessHandler("empty"),
+	}
+	assert.Assert(t, command.Run() != nil)
+}
@@ -0,0 +1,148 @@
+package nonkube
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CmdRouterAccessUpdate struct {
+	routerAccessHandler *fs.RouterAccessHandler
+	CobraCmd            *cobra.Command
+	Flags               *common.CommandRouterAccessUpdateFlags
+	namespace           string
+	routerAccessName    string
+	newSettings         v2alpha1.RouterAccessSpec
+}
+
+func NewCmdRouterAccessUpdate() *CmdRouterAccessUpdate {
+	return &CmdRouterAccessUpdate{}
+}
+
+func (cmd *CmdRouterAccessUpdate) NewClient(cobraCommand *cobra.Command, args []string) {
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace) != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String() != "" {
+		cmd.namespace = cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String()
+	}
+
+	cmd.routerAccessHandler = fs.NewRouterAccessHandler(cmd.namespace)
+}
+
+func (cmd *CmdRouterAccessUpdate) ValidateInput(args []string) error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	hostStringValidator := validator.NewHostStringValidator()
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameContext) != nil && cmd.CobraCmd.Flag(common.FlagNameContext).Value.String() != "" {
+		fmt.Println("Warning: --context flag is not supported on this platform")
+	}
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig) != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig).Value.String() != "" {
+		fmt.Println("Warning: --kubeconfig flag is not supported on this platform")
+	}
+
+	// Validate arguments name
+	if len(args) < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("router access name must be configured"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("router access name must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("router access name is not valid: %s", err))
+		} else {
+			cmd.routerAccessName = args[0]
+		}
+	}
+
+	// Validate that there is already a router access with this name in the namespace
+	if cmd.routerAccessName != "" {
+		// Update reads the latest configuration from the input directory
+		routerAccess, err := cmd.routerAccessHandler.Update(cmd.routerAccessName)
+		if routerAccess == nil || err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("router access %s must exist in namespace %s to be updated", cmd.routerAccessName, cmd.namespace))
+		} else {
+			// save existing values
+			cmd.newSettings = routerAccess.Spec
+		}
+	}
+
+	// Validate flags
+	if len(cmd.Flags.Roles) > 0 {
+		roles, err := utils.ParseRouterAccessRoles(cmd.Flags.Roles, common.RouterRoles)
+		if err != nil {
+			validationErrors = append(validationErrors, err)
+		} else {
+			cmd.newSettings.Roles = roles
+		}
+	}
+	if cmd.Flags.TlsCredentials != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.TlsCredentials)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("tls-credentials is not valid: %s", err))
+		} else {
+			cmd.newSettings.TlsCredentials = cmd.Flags.TlsCredentials
+		}
+	}
+	if cmd.Flags.BindHost != "" {
+		ok, _ := hostStringValidator.Evaluate(cmd.Flags.BindHost)
+		if !ok && net.ParseIP(cmd.Flags.BindHost) == nil {
+			validationErrors = append(validationErrors, fmt.Errorf("bind host is not valid: a valid IP address or hostname is expected"))
+		} else {
+			cmd.newSettings.BindHost = cmd.Flags.BindHost
+		}
+	}
+	if len(cmd.Flags.SubjectAlternativeNames) > 0 {
+		valid := true
+		for _, san := range cmd.Flags.SubjectAlternativeNames {
+			ok, _ := hostStringValidator.Evaluate(san)
+			if !ok && net.ParseIP(san) == nil {
+				valid = false
+				validationErrors = append(validationErrors, fmt.Errorf("subject alternative name %q is not valid: a valid IP address or hostname is expected", san))
+			}
+		}
+		if valid {
+			cmd.newSettings.SubjectAlternativeNames = cmd.Flags.SubjectAlternativeNames
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdRouterAccessUpdate) InputToOptions() {
+	if cmd.namespace == "" {
+		cmd.namespace = "default"
+	}
+}
+
+func (cmd *CmdRouterAccessUpdate) Run() error {
+	routerAccessResource := v2alpha1.RouterAccess{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "RouterAccess",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cmd.routerAccessName,
+			Namespace: cmd.namespace,
+		},
+		Spec: cmd.newSettings,
+	}
+
+	err := cmd.routerAccessHandler.Add(routerAccessResource)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (cmd *CmdRouterAccessUpdate) WaitUntil() error { return nil }
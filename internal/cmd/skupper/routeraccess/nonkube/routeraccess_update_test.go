@@ -0,0 +1,135 @@
+package nonkube
+
+import (
+	"os"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func addRouterAccess(t *testing.T, namespace string) *fs.RouterAccessHandler {
+	t.Helper()
+	if os.Getuid() == 0 {
+		api.DefaultRootDataHome = t.TempDir()
+	} else {
+		t.Setenv("XDG_DATA_HOME", t.TempDir())
+	}
+	handler := fs.NewRouterAccessHandler(namespace)
+	assert.Assert(t, handler.Add(v2alpha1.RouterAccess{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "RouterAccess",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-access",
+			Namespace: namespace,
+		},
+		Spec: v2alpha1.RouterAccessSpec{
+			Roles: []v2alpha1.RouterAccessRole{
+				{Name: "inter-router", Port: 55671},
+				{Name: "edge", Port: 45671},
+			},
+			BindHost: "0.0.0.0",
+		},
+	}))
+	return handler
+}
+
+func TestCmdRouterAccessUpdate_ValidateInput(t *testing.T) {
+	type test struct {
+		name             string
+		args             []string
+		flags            *common.CommandRouterAccessUpdateFlags
+		expectedError    string
+		expectedSettings *v2alpha1.RouterAccessSpec
+	}
+
+	testTable := []test{
+		{
+			name:          "router access does not exist",
+			args:          []string{"no-access"},
+			flags:         &common.CommandRouterAccessUpdateFlags{},
+			expectedError: "router access no-access must exist in namespace test to be updated",
+		},
+		{
+			name:          "router access name is not specified",
+			args:          []string{},
+			flags:         &common.CommandRouterAccessUpdateFlags{},
+			expectedError: "router access name must be configured",
+		},
+		{
+			name:          "more than one argument is specified",
+			args:          []string{"my", "access"},
+			flags:         &common.CommandRouterAccessUpdateFlags{},
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:          "role is not valid",
+			args:          []string{"my-access"},
+			flags:         &common.CommandRouterAccessUpdateFlags{Roles: []string{"edge:0"}},
+			expectedError: "port for role \"edge\" is not valid: must be between 1 and 65535",
+		},
+		{
+			name:          "subject alternative name is not valid",
+			args:          []string{"my-access"},
+			flags:         &common.CommandRouterAccessUpdateFlags{SubjectAlternativeNames: []string{"not valid"}},
+			expectedError: "subject alternative name \"not valid\" is not valid: a valid IP address or hostname is expected",
+		},
+		{
+			name:  "unspecified options keep their value",
+			args:  []string{"my-access"},
+			flags: &common.CommandRouterAccessUpdateFlags{TlsCredentials: "my-secret"},
+			expectedSettings: &v2alpha1.RouterAccessSpec{
+				Roles: []v2alpha1.RouterAccessRole{
+					{Name: "inter-router", Port: 55671},
+					{Name: "edge", Port: 45671},
+				},
+				TlsCredentials: "my-secret",
+				BindHost:       "0.0.0.0",
+			},
+		},
+	}
+
+	handler := addRouterAccess(t, "test")
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdRouterAccessUpdate{
+				Flags:               test.flags,
+				CobraCmd:            &cobra.Command{Use: "test"},
+				namespace:           "test",
+				routerAccessHandler: handler,
+			}
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+			if test.expectedSettings != nil {
+				assert.DeepEqual(t, command.newSettings, *test.expectedSettings)
+			}
+		})
+	}
+}
+
+func TestCmdRouterAccessUpdate_Run(t *testing.T) {
+	handler := addRouterAccess(t, "test")
+	command := &CmdRouterAccessUpdate{
+		Flags:               &common.CommandRouterAccessUpdateFlags{Roles: []string{"edge:8443"}},
+		CobraCmd:            &cobra.Command{Use: "test"},
+		namespace:           "test",
+		routerAccessHandler: handler,
+	}
+	assert.Assert(t, command.ValidateInput([]string{"my-access"}))
+	command.InputToOptions()
+
+	assert.Assert(t, command.Run())
+
+	routerAccess, err := handler.Update("my-access")
+	assert.Assert(t, err)
+	assert.DeepEqual(t, routerAccess.Spec.Roles, []v2alpha1.RouterAccessRole{{Name: "edge", Port: 8443}})
+	assert.Equal(t, routerAccess.Spec.BindHost, "0.0.0.0")
+}
@@ -0,0 +1,196 @@
+package routeraccess
+
+import (
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/routeraccess/kube"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/routeraccess/nonkube"
+	"github.com/skupperproject/skupper/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var defaultRoles = []string{"inter-router:55671", "edge:45671"}
+
+func NewCmdRouterAccess() *cobra.Command {
+
+	cmd := &cobra.Command{
+		Use:   "router-access",
+		Short: "Expose the router of the local site to remote sites",
+		Long: `A router access configures the roles and ports on which the router accepts links from remote sites,
+and how those ports are exposed outside the cluster.`,
+		Example: `skupper router-access create my-access --roles inter-router:55671,edge:45671 --access-type loadbalancer
+skupper router-access status my-access`,
+	}
+
+	platform := common.Platform(config.GetPlatform())
+	cmd.AddCommand(CmdRouterAccessCreateFactory(platform))
+	cmd.AddCommand(CmdRouterAccessStatusFactory(platform))
+	cmd.AddCommand(CmdRouterAccessUpdateFactory(platform))
+	cmd.AddCommand(CmdRouterAccessDeleteFactory(platform))
+	cmd.AddCommand(CmdRouterAccessGenerateFactory(platform))
+
+	return cmd
+}
+
+func CmdRouterAccessCreateFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdRouterAccessCreate()
+	nonKubeCommand := nonkube.NewCmdRouterAccessCreate()
+
+	cmdRouterAccessCreateDesc := common.SkupperCmdDescription{
+		Use:     "create <name>",
+		Short:   "create a router access",
+		Long:    "Create a router access to accept links from remote sites on the given roles and ports.",
+		Example: "skupper router-access create my-access --roles inter-router:55671 --access-type nodeport",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdRouterAccessCreateDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandRouterAccessCreateFlags{}
+
+	cmd.Flags().StringSliceVar(&cmdFlags.Roles, common.FlagNameRoles, defaultRoles, common.FlagDescRoles)
+	cmd.Flags().StringVar(&cmdFlags.TlsCredentials, common.FlagNameTlsCredentials, "", common.FlagDescTlsCredentials)
+	cmd.Flags().StringVar(&cmdFlags.BindHost, common.FlagNameBindHost, "", common.FlagDescBindHost)
+	cmd.Flags().StringSliceVar(&cmdFlags.SubjectAlternativeNames, common.FlagNameSubjectAlternativeNames, []string{}, common.FlagDescSubjectAlternativeNames)
+
+	if configuredPlatform == common.PlatformKubernetes {
+		cmd.Flags().StringVar(&cmdFlags.AccessType, common.FlagNameAccessType, "", common.FlagDescAccessType)
+		cmd.Flags().BoolVar(&cmdFlags.GenerateTlsCredentials, common.FlagNameGenerateTlsCredentials, true, common.FlagDescGenerateTlsCredentials)
+		cmd.Flags().StringVar(&cmdFlags.Issuer, common.FlagNameIssuer, "", common.FlagDescIssuer)
+		cmd.Flags().DurationVar(&cmdFlags.Timeout, common.FlagNameTimeout, 60*time.Second, common.FlagDescTimeout)
+		cmd.Flags().StringVar(&cmdFlags.Wait, common.FlagNameWait, "ready", common.FlagDescWait)
+	}
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
+
+func CmdRouterAccessUpdateFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdRouterAccessUpdate()
+	nonKubeCommand := nonkube.NewCmdRouterAccessUpdate()
+
+	cmdRouterAccessUpdateDesc := common.SkupperCmdDescription{
+		Use:   "update <name>",
+		Short: "update a router access",
+		Long: `Update the roles, ports or TLS settings of a router access.
+	Options that are not specified keep their current value.`,
+		Example: "skupper router-access update my-access --roles edge:8443",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdRouterAccessUpdateDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandRouterAccessUpdateFlags{}
+
+	cmd.Flags().StringSliceVar(&cmdFlags.Roles, common.FlagNameRoles, []string{}, common.FlagDescRoles)
+	cmd.Flags().StringVar(&cmdFlags.TlsCredentials, common.FlagNameTlsCredentials, "", common.FlagDescTlsCredentials)
+	cmd.Flags().StringVar(&cmdFlags.BindHost, common.FlagNameBindHost, "", common.FlagDescBindHost)
+	cmd.Flags().StringSliceVar(&cmdFlags.SubjectAlternativeNames, common.FlagNameSubjectAlternativeNames, []string{}, common.FlagDescSubjectAlternativeNames)
+
+	if configuredPlatform == common.PlatformKubernetes {
+		cmd.Flags().StringVar(&cmdFlags.AccessType, common.FlagNameAccessType, "", common.FlagDescAccessType)
+		cmd.Flags().BoolVar(&cmdFlags.GenerateTlsCredentials, common.FlagNameGenerateTlsCredentials, true, common.FlagDescGenerateTlsCredentials)
+		cmd.Flags().StringVar(&cmdFlags.Issuer, common.FlagNameIssuer, "", common.FlagDescIssuer)
+		cmd.Flags().DurationVar(&cmdFlags.Timeout, common.FlagNameTimeout, 60*time.Second, common.FlagDescTimeout)
+		cmd.Flags().StringVar(&cmdFlags.Wait, common.FlagNameWait, "ready", common.FlagDescWait)
+	}
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
+
+func CmdRouterAccessStatusFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdRouterAccessStatus()
+	nonKubeCommand := nonkube.NewCmdRouterAccessStatus()
+
+	cmdRouterAccessStatusDesc := common.SkupperCmdDescription{
+		Use:     "status <name>",
+		Short:   "get status of router accesses",
+		Long:    "Display status of all router accesses or a specific router access",
+		Example: "skupper router-access status my-access",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdRouterAccessStatusDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandRouterAccessStatusFlags{}
+
+	cmd.Flags().StringVarP(&cmdFlags.Output, common.FlagNameOutput, "o", "", common.FlagDescOutput)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
+
+func CmdRouterAccessDeleteFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdRouterAccessDelete()
+	nonKubeCommand := nonkube.NewCmdRouterAccessDelete()
+
+	cmdRouterAccessDeleteDesc := common.SkupperCmdDescription{
+		Use:     "delete <name>",
+		Short:   "delete a router access",
+		Long:    "Delete a router access <name>. Remote sites can no longer link through it.",
+		Example: "skupper router-access delete my-access",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdRouterAccessDeleteDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandRouterAccessDeleteFlags{}
+
+	if configuredPlatform == common.PlatformKubernetes {
+		cmd.Flags().DurationVar(&cmdFlags.Timeout, common.FlagNameTimeout, 60*time.Second, common.FlagDescTimeout)
+		cmd.Flags().BoolVar(&cmdFlags.Wait, common.FlagNameWait, true, common.FlagDescDeleteWait)
+	}
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
+
+func CmdRouterAccessGenerateFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdRouterAccessGenerate()
+	nonKubeCommand := nonkube.NewCmdRouterAccessGenerate()
+
+	cmdRouterAccessGenerateDesc := common.SkupperCmdDescription{
+		Use:   "generate <name>",
+		Short: "generate a router access resource and output it to a file or screen",
+		Long: `Create a router access to accept links from remote sites on the given roles and ports.
+	generate a router access to evaluate what will be created with router-access create command`,
+		Example: "skupper router-access generate my-access --roles edge:45671",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdRouterAccessGenerateDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandRouterAccessGenerateFlags{}
+
+	cmd.Flags().StringSliceVar(&cmdFlags.Roles, common.FlagNameRoles, defaultRoles, common.FlagDescRoles)
+	cmd.Flags().StringVar(&cmdFlags.TlsCredentials, common.FlagNameTlsCredentials, "", common.FlagDescTlsCredentials)
+	cmd.Flags().StringVar(&cmdFlags.BindHost, common.FlagNameBindHost, "", common.FlagDescBindHost)
+	cmd.Flags().StringSliceVar(&cmdFlags.SubjectAlternativeNames, common.FlagNameSubjectAlternativeNames, []string{}, common.FlagDescSubjectAlternativeNames)
+	cmd.Flags().StringVarP(&cmdFlags.Output, common.FlagNameOutput, "o", "yaml", common.FlagDescOutput)
+
+	if configuredPlatform == common.PlatformKubernetes {
+		cmd.Flags().StringVar(&cmdFlags.AccessType, common.FlagNameAccessType, "", common.FlagDescAccessType)
+		cmd.Flags().BoolVar(&cmdFlags.GenerateTlsCredentials, common.FlagNameGenerateTlsCredentials, true, common.FlagDescGenerateTlsCredentials)
+		cmd.Flags().StringVar(&cmdFlags.Issuer, common.FlagNameIssuer, "", common.FlagDescIssuer)
+	}
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
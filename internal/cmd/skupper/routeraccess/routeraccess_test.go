@@ -0,0 +1,114 @@
+package routeraccess
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gotest.tools/v3/assert"
+)
+
+func TestCmdRouterAccessFactory(t *testing.T) {
+
+	type test struct {
+		name                          string
+		expectedFlagsWithDefaultValue map[string]interface{}
+		command                       *cobra.Command
+	}
+
+	testTable := []test{
+		{
+			name: "CmdRouterAccessCreateFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameRoles:                   "[inter-router:55671,edge:45671]",
+				common.FlagNameTlsCredentials:          "",
+				common.FlagNameBindHost:                "",
+				common.FlagNameSubjectAlternativeNames: "[]",
+				common.FlagNameAccessType:              "",
+				common.FlagNameGenerateTlsCredentials:  "true",
+				common.FlagNameIssuer:                  "",
+				common.FlagNameTimeout:                 "1m0s",
+				common.FlagNameWait:                    "ready",
+			},
+			command: CmdRouterAccessCreateFactory(common.PlatformKubernetes),
+		},
+		{
+			name: "CmdRouterAccessUpdateFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameRoles:                   "[]",
+				common.FlagNameTlsCredentials:          "",
+				common.FlagNameBindHost:                "",
+				common.FlagNameSubjectAlternativeNames: "[]",
+				common.FlagNameAccessType:              "",
+				common.FlagNameGenerateTlsCredentials:  "true",
+				common.FlagNameIssuer:                  "",
+				common.FlagNameTimeout:                 "1m0s",
+				common.FlagNameWait:                    "ready",
+			},
+			command: CmdRouterAccessUpdateFactory(common.PlatformKubernetes),
+		},
+		{
+			name: "CmdRouterAccessStatusFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameOutput: "",
+			},
+			command: CmdRouterAccessStatusFactory(common.PlatformKubernetes),
+		},
+		{
+			name: "CmdRouterAccessDeleteFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameTimeout: "1m0s",
+				common.FlagNameWait:    "true",
+			},
+			command: CmdRouterAccessDeleteFactory(common.PlatformKubernetes),
+		},
+		{
+			name: "CmdRouterAccessGenerateFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameRoles:                   "[inter-router:55671,edge:45671]",
+				common.FlagNameTlsCredentials:          "",
+				common.FlagNameBindHost:                "",
+				common.FlagNameSubjectAlternativeNames: "[]",
+				common.FlagNameAccessType:              "",
+				common.FlagNameGenerateTlsCredentials:  "true",
+				common.FlagNameIssuer:                  "",
+				common.FlagNameOutput:                  "yaml",
+			},
+			command: CmdRouterAccessGenerateFactory(common.PlatformKubernetes),
+		},
+		{
+			name: "CmdRouterAccessCreateFactory on podman",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameRoles:                   "[inter-router:55671,edge:45671]",
+				common.FlagNameTlsCredentials:          "",
+				common.FlagNameBindHost:                "",
+				common.FlagNameSubjectAlternativeNames: "[]",
+			},
+			command: CmdRouterAccessCreateFactory(common.PlatformPodman),
+		},
+	}
+
+	for _, test := range testTable {
+
+		var flagList []string
+		t.Run(test.name, func(t *testing.T) {
+
+			test.command.Flags().VisitAll(func(flag *pflag.Flag) {
+				flagList = append(flagList, flag.Name)
+				assert.Check(t, test.expectedFlagsWithDefaultValue[flag.Name] != nil, fmt.Sprintf("flag %q not expected", flag.Name))
+				assert.Check(t, test.expectedFlagsWithDefaultValue[flag.Name] == flag.DefValue, fmt.Sprintf("default value %q for flag %q not expected", flag.DefValue, flag.Name))
+			})
+
+			assert.Check(t, len(flagList) == len(test.expectedFlagsWithDefaultValue))
+
+			assert.Assert(t, test.command.PreRunE != nil)
+			assert.Assert(t, test.command.Run != nil)
+			assert.Assert(t, test.command.PostRun != nil)
+			assert.Assert(t, test.command.Use != "")
+			assert.Assert(t, test.command.Short != "")
+			assert.Assert(t, test.command.Long != "")
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/kube/client"
+	kubeqdr "github.com/skupperproject/skupper/internal/kube/qdr"
+	"github.com/skupperproject/skupper/internal/qdr"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/generated/client/clientset/versioned/typed/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type CmdSiteDiff struct {
+	Client     skupperv2alpha1.SkupperV2alpha1Interface
+	KubeClient kubernetes.Interface
+	CobraCmd   *cobra.Command
+	Flags      *common.CommandSiteDiffFlags
+	Namespace  string
+	output     string
+}
+
+// RouterConfigDiff holds the pending changes to the configuration of
+// one of the routers of the site.
+type RouterConfigDiff struct {
+	Router  string             `json:"router"`
+	Changes []qdr.ConfigChange `json:"changes"`
+}
+
+type SiteDiff struct {
+	Site    string             `json:"site"`
+	DryRun  bool               `json:"dryRun"`
+	Routers []RouterConfigDiff `json:"routers,omitempty"`
+}
+
+func NewCmdSiteDiff() *CmdSiteDiff {
+
+	skupperCmd := CmdSiteDiff{}
+
+	return &skupperCmd
+}
+
+func (cmd *CmdSiteDiff) NewClient(cobraCommand *cobra.Command, args []string) {
+	cli, err := client.NewClient(cobraCommand.Flag("namespace").Value.String(), cobraCommand.Flag("context").Value.String(), cobraCommand.Flag("kubeconfig").Value.String())
+	utils.HandleError(utils.GenericError, err)
+
+	cmd.Client = cli.GetSkupperClient().SkupperV2alpha1()
+	cmd.KubeClient = cli.GetKubeClient()
+	cmd.Namespace = cli.Namespace
+}
+
+func (cmd *CmdSiteDiff) ValidateInput(args []string) error {
+	var validationErrors []error
+	outputTypeValidator := validator.NewOptionValidator(common.OutputTypes)
+
+	if len(args) > 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("this command does not need any arguments"))
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		} else {
+			cmd.output = cmd.Flags.Output
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdSiteDiff) InputToOptions() {}
+
+func (cmd *CmdSiteDiff) Run() error {
+	siteList, err := cmd.Client.Sites(cmd.Namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return utils.HandleMissingCrds(err)
+	}
+	if len(siteList.Items) == 0 {
+		return fmt.Errorf("there is no skupper site in this namespace")
+	}
+	site := siteList.Items[0]
+	diff, err := cmd.diff()
+	if err != nil {
+		return err
+	}
+	diff.Site = site.Name
+	diff.DryRun = site.IsDryRun()
+
+	if cmd.output != "" {
+		encodedOutput, err := utils.Encode(cmd.output, diff)
+		if err != nil {
+			return err
+		}
+		fmt.Println(encodedOutput)
+		return nil
+	}
+	if len(diff.Routers) == 0 {
+		fmt.Println("There are no pending changes to the router configuration")
+	}
+	for _, router := range diff.Routers {
+		fmt.Printf("Pending changes to the configuration of router %s:\n", router.Router)
+		for _, change := range router.Changes {
+			fmt.Printf("  %s\n", change)
+		}
+	}
+	if !diff.DryRun {
+		fmt.Printf("Site %s is not in dry-run mode, changes are applied as they are made\n", site.Name)
+	} else if len(diff.Routers) > 0 {
+		fmt.Printf("Remove the %s annotation from site %s to apply them\n", v2alpha1.DryRunAnnotation, site.Name)
+	}
+	return nil
+}
+
+func (cmd *CmdSiteDiff) diff() (*SiteDiff, error) {
+	configmaps, err := cmd.KubeClient.CoreV1().ConfigMaps(cmd.Namespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: "internal.skupper.io/router-config",
+	})
+	if err != nil {
+		return nil, err
+	}
+	diff := &SiteDiff{}
+	for _, cm := range configmaps.Items {
+		pending, err := kubeqdr.GetPendingRouterConfig(&cm)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pending configuration for router %s: %s", cm.Name, err)
+		}
+		if pending == nil {
+			continue
+		}
+		live, err := qdr.GetRouterConfigFromConfigMap(&cm)
+		if err != nil {
+			return nil, fmt.Errorf("invalid configuration for router %s: %s", cm.Name, err)
+		}
+		if live == nil {
+			live = &qdr.RouterConfig{}
+		}
+		if changes := qdr.DiffRouterConfig(live, pending).Changes(); len(changes) > 0 {
+			diff.Routers = append(diff.Routers, RouterConfigDiff{
+				Router:  cm.Name,
+				Changes: changes,
+			})
+		}
+	}
+	sort.Slice(diff.Routers, func(i, j int) bool {
+		return diff.Routers[i].Router < diff.Routers[j].Router
+	})
+	return diff, nil
+}
+
+func (cmd *CmdSiteDiff) WaitUntil() error { return nil }
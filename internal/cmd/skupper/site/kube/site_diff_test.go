@@ -0,0 +1,158 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	kubeqdr "github.com/skupperproject/skupper/internal/kube/qdr"
+	"github.com/skupperproject/skupper/internal/qdr"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdSiteDiff_ValidateInput(t *testing.T) {
+	type test struct {
+		name          string
+		args          []string
+		flags         *common.CommandSiteDiffFlags
+		expectedError string
+	}
+
+	testTable := []test{
+		{
+			name:          "argument specified",
+			args:          []string{"my-site"},
+			expectedError: "this command does not need any arguments",
+		},
+		{
+			name:          "bad output",
+			flags:         &common.CommandSiteDiffFlags{Output: "table"},
+			expectedError: "output type is not valid: value table not allowed. It should be one of this options: [json yaml]",
+		},
+		{
+			name:  "ok",
+			flags: &common.CommandSiteDiffFlags{Output: "json"},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdSiteDiff{
+				Namespace: "test",
+				Flags:     test.flags,
+			}
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func routerConfigMap(t *testing.T, name string, live *qdr.RouterConfig, pending *qdr.RouterConfig) *corev1.ConfigMap {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      name,
+			Namespace: "test",
+			Labels: map[string]string{
+				"internal.skupper.io/router-config": "",
+			},
+		},
+	}
+	assert.Assert(t, live.WriteToConfigMap(cm))
+	if pending != nil {
+		data, err := qdr.MarshalRouterConfig(*pending)
+		assert.Assert(t, err)
+		cm.Data[kubeqdr.PendingConfigKey] = data
+	}
+	return cm
+}
+
+func TestCmdSiteDiff_Run(t *testing.T) {
+	live := qdr.InitialConfig("router", "site-id", "version", false, 3)
+	pending := qdr.InitialConfig("router", "site-id", "version", false, 3)
+	pending.AddTcpListener(qdr.TcpEndpoint{Name: "backend", Address: "backend", Port: "1024"})
+
+	type test struct {
+		name            string
+		k8sObjects      []runtime.Object
+		dryRun          bool
+		expectedRouters []RouterConfigDiff
+		errorMessage    string
+	}
+
+	testTable := []test{
+		{
+			name: "no pending changes",
+			k8sObjects: []runtime.Object{
+				routerConfigMap(t, "skupper-router", &live, nil),
+			},
+		},
+		{
+			name:   "pending changes",
+			dryRun: true,
+			k8sObjects: []runtime.Object{
+				routerConfigMap(t, "skupper-router", &live, &pending),
+				routerConfigMap(t, "skupper-router-2", &live, &live),
+			},
+			expectedRouters: []RouterConfigDiff{
+				{
+					Router: "skupper-router",
+					Changes: []qdr.ConfigChange{
+						{Action: qdr.ChangeAdd, Type: "tcpListener", Name: "backend", Detail: "address=backend port=1024"},
+					},
+				},
+			},
+		},
+		{
+			name: "invalid pending config",
+			k8sObjects: []runtime.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "skupper-router",
+						Namespace: "test",
+						Labels: map[string]string{
+							"internal.skupper.io/router-config": "",
+						},
+					},
+					Data: map[string]string{
+						kubeqdr.PendingConfigKey: "not json",
+					},
+				},
+			},
+			errorMessage: "invalid pending configuration for router skupper-router",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			site := &v2alpha1.Site{
+				ObjectMeta: v1.ObjectMeta{
+					Name:        "my-site",
+					Namespace:   "test",
+					Annotations: map[string]string{},
+				},
+			}
+			if test.dryRun {
+				site.Annotations[v2alpha1.DryRunAnnotation] = "true"
+			}
+			command := &CmdSiteDiff{Namespace: "test"}
+			fakeSkupperClient, err := fakeclient.NewFakeClient(command.Namespace, test.k8sObjects, []runtime.Object{site}, "")
+			assert.Assert(t, err)
+			command.Client = fakeSkupperClient.GetSkupperClient().SkupperV2alpha1()
+			command.KubeClient = fakeSkupperClient.GetKubeClient()
+
+			err = command.Run()
+			if test.errorMessage != "" {
+				assert.ErrorContains(t, err, test.errorMessage)
+				return
+			}
+			assert.Assert(t, err)
+			diff, err := command.diff()
+			assert.Assert(t, err)
+			assert.DeepEqual(t, diff.Routers, test.expectedRouters)
+		})
+	}
+}
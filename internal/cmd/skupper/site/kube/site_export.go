@@ -0,0 +1,194 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/kube/client"
+	"github.com/skupperproject/skupper/internal/site/archive"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/generated/client/clientset/versioned/typed/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type CmdSiteExport struct {
+	Client     skupperv2alpha1.SkupperV2alpha1Interface
+	KubeClient kubernetes.Interface
+	CobraCmd   *cobra.Command
+	Flags      *common.CommandSiteExportFlags
+	Namespace  string
+	fileName   string
+	passphrase string
+	site       *v2alpha1.Site
+}
+
+func NewCmdSiteExport() *CmdSiteExport {
+
+	skupperCmd := CmdSiteExport{}
+
+	return &skupperCmd
+}
+
+func (cmd *CmdSiteExport) NewClient(cobraCommand *cobra.Command, args []string) {
+	cli, err := client.NewClient(cobraCommand.Flag("namespace").Value.String(), cobraCommand.Flag("context").Value.String(), cobraCommand.Flag("kubeconfig").Value.String())
+	utils.HandleError(utils.GenericError, err)
+
+	cmd.Client = cli.GetSkupperClient().SkupperV2alpha1()
+	cmd.KubeClient = cli.GetKubeClient()
+	cmd.Namespace = cli.Namespace
+}
+
+func (cmd *CmdSiteExport) ValidateInput(args []string) error {
+	var validationErrors []error
+
+	if len(args) == 0 || args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("file name must be specified"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else {
+		cmd.fileName = args[0]
+	}
+
+	siteList, err := cmd.Client.Sites(cmd.Namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		validationErrors = append(validationErrors, utils.HandleMissingCrds(err))
+	} else if siteList == nil || len(siteList.Items) == 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("there is no skupper site in this namespace"))
+	} else {
+		cmd.site = &siteList.Items[0]
+	}
+
+	if cmd.Flags != nil && cmd.Flags.PassphraseFile != "" {
+		passphrase, err := utils.ReadPassphraseFile(cmd.Flags.PassphraseFile)
+		if err != nil {
+			validationErrors = append(validationErrors, err)
+		} else {
+			cmd.passphrase = passphrase
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdSiteExport) InputToOptions() {}
+
+func (cmd *CmdSiteExport) Run() error {
+	siteArchive := archive.New(cmd.site, cmd.site.GetSiteId(), string(common.PlatformKubernetes))
+
+	routerAccesses, err := cmd.Client.RouterAccesses(cmd.Namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, routerAccess := range routerAccesses.Items {
+		// the router accesses managed by the site are recreated with it
+		if !ownedBySite(routerAccess.ObjectMeta) {
+			siteArchive.AddRouterAccess(routerAccess)
+		}
+	}
+
+	certificates, err := cmd.Client.Certificates(cmd.Namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, certificate := range certificates.Items {
+		if !ownedBySite(certificate.ObjectMeta) {
+			siteArchive.AddCertificate(certificate)
+		}
+		if certificate.Spec.Signing {
+			if err := cmd.addSecret(siteArchive, certificate.Name, true, false); err != nil {
+				return err
+			}
+		}
+	}
+	if err := cmd.addSecret(siteArchive, cmd.site.DefaultIssuer(), true, false); err != nil {
+		return err
+	}
+
+	listeners, err := cmd.Client.Listeners(cmd.Namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, listener := range listeners.Items {
+		siteArchive.AddListener(listener)
+		if err := cmd.addSecret(siteArchive, listener.Spec.TlsCredentials, false, false); err != nil {
+			return err
+		}
+	}
+
+	connectors, err := cmd.Client.Connectors(cmd.Namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, connector := range connectors.Items {
+		siteArchive.AddConnector(connector)
+		if err := cmd.addSecret(siteArchive, connector.Spec.TlsCredentials, false, false); err != nil {
+			return err
+		}
+	}
+
+	links, err := cmd.Client.Links(cmd.Namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, link := range links.Items {
+		siteArchive.AddLink(link)
+		if err := cmd.addSecret(siteArchive, link.Spec.TlsCredentials, false, true); err != nil {
+			return err
+		}
+	}
+
+	accessGrants, err := cmd.Client.AccessGrants(cmd.Namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, accessGrant := range accessGrants.Items {
+		siteArchive.AddAccessGrant(accessGrant)
+	}
+
+	if err := siteArchive.WriteFile(cmd.fileName, cmd.passphrase); err != nil {
+		return fmt.Errorf("unable to write site archive: %w", err)
+	}
+	fmt.Printf("Site %q exported to %s\n", cmd.site.Name, cmd.fileName)
+	if cmd.passphrase == "" {
+		fmt.Println("Warning: the archive holds the private keys of the site unencrypted, use --passphrase-file to encrypt them")
+	}
+	return nil
+}
+
+func (cmd *CmdSiteExport) WaitUntil() error { return nil }
+
+// addSecret adds the named secret to the archive, unless already added.
+// Secrets that do not exist are ignored unless required.
+func (cmd *CmdSiteExport) addSecret(siteArchive *archive.Archive, name string, issuer bool, required bool) error {
+	if name == "" || siteArchive.HasSecret(name) {
+		return nil
+	}
+	secret, err := cmd.KubeClient.CoreV1().Secrets(cmd.Namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) && !required {
+			return nil
+		}
+		return fmt.Errorf("unable to export secret %q: %w", name, err)
+	}
+	if issuer {
+		siteArchive.AddIssuer(*secret)
+	} else {
+		siteArchive.AddSecret(*secret)
+	}
+	return nil
+}
+
+func ownedBySite(meta metav1.ObjectMeta) bool {
+	for _, owner := range meta.OwnerReferences {
+		if owner.Kind == "Site" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,200 @@
+package kube
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/internal/site/archive"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdSiteExport_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		skupperObjects []runtime.Object
+		skupperError   string
+		passphrase     string
+		expectedError  string
+	}
+
+	site := &v2alpha1.Site{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "my-site",
+			Namespace: "test",
+		},
+	}
+
+	testTable := []test{
+		{
+			name:          "missing CRD",
+			args:          []string{"site.tar.gz"},
+			skupperError:  utils.CrdErr,
+			expectedError: utils.CrdHelpErr,
+		},
+		{
+			name:          "there is no site",
+			args:          []string{"site.tar.gz"},
+			expectedError: "there is no skupper site in this namespace",
+		},
+		{
+			name:           "file name is not specified",
+			args:           []string{},
+			skupperObjects: []runtime.Object{site},
+			expectedError:  "file name must be specified",
+		},
+		{
+			name:           "more than one argument was specified",
+			args:           []string{"site.tar.gz", "other.tar.gz"},
+			skupperObjects: []runtime.Object{site},
+			expectedError:  "only one argument is allowed for this command",
+		},
+		{
+			name:           "passphrase file is empty",
+			args:           []string{"site.tar.gz"},
+			skupperObjects: []runtime.Object{site},
+			passphrase:     "\n",
+			expectedError:  "passphrase file is empty",
+		},
+		{
+			name:           "site is exported",
+			args:           []string{"site.tar.gz"},
+			skupperObjects: []runtime.Object{site},
+			passphrase:     "secret",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdSiteExport{
+				Namespace: "test",
+			}
+
+			fakeSkupperClient, err := fakeclient.NewFakeClient(command.Namespace, nil, test.skupperObjects, test.skupperError)
+			assert.Assert(t, err)
+			command.Client = fakeSkupperClient.GetSkupperClient().SkupperV2alpha1()
+			command.KubeClient = fakeSkupperClient.GetKubeClient()
+
+			if test.passphrase != "" {
+				passphraseFile := path.Join(t.TempDir(), "passphrase")
+				assert.Assert(t, os.WriteFile(passphraseFile, []byte(test.passphrase), 0600))
+				command.Flags = &common.CommandSiteExportFlags{PassphraseFile: passphraseFile}
+			}
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdSiteExport_Run(t *testing.T) {
+	siteOwner := []v1.OwnerReference{{Kind: "Site", Name: "my-site"}}
+	skupperObjects := []runtime.Object{
+		&v2alpha1.Site{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "my-site",
+				Namespace: "test",
+				UID:       "11111111-2222-3333-4444-555555555555",
+			},
+			Spec: v2alpha1.SiteSpec{LinkAccess: "default"},
+		},
+		&v2alpha1.RouterAccess{
+			ObjectMeta: v1.ObjectMeta{Name: "skupper-router", Namespace: "test", OwnerReferences: siteOwner},
+		},
+		&v2alpha1.Certificate{
+			ObjectMeta: v1.ObjectMeta{Name: "skupper-site-ca", Namespace: "test", OwnerReferences: siteOwner},
+			Spec:       v2alpha1.CertificateSpec{Subject: "my-site site CA", Signing: true},
+		},
+		&v2alpha1.Listener{
+			ObjectMeta: v1.ObjectMeta{Name: "backend", Namespace: "test"},
+			Spec:       v2alpha1.ListenerSpec{RoutingKey: "backend", Host: "backend", Port: 8080},
+		},
+		&v2alpha1.Connector{
+			ObjectMeta: v1.ObjectMeta{Name: "db", Namespace: "test"},
+			Spec:       v2alpha1.ConnectorSpec{RoutingKey: "db", Host: "db", Port: 5432},
+		},
+		&v2alpha1.Link{
+			ObjectMeta: v1.ObjectMeta{Name: "link-other", Namespace: "test"},
+			Spec:       v2alpha1.LinkSpec{TlsCredentials: "link-other", Cost: 1},
+		},
+	}
+	k8sObjects := []runtime.Object{
+		&corev1.Secret{
+			ObjectMeta: v1.ObjectMeta{Name: "skupper-site-ca", Namespace: "test"},
+			Type:       corev1.SecretTypeTLS,
+			Data:       map[string][]byte{"tls.crt": []byte("ca-cert"), "tls.key": []byte("ca-key")},
+		},
+		&corev1.Secret{
+			ObjectMeta: v1.ObjectMeta{Name: "link-other", Namespace: "test"},
+			Data:       map[string][]byte{"tls.crt": []byte("link-cert"), "tls.key": []byte("link-key")},
+		},
+	}
+
+	type test struct {
+		name         string
+		k8sObjects   []runtime.Object
+		passphrase   string
+		errorMessage string
+	}
+
+	testTable := []test{
+		{
+			name:       "runs ok",
+			k8sObjects: k8sObjects,
+		},
+		{
+			name:       "runs ok with encryption",
+			k8sObjects: k8sObjects,
+			passphrase: "secret",
+		},
+		{
+			name:         "link secret is missing",
+			k8sObjects:   k8sObjects[:1],
+			errorMessage: "unable to export secret \"link-other\"",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdSiteExport{
+				Namespace:  "test",
+				fileName:   path.Join(t.TempDir(), "site.tar.gz"),
+				passphrase: test.passphrase,
+			}
+			fakeSkupperClient, err := fakeclient.NewFakeClient(command.Namespace, test.k8sObjects, skupperObjects, "")
+			assert.Assert(t, err)
+			command.Client = fakeSkupperClient.GetSkupperClient().SkupperV2alpha1()
+			command.KubeClient = fakeSkupperClient.GetKubeClient()
+			command.site = skupperObjects[0].(*v2alpha1.Site)
+
+			err = command.Run()
+			if test.errorMessage != "" {
+				assert.ErrorContains(t, err, test.errorMessage)
+				return
+			}
+			assert.Assert(t, err)
+
+			siteArchive, err := archive.ReadFile(command.fileName, test.passphrase)
+			assert.Assert(t, err)
+			assert.Equal(t, siteArchive.Manifest.SiteId, "11111111-2222-3333-4444-555555555555")
+			assert.Equal(t, siteArchive.Manifest.Platform, string(common.PlatformKubernetes))
+			assert.Equal(t, siteArchive.Site.Name, "my-site")
+			// resources owned by the site are recreated with it
+			assert.Equal(t, len(siteArchive.RouterAccesses), 0)
+			assert.Equal(t, len(siteArchive.Certificates), 0)
+			assert.Equal(t, len(siteArchive.Listeners), 1)
+			assert.Equal(t, len(siteArchive.Connectors), 1)
+			assert.Equal(t, len(siteArchive.Links), 1)
+			assert.Assert(t, siteArchive.HasSecret("skupper-site-ca"))
+			assert.Assert(t, siteArchive.HasSecret("link-other"))
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/kube/client"
+	"github.com/skupperproject/skupper/internal/site/archive"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/generated/client/clientset/versioned/typed/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type CmdSiteImport struct {
+	Client      skupperv2alpha1.SkupperV2alpha1Interface
+	KubeClient  kubernetes.Interface
+	CobraCmd    *cobra.Command
+	Flags       *common.CommandSiteImportFlags
+	Namespace   string
+	fileName    string
+	siteArchive *archive.Archive
+}
+
+func NewCmdSiteImport() *CmdSiteImport {
+
+	skupperCmd := CmdSiteImport{}
+
+	return &skupperCmd
+}
+
+func (cmd *CmdSiteImport) NewClient(cobraCommand *cobra.Command, args []string) {
+	cli, err := client.NewClient(cobraCommand.Flag("namespace").Value.String(), cobraCommand.Flag("context").Value.String(), cobraCommand.Flag("kubeconfig").Value.String())
+	utils.HandleError(utils.GenericError, err)
+
+	cmd.Client = cli.GetSkupperClient().SkupperV2alpha1()
+	cmd.KubeClient = cli.GetKubeClient()
+	cmd.Namespace = cli.Namespace
+}
+
+func (cmd *CmdSiteImport) ValidateInput(args []string) error {
+	var validationErrors []error
+
+	siteList, err := cmd.Client.Sites(cmd.Namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return utils.HandleMissingCrds(err)
+	} else if siteList != nil && len(siteList.Items) > 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("there is already a site created for this namespace"))
+	}
+
+	var passphrase string
+	if cmd.Flags != nil && cmd.Flags.PassphraseFile != "" {
+		passphrase, err = utils.ReadPassphraseFile(cmd.Flags.PassphraseFile)
+		if err != nil {
+			validationErrors = append(validationErrors, err)
+		}
+	}
+
+	if len(args) == 0 || args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("file name must be specified"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if len(validationErrors) == 0 {
+		cmd.fileName = args[0]
+		cmd.siteArchive, err = archive.ReadFile(cmd.fileName, passphrase)
+		if err != nil {
+			validationErrors = append(validationErrors, err)
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdSiteImport) InputToOptions() {}
+
+func (cmd *CmdSiteImport) Run() error {
+	ctx := context.TODO()
+	siteArchive := cmd.siteArchive
+
+	// secrets first, so that the controller does not generate new ones
+	for _, secrets := range [][]*corev1.Secret{siteArchive.Issuers, siteArchive.Secrets} {
+		for _, secret := range secrets {
+			if err := cmd.applySecret(ctx, secret); err != nil {
+				return err
+			}
+		}
+	}
+
+	site := siteArchive.Site.DeepCopy()
+	site.Namespace = cmd.Namespace
+	if siteArchive.Manifest.SiteId != "" {
+		if site.Annotations == nil {
+			site.Annotations = map[string]string{}
+		}
+		site.Annotations[v2alpha1.SiteIdAnnotation] = siteArchive.Manifest.SiteId
+	}
+	if _, err := cmd.Client.Sites(cmd.Namespace).Create(ctx, site, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("unable to create site %q: %w", site.Name, err)
+	}
+	for _, routerAccess := range siteArchive.RouterAccesses {
+		routerAccess.Namespace = cmd.Namespace
+		if _, err := cmd.Client.RouterAccesses(cmd.Namespace).Create(ctx, routerAccess, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("unable to create router access %q: %w", routerAccess.Name, err)
+		}
+	}
+	for _, certificate := range siteArchive.Certificates {
+		certificate.Namespace = cmd.Namespace
+		if _, err := cmd.Client.Certificates(cmd.Namespace).Create(ctx, certificate, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("unable to create certificate %q: %w", certificate.Name, err)
+		}
+	}
+	for _, listener := range siteArchive.Listeners {
+		listener.Namespace = cmd.Namespace
+		if _, err := cmd.Client.Listeners(cmd.Namespace).Create(ctx, listener, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("unable to create listener %q: %w", listener.Name, err)
+		}
+	}
+	for _, connector := range siteArchive.Connectors {
+		connector.Namespace = cmd.Namespace
+		if _, err := cmd.Client.Connectors(cmd.Namespace).Create(ctx, connector, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("unable to create connector %q: %w", connector.Name, err)
+		}
+	}
+	for _, link := range siteArchive.Links {
+		link.Namespace = cmd.Namespace
+		if _, err := cmd.Client.Links(cmd.Namespace).Create(ctx, link, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("unable to create link %q: %w", link.Name, err)
+		}
+	}
+	for _, accessGrant := range siteArchive.AccessGrants {
+		accessGrant.Namespace = cmd.Namespace
+		if _, err := cmd.Client.AccessGrants(cmd.Namespace).Create(ctx, accessGrant, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("unable to create access grant %q: %w", accessGrant.Name, err)
+		}
+	}
+	fmt.Printf("Site %q imported from %s\n", site.Name, cmd.fileName)
+	return nil
+}
+
+func (cmd *CmdSiteImport) WaitUntil() error { return nil }
+
+// applySecret creates the secret, replacing the content of an existing
+// secret with the same name.
+func (cmd *CmdSiteImport) applySecret(ctx context.Context, secret *corev1.Secret) error {
+	secrets := cmd.KubeClient.CoreV1().Secrets(cmd.Namespace)
+	secret = secret.DeepCopy()
+	secret.Namespace = cmd.Namespace
+	_, err := secrets.Create(ctx, secret, metav1.CreateOptions{})
+	if k8serrors.IsAlreadyExists(err) {
+		var current *corev1.Secret
+		current, err = secrets.Get(ctx, secret.Name, metav1.GetOptions{})
+		if err == nil {
+			current.Labels = secret.Labels
+			current.Annotations = secret.Annotations
+			current.Type = secret.Type
+			current.Data = secret.Data
+			_, err = secrets.Update(ctx, current, metav1.UpdateOptions{})
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("unable to import secret %q: %w", secret.Name, err)
+	}
+	return nil
+}
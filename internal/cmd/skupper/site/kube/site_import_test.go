@@ -0,0 +1,161 @@
+package kube
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/internal/site/archive"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func newTestSiteArchive() *archive.Archive {
+	site := &v2alpha1.Site{
+		ObjectMeta: v1.ObjectMeta{Name: "my-site", Namespace: "old"},
+		Spec:       v2alpha1.SiteSpec{LinkAccess: "default"},
+	}
+	siteArchive := archive.New(site, "11111111-2222-3333-4444-555555555555", "kubernetes")
+	siteArchive.AddListener(v2alpha1.Listener{
+		ObjectMeta: v1.ObjectMeta{Name: "backend", Namespace: "old"},
+		Spec:       v2alpha1.ListenerSpec{RoutingKey: "backend", Host: "backend", Port: 8080},
+	})
+	siteArchive.AddLink(v2alpha1.Link{
+		ObjectMeta: v1.ObjectMeta{Name: "link-other", Namespace: "old"},
+		Spec:       v2alpha1.LinkSpec{TlsCredentials: "link-other", Cost: 1},
+	})
+	siteArchive.AddIssuer(corev1.Secret{
+		ObjectMeta: v1.ObjectMeta{Name: "skupper-site-ca", Namespace: "old"},
+		Type:       corev1.SecretTypeTLS,
+		Data:       map[string][]byte{"tls.crt": []byte("ca-cert"), "tls.key": []byte("ca-key")},
+	})
+	siteArchive.AddSecret(corev1.Secret{
+		ObjectMeta: v1.ObjectMeta{Name: "link-other", Namespace: "old"},
+		Data:       map[string][]byte{"tls.crt": []byte("link-cert"), "tls.key": []byte("link-key")},
+	})
+	return siteArchive
+}
+
+func TestCmdSiteImport_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		skupperObjects []runtime.Object
+		skupperError   string
+		expectedError  string
+	}
+
+	fileName := path.Join(t.TempDir(), "site.tar.gz")
+	assert.Assert(t, newTestSiteArchive().WriteFile(fileName, "secret"))
+
+	testTable := []test{
+		{
+			name:          "missing CRD",
+			args:          []string{fileName},
+			skupperError:  utils.CrdErr,
+			expectedError: utils.CrdHelpErr,
+		},
+		{
+			name: "there is already a site",
+			args: []string{fileName},
+			skupperObjects: []runtime.Object{
+				&v2alpha1.Site{
+					ObjectMeta: v1.ObjectMeta{Name: "my-site", Namespace: "test"},
+				},
+			},
+			expectedError: "there is already a site created for this namespace",
+		},
+		{
+			name:          "file name is not specified",
+			args:          []string{},
+			expectedError: "file name must be specified",
+		},
+		{
+			name:          "more than one argument was specified",
+			args:          []string{fileName, "other.tar.gz"},
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:          "archive is encrypted",
+			args:          []string{fileName},
+			expectedError: "the secrets of the site archive are encrypted, a passphrase is required",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdSiteImport{
+				Namespace: "test",
+			}
+
+			fakeSkupperClient, err := fakeclient.NewFakeClient(command.Namespace, nil, test.skupperObjects, test.skupperError)
+			assert.Assert(t, err)
+			command.Client = fakeSkupperClient.GetSkupperClient().SkupperV2alpha1()
+			command.KubeClient = fakeSkupperClient.GetKubeClient()
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdSiteImport_Run(t *testing.T) {
+	type test struct {
+		name       string
+		k8sObjects []runtime.Object
+	}
+
+	testTable := []test{
+		{
+			name: "runs ok",
+		},
+		{
+			name: "existing secret is replaced",
+			k8sObjects: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: v1.ObjectMeta{Name: "skupper-site-ca", Namespace: "test"},
+					Data:       map[string][]byte{"tls.crt": []byte("other")},
+				},
+			},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdSiteImport{
+				Namespace:   "test",
+				fileName:    "site.tar.gz",
+				siteArchive: newTestSiteArchive(),
+			}
+			fakeSkupperClient, err := fakeclient.NewFakeClient(command.Namespace, test.k8sObjects, nil, "")
+			assert.Assert(t, err)
+			command.Client = fakeSkupperClient.GetSkupperClient().SkupperV2alpha1()
+			command.KubeClient = fakeSkupperClient.GetKubeClient()
+
+			assert.Assert(t, command.Run())
+
+			ctx := context.TODO()
+			site, err := command.Client.Sites("test").Get(ctx, "my-site", v1.GetOptions{})
+			assert.Assert(t, err)
+			assert.Equal(t, site.GetSiteId(), "11111111-2222-3333-4444-555555555555")
+			assert.Equal(t, site.Spec.LinkAccess, "default")
+			_, err = command.Client.Listeners("test").Get(ctx, "backend", v1.GetOptions{})
+			assert.Assert(t, err)
+			_, err = command.Client.Links("test").Get(ctx, "link-other", v1.GetOptions{})
+			assert.Assert(t, err)
+
+			ca, err := command.KubeClient.CoreV1().Secrets("test").Get(ctx, "skupper-site-ca", v1.GetOptions{})
+			assert.Assert(t, err)
+			assert.Equal(t, string(ca.Data["tls.crt"]), "ca-cert")
+			assert.Equal(t, string(ca.Data["tls.key"]), "ca-key")
+			linkSecret, err := command.KubeClient.CoreV1().Secrets("test").Get(ctx, "link-other", v1.GetOptions{})
+			assert.Assert(t, err)
+			assert.Equal(t, string(linkSecret.Data["tls.key"]), "link-key")
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package nonkube
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	nonkubecommon "github.com/skupperproject/skupper/internal/nonkube/common"
+	"github.com/skupperproject/skupper/internal/qdr"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"github.com/spf13/cobra"
+)
+
+type CmdSiteDiff struct {
+	CobraCmd  *cobra.Command
+	Flags     *common.CommandSiteDiffFlags
+	namespace string
+	output    string
+}
+
+type ResourceDiff struct {
+	Added   []string `json:"added,omitempty"`
+	Updated []string `json:"updated,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+}
+
+// SiteDiff holds the changes from the input resources of a namespace
+// that have not been loaded by its site yet.
+type SiteDiff struct {
+	Namespace      string             `json:"namespace"`
+	Listeners      ResourceDiff       `json:"listeners,omitempty"`
+	Connectors     ResourceDiff       `json:"connectors,omitempty"`
+	Links          ResourceDiff       `json:"links,omitempty"`
+	RestartReasons []string           `json:"restartReasons,omitempty"`
+	RouterConfig   []qdr.ConfigChange `json:"routerConfig,omitempty"`
+}
+
+func NewCmdSiteDiff() *CmdSiteDiff {
+	return &CmdSiteDiff{}
+}
+
+func (cmd *CmdSiteDiff) NewClient(cobraCommand *cobra.Command, args []string) {
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace) != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String() != "" {
+		cmd.namespace = cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String()
+	}
+}
+
+func (cmd *CmdSiteDiff) ValidateInput(args []string) error {
+	var validationErrors []error
+	outputTypeValidator := validator.NewOptionValidator(common.OutputTypes)
+
+	if len(args) > 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("this command does not need any arguments"))
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		} else {
+			cmd.output = cmd.Flags.Output
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdSiteDiff) InputToOptions() {
+	if cmd.namespace == "" {
+		cmd.namespace = "default"
+	}
+}
+
+func (cmd *CmdSiteDiff) Run() error {
+	diff, err := cmd.diff()
+	if err != nil {
+		return err
+	}
+
+	if cmd.output != "" {
+		encodedOutput, err := utils.Encode(cmd.output, diff)
+		if err != nil {
+			return err
+		}
+		fmt.Println(encodedOutput)
+		return nil
+	}
+	if diff.empty() {
+		fmt.Println("The site is up to date with its input resources")
+		return nil
+	}
+	printResourceDiff("Listener", diff.Listeners)
+	printResourceDiff("Connector", diff.Connectors)
+	printResourceDiff("Link", diff.Links)
+	if len(diff.RestartReasons) > 0 {
+		fmt.Println("The site must be reloaded to apply the changes:")
+		for _, reason := range diff.RestartReasons {
+			fmt.Printf("  %s\n", reason)
+		}
+	}
+	if len(diff.RouterConfig) > 0 {
+		fmt.Println("Changes to the router configuration:")
+		for _, change := range diff.RouterConfig {
+			fmt.Printf("  %s\n", change)
+		}
+	}
+	return nil
+}
+
+func printResourceDiff(kind string, diff ResourceDiff) {
+	for _, name := range diff.Added {
+		fmt.Printf("%s %q added\n", kind, name)
+	}
+	for _, name := range diff.Updated {
+		fmt.Printf("%s %q updated\n", kind, name)
+	}
+	for _, name := range diff.Removed {
+		fmt.Printf("%s %q removed\n", kind, name)
+	}
+}
+
+// diff compares the input resources against those loaded by the site,
+// working out the router configuration changes as done when they are
+// applied to a running site.
+func (cmd *CmdSiteDiff) diff() (*SiteDiff, error) {
+	desired, err := loadSiteState(cmd.namespace, api.InputSiteStatePath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load input resources: %w", err)
+	}
+	active, err := loadSiteState(cmd.namespace, api.LoadedSiteStatePath)
+	if err != nil {
+		return nil, fmt.Errorf("site in namespace %q has not been loaded yet: %w", cmd.namespace, err)
+	}
+	validator := &nonkubecommon.SiteStateValidator{}
+	if err = validator.Validate(desired); err != nil {
+		return nil, fmt.Errorf("invalid input resources: %w", err)
+	}
+	changes := nonkubecommon.DiffSiteStates(active, desired)
+	diff := &SiteDiff{
+		Namespace:      cmd.namespace,
+		Listeners:      ResourceDiff(changes.Listeners),
+		Connectors:     ResourceDiff(changes.Connectors),
+		Links:          ResourceDiff(changes.Links),
+		RestartReasons: changes.RestartReasons,
+	}
+	if changes.Empty() || changes.RequiresRestart() {
+		return diff, nil
+	}
+
+	runtimeState, err := loadSiteState(cmd.namespace, api.RuntimeSiteStatePath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load runtime resources: %w", err)
+	}
+	routerConfig, err := nonkubecommon.LoadRouterConfig(cmd.namespace)
+	if err != nil {
+		return nil, err
+	}
+	nonkubecommon.ApplySiteStateChanges(runtimeState, desired, changes)
+	runtimeState.SiteId = routerConfig.GetSiteMetadata().Id
+	desiredConfig := nonkubecommon.CopySiteState(runtimeState).ToRouterConfig(nonkubecommon.DefaultSslProfileBasePath, "")
+	for name := range desiredConfig.SslProfiles {
+		if _, ok := routerConfig.SslProfiles[name]; !ok {
+			diff.RestartReasons = append(diff.RestartReasons, fmt.Sprintf("TLS profile %q is not yet defined", name))
+		}
+	}
+	updatedConfig := *routerConfig
+	updatedConfig.Bridges = desiredConfig.Bridges
+	updatedConfig.Connectors = desiredConfig.Connectors
+	diff.RouterConfig = qdr.DiffRouterConfig(routerConfig, &updatedConfig).Changes()
+	return diff, nil
+}
+
+func (d *SiteDiff) empty() bool {
+	return nonkubecommon.ResourceChanges(d.Listeners).Empty() &&
+		nonkubecommon.ResourceChanges(d.Connectors).Empty() &&
+		nonkubecommon.ResourceChanges(d.Links).Empty() &&
+		len(d.RestartReasons) == 0 && len(d.RouterConfig) == 0
+}
+
+func loadSiteState(namespace string, internalPath api.InternalPath) (*api.SiteState, error) {
+	loader := &nonkubecommon.FileSystemSiteStateLoader{
+		Path: api.GetInternalOutputPath(namespace, internalPath),
+	}
+	return loader.Load()
+}
+
+func (cmd *CmdSiteDiff) WaitUntil() error { return nil }
@@ -0,0 +1,167 @@
+package nonkube
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	nonkubecommon "github.com/skupperproject/skupper/internal/nonkube/common"
+	"github.com/skupperproject/skupper/internal/qdr"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCmdSiteDiff_ValidateInput(t *testing.T) {
+	type test struct {
+		name          string
+		args          []string
+		flags         *common.CommandSiteDiffFlags
+		expectedError string
+	}
+
+	testTable := []test{
+		{
+			name:          "argument specified",
+			args:          []string{"my-site"},
+			expectedError: "this command does not need any arguments",
+		},
+		{
+			name:          "bad output",
+			flags:         &common.CommandSiteDiffFlags{Output: "table"},
+			expectedError: "output type is not valid: value table not allowed. It should be one of this options: [json yaml]",
+		},
+		{
+			name:  "ok",
+			flags: &common.CommandSiteDiffFlags{Output: "yaml"},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdSiteDiff{Flags: test.flags}
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdSiteDiff_Run(t *testing.T) {
+	if os.Getuid() == 0 {
+		api.DefaultRootDataHome = t.TempDir()
+	} else {
+		t.Setenv("XDG_DATA_HOME", t.TempDir())
+	}
+
+	newListener := func(name string, port int) *v2alpha1.Listener {
+		return &v2alpha1.Listener{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Listener",
+				APIVersion: "skupper.io/v2alpha1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: v2alpha1.ListenerSpec{
+				RoutingKey: name,
+				Host:       "0.0.0.0",
+				Port:       port,
+				Type:       "tcp",
+			},
+		}
+	}
+	newSiteState := func(namespace string) *api.SiteState {
+		siteState := api.NewSiteState(false)
+		siteState.SiteId = "site-id"
+		siteState.Site = &v2alpha1.Site{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Site",
+				APIVersion: "skupper.io/v2alpha1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "my-site",
+			},
+		}
+		siteState.Listeners["backend"] = newListener("backend", 8080)
+		siteState.SetNamespace(namespace)
+		return siteState
+	}
+
+	tests := []struct {
+		name                 string
+		modify               func(siteState *api.SiteState)
+		notLoaded            bool
+		expectedListeners    ResourceDiff
+		expectedReasons      []string
+		expectedRouterConfig []qdr.ConfigChange
+		expectedError        string
+	}{
+		{
+			name:   "no-changes",
+			modify: func(siteState *api.SiteState) {},
+		},
+		{
+			name: "listener-changes",
+			modify: func(siteState *api.SiteState) {
+				siteState.Listeners["backend"].Spec.Port = 9090
+				siteState.Listeners["db"] = newListener("db", 5432)
+				siteState.Listeners["db"].Namespace = siteState.GetNamespace()
+			},
+			expectedListeners: ResourceDiff{
+				Added:   []string{"db"},
+				Updated: []string{"backend"},
+			},
+			expectedRouterConfig: []qdr.ConfigChange{
+				{Action: qdr.ChangeUpdate, Type: "tcpListener", Name: "backend", Detail: "address=backend host=0.0.0.0 port=9090"},
+				{Action: qdr.ChangeAdd, Type: "tcpListener", Name: "db", Detail: "address=db host=0.0.0.0 port=5432"},
+			},
+		},
+		{
+			name: "site-changes",
+			modify: func(siteState *api.SiteState) {
+				siteState.Site.Spec.LinkAccess = "default"
+			},
+			expectedReasons: []string{"Site has changed"},
+		},
+		{
+			name:          "not-loaded",
+			modify:        func(siteState *api.SiteState) {},
+			notLoaded:     true,
+			expectedError: `site in namespace "not-loaded" has not been loaded yet`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			namespace := test.name
+			for _, dir := range []api.InternalPath{api.InputSiteStatePath, api.LoadedSiteStatePath, api.RuntimeSiteStatePath, api.RouterConfigPath} {
+				assert.Assert(t, os.MkdirAll(api.GetInternalOutputPath(namespace, dir), 0755))
+			}
+			siteState := newSiteState(namespace)
+			routerConfig := nonkubecommon.CopySiteState(siteState).ToRouterConfig(nonkubecommon.DefaultSslProfileBasePath, "")
+			routerConfigJson, err := qdr.MarshalRouterConfig(routerConfig)
+			assert.Assert(t, err)
+			assert.Assert(t, os.WriteFile(path.Join(api.GetInternalOutputPath(namespace, api.RouterConfigPath), "skrouterd.json"), []byte(routerConfigJson), 0644))
+			assert.Assert(t, api.MarshalSiteState(*siteState, api.GetInternalOutputPath(namespace, api.RuntimeSiteStatePath)))
+			if !test.notLoaded {
+				assert.Assert(t, api.MarshalSiteState(*siteState, api.GetInternalOutputPath(namespace, api.LoadedSiteStatePath)))
+			}
+			test.modify(siteState)
+			assert.Assert(t, api.MarshalSiteState(*siteState, api.GetInternalOutputPath(namespace, api.InputSiteStatePath)))
+
+			command := &CmdSiteDiff{namespace: namespace}
+			err = command.Run()
+			if test.expectedError != "" {
+				assert.ErrorContains(t, err, test.expectedError)
+				return
+			}
+			assert.Assert(t, err)
+			diff, err := command.diff()
+			assert.Assert(t, err)
+			assert.DeepEqual(t, diff.Listeners, test.expectedListeners)
+			assert.DeepEqual(t, diff.RestartReasons, test.expectedReasons)
+			assert.DeepEqual(t, diff.RouterConfig, test.expectedRouterConfig)
+		})
+	}
+}
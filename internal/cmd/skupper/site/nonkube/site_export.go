@@ -0,0 +1,185 @@
+package nonkube
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/config"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	nonkubecommon "github.com/skupperproject/skupper/internal/nonkube/common"
+	"github.com/skupperproject/skupper/internal/site/archive"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CmdSiteExport struct {
+	CobraCmd   *cobra.Command
+	Flags      *common.CommandSiteExportFlags
+	namespace  string
+	fileName   string
+	passphrase string
+	siteState  *api.SiteState
+}
+
+func NewCmdSiteExport() *CmdSiteExport {
+	return &CmdSiteExport{}
+}
+
+func (cmd *CmdSiteExport) NewClient(cobraCommand *cobra.Command, args []string) {
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace) != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String() != "" {
+		cmd.namespace = cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String()
+	}
+}
+
+func (cmd *CmdSiteExport) ValidateInput(args []string) error {
+	var validationErrors []error
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameContext) != nil && cmd.CobraCmd.Flag(common.FlagNameContext).Value.String() != "" {
+		fmt.Println("Warning: --context flag is not supported on this platform")
+	}
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig) != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig).Value.String() != "" {
+		fmt.Println("Warning: --kubeconfig flag is not supported on this platform")
+	}
+
+	if len(args) == 0 || args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("file name must be specified"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else {
+		cmd.fileName = args[0]
+	}
+
+	pathProvider := fs.PathProvider{Namespace: cmd.namespace}
+	siteStateLoader := &nonkubecommon.FileSystemSiteStateLoader{
+		Path: pathProvider.GetNamespace(),
+	}
+	siteState, err := siteStateLoader.Load()
+	if err != nil {
+		validationErrors = append(validationErrors, fmt.Errorf("there is no site defined in this namespace"))
+	} else {
+		cmd.siteState = siteState
+	}
+
+	if cmd.Flags != nil && cmd.Flags.PassphraseFile != "" {
+		passphrase, err := utils.ReadPassphraseFile(cmd.Flags.PassphraseFile)
+		if err != nil {
+			validationErrors = append(validationErrors, err)
+		} else {
+			cmd.passphrase = passphrase
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdSiteExport) InputToOptions() {
+	if cmd.namespace == "" {
+		cmd.namespace = "default"
+	}
+}
+
+func (cmd *CmdSiteExport) Run() error {
+	siteState := cmd.siteState
+	// the identity of an initialized site is the one its router runs with
+	siteId := siteState.SiteId
+	if routerConfig, err := nonkubecommon.LoadRouterConfig(cmd.namespace); err == nil {
+		siteId = routerConfig.GetSiteMetadata().Id
+	}
+	platform := string(config.GetPlatform())
+	platformLoader := &nonkubecommon.NamespacePlatformLoader{}
+	if loaded, err := platformLoader.Load(cmd.namespace); err == nil {
+		platform = loaded
+	}
+	siteArchive := archive.New(siteState.Site, siteId, platform)
+
+	for _, name := range sortedKeys(siteState.RouterAccesses) {
+		siteArchive.AddRouterAccess(*siteState.RouterAccesses[name])
+	}
+	for _, name := range sortedKeys(siteState.Certificates) {
+		siteArchive.AddCertificate(*siteState.Certificates[name])
+	}
+	for _, name := range sortedKeys(siteState.Listeners) {
+		siteArchive.AddListener(*siteState.Listeners[name])
+	}
+	for _, name := range sortedKeys(siteState.Connectors) {
+		siteArchive.AddConnector(*siteState.Connectors[name])
+	}
+	for _, name := range sortedKeys(siteState.Links) {
+		siteArchive.AddLink(*siteState.Links[name])
+	}
+	for _, name := range sortedKeys(siteState.Grants) {
+		siteArchive.AddAccessGrant(*siteState.Grants[name])
+	}
+	for _, name := range sortedKeys(siteState.Secrets) {
+		siteArchive.AddSecret(*siteState.Secrets[name])
+	}
+
+	issuersPath := api.GetInternalOutputPath(cmd.namespace, api.IssuersPath)
+	issuers, err := os.ReadDir(issuersPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("unable to read certificate authorities: %w", err)
+	}
+	for _, issuer := range issuers {
+		if !issuer.IsDir() {
+			continue
+		}
+		secret, err := readSecretDir(path.Join(issuersPath, issuer.Name()), issuer.Name())
+		if err != nil {
+			return err
+		}
+		siteArchive.AddIssuer(*secret)
+	}
+
+	if err := siteArchive.WriteFile(cmd.fileName, cmd.passphrase); err != nil {
+		return fmt.Errorf("unable to write site archive: %w", err)
+	}
+	fmt.Printf("Site %q exported to %s\n", siteState.Site.Name, cmd.fileName)
+	if cmd.passphrase == "" {
+		fmt.Println("Warning: the archive holds the private keys of the site unencrypted, use --passphrase-file to encrypt them")
+	}
+	return nil
+}
+
+func (cmd *CmdSiteExport) WaitUntil() error { return nil }
+
+// readSecretDir returns a secret holding the files of a certificate
+// directory, such as tls.crt, tls.key and ca.crt.
+func readSecretDir(dir string, name string) (*corev1.Secret, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s: %w", dir, err)
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Type:       corev1.SecretTypeTLS,
+		Data:       map[string][]byte{},
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("unable to read %s: %w", entry.Name(), err)
+		}
+		secret.Data[entry.Name()] = data
+	}
+	return secret, nil
+}
+
+func sortedKeys[T any](resources map[string]T) []string {
+	keys := make([]string, 0, len(resources))
+	for key := range resources {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,121 @@
+package nonkube
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/site/archive"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"gotest.tools/v3/assert"
+)
+
+func TestCmdSiteExport_ValidateInput(t *testing.T) {
+	type test struct {
+		name          string
+		namespace     string
+		args          []string
+		passphrase    string
+		expectedError string
+	}
+
+	if os.Getuid() == 0 {
+		api.DefaultRootDataHome = t.TempDir()
+	} else {
+		t.Setenv("XDG_DATA_HOME", t.TempDir())
+	}
+	importCmd := &CmdSiteImport{
+		namespace:   "existing",
+		siteArchive: newTestSiteArchive(),
+	}
+	assert.Assert(t, importCmd.Run())
+
+	testTable := []test{
+		{
+			name:          "there is no site",
+			namespace:     "empty",
+			args:          []string{"site.tar.gz"},
+			expectedError: "there is no site defined in this namespace",
+		},
+		{
+			name:          "file name is not specified",
+			namespace:     "existing",
+			args:          []string{},
+			expectedError: "file name must be specified",
+		},
+		{
+			name:          "more than one argument was specified",
+			namespace:     "existing",
+			args:          []string{"site.tar.gz", "other.tar.gz"},
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:          "passphrase file is empty",
+			namespace:     "existing",
+			args:          []string{"site.tar.gz"},
+			passphrase:    "\n",
+			expectedError: "passphrase file is empty",
+		},
+		{
+			name:       "site is exported",
+			namespace:  "existing",
+			args:       []string{"site.tar.gz"},
+			passphrase: "secret",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdSiteExport{
+				namespace: test.namespace,
+			}
+
+			if test.passphrase != "" {
+				passphraseFile := filepath.Join(t.TempDir(), "passphrase")
+				assert.Assert(t, os.WriteFile(passphraseFile, []byte(test.passphrase), 0600))
+				command.Flags = &common.CommandSiteExportFlags{PassphraseFile: passphraseFile}
+			}
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdSiteExport_Run(t *testing.T) {
+	if os.Getuid() == 0 {
+		api.DefaultRootDataHome = t.TempDir()
+	} else {
+		t.Setenv("XDG_DATA_HOME", t.TempDir())
+	}
+	importCmd := &CmdSiteImport{
+		namespace:   "exported",
+		siteArchive: newTestSiteArchive(),
+	}
+	assert.Assert(t, importCmd.Run())
+
+	// certificate authorities are exported from the runtime directory
+	issuerPath := filepath.Join(api.GetInternalOutputPath("exported", api.IssuersPath), "skupper-site-ca")
+	assert.Assert(t, os.MkdirAll(issuerPath, 0755))
+	assert.Assert(t, os.WriteFile(filepath.Join(issuerPath, "tls.crt"), []byte("runtime-ca-cert"), 0640))
+	assert.Assert(t, os.WriteFile(filepath.Join(issuerPath, "tls.key"), []byte("runtime-ca-key"), 0640))
+
+	command := &CmdSiteExport{
+		namespace: "exported",
+	}
+	fileName := filepath.Join(t.TempDir(), "site.tar.gz")
+	assert.Assert(t, command.ValidateInput([]string{fileName}))
+	command.passphrase = "secret"
+	assert.Assert(t, command.Run())
+
+	siteArchive, err := archive.ReadFile(fileName, "secret")
+	assert.Assert(t, err)
+	assert.Equal(t, siteArchive.Manifest.SiteName, "my-site")
+	assert.Equal(t, siteArchive.Manifest.SiteId, "11111111-2222-3333-4444-555555555555")
+	assert.Equal(t, len(siteArchive.Listeners), 1)
+	assert.Equal(t, len(siteArchive.Links), 1)
+	assert.Assert(t, siteArchive.HasSecret("link-other"))
+	assert.Equal(t, len(siteArchive.Issuers), 1)
+	assert.Equal(t, string(siteArchive.Issuers[0].Data["tls.key"]), "runtime-ca-key")
+}
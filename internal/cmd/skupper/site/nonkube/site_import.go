@@ -0,0 +1,140 @@
+package nonkube
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/internal/site/archive"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type CmdSiteImport struct {
+	CobraCmd    *cobra.Command
+	Flags       *common.CommandSiteImportFlags
+	siteHandler *fs.SiteHandler
+	namespace   string
+	fileName    string
+	siteArchive *archive.Archive
+}
+
+func NewCmdSiteImport() *CmdSiteImport {
+	return &CmdSiteImport{}
+}
+
+func (cmd *CmdSiteImport) NewClient(cobraCommand *cobra.Command, args []string) {
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace) != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String() != "" {
+		cmd.namespace = cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String()
+	}
+
+	cmd.siteHandler = fs.NewSiteHandler(cmd.namespace)
+}
+
+func (cmd *CmdSiteImport) ValidateInput(args []string) error {
+	var validationErrors []error
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameContext) != nil && cmd.CobraCmd.Flag(common.FlagNameContext).Value.String() != "" {
+		fmt.Println("Warning: --context flag is not supported on this platform")
+	}
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig) != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig).Value.String() != "" {
+		fmt.Println("Warning: --kubeconfig flag is not supported on this platform")
+	}
+
+	sites, err := cmd.siteHandler.List(fs.GetOptions{})
+	if err == nil && len(sites) > 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("there is already a site defined in this namespace"))
+	}
+
+	var passphrase string
+	if cmd.Flags != nil && cmd.Flags.PassphraseFile != "" {
+		passphrase, err = utils.ReadPassphraseFile(cmd.Flags.PassphraseFile)
+		if err != nil {
+			validationErrors = append(validationErrors, err)
+		}
+	}
+
+	if len(args) == 0 || args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("file name must be specified"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if len(validationErrors) == 0 {
+		cmd.fileName = args[0]
+		cmd.siteArchive, err = archive.ReadFile(cmd.fileName, passphrase)
+		if err != nil {
+			validationErrors = append(validationErrors, err)
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdSiteImport) InputToOptions() {
+	if cmd.namespace == "" {
+		cmd.namespace = "default"
+	}
+}
+
+func (cmd *CmdSiteImport) Run() error {
+	siteArchive := cmd.siteArchive
+	siteState := api.NewSiteState(false)
+	siteState.Site = siteArchive.Site.DeepCopy()
+	// the site keeps the identity it had when exported
+	siteState.SiteId = siteArchive.Manifest.SiteId
+	siteState.Site.UID = types.UID(siteArchive.Manifest.SiteId)
+	for _, routerAccess := range siteArchive.RouterAccesses {
+		siteState.RouterAccesses[routerAccess.Name] = routerAccess
+	}
+	for _, certificate := range siteArchive.Certificates {
+		siteState.Certificates[certificate.Name] = certificate
+	}
+	for _, listener := range siteArchive.Listeners {
+		siteState.Listeners[listener.Name] = listener
+	}
+	for _, connector := range siteArchive.Connectors {
+		siteState.Connectors[connector.Name] = connector
+	}
+	for _, link := range siteArchive.Links {
+		siteState.Links[link.Name] = link
+	}
+	for _, accessGrant := range siteArchive.AccessGrants {
+		siteState.Grants[accessGrant.Name] = accessGrant
+	}
+	for _, secret := range siteArchive.Secrets {
+		siteState.Secrets[secret.Name] = secret
+	}
+	siteState.SetNamespace(cmd.namespace)
+
+	// certificate authorities are provided as user input, so that they
+	// are used instead of generating new ones
+	issuersPath := path.Join(api.GetDefaultOutputPath(cmd.namespace), string(api.InputIssuersPath))
+	for _, issuer := range siteArchive.Issuers {
+		issuerPath := path.Join(issuersPath, issuer.Name)
+		if err := os.MkdirAll(issuerPath, 0755); err != nil {
+			return fmt.Errorf("unable to create directory %s: %w", issuerPath, err)
+		}
+		for fileName, data := range issuer.Data {
+			if err := os.WriteFile(path.Join(issuerPath, fileName), data, 0640); err != nil {
+				return fmt.Errorf("unable to write certificate authority %s: %w", issuer.Name, err)
+			}
+		}
+	}
+
+	inputPath := path.Join(api.GetDefaultOutputPath(cmd.namespace), string(api.InputSiteStatePath))
+	if err := os.MkdirAll(inputPath, 0755); err != nil {
+		return fmt.Errorf("unable to create directory %s: %w", inputPath, err)
+	}
+	if err := api.MarshalSiteState(*siteState, inputPath); err != nil {
+		return fmt.Errorf("unable to write site resources: %w", err)
+	}
+	fmt.Printf("Site %q imported from %s, run \"skupper system start\" to start it\n", siteState.Site.Name, cmd.fileName)
+	return nil
+}
+
+func (cmd *CmdSiteImport) WaitUntil() error { return nil }
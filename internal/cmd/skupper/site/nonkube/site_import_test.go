@@ -0,0 +1,158 @@
+package nonkube
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	nonkubecommon "github.com/skupperproject/skupper/internal/nonkube/common"
+	"github.com/skupperproject/skupper/internal/site/archive"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestSiteArchive() *archive.Archive {
+	site := &v2alpha1.Site{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-site", Namespace: "old"},
+		Spec:       v2alpha1.SiteSpec{LinkAccess: "default"},
+	}
+	siteArchive := archive.New(site, "11111111-2222-3333-4444-555555555555", "podman")
+	siteArchive.AddListener(v2alpha1.Listener{
+		ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: "old"},
+		Spec:       v2alpha1.ListenerSpec{RoutingKey: "backend", Host: "0.0.0.0", Port: 8080},
+	})
+	siteArchive.AddLink(v2alpha1.Link{
+		ObjectMeta: metav1.ObjectMeta{Name: "link-other", Namespace: "old"},
+		Spec:       v2alpha1.LinkSpec{TlsCredentials: "link-other", Cost: 1},
+	})
+	siteArchive.AddIssuer(corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "skupper-site-ca"},
+		Type:       corev1.SecretTypeTLS,
+		Data:       map[string][]byte{"tls.crt": []byte("ca-cert"), "tls.key": []byte("ca-key")},
+	})
+	siteArchive.AddSecret(corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "link-other", Namespace: "old"},
+		Data:       map[string][]byte{"tls.crt": []byte("link-cert"), "tls.key": []byte("link-key")},
+	})
+	return siteArchive
+}
+
+func TestCmdSiteImport_ValidateInput(t *testing.T) {
+	type test struct {
+		name          string
+		namespace     string
+		args          []string
+		passphrase    string
+		expectedError string
+	}
+
+	if os.Getuid() == 0 {
+		api.DefaultRootDataHome = t.TempDir()
+	} else {
+		t.Setenv("XDG_DATA_HOME", t.TempDir())
+	}
+	fileName := filepath.Join(t.TempDir(), "site.tar.gz")
+	assert.Assert(t, newTestSiteArchive().WriteFile(fileName, "secret"))
+
+	// add a site to the existing namespace
+	siteHandler := fs.NewSiteHandler("existing")
+	content, err := siteHandler.EncodeToYaml(v2alpha1.Site{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "skupper.io/v2alpha1", Kind: "Site"},
+		ObjectMeta: metav1.ObjectMeta{Name: "my-site", Namespace: "existing"},
+	})
+	assert.Assert(t, err)
+	path := filepath.Join(api.GetDataHome(), "/namespaces/existing/", string(api.InputSiteStatePath))
+	assert.Assert(t, siteHandler.WriteFile(path, "my-site.yaml", content, common.Sites))
+
+	testTable := []test{
+		{
+			name:          "there is already a site",
+			namespace:     "existing",
+			args:          []string{fileName},
+			passphrase:    "secret",
+			expectedError: "there is already a site defined in this namespace",
+		},
+		{
+			name:          "file name is not specified",
+			args:          []string{},
+			expectedError: "file name must be specified",
+		},
+		{
+			name:          "more than one argument was specified",
+			args:          []string{fileName, "other.tar.gz"},
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:          "archive is encrypted",
+			args:          []string{fileName},
+			expectedError: "the secrets of the site archive are encrypted, a passphrase is required",
+		},
+		{
+			name:          "wrong passphrase",
+			args:          []string{fileName},
+			passphrase:    "other",
+			expectedError: "unable to decrypt the secrets of the site archive: invalid passphrase or corrupted archive",
+		},
+		{
+			name:       "archive is valid",
+			args:       []string{fileName},
+			passphrase: "secret",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdSiteImport{
+				namespace:   test.namespace,
+				siteHandler: fs.NewSiteHandler(test.namespace),
+			}
+
+			if test.passphrase != "" {
+				passphraseFile := filepath.Join(t.TempDir(), "passphrase")
+				assert.Assert(t, os.WriteFile(passphraseFile, []byte(test.passphrase+"\n"), 0600))
+				command.Flags = &common.CommandSiteImportFlags{PassphraseFile: passphraseFile}
+			}
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdSiteImport_Run(t *testing.T) {
+	if os.Getuid() == 0 {
+		api.DefaultRootDataHome = t.TempDir()
+	} else {
+		t.Setenv("XDG_DATA_HOME", t.TempDir())
+	}
+
+	command := &CmdSiteImport{
+		namespace:   "imported",
+		fileName:    "site.tar.gz",
+		siteArchive: newTestSiteArchive(),
+	}
+	assert.Assert(t, command.Run())
+
+	pathProvider := fs.PathProvider{Namespace: "imported"}
+	siteStateLoader := &nonkubecommon.FileSystemSiteStateLoader{
+		Path: pathProvider.GetNamespace(),
+	}
+	siteState, err := siteStateLoader.Load()
+	assert.Assert(t, err)
+	assert.Equal(t, siteState.Site.Name, "my-site")
+	assert.Equal(t, siteState.Site.Namespace, "imported")
+	assert.Equal(t, siteState.SiteId, "11111111-2222-3333-4444-555555555555")
+	assert.Equal(t, siteState.Listeners["backend"].Spec.Port, 8080)
+	assert.Equal(t, siteState.Links["link-other"].Spec.TlsCredentials, "link-other")
+	assert.Equal(t, string(siteState.Secrets["link-other"].Data["tls.key"]), "link-key")
+
+	issuerPath := filepath.Join(api.GetDefaultOutputPath("imported"), string(api.InputIssuersPath), "skupper-site-ca")
+	caKey, err := os.ReadFile(filepath.Join(issuerPath, "tls.key"))
+	assert.Assert(t, err)
+	assert.Equal(t, string(caKey), "ca-key")
+}
@@ -9,7 +9,9 @@ import (
 	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
 	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
 	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	nonkubecommon "github.com/skupperproject/skupper/internal/nonkube/common"
 	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
 	"github.com/spf13/cobra"
 )
 
@@ -101,10 +103,40 @@ func (cmd *CmdSiteStatus) Run() error {
 		}
 
 		writer.Flush()
+
+		conflicts, err := cmd.portConflicts()
+		if err != nil {
+			return err
+		}
+		if len(conflicts) > 0 {
+			fmt.Println()
+			fmt.Println("Port conflicts:")
+			for _, conflict := range conflicts {
+				fmt.Printf("  %s\n", conflict.Error())
+			}
+		}
 	}
 
 	return nil
 }
 
+// portConflicts returns the ports used by the running site that are
+// reserved by other sites on this host
+func (cmd *CmdSiteStatus) portConflicts() ([]nonkubecommon.PortConflict, error) {
+	runtimeStatePath := api.GetInternalOutputPath(cmd.namespace, api.RuntimeSiteStatePath)
+	if _, err := os.Stat(runtimeStatePath); err != nil {
+		return nil, nil
+	}
+	loader := &nonkubecommon.FileSystemSiteStateLoader{
+		Path: runtimeStatePath,
+	}
+	siteState, err := loader.Load()
+	if err != nil {
+		return nil, nil
+	}
+	registry := nonkubecommon.NewPortRegistry()
+	return registry.Check(siteState.GetNamespace(), nonkubecommon.SitePortRequests(siteState))
+}
+
 func (cmd *CmdSiteStatus) InputToOptions()  {}
 func (cmd *CmdSiteStatus) WaitUntil() error { return nil }
@@ -8,6 +8,7 @@ import (
 	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
 	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
 	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	nonkubecommon "github.com/skupperproject/skupper/internal/nonkube/common"
 	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
 	"github.com/skupperproject/skupper/pkg/nonkube/api"
 	"gotest.tools/v3/assert"
@@ -241,3 +242,65 @@ func TestCmdSiteStatus_RunNoDirectory(t *testing.T) {
 		})
 	}
 }
+
+func TestCmdSiteStatus_PortConflicts(t *testing.T) {
+	if os.Getuid() == 0 {
+		api.DefaultRootDataHome = t.TempDir()
+	} else {
+		t.Setenv("XDG_DATA_HOME", t.TempDir())
+	}
+	tmpDir := api.GetDataHome()
+	path := filepath.Join(tmpDir, "/namespaces/test4/", string(api.RuntimeSiteStatePath))
+
+	command := &CmdSiteStatus{}
+	command.namespace = "test4"
+	command.siteHandler = fs.NewSiteHandler(command.namespace)
+
+	// no runtime state, no conflicts
+	conflicts, err := command.portConflicts()
+	assert.Assert(t, err)
+	assert.Equal(t, len(conflicts), 0)
+
+	siteResource := v2alpha1.Site{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "Site",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-site",
+			Namespace: "test4",
+		},
+	}
+	listenerResource := v2alpha1.Listener{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "Listener",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "backend",
+			Namespace: "test4",
+		},
+		Spec: v2alpha1.ListenerSpec{
+			RoutingKey: "backend",
+			Host:       "0.0.0.0",
+			Port:       8080,
+		},
+	}
+	content, err := command.siteHandler.EncodeToYaml(siteResource)
+	assert.Assert(t, err)
+	assert.Assert(t, command.siteHandler.WriteFile(path, "my-site.yaml", content, common.Sites))
+	content, err = command.siteHandler.EncodeToYaml(listenerResource)
+	assert.Assert(t, err)
+	assert.Assert(t, command.siteHandler.WriteFile(path, "backend.yaml", content, common.Listeners))
+
+	_, err = nonkubecommon.NewPortRegistry().Reserve("other", []nonkubecommon.PortRequest{
+		{Kind: nonkubecommon.PortKindListener, Name: "web", Host: "127.0.0.1", Port: 8080},
+	})
+	assert.Assert(t, err)
+
+	conflicts, err = command.portConflicts()
+	assert.Assert(t, err)
+	assert.Equal(t, len(conflicts), 1)
+	assert.Equal(t, conflicts[0].Error(), `port 8080 requested by Listener "backend" of namespace "test4" is already reserved by Listener "web" of namespace "other"`)
+	assert.Assert(t, command.Run())
+}
@@ -28,6 +28,9 @@ skupper site status`,
 	cmd.AddCommand(CmdSiteDeleteFactory(platform))
 	cmd.AddCommand(CmdSiteUpdateFactory(platform))
 	cmd.AddCommand(CmdSiteGenerateFactory(platform))
+	cmd.AddCommand(CmdSiteExportFactory(platform))
+	cmd.AddCommand(CmdSiteImportFactory(platform))
+	cmd.AddCommand(CmdSiteDiffFactory(platform))
 
 	return cmd
 }
@@ -187,3 +190,94 @@ Generate a site resource to evaluate what will be created with the site create c
 	return cmd
 
 }
+
+func CmdSiteExportFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdSiteExport()
+	nonKubeCommand := nonkube.NewCmdSiteExport()
+
+	cmdSiteExportDesc := common.SkupperCmdDescription{
+		Use:   "export <file>",
+		Short: "Export a site to an archive file",
+		Long: `Export the site, with its listeners, connectors, links, router accesses,
+access grants, certificates and the secrets needed to recreate it elsewhere
+with the same identity, such as the site CA and the link credentials.
+The archive holds private keys, which are encrypted when a passphrase is provided.`,
+		Example: `skupper site export my-site.tar.gz
+skupper site export my-site.tar.gz --passphrase-file ./passphrase`,
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdSiteExportDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandSiteExportFlags{}
+
+	cmd.Flags().StringVar(&cmdFlags.PassphraseFile, common.FlagNamePassphraseFile, "", common.FlagDescExportPassphraseFile)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
+
+func CmdSiteImportFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdSiteImport()
+	nonKubeCommand := nonkube.NewCmdSiteImport()
+
+	cmdSiteImportDesc := common.SkupperCmdDescription{
+		Use:   "import <file>",
+		Short: "Recreate a site from an archive file",
+		Long: `Recreate a site exported with skupper site export, keeping its identity
+and certificate authorities, so that the links from remote sites keep working.
+There must be no site defined in the namespace.`,
+		Example: `skupper site import my-site.tar.gz
+skupper site import my-site.tar.gz --passphrase-file ./passphrase`,
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdSiteImportDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandSiteImportFlags{}
+
+	cmd.Flags().StringVar(&cmdFlags.PassphraseFile, common.FlagNamePassphraseFile, "", common.FlagDescImportPassphraseFile)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
+
+func CmdSiteDiffFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdSiteDiff()
+	nonKubeCommand := nonkube.NewCmdSiteDiff()
+
+	cmdSiteDiffDesc := common.SkupperCmdDescription{
+		Use:   "diff",
+		Short: "Show the changes to the router configuration not yet applied",
+		Long: `Show the changes to the router configuration that have not been applied yet.
+On Kubernetes, these are the changes held while the site has the
+skupper.io/dry-run annotation set to "true"; they are applied once the
+annotation is removed.
+On other platforms, these are the changes from input resources not yet
+loaded by the site, along with the reasons for which loading them requires
+the site to be restarted, if any.`,
+		Example: `kubectl annotate site my-site skupper.io/dry-run=true
+skupper listener create backend 8080
+skupper site diff
+kubectl annotate site my-site skupper.io/dry-run-`,
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdSiteDiffDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandSiteDiffFlags{}
+
+	cmd.Flags().StringVarP(&cmdFlags.Output, common.FlagNameOutput, "o", "", common.FlagDescSiteDiffOutput)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
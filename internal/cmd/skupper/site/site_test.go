@@ -95,6 +95,34 @@ func TestCmdSiteFactory(t *testing.T) {
 			},
 			command: CmdSiteGenerateFactory(common.PlatformPodman),
 		},
+		{
+			name: "CmdSiteExportFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNamePassphraseFile: "",
+			},
+			command: CmdSiteExportFactory(common.PlatformKubernetes),
+		},
+		{
+			name: "CmdSiteExportFactoryNonKube",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNamePassphraseFile: "",
+			},
+			command: CmdSiteExportFactory(common.PlatformPodman),
+		},
+		{
+			name: "CmdSiteImportFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNamePassphraseFile: "",
+			},
+			command: CmdSiteImportFactory(common.PlatformKubernetes),
+		},
+		{
+			name: "CmdSiteImportFactoryNonKube",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNamePassphraseFile: "",
+			},
+			command: CmdSiteImportFactory(common.PlatformPodman),
+		},
 	}
 
 	for _, test := range testTable {
@@ -1 +1,262 @@
 package nonkube
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+func TestCmdTokenIssue_ValidateInput(t *testing.T) {
+	type test struct {
+		name               string
+		args               []string
+		flags              common.CommandTokenIssueFlags
+		createSite         bool
+		createRouterAccess bool
+		expectedError      string
+	}
+
+	validFlags := common.CommandTokenIssueFlags{
+		ExpirationWindow:   15 * time.Minute,
+		RedemptionsAllowed: 1,
+		Timeout:            60 * time.Second,
+		Cost:               "1",
+	}
+
+	testTable := []test{
+		{
+			name:               "file name is not specified",
+			args:               []string{},
+			flags:              validFlags,
+			createSite:         true,
+			createRouterAccess: true,
+			expectedError:      "file name must be configured",
+		},
+		{
+			name:               "more than one argument is specified",
+			args:               []string{"my-grant", "/tmp/my-grant.yaml"},
+			flags:              validFlags,
+			createSite:         true,
+			createRouterAccess: true,
+			expectedError:      "only one argument is allowed for this command",
+		},
+		{
+			name:               "token file name is not valid",
+			args:               []string{"my new file"},
+			flags:              validFlags,
+			createSite:         true,
+			createRouterAccess: true,
+			expectedError:      "token file name is not valid: value does not match this regular expression: ^[A-Za-z0-9./~-]+$",
+		},
+		{
+			name:          "site is not active",
+			args:          []string{"/tmp/token-issue.yaml"},
+			flags:         validFlags,
+			expectedError: "there is no active skupper site in this namespace",
+		},
+		{
+			name:          "site was not enabled for link access",
+			args:          []string{"/tmp/token-issue.yaml"},
+			flags:         validFlags,
+			createSite:    true,
+			expectedError: "You must enable link access for this site before you can create a token.",
+		},
+		{
+			name: "redemptions is not valid",
+			args: []string{"/tmp/token-issue.yaml"},
+			flags: common.CommandTokenIssueFlags{
+				ExpirationWindow:   15 * time.Minute,
+				RedemptionsAllowed: 0,
+				Timeout:            60 * time.Second,
+				Cost:               "1",
+			},
+			createSite:         true,
+			createRouterAccess: true,
+			expectedError:      "number of redemptions is not valid",
+		},
+		{
+			name: "cost is not valid",
+			args: []string{"/tmp/token-issue.yaml"},
+			flags: common.CommandTokenIssueFlags{
+				ExpirationWindow:   15 * time.Minute,
+				RedemptionsAllowed: 1,
+				Timeout:            60 * time.Second,
+				Cost:               "two",
+			},
+			createSite:         true,
+			createRouterAccess: true,
+			expectedError:      "link cost is not valid: strconv.Atoi: parsing \"two\": invalid syntax",
+		},
+		{
+			name:               "flags all valid",
+			args:               []string{"/tmp/token-issue.yaml"},
+			flags:              validFlags,
+			createSite:         true,
+			createRouterAccess: true,
+			expectedError:      "",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			if os.Getuid() == 0 {
+				api.DefaultRootDataHome = t.TempDir()
+			} else {
+				t.Setenv("XDG_DATA_HOME", t.TempDir())
+			}
+			path := filepath.Join(api.GetDataHome(), "/namespaces/test/", string(api.RuntimeSiteStatePath))
+			if test.createSite {
+				createSiteResource(path, t)
+			}
+			if test.createRouterAccess {
+				createRouterAccessResource(path, t)
+			}
+
+			command := &CmdTokenIssue{}
+			command.Namespace = "test"
+			command.Flags = &test.flags
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdTokenIssue_RunAndWaitUntil(t *testing.T) {
+	if os.Getuid() == 0 {
+		api.DefaultRootDataHome = t.TempDir()
+	} else {
+		t.Setenv("XDG_DATA_HOME", t.TempDir())
+	}
+	path := filepath.Join(api.GetDataHome(), "/namespaces/test/", string(api.RuntimeSiteStatePath))
+	createSiteResource(path, t)
+	createRouterAccessResource(path, t)
+	tokenFile := "/tmp/token-issue-run.yaml"
+	defer os.Remove(tokenFile)
+
+	command := &CmdTokenIssue{}
+	command.Namespace = "test"
+	command.Flags = &common.CommandTokenIssueFlags{
+		ExpirationWindow:   15 * time.Minute,
+		RedemptionsAllowed: 2,
+		Timeout:            10 * time.Second,
+		Cost:               "3",
+	}
+	assert.Assert(t, command.ValidateInput([]string{tokenFile}))
+	assert.Assert(t, command.Run())
+
+	grant, err := command.loadGrant()
+	assert.Assert(t, err)
+	assert.Equal(t, grant.Name, command.grantName)
+	assert.Assert(t, grant.UID != "")
+	assert.Equal(t, grant.Spec.RedemptionsAllowed, 2)
+	assert.Equal(t, grant.Spec.ExpirationWindow, "15m0s")
+	assert.Equal(t, filepath.Dir(command.grantFileName()), api.GetInternalOutputPath("test", api.RuntimeGrantsPath))
+
+	// simulating the grant server
+	grant.Status.Url = "https://10.0.0.1:9090/test/" + string(grant.UID)
+	grant.Status.Ca = "ca-data"
+	grant.Status.Code = "secret-code"
+	grant.SetProcessed(nil)
+	grant.SetResolved()
+	encoded, err := utils.Encode("yaml", grant)
+	assert.Assert(t, err)
+	assert.Assert(t, os.WriteFile(command.grantFileName(), []byte(encoded), 0644))
+
+	assert.Assert(t, command.WaitUntil())
+
+	data, err := os.ReadFile(tokenFile)
+	assert.Assert(t, err)
+	token := &v2alpha1.AccessToken{}
+	assert.Assert(t, yaml.Unmarshal(data, token))
+	assert.Equal(t, token.Name, command.grantName)
+	assert.Equal(t, token.Spec.Url, grant.Status.Url)
+	assert.Equal(t, token.Spec.Ca, "ca-data")
+	assert.Equal(t, token.Spec.Code, "secret-code")
+	assert.Equal(t, token.Spec.LinkCost, 3)
+}
+
+func TestCmdTokenIssue_WaitUntilNotReady(t *testing.T) {
+	if os.Getuid() == 0 {
+		api.DefaultRootDataHome = t.TempDir()
+	} else {
+		t.Setenv("XDG_DATA_HOME", t.TempDir())
+	}
+	command := &CmdTokenIssue{}
+	command.Namespace = "test"
+	command.grantName = "my-site-grant"
+	command.grantsPath = api.GetInternalOutputPath("test", api.RuntimeGrantsPath)
+	command.fileName = filepath.Join(t.TempDir(), "token.yaml")
+	command.Flags = &common.CommandTokenIssueFlags{
+		ExpirationWindow:   15 * time.Minute,
+		RedemptionsAllowed: 1,
+		Timeout:            1 * time.Second,
+		Cost:               "1",
+	}
+	assert.Assert(t, command.Run())
+	err := command.WaitUntil()
+	assert.Error(t, err, "grant \"my-site-grant\" not ready yet, make sure the system controller is running with AccessGrants enabled")
+	_, err = os.Stat(command.fileName)
+	assert.Assert(t, os.IsNotExist(err))
+}
+
+// --- helper methods
+func createSiteResource(path string, t *testing.T) {
+	siteResource := v2alpha1.Site{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "Site",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-site",
+			Namespace: "test",
+		},
+	}
+
+	siteHandler := fs.NewSiteHandler("test")
+	contentSite, err := siteHandler.EncodeToYaml(siteResource)
+	assert.Check(t, err == nil)
+	err = siteHandler.WriteFile(path, "my-site.yaml", contentSite, common.Sites)
+	assert.Check(t, err == nil)
+}
+
+func createRouterAccessResource(path string, t *testing.T) {
+	routerAccessResource := v2alpha1.RouterAccess{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "RouterAccess",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "router-access-test",
+			Namespace: "test",
+		},
+		Spec: v2alpha1.RouterAccessSpec{
+			Roles: []v2alpha1.RouterAccessRole{
+				{
+					Name: "inter-router",
+					Port: 55671,
+				},
+				{
+					Name: "edge",
+					Port: 45671,
+				},
+			},
+		},
+	}
+
+	routerAccessHandler := fs.NewRouterAccessHandler("test")
+	contentRouterAccess, err := routerAccessHandler.EncodeToYaml(routerAccessResource)
+	assert.Check(t, err == nil)
+	err = routerAccessHandler.WriteFile(path, "my-router-access.yaml", contentRouterAccess, common.RouterAccesses)
+	assert.Check(t, err == nil)
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 )
 
 func StringVar(flags *flag.FlagSet, output *string, flagName string, envVarName string, defaultValue string, usage string) {
@@ -26,6 +27,13 @@ func IntVar(flags *flag.FlagSet, output *int, flagName string, envVarName string
 	return err
 }
 
+func DurationVar(flags *flag.FlagSet, output *time.Duration, flagName string, envVarName string, defaultValue time.Duration, usage string) error {
+	dval, err := durationEnvVar(envVarName, defaultValue)
+	//set flag in spite of error, caller can decide whether to ignore and go with default or not
+	flags.DurationVar(output, flagName, dval, usage)
+	return err
+}
+
 func MultiStringVar(flags *flag.FlagSet, output *[]string, flagName string, envVarName string, defaultValue []string, usage string) {
 	ms := &multistring{
 		output: output,
@@ -63,6 +71,17 @@ func intEnvVar(name string, defaultValue int) (int, error) {
 	return defaultValue, nil
 }
 
+func durationEnvVar(name string, defaultValue time.Duration) (time.Duration, error) {
+	if svalue, ok := os.LookupEnv(name); ok {
+		value, err := time.ParseDuration(svalue)
+		if err != nil {
+			return defaultValue, fmt.Errorf("Bad value for %q: %s", name, err)
+		}
+		return value, nil
+	}
+	return defaultValue, nil
+}
+
 func boolEnvVar(name string, defaultValue bool) (bool, error) {
 	if svalue, ok := os.LookupEnv(name); ok {
 		value, err := strconv.ParseBool(svalue)
@@ -3,6 +3,7 @@ package flag
 import (
 	"flag"
 	"testing"
+	"time"
 
 	"gotest.tools/v3/assert"
 )
@@ -230,6 +231,87 @@ func Test_IntVar(t *testing.T) {
 	}
 }
 
+func Test_DurationVar(t *testing.T) {
+	tests := []struct {
+		name          string
+		defaultValue  time.Duration
+		args          []string
+		env           map[string]string
+		expectedValue time.Duration
+		expectedError string
+	}{
+		{
+			name:          "default value returned",
+			defaultValue:  time.Hour,
+			expectedValue: time.Hour,
+		},
+		{
+			name:          "flag specified as two args",
+			args:          []string{"-dummy", "10m"},
+			expectedValue: 10 * time.Minute,
+		},
+		{
+			name:          "flag specified as one arg",
+			args:          []string{"-dummy=30s"},
+			expectedValue: 30 * time.Second,
+		},
+		{
+			name:          "flag overrides default",
+			defaultValue:  time.Hour,
+			args:          []string{"-dummy=2h"},
+			expectedValue: 2 * time.Hour,
+		},
+		{
+			name: "env var returned",
+			env: map[string]string{
+				"SKUPPER_DUMMY": "720h",
+			},
+			expectedValue: 720 * time.Hour,
+		},
+		{
+			name:         "env var overrides default",
+			defaultValue: time.Hour,
+			env: map[string]string{
+				"SKUPPER_DUMMY": "5m",
+			},
+			expectedValue: 5 * time.Minute,
+		},
+		{
+			name:         "invalid env var",
+			defaultValue: time.Minute,
+			env: map[string]string{
+				"SKUPPER_DUMMY": "i am a bad value!",
+			},
+			expectedError: "i am a bad value",
+			expectedValue: time.Minute,
+		},
+		{
+			name: "error references env var name",
+			env: map[string]string{
+				"SKUPPER_DUMMY": "i am a bad value!",
+			},
+			expectedError: "SKUPPER_DUMMY",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := &flag.FlagSet{}
+			var value time.Duration
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			err := DurationVar(flags, &value, "dummy", "SKUPPER_DUMMY", tt.defaultValue, "Test of dummy config option")
+			flags.Parse(tt.args)
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+			} else if err != nil {
+				t.Error(err)
+			}
+			assert.Equal(t, value, tt.expectedValue)
+		})
+	}
+}
+
 func Test_MultiStringVar(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -5,6 +5,7 @@ import (
 	"log"
 	"log/slog"
 	"os"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 
@@ -17,13 +18,15 @@ import (
 // Syncs the live router config with the configmap (bridge configuration,
 // secrets for services with TLS enabled, and secrets and connectors for links)
 type ConfigSync struct {
-	agentPool       *qdr.AgentPool
-	controller      *watchers.EventProcessor
-	namespace       string
-	profileSyncer   *secrets.Sync
-	config          *watchers.ConfigMapWatcher
-	path            string
-	routerConfigMap string
+	agentPool          *qdr.AgentPool
+	controller         *watchers.EventProcessor
+	namespace          string
+	profileSyncer      *secrets.Sync
+	config             *watchers.ConfigMapWatcher
+	path               string
+	routerConfigMap    string
+	driftCheckInterval time.Duration
+	linkCheckInterval  time.Duration
 }
 
 func sslSecretsWatcher(namespace string, eventProcessor *watchers.EventProcessor) secrets.SecretsCacheFactory {
@@ -37,11 +40,13 @@ func sslSecretsWatcher(namespace string, eventProcessor *watchers.EventProcessor
 func NewConfigSync(cli internalclient.Clients, namespace string, path string, routerConfigMap string) *ConfigSync {
 	controller := watchers.NewEventProcessor("config-sync", cli)
 	configSync := &ConfigSync{
-		agentPool:       qdr.NewAgentPool("amqp://localhost:5672", nil),
-		controller:      controller,
-		namespace:       namespace,
-		path:            path,
-		routerConfigMap: routerConfigMap,
+		agentPool:          qdr.NewAgentPool("amqp://localhost:5672", nil),
+		controller:         controller,
+		namespace:          namespace,
+		path:               path,
+		routerConfigMap:    routerConfigMap,
+		driftCheckInterval: DefaultDriftCheckInterval,
+		linkCheckInterval:  DefaultLinkCheckInterval,
 	}
 	configSync.profileSyncer = secrets.NewSync(
 		sslSecretsWatcher(namespace, controller),
@@ -76,6 +81,8 @@ func (c *ConfigSync) Start(stopCh <-chan struct{}) error {
 		log.Printf("CONFIG_SYNC: Error recovering tracked ssl profiles: %s", err)
 	}
 	c.controller.Start(stopCh)
+	c.scheduleDriftCheck()
+	c.scheduleLinkCheck()
 	return nil
 }
 
@@ -109,6 +116,9 @@ func (c *ConfigSync) configEvent(key string, configmap *corev1.ConfigMap) error
 		log.Printf("sync failed: %s", err)
 		return err
 	}
+	if err := c.enforceLinkRestrictions(configmap); err != nil {
+		log.Printf("CONFIG_SYNC: Unable to check incoming links: %s", err)
+	}
 	return nil
 }
 
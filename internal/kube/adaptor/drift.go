@@ -0,0 +1,126 @@
+package adaptor
+
+import (
+	"context"
+	"log"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	kubeqdr "github.com/skupperproject/skupper/internal/kube/qdr"
+	"github.com/skupperproject/skupper/internal/qdr"
+)
+
+const DefaultDriftCheckInterval = time.Minute
+
+// SetDriftCheckInterval sets how often the entities of the running
+// router are compared against the ConfigMap, to detect changes made
+// to the router other than through the ConfigMap. Zero disables the
+// check.
+func (c *ConfigSync) SetDriftCheckInterval(interval time.Duration) {
+	c.driftCheckInterval = interval
+}
+
+func (c *ConfigSync) scheduleDriftCheck() {
+	if c.driftCheckInterval > 0 {
+		c.controller.CallbackAfter(c.driftCheckInterval, c.checkDrift, c.key(c.routerConfigMap))
+	}
+}
+
+// checkDrift runs on the event processing thread, so that the router
+// is not resynchronised concurrently with a change to the ConfigMap.
+// Any drift found is corrected and recorded on the ConfigMap.
+func (c *ConfigSync) checkDrift(key string) error {
+	defer c.scheduleDriftCheck()
+	configmap, err := c.config.Get(key)
+	if err != nil || configmap == nil {
+		return nil
+	}
+	desired, err := qdr.GetRouterConfigFromConfigMap(configmap)
+	if err != nil || desired == nil {
+		return nil
+	}
+	changes, err := c.routerConfigDrift(desired)
+	if err != nil {
+		log.Printf("CONFIG_SYNC: Unable to check router for drift: %s", err)
+		return nil
+	}
+	var drift *kubeqdr.RouterConfigDrift
+	if len(changes) > 0 {
+		for _, change := range changes {
+			log.Printf("CONFIG_SYNC: Router config drift detected: %s", change)
+		}
+		if err := c.configEvent(key, configmap); err != nil {
+			log.Printf("CONFIG_SYNC: Error correcting router config drift: %s", err)
+		}
+		remaining, err := c.routerConfigDrift(desired)
+		drift = &kubeqdr.RouterConfigDrift{
+			Time:      time.Now().UTC().Format(time.RFC3339),
+			Changes:   changes,
+			Corrected: err == nil && len(remaining) == 0,
+		}
+	} else if recorded, err := kubeqdr.GetRouterConfigDrift(configmap); err == nil && recorded != nil && !recorded.Corrected {
+		// drift reported earlier has since been corrected
+		recorded.Corrected = true
+		drift = recorded
+	}
+	if drift != nil {
+		if err := c.recordDrift(drift); err != nil {
+			log.Printf("CONFIG_SYNC: Error recording router config drift: %s", err)
+		}
+	}
+	return nil
+}
+
+func (c *ConfigSync) routerConfigDrift(desired *qdr.RouterConfig) ([]qdr.ConfigChange, error) {
+	agent, err := c.agentPool.Get()
+	if err != nil {
+		return nil, err
+	}
+	defer c.agentPool.Put(agent)
+	bridges, err := agent.GetLocalBridgeConfig()
+	if err != nil {
+		return nil, err
+	}
+	connectors, err := agent.GetLocalConnectors()
+	if err != nil {
+		return nil, err
+	}
+	listeners, err := agent.GetLocalListeners()
+	if err != nil {
+		return nil, err
+	}
+	return routerConfigDrift(bridges, connectors, listeners, desired), nil
+}
+
+// routerConfigDrift compares the entities of a running router against
+// its desired configuration, limited to the entities kept in sync with
+// it.
+func routerConfigDrift(bridges *qdr.BridgeConfig, connectors map[string]qdr.Connector, listeners map[string]qdr.Listener, desired *qdr.RouterConfig) []qdr.ConfigChange {
+	ignorePrefix := "auto-mesh"
+	difference := &qdr.RouterConfigDifference{
+		Bridges:    bridges.Difference(&desired.Bridges),
+		Connectors: qdr.ConnectorsDifference(connectors, desired, &ignorePrefix),
+		Listeners:  qdr.ListenersDifference(qdr.FilterListeners(listeners, qdr.IsNotProtectedListener), desired.GetMatchingListeners(qdr.IsNotProtectedListener)),
+	}
+	if difference.Empty() {
+		return nil
+	}
+	return difference.Changes()
+}
+
+func (c *ConfigSync) recordDrift(drift *kubeqdr.RouterConfigDrift) error {
+	configmaps := c.controller.GetKubeClient().CoreV1().ConfigMaps(c.namespace)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := configmaps.Get(context.TODO(), c.routerConfigMap, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if changed, err := kubeqdr.SetRouterConfigDrift(current, drift); err != nil || !changed {
+			return err
+		}
+		_, err = configmaps.Update(context.TODO(), current, metav1.UpdateOptions{})
+		return err
+	})
+}
@@ -0,0 +1,57 @@
+package adaptor
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/qdr"
+	"gotest.tools/v3/assert"
+)
+
+func TestRouterConfigDrift(t *testing.T) {
+	desired := qdr.InitialConfig("router", "site", "version", false, 3)
+	desired.AddListener(qdr.Listener{Name: "amqp", Host: "localhost", Port: 5672})
+	desired.AddListener(qdr.Listener{Name: "inter-router", Role: qdr.RoleInterRouter, Port: 55671})
+	desired.AddConnector(qdr.Connector{Name: "east", Role: qdr.RoleInterRouter, Host: "east", Port: "55671"})
+	desired.AddTcpListener(qdr.TcpEndpoint{Name: "backend", Address: "backend", Port: "1024"})
+
+	tests := []struct {
+		name       string
+		bridges    qdr.BridgeConfig
+		connectors map[string]qdr.Connector
+		listeners  map[string]qdr.Listener
+		expected   []qdr.ConfigChange
+	}{
+		{
+			name:       "in sync",
+			bridges:    desired.Bridges,
+			connectors: desired.Connectors,
+			listeners:  desired.Listeners,
+		},
+		{
+			name: "entities changed on the router",
+			bridges: qdr.BridgeConfig{
+				TcpListeners: qdr.TcpEndpointMap{
+					"backend": {Name: "backend", Address: "backend", Port: "1025"},
+				},
+			},
+			connectors: map[string]qdr.Connector{
+				"east":          desired.Connectors["east"],
+				"auto-mesh/abc": {Name: "auto-mesh/abc", Role: qdr.RoleInterRouter, Host: "peer", Port: "55671"},
+				"west":          {Name: "west", Role: qdr.RoleInterRouter, Host: "west", Port: "55671"},
+			},
+			listeners: map[string]qdr.Listener{
+				"amqp": {Name: "amqp", Host: "localhost", Port: 5672},
+			},
+			expected: []qdr.ConfigChange{
+				{Action: qdr.ChangeDelete, Type: "connector", Name: "west"},
+				{Action: qdr.ChangeAdd, Type: "listener", Name: "inter-router", Detail: "role=inter-router host= port=55671"},
+				{Action: qdr.ChangeUpdate, Type: "tcpListener", Name: "backend", Detail: "address=backend port=1024"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.DeepEqual(t, routerConfigDrift(&tt.bridges, tt.connectors, tt.listeners, &desired), tt.expected)
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package adaptor
+
+import (
+	"log"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	kubeqdr "github.com/skupperproject/skupper/internal/kube/qdr"
+	"github.com/skupperproject/skupper/internal/qdr"
+)
+
+const DefaultLinkCheckInterval = 10 * time.Second
+
+// SetLinkCheckInterval sets how often the incoming links of the router
+// are checked against the restrictions recorded on the ConfigMap by
+// the controller. Zero disables the periodic check, the links are then
+// only checked when the ConfigMap changes.
+func (c *ConfigSync) SetLinkCheckInterval(interval time.Duration) {
+	c.linkCheckInterval = interval
+}
+
+func (c *ConfigSync) scheduleLinkCheck() {
+	if c.linkCheckInterval > 0 {
+		c.controller.CallbackAfter(c.linkCheckInterval, c.checkLinks, c.key(c.routerConfigMap))
+	}
+}
+
+func (c *ConfigSync) checkLinks(key string) error {
+	defer c.scheduleLinkCheck()
+	configmap, err := c.config.Get(key)
+	if err != nil || configmap == nil {
+		return nil
+	}
+	if err := c.enforceLinkRestrictions(configmap); err != nil {
+		log.Printf("CONFIG_SYNC: Unable to check incoming links: %s", err)
+	}
+	return nil
+}
+
+// enforceLinkRestrictions closes the incoming links of the router that
+// the restrictions on the ConfigMap, if any, do not allow.
+func (c *ConfigSync) enforceLinkRestrictions(configmap *corev1.ConfigMap) error {
+	restrictions, err := kubeqdr.GetLinkRestrictions(configmap)
+	if err != nil || restrictions == nil {
+		return err
+	}
+	agent, err := c.agentPool.Get()
+	if err != nil {
+		return err
+	}
+	defer c.agentPool.Put(agent)
+	connections, err := agent.GetConnections()
+	if err != nil {
+		return err
+	}
+	for _, connection := range refusedLinks(connections, restrictions) {
+		log.Printf("CONFIG_SYNC: Closing %s link from %s (%s) refused by access policy", connection.Role, connection.Host, connection.User)
+		if err := agent.CloseConnection(connection.Identity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// refusedLinks returns the incoming inter-router and edge connections
+// that do not comply with the restrictions: those authenticated with a
+// certificate subject that is not allowed and, beyond the maximum
+// number of links, the most recent ones.
+func refusedLinks(connections []qdr.Connection, restrictions *kubeqdr.LinkRestrictions) []qdr.Connection {
+	var refused []qdr.Connection
+	var accepted []qdr.Connection
+	for _, connection := range connections {
+		if connection.Dir != qdr.DirectionIn || (connection.Role != string(qdr.RoleInterRouter) && connection.Role != string(qdr.RoleEdge)) {
+			continue
+		}
+		subject := commonName(connection.User)
+		if slices.Contains(restrictions.SiteSubjects, subject) {
+			continue
+		}
+		if restrictions.AllowedSubjects != nil && !slices.Contains(restrictions.AllowedSubjects, subject) {
+			refused = append(refused, connection)
+			continue
+		}
+		accepted = append(accepted, connection)
+	}
+	if max := restrictions.MaxLinks; max > 0 && len(accepted) > max {
+		sort.SliceStable(accepted, func(i, j int) bool {
+			return accepted[i].Uptime > accepted[j].Uptime
+		})
+		refused = append(refused, accepted[max:]...)
+	}
+	return refused
+}
+
+// commonName returns the common name in the certificate subject a peer
+// authenticated with, as reported by the router.
+func commonName(subject string) string {
+	for _, part := range strings.Split(subject, ",") {
+		if name, ok := strings.CutPrefix(strings.TrimSpace(part), "CN="); ok {
+			return name
+		}
+	}
+	return subject
+}
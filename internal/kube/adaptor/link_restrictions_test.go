@@ -0,0 +1,75 @@
+package adaptor
+
+import (
+	"testing"
+
+	kubeqdr "github.com/skupperproject/skupper/internal/kube/qdr"
+	"github.com/skupperproject/skupper/internal/qdr"
+	"gotest.tools/v3/assert"
+)
+
+func TestRefusedLinks(t *testing.T) {
+	connections := []qdr.Connection{
+		{Identity: "1", Role: "normal", Dir: qdr.DirectionIn, User: "anonymous"},
+		{Identity: "2", Role: "inter-router", Dir: qdr.DirectionIn, User: "CN=east", Uptime: 300},
+		{Identity: "3", Role: "inter-router", Dir: qdr.DirectionIn, User: "CN=west,O=example", Uptime: 200},
+		{Identity: "4", Role: "edge", Dir: qdr.DirectionIn, User: "CN=north", Uptime: 100},
+		{Identity: "5", Role: "inter-router", Dir: qdr.DirectionIn, User: "CN=skupper-router-2", Uptime: 10},
+		{Identity: "6", Role: "inter-router", Dir: qdr.DirectionOut, User: "CN=south"},
+	}
+	identities := func(connections []qdr.Connection) []string {
+		var ids []string
+		for _, connection := range connections {
+			ids = append(ids, connection.Identity)
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name         string
+		restrictions kubeqdr.LinkRestrictions
+		expected     []string
+	}{
+		{
+			name:         "no restrictions",
+			restrictions: kubeqdr.LinkRestrictions{},
+		},
+		{
+			name: "allowed subjects",
+			restrictions: kubeqdr.LinkRestrictions{
+				AllowedSubjects: []string{"east", "north"},
+				SiteSubjects:    []string{"skupper-router", "skupper-router-2"},
+			},
+			expected: []string{"3"},
+		},
+		{
+			name: "no allowed subjects",
+			restrictions: kubeqdr.LinkRestrictions{
+				AllowedSubjects: []string{},
+				SiteSubjects:    []string{"skupper-router", "skupper-router-2"},
+			},
+			expected: []string{"2", "3", "4"},
+		},
+		{
+			name: "most recent links beyond the limit",
+			restrictions: kubeqdr.LinkRestrictions{
+				MaxLinks:     1,
+				SiteSubjects: []string{"skupper-router", "skupper-router-2"},
+			},
+			expected: []string{"3", "4"},
+		},
+		{
+			name: "limit applies to allowed links",
+			restrictions: kubeqdr.LinkRestrictions{
+				AllowedSubjects: []string{"west", "north"},
+				MaxLinks:        1,
+			},
+			expected: []string{"2", "5", "4"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.DeepEqual(t, identities(refusedLinks(connections, &tt.restrictions)), tt.expected)
+		})
+	}
+}
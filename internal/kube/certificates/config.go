@@ -0,0 +1,22 @@
+package certificates
+
+import (
+	"flag"
+	"time"
+
+	iflag "github.com/skupperproject/skupper/internal/flag"
+)
+
+// DefaultRenewalWindow is how long before expiry a certificate is
+// renewed, unless configured otherwise.
+const DefaultRenewalWindow = 30 * 24 * time.Hour
+
+type Config struct {
+	RenewalWindow time.Duration
+}
+
+func BoundConfig(flags *flag.FlagSet) (*Config, error) {
+	c := &Config{}
+	err := iflag.DurationVar(flags, &c.RenewalWindow, "certificate-renewal-window", "SKUPPER_CERTIFICATE_RENEWAL_WINDOW", DefaultRenewalWindow, "How long before their expiry the certificates managed by the controller are renewed.")
+	return c, err
+}
@@ -0,0 +1,68 @@
+package certificates
+
+import (
+	"flag"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func Test_BoundConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		env           map[string]string
+		expectedValue *Config
+		expectedError string
+	}{
+		{
+			name: "defaults",
+			expectedValue: &Config{
+				RenewalWindow: DefaultRenewalWindow,
+			},
+		},
+		{
+			name: "env var",
+			env: map[string]string{
+				"SKUPPER_CERTIFICATE_RENEWAL_WINDOW": "48h",
+			},
+			expectedValue: &Config{
+				RenewalWindow: 48 * time.Hour,
+			},
+		},
+		{
+			name: "flag",
+			args: []string{"-certificate-renewal-window=1h"},
+			expectedValue: &Config{
+				RenewalWindow: time.Hour,
+			},
+		},
+		{
+			name: "invalid env var",
+			env: map[string]string{
+				"SKUPPER_CERTIFICATE_RENEWAL_WINDOW": "a month",
+			},
+			expectedValue: &Config{
+				RenewalWindow: DefaultRenewalWindow,
+			},
+			expectedError: "SKUPPER_CERTIFICATE_RENEWAL_WINDOW",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			flags := &flag.FlagSet{}
+			config, err := BoundConfig(flags)
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+			} else {
+				assert.Assert(t, err)
+			}
+			flags.Parse(tt.args)
+			assert.DeepEqual(t, config, tt.expectedValue)
+		})
+	}
+}
@@ -4,7 +4,10 @@
 package certificates
 
 import (
+	"bytes"
 	"context"
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"log"
@@ -18,6 +21,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/skupperproject/skupper/internal/certs"
+	"github.com/skupperproject/skupper/internal/kube/metrics"
 	"github.com/skupperproject/skupper/internal/kube/watchers"
 	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
 )
@@ -33,8 +37,15 @@ type ControllerContext interface {
 	SetLabels(namespace string, name string, kind string, labels map[string]string) bool
 	// Called to set any extra annotations on resources managed by the CertificateManager.
 	SetAnnotations(namespace string, name string, kind string, annotations map[string]string) bool
+	// Determines how many previous revisions of a renewed CA
+	// remain trusted in a given namespace.
+	TLSPriorValidRevisions(namespace string) uint64
 }
 
+// DefaultPriorValidRevisions is the number of previous revisions of
+// a renewed CA that remain trusted when there is no ControllerContext.
+const DefaultPriorValidRevisions = 2
+
 // The CertificateManager interface defines the methods through which
 // the existence of a particular Certificate resource can be
 // ensured. It is currently used by package internal/kube/site.
@@ -50,14 +61,18 @@ type CertificateManagerImpl struct {
 	secretWatcher      *watchers.SecretWatcher
 	processor          *watchers.EventProcessor
 	context            ControllerContext
+	renewalWindow      time.Duration
+	renewals           map[string]time.Time
 }
 
 // Returns a correctly initialised CertificateManager.
 func NewCertificateManager(processor *watchers.EventProcessor) *CertificateManagerImpl {
 	return &CertificateManagerImpl{
-		definitions: map[string]*skupperv2alpha1.Certificate{},
-		secrets:     map[string]*corev1.Secret{},
-		processor:   processor,
+		definitions:   map[string]*skupperv2alpha1.Certificate{},
+		secrets:       map[string]*corev1.Secret{},
+		processor:     processor,
+		renewalWindow: DefaultRenewalWindow,
+		renewals:      map[string]time.Time{},
 	}
 }
 
@@ -66,6 +81,12 @@ func (m *CertificateManagerImpl) SetControllerContext(context ControllerContext)
 	m.context = context
 }
 
+// Sets how long before their expiry the certificates managed by this
+// CertificateManager are renewed.
+func (m *CertificateManagerImpl) SetRenewalWindow(window time.Duration) {
+	m.renewalWindow = window
+}
+
 // Causes the CertificateManager to start watching relevant resources.
 func (m *CertificateManagerImpl) Watch(watchNamespace string) {
 	m.certificateWatcher = m.processor.WatchCertificates(watchNamespace, watchers.FilterByNamespace(m.isControlled, m.checkCertificate))
@@ -88,6 +109,7 @@ func (m *CertificateManagerImpl) Recover() {
 			continue
 		}
 		m.secrets[secretKey(secret)] = secret
+		recordExpiry(secret)
 	}
 	for _, cert := range m.certificateWatcher.List() {
 		if !m.isControlled(cert.Namespace) {
@@ -222,20 +244,153 @@ func (m *CertificateManagerImpl) checkCertificate(key string, certificate *skupp
 // This method does whatever is required to ensure that there is a
 // Secret resource corresponding to the supplied CertificateResource.
 func (m *CertificateManagerImpl) reconcile(key string, certificate *skupperv2alpha1.Certificate, secret *corev1.Secret) error {
+	renewal := ""
 	if secret != nil {
-		if err := m.updateSecret(key, certificate, secret); err != nil {
-			return m.updateStatus(certificate, err)
+		if isSecretControlled(secret) {
+			renewal = m.renewalReason(certificate, secret)
+		}
+		err := m.updateSecret(key, certificate, secret, renewal)
+		if renewal != "" {
+			m.recordRenewal(certificate, renewal, err)
+		}
+		if err != nil {
+			return m.updateStatus(certificate, err, renewal != "")
 		}
 	} else {
 		if err := m.createSecret(key, certificate); err != nil {
-			return m.updateStatus(certificate, err)
+			return m.updateStatus(certificate, err, false)
+		}
+	}
+	m.scheduleRenewal(key, certificate)
+	return m.updateStatus(certificate, nil, renewal != "")
+}
+
+// Returns the reason why the controlled Secret for a Certificate
+// needs to be renewed, or an empty string if it does not.
+func (m *CertificateManagerImpl) renewalReason(certificate *skupperv2alpha1.Certificate, secret *corev1.Secret) string {
+	cert, err := certs.DecodeCertificate(secret.Data["tls.crt"])
+	if err != nil {
+		return ""
+	}
+	if time.Now().After(cert.NotAfter) {
+		return fmt.Sprintf("certificate expired at %s", cert.NotAfter.UTC().Format(time.RFC3339))
+	}
+	if time.Now().Add(m.renewalWindow).After(cert.NotAfter) {
+		return fmt.Sprintf("certificate expires at %s", cert.NotAfter.UTC().Format(time.RFC3339))
+	}
+	if !certificate.Spec.Signing {
+		if ca, ok := m.secrets[fmt.Sprintf("%s/%s", certificate.Namespace, certificate.Spec.Ca)]; ok {
+			// a certificate issued by a prior revision of its CA is kept
+			// while that revision is trusted, as peers holding only the
+			// prior revision would otherwise reject it
+			if caCert, err := certs.DecodeCertificate(ca.Data["tls.crt"]); err == nil && cert.CheckSignatureFrom(caCert) != nil && trustedPriorCA(ca, cert) == nil {
+				return fmt.Sprintf("CA %q has been renewed", certificate.Spec.Ca)
+			}
+		}
+	}
+	return ""
+}
+
+// Returns the unexpired CA in the ca.crt bundle of a CA Secret that
+// issued a certificate, or nil if there is none.
+func trustedPriorCA(ca *corev1.Secret, cert *x509.Certificate) *x509.Certificate {
+	bundle := ca.Data["ca.crt"]
+	for {
+		var block *pem.Block
+		block, bundle = pem.Decode(bundle)
+		if block == nil {
+			return nil
+		}
+		prior, err := x509.ParseCertificate(block.Bytes)
+		if err != nil || !time.Now().Before(prior.NotAfter) {
+			continue
+		}
+		if cert.CheckSignatureFrom(prior) == nil {
+			return prior
+		}
+	}
+}
+
+func (m *CertificateManagerImpl) recordRenewal(certificate *skupperv2alpha1.Certificate, reason string, err error) {
+	if err != nil {
+		log.Printf("Failed to renew Certificate %s (%s): %s", certificate.Key(), reason, err)
+		m.recordEvent(certificate, corev1.EventTypeWarning, "RenewalFailed", fmt.Sprintf("Failed to renew certificate (%s): %s", reason, err))
+	} else {
+		log.Printf("Renewed Certificate %s (%s)", certificate.Key(), reason)
+		m.recordEvent(certificate, corev1.EventTypeNormal, "Renewed", fmt.Sprintf("Renewed certificate (%s)", reason))
+	}
+}
+
+func (m *CertificateManagerImpl) recordEvent(certificate *skupperv2alpha1.Certificate, eventType string, reason string, message string) {
+	now := metav1.Now()
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s.%x", certificate.Name, now.UnixNano()),
+			Namespace: certificate.Namespace,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			Kind:            "Certificate",
+			APIVersion:      "skupper.io/v2alpha1",
+			Namespace:       certificate.Namespace,
+			Name:            certificate.Name,
+			UID:             certificate.UID,
+			ResourceVersion: certificate.ResourceVersion,
+		},
+		Reason:         reason,
+		Message:        message,
+		Type:           eventType,
+		Source:         corev1.EventSource{Component: "skupper-controller"},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}
+	if _, err := m.processor.GetKubeClient().CoreV1().Events(certificate.Namespace).Create(context.TODO(), event, metav1.CreateOptions{}); err != nil {
+		log.Printf("Error recording event %s for Certificate %s: %s", reason, certificate.Key(), err)
+	}
+}
+
+// Arranges for the Certificate to be checked again once its controlled
+// Secret enters the renewal window, or once the prior revision of the
+// CA that issued it expires.
+func (m *CertificateManagerImpl) scheduleRenewal(key string, certificate *skupperv2alpha1.Certificate) {
+	secret, ok := m.secrets[key]
+	if !ok || !isSecretControlled(secret) {
+		return
+	}
+	cert, err := certs.DecodeCertificate(secret.Data["tls.crt"])
+	if err != nil {
+		return
+	}
+	renewAt := cert.NotAfter.Add(-m.renewalWindow)
+	if !certificate.Spec.Signing {
+		if ca, ok := m.secrets[fmt.Sprintf("%s/%s", certificate.Namespace, certificate.Spec.Ca)]; ok {
+			if prior := trustedPriorCA(ca, cert); prior != nil && prior.NotAfter.Before(renewAt) {
+				renewAt = prior.NotAfter
+			}
 		}
 	}
-	return m.updateStatus(certificate, nil)
+	if scheduled, ok := m.renewals[key]; ok && scheduled.Equal(renewAt) {
+		return
+	}
+	m.renewals[key] = renewAt
+	m.processor.CallbackAfter(time.Until(renewAt), m.renewalDue, key)
+}
+
+func (m *CertificateManagerImpl) renewalDue(key string) error {
+	// ignore callbacks superseded by a later schedule
+	if renewAt, ok := m.renewals[key]; !ok || time.Now().Before(renewAt) {
+		return nil
+	}
+	delete(m.renewals, key)
+	if definition, ok := m.definitions[key]; ok {
+		return m.checkCertificate(key, definition)
+	}
+	return nil
 }
 
 func (m *CertificateManagerImpl) certificateDeleted(key string) error {
 	delete(m.definitions, key)
+	delete(m.renewals, key)
 	if secret, ok := m.secrets[key]; ok {
 		err := m.processor.GetKubeClient().CoreV1().Secrets(secret.Namespace).Delete(context.Background(), secret.Name, metav1.DeleteOptions{})
 		if err != nil {
@@ -248,12 +403,24 @@ func (m *CertificateManagerImpl) certificateDeleted(key string) error {
 
 func (m *CertificateManagerImpl) secretDeleted(key string) error {
 	delete(m.secrets, key)
+	if namespace, name, ok := strings.Cut(key, "/"); ok {
+		metrics.CertificateRemoved(namespace, name)
+	}
 	//TODO
 	return nil
 }
 
-func (m *CertificateManagerImpl) updateStatus(certificate *skupperv2alpha1.Certificate, err error) error {
-	if certificate.SetReady(err) {
+func (m *CertificateManagerImpl) updateStatus(certificate *skupperv2alpha1.Certificate, err error, renewed bool) error {
+	changed := certificate.SetReady(err)
+	if renewed && certificate.SetRenewed(err) {
+		changed = true
+	}
+	if secret, ok := m.secrets[certificate.Key()]; ok && err == nil {
+		if cert, err := certs.DecodeCertificate(secret.Data["tls.crt"]); err == nil && certificate.SetExpiration(cert.NotAfter) {
+			changed = true
+		}
+	}
+	if changed {
 		latest, err := m.processor.GetSkupperClient().SkupperV2alpha1().Certificates(certificate.Namespace).UpdateStatus(context.TODO(), certificate, metav1.UpdateOptions{})
 		if err != nil {
 			return err
@@ -266,15 +433,15 @@ func (m *CertificateManagerImpl) updateStatus(certificate *skupperv2alpha1.Certi
 	return nil
 }
 
-func (m *CertificateManagerImpl) updateSecret(key string, certificate *skupperv2alpha1.Certificate, secret *corev1.Secret) error {
+func (m *CertificateManagerImpl) updateSecret(key string, certificate *skupperv2alpha1.Certificate, secret *corev1.Secret, renewal string) error {
 	changed := false
 	controlled := isSecretControlled(secret)
-	if !isSecretCorrect(certificate, secret) {
+	if renewal != "" || !isSecretCorrect(certificate, secret) {
 		if !controlled {
 			return errors.New("Secret exists but is not controlled by skupper")
 		}
 
-		regenerated, err := m.generateSecret(certificate)
+		regenerated, err := m.generateSecret(certificate, secret)
 		if err != nil {
 			log.Printf("Error generating Secret %s/%s for Certificate %s", certificate.Namespace, secret.Name, key)
 			return err
@@ -282,6 +449,14 @@ func (m *CertificateManagerImpl) updateSecret(key string, certificate *skupperv2
 		changed = true
 		secret.Data = regenerated.Data
 		secret.Annotations["internal.skupper.io/hosts"] = strings.Join(certificate.Spec.Hosts, ",")
+	} else if controlled && !certificate.Spec.Signing {
+		// trust a renewed CA before the certificate is reissued by it
+		if ca, ok := m.secrets[fmt.Sprintf("%s/%s", certificate.Namespace, certificate.Spec.Ca)]; ok {
+			if bundle, ok := ca.Data["ca.crt"]; ok && !bytes.Equal(secret.Data["ca.crt"], bundle) {
+				secret.Data["ca.crt"] = bundle
+				changed = true
+			}
+		}
 	}
 	if m.context != nil && controlled {
 		if secret.Labels == nil {
@@ -311,7 +486,10 @@ func (m *CertificateManagerImpl) updateSecret(key string, certificate *skupperv2
 	return nil
 }
 
-func (m *CertificateManagerImpl) generateSecret(certificate *skupperv2alpha1.Certificate) (*corev1.Secret, error) {
+// Generates the Secret for a Certificate. When a CA is renewed, the
+// previous revisions of it are kept in ca.crt so that certificates
+// it already issued remain trusted.
+func (m *CertificateManagerImpl) generateSecret(certificate *skupperv2alpha1.Certificate, previous *corev1.Secret) (*corev1.Secret, error) {
 	var secret *corev1.Secret
 	var err error
 	if certificate.Spec.Signing {
@@ -319,6 +497,9 @@ func (m *CertificateManagerImpl) generateSecret(certificate *skupperv2alpha1.Cer
 		if err != nil {
 			return secret, err
 		}
+		if previous != nil {
+			secret.Data["ca.crt"] = append(secret.Data["ca.crt"], priorCAs(previous.Data["ca.crt"], m.priorValidRevisions(certificate.Namespace))...)
+		}
 	} else {
 		expiration := time.Hour * 24 * 365 * 5 // TODO: make this configurable (through controller setting or field on certificate?)
 		caKey := fmt.Sprintf("%s/%s", certificate.Namespace, certificate.Spec.Ca)
@@ -332,13 +513,43 @@ func (m *CertificateManagerImpl) generateSecret(certificate *skupperv2alpha1.Cer
 		if err != nil {
 			return nil, err
 		}
+		if bundle, ok := ca.Data["ca.crt"]; ok {
+			secret.Data["ca.crt"] = bundle
+		}
 	}
 	secret.ObjectMeta.OwnerReferences = ownerReferences(certificate)
 	return secret, nil
 }
 
+func (m *CertificateManagerImpl) priorValidRevisions(namespace string) uint64 {
+	if m.context != nil {
+		return m.context.TLSPriorValidRevisions(namespace)
+	}
+	return DefaultPriorValidRevisions
+}
+
+// Returns up to the given number of unexpired CA certificates from a
+// PEM encoded bundle.
+func priorCAs(bundle []byte, revisions uint64) []byte {
+	var retained []byte
+	for count := uint64(0); count < revisions; {
+		var block *pem.Block
+		block, bundle = pem.Decode(bundle)
+		if block == nil {
+			break
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil || time.Now().After(cert.NotAfter) {
+			continue
+		}
+		retained = append(retained, pem.EncodeToMemory(block)...)
+		count++
+	}
+	return retained
+}
+
 func (m *CertificateManagerImpl) createSecret(key string, certificate *skupperv2alpha1.Certificate) error {
-	secret, err := m.generateSecret(certificate)
+	secret, err := m.generateSecret(certificate, nil)
 	if err != nil {
 		log.Printf("Error generating secret for Certificate %s: %s", key, err)
 		return err
@@ -372,11 +583,25 @@ func (m *CertificateManagerImpl) checkSecret(key string, secret *corev1.Secret)
 		return m.secretDeleted(key)
 	}
 	m.secrets[key] = secret
+	recordExpiry(secret)
 	if definition, ok := m.definitions[key]; ok {
-		return m.reconcile(key, definition, secret)
+		if err := m.reconcile(key, definition, secret); err != nil {
+			return err
+		}
 	}
-
-	return nil
+	// certificates issued by a renewed CA need to trust it, and to be
+	// reissued once the prior revision that issued them is no longer
+	// trusted
+	var errs []error
+	for issuedKey, issued := range m.definitions {
+		if issued.Spec.Signing || issued.Namespace != secret.Namespace || issued.Spec.Ca != secret.Name {
+			continue
+		}
+		if issuedSecret, ok := m.secrets[issuedKey]; ok {
+			errs = append(errs, m.reconcile(issuedKey, issued, issuedSecret))
+		}
+	}
+	return errors.Join(errs...)
 }
 
 func isSecretCorrect(certificate *skupperv2alpha1.Certificate, secret *corev1.Secret) bool {
@@ -411,6 +636,16 @@ func isSecretCorrect(certificate *skupperv2alpha1.Certificate, secret *corev1.Se
 	return true
 }
 
+// Reports the expiry of the certificate held in a controlled Secret.
+func recordExpiry(secret *corev1.Secret) {
+	if !isSecretControlled(secret) {
+		return
+	}
+	if cert, err := certs.DecodeCertificate(secret.Data["tls.crt"]); err == nil {
+		metrics.CertificateExpires(secret.Namespace, secret.Name, cert.NotAfter)
+	}
+}
+
 func isSecretControlled(secret *corev1.Secret) bool {
 	return hasControlledAnnotation(secret) || hasCertificateOwner(secret)
 }
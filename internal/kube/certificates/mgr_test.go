@@ -1,7 +1,10 @@
 package certificates
 
 import (
+	"bytes"
 	"context"
+	"crypto/x509"
+	"encoding/pem"
 	"testing"
 	"time"
 
@@ -23,6 +26,7 @@ type FakeContext struct {
 	controlled  map[string]bool
 	labels      map[string]string
 	annotations map[string]string
+	revisions   uint64
 }
 
 func TestCertificateManager(t *testing.T) {
@@ -306,6 +310,165 @@ func TestCertificateManager(t *testing.T) {
 	}
 }
 
+func TestCertificateRenewal(t *testing.T) {
+	testTable := []struct {
+		name            string
+		controlled      bool
+		context         *FakeContext
+		renewalWindow   time.Duration
+		expiredPriorCA  bool
+		expectRenewal   bool
+		expectReissue   bool
+		expectedCaCerts int
+	}{
+		{
+			name:            "ca renewed and issued certificate kept during overlap",
+			controlled:      true,
+			context:         fakeContext().control("test"),
+			expectRenewal:   true,
+			expectedCaCerts: 2,
+		},
+		{
+			name:            "no prior revisions retained",
+			controlled:      true,
+			context:         fakeContext().control("test").priorValidRevisions(0),
+			expectRenewal:   true,
+			expectReissue:   true,
+			expectedCaCerts: 1,
+		},
+		{
+			name:            "issued certificate reissued once prior revision expired",
+			controlled:      true,
+			context:         fakeContext().control("test"),
+			renewalWindow:   time.Hour,
+			expiredPriorCA:  true,
+			expectReissue:   true,
+			expectedCaCerts: 2,
+		},
+		{
+			name:            "outside configured renewal window",
+			controlled:      true,
+			context:         fakeContext().control("test"),
+			renewalWindow:   time.Hour,
+			expectedCaCerts: 1,
+		},
+		{
+			name:            "non-controlled secrets not renewed",
+			context:         fakeContext().control("test"),
+			expectedCaCerts: 1,
+		},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			ca := fixtureCASecret(t, "my-ca", "test")
+			leafIssuer := ca
+			if tt.expiredPriorCA {
+				prior, err := certs.GenerateSecret("my-ca", "skupper test CA", "", -time.Hour, nil)
+				assert.Assert(t, err)
+				ca.Data["ca.crt"] = append(ca.Data["ca.crt"], prior.Data["tls.crt"]...)
+				leafIssuer = prior
+			}
+			leaf, err := certs.GenerateSecret("foo", "my-subject", "aaa", 0, leafIssuer)
+			assert.Assert(t, err)
+			leaf.Namespace = "test"
+			if tt.controlled {
+				ca.Annotations = map[string]string{"internal.skupper.io/controlled": "true"}
+				leaf.Annotations = map[string]string{"internal.skupper.io/controlled": "true"}
+			}
+			client, err := fakeclient.NewFakeClient("test", []runtime.Object{ca, leaf}, []runtime.Object{
+				caCertificate("my-ca", "test", "skupper test CA", nil, nil),
+				certificate("foo", "test", "my-ca", "my-subject", []string{"aaa"}, false, true, nil, nil),
+			}, "")
+			assert.Assert(t, err)
+			processor := watchers.NewEventProcessor("Controller", client)
+			mgr := NewCertificateManager(processor)
+			mgr.SetControllerContext(tt.context)
+			if tt.renewalWindow != 0 {
+				mgr.SetRenewalWindow(tt.renewalWindow)
+			}
+			mgr.Watch(metav1.NamespaceAll)
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+			processor.StartWatchers(stopCh)
+			processor.WaitForCacheSync(stopCh)
+			mgr.Recover()
+			// let the informer caches catch up with the changes made on recovery
+			time.Sleep(100 * time.Millisecond)
+			processor.TestProcessAll()
+
+			actualCa, err := client.GetKubeClient().CoreV1().Secrets("test").Get(context.Background(), "my-ca", metav1.GetOptions{})
+			assert.Assert(t, err)
+			actualLeaf, err := client.GetKubeClient().CoreV1().Secrets("test").Get(context.Background(), "foo", metav1.GetOptions{})
+			assert.Assert(t, err)
+			caCerts := decodeBundle(t, actualCa.Data["ca.crt"])
+			assert.Equal(t, len(caCerts), tt.expectedCaCerts)
+			issuer, err := certs.DecodeCertificate(actualCa.Data["tls.crt"])
+			assert.Assert(t, err)
+			issued, err := certs.DecodeCertificate(actualLeaf.Data["tls.crt"])
+			assert.Assert(t, err)
+			roots := x509.NewCertPool()
+			for _, caCert := range caCerts {
+				roots.AddCert(caCert)
+			}
+			_, err = issued.Verify(x509.VerifyOptions{DNSName: "aaa", Roots: roots})
+			assert.Assert(t, err)
+
+			events, err := client.GetKubeClient().CoreV1().Events("test").List(context.Background(), metav1.ListOptions{})
+			assert.Assert(t, err)
+			renewed := map[string]bool{}
+			for _, event := range events.Items {
+				assert.Equal(t, event.Reason, "Renewed")
+				assert.Equal(t, event.InvolvedObject.Kind, "Certificate")
+				renewed[event.InvolvedObject.Name] = true
+			}
+			if tt.expectRenewal {
+				assert.Assert(t, !bytes.Equal(actualCa.Data["tls.crt"], ca.Data["tls.crt"]))
+				assert.DeepEqual(t, actualLeaf.Data["ca.crt"], actualCa.Data["ca.crt"])
+				if tt.expectedCaCerts > 1 {
+					original, err := certs.DecodeCertificate(ca.Data["tls.crt"])
+					assert.Assert(t, err)
+					assert.Assert(t, caCerts[len(caCerts)-1].Equal(original))
+				}
+				assert.Assert(t, renewed["my-ca"])
+				cert, err := client.GetSkupperClient().SkupperV2alpha1().Certificates("test").Get(context.Background(), "my-ca", metav1.GetOptions{})
+				assert.Assert(t, err)
+				assert.Assert(t, meta.IsStatusConditionTrue(cert.Status.Conditions, skupperv2alpha1.CONDITION_TYPE_RENEWED))
+				assert.Equal(t, cert.Status.Expiration, issuer.NotAfter.UTC().Format(time.RFC3339))
+			} else {
+				assert.DeepEqual(t, actualCa.Data, ca.Data)
+				assert.Assert(t, !renewed["my-ca"])
+			}
+			if tt.expectReissue {
+				assert.Assert(t, !bytes.Equal(actualLeaf.Data["tls.crt"], leaf.Data["tls.crt"]))
+				assert.Assert(t, issued.CheckSignatureFrom(issuer))
+				assert.Assert(t, renewed["foo"])
+			} else {
+				assert.DeepEqual(t, actualLeaf.Data["tls.crt"], leaf.Data["tls.crt"])
+				assert.Assert(t, !renewed["foo"])
+			}
+			if !tt.expectRenewal && !tt.expectReissue {
+				assert.DeepEqual(t, actualLeaf.Data, leaf.Data)
+				assert.Equal(t, len(renewed), 0)
+			}
+		})
+	}
+}
+
+func decodeBundle(t *testing.T, bundle []byte) []*x509.Certificate {
+	t.Helper()
+	var result []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, bundle = pem.Decode(bundle)
+		if block == nil {
+			return result
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		assert.Assert(t, err)
+		result = append(result, cert)
+	}
+}
+
 func secret(name string, namespace string, data map[string][]byte, labels map[string]string, annotations map[string]string) *corev1.Secret {
 	return &corev1.Secret{
 		TypeMeta: metav1.TypeMeta{
@@ -403,6 +566,7 @@ func fakeContext() *FakeContext {
 		controlled:  map[string]bool{},
 		labels:      map[string]string{},
 		annotations: map[string]string{},
+		revisions:   DefaultPriorValidRevisions,
 	}
 }
 
@@ -421,6 +585,15 @@ func (c *FakeContext) annotate(key, value string) *FakeContext {
 	return c
 }
 
+func (c *FakeContext) priorValidRevisions(revisions uint64) *FakeContext {
+	c.revisions = revisions
+	return c
+}
+
+func (c *FakeContext) TLSPriorValidRevisions(namespace string) uint64 {
+	return c.revisions
+}
+
 func (c *FakeContext) IsControlled(namespace string) bool {
 	return c.controlled[namespace]
 }
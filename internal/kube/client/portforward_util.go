@@ -0,0 +1,57 @@
+package client
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// PortForward forwards a random local port to the given port of a pod,
+// returning the local port once the forwarding is ready. Forwarding
+// carries on until the stop channel is closed.
+func PortForward(podName string, namespace string, port int, config *restclient.Config, stop chan struct{}) (uint16, error) {
+	transport, upgrader, err := spdy.RoundTripperFor(config)
+	if err != nil {
+		return 0, err
+	}
+	restClient, err := restclient.RESTClientFor(config)
+	if err != nil {
+		return 0, err
+	}
+	req := restClient.Post().
+		Resource("pods").
+		Name(podName).
+		Namespace(namespace).
+		SubResource("portforward")
+
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", req.URL())
+	ready := make(chan struct{})
+	forwarder, err := portforward.NewOnAddresses(dialer, []string{"127.0.0.1"}, []string{fmt.Sprintf("0:%d", port)}, stop, ready, io.Discard, io.Discard)
+	if err != nil {
+		return 0, err
+	}
+	errs := make(chan error, 1)
+	go func() {
+		errs <- forwarder.ForwardPorts()
+	}()
+	select {
+	case <-ready:
+	case err := <-errs:
+		if err == nil {
+			err = fmt.Errorf("port forwarding to %s stopped", podName)
+		}
+		return 0, err
+	}
+	ports, err := forwarder.GetPorts()
+	if err != nil {
+		return 0, err
+	}
+	if len(ports) == 0 {
+		return 0, fmt.Errorf("no port forwarded to %s", podName)
+	}
+	return ports[0].Local, nil
+}
@@ -2,10 +2,12 @@ package controller
 
 import (
 	"flag"
+	"fmt"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	iflag "github.com/skupperproject/skupper/internal/flag"
+	"github.com/skupperproject/skupper/internal/kube/certificates"
 	"github.com/skupperproject/skupper/internal/kube/grants"
 	"github.com/skupperproject/skupper/internal/kube/securedaccess"
 )
@@ -13,11 +15,13 @@ import (
 type Config struct {
 	GrantConfig            *grants.GrantConfig
 	SecuredAccessConfig    *securedaccess.Config
+	CertificateConfig      *certificates.Config
 	Namespace              string
 	Kubeconfig             string
 	WatchNamespace         string
 	Name                   string
 	RequireExplicitControl bool
+	MetricsPort            int
 }
 
 func (c *Config) WatchingAllNamespaces() bool {
@@ -28,6 +32,14 @@ func (c *Config) requireExplicitControl() bool {
 	return !c.WatchingAllNamespaces() || c.RequireExplicitControl
 }
 
+func (c *Config) MetricsEnabled() bool {
+	return c.MetricsPort > 0
+}
+
+func (c *Config) MetricsAddress() string {
+	return fmt.Sprintf(":%d", c.MetricsPort)
+}
+
 func BoundConfig(flags *flag.FlagSet) (*Config, error) {
 	grantConfig, err := grants.BoundGrantConfig(flags)
 	if err != nil {
@@ -39,14 +51,22 @@ func BoundConfig(flags *flag.FlagSet) (*Config, error) {
 	} else if err := securedAccessConfig.Verify(); err != nil {
 		return nil, err
 	}
+	certificateConfig, err := certificates.BoundConfig(flags)
+	if err != nil {
+		return nil, err
+	}
 	c := &Config{
 		GrantConfig:         grantConfig,
 		SecuredAccessConfig: securedAccessConfig,
+		CertificateConfig:   certificateConfig,
 	}
 	iflag.StringVar(flags, &c.Namespace, "namespace", "NAMESPACE", "", "The Kubernetes namespace scope for the controller")
 	iflag.StringVar(flags, &c.Kubeconfig, "kubeconfig", "KUBECONFIG", "", "A path to the kubeconfig file to use")
 	iflag.StringVar(flags, &c.WatchNamespace, "watch-namespace", "WATCH_NAMESPACE", metav1.NamespaceAll, "The Kubernetes namespace the controller should monitor for controlled resources (will monitor all if not specified)")
 	iflag.StringVar(flags, &c.Name, "name", "CONTROLLER_NAME", "", "A name identifying the controller. If not specified it will be deduced from the hostname.")
+	if err := iflag.IntVar(flags, &c.MetricsPort, "metrics-port", "SKUPPER_CONTROLLER_METRICS_PORT", 9191, "The port on which metrics and health checks are served (disabled if 0)."); err != nil {
+		return nil, err
+	}
 	iflag.BoolVar(flags, &c.RequireExplicitControl, "require-explicit-control", "REQUIRE_EXPLICIT_CONTROL", false, "If set, this controller instance will only process resources in which there is a ConfigMap named skupper with an entry 'controller' whose value matches the controller's namespace qualified name. Controllers watching a single namespace require that ConfigMap regardless of this setting.")
 	return c, nil
 }
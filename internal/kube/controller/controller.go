@@ -8,6 +8,7 @@ import (
 	"log/slog"
 	"os"
 	"regexp"
+	"sync/atomic"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -18,6 +19,7 @@ import (
 	"github.com/skupperproject/skupper/internal/kube/certificates"
 	internalclient "github.com/skupperproject/skupper/internal/kube/client"
 	"github.com/skupperproject/skupper/internal/kube/grants"
+	kubeqdr "github.com/skupperproject/skupper/internal/kube/qdr"
 	"github.com/skupperproject/skupper/internal/kube/securedaccess"
 	"github.com/skupperproject/skupper/internal/kube/site"
 	"github.com/skupperproject/skupper/internal/kube/site/labels"
@@ -52,6 +54,7 @@ type Controller struct {
 	attachableConnectors map[string]*skupperv2alpha1.AttachedConnector
 	log                  *slog.Logger
 	namespaces           *NamespaceConfig
+	ready                atomic.Bool
 }
 
 func skupperRouterConfig() internalinterfaces.TweakListOptionsFunc {
@@ -59,6 +62,11 @@ func skupperRouterConfig() internalinterfaces.TweakListOptionsFunc {
 		options.LabelSelector = "internal.skupper.io/router-config"
 	}
 }
+func skupperLinkOrigins() internalinterfaces.TweakListOptionsFunc {
+	return func(options *metav1.ListOptions) {
+		options.FieldSelector = "metadata.name=" + grants.LinkOriginsConfigMap
+	}
+}
 func skupperNetworkStatus() internalinterfaces.TweakListOptionsFunc {
 	return func(options *metav1.ListOptions) {
 		options.FieldSelector = "metadata.name=skupper-network-status"
@@ -125,8 +133,10 @@ func NewController(cli internalclient.Clients, config *Config) (*Controller, err
 	controller.eventProcessor.WatchAttachedConnectors(config.WatchNamespace, filter(controller, controller.checkAttachedConnector))
 	controller.eventProcessor.WatchAttachedConnectorBindings(config.WatchNamespace, filter(controller, controller.checkAttachedConnectorBinding))
 	controller.eventProcessor.WatchLinks(config.WatchNamespace, filter(controller, controller.checkLink))
+	controller.eventProcessor.WatchAccessPolicies(config.WatchNamespace, filter(controller, controller.checkAccessPolicy))
 	controller.eventProcessor.WatchConfigMaps(skupperNetworkStatus(), config.WatchNamespace, filter(controller, controller.networkStatusUpdate))
 	controller.eventProcessor.WatchConfigMaps(skupperRouterConfig(), config.WatchNamespace, filter(controller, controller.routerConfigUpdate))
+	controller.eventProcessor.WatchConfigMaps(skupperLinkOrigins(), config.WatchNamespace, filter(controller, controller.linkOriginsUpdate))
 	controller.eventProcessor.WatchAccessTokens(config.WatchNamespace, filter(controller, controller.checkAccessToken))
 	controller.eventProcessor.WatchPods("skupper.io/component=router,skupper.io/type=site", config.WatchNamespace, filter(controller, controller.routerPodEvent))
 	controller.siteSizingWatcher = controller.eventProcessor.WatchConfigMaps(skupperSiteSizingConfig(), config.Namespace, filter(controller, controller.siteSizing.Update))
@@ -135,6 +145,9 @@ func NewController(cli internalclient.Clients, config *Config) (*Controller, err
 
 	controller.certMgr = certificates.NewCertificateManager(controller.eventProcessor)
 	controller.certMgr.SetControllerContext(controller)
+	if config.CertificateConfig != nil {
+		controller.certMgr.SetRenewalWindow(config.CertificateConfig.RenewalWindow)
+	}
 	controller.certMgr.Watch(config.WatchNamespace)
 
 	controller.accessMgr = securedaccess.NewSecuredAccessManager(controller.eventProcessor, controller.certMgr, config.SecuredAccessConfig, controller)
@@ -162,6 +175,13 @@ func (c *Controller) SetAnnotations(namespace string, name string, kind string,
 	return c.labelling.SetAnnotations(namespace, name, kind, annotations)
 }
 
+func (c *Controller) TLSPriorValidRevisions(namespace string) uint64 {
+	if site, ok := c.sites[namespace]; ok {
+		return site.TLSPriorValidRevisions()
+	}
+	return certificates.DefaultPriorValidRevisions
+}
+
 func (c *Controller) Namespace() string {
 	return c.self.Namespace
 }
@@ -309,11 +329,18 @@ func (c *Controller) init(stopCh <-chan struct{}) error {
 func (c *Controller) start(stopCh <-chan struct{}) error {
 	c.log.Info("Starting event loop")
 	c.eventProcessor.Start(stopCh)
+	c.ready.Store(true)
 	<-stopCh
 	c.log.Info("Shutting down")
 	return nil
 }
 
+// Ready reports whether the controller has recovered its state and is
+// handling events.
+func (c *Controller) Ready() bool {
+	return c.ready.Load()
+}
+
 func (c *Controller) getSite(namespace string) *site.Site {
 	if existing, ok := c.sites[namespace]; ok {
 		return existing
@@ -386,6 +413,14 @@ func (c *Controller) checkLink(key string, linkconfig *skupperv2alpha1.Link) err
 	return c.getSite(namespace).CheckLink(name, linkconfig)
 }
 
+func (c *Controller) checkAccessPolicy(key string, policy *skupperv2alpha1.AccessPolicy) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+	return c.getSite(namespace).CheckAccessPolicy(name, policy)
+}
+
 func (c *Controller) checkAccessToken(key string, token *skupperv2alpha1.AccessToken) error {
 	if token == nil || token.IsRedeemed() {
 		return nil
@@ -462,8 +497,25 @@ func (c *Controller) routerConfigUpdate(_ string, cm *corev1.ConfigMap) error {
 	if err != nil {
 		return err
 	}
-	c.getSite(cm.Namespace).CheckSslProfiles(config)
-	return nil
+	s := c.getSite(cm.Namespace)
+	s.CheckSslProfiles(config)
+	drift, err := kubeqdr.GetRouterConfigDrift(cm)
+	if err != nil {
+		c.log.Error("Error parsing router config drift",
+			slog.String("namespace", cm.Namespace),
+			slog.String("name", cm.Name),
+			slog.Any("error", err))
+		return nil
+	}
+	return s.RouterConfigDriftUpdated(cm.Name, drift)
+}
+
+func (c *Controller) linkOriginsUpdate(key string, cm *corev1.ConfigMap) error {
+	namespace, _, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+	return c.getSite(namespace).LinkOriginsUpdated(grants.GetLinkOrigins(cm))
 }
 
 func (c *Controller) networkStatusUpdate(key string, cm *corev1.ConfigMap) error {
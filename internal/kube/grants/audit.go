@@ -0,0 +1,207 @@
+package grants
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	internalclient "github.com/skupperproject/skupper/internal/kube/client"
+	"github.com/skupperproject/skupper/internal/kube/metrics"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+)
+
+// RedemptionRedeemed is the outcome of a successful redemption; other
+// outcomes are the reasons defined for failures in the metrics package.
+const RedemptionRedeemed = "redeemed"
+
+const (
+	DefaultAuditLogMaxSize  = 10 * 1024 * 1024
+	DefaultAuditLogMaxFiles = 5
+)
+
+// RedemptionAttempt is the audit record of an attempt to redeem an
+// AccessGrant.
+type RedemptionAttempt struct {
+	Time          time.Time `json:"time"`
+	Namespace     string    `json:"namespace,omitempty"`
+	Grant         string    `json:"grant,omitempty"`
+	Key           string    `json:"key"`
+	RemoteAddress string    `json:"remoteAddress"`
+	Subject       string    `json:"subject,omitempty"`
+	SiteName      string    `json:"siteName,omitempty"`
+	Outcome       string    `json:"outcome"`
+}
+
+func (a RedemptionAttempt) failed() bool {
+	return a.Outcome != RedemptionRedeemed
+}
+
+func (a RedemptionAttempt) summary() skupperv2alpha1.AccessGrantRedemptionAttempt {
+	return skupperv2alpha1.AccessGrantRedemptionAttempt{
+		Time:          a.Time.UTC().Format(time.RFC3339),
+		RemoteAddress: a.RemoteAddress,
+		Subject:       a.Subject,
+		Outcome:       a.Outcome,
+	}
+}
+
+// RedemptionAuditor records an attempt to redeem an AccessGrant. The
+// grant is nil if the attempt did not match any known AccessGrant.
+type RedemptionAuditor func(grant *skupperv2alpha1.AccessGrant, attempt RedemptionAttempt)
+
+// AuditLog writes redemption attempts as JSON lines to a file, rotating
+// it once it reaches a maximum size and keeping a bounded number of
+// rotated files alongside it (<path>.1 being the most recent).
+type AuditLog struct {
+	path     string
+	maxSize  int64
+	maxFiles int
+	file     *os.File
+	size     int64
+	lock     sync.Mutex
+}
+
+func NewAuditLog(path string, maxSize int64, maxFiles int) *AuditLog {
+	return &AuditLog{
+		path:     path,
+		maxSize:  maxSize,
+		maxFiles: maxFiles,
+	}
+}
+
+// Record is a RedemptionAuditor writing to the audit log.
+func (a *AuditLog) Record(grant *skupperv2alpha1.AccessGrant, attempt RedemptionAttempt) {
+	data, err := json.Marshal(attempt)
+	if err != nil {
+		log.Printf("Error encoding redemption attempt for audit log: %s", err)
+		return
+	}
+	if err := a.write(append(data, '\n')); err != nil {
+		log.Printf("Error writing redemption attempt to audit log %s: %s", a.path, err)
+	}
+}
+
+func (a *AuditLog) write(data []byte) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if a.file != nil && a.maxSize > 0 && a.size+int64(len(data)) > a.maxSize {
+		if err := a.rotate(); err != nil {
+			return err
+		}
+	}
+	if a.file == nil {
+		if err := a.open(); err != nil {
+			return err
+		}
+	}
+	n, err := a.file.Write(data)
+	a.size += int64(n)
+	return err
+}
+
+func (a *AuditLog) open() error {
+	if err := os.MkdirAll(filepath.Dir(a.path), 0700); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(a.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	a.file = file
+	a.size = info.Size()
+	return nil
+}
+
+func (a *AuditLog) rotate() error {
+	if err := a.file.Close(); err != nil {
+		return err
+	}
+	a.file = nil
+	if a.maxFiles <= 0 {
+		return os.Remove(a.path)
+	}
+	for i := a.maxFiles - 1; i > 0; i-- {
+		if err := os.Rename(a.rotated(i), a.rotated(i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return os.Rename(a.path, a.rotated(1))
+}
+
+func (a *AuditLog) rotated(index int) string {
+	return fmt.Sprintf("%s.%d", a.path, index)
+}
+
+// Close closes the current audit log file; it is reopened on the next
+// attempt recorded.
+func (a *AuditLog) Close() error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if a.file == nil {
+		return nil
+	}
+	err := a.file.Close()
+	a.file = nil
+	return err
+}
+
+// newEventAuditor returns a RedemptionAuditor recording attempts as
+// Kubernetes Events on the AccessGrant. Attempts not matching a grant
+// and those refused by rate limiting are not recorded, so that the
+// number of events cannot be driven by a client.
+func newEventAuditor(clients internalclient.Clients) RedemptionAuditor {
+	return func(grant *skupperv2alpha1.AccessGrant, attempt RedemptionAttempt) {
+		if grant == nil || attempt.Outcome == metrics.RedemptionRateLimited {
+			return
+		}
+		eventType := corev1.EventTypeNormal
+		reason := "Redeemed"
+		message := fmt.Sprintf("Redeemed from %s", attempt.RemoteAddress)
+		if attempt.failed() {
+			eventType = corev1.EventTypeWarning
+			reason = "RedemptionRefused"
+			message = fmt.Sprintf("Redemption from %s refused (%s)", attempt.RemoteAddress, attempt.Outcome)
+		}
+		if attempt.Subject != "" {
+			message = fmt.Sprintf("%s for subject %q", message, attempt.Subject)
+		}
+		now := metav1.NewTime(attempt.Time)
+		event := &corev1.Event{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s.%x", grant.Name, attempt.Time.UnixNano()),
+				Namespace: grant.Namespace,
+			},
+			InvolvedObject: corev1.ObjectReference{
+				Kind:            "AccessGrant",
+				APIVersion:      "skupper.io/v2alpha1",
+				Namespace:       grant.Namespace,
+				Name:            grant.Name,
+				UID:             grant.UID,
+				ResourceVersion: grant.ResourceVersion,
+			},
+			Reason:         reason,
+			Message:        message,
+			Type:           eventType,
+			Source:         corev1.EventSource{Component: "skupper-controller"},
+			FirstTimestamp: now,
+			LastTimestamp:  now,
+			Count:          1,
+		}
+		if _, err := clients.GetKubeClient().CoreV1().Events(grant.Namespace).Create(context.TODO(), event, metav1.CreateOptions{}); err != nil {
+			log.Printf("Error recording event %s for AccessGrant %s/%s: %s", reason, grant.Namespace, grant.Name, err)
+		}
+	}
+}
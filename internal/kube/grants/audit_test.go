@@ -0,0 +1,61 @@
+package grants
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func readAuditLog(t *testing.T, path string) []RedemptionAttempt {
+	file, err := os.Open(path)
+	assert.Assert(t, err)
+	defer file.Close()
+	var attempts []RedemptionAttempt
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		attempt := RedemptionAttempt{}
+		assert.Assert(t, json.Unmarshal(scanner.Bytes(), &attempt))
+		attempts = append(attempts, attempt)
+	}
+	assert.Assert(t, scanner.Err())
+	return attempts
+}
+
+func TestAuditLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "grant-server", "audit.log")
+	attempt := RedemptionAttempt{
+		Time:          time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC),
+		Namespace:     "test",
+		Grant:         "my-grant",
+		Key:           "0bde3bc8-a4a2-404a-bfbe-44fdf7bf3231",
+		RemoteAddress: "10.0.0.1",
+		Subject:       "west",
+		Outcome:       "invalid_code",
+	}
+	line, err := json.Marshal(attempt)
+	assert.Assert(t, err)
+
+	// room for two records per file
+	auditLog := NewAuditLog(path, int64(2*(len(line)+1)), 2)
+	for i := 0; i < 7; i++ {
+		auditLog.Record(nil, attempt)
+	}
+	assert.Assert(t, auditLog.Close())
+
+	assert.Equal(t, len(readAuditLog(t, path)), 1)
+	assert.Equal(t, len(readAuditLog(t, path+".1")), 2)
+	assert.DeepEqual(t, readAuditLog(t, path+".2"), []RedemptionAttempt{attempt, attempt})
+	_, err = os.Stat(path + ".3")
+	assert.Assert(t, os.IsNotExist(err))
+
+	// writing resumes at the end of the existing file
+	auditLog = NewAuditLog(path, int64(2*(len(line)+1)), 2)
+	auditLog.Record(nil, attempt)
+	assert.Assert(t, auditLog.Close())
+	assert.Equal(t, len(readAuditLog(t, path)), 2)
+}
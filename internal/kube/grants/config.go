@@ -15,6 +15,9 @@ type GrantConfig struct {
 	Port                 int
 	TlsCredentialsSecret string
 	Hostname             string
+	RateLimits           RateLimits
+	AuditLog             string
+	AuditEvents          bool
 }
 
 func BoundGrantConfig(flags *flag.FlagSet) (*GrantConfig, error) {
@@ -32,12 +35,35 @@ func BoundGrantConfig(flags *flag.FlagSet) (*GrantConfig, error) {
 	}
 	iflag.StringVar(flags, &c.TlsCredentialsSecret, "grant-server-tls-credentials", "SKUPPER_GRANT_SERVER_TLS_CREDENTIALS", "skupper-grant-server", "The name of a secret in which TLS credentials for the AccessGrant server are found.")
 	iflag.StringVar(flags, &c.Hostname, "grant-server-podname", "HOSTNAME", "", "The name of the pod in which the AccessGrant server is running (defaults to $HOSTNAME).")
+	errors = append(errors, BoundRateLimits(flags, &c.RateLimits)...)
+	iflag.StringVar(flags, &c.AuditLog, "grant-server-audit-log", "SKUPPER_GRANT_SERVER_AUDIT_LOG", "", "The path of a file to which every AccessGrant redemption attempt is written as a JSON line.")
+	if err := iflag.BoolVar(flags, &c.AuditEvents, "grant-server-audit-events", "SKUPPER_GRANT_SERVER_AUDIT_EVENTS", false, "Record AccessGrant redemption attempts as Kubernetes Events on the AccessGrant."); err != nil {
+		errors = append(errors, err.Error())
+	}
 	if len(errors) > 0 {
 		return c, fmt.Errorf("Invalid environment variable(s): %s", strings.Join(errors, ", "))
 	}
 	return c, nil
 }
 
+// BoundRateLimits binds the flags limiting the rate of AccessGrant
+// redemptions, returning the description of any invalid environment
+// variable.
+func BoundRateLimits(flags *flag.FlagSet, limits *RateLimits) []string {
+	var errors []string
+	limits.Window = DefaultRateLimits.Window
+	if err := iflag.IntVar(flags, &limits.Attempts, "grant-server-rate-limit", "SKUPPER_GRANT_SERVER_RATE_LIMIT", DefaultRateLimits.Attempts, "The number of AccessGrant redemption attempts allowed per minute from a single address (0 for no limit)."); err != nil {
+		errors = append(errors, err.Error())
+	}
+	if err := iflag.IntVar(flags, &limits.MaxFailures, "grant-server-max-failures", "SKUPPER_GRANT_SERVER_MAX_FAILURES", DefaultRateLimits.MaxFailures, "The number of consecutive failed AccessGrant redemptions from a single address after which further attempts from it are refused (0 for no lockout)."); err != nil {
+		errors = append(errors, err.Error())
+	}
+	if err := iflag.DurationVar(flags, &limits.Lockout, "grant-server-lockout", "SKUPPER_GRANT_SERVER_LOCKOUT", DefaultRateLimits.Lockout, "The time for which AccessGrant redemptions are refused after repeated failures."); err != nil {
+		errors = append(errors, err.Error())
+	}
+	return errors
+}
+
 func (c *GrantConfig) addr() string {
 	return fmt.Sprintf(":%d", c.Port)
 }
@@ -4,6 +4,7 @@ import (
 	"flag"
 	"os"
 	"testing"
+	"time"
 
 	"gotest.tools/v3/assert"
 )
@@ -22,6 +23,7 @@ func Test_BoundGrantConfig(t *testing.T) {
 				Port:                 9090,
 				TlsCredentialsSecret: "skupper-grant-server",
 				Hostname:             os.Getenv("HOSTNAME"),
+				RateLimits:           DefaultRateLimits,
 			},
 		},
 		{
@@ -41,6 +43,7 @@ func Test_BoundGrantConfig(t *testing.T) {
 				Port:                 1234,
 				TlsCredentialsSecret: "my-secret",
 				Hostname:             "my-host",
+				RateLimits:           DefaultRateLimits,
 			},
 		},
 		{
@@ -68,6 +71,7 @@ func Test_BoundGrantConfig(t *testing.T) {
 				Port:                 9876,
 				TlsCredentialsSecret: "a-different-secret",
 				Hostname:             "a-different-host",
+				RateLimits:           DefaultRateLimits,
 			},
 		},
 		{
@@ -87,6 +91,7 @@ func Test_BoundGrantConfig(t *testing.T) {
 				Port:                 1234,
 				TlsCredentialsSecret: "my-secret",
 				Hostname:             "my-host",
+				RateLimits:           DefaultRateLimits,
 			},
 		},
 		{
@@ -111,6 +116,7 @@ func Test_BoundGrantConfig(t *testing.T) {
 				Port:                 1234,
 				TlsCredentialsSecret: "my-secret",
 				Hostname:             "my-host",
+				RateLimits:           DefaultRateLimits,
 			},
 		},
 		{
@@ -135,6 +141,7 @@ func Test_BoundGrantConfig(t *testing.T) {
 				Port:                 1234,
 				TlsCredentialsSecret: "my-secret",
 				Hostname:             "my-host",
+				RateLimits:           DefaultRateLimits,
 			},
 		},
 		{
@@ -159,6 +166,48 @@ func Test_BoundGrantConfig(t *testing.T) {
 				Port:                 9090,
 				TlsCredentialsSecret: "my-secret",
 				Hostname:             "my-host",
+				RateLimits:           DefaultRateLimits,
+			},
+		},
+		{
+			name: "rate limits and audit",
+			env: map[string]string{
+				"SKUPPER_GRANT_SERVER_RATE_LIMIT":   "10",
+				"SKUPPER_GRANT_SERVER_AUDIT_EVENTS": "true",
+			},
+			args: []string{
+				"--grant-server-max-failures=3",
+				"--grant-server-lockout=1h",
+				"--grant-server-audit-log=/var/log/skupper/grants.log",
+			},
+			expectedValue: &GrantConfig{
+				Port:                 9090,
+				TlsCredentialsSecret: "skupper-grant-server",
+				Hostname:             os.Getenv("HOSTNAME"),
+				RateLimits: RateLimits{
+					Attempts:    10,
+					Window:      time.Minute,
+					MaxFailures: 3,
+					Lockout:     time.Hour,
+				},
+				AuditLog:    "/var/log/skupper/grants.log",
+				AuditEvents: true,
+			},
+		},
+		{
+			name: "invalid env var for lockout",
+			env: map[string]string{
+				"SKUPPER_GRANT_SERVER_LOCKOUT": "forever",
+			},
+			expectedErrors: []string{
+				"Invalid environment variable(s)",
+				"SKUPPER_GRANT_SERVER_LOCKOUT",
+			},
+			expectedValue: &GrantConfig{
+				Port:                 9090,
+				TlsCredentialsSecret: "skupper-grant-server",
+				Hostname:             os.Getenv("HOSTNAME"),
+				RateLimits:           DefaultRateLimits,
 			},
 		},
 	}
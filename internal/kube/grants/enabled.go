@@ -1,12 +1,18 @@
 package grants
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"slices"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	internalclient "github.com/skupperproject/skupper/internal/kube/client"
 	"github.com/skupperproject/skupper/internal/kube/watchers"
+	"github.com/skupperproject/skupper/internal/network"
+	"github.com/skupperproject/skupper/internal/site"
 	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
 )
 
@@ -14,12 +20,23 @@ type NamespaceFilter func(string) bool
 
 func enabled(controller *watchers.EventProcessor, currentNamespace string, watchNamespace string, config *GrantConfig, generator GrantResponse, filter NamespaceFilter) *GrantsEnabled {
 	gc := &GrantsEnabled{
-		grants: newGrants(controller, generator, config.scheme(), config.BaseUrl),
+		grants:  newGrants(controller, generator, config.scheme(), config.BaseUrl),
+		clients: controller,
 	}
 	gc.server = newServer(config.addr(), config.tlsEnabled(), gc.grants)
 
 	gc.grantWatcher = controller.WatchAccessGrants(watchNamespace, watchers.FilterByNamespace(filter, gc.grants.checkGrant))
 	gc.secretWatcher = controller.WatchSecrets(watchers.ByName(config.TlsCredentialsSecret), watchNamespace, watchers.FilterByNamespace(filter, gc.tlsCredentialsUpdated))
+	gc.policyWatcher = controller.WatchAccessPolicies(watchNamespace, watchers.FilterByNamespace(filter, gc.accessPolicyUpdated))
+	gc.grants.SetRedemptionPolicy(gc.checkLinkOrigin)
+	gc.grants.SetCredentialRecorder(gc.recordLinkOrigin)
+	gc.grants.SetRateLimiter(NewRateLimiter(config.RateLimits))
+	if config.AuditLog != "" {
+		gc.grants.AddRedemptionAuditor(NewAuditLog(config.AuditLog, DefaultAuditLogMaxSize, DefaultAuditLogMaxFiles).Record)
+	}
+	if config.AuditEvents {
+		gc.grants.AddRedemptionAuditor(newEventAuditor(controller))
+	}
 
 	if config.AutoConfigure {
 		ac, err := newAutoConfigure(gc.securedAccessChanged, controller, currentNamespace, config)
@@ -33,9 +50,11 @@ func enabled(controller *watchers.EventProcessor, currentNamespace string, watch
 
 type GrantsEnabled struct {
 	grants        *Grants
+	clients       internalclient.Clients
 	server        *Server
 	grantWatcher  *watchers.AccessGrantWatcher
 	secretWatcher *watchers.SecretWatcher
+	policyWatcher *watchers.AccessPolicyWatcher
 	autoConfigure *AutoConfigure
 	started       bool
 	filter        NamespaceFilter
@@ -95,3 +114,53 @@ func (s *GrantsEnabled) tlsCredentialsUpdated(key string, secret *corev1.Secret)
 	log.Print("Grant server tls credentials updated")
 	return nil
 }
+
+func (s *GrantsEnabled) accessPolicyUpdated(key string, policy *skupperv2alpha1.AccessPolicy) error {
+	// policies are looked up from the watcher's cache when a grant is
+	// redeemed, so there is nothing to do here
+	return nil
+}
+
+func (s *GrantsEnabled) checkLinkOrigin(namespace string, siteName string, siteLabels map[string]string) error {
+	policies := site.AccessPolicies{}
+	for _, policy := range s.policyWatcher.List() {
+		if policy.Namespace == namespace {
+			policies[policy.Name] = policy
+		}
+	}
+	if err := policies.CheckLinkOrigin(siteName, siteLabels); err != nil {
+		return err
+	}
+	if max := policies.MaxLinks(); max > 0 {
+		linked, err := s.sitesLinkedIn(namespace)
+		if err != nil {
+			return err
+		}
+		if len(linked) >= max && !slices.Contains(linked, siteName) {
+			return fmt.Errorf("Limit of %d links set by AccessPolicy has been reached", max)
+		}
+	}
+	return nil
+}
+
+// sitesLinkedIn returns the names of the sites that have linked in to
+// the site in the namespace.
+func (s *GrantsEnabled) sitesLinkedIn(namespace string) ([]string, error) {
+	sites, err := s.clients.GetSkupperClient().SkupperV2alpha1().Sites(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, site := range sites.Items {
+		if site.IsConfigured() {
+			return network.GetSitesLinkingTo(site.GetSiteId(), site.Status.Network), nil
+		}
+	}
+	return nil, nil
+}
+
+// recordLinkOrigin records the recipient of the grant, so that the
+// links established with the credentials issued to it can be checked
+// against the access policies in the namespace.
+func (s *GrantsEnabled) recordLinkOrigin(grant *skupperv2alpha1.AccessGrant, subject string) error {
+	return RecordLinkOrigin(s.clients.GetKubeClient(), grant.Namespace, subject, *grant.Spec.Recipient)
+}
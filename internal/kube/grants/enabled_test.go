@@ -1,9 +1,17 @@
 package grants
 
 import (
+	"context"
 	"testing"
 
+	"gotest.tools/v3/assert"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/internal/kube/watchers"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
 )
 
 func Test_tlsCredentialsUpdated(t *testing.T) {
@@ -45,3 +53,102 @@ func Test_tlsCredentialsUpdated(t *testing.T) {
 		})
 	}
 }
+
+func Test_checkLinkOrigin(t *testing.T) {
+	policies := []runtime.Object{
+		&v2alpha1.AccessPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "origins",
+				Namespace: "test",
+			},
+			Spec: v2alpha1.AccessPolicySpec{
+				LinkOrigins: v2alpha1.LinkOrigins{
+					SiteNames: []string{"east"},
+				},
+			},
+		},
+	}
+	client, err := fake.NewFakeClient("test", nil, policies, "")
+	assert.Assert(t, err)
+	controller := watchers.NewEventProcessor("Controller", client)
+	gc := enabled(controller, "test", metav1.NamespaceAll, &GrantConfig{Enabled: true}, nil, nil)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	controller.StartWatchers(stopCh)
+	assert.Assert(t, controller.WaitForCacheSync(stopCh))
+
+	assert.Assert(t, gc.checkLinkOrigin("test", "east", nil))
+	assert.Error(t, gc.checkLinkOrigin("test", "west", nil), `Links from site "west" are not allowed by AccessPolicy origins`)
+	assert.Assert(t, gc.checkLinkOrigin("other", "west", nil))
+}
+
+func Test_checkLinkOriginMaxLinks(t *testing.T) {
+	site := &v2alpha1.Site{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "hub",
+			Namespace: "test",
+			UID:       "hub-uid",
+		},
+		Status: v2alpha1.SiteStatus{
+			Network: []v2alpha1.SiteRecord{
+				{Id: "hub-uid", Name: "hub"},
+				{Id: "east-uid", Name: "east", Links: []v2alpha1.LinkRecord{{Name: "hub", RemoteSiteId: "hub-uid", Operational: true}}},
+			},
+		},
+	}
+	site.SetConfigured(nil)
+	objects := []runtime.Object{
+		site,
+		&v2alpha1.AccessPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "limit",
+				Namespace: "test",
+			},
+			Spec: v2alpha1.AccessPolicySpec{
+				MaxLinks: 1,
+			},
+		},
+	}
+	client, err := fake.NewFakeClient("test", nil, objects, "")
+	assert.Assert(t, err)
+	controller := watchers.NewEventProcessor("Controller", client)
+	gc := enabled(controller, "test", metav1.NamespaceAll, &GrantConfig{Enabled: true}, nil, nil)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	controller.StartWatchers(stopCh)
+	assert.Assert(t, controller.WaitForCacheSync(stopCh))
+
+	// east has linked in already, so may redeem again, but no one else
+	assert.Assert(t, gc.checkLinkOrigin("test", "east", nil))
+	assert.Error(t, gc.checkLinkOrigin("test", "west", nil), "Limit of 1 links set by AccessPolicy has been reached")
+}
+
+func Test_recordLinkOrigin(t *testing.T) {
+	client, err := fake.NewFakeClient("test", nil, nil, "")
+	assert.Assert(t, err)
+	gc := &GrantsEnabled{
+		clients: client,
+	}
+	grant := &v2alpha1.AccessGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "for-east",
+			Namespace: "test",
+		},
+		Spec: v2alpha1.AccessGrantSpec{
+			Recipient: &v2alpha1.GrantRecipient{
+				SiteName:   "east",
+				SiteLabels: map[string]string{"tenant": "a"},
+			},
+		},
+	}
+	assert.Assert(t, gc.recordLinkOrigin(grant, "east"))
+	grant.Spec.Recipient = &v2alpha1.GrantRecipient{SiteName: "west"}
+	assert.Assert(t, gc.recordLinkOrigin(grant, "west"))
+
+	cm, err := client.GetKubeClient().CoreV1().ConfigMaps("test").Get(context.TODO(), LinkOriginsConfigMap, metav1.GetOptions{})
+	assert.Assert(t, err)
+	assert.DeepEqual(t, GetLinkOrigins(cm), map[string]v2alpha1.GrantRecipient{
+		"east": {SiteName: "east", SiteLabels: map[string]string{"tenant": "a"}},
+		"west": {SiteName: "west"},
+	})
+}
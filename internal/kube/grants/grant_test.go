@@ -7,10 +7,13 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"sync"
 	"testing"
 	"time"
 
 	"gotest.tools/v3/assert"
+	"gotest.tools/v3/poll"
 	meta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -30,6 +33,13 @@ func dummyGeneratorWithError(namespace string, name string, subject string, writ
 	return errors.New("Failed")
 }
 
+func allowTenantA(namespace string, siteName string, siteLabels map[string]string) error {
+	if namespace != "test" || siteLabels["tenant"] != "a" {
+		return errors.New("Links from site not allowed")
+	}
+	return nil
+}
+
 func TestGrantRegistryGeneral(t *testing.T) {
 	grants := []*v2alpha1.AccessGrant{
 		&v2alpha1.AccessGrant{
@@ -203,12 +213,51 @@ func Test_ServeHttp(t *testing.T) {
 		},
 	}
 
+	forTenantA := &v2alpha1.AccessGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "for-tenant-a",
+			Namespace: "test",
+			UID:       "5d0b2a3e-0c43-4bd4-9a5c-5b7f0e2f6c11",
+		},
+		Spec: v2alpha1.AccessGrantSpec{
+			RedemptionsAllowed: 10,
+			Recipient: &v2alpha1.GrantRecipient{
+				SiteName:   "east",
+				SiteLabels: map[string]string{"tenant": "a", "zone": "1"},
+			},
+		},
+		Status: v2alpha1.AccessGrantStatus{
+			Code:           "supersecret",
+			ExpirationTime: time.Date(2124, time.January, 0, 0, 0, 0, 0, time.UTC).Format(time.RFC3339),
+		},
+	}
+	forTenantB := &v2alpha1.AccessGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "for-tenant-b",
+			Namespace: "test",
+			UID:       "e2b4f0a7-5d7e-4c8e-8f7b-1f3b8c6d2a90",
+		},
+		Spec: v2alpha1.AccessGrantSpec{
+			RedemptionsAllowed: 10,
+			Recipient: &v2alpha1.GrantRecipient{
+				SiteName:   "west",
+				SiteLabels: map[string]string{"tenant": "b"},
+			},
+		},
+		Status: v2alpha1.AccessGrantStatus{
+			Code:           "supersecret",
+			ExpirationTime: time.Date(2124, time.January, 0, 0, 0, 0, 0, time.UTC).Format(time.RFC3339),
+		},
+	}
+
 	skupperObjects := []runtime.Object{
 		good,
 		expired,
 		used,
 		badExpiration,
 		deleted,
+		forTenantA,
+		forTenantB,
 	}
 
 	var tests = []struct {
@@ -218,6 +267,9 @@ func Test_ServeHttp(t *testing.T) {
 		body         io.Reader
 		expectedCode int
 		generator    GrantResponse
+		policy       RedemptionPolicy
+		headers      map[string]string
+		expectedBody string
 	}{
 		{
 			name:         "bad method",
@@ -268,6 +320,53 @@ func Test_ServeHttp(t *testing.T) {
 			expectedCode: http.StatusInternalServerError,
 			generator:    dummyGeneratorWithError,
 		},
+		{
+			name:         "credentials issued to recipient",
+			method:       http.MethodPost,
+			path:         "/" + string(forTenantA.ObjectMeta.UID),
+			body:         bytes.NewBufferString(forTenantA.Status.Code),
+			expectedCode: http.StatusOK,
+			headers: map[string]string{
+				"subject": "someone-else",
+			},
+			expectedBody: "test,for-tenant-a,east",
+		},
+		{
+			name:         "allowed by policy",
+			method:       http.MethodPost,
+			path:         "/" + string(forTenantA.ObjectMeta.UID),
+			body:         bytes.NewBufferString(forTenantA.Status.Code),
+			expectedCode: http.StatusOK,
+			policy:       allowTenantA,
+		},
+		{
+			name:         "refused by policy",
+			method:       http.MethodPost,
+			path:         "/" + string(forTenantB.ObjectMeta.UID),
+			body:         bytes.NewBufferString(forTenantB.Status.Code),
+			expectedCode: http.StatusForbidden,
+			policy:       allowTenantA,
+		},
+		{
+			name:         "refused by policy whatever the site claims",
+			method:       http.MethodPost,
+			path:         "/" + string(forTenantB.ObjectMeta.UID),
+			body:         bytes.NewBufferString(forTenantB.Status.Code),
+			expectedCode: http.StatusForbidden,
+			policy:       allowTenantA,
+			headers: map[string]string{
+				"site-name":   "east",
+				"site-labels": "tenant=a,zone=1",
+			},
+		},
+		{
+			name:         "refused by policy without recipient",
+			method:       http.MethodPost,
+			path:         "/" + string(good.ObjectMeta.UID),
+			body:         bytes.NewBufferString(good.Status.Code),
+			expectedCode: http.StatusForbidden,
+			policy:       allowTenantA,
+		},
 		{
 			name:         "bad body",
 			method:       http.MethodPost,
@@ -301,7 +400,10 @@ func Test_ServeHttp(t *testing.T) {
 				generator = dummyGenerator
 			}
 			registry := newGrants(client, generator, "https", "")
-			for _, grant := range []*v2alpha1.AccessGrant{good, expired, used, badExpiration, deleted} {
+			if tt.policy != nil {
+				registry.SetRedemptionPolicy(tt.policy)
+			}
+			for _, grant := range []*v2alpha1.AccessGrant{good, expired, used, badExpiration, deleted, forTenantA, forTenantB} {
 				err = registry.checkGrant(grant.Namespace+"/"+grant.Name, grant)
 				if err != nil {
 					t.Error(err)
@@ -312,9 +414,15 @@ func Test_ServeHttp(t *testing.T) {
 				t.Error(err)
 			}
 			req := httptest.NewRequest(tt.method, tt.path, tt.body)
+			for key, value := range tt.headers {
+				req.Header.Add(key, value)
+			}
 			res := httptest.NewRecorder()
 			registry.ServeHTTP(res, req)
 			assert.Equal(t, res.Code, tt.expectedCode)
+			if tt.expectedBody != "" {
+				assert.Equal(t, res.Body.String(), tt.expectedBody)
+			}
 		})
 	}
 
@@ -517,3 +625,125 @@ func Test_checkGrant(t *testing.T) {
 		})
 	}
 }
+
+func Test_ServeHttpLockout(t *testing.T) {
+	grant := &v2alpha1.AccessGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-grant",
+			Namespace: "test",
+			UID:       "0bde3bc8-a4a2-404a-bfbe-44fdf7bf3231",
+		},
+		Spec: v2alpha1.AccessGrantSpec{
+			RedemptionsAllowed: 1,
+		},
+		Status: v2alpha1.AccessGrantStatus{
+			Code:           "supersecret",
+			ExpirationTime: time.Date(2124, time.January, 0, 0, 0, 0, 0, time.UTC).Format(time.RFC3339),
+		},
+	}
+	client, err := fake.NewFakeClient("test", nil, []runtime.Object{grant}, "")
+	assert.Assert(t, err)
+	registry := newGrants(client, dummyGenerator, "https", "")
+	limiter, clock := newTestRateLimiter(RateLimits{Attempts: 10, Window: time.Minute, MaxFailures: 3, Lockout: 5 * time.Minute})
+	registry.SetRateLimiter(limiter)
+	var outcomes []string
+	registry.AddRedemptionAuditor(func(grant *v2alpha1.AccessGrant, attempt RedemptionAttempt) {
+		assert.Equal(t, attempt.Key, "0bde3bc8-a4a2-404a-bfbe-44fdf7bf3231")
+		assert.Equal(t, attempt.Grant, "my-grant")
+		assert.Equal(t, attempt.Subject, "west")
+		outcomes = append(outcomes, attempt.Outcome)
+	})
+	assert.Assert(t, registry.checkGrant("test/my-grant", grant))
+
+	redeem := func(address string, code string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/0bde3bc8-a4a2-404a-bfbe-44fdf7bf3231", bytes.NewBufferString(code))
+		req.RemoteAddr = address + ":40000"
+		req.Header.Add("subject", "west")
+		res := httptest.NewRecorder()
+		registry.ServeHTTP(res, req)
+		return res
+	}
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, redeem("10.0.0.1", "guess").Code, http.StatusForbidden)
+	}
+	res := redeem("10.0.0.1", "supersecret")
+	assert.Equal(t, res.Code, http.StatusTooManyRequests)
+	assert.Equal(t, res.Header().Get("Retry-After"), "300")
+	// the grant itself is not locked out, so invalid codes cannot be
+	// used to prevent its redemption
+	clock.advance(time.Minute)
+	res = redeem("10.0.0.2", "supersecret")
+	assert.Equal(t, res.Code, http.StatusOK)
+
+	current, err := client.GetSkupperClient().SkupperV2alpha1().AccessGrants("test").Get(context.TODO(), "my-grant", metav1.GetOptions{})
+	assert.Assert(t, err)
+	assert.Equal(t, current.Status.Redemptions, 1)
+	assert.Equal(t, current.Status.FailedRedemptions, 3)
+	assert.Equal(t, len(current.Status.RecentAttempts), 4)
+	assert.DeepEqual(t, current.Status.RecentAttempts[0], v2alpha1.AccessGrantRedemptionAttempt{
+		Time:          current.Status.RecentAttempts[0].Time,
+		RemoteAddress: "10.0.0.1",
+		Subject:       "west",
+		Outcome:       "invalid_code",
+	})
+	assert.Equal(t, current.Status.RecentAttempts[3].Outcome, "redeemed")
+	assert.Equal(t, current.Status.RecentAttempts[3].RemoteAddress, "10.0.0.2")
+
+	assert.DeepEqual(t, outcomes, []string{"invalid_code", "invalid_code", "invalid_code", "rate_limited", "redeemed"})
+}
+
+func Test_ServeHttpRefusalStatusRate(t *testing.T) {
+	grant := &v2alpha1.AccessGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-grant",
+			Namespace: "test",
+			UID:       "0bde3bc8-a4a2-404a-bfbe-44fdf7bf3231",
+		},
+		Spec: v2alpha1.AccessGrantSpec{
+			RedemptionsAllowed: 1,
+		},
+		Status: v2alpha1.AccessGrantStatus{
+			Code:           "supersecret",
+			ExpirationTime: time.Date(2124, time.January, 0, 0, 0, 0, 0, time.UTC).Format(time.RFC3339),
+		},
+	}
+	var (
+		mu      sync.Mutex
+		written []v2alpha1.AccessGrantStatus
+	)
+	updater := func(grant *v2alpha1.AccessGrant) (*v2alpha1.AccessGrant, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		written = append(written, *grant.Status.DeepCopy())
+		return grant, nil
+	}
+	writes := func() []v2alpha1.AccessGrantStatus {
+		mu.Lock()
+		defer mu.Unlock()
+		return slices.Clone(written)
+	}
+	registry := NewGrants(updater, dummyGenerator, "https", "")
+	registry.SetRateLimiter(nil)
+	registry.refusalInterval = 100 * time.Millisecond
+	assert.Assert(t, registry.checkGrant("test/my-grant", grant))
+	initial := len(writes())
+
+	for i := 0; i < 5; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/0bde3bc8-a4a2-404a-bfbe-44fdf7bf3231", bytes.NewBufferString("guess"))
+		res := httptest.NewRecorder()
+		registry.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusForbidden)
+	}
+	// the first refusal is written at once, the others together later
+	assert.Equal(t, len(writes()), initial+1)
+	assert.Equal(t, writes()[initial].FailedRedemptions, 1)
+	poll.WaitOn(t, func(poll.LogT) poll.Result {
+		if len(writes()) < initial+2 {
+			return poll.Continue("refusals not written yet")
+		}
+		return poll.Success()
+	}, poll.WithTimeout(5*time.Second), poll.WithDelay(10*time.Millisecond))
+	assert.Equal(t, len(writes()), initial+2)
+	assert.Equal(t, writes()[initial+1].FailedRedemptions, 5)
+	assert.Equal(t, len(writes()[initial+1].RecentAttempts), 5)
+}
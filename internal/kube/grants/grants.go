@@ -2,10 +2,12 @@ package grants
 
 import (
 	"context"
+	"crypto/subtle"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -14,31 +16,137 @@ import (
 	kubetypes "k8s.io/apimachinery/pkg/types"
 
 	internalclient "github.com/skupperproject/skupper/internal/kube/client"
+	"github.com/skupperproject/skupper/internal/kube/metrics"
 	"github.com/skupperproject/skupper/internal/utils"
 	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
 )
 
+// refusalStatusInterval is the minimum time between two writes of the
+// status of an AccessGrant recording refused redemptions.
+const refusalStatusInterval = 10 * time.Second
+
 type GrantResponse func(namespace string, name string, subject string, writer io.Writer) error
 
+// GrantStatusUpdater persists the status of an AccessGrant, returning
+// the updated AccessGrant.
+type GrantStatusUpdater func(grant *skupperv2alpha1.AccessGrant) (*skupperv2alpha1.AccessGrant, error)
+
+// RedemptionPolicy returns an error if the site with the given name
+// and labels may not link to the sites in the namespace. The name and
+// labels are those of the recipient of the AccessGrant being redeemed,
+// never what the redeeming site claims to be.
+type RedemptionPolicy func(namespace string, siteName string, siteLabels map[string]string) error
+
+// CredentialRecorder records the subject of the link credentials about
+// to be issued on redemption of an AccessGrant with a recipient.
+type CredentialRecorder func(grant *skupperv2alpha1.AccessGrant, subject string) error
+
 type Grants struct {
-	clients    internalclient.Clients
+	updater    GrantStatusUpdater
 	generator  GrantResponse
+	policy     RedemptionPolicy
+	recorder   CredentialRecorder
+	limiter    *RateLimiter
+	auditors   []RedemptionAuditor
 	url        string
 	ca         string
 	scheme     string
 	grants     map[kubetypes.UID]*skupperv2alpha1.AccessGrant
 	grantIndex map[string]kubetypes.UID
 	lock       sync.Mutex
+
+	// refused redemptions are recorded in the status of the grant in
+	// memory and written at most once per refusalInterval
+	refusalInterval time.Duration
+	refusalsWritten map[string]time.Time
+	refusalsPending map[string]bool
 }
 
 func newGrants(clients internalclient.Clients, generator GrantResponse, scheme string, url string) *Grants {
+	updater := func(grant *skupperv2alpha1.AccessGrant) (*skupperv2alpha1.AccessGrant, error) {
+		return clients.GetSkupperClient().SkupperV2alpha1().AccessGrants(grant.ObjectMeta.Namespace).UpdateStatus(context.TODO(), grant, metav1.UpdateOptions{})
+	}
+	return NewGrants(updater, generator, scheme, url)
+}
+
+// NewGrants returns a Grants instance that stores the status of the
+// AccessGrants it processes through the given updater, allowing the
+// grant protocol to be served for AccessGrants that are not kept in
+// the kubernetes API.
+func NewGrants(updater GrantStatusUpdater, generator GrantResponse, scheme string, url string) *Grants {
 	return &Grants{
-		clients:    clients,
+		updater:    updater,
 		generator:  generator,
 		scheme:     scheme,
 		url:        url,
+		limiter:    NewRateLimiter(DefaultRateLimits),
 		grants:     map[kubetypes.UID]*skupperv2alpha1.AccessGrant{},
 		grantIndex: map[string]kubetypes.UID{},
+
+		refusalInterval: refusalStatusInterval,
+		refusalsWritten: map[string]time.Time{},
+		refusalsPending: map[string]bool{},
+	}
+}
+
+// SetRedemptionPolicy sets the policy used to refuse redemption of
+// AccessGrants by sites that may not link in.
+func (g *Grants) SetRedemptionPolicy(policy RedemptionPolicy) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.policy = policy
+}
+
+func (g *Grants) getRedemptionPolicy() RedemptionPolicy {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	return g.policy
+}
+
+// SetCredentialRecorder sets the recorder to which the subject of the
+// link credentials issued to the recipient of an AccessGrant is
+// reported before they are issued.
+func (g *Grants) SetCredentialRecorder(recorder CredentialRecorder) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.recorder = recorder
+}
+
+func (g *Grants) getCredentialRecorder() CredentialRecorder {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	return g.recorder
+}
+
+// SetRateLimiter sets the limiter used to refuse redemption attempts
+// that are too frequent or follow repeated failures. A nil limiter
+// disables rate limiting.
+func (g *Grants) SetRateLimiter(limiter *RateLimiter) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.limiter = limiter
+}
+
+func (g *Grants) getRateLimiter() *RateLimiter {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	return g.limiter
+}
+
+// AddRedemptionAuditor adds an auditor to which every redemption
+// attempt is reported.
+func (g *Grants) AddRedemptionAuditor(auditor RedemptionAuditor) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.auditors = append(g.auditors, auditor)
+}
+
+func (g *Grants) audit(grant *skupperv2alpha1.AccessGrant, attempt RedemptionAttempt) {
+	g.lock.Lock()
+	auditors := g.auditors
+	g.lock.Unlock()
+	for _, auditor := range auditors {
+		auditor(grant, attempt)
 	}
 }
 
@@ -52,6 +160,14 @@ func (g *Grants) setCA(ca string) bool {
 	return true
 }
 
+// SetCA sets the CA used to verify the grant server and updates the
+// status of all known AccessGrants if it has changed.
+func (g *Grants) SetCA(ca string) {
+	if g.setCA(ca) {
+		g.recheckCa()
+	}
+}
+
 func (g *Grants) getCA() string {
 	g.lock.Lock()
 	defer g.lock.Unlock()
@@ -74,6 +190,7 @@ func (g *Grants) remove(key string) {
 	if uid, ok := g.grantIndex[key]; ok {
 		delete(g.grantIndex, key)
 		delete(g.grants, uid)
+		delete(g.refusalsWritten, string(uid))
 	}
 }
 
@@ -114,6 +231,14 @@ func (g *Grants) setUrl(url string) bool {
 	return true
 }
 
+// SetUrl sets the url through which the grant server can be reached
+// and updates the status of all known AccessGrants if it has changed.
+func (g *Grants) SetUrl(url string) {
+	if g.setUrl(url) {
+		g.recheckUrl()
+	}
+}
+
 func (g *Grants) getUrl() string {
 	g.lock.Lock()
 	defer g.lock.Unlock()
@@ -170,6 +295,12 @@ func (g *Grants) checkCa(key string, grant *skupperv2alpha1.AccessGrant) bool {
 	return true
 }
 
+// CheckGrant records the AccessGrant identified by key, updating its
+// status if needed. A nil grant removes any previously recorded one.
+func (g *Grants) CheckGrant(key string, grant *skupperv2alpha1.AccessGrant) error {
+	return g.checkGrant(key, grant)
+}
+
 func (g *Grants) checkGrant(key string, grant *skupperv2alpha1.AccessGrant) error {
 	if grant == nil {
 		g.remove(key)
@@ -229,7 +360,7 @@ func (g *Grants) checkGrant(key string, grant *skupperv2alpha1.AccessGrant) erro
 }
 
 func (g *Grants) updateGrantStatus(grant *skupperv2alpha1.AccessGrant) error {
-	updated, err := g.clients.GetSkupperClient().SkupperV2alpha1().AccessGrants(grant.ObjectMeta.Namespace).UpdateStatus(context.TODO(), grant, metav1.UpdateOptions{})
+	updated, err := g.updater(grant)
 	if err != nil {
 		return err
 	}
@@ -237,55 +368,138 @@ func (g *Grants) updateGrantStatus(grant *skupperv2alpha1.AccessGrant) error {
 	return nil
 }
 
-func (g *Grants) checkAndUpdateAccessToken(key string, data []byte) (*skupperv2alpha1.AccessGrant, *HttpError) {
+func (g *Grants) checkAndUpdateAccessToken(key string, data []byte, requester redemptionRequester) (*skupperv2alpha1.AccessGrant, *HttpError) {
 	log.Printf("Checking access token for %s", key)
 	grant := g.get(key)
 	if grant == nil {
-		return nil, httpError("No such claim", http.StatusNotFound)
+		g.getRateLimiter().failed(addressKey(requester.address))
+		return nil, httpError(metrics.RedemptionNotFound, "No such claim", http.StatusNotFound)
 	}
 
 	expiration, err := time.Parse(time.RFC3339, grant.Status.ExpirationTime)
 	if err != nil {
 		log.Printf("Cannot determine expiration for %s/%s: %s", grant.Namespace, grant.Name, err)
-		return nil, httpError("Corrupted claim", http.StatusInternalServerError)
+		return grant, httpError(metrics.RedemptionInternal, "Corrupted claim", http.StatusInternalServerError)
 	}
 	if expiration.Before(time.Now()) {
 		log.Printf("AccessGrant %s/%s expired", grant.Namespace, grant.Name)
-		return nil, httpError("No such claim", http.StatusNotFound)
+		return g.refuse(key, grant, requester, httpError(metrics.RedemptionExpired, "No such claim", http.StatusNotFound), addressKey(requester.address))
 	}
 	if grant.Spec.RedemptionsAllowed <= grant.Status.Redemptions {
 		log.Printf("AccessGrant %s/%s already redeemed", grant.Namespace, grant.Name)
-		return nil, httpError("No such access granted", http.StatusNotFound)
-	}
-	if grant.Status.Code != string(data) {
-		return nil, httpError("Redemption of access token refused", http.StatusForbidden)
+		return g.refuse(key, grant, requester, httpError(metrics.RedemptionExhausted, "No such access granted", http.StatusNotFound), addressKey(requester.address))
+	}
+	if subtle.ConstantTimeCompare([]byte(grant.Status.Code), data) != 1 {
+		log.Printf("Invalid code presented for AccessGrant %s/%s from %s", grant.Namespace, grant.Name, requester.address)
+		return g.refuse(key, grant, requester, httpError(metrics.RedemptionInvalidCode, "Redemption of access token refused", http.StatusForbidden), addressKey(requester.address))
+	}
+	if policy := g.getRedemptionPolicy(); policy != nil {
+		var siteName string
+		var siteLabels map[string]string
+		if recipient := grant.Spec.Recipient; recipient != nil {
+			siteName = recipient.SiteName
+			siteLabels = recipient.SiteLabels
+		}
+		if err := policy(grant.Namespace, siteName, siteLabels); err != nil {
+			log.Printf("Redemption of AccessGrant %s/%s refused: %s", grant.Namespace, grant.Name, err)
+			return g.refuse(key, grant, requester, httpError(metrics.RedemptionPolicy, err.Error(), http.StatusForbidden))
+		}
 	}
+	g.getRateLimiter().succeeded(addressKey(requester.address))
 	grant.Status.Redemptions += 1
+	grant.RecordRedemptionAttempt(requester.attempt(grant, key, RedemptionRedeemed).summary(), false)
 	err = g.updateGrantStatus(grant)
 	if err != nil {
 		log.Printf("Error updating access grant %s/%s: %s", grant.Namespace, grant.Name, err)
-		return nil, httpError("Internal error", http.StatusServiceUnavailable)
+		return grant, httpError(metrics.RedemptionInternal, "Internal error", http.StatusServiceUnavailable)
 	}
 	return grant, nil
 }
 
+// refuse records a refused attempt to redeem the grant in its status,
+// counting it as a failure against the given rate limiting keys.
+func (g *Grants) refuse(key string, grant *skupperv2alpha1.AccessGrant, requester redemptionRequester, e *HttpError, keys ...string) (*skupperv2alpha1.AccessGrant, *HttpError) {
+	if len(keys) > 0 && g.getRateLimiter().failed(keys...) {
+		log.Printf("Refusing further redemptions from %s after repeated failures", requester.address)
+	}
+	grant.RecordRedemptionAttempt(requester.attempt(grant, key, e.reason).summary(), true)
+	g.writeRefusals(key, grant)
+	return grant, e
+}
+
+// writeRefusals writes the status of the grant recording refused
+// redemptions, unless it was written less than refusalInterval ago, in
+// which case the write is deferred. Refused attempts cannot therefore
+// cause more than one write per interval.
+func (g *Grants) writeRefusals(key string, grant *skupperv2alpha1.AccessGrant) {
+	g.lock.Lock()
+	if g.refusalsPending[key] {
+		g.lock.Unlock()
+		return
+	}
+	if wait := time.Until(g.refusalsWritten[key].Add(g.refusalInterval)); wait > 0 {
+		g.refusalsPending[key] = true
+		g.lock.Unlock()
+		time.AfterFunc(wait, func() {
+			g.writePendingRefusals(key)
+		})
+		return
+	}
+	g.refusalsWritten[key] = time.Now()
+	g.lock.Unlock()
+	if err := g.updateGrantStatus(grant); err != nil {
+		log.Printf("Error recording refused redemption of AccessGrant %s/%s: %s", grant.Namespace, grant.Name, err)
+	}
+}
+
+func (g *Grants) writePendingRefusals(key string) {
+	g.lock.Lock()
+	delete(g.refusalsPending, key)
+	g.refusalsWritten[key] = time.Now()
+	g.lock.Unlock()
+	grant := g.get(key)
+	if grant == nil {
+		return
+	}
+	if err := g.updateGrantStatus(grant); err != nil {
+		log.Printf("Error recording refused redemptions of AccessGrant %s/%s: %s", grant.Namespace, grant.Name, err)
+	}
+}
+
 func (g *Grants) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := strings.Join(strings.Split(r.URL.Path, "/"), "")
+	requester := getRedemptionRequester(r)
 	if r.Method != http.MethodPost {
 		log.Printf("Bad method %s for path %s", r.Method, r.URL.Path)
 		http.Error(w, "Only POST is supported", http.StatusMethodNotAllowed)
+		metrics.GrantRedemptionFailed(metrics.RedemptionBadRequest)
+		g.audit(nil, requester.attempt(nil, key, metrics.RedemptionBadRequest))
+		return
+	}
+	if retryAfter, ok := g.getRateLimiter().allow(addressKey(requester.address)); !ok {
+		log.Printf("Too many redemption attempts for %s from %s", key, requester.address)
+		e := httpError(metrics.RedemptionRateLimited, "Too many redemption attempts", http.StatusTooManyRequests)
+		e.retryAfter = retryAfter
+		e.write(w)
+		metrics.GrantRedemptionFailed(e.reason)
+		grant := g.get(key)
+		g.audit(grant, requester.attempt(grant, key, e.reason))
 		return
 	}
-	key := strings.Join(strings.Split(r.URL.Path, "/"), "")
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
 		log.Printf("Error reading body for path %s: %s", r.URL.Path, err.Error())
 		http.Error(w, "Request body not valid", http.StatusBadRequest)
+		metrics.GrantRedemptionFailed(metrics.RedemptionBadRequest)
+		g.audit(nil, requester.attempt(nil, key, metrics.RedemptionBadRequest))
 		return
 	}
 
-	grant, e := g.checkAndUpdateAccessToken(key, body)
+	grant, e := g.checkAndUpdateAccessToken(key, body, requester)
 	if e != nil {
 		e.write(w)
+		metrics.GrantRedemptionFailed(e.reason)
+		g.audit(grant, requester.attempt(grant, key, e.reason))
 		return
 	}
 
@@ -298,26 +512,92 @@ func (g *Grants) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if subject == "" {
 		subject = name
 	}
+	if recipient := grant.Spec.Recipient; recipient != nil && recipient.SiteName != "" {
+		// the credentials identify the recipient of the grant, so
+		// that its links can be checked against the access policies
+		subject = recipient.SiteName
+		if recorder := g.getCredentialRecorder(); recorder != nil {
+			if err := recorder(grant, subject); err != nil {
+				log.Printf("Failed to record link credentials for %s/%s: %s", grant.Namespace, grant.Name, err.Error())
+				http.Error(w, "Internal error", http.StatusInternalServerError)
+				metrics.GrantRedemptionFailed(metrics.RedemptionTokenFailure)
+				g.audit(grant, requester.attempt(grant, key, metrics.RedemptionTokenFailure))
+				return
+			}
+		}
+	}
 	if err := g.generator(grant.Namespace, name, subject, w); err != nil {
 		log.Printf("Failed to create token for %s/%s: %s", grant.Namespace, grant.Name, err.Error())
 		http.Error(w, err.Error(), http.StatusInternalServerError)
+		metrics.GrantRedemptionFailed(metrics.RedemptionTokenFailure)
+		g.audit(grant, requester.attempt(grant, key, metrics.RedemptionTokenFailure))
 		return
 	}
 	log.Printf("Redemption of access token %s/%s succeeded", grant.Namespace, grant.Name)
+	metrics.GrantRedeemed()
+	g.audit(grant, requester.attempt(grant, key, RedemptionRedeemed))
+}
+
+type redemptionRequester struct {
+	address  string
+	subject  string
+	siteName string
+}
+
+// getRedemptionRequester describes the site redeeming an AccessGrant
+// from the headers of the request, for auditing only: they are set by
+// the client so cannot be trusted. Older sites do not send the
+// site-name header; those redeeming from a non-kubernetes site use the
+// site name as subject.
+func getRedemptionRequester(r *http.Request) redemptionRequester {
+	requester := redemptionRequester{
+		address:  remoteAddress(r),
+		subject:  r.Header.Get("subject"),
+		siteName: r.Header.Get("site-name"),
+	}
+	if requester.subject == "" {
+		requester.subject = r.Header.Get("name")
+	}
+	if requester.siteName == "" {
+		requester.siteName = r.Header.Get("subject")
+	}
+	return requester
+}
+
+func (r redemptionRequester) attempt(grant *skupperv2alpha1.AccessGrant, key string, outcome string) RedemptionAttempt {
+	attempt := RedemptionAttempt{
+		Time:          time.Now(),
+		Key:           key,
+		RemoteAddress: r.address,
+		Subject:       r.subject,
+		SiteName:      r.siteName,
+		Outcome:       outcome,
+	}
+	if grant != nil {
+		attempt.Namespace = grant.Namespace
+		attempt.Grant = grant.Name
+	}
+	return attempt
 }
 
 type HttpError struct {
-	text string
-	code int
+	reason     string
+	text       string
+	code       int
+	retryAfter time.Duration
 }
 
 func (e *HttpError) write(w http.ResponseWriter) {
+	if e.retryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int((e.retryAfter+time.Second-1)/time.Second)))
+	}
 	http.Error(w, e.text, e.code)
 }
 
-func httpError(text string, code int) *HttpError {
+func httpError(reason string, text string, code int) *HttpError {
 	return &HttpError{
-		text: text,
-		code: code,
+		reason: reason,
+		text:   text,
+		code:   code,
 	}
 }
@@ -0,0 +1,177 @@
+package grants
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RateLimits bound the rate at which AccessGrants can be redeemed, so
+// that their codes cannot be guessed by brute force.
+type RateLimits struct {
+	// Attempts is the number of redemption attempts allowed from a
+	// single remote address in each Window. Zero disables the limit.
+	Attempts int
+	Window   time.Duration
+	// MaxFailures is the number of consecutive failed attempts from a
+	// remote address after which further attempts from it are refused
+	// for the Lockout period. Zero disables lockout. Grants themselves
+	// are never locked out, as that would let anyone deny their
+	// redemption by presenting invalid codes.
+	MaxFailures int
+	Lockout     time.Duration
+}
+
+var DefaultRateLimits = RateLimits{
+	Attempts:    30,
+	Window:      time.Minute,
+	MaxFailures: 5,
+	Lockout:     15 * time.Minute,
+}
+
+// RateLimiter tracks redemption attempts by key, where a key identifies
+// the remote address an attempt is made from. It may be shared by
+// several Grants instances served through the same port.
+type RateLimiter struct {
+	limits  RateLimits
+	now     func() time.Time
+	entries map[string]*rateLimiterEntry
+	pruned  time.Time
+	lock    sync.Mutex
+}
+
+type rateLimiterEntry struct {
+	lastSeen    time.Time
+	windowStart time.Time
+	attempts    int
+	failures    int
+	lockedUntil time.Time
+}
+
+func NewRateLimiter(limits RateLimits) *RateLimiter {
+	return &RateLimiter{
+		limits:  limits,
+		now:     time.Now,
+		entries: map[string]*rateLimiterEntry{},
+	}
+}
+
+func addressKey(address string) string {
+	return "address/" + address
+}
+
+// allow records an attempt against each of the given keys, unless one
+// of them is locked out or has exhausted its attempts for the current
+// window, in which case the time after which to retry is returned.
+func (l *RateLimiter) allow(keys ...string) (time.Duration, bool) {
+	if l == nil {
+		return 0, true
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	now := l.now()
+	l.prune(now)
+	var retryAfter time.Duration
+	for _, key := range keys {
+		entry, ok := l.entries[key]
+		if !ok {
+			continue
+		}
+		if now.Before(entry.lockedUntil) {
+			retryAfter = max(retryAfter, entry.lockedUntil.Sub(now))
+		} else if l.limits.Attempts > 0 && now.Sub(entry.windowStart) < l.limits.Window && entry.attempts >= l.limits.Attempts {
+			retryAfter = max(retryAfter, entry.windowStart.Add(l.limits.Window).Sub(now))
+		}
+	}
+	if retryAfter > 0 {
+		return retryAfter, false
+	}
+	for _, key := range keys {
+		entry := l.entry(key)
+		entry.lastSeen = now
+		if now.Sub(entry.windowStart) >= l.limits.Window {
+			entry.windowStart = now
+			entry.attempts = 0
+		}
+		entry.attempts++
+	}
+	return 0, true
+}
+
+// failed records a failed attempt against each of the given keys,
+// returning true if any of them is now locked out.
+func (l *RateLimiter) failed(keys ...string) bool {
+	if l == nil || l.limits.MaxFailures <= 0 {
+		return false
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	now := l.now()
+	locked := false
+	for _, key := range keys {
+		entry := l.entry(key)
+		entry.lastSeen = now
+		entry.failures++
+		if entry.failures >= l.limits.MaxFailures {
+			entry.failures = 0
+			entry.lockedUntil = now.Add(l.limits.Lockout)
+			locked = true
+		}
+	}
+	return locked
+}
+
+// succeeded clears the failures recorded against the given keys.
+func (l *RateLimiter) succeeded(keys ...string) {
+	if l == nil {
+		return
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	for _, key := range keys {
+		if entry, ok := l.entries[key]; ok {
+			entry.failures = 0
+		}
+	}
+}
+
+func (l *RateLimiter) entry(key string) *rateLimiterEntry {
+	entry, ok := l.entries[key]
+	if !ok {
+		entry = &rateLimiterEntry{}
+		l.entries[key] = entry
+	}
+	return entry
+}
+
+// prune drops the entries that no longer affect any decision, at most
+// once per window, so that keys derived from arbitrary requests do not
+// accumulate. Failures are forgotten once no attempt has been made for
+// the lockout period.
+func (l *RateLimiter) prune(now time.Time) {
+	if now.Sub(l.pruned) < l.limits.Window {
+		return
+	}
+	l.pruned = now
+	for key, entry := range l.entries {
+		idle := now.Sub(entry.lastSeen)
+		if now.Before(entry.lockedUntil) || idle < l.limits.Window {
+			continue
+		}
+		if entry.failures == 0 || idle >= l.limits.Lockout {
+			delete(l.entries, key)
+		}
+	}
+}
+
+// remoteAddress returns the address from which a request was received.
+// Forwarding headers are deliberately ignored, as they are under the
+// control of the client.
+func remoteAddress(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
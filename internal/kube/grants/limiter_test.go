@@ -0,0 +1,105 @@
+package grants
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newTestRateLimiter(limits RateLimits) (*RateLimiter, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)}
+	limiter := NewRateLimiter(limits)
+	limiter.now = clock.Now
+	return limiter, clock
+}
+
+func TestRateLimiterAttempts(t *testing.T) {
+	limiter, clock := newTestRateLimiter(RateLimits{Attempts: 2, Window: time.Minute})
+	for i := 0; i < 2; i++ {
+		_, ok := limiter.allow(addressKey("10.0.0.1"))
+		assert.Assert(t, ok)
+	}
+	retryAfter, ok := limiter.allow(addressKey("10.0.0.1"))
+	assert.Assert(t, !ok)
+	assert.Equal(t, retryAfter, time.Minute)
+
+	// other addresses are not limited
+	clock.advance(10 * time.Second)
+	_, ok = limiter.allow(addressKey("10.0.0.2"))
+	assert.Assert(t, ok)
+
+	// refused attempts are not counted
+	retryAfter, ok = limiter.allow(addressKey("10.0.0.1"))
+	assert.Assert(t, !ok)
+	assert.Equal(t, retryAfter, 50*time.Second)
+
+	clock.advance(50 * time.Second)
+	_, ok = limiter.allow(addressKey("10.0.0.1"))
+	assert.Assert(t, ok)
+}
+
+func TestRateLimiterLockout(t *testing.T) {
+	limiter, clock := newTestRateLimiter(RateLimits{Window: time.Minute, MaxFailures: 3, Lockout: 10 * time.Minute})
+	assert.Assert(t, !limiter.failed(addressKey("10.0.0.1")))
+	assert.Assert(t, !limiter.failed(addressKey("10.0.0.1")))
+	// a success clears the failures
+	limiter.succeeded(addressKey("10.0.0.1"))
+	assert.Assert(t, !limiter.failed(addressKey("10.0.0.1")))
+	assert.Assert(t, !limiter.failed(addressKey("10.0.0.1")))
+	_, ok := limiter.allow(addressKey("10.0.0.1"))
+	assert.Assert(t, ok)
+	assert.Assert(t, limiter.failed(addressKey("10.0.0.1")))
+
+	clock.advance(time.Minute)
+	retryAfter, ok := limiter.allow(addressKey("10.0.0.1"))
+	assert.Assert(t, !ok)
+	assert.Equal(t, retryAfter, 9*time.Minute)
+	_, ok = limiter.allow(addressKey("10.0.0.2"))
+	assert.Assert(t, ok)
+
+	clock.advance(9 * time.Minute)
+	_, ok = limiter.allow(addressKey("10.0.0.1"))
+	assert.Assert(t, ok)
+}
+
+func TestRateLimiterPrune(t *testing.T) {
+	limiter, clock := newTestRateLimiter(RateLimits{Attempts: 5, Window: time.Minute, MaxFailures: 3, Lockout: 10 * time.Minute})
+	_, ok := limiter.allow(addressKey("10.0.0.1"))
+	assert.Assert(t, ok)
+	_, ok = limiter.allow(addressKey("10.0.0.2"))
+	assert.Assert(t, ok)
+	limiter.failed(addressKey("10.0.0.2"))
+	assert.Equal(t, len(limiter.entries), 2)
+
+	clock.advance(time.Minute)
+	_, ok = limiter.allow(addressKey("10.0.0.3"))
+	assert.Assert(t, ok)
+	assert.Equal(t, len(limiter.entries), 2)
+
+	// failures are forgotten after the lockout period
+	clock.advance(10 * time.Minute)
+	_, ok = limiter.allow(addressKey("10.0.0.3"))
+	assert.Assert(t, ok)
+	assert.Equal(t, len(limiter.entries), 1)
+}
+
+func TestNilRateLimiter(t *testing.T) {
+	var limiter *RateLimiter
+	_, ok := limiter.allow(addressKey("10.0.0.1"))
+	assert.Assert(t, ok)
+	assert.Assert(t, !limiter.failed(addressKey("10.0.0.1")))
+	limiter.succeeded(addressKey("10.0.0.1"))
+}
@@ -0,0 +1,78 @@
+package grants
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+)
+
+// LinkOriginsConfigMap records, for each subject link credentials
+// were issued for on redemption of an AccessGrant, the recipient of
+// the grant. It lets the sites using those credentials be identified
+// when they link in.
+const LinkOriginsConfigMap = "skupper-link-origins"
+
+// RecordLinkOrigin records that link credentials with the given
+// subject were issued to the recipient.
+func RecordLinkOrigin(client kubernetes.Interface, namespace string, subject string, recipient skupperv2alpha1.GrantRecipient) error {
+	data, err := json.Marshal(recipient)
+	if err != nil {
+		return err
+	}
+	configmaps := client.CoreV1().ConfigMaps(namespace)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := configmaps.Get(context.TODO(), LinkOriginsConfigMap, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			cm := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: LinkOriginsConfigMap,
+				},
+				Data: map[string]string{
+					subject: string(data),
+				},
+			}
+			_, err = configmaps.Create(context.TODO(), cm, metav1.CreateOptions{})
+			if k8serrors.IsAlreadyExists(err) {
+				return k8serrors.NewConflict(corev1.Resource("configmaps"), LinkOriginsConfigMap, err)
+			}
+			return err
+		} else if err != nil {
+			return err
+		}
+		if current.Data[subject] == string(data) {
+			return nil
+		}
+		if current.Data == nil {
+			current.Data = map[string]string{}
+		}
+		current.Data[subject] = string(data)
+		_, err = configmaps.Update(context.TODO(), current, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// GetLinkOrigins returns the recipients recorded in the ConfigMap,
+// keyed by the subject of the link credentials issued to them.
+func GetLinkOrigins(cm *corev1.ConfigMap) map[string]skupperv2alpha1.GrantRecipient {
+	origins := map[string]skupperv2alpha1.GrantRecipient{}
+	if cm == nil {
+		return origins
+	}
+	for subject, value := range cm.Data {
+		var recipient skupperv2alpha1.GrantRecipient
+		if err := json.Unmarshal([]byte(value), &recipient); err != nil {
+			log.Printf("Ignoring invalid link origin for %q in %s/%s: %s", subject, cm.Namespace, cm.Name, err)
+			continue
+		}
+		origins[subject] = recipient
+	}
+	return origins
+}
@@ -35,6 +35,28 @@ func newServer(addr string, tlsEnabled bool, handler http.Handler) *Server {
 	}
 }
 
+// NewServer returns a grant Server listening on addr that dispatches
+// requests to handler, using TLS if tlsEnabled is true.
+func NewServer(addr string, tlsEnabled bool, handler http.Handler) *Server {
+	return newServer(addr, tlsEnabled, handler)
+}
+
+// Start serves requests in the background.
+func (s *Server) Start() {
+	s.start()
+}
+
+// Stop closes the server along with any listener it is serving on.
+func (s *Server) Stop() error {
+	return s.server.Close()
+}
+
+// SetCertificate sets the certificate presented by the server when
+// TLS is enabled.
+func (s *Server) SetCertificate(cert *tls.Certificate) {
+	s.setCertificate(cert)
+}
+
 func (s *Server) start() {
 	go s.listenAndServe()
 }
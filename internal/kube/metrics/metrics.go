@@ -0,0 +1,206 @@
+// Package metrics holds the Prometheus metrics describing the work
+// done by the skupper controller: reconciliation of resources, the
+// state of its work queues, AccessGrant redemptions and the expiry of
+// the certificates it maintains.
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const namespace = "skupper_controller"
+
+// Reasons for which an AccessGrant redemption can fail.
+const (
+	RedemptionBadRequest   = "bad_request"
+	RedemptionNotFound     = "not_found"
+	RedemptionExpired      = "expired"
+	RedemptionExhausted    = "exhausted"
+	RedemptionInvalidCode  = "invalid_code"
+	RedemptionPolicy       = "policy"
+	RedemptionInternal     = "internal"
+	RedemptionTokenFailure = "token_generation"
+	RedemptionRateLimited  = "rate_limited"
+)
+
+var (
+	// Registry holds all the metrics exposed by the controller.
+	Registry = prometheus.NewRegistry()
+
+	reconcileTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reconcile_total",
+		Help:      "Number of resource change events handled, by resource kind.",
+	}, []string{"kind"})
+	reconcileErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reconcile_errors_total",
+		Help:      "Number of resource change events whose handling returned an error, by resource kind.",
+	}, []string{"kind"})
+	reconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "reconcile_duration_seconds",
+		Help:      "Time taken to handle resource change events, by resource kind.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 4, 8),
+	}, []string{"kind"})
+
+	grantRedemptions = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "grant_redemptions_total",
+		Help:      "Number of AccessGrants successfully redeemed.",
+	})
+	grantRedemptionFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "grant_redemption_failures_total",
+		Help:      "Number of refused or failed AccessGrant redemptions, by reason.",
+	}, []string{"reason"})
+
+	certificateExpiry = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "certificate_expiry_timestamp_seconds",
+		Help:      "Time at which the certificate held in a secret expires, in seconds since the epoch.",
+	}, []string{"namespace", "secret"})
+
+	queue = newQueueMetrics()
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		reconcileTotal,
+		reconcileErrors,
+		reconcileDuration,
+		grantRedemptions,
+		grantRedemptionFailures,
+		certificateExpiry,
+		queue.depth,
+		queue.adds,
+		queue.latency,
+		queue.workDuration,
+		queue.unfinishedWork,
+		queue.longestRunningProcessor,
+		queue.retries,
+	)
+	workqueue.SetProvider(queue)
+}
+
+// ReconcileObserved records the handling of a change to a resource of
+// the given kind.
+func ReconcileObserved(kind string, duration time.Duration, err error) {
+	reconcileTotal.WithLabelValues(kind).Inc()
+	reconcileDuration.WithLabelValues(kind).Observe(duration.Seconds())
+	if err != nil {
+		reconcileErrors.WithLabelValues(kind).Inc()
+	}
+}
+
+// GrantRedeemed records a successful AccessGrant redemption.
+func GrantRedeemed() {
+	grantRedemptions.Inc()
+}
+
+// GrantRedemptionFailed records an AccessGrant redemption that was
+// refused or failed for the given reason.
+func GrantRedemptionFailed(reason string) {
+	grantRedemptionFailures.WithLabelValues(reason).Inc()
+}
+
+// CertificateExpires records the expiry of the certificate held in
+// the named secret.
+func CertificateExpires(namespace string, secret string, notAfter time.Time) {
+	certificateExpiry.WithLabelValues(namespace, secret).Set(float64(notAfter.Unix()))
+}
+
+// CertificateRemoved stops reporting the expiry of the certificate
+// held in the named secret.
+func CertificateRemoved(namespace string, secret string) {
+	certificateExpiry.DeleteLabelValues(namespace, secret)
+}
+
+// queueMetrics implements workqueue.MetricsProvider, reporting the
+// state of every named work queue created by the controller.
+type queueMetrics struct {
+	depth                   *prometheus.GaugeVec
+	adds                    *prometheus.CounterVec
+	latency                 *prometheus.HistogramVec
+	workDuration            *prometheus.HistogramVec
+	unfinishedWork          *prometheus.GaugeVec
+	longestRunningProcessor *prometheus.GaugeVec
+	retries                 *prometheus.CounterVec
+}
+
+func newQueueMetrics() *queueMetrics {
+	buckets := prometheus.ExponentialBuckets(0.001, 4, 8)
+	return &queueMetrics{
+		depth: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "workqueue_depth",
+			Help:      "Number of events waiting in the work queue.",
+		}, []string{"name"}),
+		adds: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "workqueue_adds_total",
+			Help:      "Number of events added to the work queue.",
+		}, []string{"name"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "workqueue_queue_duration_seconds",
+			Help:      "Time events spend in the work queue before being handled.",
+			Buckets:   buckets,
+		}, []string{"name"}),
+		workDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "workqueue_work_duration_seconds",
+			Help:      "Time taken to handle events taken from the work queue.",
+			Buckets:   buckets,
+		}, []string{"name"}),
+		unfinishedWork: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "workqueue_unfinished_work_seconds",
+			Help:      "Time for which events currently being handled have been in progress.",
+		}, []string{"name"}),
+		longestRunningProcessor: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "workqueue_longest_running_processor_seconds",
+			Help:      "Time for which the longest running event handler has been in progress.",
+		}, []string{"name"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "workqueue_retries_total",
+			Help:      "Number of events requeued after an error.",
+		}, []string{"name"}),
+	}
+}
+
+func (m *queueMetrics) NewDepthMetric(name string) workqueue.GaugeMetric {
+	return m.depth.WithLabelValues(name)
+}
+
+func (m *queueMetrics) NewAddsMetric(name string) workqueue.CounterMetric {
+	return m.adds.WithLabelValues(name)
+}
+
+func (m *queueMetrics) NewLatencyMetric(name string) workqueue.HistogramMetric {
+	return m.latency.WithLabelValues(name)
+}
+
+func (m *queueMetrics) NewWorkDurationMetric(name string) workqueue.HistogramMetric {
+	return m.workDuration.WithLabelValues(name)
+}
+
+func (m *queueMetrics) NewUnfinishedWorkSecondsMetric(name string) workqueue.SettableGaugeMetric {
+	return m.unfinishedWork.WithLabelValues(name)
+}
+
+func (m *queueMetrics) NewLongestRunningProcessorSecondsMetric(name string) workqueue.SettableGaugeMetric {
+	return m.longestRunningProcessor.WithLabelValues(name)
+}
+
+func (m *queueMetrics) NewRetriesMetric(name string) workqueue.CounterMetric {
+	return m.retries.WithLabelValues(name)
+}
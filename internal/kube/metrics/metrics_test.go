@@ -0,0 +1,109 @@
+package metrics
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"gotest.tools/v3/assert"
+	"k8s.io/client-go/util/workqueue"
+)
+
+func TestReconcileObserved(t *testing.T) {
+	ReconcileObserved("Widget", time.Millisecond, nil)
+	ReconcileObserved("Widget", time.Millisecond, errors.New("failed"))
+	ReconcileObserved("Gadget", time.Millisecond, nil)
+
+	assert.Equal(t, testutil.ToFloat64(reconcileTotal.WithLabelValues("Widget")), float64(2))
+	assert.Equal(t, testutil.ToFloat64(reconcileErrors.WithLabelValues("Widget")), float64(1))
+	assert.Equal(t, testutil.ToFloat64(reconcileTotal.WithLabelValues("Gadget")), float64(1))
+	assert.Equal(t, testutil.ToFloat64(reconcileErrors.WithLabelValues("Gadget")), float64(0))
+	assert.Equal(t, testutil.CollectAndCount(reconcileDuration), 2)
+}
+
+func TestGrantRedemptions(t *testing.T) {
+	GrantRedeemed()
+	GrantRedeemed()
+	GrantRedemptionFailed(RedemptionExpired)
+	GrantRedemptionFailed(RedemptionPolicy)
+	GrantRedemptionFailed(RedemptionPolicy)
+
+	assert.Equal(t, testutil.ToFloat64(grantRedemptions), float64(2))
+	assert.Equal(t, testutil.ToFloat64(grantRedemptionFailures.WithLabelValues(RedemptionExpired)), float64(1))
+	assert.Equal(t, testutil.ToFloat64(grantRedemptionFailures.WithLabelValues(RedemptionPolicy)), float64(2))
+}
+
+func TestCertificateExpiry(t *testing.T) {
+	expiry := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	CertificateExpires("test", "skupper-site-server", expiry)
+	assert.Equal(t, testutil.ToFloat64(certificateExpiry.WithLabelValues("test", "skupper-site-server")), float64(expiry.Unix()))
+
+	CertificateRemoved("test", "skupper-site-server")
+	assert.Equal(t, testutil.CollectAndCount(certificateExpiry), 0)
+}
+
+func TestQueueMetrics(t *testing.T) {
+	q := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "metrics-test")
+	defer q.ShutDown()
+	q.Add("a")
+	q.Add("b")
+
+	assert.Equal(t, testutil.ToFloat64(queue.depth.WithLabelValues("metrics-test")), float64(2))
+	assert.Equal(t, testutil.ToFloat64(queue.adds.WithLabelValues("metrics-test")), float64(2))
+
+	item, _ := q.Get()
+	q.Done(item)
+	assert.Equal(t, testutil.ToFloat64(queue.depth.WithLabelValues("metrics-test")), float64(1))
+}
+
+func TestHandler(t *testing.T) {
+	ready := false
+	srv := httptest.NewServer(Handler(func() bool { return ready }))
+	defer srv.Close()
+
+	tests := []struct {
+		path         string
+		ready        bool
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			path:         "/healthz",
+			expectedCode: http.StatusOK,
+			expectedBody: "ok",
+		},
+		{
+			path:         "/readyz",
+			expectedCode: http.StatusServiceUnavailable,
+			expectedBody: "not ready",
+		},
+		{
+			path:         "/readyz",
+			ready:        true,
+			expectedCode: http.StatusOK,
+			expectedBody: "ok",
+		},
+		{
+			path:         "/metrics",
+			expectedCode: http.StatusOK,
+			expectedBody: "skupper_controller_workqueue_depth",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			ready = tt.ready
+			resp, err := http.Get(srv.URL + tt.path)
+			assert.NilError(t, err)
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			assert.NilError(t, err)
+			assert.Equal(t, resp.StatusCode, tt.expectedCode)
+			assert.Assert(t, strings.Contains(string(body), tt.expectedBody), string(body))
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Server exposes the controller metrics on /metrics along with a
+// liveness check on /healthz and a readiness check on /readyz.
+type Server struct {
+	srv *http.Server
+	log *slog.Logger
+}
+
+// NewServer returns a Server listening on the supplied address. The
+// readiness check succeeds once the ready function returns true.
+func NewServer(addr string, ready func() bool) *Server {
+	return &Server{
+		srv: &http.Server{
+			Addr:              addr,
+			Handler:           Handler(ready),
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+		log: slog.New(slog.Default().Handler()).With(slog.String("component", "kube.metrics")),
+	}
+}
+
+// Handler returns the http.Handler serving the metrics, liveness and
+// readiness endpoints.
+func Handler(ready func() bool) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry}))
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		if ready != nil && !ready() {
+			http.Error(w, "not ready", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok"))
+	})
+	return mux
+}
+
+// Start serves requests in a new go routine until Stop is called.
+func (s *Server) Start() {
+	go func() {
+		s.log.Info("Serving metrics and health checks", slog.String("address", s.srv.Addr))
+		if err := s.srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			s.log.Error("Metrics server failed", slog.Any("error", err))
+		}
+	}()
+}
+
+// Stop shuts the server down.
+func (s *Server) Stop() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return s.srv.Shutdown(ctx)
+}
@@ -0,0 +1,54 @@
+package qdr
+
+import (
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/skupperproject/skupper/internal/qdr"
+)
+
+// RouterConfigDriftAnnotation holds, on a router ConfigMap, the last
+// differences found between the entities of the running router and the
+// configuration in the ConfigMap.
+const RouterConfigDriftAnnotation = "internal.skupper.io/router-config-drift"
+
+// RouterConfigDrift records differences found between a running router
+// and its configuration. The changes are those required to bring the
+// router back in line with its configuration.
+type RouterConfigDrift struct {
+	Time      string             `json:"time"`
+	Changes   []qdr.ConfigChange `json:"changes,omitempty"`
+	Corrected bool               `json:"corrected"`
+}
+
+// GetRouterConfigDrift returns the drift recorded on a router
+// ConfigMap, or nil if none has been recorded.
+func GetRouterConfigDrift(configmap *corev1.ConfigMap) (*RouterConfigDrift, error) {
+	value, ok := configmap.ObjectMeta.Annotations[RouterConfigDriftAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	drift := &RouterConfigDrift{}
+	if err := json.Unmarshal([]byte(value), drift); err != nil {
+		return nil, err
+	}
+	return drift, nil
+}
+
+// SetRouterConfigDrift records the drift on a router ConfigMap,
+// returning true if the annotation changed.
+func SetRouterConfigDrift(configmap *corev1.ConfigMap, drift *RouterConfigDrift) (bool, error) {
+	data, err := json.Marshal(drift)
+	if err != nil {
+		return false, err
+	}
+	if configmap.ObjectMeta.Annotations[RouterConfigDriftAnnotation] == string(data) {
+		return false, nil
+	}
+	if configmap.ObjectMeta.Annotations == nil {
+		configmap.ObjectMeta.Annotations = map[string]string{}
+	}
+	configmap.ObjectMeta.Annotations[RouterConfigDriftAnnotation] = string(data)
+	return true, nil
+}
@@ -0,0 +1,86 @@
+package qdr
+
+import (
+	"context"
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+// LinkRestrictionsAnnotation holds, on a router ConfigMap, the
+// restrictions on the links peer sites may establish to the router.
+// The annotation is absent if there are no restrictions.
+const LinkRestrictionsAnnotation = "internal.skupper.io/link-restrictions"
+
+// LinkRestrictions limit the incoming links of a router. They are set
+// by the controller from the AccessPolicies of the site and enforced
+// by the config-sync sidecar, which closes the connections that do
+// not comply.
+type LinkRestrictions struct {
+	// AllowedSubjects, if not nil, lists the only certificate subjects
+	// peer sites may link in with.
+	AllowedSubjects []string `json:"allowedSubjects"`
+	// SiteSubjects are the certificate subjects of the other routers
+	// of the site, which are neither restricted nor counted as links.
+	SiteSubjects []string `json:"siteSubjects,omitempty"`
+	// MaxLinks, if not zero, is the maximum number of incoming links.
+	MaxLinks int `json:"maxLinks,omitempty"`
+}
+
+// GetLinkRestrictions returns the link restrictions recorded on a
+// router ConfigMap, or nil if there are none.
+func GetLinkRestrictions(configmap *corev1.ConfigMap) (*LinkRestrictions, error) {
+	value, ok := configmap.ObjectMeta.Annotations[LinkRestrictionsAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	restrictions := &LinkRestrictions{}
+	if err := json.Unmarshal([]byte(value), restrictions); err != nil {
+		return nil, err
+	}
+	return restrictions, nil
+}
+
+// SetLinkRestrictions records the link restrictions on a router
+// ConfigMap, removing them if nil, and returns true if the annotation
+// changed.
+func SetLinkRestrictions(configmap *corev1.ConfigMap, restrictions *LinkRestrictions) (bool, error) {
+	if restrictions == nil {
+		if _, ok := configmap.ObjectMeta.Annotations[LinkRestrictionsAnnotation]; !ok {
+			return false, nil
+		}
+		delete(configmap.ObjectMeta.Annotations, LinkRestrictionsAnnotation)
+		return true, nil
+	}
+	data, err := json.Marshal(restrictions)
+	if err != nil {
+		return false, err
+	}
+	if value, ok := configmap.ObjectMeta.Annotations[LinkRestrictionsAnnotation]; ok && value == string(data) {
+		return false, nil
+	}
+	if configmap.ObjectMeta.Annotations == nil {
+		configmap.ObjectMeta.Annotations = map[string]string{}
+	}
+	configmap.ObjectMeta.Annotations[LinkRestrictionsAnnotation] = string(data)
+	return true, nil
+}
+
+// UpdateLinkRestrictions records the link restrictions on the named
+// router ConfigMap.
+func UpdateLinkRestrictions(client kubernetes.Interface, name string, namespace string, ctxt context.Context, restrictions *LinkRestrictions) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := client.CoreV1().ConfigMaps(namespace).Get(ctxt, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if changed, err := SetLinkRestrictions(current, restrictions); err != nil || !changed {
+			return err
+		}
+		_, err = client.CoreV1().ConfigMaps(namespace).Update(ctxt, current, metav1.UpdateOptions{})
+		return err
+	})
+}
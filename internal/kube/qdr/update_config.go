@@ -3,10 +3,12 @@ package qdr
 import (
 	"context"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/util/retry"
 
+	"github.com/skupperproject/skupper/api/types"
 	"github.com/skupperproject/skupper/internal/qdr"
 )
 
@@ -15,13 +17,66 @@ type Labelling interface {
 	SetAnnotations(namespace string, name string, kind string, annotations map[string]string) bool
 }
 
+// PendingConfigKey is the key in the router ConfigMap under which
+// changes to the router configuration are held while the site is in
+// dry-run mode.
+const PendingConfigKey = "skrouterd-pending.json"
+
 func UpdateRouterConfig(client kubernetes.Interface, name string, namespace string, ctxt context.Context, update qdr.ConfigUpdate, labelling Labelling) error {
 	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		return updateRouterConfig(client, name, namespace, ctxt, update, labelling)
+		return updateRouterConfig(client, name, namespace, ctxt, update, labelling, false)
+	})
+}
+
+// UpdatePendingRouterConfig applies an update to the pending router
+// configuration, which starts as a copy of the live configuration,
+// leaving the live configuration unchanged.
+func UpdatePendingRouterConfig(client kubernetes.Interface, name string, namespace string, ctxt context.Context, update qdr.ConfigUpdate, labelling Labelling) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		return updateRouterConfig(client, name, namespace, ctxt, update, labelling, true)
+	})
+}
+
+// CommitPendingRouterConfig makes the pending router configuration, if
+// any, the live one. It returns true if there was a pending
+// configuration.
+func CommitPendingRouterConfig(client kubernetes.Interface, name string, namespace string, ctxt context.Context) (bool, error) {
+	committed := false
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := client.CoreV1().ConfigMaps(namespace).Get(ctxt, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		pending, ok := current.Data[PendingConfigKey]
+		if !ok {
+			return nil
+		}
+		current.Data[types.TransportConfigFile] = pending
+		delete(current.Data, PendingConfigKey)
+		if _, err := client.CoreV1().ConfigMaps(namespace).Update(ctxt, current, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+		committed = true
+		return nil
 	})
+	return committed, err
 }
 
-func updateRouterConfig(client kubernetes.Interface, name string, namespace string, ctxt context.Context, update qdr.ConfigUpdate, labelling Labelling) error {
+// GetPendingRouterConfig returns the pending router configuration held
+// in the ConfigMap, or nil if there is none.
+func GetPendingRouterConfig(configmap *corev1.ConfigMap) (*qdr.RouterConfig, error) {
+	pending, ok := configmap.Data[PendingConfigKey]
+	if !ok {
+		return nil, nil
+	}
+	config, err := qdr.UnmarshalRouterConfig(pending)
+	if err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+func updateRouterConfig(client kubernetes.Interface, name string, namespace string, ctxt context.Context, update qdr.ConfigUpdate, labelling Labelling, pending bool) error {
 	current, err := client.CoreV1().ConfigMaps(namespace).Get(ctxt, name, metav1.GetOptions{})
 	if err != nil {
 		return err
@@ -37,6 +92,13 @@ func updateRouterConfig(client kubernetes.Interface, name string, namespace stri
 	if err != nil {
 		return err
 	}
+	if pending {
+		if pendingConfig, err := GetPendingRouterConfig(current); err != nil {
+			return err
+		} else if pendingConfig != nil {
+			config = pendingConfig
+		}
+	}
 	updated := false
 
 	if update.Apply(config) {
@@ -55,8 +117,13 @@ func updateRouterConfig(client kubernetes.Interface, name string, namespace stri
 		return nil
 	}
 
-	err = config.WriteToConfigMap(current)
-	if err != nil {
+	if pending {
+		data, err := qdr.MarshalRouterConfig(*config)
+		if err != nil {
+			return err
+		}
+		current.Data[PendingConfigKey] = data
+	} else if err := writeRouterConfig(config, current); err != nil {
 		return err
 	}
 
@@ -66,3 +133,16 @@ func updateRouterConfig(client kubernetes.Interface, name string, namespace stri
 	}
 	return nil
 }
+
+// writeRouterConfig writes the live router configuration to the
+// ConfigMap, preserving any pending configuration.
+func writeRouterConfig(config *qdr.RouterConfig, configmap *corev1.ConfigMap) error {
+	pending, hasPending := configmap.Data[PendingConfigKey]
+	if err := config.WriteToConfigMap(configmap); err != nil {
+		return err
+	}
+	if hasPending {
+		configmap.Data[PendingConfigKey] = pending
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package site
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubeqdr "github.com/skupperproject/skupper/internal/kube/qdr"
+	internalnetwork "github.com/skupperproject/skupper/internal/network"
+	"github.com/skupperproject/skupper/internal/site"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+)
+
+// CheckAccessPolicy records the latest definition of an AccessPolicy
+// in the site namespace and reconfigures the router, listeners,
+// connectors and incoming links to comply with the restrictions in
+// effect.
+func (s *Site) CheckAccessPolicy(name string, policy *skupperv2alpha1.AccessPolicy) error {
+	if !s.policies.Update(name, policy) {
+		s.updateAccessPolicyStatus()
+		return nil
+	}
+	s.logger.Info("Access policies changed",
+		slog.String("namespace", s.namespace),
+		slog.String("policy", name))
+	var errs []error
+	if s.initialised {
+		errs = append(errs, s.updateRouterConfig(s.bindings))
+		s.refreshBindingsConfiguredStatus()
+		s.bindings.MapOverAttachedConnectors(func(connector *AttachedConnector) {
+			errs = append(errs, connector.updateStatus())
+		})
+	}
+	errs = append(errs, s.updateLinkRestrictions())
+	s.updateAccessPolicyStatus()
+	return stderrors.Join(errs...)
+}
+
+func (s *Site) refreshBindingsConfiguredStatus() {
+	lf := func(listener *skupperv2alpha1.Listener) *skupperv2alpha1.Listener {
+		if err := s.updateListenerStatus(listener, site.ValidateBindingType(listener.Spec.Type)); err != nil {
+			s.logger.Error("Could not update listener status",
+				slog.String("namespace", listener.ObjectMeta.Namespace),
+				slog.String("listener", listener.ObjectMeta.Name),
+				slog.Any("error", err))
+		}
+		return nil
+	}
+	cf := func(connector *skupperv2alpha1.Connector) *skupperv2alpha1.Connector {
+		err := site.ValidateBindingType(connector.Spec.Type)
+		if selection, ok := s.bindings.selectors[connector.Name]; ok && len(selection.List()) == 0 {
+			err = stderrors.Join(err, fmt.Errorf("No matches for selector"))
+		}
+		if err := s.updateConnectorConfiguredStatus(connector, err); err != nil {
+			s.logger.Error("Could not update connector status",
+				slog.String("namespace", connector.ObjectMeta.Namespace),
+				slog.String("connector", connector.ObjectMeta.Name),
+				slog.Any("error", err))
+		}
+		return nil
+	}
+	s.bindings.Map(cf, lf)
+}
+
+// LinkOriginsUpdated records the recipients of the link credentials
+// issued on redemption of AccessGrants, keyed by the subject of the
+// credentials, and restricts incoming links accordingly.
+func (s *Site) LinkOriginsUpdated(origins map[string]skupperv2alpha1.GrantRecipient) error {
+	if reflect.DeepEqual(s.linkOrigins, origins) {
+		return nil
+	}
+	s.linkOrigins = origins
+	return s.updateLinkRestrictions()
+}
+
+// linkRestrictions returns the restrictions the config-sync sidecar
+// enforces on the incoming links of the routers, or nil if there are
+// none. Only the credentials issued to the recipients of AccessGrants
+// that the policies allow to link in are accepted if link origins are
+// restricted.
+func (s *Site) linkRestrictions() *kubeqdr.LinkRestrictions {
+	subjects := s.policies.AllowedLinkSubjects(s.linkOrigins)
+	max := s.policies.MaxLinks()
+	if subjects == nil && max == 0 {
+		return nil
+	}
+	restrictions := &kubeqdr.LinkRestrictions{
+		AllowedSubjects: subjects,
+		MaxLinks:        max,
+	}
+	if groups := s.groups(); len(groups) > 1 {
+		// the routers of the site link to each other with the
+		// credentials of their SecuredAccess
+		for _, la := range s.linkAccess {
+			for i := range groups {
+				name := la.Name
+				if i > 0 {
+					name = fmt.Sprintf("%s-%d", la.Name, (i + 1))
+				}
+				restrictions.SiteSubjects = append(restrictions.SiteSubjects, name)
+			}
+		}
+		sort.Strings(restrictions.SiteSubjects)
+	}
+	return restrictions
+}
+
+func (s *Site) updateLinkRestrictions() error {
+	if !s.initialised {
+		return nil
+	}
+	restrictions := s.linkRestrictions()
+	for _, group := range s.groups() {
+		if err := kubeqdr.UpdateLinkRestrictions(s.clients.GetKubeClient(), group, s.namespace, context.TODO(), restrictions); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sitesLinkedIn returns the names of the sites that have linked in to
+// this site, as reported in the network status.
+func (s *Site) sitesLinkedIn() []string {
+	if s.site == nil {
+		return nil
+	}
+	return internalnetwork.GetSitesLinkingTo(s.site.GetSiteId(), s.site.Status.Network)
+}
+
+func (s *Site) accessPolicyViolations(name string) []string {
+	var listeners []*skupperv2alpha1.Listener
+	var connectors []*skupperv2alpha1.Connector
+	s.bindings.Map(func(connector *skupperv2alpha1.Connector) *skupperv2alpha1.Connector {
+		connectors = append(connectors, connector)
+		return nil
+	}, func(listener *skupperv2alpha1.Listener) *skupperv2alpha1.Listener {
+		listeners = append(listeners, listener)
+		return nil
+	})
+	violations := s.policies.Violations(name, listeners, connectors)
+	policy := s.policies[name]
+	s.bindings.MapOverAttachedConnectors(func(connector *AttachedConnector) {
+		if connector.binding != nil && !policy.AllowsConnector(connector.binding.Spec.RoutingKey) {
+			violations = append(violations, fmt.Sprintf("AttachedConnectorBinding %s uses routing key %q", connector.binding.Name, connector.binding.Spec.RoutingKey))
+		}
+	})
+	if linked := s.sitesLinkedIn(); policy.Spec.MaxLinks > 0 && len(linked) > policy.Spec.MaxLinks {
+		violations = append(violations, fmt.Sprintf("Links from %d sites (%s) exceed the limit of %d links", len(linked), strings.Join(linked, ", "), policy.Spec.MaxLinks))
+	}
+	sort.Strings(violations)
+	return violations
+}
+
+func (s *Site) updateAccessPolicyStatus() {
+	for name, policy := range s.policies {
+		configured := policy.SetConfigured(policy.Validate())
+		if !policy.SetViolations(s.accessPolicyViolations(name)) && !configured {
+			continue
+		}
+		updated, err := s.clients.GetSkupperClient().SkupperV2alpha1().AccessPolicies(policy.ObjectMeta.Namespace).UpdateStatus(context.TODO(), policy, metav1.UpdateOptions{})
+		if err != nil {
+			s.logger.Error("Could not update access policy status",
+				slog.String("namespace", policy.ObjectMeta.Namespace),
+				slog.String("policy", name),
+				slog.Any("error", err))
+			continue
+		}
+		s.policies[name] = updated
+	}
+}
@@ -0,0 +1,215 @@
+package site
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kubeqdr "github.com/skupperproject/skupper/internal/kube/qdr"
+	"github.com/skupperproject/skupper/internal/qdr"
+	"github.com/skupperproject/skupper/internal/version"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func testListener(name string, routingKey string) *skupperv2alpha1.Listener {
+	return &skupperv2alpha1.Listener{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test",
+		},
+		Spec: skupperv2alpha1.ListenerSpec{
+			RoutingKey: routingKey,
+			Host:       name,
+			Port:       8080,
+			Type:       "tcp",
+		},
+	}
+}
+
+func testLink(name string, created time.Time) *skupperv2alpha1.Link {
+	return &skupperv2alpha1.Link{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "test",
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Spec: skupperv2alpha1.LinkSpec{
+			Endpoints: []skupperv2alpha1.Endpoint{
+				{
+					Name: string(qdr.RoleInterRouter),
+					Host: "10.10.10.1",
+					Port: "55671",
+				},
+			},
+		},
+	}
+}
+
+func configuredCondition(conditions []metav1.Condition) *metav1.Condition {
+	return meta.FindStatusCondition(conditions, skupperv2alpha1.CONDITION_TYPE_CONFIGURED)
+}
+
+func TestSite_CheckAccessPolicy(t *testing.T) {
+	now := time.Now()
+	policy := &skupperv2alpha1.AccessPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "restricted",
+			Namespace:  "test",
+			Generation: 1,
+		},
+		Spec: skupperv2alpha1.AccessPolicySpec{
+			ListenerRoutingKeys: []string{"back*"},
+			MaxLinks:            1,
+		},
+	}
+	listeners := []*skupperv2alpha1.Listener{
+		testListener("allowed", "backend"),
+		testListener("denied", "database"),
+	}
+	links := []*skupperv2alpha1.Link{
+		testLink("older", now.Add(-time.Hour)),
+		testLink("newer", now),
+	}
+	skupperObjects := []runtime.Object{policy}
+	for _, listener := range listeners {
+		skupperObjects = append(skupperObjects, listener)
+	}
+	for _, link := range links {
+		skupperObjects = append(skupperObjects, link)
+	}
+	s, err := newSiteMocks("test", nil, skupperObjects, "", false)
+	assert.Assert(t, err)
+	s.initialised = true
+	assert.Assert(t, createRouterConfigMock(s))
+
+	for _, listener := range listeners {
+		assert.Assert(t, s.CheckListener(listener.Name, listener))
+	}
+	for _, link := range links {
+		assert.Assert(t, s.CheckLink(link.Name, link))
+	}
+	assert.Equal(t, len(s.links), 2)
+
+	assert.Assert(t, s.CheckAccessPolicy(policy.Name, policy))
+
+	// only the listener with an allowed routing key is configured
+	assert.Equal(t, configuredCondition(listeners[0].Status.Conditions).Status, metav1.ConditionTrue)
+	condition := configuredCondition(listeners[1].Status.Conditions)
+	assert.Equal(t, condition.Status, metav1.ConditionFalse)
+	assert.Equal(t, condition.Message, `Routing key "database" is not allowed for listeners by AccessPolicy restricted`)
+	rc := qdr.InitialConfig("test", s.site.GetSiteId(), version.Version, false, 3)
+	s.bindings.Apply(&rc)
+	_, ok := rc.Bridges.TcpListeners["allowed"]
+	assert.Assert(t, ok)
+	_, ok = rc.Bridges.TcpListeners["denied"]
+	assert.Assert(t, !ok)
+
+	// the limit applies to incoming links, so outgoing links are
+	// unaffected
+	assert.Equal(t, len(s.links), 2)
+	for _, link := range links {
+		assert.Equal(t, configuredCondition(link.Status.Conditions).Status, metav1.ConditionTrue)
+	}
+
+	// the routers are told to accept a single incoming link
+	restrictions := routerLinkRestrictions(t, s)
+	assert.Assert(t, restrictions != nil)
+	assert.Equal(t, restrictions.MaxLinks, 1)
+	assert.Assert(t, restrictions.AllowedSubjects == nil)
+
+	// two peer sites link in
+	network := []skupperv2alpha1.SiteRecord{
+		{Id: s.site.GetSiteId(), Name: "test"},
+		{Id: "east-id", Name: "east", Links: []skupperv2alpha1.LinkRecord{{Name: "to-test", RemoteSiteId: s.site.GetSiteId(), Operational: true}}},
+		{Id: "west-id", Name: "west", Links: []skupperv2alpha1.LinkRecord{{Name: "to-test", RemoteSiteId: s.site.GetSiteId(), Operational: true}}},
+	}
+	assert.Assert(t, s.NetworkStatusUpdated(network))
+
+	updated := s.policies[policy.Name]
+	assert.DeepEqual(t, updated.Status.Violations, []string{
+		`Links from 2 sites (east, west) exceed the limit of 1 links`,
+		`Listener denied uses routing key "database"`,
+	})
+	assert.Assert(t, meta.IsStatusConditionFalse(updated.Status.Conditions, skupperv2alpha1.CONDITION_TYPE_COMPLIANT))
+	assert.Assert(t, updated.IsReady())
+
+	// removing the policy lifts the restrictions
+	assert.Assert(t, s.CheckAccessPolicy(policy.Name, nil))
+	assert.Equal(t, len(s.links), 2)
+	assert.Assert(t, routerLinkRestrictions(t, s) == nil)
+	listener := s.bindings.bindings.GetListener("denied")
+	assert.Equal(t, configuredCondition(listener.Status.Conditions).Status, metav1.ConditionTrue)
+	rc = qdr.InitialConfig("test", s.site.GetSiteId(), version.Version, false, 3)
+	s.bindings.Apply(&rc)
+	_, ok = rc.Bridges.TcpListeners["denied"]
+	assert.Assert(t, ok)
+}
+
+func TestSite_LinkOriginsUpdated(t *testing.T) {
+	policy := &skupperv2alpha1.AccessPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "origins",
+			Namespace:  "test",
+			Generation: 1,
+		},
+		Spec: skupperv2alpha1.AccessPolicySpec{
+			LinkOrigins: skupperv2alpha1.LinkOrigins{
+				SiteLabels: map[string]string{"tenant": "a"},
+			},
+		},
+	}
+	s, err := newSiteMocks("test", nil, []runtime.Object{policy}, "", false)
+	assert.Assert(t, err)
+	s.initialised = true
+	assert.Assert(t, createRouterConfigMock(s))
+
+	// no credentials have been issued to sites allowed to link in
+	assert.Assert(t, s.CheckAccessPolicy(policy.Name, policy))
+	restrictions := routerLinkRestrictions(t, s)
+	assert.Assert(t, restrictions != nil)
+	assert.DeepEqual(t, restrictions.AllowedSubjects, []string{})
+
+	assert.Assert(t, s.LinkOriginsUpdated(map[string]skupperv2alpha1.GrantRecipient{
+		"east":  {SiteName: "east", SiteLabels: map[string]string{"tenant": "a"}},
+		"west":  {SiteName: "west", SiteLabels: map[string]string{"tenant": "b"}},
+		"north": {SiteName: "north", SiteLabels: map[string]string{"tenant": "a", "zone": "1"}},
+	}))
+	restrictions = routerLinkRestrictions(t, s)
+	assert.DeepEqual(t, restrictions.AllowedSubjects, []string{"east", "north"})
+	assert.Equal(t, restrictions.MaxLinks, 0)
+}
+
+func routerLinkRestrictions(t *testing.T, s *Site) *kubeqdr.LinkRestrictions {
+	t.Helper()
+	cm, err := s.clients.GetKubeClient().CoreV1().ConfigMaps(s.namespace).Get(context.TODO(), "skupper-router", metav1.GetOptions{})
+	assert.Assert(t, err)
+	restrictions, err := kubeqdr.GetLinkRestrictions(cm)
+	assert.Assert(t, err)
+	return restrictions
+}
+
+func TestSite_CheckAccessPolicyInvalid(t *testing.T) {
+	policy := &skupperv2alpha1.AccessPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "invalid",
+			Namespace:  "test",
+			Generation: 1,
+		},
+		Spec: skupperv2alpha1.AccessPolicySpec{
+			ConnectorRoutingKeys: []string{"[a-"},
+		},
+	}
+	s, err := newSiteMocks("test", nil, []runtime.Object{policy}, "", false)
+	assert.Assert(t, err)
+
+	assert.Assert(t, s.CheckAccessPolicy(policy.Name, policy))
+	updated := s.policies[policy.Name]
+	assert.Assert(t, !updated.IsReady())
+	assert.Equal(t, configuredCondition(updated.Status.Conditions).Message, `Invalid routing key pattern "[a-": syntax error in pattern`)
+	// invalid patterns match nothing, so all connectors are refused
+	assert.Assert(t, s.policies.CheckConnectorRoutingKey("backend") != nil)
+}
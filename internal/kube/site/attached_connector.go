@@ -106,6 +106,9 @@ func (a *AttachedConnector) updateStatusNoBinding() error {
 
 func (a *AttachedConnector) updateStatusTo(err error, activeDefinition *skupperv2alpha1.AttachedConnector) error {
 	var errors []string
+	if err == nil {
+		err = a.parent.policies.CheckConnectorRoutingKey(a.binding.Spec.RoutingKey)
+	}
 	if a.binding.SetConfigured(err) {
 		if err := a.updateBindingStatus(); err != nil {
 			errors = append(errors, err.Error())
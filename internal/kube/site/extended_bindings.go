@@ -21,6 +21,7 @@ type ExtendedBindings struct {
 	listenerHosts      map[string]string // listener name -> host
 	controller         *watchers.EventProcessor
 	site               *Site
+	policies           site.AccessPolicies
 	logger             *slog.Logger
 }
 
@@ -182,6 +183,11 @@ func (b *ExtendedBindings) SetConnectorConfiguration(configuration site.Connecto
 	b.bindings.SetConnectorConfiguration(configuration)
 }
 
+func (b *ExtendedBindings) SetAccessPolicies(policies site.AccessPolicies) {
+	b.policies = policies
+	b.bindings.SetBindingPolicy(policies)
+}
+
 func (b *ExtendedBindings) SetBindingEventHandler(handler site.BindingEventHandler) {
 	b.bindings.SetBindingEventHandler(handler)
 }
@@ -252,10 +258,16 @@ func (b *ExtendedBindings) MapOverAttachedConnectors(cf AttachedConnectorFunctio
 func (b *ExtendedBindings) Apply(config *qdr.RouterConfig) bool {
 	desired := b.bindings.ToBridgeConfig()
 	for _, connector := range b.connectors {
+		if connector.binding != nil && b.policies.CheckConnectorRoutingKey(connector.binding.Spec.RoutingKey) != nil {
+			continue
+		}
 		connector.updateBridgeConfig(b.bindings.SiteId, &desired)
 		b.AddSslProfiles(config, connector.definitions)
 	}
 	for _, ptl := range b.perTargetListeners {
+		if b.policies.CheckListener(ptl.definition) != nil {
+			continue
+		}
 		ptl.updateBridgeConfig(b.bindings.SiteId, &desired)
 	}
 	b.bindings.AddSslProfiles(config)
@@ -78,11 +78,17 @@ func (p ExposedPorts) Unexpose(host string, portname string) *ExposedPortSet {
 func toServicePorts(desired map[string]Port) map[string]corev1.ServicePort {
 	results := map[string]corev1.ServicePort{}
 	for name, details := range desired {
+		protocol := details.Protocol
+		if protocol == "" {
+			// as defaulted by the api server, so that updates are not
+			// detected where there are none
+			protocol = corev1.ProtocolTCP
+		}
 		results[name] = corev1.ServicePort{
 			Name:       name,
 			Port:       int32(details.Port),
 			TargetPort: intstr.IntOrString{IntVal: int32(details.TargetPort)},
-			Protocol:   details.Protocol,
+			Protocol:   protocol,
 		}
 	}
 	return results
@@ -0,0 +1,73 @@
+package site
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestUpdatePorts(t *testing.T) {
+	tests := []struct {
+		name            string
+		actual          []corev1.ServicePort
+		desired         map[string]Port
+		expectedChanged bool
+		expected        []corev1.ServicePort
+	}{
+		{
+			name: "new tcp port",
+			desired: map[string]Port{
+				"backend": {Name: "backend", Port: 8080, TargetPort: 1024, Protocol: corev1.ProtocolTCP},
+			},
+			expectedChanged: true,
+			expected: []corev1.ServicePort{
+				{Name: "backend", Port: 8080, TargetPort: intstr.IntOrString{IntVal: 1024}, Protocol: corev1.ProtocolTCP},
+			},
+		},
+		{
+			name: "udp port",
+			desired: map[string]Port{
+				"dns": {Name: "dns", Port: 53, TargetPort: 1025, Protocol: corev1.ProtocolUDP},
+			},
+			expectedChanged: true,
+			expected: []corev1.ServicePort{
+				{Name: "dns", Port: 53, TargetPort: intstr.IntOrString{IntVal: 1025}, Protocol: corev1.ProtocolUDP},
+			},
+		},
+		{
+			name: "unspecified protocol matches tcp",
+			actual: []corev1.ServicePort{
+				{Name: "backend", Port: 8080, TargetPort: intstr.IntOrString{IntVal: 1024}, Protocol: corev1.ProtocolTCP},
+			},
+			desired: map[string]Port{
+				"backend": {Name: "backend", Port: 8080, TargetPort: 1024},
+			},
+			expectedChanged: false,
+			expected: []corev1.ServicePort{
+				{Name: "backend", Port: 8080, TargetPort: intstr.IntOrString{IntVal: 1024}, Protocol: corev1.ProtocolTCP},
+			},
+		},
+		{
+			name: "protocol changed",
+			actual: []corev1.ServicePort{
+				{Name: "syslog", Port: 514, TargetPort: intstr.IntOrString{IntVal: 1024}, Protocol: corev1.ProtocolTCP},
+			},
+			desired: map[string]Port{
+				"syslog": {Name: "syslog", Port: 514, TargetPort: 1024, Protocol: corev1.ProtocolUDP},
+			},
+			expectedChanged: true,
+			expected: []corev1.ServicePort{
+				{Name: "syslog", Port: 514, TargetPort: intstr.IntOrString{IntVal: 1024}, Protocol: corev1.ProtocolUDP},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &corev1.ServiceSpec{Ports: tt.actual}
+			assert.Equal(t, updatePorts(spec, tt.desired), tt.expectedChanged)
+			assert.DeepEqual(t, spec.Ports, tt.expected)
+		})
+	}
+}
@@ -7,6 +7,10 @@ import (
 	"fmt"
 	"strconv"
 
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/yaml"
 
@@ -175,6 +179,62 @@ func Apply(clients internalclient.Clients, ctx context.Context, site *skupperv2a
 	return nil
 }
 
+// RestartRequired reports whether applying the resources for a group
+// would restart its router, i.e. whether the images or the settings
+// requiring a restart differ from those of its existing deployment. It
+// returns false if the deployment does not exist yet.
+func RestartRequired(clients internalclient.Clients, ctx context.Context, site *skupperv2alpha1.Site, group string, size sizing.Sizing) (bool, error) {
+	deployment, err := getDeployment(clients, ctx, site.Namespace, group)
+	if err != nil || deployment == nil {
+		return false, err
+	}
+	params := getCoreParams(site, group, size)
+	if deployment.Spec.Template.ObjectMeta.Annotations["skupper.io/config-digest"] != params.ConfigDigest {
+		return true, nil
+	}
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		if container.Name == "router" && container.Image != params.RouterImage.Name {
+			return true, nil
+		}
+		if container.Name == "kube-adaptor" && container.Image != params.AdaptorImage.Name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// RolledOut reports whether all the replicas of the deployment for a
+// group have been updated to its latest spec and are available.
+func RolledOut(clients internalclient.Clients, ctx context.Context, namespace string, group string) (bool, error) {
+	deployment, err := getDeployment(clients, ctx, namespace, group)
+	if err != nil || deployment == nil {
+		return false, err
+	}
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	status := deployment.Status
+	return status.ObservedGeneration >= deployment.Generation &&
+		status.UpdatedReplicas == replicas &&
+		status.AvailableReplicas == replicas &&
+		status.Replicas == replicas, nil
+}
+
+func getDeployment(clients internalclient.Clients, ctx context.Context, namespace string, group string) (*appsv1.Deployment, error) {
+	obj, err := clients.GetDynamicClient().Resource(resource.DeploymentResource()).Namespace(namespace).Get(ctx, group, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	deployment := &appsv1.Deployment{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), deployment); err != nil {
+		return nil, err
+	}
+	return deployment, nil
+}
+
 func enableAntiAffinity(site *skupperv2alpha1.Site) bool {
 	return site.Spec.HA && !getValueAsBool(site.Spec.Settings, "disable-anti-affinity")
 }
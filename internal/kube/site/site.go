@@ -21,7 +21,6 @@ import (
 	internalclient "github.com/skupperproject/skupper/internal/kube/client"
 	kubeqdr "github.com/skupperproject/skupper/internal/kube/qdr"
 	"github.com/skupperproject/skupper/internal/kube/secrets"
-	"github.com/skupperproject/skupper/internal/kube/site/resources"
 	"github.com/skupperproject/skupper/internal/kube/site/sizing"
 	"github.com/skupperproject/skupper/internal/kube/watchers"
 	"github.com/skupperproject/skupper/internal/qdr"
@@ -43,12 +42,15 @@ type Labelling interface {
 
 type Site struct {
 	initialised   bool
+	dryRun        bool
 	site          *skupperv2alpha1.Site
 	name          string
 	namespace     string
 	clients       *watchers.EventProcessor
 	bindings      *ExtendedBindings
 	links         map[string]*site.Link
+	policies      site.AccessPolicies
+	linkOrigins   map[string]skupperv2alpha1.GrantRecipient
 	errors        map[string]string
 	linkAccess    site.RouterAccessMap
 	certs         certificates.CertificateManager
@@ -59,25 +61,32 @@ type Site struct {
 	currentGroups []string
 	labelling     Labelling
 	profiles      *secrets.ProfilesWatcher
+	drift         map[string]*kubeqdr.RouterConfigDrift
+	upgrade       *routerUpgrade
+
+	upgradeCheckScheduled bool
 }
 
 func NewSite(namespace string, eventProcessor *watchers.EventProcessor, certs certificates.CertificateManager, access SecuredAccessFactory, sizes *sizing.Registry, labelling Labelling) *Site {
 	logger := slog.New(slog.Default().Handler())
 	site := &Site{
-		bindings:   NewExtendedBindings(eventProcessor, SSL_PROFILE_PATH),
-		namespace:  namespace,
-		clients:    eventProcessor,
-		links:      map[string]*site.Link{},
-		linkAccess: site.RouterAccessMap{},
-		certs:      certs,
-		access:     access,
-		sizes:      sizes,
-		routerPods: map[string]*corev1.Pod{},
+		bindings:    NewExtendedBindings(eventProcessor, SSL_PROFILE_PATH),
+		namespace:   namespace,
+		clients:     eventProcessor,
+		links:       map[string]*site.Link{},
+		policies:    site.AccessPolicies{},
+		linkOrigins: map[string]skupperv2alpha1.GrantRecipient{},
+		linkAccess:  site.RouterAccessMap{},
+		certs:       certs,
+		access:      access,
+		sizes:       sizes,
+		routerPods:  map[string]*corev1.Pod{},
 		logger: logger.With(
 			slog.String("component", "kube.site.site"),
 		),
 		labelling: labelling,
 	}
+	site.bindings.SetAccessPolicies(site.policies)
 	site.profiles = secrets.NewProfilesWatcher(
 		sslSecretsWatcher(namespace, eventProcessor),
 		eventProcessor.GetKubeClient(),
@@ -146,6 +155,12 @@ func (s *Site) reconcile(siteDef *skupperv2alpha1.Site, inRecovery bool) error {
 	if err := s.verifySiteSpec(siteDef); err != nil {
 		return err
 	}
+	if !siteDef.IsDryRun() && (s.dryRun || !s.initialised) {
+		if err := s.commitPendingRouterConfig(); err != nil {
+			return err
+		}
+	}
+	s.dryRun = siteDef.IsDryRun()
 	// ensure necessary resources:
 	// 1. skupper-internal configmap
 	if !s.initialised {
@@ -167,6 +182,9 @@ func (s *Site) reconcile(siteDef *skupperv2alpha1.Site, inRecovery bool) error {
 		s.bindings.SetSite(s)
 		s.setBindingsConfiguredStatus(nil)
 		s.checkSecuredAccess()
+		if err := s.updateLinkRestrictions(); err != nil {
+			return err
+		}
 	} else if len(s.currentGroups) != len(s.groups()) {
 		s.logger.Info("EnableHA setting changed for site",
 			slog.String("namespace", siteDef.Namespace),
@@ -181,6 +199,9 @@ func (s *Site) reconcile(siteDef *skupperv2alpha1.Site, inRecovery bool) error {
 		if err := s.checkSecuredAccess(); err != nil {
 			return err
 		}
+		if err := s.updateLinkRestrictions(); err != nil {
+			return err
+		}
 	} else {
 		if err := s.updateRouterConfig(s); err != nil {
 			return err
@@ -229,12 +250,7 @@ func (s *Site) reconcile(siteDef *skupperv2alpha1.Site, inRecovery bool) error {
 			slog.Any("sizing", size),
 		)
 	}
-	for _, group := range s.groups() {
-		if err := resources.Apply(s.clients, ctxt, s.site, group, size, s.labelling); err != nil {
-			return err
-		}
-	}
-	return nil
+	return s.applyRouterResources(ctxt, size)
 }
 
 func (s *Site) initialRouterConfig() *qdr.RouterConfig {
@@ -689,7 +705,7 @@ func (s *Site) recoverRouterConfig(update bool) ([]*qdr.RouterConfig, error) {
 		if config, ok := byName[group]; ok {
 			if update {
 				op := ConfigUpdateList{s.bindings, s, s.linkAccess.DesiredConfig(groups[:i], SSL_PROFILE_PATH)}
-				if err := kubeqdr.UpdateRouterConfig(s.clients.GetKubeClient(), group, s.namespace, context.TODO(), op, s.labelling); err != nil {
+				if err := s.writeRouterConfig(group, op); err != nil {
 					s.logger.Error("Failed to update router config map",
 						slog.String("namespace", s.namespace),
 						slog.String("name", group),
@@ -808,12 +824,38 @@ func (s *Site) updateRouterConfigForGroup(update qdr.ConfigUpdate, group string)
 	if !s.initialised {
 		return nil
 	}
-	if err := kubeqdr.UpdateRouterConfig(s.clients.GetKubeClient(), group, s.namespace, context.TODO(), update, s.labelling); err != nil {
+	if err := s.writeRouterConfig(group, update); err != nil {
 		return err
 	}
 	return nil
 }
 
+// writeRouterConfig applies an update to the router config of a group,
+// holding it as pending while the site is in dry-run mode.
+func (s *Site) writeRouterConfig(group string, update qdr.ConfigUpdate) error {
+	if s.dryRun {
+		return kubeqdr.UpdatePendingRouterConfig(s.clients.GetKubeClient(), group, s.namespace, context.TODO(), update, s.labelling)
+	}
+	return kubeqdr.UpdateRouterConfig(s.clients.GetKubeClient(), group, s.namespace, context.TODO(), update, s.labelling)
+}
+
+func (s *Site) commitPendingRouterConfig() error {
+	for _, group := range s.groups() {
+		committed, err := kubeqdr.CommitPendingRouterConfig(s.clients.GetKubeClient(), group, s.namespace, context.TODO())
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if committed {
+			s.logger.Info("Pending router config applied",
+				slog.String("namespace", s.namespace),
+				slog.String("name", group))
+		}
+	}
+	return nil
+}
+
 func (s *Site) updateConnectorStatus(connector *skupperv2alpha1.Connector) error {
 	updated, err := updateConnectorStatus(s.clients, connector)
 	if err != nil {
@@ -824,7 +866,7 @@ func (s *Site) updateConnectorStatus(connector *skupperv2alpha1.Connector) error
 }
 
 func (s *Site) updateConnectorConfiguredStatus(connector *skupperv2alpha1.Connector, err error) error {
-	if connector.SetConfigured(err) {
+	if connector.SetConfigured(stderrors.Join(err, s.policies.CheckConnector(connector))) {
 		return s.updateConnectorStatus(connector)
 	}
 	return nil
@@ -840,7 +882,7 @@ func (s *Site) updateConnectorConfiguredStatusWithSelectedPods(connector *skuppe
 	} else {
 
 	}
-	if connector.SetConfigured(err) || connector.SetSelectedPods(selected) {
+	if connector.SetConfigured(stderrors.Join(err, s.policies.CheckConnector(connector))) || connector.SetSelectedPods(selected) {
 		return s.updateConnectorStatus(connector)
 	}
 	return nil
@@ -858,14 +900,15 @@ func (s *Site) CheckConnector(name string, connector *skupperv2alpha1.Connector)
 		return nil
 	}
 	err := s.updateRouterConfig(update)
+	s.updateAccessPolicyStatus()
 	if connector == nil {
 		return err
 	}
-	return s.updateConnectorConfiguredStatus(connector, err)
+	return s.updateConnectorConfiguredStatus(connector, stderrors.Join(err, site.ValidateBindingType(connector.Spec.Type)))
 }
 
 func (s *Site) updateListenerStatus(listener *skupperv2alpha1.Listener, err error) error {
-	if listener.SetConfigured(err) {
+	if listener.SetConfigured(stderrors.Join(err, s.policies.CheckListener(listener))) {
 		updated, err := s.clients.GetSkupperClient().SkupperV2alpha1().Listeners(listener.ObjectMeta.Namespace).UpdateStatus(context.TODO(), listener, metav1.UpdateOptions{})
 		if err == nil {
 			return err
@@ -917,15 +960,16 @@ func (s *Site) CheckListener(name string, listener *skupperv2alpha1.Listener) er
 		return nil
 	}
 	err2 := s.updateRouterConfig(update)
+	s.updateAccessPolicyStatus()
 	if listener == nil {
 		return stderrors.Join(err1, err2)
 	}
-	return s.updateListenerStatus(listener, stderrors.Join(err1, err2))
+	return s.updateListenerStatus(listener, stderrors.Join(err1, err2, site.ValidateBindingType(listener.Spec.Type)))
 }
 
 func (s *Site) setBindingsConfiguredStatus(err error) {
 	lf := func(listener *skupperv2alpha1.Listener) *skupperv2alpha1.Listener {
-		if listener.SetConfigured(nil) {
+		if listener.SetConfigured(s.policies.CheckListener(listener)) {
 			updated, err := s.clients.GetSkupperClient().SkupperV2alpha1().Listeners(listener.ObjectMeta.Namespace).UpdateStatus(context.TODO(), listener, metav1.UpdateOptions{})
 			if err == nil {
 				return updated
@@ -939,7 +983,7 @@ func (s *Site) setBindingsConfiguredStatus(err error) {
 		return nil
 	}
 	cf := func(connector *skupperv2alpha1.Connector) *skupperv2alpha1.Connector {
-		if connector.SetConfigured(nil) {
+		if connector.SetConfigured(s.policies.CheckConnector(connector)) {
 			updated, err := s.clients.GetSkupperClient().SkupperV2alpha1().Connectors(connector.ObjectMeta.Namespace).UpdateStatus(context.TODO(), connector, metav1.UpdateOptions{})
 			if err == nil {
 				return updated
@@ -1102,6 +1146,67 @@ func (s *Site) CheckSslProfiles(config *qdr.RouterConfig) error {
 	return nil
 }
 
+// RouterConfigDriftUpdated records the drift last reported for the
+// router of the given group, reflecting that of all groups in the
+// Synchronized condition of the site. The condition is only set once
+// drift has been reported.
+func (s *Site) RouterConfigDriftUpdated(group string, drift *kubeqdr.RouterConfigDrift) error {
+	if s.site == nil {
+		return nil
+	}
+	if drift == nil {
+		delete(s.drift, group)
+	} else {
+		if s.drift == nil {
+			s.drift = map[string]*kubeqdr.RouterConfigDrift{}
+		}
+		s.drift[group] = drift
+	}
+	if len(s.drift) == 0 {
+		return nil
+	}
+	if s.site.SetSynchronized(s.synchronizedCondition()) {
+		return s.updateSiteStatus()
+	}
+	return nil
+}
+
+func (s *Site) synchronizedCondition() skupperv2alpha1.ConditionState {
+	var uncorrected []string
+	var corrected []string
+	for _, group := range s.groups() {
+		drift, ok := s.drift[group]
+		if !ok {
+			continue
+		}
+		var changes []string
+		for _, change := range drift.Changes {
+			changes = append(changes, change.String())
+		}
+		description := fmt.Sprintf("router %s at %s (%s)", group, drift.Time, strings.Join(changes, ", "))
+		if drift.Corrected {
+			corrected = append(corrected, description)
+		} else {
+			uncorrected = append(uncorrected, description)
+		}
+	}
+	if len(uncorrected) > 0 {
+		return skupperv2alpha1.ConditionState{
+			Status:  metav1.ConditionFalse,
+			Reason:  skupperv2alpha1.StatusError,
+			Message: "Configuration drift not corrected for " + strings.Join(uncorrected, "; "),
+		}
+	}
+	if len(corrected) > 0 {
+		return skupperv2alpha1.ConditionState{
+			Status:  metav1.ConditionTrue,
+			Reason:  skupperv2alpha1.StatusReady,
+			Message: "Configuration drift corrected for " + strings.Join(corrected, "; "),
+		}
+	}
+	return skupperv2alpha1.ReadyCondition()
+}
+
 func (s *Site) NetworkStatusUpdated(network []skupperv2alpha1.SiteRecord) error {
 	if s.site == nil || reflect.DeepEqual(s.site.Status.Network, network) {
 		return nil
@@ -1132,6 +1237,10 @@ func (s *Site) NetworkStatusUpdated(network []skupperv2alpha1.SiteRecord) error
 		}
 	}
 
+	if len(s.policies) > 0 {
+		s.updateAccessPolicyStatus()
+	}
+
 	bindingStatus := newBindingStatus(s.clients, network)
 	s.bindings.Map(bindingStatus.updateMatchingListenerCount, bindingStatus.updateMatchingConnectorCount)
 	s.logger.Debug("Updating matching listeners for attached connectors")
@@ -1294,6 +1403,11 @@ func (s *Site) CheckRouterAccess(name string, la *skupperv2alpha1.RouterAccess)
 		if la != nil && la.SetConfigured(err) {
 			s.updateRouterAccessStatus(la)
 		}
+		if err := s.updateLinkRestrictions(); err != nil {
+			s.logger.Error("Error updating link restrictions",
+				slog.String("namespace", s.namespace),
+				slog.Any("error", err))
+		}
 	}
 	return s.updateResolved()
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/skupperproject/skupper/internal/kube/certificates"
 	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	kubeqdr "github.com/skupperproject/skupper/internal/kube/qdr"
 	"github.com/skupperproject/skupper/internal/kube/securedaccess"
 	"github.com/skupperproject/skupper/internal/kube/watchers"
 	"github.com/skupperproject/skupper/internal/qdr"
@@ -15,6 +16,7 @@ import (
 	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
 	"gotest.tools/v3/assert"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -1014,6 +1016,88 @@ func Test_CheckSecuredAccess(t *testing.T) {
 	}
 }
 
+type addTcpListener qdr.TcpEndpoint
+
+func (l addTcpListener) Apply(config *qdr.RouterConfig) bool {
+	if _, ok := config.Bridges.TcpListeners[l.Name]; ok {
+		return false
+	}
+	config.AddTcpListener(qdr.TcpEndpoint(l))
+	return true
+}
+
+func TestSite_DryRun(t *testing.T) {
+	s, err := newSiteMocks("test", nil, nil, "", false)
+	assert.Assert(t, err)
+	assert.Assert(t, createRouterConfigMock(s))
+	s.initialised = true
+	s.dryRun = true
+
+	getConfigMap := func() *corev1.ConfigMap {
+		cm, err := s.clients.GetKubeClient().CoreV1().ConfigMaps("test").Get(context.TODO(), "skupper-router", metav1.GetOptions{})
+		assert.Assert(t, err)
+		return cm
+	}
+	assert.Assert(t, s.updateRouterConfig(addTcpListener{Name: "backend", Address: "backend", Port: "1024"}))
+
+	cm := getConfigMap()
+	live, err := qdr.GetRouterConfigFromConfigMap(cm)
+	assert.Assert(t, err)
+	assert.Equal(t, len(live.Bridges.TcpListeners), 0)
+	pending, err := kubeqdr.GetPendingRouterConfig(cm)
+	assert.Assert(t, err)
+	assert.Assert(t, pending != nil)
+	diff := qdr.DiffRouterConfig(live, pending)
+	assert.DeepEqual(t, diff.Changes(), []qdr.ConfigChange{
+		{Action: qdr.ChangeAdd, Type: "tcpListener", Name: "backend", Detail: "address=backend port=1024"},
+	})
+
+	// further changes accumulate in the pending config
+	assert.Assert(t, s.updateRouterConfig(addTcpListener{Name: "db", Address: "db", Port: "1025"}))
+	pending, err = kubeqdr.GetPendingRouterConfig(getConfigMap())
+	assert.Assert(t, err)
+	assert.Equal(t, len(pending.Bridges.TcpListeners), 2)
+
+	// leaving dry-run mode applies the pending config
+	s.dryRun = false
+	assert.Assert(t, s.commitPendingRouterConfig())
+	cm = getConfigMap()
+	live, err = qdr.GetRouterConfigFromConfigMap(cm)
+	assert.Assert(t, err)
+	assert.Equal(t, len(live.Bridges.TcpListeners), 2)
+	_, ok := cm.Data[kubeqdr.PendingConfigKey]
+	assert.Assert(t, !ok)
+}
+
+func TestSite_RouterConfigDriftUpdated(t *testing.T) {
+	s, err := newSiteMocks("test", nil, nil, "", false)
+	assert.Assert(t, err)
+	change := qdr.ConfigChange{Action: qdr.ChangeAdd, Type: "tcpListener", Name: "backend"}
+
+	assert.Assert(t, s.RouterConfigDriftUpdated("skupper-router", nil))
+	assert.Assert(t, meta.FindStatusCondition(s.site.Status.Conditions, skupperv2alpha1.CONDITION_TYPE_SYNCHRONIZED) == nil)
+
+	assert.Assert(t, s.RouterConfigDriftUpdated("skupper-router", &kubeqdr.RouterConfigDrift{
+		Time:    "2024-01-01T00:00:00Z",
+		Changes: []qdr.ConfigChange{change},
+	}))
+	condition := meta.FindStatusCondition(s.site.Status.Conditions, skupperv2alpha1.CONDITION_TYPE_SYNCHRONIZED)
+	assert.Assert(t, condition != nil)
+	assert.Equal(t, condition.Status, metav1.ConditionFalse)
+	assert.Equal(t, condition.Message, "Configuration drift not corrected for router skupper-router at 2024-01-01T00:00:00Z (+ tcpListener backend)")
+
+	assert.Assert(t, s.RouterConfigDriftUpdated("skupper-router", &kubeqdr.RouterConfigDrift{
+		Time:      "2024-01-01T00:00:00Z",
+		Changes:   []qdr.ConfigChange{change},
+		Corrected: true,
+	}))
+	condition = meta.FindStatusCondition(s.site.Status.Conditions, skupperv2alpha1.CONDITION_TYPE_SYNCHRONIZED)
+	assert.Equal(t, condition.Status, metav1.ConditionTrue)
+	assert.Equal(t, condition.Message, "Configuration drift corrected for router skupper-router at 2024-01-01T00:00:00Z (+ tcpListener backend)")
+	// drift does not affect readiness
+	assert.Assert(t, meta.FindStatusCondition(s.site.Status.Conditions, skupperv2alpha1.CONDITION_TYPE_READY) == nil)
+}
+
 // --- helper
 
 func newSiteMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string, accessMgr bool) (*Site, error) {
@@ -1039,19 +1123,22 @@ func newSiteMocks(namespace string, k8sObjects []runtime.Object, skupperObjects
 
 	controller := watchers.NewEventProcessor("test", client)
 	newSite := &Site{
-		clients:    controller,
-		bindings:   NewExtendedBindings(controller, ""),
-		links:      make(map[string]*site1.Link),
-		errors:     make(map[string]string),
-		linkAccess: make(map[string]*skupperv2alpha1.RouterAccess),
-		certs:      certificates.NewCertificateManager(controller),
-		access:     securedaccess.NewSecuredAccessManager(client, nil, &securedaccess.Config{DefaultAccessType: "loadbalancer"}, nil),
-		routerPods: make(map[string]*corev1.Pod),
+		clients:     controller,
+		bindings:    NewExtendedBindings(controller, ""),
+		links:       make(map[string]*site1.Link),
+		policies:    site1.AccessPolicies{},
+		linkOrigins: make(map[string]skupperv2alpha1.GrantRecipient),
+		errors:      make(map[string]string),
+		linkAccess:  make(map[string]*skupperv2alpha1.RouterAccess),
+		certs:       certificates.NewCertificateManager(controller),
+		access:      securedaccess.NewSecuredAccessManager(client, nil, &securedaccess.Config{DefaultAccessType: "loadbalancer"}, nil),
+		routerPods:  make(map[string]*corev1.Pod),
 		logger: slog.New(slog.Default().Handler()).With(
 			slog.String("component", "kube.site.site"),
 		),
 	}
 	newSite.bindings.init(NewMockBindingContext(map[string]TargetSelection{}), &qdr.RouterConfig{})
+	newSite.bindings.SetAccessPolicies(newSite.policies)
 
 	newSite.site = site
 	newSite.name = site.ObjectMeta.Name
@@ -0,0 +1,244 @@
+package site
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	"github.com/skupperproject/skupper/internal/kube/site/resources"
+	"github.com/skupperproject/skupper/internal/kube/site/sizing"
+	internalnetwork "github.com/skupperproject/skupper/internal/network"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+)
+
+const (
+	defaultRouterDrainPeriod = 30 * time.Second
+	routerUpgradeRecheck     = 5 * time.Second
+)
+
+// Removing the application label from the router pods of a group takes
+// them out of the services for listeners (and of the local service),
+// so that new connections go to the other group, while established
+// ones carry on until the router restarts.
+const (
+	drainingPatch   = `{"metadata":{"labels":{"application":null,"skupper.io/draining":"true"}}}`
+	undrainingPatch = `{"metadata":{"labels":{"application":"skupper-router","skupper.io/draining":null}}}`
+)
+
+// routerUpgrade tracks the router group of an HA site being restarted.
+type routerUpgrade struct {
+	group         string
+	drainingSince time.Time
+	applied       bool
+}
+
+// applyRouterResources applies the resources for each router group. For
+// an HA site, a group whose router needs to be restarted is only
+// updated once the routers of the other group are ready with their
+// links operational, and after having been drained. Only one group is
+// restarted at a time.
+func (s *Site) applyRouterResources(ctxt context.Context, size sizing.Sizing) error {
+	groups := s.groups()
+	if len(groups) < 2 {
+		s.upgrade = nil
+		for _, group := range groups {
+			if err := resources.Apply(s.clients, ctxt, s.site, group, size, s.labelling); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if s.upgrade != nil && s.upgrade.applied {
+		done, err := resources.RolledOut(s.clients, ctxt, s.namespace, s.upgrade.group)
+		if err != nil {
+			return err
+		}
+		if done {
+			s.logger.Info("Router group restarted",
+				slog.String("namespace", s.namespace),
+				slog.String("group", s.upgrade.group))
+			s.upgrade = nil
+		}
+	}
+	pending := false
+	for _, group := range groups {
+		if s.upgrade == nil || s.upgrade.group != group || !s.upgrade.applied {
+			restart, err := resources.RestartRequired(s.clients, ctxt, s.site, group, size)
+			if err != nil {
+				return err
+			}
+			if restart {
+				pending = true
+				if !s.readyToRestart(group, groups) {
+					continue
+				}
+			} else if s.upgrade != nil && s.upgrade.group == group {
+				// change no longer requires a restart
+				s.patchRouterGroup(group, undrainingPatch)
+				s.upgrade = nil
+			}
+		}
+		if err := resources.Apply(s.clients, ctxt, s.site, group, size, s.labelling); err != nil {
+			return err
+		}
+		if s.upgrade != nil && s.upgrade.group == group && !s.upgrade.applied {
+			s.logger.Info("Restarting router group",
+				slog.String("namespace", s.namespace),
+				slog.String("group", group))
+			s.upgrade.applied = true
+			s.setUpgradeCondition(skupperv2alpha1.PendingCondition(fmt.Sprintf("Restarting router group %s", group)))
+		}
+	}
+	if s.upgrade != nil || pending {
+		s.scheduleUpgradeCheck()
+	} else {
+		s.setUpgradeCondition(skupperv2alpha1.ReadyCondition())
+	}
+	return nil
+}
+
+// readyToRestart moves the upgrade of a group requiring a restart
+// along, returning true once it can be restarted.
+func (s *Site) readyToRestart(group string, groups []string) bool {
+	if s.upgrade != nil && s.upgrade.group != group {
+		return false
+	}
+	if s.upgrade == nil {
+		for _, other := range groups {
+			if other == group {
+				continue
+			}
+			if reason := s.routerGroupNotReady(other); reason != "" {
+				s.setUpgradeCondition(skupperv2alpha1.PendingCondition(fmt.Sprintf("Router group %s waiting for %s", group, reason)))
+				return false
+			}
+		}
+		s.logger.Info("Draining router group",
+			slog.String("namespace", s.namespace),
+			slog.String("group", group))
+		s.upgrade = &routerUpgrade{
+			group:         group,
+			drainingSince: time.Now(),
+		}
+		s.patchRouterGroup(group, drainingPatch)
+		s.setUpgradeCondition(skupperv2alpha1.PendingCondition(fmt.Sprintf("Draining router group %s", group)))
+	}
+	return time.Since(s.upgrade.drainingSince) >= s.routerDrainPeriod()
+}
+
+// routerGroupNotReady returns the reason for which the routers of a
+// group cannot yet take over the traffic of another group, or an empty
+// string if they can.
+func (s *Site) routerGroupNotReady(group string) string {
+	pods := s.routerPodsForGroup(group)
+	if len(pods) == 0 {
+		return fmt.Sprintf("router group %s to be running", group)
+	}
+	for _, pod := range pods {
+		if !isPodRunning(pod) || !isPodReady(pod) || pod.ObjectMeta.Labels["skupper.io/draining"] == "true" {
+			return fmt.Sprintf("router %s to be ready", pod.Name)
+		}
+	}
+	var notOperational []string
+	records := internalnetwork.GetLinkRecordsForSite(s.site.GetSiteId(), s.site.Status.Network)
+	for name := range s.links {
+		for _, pod := range pods {
+			if !isLinkOperational(records, name, pod.Name) {
+				notOperational = append(notOperational, name)
+				break
+			}
+		}
+	}
+	if len(notOperational) > 0 {
+		return fmt.Sprintf("links of router group %s to be operational (%s)", group, strings.Join(notOperational, ", "))
+	}
+	return ""
+}
+
+func isLinkOperational(records []skupperv2alpha1.LinkRecord, name string, router string) bool {
+	for _, record := range records {
+		if record.Name == name && record.Router == router {
+			return record.Operational
+		}
+	}
+	return false
+}
+
+func (s *Site) routerPodsForGroup(group string) []*corev1.Pod {
+	var pods []*corev1.Pod
+	for _, pod := range s.routerPods {
+		if pod.ObjectMeta.Labels["skupper.io/group"] == group {
+			pods = append(pods, pod)
+		}
+	}
+	return pods
+}
+
+func (s *Site) patchRouterGroup(group string, patch string) {
+	for _, pod := range s.routerPodsForGroup(group) {
+		_, err := s.clients.GetKubeClient().CoreV1().Pods(pod.Namespace).Patch(context.TODO(), pod.Name, k8stypes.MergePatchType, []byte(patch), metav1.PatchOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			s.logger.Error("Error updating draining labels of router pod",
+				slog.String("namespace", pod.Namespace),
+				slog.String("name", pod.Name),
+				slog.Any("error", err))
+		}
+	}
+}
+
+func (s *Site) routerDrainPeriod() time.Duration {
+	if value, ok := s.site.Spec.Settings["router-drain-period"]; ok {
+		if period, err := time.ParseDuration(value); err == nil {
+			return period
+		}
+	}
+	return defaultRouterDrainPeriod
+}
+
+func (s *Site) scheduleUpgradeCheck() {
+	if s.upgradeCheckScheduled {
+		return
+	}
+	s.upgradeCheckScheduled = true
+	s.clients.CallbackAfter(routerUpgradeRecheck, s.checkRouterUpgrade, s.namespace)
+}
+
+func (s *Site) checkRouterUpgrade(string) error {
+	s.upgradeCheckScheduled = false
+	if s.site == nil || !s.initialised {
+		return nil
+	}
+	size, err := s.sizes.GetSizing(s.site)
+	if err != nil {
+		s.logger.Info("Did not retrieve size for site",
+			slog.String("namespace", s.site.Namespace),
+			slog.String("name", s.site.Name),
+			slog.String("reason", err.Error()),
+		)
+	}
+	return s.applyRouterResources(context.TODO(), size)
+}
+
+// setUpgradeCondition only records the condition on sites for which an
+// upgrade has been coordinated.
+func (s *Site) setUpgradeCondition(state skupperv2alpha1.ConditionState) {
+	if state.Status == metav1.ConditionTrue && meta.FindStatusCondition(s.site.Status.Conditions, skupperv2alpha1.CONDITION_TYPE_UPGRADED) == nil {
+		return
+	}
+	if s.site.SetUpgraded(state) {
+		if err := s.updateSiteStatus(); err != nil {
+			s.logger.Error("Error updating site status",
+				slog.String("namespace", s.namespace),
+				slog.String("name", s.site.Name),
+				slog.Any("error", err))
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package site
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/skupperproject/skupper/internal/kube/resource"
+	"github.com/skupperproject/skupper/internal/kube/site/resources"
+	"github.com/skupperproject/skupper/internal/kube/site/sizing"
+	site1 "github.com/skupperproject/skupper/internal/site"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+)
+
+func routerPod(name string, group string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test",
+			Labels: map[string]string{
+				"application":          "skupper-router",
+				"skupper.io/component": "router",
+				"skupper.io/group":     group,
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			Conditions: []corev1.PodCondition{
+				{
+					Type:   corev1.PodReady,
+					Status: corev1.ConditionTrue,
+				},
+			},
+		},
+	}
+}
+
+func TestSite_applyRouterResources(t *testing.T) {
+	pods := []*corev1.Pod{
+		routerPod("router-a", "skupper-router"),
+		routerPod("router-b", "skupper-router-2"),
+	}
+	s, err := newSiteMocks("test", []runtime.Object{pods[0], pods[1]}, nil, "", false)
+	assert.Assert(t, err)
+	s.initialised = true
+	s.site.Spec.HA = true
+	s.site.Spec.Settings = map[string]string{"router-drain-period": "0s"}
+	for _, pod := range pods {
+		s.routerPods[pod.Name] = pod
+	}
+	s.links["link1"] = site1.NewLink("link1", "/etc/skupper-router-certs")
+	ctxt := context.TODO()
+	size := sizing.Sizing{}
+
+	// deploy both groups, then change a setting requiring a restart
+	handleApply(t, s)
+	assert.Assert(t, s.applyRouterResources(ctxt, size))
+	assert.Assert(t, meta.FindStatusCondition(s.site.Status.Conditions, skupperv2alpha1.CONDITION_TYPE_UPGRADED) == nil)
+	s.site.Spec.Edge = true
+	for _, group := range s.groups() {
+		restart, err := resources.RestartRequired(s.clients, ctxt, s.site, group, size)
+		assert.Assert(t, err)
+		assert.Assert(t, restart)
+	}
+
+	// the links of the other group are not operational yet
+	assert.Assert(t, s.applyRouterResources(ctxt, size))
+	condition := meta.FindStatusCondition(s.site.Status.Conditions, skupperv2alpha1.CONDITION_TYPE_UPGRADED)
+	assert.Assert(t, condition != nil)
+	assert.Equal(t, condition.Message, "Router group skupper-router-2 waiting for links of router group skupper-router to be operational (link1)")
+	assert.Assert(t, s.upgrade == nil)
+
+	s.site.Status.Network = []skupperv2alpha1.SiteRecord{
+		{
+			Id: s.site.GetSiteId(),
+			Links: []skupperv2alpha1.LinkRecord{
+				{Name: "link1", Router: "router-a", Operational: true},
+				{Name: "link1", Router: "router-b", Operational: true},
+			},
+		},
+	}
+	assert.Assert(t, s.applyRouterResources(ctxt, size))
+	assert.Assert(t, s.upgrade != nil)
+	first := s.upgrade.group
+	assert.Assert(t, s.upgrade.applied)
+	condition = meta.FindStatusCondition(s.site.Status.Conditions, skupperv2alpha1.CONDITION_TYPE_UPGRADED)
+	assert.Equal(t, condition.Message, "Restarting router group "+first)
+	drained, err := s.clients.GetKubeClient().CoreV1().Pods("test").Get(ctxt, s.routerPodsForGroup(first)[0].Name, metav1.GetOptions{})
+	assert.Assert(t, err)
+	assert.Equal(t, drained.ObjectMeta.Labels["skupper.io/draining"], "true")
+	_, ok := drained.ObjectMeta.Labels["application"]
+	assert.Assert(t, !ok)
+
+	// the other group is not restarted until the first has rolled out
+	var second string
+	for _, group := range s.groups() {
+		if group != first {
+			second = group
+		}
+	}
+	restart, err := resources.RestartRequired(s.clients, ctxt, s.site, second, size)
+	assert.Assert(t, err)
+	assert.Assert(t, restart)
+	assert.Assert(t, s.applyRouterResources(ctxt, size))
+	assert.Equal(t, s.upgrade.group, first)
+
+	markRolledOut(t, s, first)
+	assert.Assert(t, s.applyRouterResources(ctxt, size))
+	assert.Equal(t, s.upgrade.group, second)
+	assert.Assert(t, s.upgrade.applied)
+
+	markRolledOut(t, s, second)
+	assert.Assert(t, s.applyRouterResources(ctxt, size))
+	assert.Assert(t, s.upgrade == nil)
+	condition = meta.FindStatusCondition(s.site.Status.Conditions, skupperv2alpha1.CONDITION_TYPE_UPGRADED)
+	assert.Equal(t, condition.Status, metav1.ConditionTrue)
+}
+
+// handleApply makes the fake dynamic client treat an apply patch as a
+// replacement of the resource, which it does not otherwise support.
+func handleApply(t *testing.T, s *Site) {
+	client := s.clients.GetDynamicClient().(*dynamicfake.FakeDynamicClient)
+	client.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patch := action.(k8stesting.PatchAction)
+		obj := &unstructured.Unstructured{}
+		assert.Assert(t, json.Unmarshal(patch.GetPatch(), &obj.Object))
+		gvr := patch.GetResource()
+		if existing, err := client.Tracker().Get(gvr, patch.GetNamespace(), patch.GetName()); err == nil {
+			obj.Object["status"] = existing.(*unstructured.Unstructured).Object["status"]
+			return true, obj, client.Tracker().Update(gvr, obj, patch.GetNamespace())
+		}
+		return true, obj, client.Tracker().Create(gvr, obj, patch.GetNamespace())
+	})
+}
+
+func markRolledOut(t *testing.T, s *Site, group string) {
+	deployments := s.clients.GetDynamicClient().Resource(resource.DeploymentResource()).Namespace("test")
+	deployment, err := deployments.Get(context.TODO(), group, metav1.GetOptions{})
+	assert.Assert(t, err)
+	deployment.Object["status"] = map[string]interface{}{
+		"replicas":          int64(1),
+		"updatedReplicas":   int64(1),
+		"availableReplicas": int64(1),
+	}
+	_, err = deployments.Update(context.TODO(), deployment, metav1.UpdateOptions{})
+	assert.Assert(t, err)
+	// the new router pods are not draining
+	for _, pod := range s.routerPodsForGroup(group) {
+		s.routerPods[pod.Name] = routerPod(pod.Name, group)
+	}
+}
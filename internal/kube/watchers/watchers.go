@@ -31,6 +31,7 @@ import (
 	routev1informer "github.com/openshift/client-go/route/informers/externalversions/route/v1"
 
 	internalclient "github.com/skupperproject/skupper/internal/kube/client"
+	"github.com/skupperproject/skupper/internal/kube/metrics"
 	"github.com/skupperproject/skupper/internal/kube/resource"
 	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
 	skupperclient "github.com/skupperproject/skupper/pkg/generated/client/clientset/versioned"
@@ -42,9 +43,11 @@ import (
 // EventProcessor's work queue. Each ResourceChange event has a key
 // that identifies the resource along with an implementation of the
 // ResourceChangeHandler interface that will be used when processing
-// the event.
+// the event. The kind of the resource is used to label the
+// reconciliation metrics.
 type ResourceChange struct {
 	Handler ResourceChangeHandler
+	Kind    string
 	Key     string
 }
 
@@ -169,7 +172,9 @@ func (c *EventProcessor) process() bool {
 	retry := false
 	defer c.queue.Done(obj)
 	if evt, ok := obj.(ResourceChange); ok {
+		start := time.Now()
 		err := evt.Handler.Handle(evt)
+		metrics.ReconcileObserved(evt.Kind, time.Since(start), err)
 		if err != nil {
 			retry = true
 			log.Printf("[%s] Error while handling %s: %s", c.errorKey, evt.Handler.Describe(evt), err)
@@ -195,9 +200,10 @@ func (c *EventProcessor) Stop() {
 // Creates an event handler that will take handle events from an
 // informer by constructing an appropriate ResourceChange instance and
 // adding it to the EventProcessor's work queue.
-func (c *EventProcessor) newEventHandler(handler ResourceChangeHandler) *cache.ResourceEventHandlerFuncs {
+func (c *EventProcessor) newEventHandler(kind string, handler ResourceChangeHandler) *cache.ResourceEventHandlerFuncs {
 	evt := ResourceChange{
 		Handler: handler,
+		Kind:    kind,
 	}
 	return &cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
@@ -267,7 +273,7 @@ func (c *EventProcessor) WatchConfigMaps(options internalinterfaces.TweakListOpt
 		namespace: namespace,
 	}
 
-	watcher.informer.AddEventHandler(c.newEventHandler(watcher))
+	watcher.informer.AddEventHandler(c.newEventHandler("ConfigMap", watcher))
 	c.addWatcher(watcher)
 	return watcher
 }
@@ -339,7 +345,7 @@ func (c *EventProcessor) WatchSecrets(options internalinterfaces.TweakListOption
 		namespace: namespace,
 	}
 
-	watcher.informer.AddEventHandler(c.newEventHandler(watcher))
+	watcher.informer.AddEventHandler(c.newEventHandler("Secret", watcher))
 	c.addWatcher(watcher)
 	return watcher
 }
@@ -356,7 +362,7 @@ func (c *EventProcessor) WatchAllSecrets(namespace string, handler SecretHandler
 		namespace: namespace,
 	}
 
-	watcher.informer.AddEventHandler(c.newEventHandler(watcher))
+	watcher.informer.AddEventHandler(c.newEventHandler("Secret", watcher))
 	c.addWatcher(watcher)
 	return watcher
 }
@@ -429,7 +435,7 @@ func (c *EventProcessor) WatchServices(options internalinterfaces.TweakListOptio
 		namespace: namespace,
 	}
 
-	watcher.informer.AddEventHandler(c.newEventHandler(watcher))
+	watcher.informer.AddEventHandler(c.newEventHandler("Service", watcher))
 	c.addWatcher(watcher)
 	return watcher
 }
@@ -503,7 +509,7 @@ func (c *EventProcessor) WatchPods(selector string, namespace string, handler Po
 		namespace: namespace,
 	}
 
-	watcher.informer.AddEventHandler(c.newEventHandler(watcher))
+	watcher.informer.AddEventHandler(c.newEventHandler("Pod", watcher))
 	c.addWatcher(watcher)
 	return watcher
 }
@@ -596,7 +602,7 @@ func (c *EventProcessor) WatchDynamic(resource schema.GroupVersionResource, opti
 		resource:  resource,
 	}
 
-	watcher.informer.AddEventHandler(c.newEventHandler(watcher))
+	watcher.informer.AddEventHandler(c.newEventHandler("Dynamic", watcher))
 	c.addWatcher(watcher)
 	return watcher
 }
@@ -681,6 +687,7 @@ func (c *EventProcessor) CallbackAfter(delay time.Duration, callback Callback, c
 			callback: callback,
 			context:  context,
 		},
+		Kind: "Callback",
 	}
 	c.queue.AddAfter(evt, delay)
 }
@@ -695,7 +702,7 @@ func (c *EventProcessor) WatchNamespaces(options internalinterfaces.TweakListOpt
 			options),
 	}
 
-	watcher.informer.AddEventHandler(c.newEventHandler(watcher))
+	watcher.informer.AddEventHandler(c.newEventHandler("Namespace", watcher))
 	c.addWatcher(watcher)
 	return watcher
 }
@@ -760,7 +767,7 @@ func (c *EventProcessor) WatchNodes(handler NodeHandler) *NodeWatcher {
 			cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}),
 	}
 
-	watcher.informer.AddEventHandler(c.newEventHandler(watcher))
+	watcher.informer.AddEventHandler(c.newEventHandler("Node", watcher))
 	c.addWatcher(watcher)
 	return watcher
 }
@@ -826,7 +833,7 @@ func (c *EventProcessor) WatchSites(namespace string, handler SiteHandler) *Site
 			cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}),
 		namespace: namespace,
 	}
-	watcher.informer.AddEventHandler(c.newEventHandler(watcher))
+	watcher.informer.AddEventHandler(c.newEventHandler("Site", watcher))
 	c.addWatcher(watcher)
 	return watcher
 }
@@ -893,7 +900,7 @@ func (c *EventProcessor) WatchListeners(namespace string, handler ListenerHandle
 			cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}),
 		namespace: namespace,
 	}
-	watcher.informer.AddEventHandler(c.newEventHandler(watcher))
+	watcher.informer.AddEventHandler(c.newEventHandler("Listener", watcher))
 	c.addWatcher(watcher)
 	return watcher
 }
@@ -960,7 +967,7 @@ func (c *EventProcessor) WatchConnectors(namespace string, handler ConnectorHand
 			cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}),
 		namespace: namespace,
 	}
-	watcher.informer.AddEventHandler(c.newEventHandler(watcher))
+	watcher.informer.AddEventHandler(c.newEventHandler("Connector", watcher))
 	c.addWatcher(watcher)
 	return watcher
 }
@@ -1027,7 +1034,7 @@ func (c *EventProcessor) WatchLinks(namespace string, handler LinkHandler) *Link
 			cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}),
 		namespace: namespace,
 	}
-	watcher.informer.AddEventHandler(c.newEventHandler(watcher))
+	watcher.informer.AddEventHandler(c.newEventHandler("Link", watcher))
 	c.addWatcher(watcher)
 	return watcher
 }
@@ -1094,7 +1101,7 @@ func (c *EventProcessor) WatchAccessTokens(namespace string, handler AccessToken
 			cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}),
 		namespace: namespace,
 	}
-	watcher.informer.AddEventHandler(c.newEventHandler(watcher))
+	watcher.informer.AddEventHandler(c.newEventHandler("AccessToken", watcher))
 	c.addWatcher(watcher)
 	return watcher
 }
@@ -1161,7 +1168,7 @@ func (c *EventProcessor) WatchAccessGrants(namespace string, handler AccessGrant
 			cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}),
 		namespace: namespace,
 	}
-	watcher.informer.AddEventHandler(c.newEventHandler(watcher))
+	watcher.informer.AddEventHandler(c.newEventHandler("AccessGrant", watcher))
 	c.addWatcher(watcher)
 	return watcher
 }
@@ -1228,7 +1235,7 @@ func (c *EventProcessor) WatchSecuredAccesses(namespace string, handler SecuredA
 			cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}),
 		namespace: namespace,
 	}
-	watcher.informer.AddEventHandler(c.newEventHandler(watcher))
+	watcher.informer.AddEventHandler(c.newEventHandler("SecuredAccess", watcher))
 	c.addWatcher(watcher)
 	return watcher
 }
@@ -1244,7 +1251,7 @@ func (c *EventProcessor) WatchSecuredAccessesWithOptions(options skupperv2alpha1
 			options),
 		namespace: namespace,
 	}
-	watcher.informer.AddEventHandler(c.newEventHandler(watcher))
+	watcher.informer.AddEventHandler(c.newEventHandler("SecuredAccess", watcher))
 	c.addWatcher(watcher)
 	return watcher
 }
@@ -1313,7 +1320,7 @@ func (c *EventProcessor) WatchIngresses(options internalinterfaces.TweakListOpti
 		namespace: namespace,
 	}
 
-	watcher.informer.AddEventHandler(c.newEventHandler(watcher))
+	watcher.informer.AddEventHandler(c.newEventHandler("Ingress", watcher))
 	c.addWatcher(watcher)
 	return watcher
 }
@@ -1385,7 +1392,7 @@ func (c *EventProcessor) WatchRoutes(options routev1interfaces.TweakListOptionsF
 		namespace: namespace,
 	}
 
-	watcher.informer.AddEventHandler(c.newEventHandler(watcher))
+	watcher.informer.AddEventHandler(c.newEventHandler("Route", watcher))
 	c.addWatcher(watcher)
 	return watcher
 }
@@ -1452,7 +1459,7 @@ func (c *EventProcessor) WatchCertificates(namespace string, handler Certificate
 			cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}),
 		namespace: namespace,
 	}
-	watcher.informer.AddEventHandler(c.newEventHandler(watcher))
+	watcher.informer.AddEventHandler(c.newEventHandler("Certificate", watcher))
 	c.addWatcher(watcher)
 	return watcher
 }
@@ -1519,7 +1526,7 @@ func (c *EventProcessor) WatchRouterAccesses(namespace string, handler RouterAcc
 			cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}),
 		namespace: namespace,
 	}
-	watcher.informer.AddEventHandler(c.newEventHandler(watcher))
+	watcher.informer.AddEventHandler(c.newEventHandler("RouterAccess", watcher))
 	c.addWatcher(watcher)
 	return watcher
 }
@@ -1598,7 +1605,7 @@ func (c *EventProcessor) WatchAttachedConnectorBindings(namespace string, handle
 			cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}),
 		namespace: namespace,
 	}
-	watcher.informer.AddEventHandler(c.newEventHandler(watcher))
+	watcher.informer.AddEventHandler(c.newEventHandler("AttachedConnectorBinding", watcher))
 	c.addWatcher(watcher)
 	return watcher
 }
@@ -1665,7 +1672,7 @@ func (c *EventProcessor) WatchAttachedConnectors(namespace string, handler Attac
 			cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}),
 		namespace: namespace,
 	}
-	watcher.informer.AddEventHandler(c.newEventHandler(watcher))
+	watcher.informer.AddEventHandler(c.newEventHandler("AttachedConnector", watcher))
 	c.addWatcher(watcher)
 	return watcher
 }
@@ -1734,3 +1741,70 @@ func FilterByNamespace[V any](match func(string) bool, handler func(string, V) e
 		return nil
 	}
 }
+
+func (c *EventProcessor) WatchAccessPolicies(namespace string, handler AccessPolicyHandler) *AccessPolicyWatcher {
+	watcher := &AccessPolicyWatcher{
+		handler: handler,
+		informer: skupperv2alpha1informer.NewAccessPolicyInformer(
+			c.skupperClient,
+			namespace,
+			time.Second*30,
+			cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}),
+		namespace: namespace,
+	}
+	watcher.informer.AddEventHandler(c.newEventHandler("AccessPolicy", watcher))
+	c.addWatcher(watcher)
+	return watcher
+}
+
+type AccessPolicyHandler func(string, *skupperv2alpha1.AccessPolicy) error
+
+type AccessPolicyWatcher struct {
+	handler   AccessPolicyHandler
+	informer  cache.SharedIndexInformer
+	namespace string
+}
+
+func (w *AccessPolicyWatcher) Handle(event ResourceChange) error {
+	obj, err := w.Get(event.Key)
+	if err != nil {
+		return err
+	}
+	return w.handler(event.Key, obj)
+}
+
+func (w *AccessPolicyWatcher) HasSynced() func() bool {
+	return w.informer.HasSynced
+}
+
+func (w *AccessPolicyWatcher) Describe(event ResourceChange) string {
+	return fmt.Sprintf("AccessPolicy %s", event.Key)
+}
+
+func (w *AccessPolicyWatcher) Start(stopCh <-chan struct{}) {
+	go w.informer.Run(stopCh)
+}
+
+func (w *AccessPolicyWatcher) Sync(stopCh <-chan struct{}) bool {
+	return cache.WaitForCacheSync(stopCh, w.informer.HasSynced)
+}
+
+func (w *AccessPolicyWatcher) Get(key string) (*skupperv2alpha1.AccessPolicy, error) {
+	entity, exists, err := w.informer.GetStore().GetByKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+	return entity.(*skupperv2alpha1.AccessPolicy), nil
+}
+
+func (w *AccessPolicyWatcher) List() []*skupperv2alpha1.AccessPolicy {
+	list := w.informer.GetStore().List()
+	results := []*skupperv2alpha1.AccessPolicy{}
+	for _, o := range list {
+		results = append(results, o.(*skupperv2alpha1.AccessPolicy))
+	}
+	return results
+}
@@ -366,7 +366,7 @@ func TestProcessRequeueLimit(t *testing.T) {
 	processor := NewEventProcessor("tester", client)
 	processor.queue = workqueue.NewNamedRateLimitingQueue(workqueue.NewItemFastSlowRateLimiter(0, time.Microsecond, 10), "testing")
 	stubHandler := stubErrResourceChangeHandler{}
-	eventsIn := processor.newEventHandler(&stubHandler)
+	eventsIn := processor.newEventHandler("Node", &stubHandler)
 	eventsIn.AddFunc(node("test"))
 	callCount := 0 // set upper bound on how long test will run
 	for processor.queue.Len() > 0 && callCount < 1_000 {
@@ -403,3 +403,18 @@ func makeHandler[T any](errStr string) (handler func(key string, obj *T) error,
 			return actual
 		}
 }
+
+func TestEventKind(t *testing.T) {
+	client, _ := fakeclient.NewFakeClient("test", nil, nil, "")
+	processor := NewEventProcessor("tester", client)
+	processor.newEventHandler("Site", &SiteWatcher{}).AddFunc(node("mysite"))
+	processor.CallbackAfter(0, func(string) error { return nil }, "test")
+
+	var kinds []string
+	for len(kinds) < 2 {
+		obj, _ := processor.queue.Get()
+		kinds = append(kinds, obj.(ResourceChange).Kind)
+		processor.queue.Done(obj)
+	}
+	assert.DeepEqual(t, kinds, []string{"Site", "Callback"})
+}
@@ -0,0 +1,214 @@
+package lint
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer/yaml"
+	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// Source identifies the document a resource was read from.
+type Source struct {
+	File     string
+	Document int
+}
+
+func (s Source) String() string {
+	if s.File == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s#%d", s.File, s.Document)
+}
+
+// Resource is a resource of the bundle along with its source.
+type Resource[T metav1.Object] struct {
+	Source Source
+	Object T
+}
+
+// Bundle holds every resource read from a set of files. Unlike a
+// SiteState, resources are not keyed by name, so that duplicates and
+// resources of several namespaces are preserved for linting.
+type Bundle struct {
+	Sites                     []Resource[*v2alpha1.Site]
+	Listeners                 []Resource[*v2alpha1.Listener]
+	Connectors                []Resource[*v2alpha1.Connector]
+	AttachedConnectorBindings []Resource[*v2alpha1.AttachedConnectorBinding]
+	Links                     []Resource[*v2alpha1.Link]
+	RouterAccesses            []Resource[*v2alpha1.RouterAccess]
+	AccessGrants              []Resource[*v2alpha1.AccessGrant]
+	AccessTokens              []Resource[*v2alpha1.AccessToken]
+	Certificates              []Resource[*v2alpha1.Certificate]
+	Secrets                   []Resource[*corev1.Secret]
+}
+
+// Load reads the resources held by the given files. Directories are
+// walked recursively for .yaml, .yml and .json files. Documents that can
+// not be decoded are reported as findings rather than failing the load.
+func Load(paths ...string) (*Bundle, []Finding, error) {
+	bundle := &Bundle{}
+	var findings []Finding
+	for _, p := range paths {
+		files, err := listFiles(p)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, file := range files {
+			f, err := os.Open(file)
+			if err != nil {
+				return nil, nil, err
+			}
+			findings = append(findings, bundle.Read(file, f)...)
+			f.Close()
+		}
+	}
+	return bundle, findings, nil
+}
+
+func listFiles(p string) ([]string, error) {
+	info, err := os.Stat(p)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{p}, nil
+	}
+	var files []string
+	err = filepath.WalkDir(p, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		switch strings.ToLower(filepath.Ext(file)) {
+		case ".yaml", ".yml", ".json":
+			files = append(files, file)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// Read adds the resources of a multi-document YAML or JSON stream to the
+// bundle. Resources that are neither skupper resources nor secrets are
+// ignored, as bundles often carry the applications as well.
+func (b *Bundle) Read(file string, r io.Reader) []Finding {
+	var findings []Finding
+	decoder := yamlutil.NewYAMLOrJSONDecoder(bufio.NewReader(r), 1024)
+	for document := 1; ; document++ {
+		source := Source{File: file, Document: document}
+		var rawObj runtime.RawExtension
+		if err := decoder.Decode(&rawObj); err != nil {
+			if !errors.Is(err, io.EOF) {
+				findings = append(findings, Finding{
+					Severity: SeverityError,
+					Rule:     RuleDecode,
+					File:     source.String(),
+					Message:  fmt.Sprintf("unable to decode document: %s", err),
+				})
+			}
+			return findings
+		}
+		if len(rawObj.Raw) == 0 {
+			continue
+		}
+		obj, gvk, err := yaml.NewDecodingSerializer(unstructured.UnstructuredJSONScheme).Decode(rawObj.Raw, nil, nil)
+		if err != nil {
+			findings = append(findings, Finding{
+				Severity: SeverityError,
+				Rule:     RuleDecode,
+				File:     source.String(),
+				Message:  fmt.Sprintf("unable to decode document: %s", err),
+			})
+			continue
+		}
+		content := obj.(runtime.Unstructured).UnstructuredContent()
+		var target metav1.Object
+		switch {
+		case gvk.GroupVersion() == v2alpha1.SchemeGroupVersion:
+			target, err = b.add(source, gvk.Kind, content)
+			if target == nil {
+				findings = append(findings, Finding{
+					Severity: SeverityWarning,
+					Rule:     RuleUnknownKind,
+					File:     source.String(),
+					Kind:     gvk.Kind,
+					Message:  fmt.Sprintf("kind %q is not a %s resource", gvk.Kind, v2alpha1.SchemeGroupVersion),
+				})
+				continue
+			}
+		case gvk.GroupVersion() == corev1.SchemeGroupVersion && gvk.Kind == "Secret":
+			target, err = decode(source, content, &corev1.Secret{}, &b.Secrets)
+		default:
+			continue
+		}
+		if err != nil {
+			findings = append(findings, Finding{
+				Severity:  SeverityError,
+				Rule:      RuleDecode,
+				File:      source.String(),
+				Kind:      gvk.Kind,
+				Namespace: target.GetNamespace(),
+				Name:      target.GetName(),
+				Message:   fmt.Sprintf("invalid %s: %s", gvk.Kind, err),
+			})
+		}
+	}
+}
+
+// decode converts the content into the given resource, adding it to the
+// list only if valid so that no other check runs against it.
+func decode[T metav1.Object](source Source, content map[string]interface{}, obj T, list *[]Resource[T]) (metav1.Object, error) {
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(content, obj); err != nil {
+		return obj, err
+	}
+	*list = append(*list, Resource[T]{Source: source, Object: obj})
+	return obj, nil
+}
+
+// add decodes a resource of the given kind into the bundle, returning nil
+// if the kind is not known.
+func (b *Bundle) add(source Source, kind string, content map[string]interface{}) (metav1.Object, error) {
+	switch kind {
+	case "Site":
+		return decode(source, content, &v2alpha1.Site{}, &b.Sites)
+	case "Listener":
+		return decode(source, content, &v2alpha1.Listener{}, &b.Listeners)
+	case "Connector":
+		return decode(source, content, &v2alpha1.Connector{}, &b.Connectors)
+	case "AttachedConnectorBinding":
+		return decode(source, content, &v2alpha1.AttachedConnectorBinding{}, &b.AttachedConnectorBindings)
+	case "Link":
+		return decode(source, content, &v2alpha1.Link{}, &b.Links)
+	case "RouterAccess":
+		return decode(source, content, &v2alpha1.RouterAccess{}, &b.RouterAccesses)
+	case "AccessGrant":
+		return decode(source, content, &v2alpha1.AccessGrant{}, &b.AccessGrants)
+	case "AccessToken":
+		return decode(source, content, &v2alpha1.AccessToken{}, &b.AccessTokens)
+	case "Certificate":
+		return decode(source, content, &v2alpha1.Certificate{}, &b.Certificates)
+	case "AttachedConnector", "SecuredAccess", "AccessPolicy":
+		// known kinds for which there is nothing to lint
+		return &metav1.PartialObjectMetadata{}, nil
+	}
+	return nil, nil
+}
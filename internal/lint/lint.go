@@ -0,0 +1,498 @@
+// Package lint validates bundles of skupper resources offline, without
+// access to a cluster or to a running site, so that they can be checked
+// before being applied, for instance in continuous integration.
+package lint
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	nonkubecommon "github.com/skupperproject/skupper/internal/nonkube/common"
+	"github.com/skupperproject/skupper/internal/site"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Rules identifying the check that produced a finding.
+const (
+	RuleDecode              = "decode"
+	RuleUnknownKind         = "unknown-kind"
+	RuleInvalidName         = "invalid-name"
+	RuleDuplicateResource   = "duplicate-resource"
+	RuleMultipleSites       = "multiple-sites"
+	RuleMissingSite         = "missing-site"
+	RuleInvalidSite         = "invalid-site"
+	RuleInvalidListener     = "invalid-listener"
+	RuleInvalidConnector    = "invalid-connector"
+	RuleInvalidRouterAccess = "invalid-router-access"
+	RuleInvalidLink         = "invalid-link"
+	RuleMissingSecret       = "missing-secret"
+	RuleUnmatchedRoutingKey = "unmatched-routing-key"
+	RuleDuplicatePort       = "duplicate-port"
+	RuleSiteState           = "site-state"
+)
+
+// Finding is an issue found in a bundle.
+type Finding struct {
+	Severity  Severity `json:"severity"`
+	Rule      string   `json:"rule"`
+	File      string   `json:"file,omitempty"`
+	Kind      string   `json:"kind,omitempty"`
+	Namespace string   `json:"namespace,omitempty"`
+	Name      string   `json:"name,omitempty"`
+	Message   string   `json:"message"`
+}
+
+// HasErrors returns true if any of the findings is an error.
+func HasErrors(findings []Finding) bool {
+	for _, finding := range findings {
+		if finding.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Lint runs the checks applicable to the given target platform against
+// the bundle: kubernetes, or one of podman, docker and linux for
+// non-kubernetes sites.
+func Lint(bundle *Bundle, platform string) []Finding {
+	l := &linter{
+		bundle:  bundle,
+		kube:    platform == "kubernetes",
+		secrets: map[string]bool{},
+	}
+	for _, secret := range bundle.Secrets {
+		l.secrets[key(secret.Object)] = true
+	}
+	l.checkSites()
+	l.checkListeners()
+	l.checkConnectors()
+	l.checkRouterAccesses()
+	l.checkLinks()
+	l.checkNames()
+	l.checkRoutingKeys()
+	l.checkPorts()
+	if !l.kube {
+		l.checkSiteStates()
+	}
+	return l.findings
+}
+
+type linter struct {
+	bundle   *Bundle
+	kube     bool
+	secrets  map[string]bool
+	findings []Finding
+}
+
+func key(obj metav1.Object) string {
+	return obj.GetNamespace() + "/" + obj.GetName()
+}
+
+func (l *linter) report(severity Severity, rule string, source Source, kind string, obj metav1.Object, format string, args ...interface{}) {
+	l.findings = append(l.findings, Finding{
+		Severity:  severity,
+		Rule:      rule,
+		File:      source.String(),
+		Kind:      kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		Message:   fmt.Sprintf(format, args...),
+	})
+}
+
+// namespaces returns the namespaces of the bundle that hold resources
+// depending on a site.
+func (l *linter) namespaces() map[string]Source {
+	namespaces := map[string]Source{}
+	add := func(source Source, obj metav1.Object) {
+		if _, ok := namespaces[obj.GetNamespace()]; !ok {
+			namespaces[obj.GetNamespace()] = source
+		}
+	}
+	for _, r := range l.bundle.Listeners {
+		add(r.Source, r.Object)
+	}
+	for _, r := range l.bundle.Connectors {
+		add(r.Source, r.Object)
+	}
+	for _, r := range l.bundle.Links {
+		add(r.Source, r.Object)
+	}
+	for _, r := range l.bundle.RouterAccesses {
+		add(r.Source, r.Object)
+	}
+	return namespaces
+}
+
+func (l *linter) checkSites() {
+	sites := map[string][]Resource[*v2alpha1.Site]{}
+	for _, r := range l.bundle.Sites {
+		sites[r.Object.Namespace] = append(sites[r.Object.Namespace], r)
+		if !l.kube && r.Object.Namespace != "" {
+			if err := nonkubecommon.ValidateName(r.Object.Namespace); err != nil {
+				l.report(SeverityError, RuleInvalidSite, r.Source, "Site", r.Object, "invalid namespace: %s", err)
+			}
+		}
+	}
+	for _, namespace := range sortedKeys(sites) {
+		resources := sites[namespace]
+		for _, r := range resources[1:] {
+			l.report(SeverityError, RuleMultipleSites, r.Source, "Site", r.Object,
+				"only one site is allowed per namespace, site %q is also defined in %s", resources[0].Object.Name, resources[0].Source)
+		}
+	}
+	namespaces := l.namespaces()
+	for _, namespace := range sortedKeys(namespaces) {
+		source := namespaces[namespace]
+		if _, ok := sites[namespace]; ok {
+			continue
+		}
+		// on kubernetes, the site may already exist in the cluster
+		severity := SeverityError
+		if l.kube {
+			severity = SeverityWarning
+		}
+		l.report(severity, RuleMissingSite, source, "", &metav1.ObjectMeta{Namespace: namespace},
+			"there is no site defined for namespace %q", namespace)
+	}
+}
+
+// validHost checks the host of a listener or connector. On kubernetes the
+// host of a listener is the name of the service created for it.
+func (l *linter) validHost(host string, service bool) error {
+	if l.kube && service {
+		if errs := validation.IsDNS1035Label(host); len(errs) > 0 {
+			return fmt.Errorf("invalid service name %q: %s", host, strings.Join(errs, ", "))
+		}
+		return nil
+	}
+	if net.ParseIP(host) == nil && len(validation.IsDNS1123Subdomain(host)) > 0 {
+		return fmt.Errorf("invalid host %q: a valid IP address or hostname is expected", host)
+	}
+	return nil
+}
+
+func validPort(port int) error {
+	if port < 1 || port > 65535 {
+		return fmt.Errorf("invalid port %d: must be between 1 and 65535", port)
+	}
+	return nil
+}
+
+func (l *linter) checkListeners() {
+	for _, r := range l.bundle.Listeners {
+		spec := r.Object.Spec
+		if spec.RoutingKey == "" {
+			l.report(SeverityError, RuleInvalidListener, r.Source, "Listener", r.Object, "routing key is required")
+		}
+		if spec.Host == "" {
+			l.report(SeverityError, RuleInvalidListener, r.Source, "Listener", r.Object, "host is required")
+		} else if err := l.validHost(spec.Host, true); err != nil {
+			l.report(SeverityError, RuleInvalidListener, r.Source, "Listener", r.Object, "%s", err)
+		}
+		if err := validPort(spec.Port); err != nil {
+			l.report(SeverityError, RuleInvalidListener, r.Source, "Listener", r.Object, "%s", err)
+		}
+		if err := site.ValidateBindingType(spec.Type); err != nil {
+			l.report(SeverityError, RuleInvalidListener, r.Source, "Listener", r.Object, "%s", err)
+		}
+	}
+}
+
+func (l *linter) checkConnectors() {
+	for _, r := range l.bundle.Connectors {
+		spec := r.Object.Spec
+		if spec.RoutingKey == "" {
+			l.report(SeverityError, RuleInvalidConnector, r.Source, "Connector", r.Object, "routing key is required")
+		}
+		switch {
+		case l.kube && spec.Host == "" && spec.Selector == "":
+			l.report(SeverityError, RuleInvalidConnector, r.Source, "Connector", r.Object, "host or selector is required")
+		case l.kube && spec.Host != "" && spec.Selector != "":
+			l.report(SeverityWarning, RuleInvalidConnector, r.Source, "Connector", r.Object, "both host and selector are set, host is ignored")
+		case !l.kube && spec.Host == "":
+			l.report(SeverityError, RuleInvalidConnector, r.Source, "Connector", r.Object, "host is required")
+		case !l.kube && spec.Selector != "":
+			l.report(SeverityWarning, RuleInvalidConnector, r.Source, "Connector", r.Object, "selector is only supported on kubernetes and is ignored")
+		}
+		if spec.Host != "" {
+			if err := l.validHost(spec.Host, false); err != nil {
+				l.report(SeverityError, RuleInvalidConnector, r.Source, "Connector", r.Object, "%s", err)
+			}
+		}
+		if err := validPort(spec.Port); err != nil {
+			l.report(SeverityError, RuleInvalidConnector, r.Source, "Connector", r.Object, "%s", err)
+		}
+		if err := site.ValidateBindingType(spec.Type); err != nil {
+			l.report(SeverityError, RuleInvalidConnector, r.Source, "Connector", r.Object, "%s", err)
+		}
+	}
+}
+
+func (l *linter) checkRouterAccesses() {
+	for _, r := range l.bundle.RouterAccesses {
+		if len(r.Object.Spec.Roles) == 0 {
+			l.report(SeverityError, RuleInvalidRouterAccess, r.Source, "RouterAccess", r.Object, "roles are required")
+		}
+		for _, role := range r.Object.Spec.Roles {
+			if role.Name != "edge" && role.Name != "inter-router" {
+				l.report(SeverityError, RuleInvalidRouterAccess, r.Source, "RouterAccess", r.Object, "invalid role %q (valid roles: [edge inter-router])", role.Name)
+			}
+			if role.Port != 0 {
+				if err := validPort(role.Port); err != nil {
+					l.report(SeverityError, RuleInvalidRouterAccess, r.Source, "RouterAccess", r.Object, "%s", err)
+				}
+			}
+		}
+	}
+}
+
+func (l *linter) checkLinks() {
+	for _, r := range l.bundle.Links {
+		spec := r.Object.Spec
+		if len(spec.Endpoints) == 0 {
+			l.report(SeverityError, RuleInvalidLink, r.Source, "Link", r.Object, "at least one endpoint is required")
+		}
+		if spec.TlsCredentials == "" {
+			l.report(SeverityError, RuleInvalidLink, r.Source, "Link", r.Object, "tlsCredentials is required")
+			continue
+		}
+		if l.secrets[r.Object.Namespace+"/"+spec.TlsCredentials] {
+			continue
+		}
+		// on kubernetes, the secret may already exist in the cluster
+		severity := SeverityError
+		if l.kube {
+			severity = SeverityWarning
+		}
+		l.report(severity, RuleMissingSecret, r.Source, "Link", r.Object, "secret %q is not defined", spec.TlsCredentials)
+	}
+}
+
+// checkNames validates the names of all resources and reports resources
+// of the same kind defined more than once in a namespace.
+func (l *linter) checkNames() {
+	seen := map[string]Source{}
+	check := func(kind string, source Source, obj metav1.Object) {
+		var err error
+		if l.kube {
+			if errs := validation.IsDNS1123Subdomain(obj.GetName()); len(errs) > 0 {
+				err = fmt.Errorf("invalid name %q: %s", obj.GetName(), strings.Join(errs, ", "))
+			}
+		} else {
+			err = nonkubecommon.ValidateName(obj.GetName())
+		}
+		if err != nil {
+			l.report(SeverityError, RuleInvalidName, source, kind, obj, "%s", err)
+		}
+		id := kind + "/" + key(obj)
+		if first, ok := seen[id]; ok {
+			l.report(SeverityError, RuleDuplicateResource, source, kind, obj, "%s %q is also defined in %s", kind, obj.GetName(), first)
+			return
+		}
+		seen[id] = source
+	}
+	for _, r := range l.bundle.Sites {
+		check("Site", r.Source, r.Object)
+	}
+	for _, r := range l.bundle.Listeners {
+		check("Listener", r.Source, r.Object)
+	}
+	for _, r := range l.bundle.Connectors {
+		check("Connector", r.Source, r.Object)
+	}
+	for _, r := range l.bundle.AttachedConnectorBindings {
+		check("AttachedConnectorBinding", r.Source, r.Object)
+	}
+	for _, r := range l.bundle.Links {
+		check("Link", r.Source, r.Object)
+	}
+	for _, r := range l.bundle.RouterAccesses {
+		check("RouterAccess", r.Source, r.Object)
+	}
+	for _, r := range l.bundle.AccessGrants {
+		check("AccessGrant", r.Source, r.Object)
+	}
+	for _, r := range l.bundle.AccessTokens {
+		check("AccessToken", r.Source, r.Object)
+	}
+	for _, r := range l.bundle.Certificates {
+		check("Certificate", r.Source, r.Object)
+	}
+	for _, r := range l.bundle.Secrets {
+		check("Secret", r.Source, r.Object)
+	}
+}
+
+// checkRoutingKeys reports listeners for which no connector in the whole
+// bundle, whatever its namespace, provides the routing key.
+func (l *linter) checkRoutingKeys() {
+	routingKeys := map[string]bool{}
+	for _, r := range l.bundle.Connectors {
+		routingKeys[r.Object.Spec.RoutingKey] = true
+	}
+	for _, r := range l.bundle.AttachedConnectorBindings {
+		routingKeys[r.Object.Spec.RoutingKey] = true
+	}
+	for _, r := range l.bundle.Listeners {
+		routingKey := r.Object.Spec.RoutingKey
+		if routingKey != "" && !routingKeys[routingKey] {
+			l.report(SeverityWarning, RuleUnmatchedRoutingKey, r.Source, "Listener", r.Object, "there is no connector for routing key %q", routingKey)
+		}
+	}
+}
+
+// checkPorts reports listeners using a port already in use in their
+// namespace. On kubernetes the host of a listener is a service, so only
+// listeners of the same service conflict. Elsewhere listeners and router
+// accesses are all bound on the host of the site. TCP and UDP ports do
+// not conflict with each other.
+func (l *linter) checkPorts() {
+	type binding struct {
+		source Source
+		kind   string
+		name   string
+	}
+	bound := map[string]binding{}
+	bind := func(namespace string, protocol corev1.Protocol, host string, port int, b binding) (binding, bool) {
+		scope := fmt.Sprintf("%s/%s", namespace, protocol)
+		id := fmt.Sprintf("%s/%s:%d", scope, host, port)
+		if !l.kube {
+			// an unspecified address conflicts with any other on the same port
+			if first, ok := bound[fmt.Sprintf("%s/%s:%d", scope, "0.0.0.0", port)]; ok {
+				return first, true
+			}
+			if host == "0.0.0.0" {
+				for other, first := range bound {
+					if strings.HasPrefix(other, scope+"/") && strings.HasSuffix(other, fmt.Sprintf(":%d", port)) {
+						return first, true
+					}
+				}
+			}
+		}
+		if first, ok := bound[id]; ok {
+			return first, true
+		}
+		bound[id] = b
+		return binding{}, false
+	}
+	if !l.kube {
+		for _, r := range l.bundle.RouterAccesses {
+			host := r.Object.Spec.BindHost
+			if host == "" {
+				host = "0.0.0.0"
+			}
+			for _, role := range r.Object.Spec.Roles {
+				port := int(role.GetPort())
+				if first, ok := bind(r.Object.Namespace, corev1.ProtocolTCP, host, port, binding{r.Source, "RouterAccess", r.Object.Name}); ok {
+					l.report(SeverityError, RuleDuplicatePort, r.Source, "RouterAccess", r.Object,
+						"port %d of role %q is already used by %s %q (%s)", port, role.Name, first.kind, first.name, first.source)
+				}
+			}
+		}
+	}
+	for _, r := range l.bundle.Listeners {
+		spec := r.Object.Spec
+		if spec.Port == 0 {
+			continue
+		}
+		if first, ok := bind(r.Object.Namespace, r.Object.Protocol(), spec.Host, spec.Port, binding{r.Source, "Listener", r.Object.Name}); ok {
+			l.report(SeverityError, RuleDuplicatePort, r.Source, "Listener", r.Object,
+				"port %d on host %q is already used by %s %q (%s)", spec.Port, spec.Host, first.kind, first.name, first.source)
+		}
+	}
+}
+
+// checkSiteStates runs the validation applied by "skupper system start"
+// against the resources of each namespace. As it stops at the first
+// error, it is only reported for namespaces where no other error was
+// found, to catch anything the checks above do not cover.
+func (l *linter) checkSiteStates() {
+	failed := map[string]bool{}
+	for _, finding := range l.findings {
+		if finding.Severity == SeverityError {
+			failed[finding.Namespace] = true
+		}
+	}
+	validator := &nonkubecommon.SiteStateValidator{}
+	for _, r := range l.bundle.Sites {
+		namespace := r.Object.Namespace
+		if failed[namespace] {
+			continue
+		}
+		siteState := l.siteState(r.Object)
+		if err := validator.Validate(siteState); err != nil {
+			l.report(SeverityError, RuleSiteState, r.Source, "Site", r.Object, "%s", err)
+		}
+	}
+}
+
+func (l *linter) siteState(site *v2alpha1.Site) *api.SiteState {
+	namespace := site.Namespace
+	siteState := api.NewSiteState(false)
+	siteState.Site = site
+	for _, r := range l.bundle.Listeners {
+		if r.Object.Namespace == namespace {
+			siteState.Listeners[r.Object.Name] = r.Object
+		}
+	}
+	for _, r := range l.bundle.Connectors {
+		if r.Object.Namespace == namespace {
+			siteState.Connectors[r.Object.Name] = r.Object
+		}
+	}
+	for _, r := range l.bundle.Links {
+		if r.Object.Namespace == namespace {
+			siteState.Links[r.Object.Name] = r.Object
+		}
+	}
+	for _, r := range l.bundle.RouterAccesses {
+		if r.Object.Namespace == namespace {
+			siteState.RouterAccesses[r.Object.Name] = r.Object
+		}
+	}
+	for _, r := range l.bundle.AccessGrants {
+		if r.Object.Namespace == namespace {
+			siteState.Grants[r.Object.Name] = r.Object
+		}
+	}
+	for _, r := range l.bundle.AccessTokens {
+		if r.Object.Namespace == namespace {
+			siteState.Claims[r.Object.Name] = r.Object
+		}
+	}
+	for _, r := range l.bundle.Certificates {
+		if r.Object.Namespace == namespace {
+			siteState.Certificates[r.Object.Name] = r.Object
+		}
+	}
+	for _, r := range l.bundle.Secrets {
+		if r.Object.Namespace == namespace {
+			siteState.Secrets[r.Object.Name] = r.Object
+		}
+	}
+	return siteState
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
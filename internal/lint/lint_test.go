@@ -0,0 +1,289 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+const siteDoc = `apiVersion: skupper.io/v2alpha1
+kind: Site
+metadata:
+  name: west
+  namespace: west
+`
+
+const listener = `apiVersion: skupper.io/v2alpha1
+kind: Listener
+metadata:
+  name: backend
+  namespace: west
+spec:
+  routingKey: backend
+  host: backend
+  port: 8080
+`
+
+const connector = `apiVersion: skupper.io/v2alpha1
+kind: Connector
+metadata:
+  name: backend
+  namespace: east
+spec:
+  routingKey: backend
+  host: backend.local
+  port: 8080
+`
+
+const link = `apiVersion: skupper.io/v2alpha1
+kind: Link
+metadata:
+  name: to-east
+  namespace: west
+spec:
+  tlsCredentials: to-east
+  endpoints:
+  - name: inter-router
+    host: 10.0.0.1
+    port: "55671"
+`
+
+const secret = `apiVersion: v1
+kind: Secret
+metadata:
+  name: to-east
+  namespace: west
+data:
+  tls.crt: Y2VydA==
+`
+
+func documents(docs ...string) string {
+	return strings.Join(docs, "---\n")
+}
+
+type expectedFinding struct {
+	severity Severity
+	rule     string
+	name     string
+	message  string
+}
+
+func TestLint(t *testing.T) {
+	eastSite := strings.ReplaceAll(siteDoc, "west", "east")
+	tests := []struct {
+		name     string
+		platform string
+		input    string
+		expected []expectedFinding
+	}{
+		{
+			name:     "valid bundle",
+			platform: "podman",
+			input:    documents(siteDoc, listener, link, secret, eastSite, connector),
+		},
+		{
+			name:     "valid bundle on kubernetes",
+			platform: "kubernetes",
+			input:    documents(siteDoc, listener, link, secret, eastSite, connector),
+		},
+		{
+			name:     "listener without connector",
+			platform: "podman",
+			input:    documents(siteDoc, listener),
+			expected: []expectedFinding{
+				{SeverityWarning, RuleUnmatchedRoutingKey, "backend", `there is no connector for routing key "backend"`},
+			},
+		},
+		{
+			name:     "link secret is missing",
+			platform: "podman",
+			input:    documents(siteDoc, link),
+			expected: []expectedFinding{
+				{SeverityError, RuleMissingSecret, "to-east", `secret "to-east" is not defined`},
+			},
+		},
+		{
+			name:     "link secret may exist in the cluster",
+			platform: "kubernetes",
+			input:    documents(siteDoc, link),
+			expected: []expectedFinding{
+				{SeverityWarning, RuleMissingSecret, "to-east", `secret "to-east" is not defined`},
+			},
+		},
+		{
+			name:     "duplicate listener port",
+			platform: "podman",
+			input:    documents(siteDoc, listener, strings.Replace(listener, "name: backend", "name: other", 1), eastSite, connector),
+			expected: []expectedFinding{
+				{SeverityError, RuleDuplicatePort, "other", `port 8080 on host "backend" is already used by Listener "backend" (test.yaml#2)`},
+			},
+		},
+		{
+			name:     "tcp and udp listeners share a port",
+			platform: "podman",
+			input:    documents(siteDoc, listener, strings.Replace(listener, "name: backend", "name: other", 1)+"  type: udp\n", eastSite, connector),
+		},
+		{
+			name:     "listener port used by router access",
+			platform: "podman",
+			input: documents(siteDoc, strings.Replace(listener, "port: 8080", "port: 55671", 1), eastSite, connector, `apiVersion: skupper.io/v2alpha1
+kind: RouterAccess
+metadata:
+  name: skupper-router
+  namespace: west
+spec:
+  roles:
+  - name: inter-router
+    port: 55671
+`),
+			expected: []expectedFinding{
+				{SeverityError, RuleDuplicatePort, "backend", `port 55671 on host "backend" is already used by RouterAccess "skupper-router" (test.yaml#5)`},
+			},
+		},
+		{
+			name:     "listener port is shared on kubernetes services",
+			platform: "kubernetes",
+			input:    documents(siteDoc, listener, strings.Replace(strings.Replace(listener, "name: backend", "name: other", 1), "host: backend", "host: other", 1), eastSite, connector),
+		},
+		{
+			name:     "duplicate resource",
+			platform: "podman",
+			input:    documents(siteDoc, listener, listener, eastSite, connector),
+			expected: []expectedFinding{
+				{SeverityError, RuleDuplicateResource, "backend", `Listener "backend" is also defined in test.yaml#2`},
+				{SeverityError, RuleDuplicatePort, "backend", `port 8080 on host "backend" is already used by Listener "backend" (test.yaml#2)`},
+			},
+		},
+		{
+			name:     "multiple sites",
+			platform: "podman",
+			input:    documents(siteDoc, strings.Replace(siteDoc, "name: west", "name: other", 1)),
+			expected: []expectedFinding{
+				{SeverityError, RuleMultipleSites, "other", `only one site is allowed per namespace, site "west" is also defined in test.yaml#1`},
+			},
+		},
+		{
+			name:     "missing site",
+			platform: "podman",
+			input:    documents(connector),
+			expected: []expectedFinding{
+				{SeverityError, RuleMissingSite, "", `there is no site defined for namespace "east"`},
+			},
+		},
+		{
+			name:     "invalid listener",
+			platform: "podman",
+			input:    documents(siteDoc, strings.Replace(strings.Replace(listener, "port: 8080", "port: 70000", 1), "routingKey: backend", "type: udp2", 1)),
+			expected: []expectedFinding{
+				{SeverityError, RuleInvalidListener, "backend", "routing key is required"},
+				{SeverityError, RuleInvalidListener, "backend", "invalid port 70000: must be between 1 and 65535"},
+				{SeverityError, RuleInvalidListener, "backend", `invalid type "udp2" (valid types: [tcp http http2 udp])`},
+			},
+		},
+		{
+			name:     "selector is only supported on kubernetes",
+			platform: "podman",
+			input:    documents(eastSite, strings.Replace(connector, "host: backend.local", "selector: app=backend", 1)),
+			expected: []expectedFinding{
+				{SeverityError, RuleInvalidConnector, "backend", "host is required"},
+			},
+		},
+		{
+			name:     "connector with selector",
+			platform: "kubernetes",
+			input:    documents(eastSite, strings.Replace(connector, "host: backend.local", "selector: app=backend", 1)),
+		},
+		{
+			name:     "invalid name",
+			platform: "podman",
+			input:    documents(siteDoc, strings.Replace(listener, "name: backend", "name: Backend", 1), eastSite, connector),
+			expected: []expectedFinding{
+				{SeverityError, RuleInvalidName, "Backend", `invalid name "Backend"`},
+			},
+		},
+		{
+			name:     "site state validation",
+			platform: "podman",
+			input: documents(siteDoc, `apiVersion: skupper.io/v2alpha1
+kind: RouterAccess
+metadata:
+  name: skupper-router
+  namespace: west
+spec:
+  tlsCredentials: Invalid
+  roles:
+  - name: inter-router
+`),
+			expected: []expectedFinding{
+				{SeverityError, RuleSiteState, "west", "invalid router access tls credentials"},
+			},
+		},
+		{
+			name:     "invalid field type",
+			platform: "podman",
+			input:    documents(eastSite, strings.Replace(connector, "port: 8080", "port: abc", 1)),
+			expected: []expectedFinding{
+				{SeverityError, RuleDecode, "backend", "invalid Connector"},
+			},
+		},
+		{
+			name:     "unknown kind",
+			platform: "podman",
+			input: documents(siteDoc, `apiVersion: skupper.io/v2alpha1
+kind: Gateway
+metadata:
+  name: gateway
+`),
+			expected: []expectedFinding{
+				{SeverityWarning, RuleUnknownKind, "", `kind "Gateway" is not a skupper.io/v2alpha1 resource`},
+			},
+		},
+		{
+			name:     "other resources are ignored",
+			platform: "podman",
+			input: documents(siteDoc, `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: backend
+`),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bundle := &Bundle{}
+			findings := bundle.Read("test.yaml", strings.NewReader(test.input))
+			findings = append(findings, Lint(bundle, test.platform)...)
+			assert.Equal(t, len(findings), len(test.expected), "%v", findings)
+			for i, expected := range test.expected {
+				assert.Equal(t, findings[i].Severity, expected.severity)
+				assert.Equal(t, findings[i].Rule, expected.rule)
+				assert.Equal(t, findings[i].Name, expected.name)
+				assert.Assert(t, strings.Contains(findings[i].Message, expected.message), findings[i].Message)
+			}
+			assert.Equal(t, HasErrors(findings), len(test.expected) > 0 && test.expected[0].severity == SeverityError)
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	assert.Assert(t, os.MkdirAll(filepath.Join(dir, "west"), 0755))
+	assert.Assert(t, os.WriteFile(filepath.Join(dir, "west", "site.yaml"), []byte(documents(siteDoc, listener)), 0644))
+	assert.Assert(t, os.WriteFile(filepath.Join(dir, "east.yml"), []byte(documents(strings.ReplaceAll(siteDoc, "west", "east"), connector)), 0644))
+	assert.Assert(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# not a resource"), 0644))
+
+	bundle, findings, err := Load(dir)
+	assert.Assert(t, err)
+	assert.Equal(t, len(findings), 0)
+	assert.Equal(t, len(bundle.Sites), 2)
+	assert.Equal(t, len(bundle.Listeners), 1)
+	assert.Equal(t, len(bundle.Connectors), 1)
+	assert.Equal(t, bundle.Listeners[0].Source.String(), filepath.Join(dir, "west", "site.yaml")+"#2")
+	assert.Equal(t, len(Lint(bundle, "podman")), 0)
+
+	_, _, err = Load(filepath.Join(dir, "missing"))
+	assert.Assert(t, os.IsNotExist(err))
+}
@@ -3,6 +3,7 @@ package network
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
@@ -214,6 +215,7 @@ func ExtractSiteRecords(status NetworkStatusInfo) []v2alpha1.SiteRecord {
 						RemoteSiteId:   site,
 						RemoteSiteName: siteNames[site],
 						Operational:    strings.EqualFold(link.Status, "up"),
+						Router:         router.Router.Hostname,
 					})
 				}
 			}
@@ -261,6 +263,29 @@ func GetLinkRecordsForSite(siteId string, network []v2alpha1.SiteRecord) []v2alp
 	return nil
 }
 
+// GetSitesLinkingTo returns the names of the sites with an operational
+// link to the given site, that is the sites that have linked in to it.
+func GetSitesLinkingTo(siteId string, network []v2alpha1.SiteRecord) []string {
+	var sites []string
+	for _, siteRecord := range network {
+		if siteRecord.Id == siteId {
+			continue
+		}
+		for _, link := range siteRecord.Links {
+			if link.RemoteSiteId == siteId && link.Operational {
+				name := siteRecord.Name
+				if name == "" {
+					name = siteRecord.Id
+				}
+				sites = append(sites, name)
+				break
+			}
+		}
+	}
+	sort.Strings(sites)
+	return sites
+}
+
 func HasMatchingPair(networkStatus NetworkStatusInfo, address string) bool {
 	for _, addressInfo := range networkStatus.Addresses {
 		if addressInfo.Name == address {
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
 	"gotest.tools/v3/assert"
 )
 
@@ -208,3 +209,19 @@ func TestHasMatchingPair(t *testing.T) {
 		assert.Equal(t, scenario.expectedMatch, HasMatchingPair(networkStatus, scenario.address))
 	}
 }
+
+func TestGetSitesLinkingTo(t *testing.T) {
+	network := []v2alpha1.SiteRecord{
+		{Id: "hub", Name: "hub", Links: []v2alpha1.LinkRecord{{Name: "to-east", RemoteSiteId: "east", Operational: true}}},
+		{Id: "west", Name: "west", Links: []v2alpha1.LinkRecord{
+			{Name: "to-hub", RemoteSiteId: "hub", Operational: true},
+			{Name: "to-hub-2", RemoteSiteId: "hub", Operational: true},
+		}},
+		{Id: "south", Links: []v2alpha1.LinkRecord{{Name: "to-hub", RemoteSiteId: "hub", Operational: true}}},
+		{Id: "north", Name: "north", Links: []v2alpha1.LinkRecord{{Name: "to-hub", RemoteSiteId: "hub"}}},
+		{Id: "east", Name: "east"},
+	}
+	assert.DeepEqual(t, GetSitesLinkingTo("hub", network), []string{"south", "west"})
+	assert.DeepEqual(t, GetSitesLinkingTo("east", network), []string{"hub"})
+	assert.Assert(t, GetSitesLinkingTo("west", network) == nil)
+}
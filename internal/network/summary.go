@@ -0,0 +1,324 @@
+package network
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// NetworkSummary is a site level view of a NetworkStatusInfo: the sites in
+// the network, the links between them and where each routing key is
+// exposed, along with any inconsistencies found.
+type NetworkSummary struct {
+	Sites       []SiteSummary       `json:"sites"`
+	Links       []LinkSummary       `json:"links"`
+	RoutingKeys []RoutingKeySummary `json:"routingKeys"`
+	Mismatches  []string            `json:"mismatches,omitempty"`
+}
+
+type SiteSummary struct {
+	Id        string `json:"id"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	Platform  string `json:"platform,omitempty"`
+	Version   string `json:"version,omitempty"`
+	Routers   int    `json:"routers"`
+}
+
+type LinkSummary struct {
+	Name     string `json:"name"`
+	FromSite string `json:"fromSite"`
+	ToSite   string `json:"toSite,omitempty"`
+	Cost     uint64 `json:"cost,omitempty"`
+	Status   string `json:"status,omitempty"`
+}
+
+// Operational reports whether the link is up
+func (l LinkSummary) Operational() bool {
+	return strings.EqualFold(l.Status, "up")
+}
+
+type RoutingKeySummary struct {
+	RoutingKey string            `json:"routingKey"`
+	Protocol   string            `json:"protocol,omitempty"`
+	Listeners  []EndpointSummary `json:"listeners,omitempty"`
+	Connectors []EndpointSummary `json:"connectors,omitempty"`
+}
+
+type EndpointSummary struct {
+	Site     string `json:"site"`
+	Name     string `json:"name,omitempty"`
+	Host     string `json:"host,omitempty"`
+	Port     string `json:"port,omitempty"`
+	Protocol string `json:"protocol,omitempty"`
+}
+
+func (e EndpointSummary) address() string {
+	if e.Port == "" {
+		return e.Host
+	}
+	return net.JoinHostPort(e.Host, e.Port)
+}
+
+// Summarize builds the NetworkSummary of the given network status
+func Summarize(status NetworkStatusInfo) NetworkSummary {
+	summary := NetworkSummary{}
+	accessPointSites := map[string]string{} // router access point ID -> site name
+	for _, site := range status.SiteStatus {
+		for _, router := range site.RouterStatus {
+			for _, ap := range router.AccessPoints {
+				accessPointSites[ap.Identity] = site.Site.Name
+			}
+		}
+	}
+	protocols := map[string]string{}
+	for _, address := range status.Addresses {
+		protocols[address.Name] = address.Protocol
+	}
+
+	routingKeys := map[string]*RoutingKeySummary{}
+	routingKey := func(address string) *RoutingKeySummary {
+		key, ok := routingKeys[address]
+		if !ok {
+			key = &RoutingKeySummary{
+				RoutingKey: address,
+				Protocol:   protocols[address],
+			}
+			routingKeys[address] = key
+		}
+		return key
+	}
+	for _, site := range status.SiteStatus {
+		summary.Sites = append(summary.Sites, SiteSummary{
+			Id:        site.Site.Identity,
+			Name:      site.Site.Name,
+			Namespace: site.Site.Namespace,
+			Platform:  site.Site.Platform,
+			Version:   site.Site.Version,
+			Routers:   len(site.RouterStatus),
+		})
+		for _, router := range site.RouterStatus {
+			for _, link := range router.Links {
+				if link.Name == "" {
+					continue
+				}
+				peer, ok := accessPointSites[link.Peer]
+				if ok && peer == site.Site.Name {
+					// links between the routers of a site
+					continue
+				}
+				summary.Links = append(summary.Links, LinkSummary{
+					Name:     link.Name,
+					FromSite: site.Site.Name,
+					ToSite:   peer,
+					Cost:     link.LinkCost,
+					Status:   link.Status,
+				})
+			}
+			for _, listener := range router.Listeners {
+				if listener.Address == "" {
+					continue
+				}
+				key := routingKey(listener.Address)
+				key.Listeners = append(key.Listeners, EndpointSummary{
+					Site:     site.Site.Name,
+					Name:     listener.Name,
+					Host:     listener.DestHost,
+					Port:     listener.DestPort,
+					Protocol: listener.Protocol,
+				})
+			}
+			for _, connector := range router.Connectors {
+				if connector.Address == "" {
+					continue
+				}
+				key := routingKey(connector.Address)
+				key.Connectors = append(key.Connectors, EndpointSummary{
+					Site: site.Site.Name,
+					Name: connector.Target,
+					Host: connector.DestHost,
+					Port: connector.DestPort,
+				})
+			}
+		}
+	}
+	for _, key := range routingKeys {
+		summary.RoutingKeys = append(summary.RoutingKeys, *key)
+	}
+
+	sort.Slice(summary.Sites, func(i, j int) bool {
+		return summary.Sites[i].Name < summary.Sites[j].Name
+	})
+	sort.Slice(summary.Links, func(i, j int) bool {
+		if summary.Links[i].FromSite != summary.Links[j].FromSite {
+			return summary.Links[i].FromSite < summary.Links[j].FromSite
+		}
+		return summary.Links[i].Name < summary.Links[j].Name
+	})
+	sort.Slice(summary.RoutingKeys, func(i, j int) bool {
+		return summary.RoutingKeys[i].RoutingKey < summary.RoutingKeys[j].RoutingKey
+	})
+	summary.Mismatches = summary.mismatches()
+	return summary
+}
+
+func (s NetworkSummary) mismatches() []string {
+	var mismatches []string
+	for _, link := range s.Links {
+		if link.ToSite == "" {
+			mismatches = append(mismatches, fmt.Sprintf("link %q from site %q does not reach a known site", link.Name, link.FromSite))
+		} else if !link.Operational() {
+			mismatches = append(mismatches, fmt.Sprintf("link %q from site %q to site %q is not operational", link.Name, link.FromSite, link.ToSite))
+		}
+	}
+	for _, key := range s.RoutingKeys {
+		if len(key.Connectors) == 0 {
+			mismatches = append(mismatches, fmt.Sprintf("routing key %q has listeners but no connectors", key.RoutingKey))
+		}
+		if len(key.Listeners) == 0 {
+			mismatches = append(mismatches, fmt.Sprintf("routing key %q has connectors but no listeners", key.RoutingKey))
+		}
+		protocols := map[string]bool{}
+		for _, listener := range key.Listeners {
+			if listener.Protocol != "" {
+				protocols[listener.Protocol] = true
+			}
+		}
+		if len(protocols) > 1 {
+			var names []string
+			for protocol := range protocols {
+				names = append(names, protocol)
+			}
+			sort.Strings(names)
+			mismatches = append(mismatches, fmt.Sprintf("routing key %q has listeners with different protocols: %s", key.RoutingKey, strings.Join(names, ", ")))
+		}
+	}
+	return mismatches
+}
+
+// WriteTable writes the summary as human readable tables
+func (s NetworkSummary) WriteTable(out io.Writer) error {
+	if len(s.Sites) == 0 {
+		_, err := fmt.Fprintln(out, "There is no network status available yet")
+		return err
+	}
+	writer := tabwriter.NewWriter(out, 0, 8, 1, '\t', tabwriter.AlignRight)
+	fmt.Fprintln(writer, "SITES")
+	fmt.Fprintln(writer, "NAME\tNAMESPACE\tPLATFORM\tVERSION\tROUTERS")
+	for _, site := range s.Sites {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%d\n", site.Name, site.Namespace, site.Platform, site.Version, site.Routers)
+	}
+
+	fmt.Fprintln(writer)
+	fmt.Fprintln(writer, "LINKS")
+	if len(s.Links) == 0 {
+		fmt.Fprintln(writer, "There are no links between sites")
+	} else {
+		fmt.Fprintln(writer, "FROM\tTO\tNAME\tCOST\tSTATUS")
+		for _, link := range s.Links {
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%d\t%s\n", link.FromSite, link.ToSite, link.Name, link.Cost, link.Status)
+		}
+	}
+
+	fmt.Fprintln(writer)
+	fmt.Fprintln(writer, "ROUTING KEYS")
+	if len(s.RoutingKeys) == 0 {
+		fmt.Fprintln(writer, "There are no listeners or connectors")
+	} else {
+		fmt.Fprintln(writer, "ROUTING KEY\tPROTOCOL\tSITE\tLISTENERS\tCONNECTORS")
+		for _, key := range s.RoutingKeys {
+			for _, site := range key.sites() {
+				var listeners, connectors []string
+				for _, listener := range key.Listeners {
+					if listener.Site == site {
+						listeners = append(listeners, listener.address())
+					}
+				}
+				for _, connector := range key.Connectors {
+					if connector.Site == site {
+						connectors = append(connectors, connector.address())
+					}
+				}
+				fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n", key.RoutingKey, key.Protocol, site, orNone(listeners), orNone(connectors))
+			}
+		}
+	}
+
+	if len(s.Mismatches) > 0 {
+		fmt.Fprintln(writer)
+		fmt.Fprintln(writer, "MISMATCHES")
+		for _, mismatch := range s.Mismatches {
+			fmt.Fprintln(writer, mismatch)
+		}
+	}
+	return writer.Flush()
+}
+
+// sites returns the names of the sites with listeners or connectors for
+// the routing key.
+func (k RoutingKeySummary) sites() []string {
+	seen := map[string]bool{}
+	var sites []string
+	for _, endpoint := range append(append([]EndpointSummary{}, k.Listeners...), k.Connectors...) {
+		if !seen[endpoint.Site] {
+			seen[endpoint.Site] = true
+			sites = append(sites, endpoint.Site)
+		}
+	}
+	sort.Strings(sites)
+	return sites
+}
+
+func orNone(values []string) string {
+	if len(values) == 0 {
+		return "-"
+	}
+	return strings.Join(values, ",")
+}
+
+// WriteDot writes the summary as a graph in the DOT language, with the
+// sites and routing keys as nodes. Links are drawn between sites, listeners
+// from a site to a routing key and connectors from a routing key to a site.
+func (s NetworkSummary) WriteDot(out io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph network {\n")
+	b.WriteString("  rankdir=LR;\n")
+	for _, site := range s.Sites {
+		fmt.Fprintf(&b, "  %q [shape=box, label=%q];\n", "site/"+site.Name, site.Name)
+	}
+	for _, key := range s.RoutingKeys {
+		fmt.Fprintf(&b, "  %q [shape=ellipse, label=%q];\n", "key/"+key.RoutingKey, key.RoutingKey)
+	}
+	for _, link := range s.Links {
+		if link.ToSite == "" {
+			continue
+		}
+		style := "solid"
+		if !link.Operational() {
+			style = "dashed"
+		}
+		fmt.Fprintf(&b, "  %q -> %q [label=%q, style=%s];\n", "site/"+link.FromSite, "site/"+link.ToSite, fmt.Sprintf("cost %d", link.Cost), style)
+	}
+	for _, key := range s.RoutingKeys {
+		for _, site := range key.sites() {
+			for _, listener := range key.Listeners {
+				if listener.Site == site {
+					fmt.Fprintf(&b, "  %q -> %q [label=%q, color=blue];\n", "site/"+site, "key/"+key.RoutingKey, "listener")
+					break
+				}
+			}
+			for _, connector := range key.Connectors {
+				if connector.Site == site {
+					fmt.Fprintf(&b, "  %q -> %q [label=%q, color=darkgreen];\n", "key/"+key.RoutingKey, "site/"+site, "connector")
+					break
+				}
+			}
+		}
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(out, b.String())
+	return err
+}
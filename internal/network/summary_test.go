@@ -0,0 +1,140 @@
+package network
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func createTestNetworkStatus() NetworkStatusInfo {
+	return NetworkStatusInfo{
+		Addresses: []AddressInfo{
+			{Name: "backend", Protocol: "tcp", ListenerCount: 1, ConnectorCount: 1},
+			{Name: "orphan", Protocol: "tcp", ListenerCount: 1},
+		},
+		SiteStatus: []SiteStatusInfo{
+			{
+				Site: SiteInfo{Identity: "west-id", Name: "west", Namespace: "west", Platform: "kubernetes", Version: "2.0.0"},
+				RouterStatus: []RouterStatusInfo{
+					{
+						Router:       RouterInfo{Name: "0/west-skupper-router"},
+						AccessPoints: []RouterAccessInfo{{Identity: "west-ap"}},
+						Links: []LinkInfo{
+							{Name: "west-to-west", Peer: "west-ap-2", Status: "up"},
+						},
+						Listeners: []ListenerInfo{
+							{Name: "backend", Address: "backend", DestHost: "0.0.0.0", DestPort: "8080", Protocol: "tcp"},
+						},
+					},
+					{
+						Router:       RouterInfo{Name: "0/west-skupper-router-2"},
+						AccessPoints: []RouterAccessInfo{{Identity: "west-ap-2"}},
+					},
+				},
+			},
+			{
+				Site: SiteInfo{Identity: "east-id", Name: "east", Namespace: "east", Platform: "podman", Version: "2.0.0"},
+				RouterStatus: []RouterStatusInfo{
+					{
+						Router: RouterInfo{Name: "0/east"},
+						Links: []LinkInfo{
+							{Name: "east-to-west", Peer: "west-ap", Status: "up", LinkCost: 1},
+							{Name: "east-to-nowhere", Peer: "unknown-ap", Status: "down", LinkCost: 5},
+						},
+						Listeners: []ListenerInfo{
+							{Name: "orphan", Address: "orphan", DestHost: "0.0.0.0", DestPort: "9090", Protocol: "tcp"},
+						},
+						Connectors: []ConnectorInfo{
+							{Address: "backend", DestHost: "10.0.0.5", DestPort: "8080", Target: "backend-pod"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	summary := Summarize(createTestNetworkStatus())
+
+	assert.DeepEqual(t, summary.Sites, []SiteSummary{
+		{Id: "east-id", Name: "east", Namespace: "east", Platform: "podman", Version: "2.0.0", Routers: 1},
+		{Id: "west-id", Name: "west", Namespace: "west", Platform: "kubernetes", Version: "2.0.0", Routers: 2},
+	})
+	assert.DeepEqual(t, summary.Links, []LinkSummary{
+		{Name: "east-to-nowhere", FromSite: "east", Cost: 5, Status: "down"},
+		{Name: "east-to-west", FromSite: "east", ToSite: "west", Cost: 1, Status: "up"},
+	})
+	assert.DeepEqual(t, summary.RoutingKeys, []RoutingKeySummary{
+		{
+			RoutingKey: "backend",
+			Protocol:   "tcp",
+			Listeners:  []EndpointSummary{{Site: "west", Name: "backend", Host: "0.0.0.0", Port: "8080", Protocol: "tcp"}},
+			Connectors: []EndpointSummary{{Site: "east", Name: "backend-pod", Host: "10.0.0.5", Port: "8080"}},
+		},
+		{
+			RoutingKey: "orphan",
+			Protocol:   "tcp",
+			Listeners:  []EndpointSummary{{Site: "east", Name: "orphan", Host: "0.0.0.0", Port: "9090", Protocol: "tcp"}},
+		},
+	})
+	assert.DeepEqual(t, summary.Mismatches, []string{
+		`link "east-to-nowhere" from site "east" does not reach a known site`,
+		`routing key "orphan" has listeners but no connectors`,
+	})
+}
+
+func TestSummarizeEmpty(t *testing.T) {
+	summary := Summarize(NetworkStatusInfo{})
+	assert.Equal(t, len(summary.Sites), 0)
+	assert.Equal(t, len(summary.Mismatches), 0)
+
+	out := &bytes.Buffer{}
+	assert.Assert(t, summary.WriteTable(out))
+	assert.Equal(t, out.String(), "There is no network status available yet\n")
+}
+
+func TestNetworkSummaryWriteTable(t *testing.T) {
+	out := &bytes.Buffer{}
+	assert.Assert(t, Summarize(createTestNetworkStatus()).WriteTable(out))
+	lines := strings.Split(out.String(), "\n")
+	var fields [][]string
+	for _, line := range lines {
+		fields = append(fields, strings.Fields(line))
+	}
+	assert.Assert(t, contains(fields, []string{"west", "west", "kubernetes", "2.0.0", "2"}))
+	assert.Assert(t, contains(fields, []string{"east", "west", "east-to-west", "1", "up"}))
+	assert.Assert(t, contains(fields, []string{"backend", "tcp", "east", "-", "10.0.0.5:8080"}))
+	assert.Assert(t, contains(fields, []string{"backend", "tcp", "west", "0.0.0.0:8080", "-"}))
+	assert.Assert(t, strings.Contains(out.String(), "MISMATCHES\n"))
+	assert.Assert(t, strings.Contains(out.String(), `routing key "orphan" has listeners but no connectors`))
+}
+
+func TestNetworkSummaryWriteDot(t *testing.T) {
+	out := &bytes.Buffer{}
+	assert.Assert(t, Summarize(createTestNetworkStatus()).WriteDot(out))
+	dot := out.String()
+	assert.Assert(t, strings.HasPrefix(dot, "digraph network {\n"))
+	assert.Assert(t, strings.HasSuffix(dot, "}\n"))
+	for _, expected := range []string{
+		`"site/west" [shape=box, label="west"];`,
+		`"key/backend" [shape=ellipse, label="backend"];`,
+		`"site/east" -> "site/west" [label="cost 1", style=solid];`,
+		`"site/west" -> "key/backend" [label="listener", color=blue];`,
+		`"key/backend" -> "site/east" [label="connector", color=darkgreen];`,
+	} {
+		assert.Assert(t, strings.Contains(dot, expected), expected)
+	}
+	assert.Assert(t, !strings.Contains(dot, "east-to-nowhere"))
+}
+
+func contains(lines [][]string, expected []string) bool {
+	for _, line := range lines {
+		if strings.Join(line, " ") == strings.Join(expected, " ") {
+			return true
+		}
+	}
+	return false
+}
@@ -2,6 +2,7 @@ package bootstrap
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path"
@@ -24,6 +25,16 @@ type Config struct {
 	IsBundle       bool
 	Platform       types.Platform
 	Binary         string
+	// Out receives the messages reporting the progress of the
+	// bootstrap. It defaults to the standard output.
+	Out io.Writer
+}
+
+func (c *Config) out() io.Writer {
+	if c.Out == nil {
+		return os.Stdout
+	}
+	return c.Out
 }
 
 func PreBootstrap(config *Config) error {
@@ -39,9 +50,9 @@ func PreBootstrap(config *Config) error {
 		// when input path is empty, but a namespace is provided, try to reload an existing site definition
 		if inputSourcesDefined {
 			config.InputPath = existingPath
-			fmt.Printf("Sources will be consumed from namespace %q\n", config.Namespace)
+			fmt.Fprintf(config.out(), "Sources will be consumed from namespace %q\n", config.Namespace)
 		} else {
-			fmt.Printf("Namespace %q does not exist\n", config.Namespace)
+			fmt.Fprintf(config.out(), "Namespace %q does not exist\n", config.Namespace)
 			return fmt.Errorf("No sources found at: %s\n", path.Join(api.GetHostNamespaceHome(config.Namespace), string(api.InputSiteStatePath)))
 		}
 	} else if inputSourcesDefined && !api.IsRunningInContainer() {
@@ -153,34 +164,34 @@ func PostBootstrap(config *Config, siteState *api.SiteState) {
 			if err == nil && !outFile.IsDir() {
 				err = os.WriteFile("/bootstrap.out", []byte(siteState.GetNamespace()), 0644)
 				if err != nil {
-					fmt.Println("Failed to write to bootstrap.out:", err)
-					fmt.Println("The systemd service will not be created.")
+					fmt.Fprintln(config.out(), "Failed to write to bootstrap.out:", err)
+					fmt.Fprintln(config.out(), "The systemd service will not be created.")
 				}
 			}
 		}
 	}
-	fmt.Printf("Site %q has been created%s\n", siteState.Site.Name, bundleSuffix)
+	fmt.Fprintf(config.out(), "Site %q has been created%s\n", siteState.Site.Name, bundleSuffix)
 	if !config.IsBundle {
-		fmt.Printf("Platform: %s\n", config.Platform)
+		fmt.Fprintf(config.out(), "Platform: %s\n", config.Platform)
 		tokenPath := api.GetInternalOutputPath(siteState.Site.Namespace, api.RuntimeTokenPath)
 		hostTokenPath := api.GetHostSiteInternalPath(siteState.Site, api.RuntimeTokenPath)
 		tokens, _ := os.ReadDir(tokenPath)
 		for _, token := range tokens {
 			if !token.IsDir() {
-				fmt.Println("Static links have been defined at:", hostTokenPath)
+				fmt.Fprintln(config.out(), "Static links have been defined at:", hostTokenPath)
 				break
 			}
 		}
 		sourcesPath := api.GetHostSiteInternalPath(siteState.Site, api.InputSiteStatePath)
-		fmt.Printf("Definition is available at: %s\n", sourcesPath)
+		fmt.Fprintf(config.out(), "Definition is available at: %s\n", sourcesPath)
 	} else {
 		siteHome := api.GetHostBundlesPath()
 		installationFile := path.Join(siteHome, fmt.Sprintf("%s.sh", config.BundleName))
 		if internalbundle.GetBundleStrategy(config.BundleStrategy) == string(internalbundle.BundleStrategyTarball) {
 			installationFile = path.Join(siteHome, fmt.Sprintf("%s.tar.gz", config.BundleName))
 		}
-		fmt.Println("Installation bundle available at:", installationFile)
-		fmt.Println("Default namespace:", siteState.GetNamespace())
-		fmt.Println("Default platform:", string(config.Platform))
+		fmt.Fprintln(config.out(), "Installation bundle available at:", installationFile)
+		fmt.Fprintln(config.out(), "Default namespace:", siteState.GetNamespace())
+		fmt.Fprintln(config.out(), "Default platform:", string(config.Platform))
 	}
 }
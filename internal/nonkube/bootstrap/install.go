@@ -14,6 +14,7 @@ import (
 	"github.com/skupperproject/skupper/internal/nonkube/bootstrap/controller"
 	internalclient "github.com/skupperproject/skupper/internal/nonkube/client/compat"
 	"github.com/skupperproject/skupper/internal/nonkube/common"
+	"github.com/skupperproject/skupper/internal/nonkube/grants"
 	"github.com/skupperproject/skupper/pkg/container"
 	"github.com/skupperproject/skupper/pkg/nonkube/api"
 )
@@ -77,6 +78,25 @@ func Install(platform string) error {
 		"CONTAINER_ENGINE":    config.containerEngine,
 	}
 
+	// the AccessGrant server used by "skupper token issue" is reachable
+	// by anyone who can reach the published port, so it is only enabled
+	// when explicitly requested
+	var ports []container.Port
+	if enableGrants, _ := strconv.ParseBool(os.Getenv("SKUPPER_ENABLE_GRANTS")); enableGrants {
+		grantServerPort := os.Getenv("SKUPPER_GRANT_SERVER_PORT")
+		if grantServerPort == "" {
+			grantServerPort = strconv.Itoa(grants.DefaultGrantServerPort)
+		}
+		env["SKUPPER_ENABLE_GRANTS"] = "true"
+		env["SKUPPER_GRANT_SERVER_PORT"] = grantServerPort
+		ports = append(ports, container.Port{
+			Host:     grantServerPort,
+			Target:   grantServerPort,
+			Protocol: "tcp",
+		})
+		fmt.Printf("AccessGrant server enabled on port %s\n", grantServerPort)
+	}
+
 	//To mount a volume as a bind, the host path must be specified in the Name field
 	//instead of the Source field. If the values Name/Destination are empty, volumes will be ignored, not mounted,
 	//and the system-controller container will fail to start.
@@ -121,6 +141,7 @@ func Install(platform string) error {
 		Env:         env,
 		Mounts:      mounts,
 		Annotations: annotations,
+		Ports:       ports,
 	}
 
 	err = cli.ContainerCreate(&sysControllerContainer)
@@ -30,6 +30,10 @@ func Teardown(namespace string) error {
 		return err
 	}
 
+	if err := common.NewPortRegistry().Release(namespace); err != nil {
+		return err
+	}
+
 	if err := removeDefinition(namespace); err != nil {
 		return err
 	}
@@ -1,6 +1,8 @@
 package fs
 
 import (
+	"os"
+
 	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
 	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
 )
@@ -34,8 +36,39 @@ func (c *CertificateHandler) Add(resource v2alpha1.Certificate) error {
 	return nil
 }
 
-func (c *CertificateHandler) Get(name string, opt GetOptions) (*v2alpha1.Certificate, error) {
-	return nil, nil
+func (c *CertificateHandler) Get(name string, opts GetOptions) (*v2alpha1.Certificate, error) {
+	var context v2alpha1.Certificate
+	fileName := name + ".yaml"
+
+	if opts.RuntimeFirst == true {
+		// First read from runtime directory, where output is found after bootstrap
+		// has run.  If no runtime certificates try and display configured certificates
+		err, file := c.ReadFile(c.pathProvider.GetRuntimeNamespace(), fileName, common.Certificates)
+		if err != nil {
+			if opts.LogWarning {
+				os.Stderr.WriteString("Site not initialized yet\n")
+			}
+			err, file = c.ReadFile(c.pathProvider.GetNamespace(), fileName, common.Certificates)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if err = c.DecodeYaml(file, &context); err != nil {
+			return nil, err
+		}
+	} else {
+		// read from input directory to get latest config
+		err, file := c.ReadFile(c.pathProvider.GetNamespace(), fileName, common.Certificates)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.DecodeYaml(file, &context); err != nil {
+			return nil, err
+		}
+	}
+
+	return &context, nil
 }
 
 func (c *CertificateHandler) Delete(name string) error {
@@ -48,4 +81,32 @@ func (c *CertificateHandler) Delete(name string) error {
 	return nil
 }
 
-func (c *CertificateHandler) List() ([]*v2alpha1.Certificate, error) { return nil, nil }
+func (c *CertificateHandler) List() ([]*v2alpha1.Certificate, error) {
+	var certificates []*v2alpha1.Certificate
+
+	// First read from runtime directory, where output is found after bootstrap
+	// has run.  If no runtime certificates try and display configured certificates
+	path := c.pathProvider.GetRuntimeNamespace()
+	err, files := c.ReadDir(path, common.Certificates)
+	if err != nil {
+		os.Stderr.WriteString("Site not initialized yet\n")
+		path = c.pathProvider.GetNamespace()
+		err, files = c.ReadDir(path, common.Certificates)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, file := range files {
+		err, certificate := c.ReadFile(path, file.Name(), common.Certificates)
+		if err != nil {
+			return nil, err
+		}
+		var context v2alpha1.Certificate
+		if err = c.DecodeYaml(certificate, &context); err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, &context)
+	}
+	return certificates, nil
+}
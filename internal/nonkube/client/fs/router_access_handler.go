@@ -2,6 +2,8 @@ package fs
 
 import (
 	"fmt"
+	"os"
+
 	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
 	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
 )
@@ -57,6 +59,36 @@ func (s *RouterAccessHandler) Get(name string) (*v2alpha1.RouterAccess, error) {
 	return &context, nil
 }
 
+func (s *RouterAccessHandler) List() ([]*v2alpha1.RouterAccess, error) {
+	var routerAccesses []*v2alpha1.RouterAccess
+
+	// First read from runtime directory, where output is found after bootstrap
+	// has run.  If no runtime router accesses try and display configured ones
+	path := s.pathProvider.GetRuntimeNamespace()
+	err, files := s.ReadDir(path, common.RouterAccesses)
+	if err != nil {
+		os.Stderr.WriteString("Site not initialized yet\n")
+		path = s.pathProvider.GetNamespace()
+		err, files = s.ReadDir(path, common.RouterAccesses)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, file := range files {
+		err, routerAccess := s.ReadFile(path, file.Name(), common.RouterAccesses)
+		if err != nil {
+			return nil, err
+		}
+		var context v2alpha1.RouterAccess
+		if err = s.DecodeYaml(routerAccess, &context); err != nil {
+			return nil, err
+		}
+		routerAccesses = append(routerAccesses, &context)
+	}
+	return routerAccesses, nil
+}
+
 func (s *RouterAccessHandler) Delete(name string) error {
 	fileName := name + ".yaml"
 
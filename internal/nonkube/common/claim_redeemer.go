@@ -99,6 +99,16 @@ type LinkDecoder struct {
 	Links   []skupperv2alpha1.Link
 }
 
+// DecodeLinks reads a Secret followed by the Links that use it, in the
+// same format returned when an AccessToken is redeemed.
+func DecodeLinks(r io.Reader) (*LinkDecoder, error) {
+	decoder := newLinkDecoder(r)
+	if err := decoder.decodeAll(); err != nil {
+		return nil, err
+	}
+	return decoder, nil
+}
+
 func newLinkDecoder(r io.Reader) *LinkDecoder {
 	return &LinkDecoder{
 		decoder: yaml.NewYAMLOrJSONDecoder(r, 1024),
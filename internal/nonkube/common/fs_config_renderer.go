@@ -30,6 +30,7 @@ var (
 		api.LoadedSiteStatePath,
 		api.RuntimeSiteStatePath,
 		api.RuntimeTokenPath,
+		api.RuntimeGrantsPath,
 		api.ScriptsPath,
 	}
 	reloadDirectories = []api.InternalPath{
@@ -60,7 +61,9 @@ type FileSystemConfigurationRenderer struct {
 	RouterConfig       qdr.RouterConfig
 	Platform           string
 	Bundle             bool
-	customOutputPath   string
+	// MetricsPort when set exposes the health and metrics endpoint of the router
+	MetricsPort      int
+	customOutputPath string
 }
 
 func NewFileSystemConfigurationRenderer(outputPath string) *FileSystemConfigurationRenderer {
@@ -306,6 +309,9 @@ func (c *FileSystemConfigurationRenderer) createTokens(siteState *api.SiteState)
 
 func (c *FileSystemConfigurationRenderer) createRouterConfig(siteState *api.SiteState) error {
 	c.RouterConfig = siteState.ToRouterConfig(c.SslProfileBasePath, c.Platform)
+	if c.MetricsPort != 0 {
+		c.RouterConfig.AddHealthAndMetricsListener(int32(c.MetricsPort))
+	}
 
 	// Saving router config
 	routerConfigJson, err := qdr.MarshalRouterConfig(c.RouterConfig)
@@ -0,0 +1,391 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+
+	"github.com/skupperproject/skupper/internal/utils"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	PortKindRouterAccess = "RouterAccess"
+	PortKindListener     = "Listener"
+	PortKindMetrics      = "Metrics"
+
+	// DefaultRouterAccessPort is the first port tried for the
+	// skupper-local router access
+	DefaultRouterAccessPort = 5671
+	// DefaultMetricsPort is the first port tried for the health and
+	// metrics endpoint of the router, away from the grant server port
+	DefaultMetricsPort = 9190
+
+	portRegistryFileName     = "ports.yaml"
+	portRegistryLockFileName = "ports.lock"
+)
+
+// PortReservation is a host port claimed by a resource of a site. An
+// empty protocol stands for TCP.
+type PortReservation struct {
+	Namespace string `json:"namespace"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Host      string `json:"host,omitempty"`
+	Protocol  string `json:"protocol,omitempty"`
+	Port      int    `json:"port"`
+}
+
+func (p PortReservation) String() string {
+	return fmt.Sprintf("%s %q of namespace %q", p.Kind, p.Name, p.Namespace)
+}
+
+func (p PortReservation) overlaps(other PortReservation) bool {
+	if p.Port != other.Port || portProtocol(p.Protocol) != portProtocol(other.Protocol) {
+		return false
+	}
+	return p.Host == other.Host || isWildcardHost(p.Host) || isWildcardHost(other.Host)
+}
+
+func isWildcardHost(host string) bool {
+	return host == "" || host == "0.0.0.0" || host == "::"
+}
+
+func portProtocol(protocol string) string {
+	if protocol == "" {
+		return "TCP"
+	}
+	return protocol
+}
+
+// PortRequest asks for a host port. When Port is zero, a free port is
+// allocated starting from StartPort, the port previously reserved for the
+// same resource being preferred so that it does not change on restarts.
+type PortRequest struct {
+	Kind      string
+	Name      string
+	Host      string
+	Protocol  string
+	Port      int
+	StartPort int
+}
+
+// PortConflict reports a port requested by a site that is already
+// reserved by another resource.
+type PortConflict struct {
+	Requested PortReservation
+	Owner     PortReservation
+}
+
+func (c PortConflict) Error() string {
+	return fmt.Sprintf("port %d requested by %s is already reserved by %s", c.Requested.Port, c.Requested, c.Owner)
+}
+
+type portRegistryData struct {
+	Reservations []PortReservation `json:"reservations"`
+}
+
+// PortRegistry keeps track of the host ports reserved by all the sites
+// (namespaces) running on a host, so that they do not claim the same
+// ports. It is stored under the system controller path and updates are
+// serialized through a lock file.
+type PortRegistry struct {
+	Path string
+	// InUse tells whether a port is currently bound on the host
+	InUse func(host string, port int) bool
+}
+
+func NewPortRegistry() *PortRegistry {
+	return &PortRegistry{
+		Path:  api.GetDefaultOutputSystemControllerPath(),
+		InUse: utils.TcpPortInUse,
+	}
+}
+
+// List returns all reservations, sorted by port.
+func (r *PortRegistry) List() ([]PortReservation, error) {
+	data, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	return data.Reservations, nil
+}
+
+// Reserve atomically replaces the reservations of the namespace with the
+// requested ports. Nothing is reserved if any of the fixed ports is
+// already reserved by another namespace or resource, in which case all
+// the conflicts are returned.
+func (r *PortRegistry) Reserve(namespace string, requests []PortRequest) ([]PortReservation, error) {
+	var reservations []PortReservation
+	err := r.update(func(data *portRegistryData) error {
+		var others, previous []PortReservation
+		for _, reservation := range data.Reservations {
+			if reservation.Namespace == namespace {
+				previous = append(previous, reservation)
+			} else {
+				others = append(others, reservation)
+			}
+		}
+		var conflicts []error
+		reserved := others
+		// fixed ports first, so that allocated ports do not take them
+		for _, request := range requests {
+			if request.Port == 0 {
+				continue
+			}
+			reservation := newReservation(namespace, request, request.Port)
+			if owner, ok := findOverlap(reserved, reservation); ok {
+				conflicts = append(conflicts, PortConflict{Requested: reservation, Owner: owner})
+				continue
+			}
+			reserved = append(reserved, reservation)
+			reservations = append(reservations, reservation)
+		}
+		if len(conflicts) > 0 {
+			return errors.Join(conflicts...)
+		}
+		for _, request := range requests {
+			if request.Port != 0 {
+				continue
+			}
+			port, err := r.allocate(namespace, request, reserved, previous)
+			if err != nil {
+				return err
+			}
+			reservation := newReservation(namespace, request, port)
+			reserved = append(reserved, reservation)
+			reservations = append(reservations, reservation)
+		}
+		data.Reservations = reserved
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reservations, nil
+}
+
+// Release removes all the reservations of the namespace.
+func (r *PortRegistry) Release(namespace string) error {
+	return r.update(func(data *portRegistryData) error {
+		var reservations []PortReservation
+		for _, reservation := range data.Reservations {
+			if reservation.Namespace != namespace {
+				reservations = append(reservations, reservation)
+			}
+		}
+		data.Reservations = reservations
+		return nil
+	})
+}
+
+// Check returns the requested ports of the namespace that overlap with
+// ports reserved by other namespaces, without changing the registry.
+// Requests for ports to be allocated are checked against the port
+// currently reserved for them, if any.
+func (r *PortRegistry) Check(namespace string, requests []PortRequest) ([]PortConflict, error) {
+	data, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	var others, current []PortReservation
+	for _, reservation := range data.Reservations {
+		if reservation.Namespace == namespace {
+			current = append(current, reservation)
+		} else {
+			others = append(others, reservation)
+		}
+	}
+	var conflicts []PortConflict
+	for _, request := range requests {
+		port := request.Port
+		if port == 0 {
+			for _, reservation := range current {
+				if reservation.Kind == request.Kind && reservation.Name == request.Name {
+					port = reservation.Port
+				}
+			}
+			if port == 0 {
+				continue
+			}
+		}
+		reservation := newReservation(namespace, request, port)
+		if owner, ok := findOverlap(others, reservation); ok {
+			conflicts = append(conflicts, PortConflict{Requested: reservation, Owner: owner})
+		}
+	}
+	return conflicts, nil
+}
+
+func (r *PortRegistry) allocate(namespace string, request PortRequest, reserved []PortReservation, previous []PortReservation) (int, error) {
+	available := func(port int) bool {
+		if _, ok := findOverlap(reserved, newReservation(namespace, request, port)); ok {
+			return false
+		}
+		return r.InUse == nil || !r.InUse(request.Host, port)
+	}
+	for _, reservation := range previous {
+		if reservation.Kind == request.Kind && reservation.Name == request.Name && available(reservation.Port) {
+			return reservation.Port, nil
+		}
+	}
+	for port := request.StartPort; port > 0 && port <= 65535; port++ {
+		if available(port) {
+			return port, nil
+		}
+	}
+	return 0, fmt.Errorf("no available port found for %s %q starting at %d", request.Kind, request.Name, request.StartPort)
+}
+
+func newReservation(namespace string, request PortRequest, port int) PortReservation {
+	return PortReservation{
+		Namespace: namespace,
+		Kind:      request.Kind,
+		Name:      request.Name,
+		Host:      request.Host,
+		Protocol:  request.Protocol,
+		Port:      port,
+	}
+}
+
+func findOverlap(reservations []PortReservation, reservation PortReservation) (PortReservation, bool) {
+	for _, existing := range reservations {
+		if existing.overlaps(reservation) {
+			return existing, true
+		}
+	}
+	return PortReservation{}, false
+}
+
+func (r *PortRegistry) load() (*portRegistryData, error) {
+	data := &portRegistryData{}
+	content, err := os.ReadFile(path.Join(r.Path, portRegistryFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return data, nil
+		}
+		return nil, fmt.Errorf("unable to read port registry: %w", err)
+	}
+	if err = yaml.Unmarshal(content, data); err != nil {
+		return nil, fmt.Errorf("unable to parse port registry: %w", err)
+	}
+	return data, nil
+}
+
+func (r *PortRegistry) update(modify func(data *portRegistryData) error) error {
+	if err := os.MkdirAll(r.Path, 0755); err != nil {
+		return fmt.Errorf("unable to create port registry directory: %w", err)
+	}
+	unlock, err := lockFile(path.Join(r.Path, portRegistryLockFileName))
+	if err != nil {
+		return fmt.Errorf("unable to lock port registry: %w", err)
+	}
+	defer unlock()
+	data, err := r.load()
+	if err != nil {
+		return err
+	}
+	if err = modify(data); err != nil {
+		return err
+	}
+	sort.Slice(data.Reservations, func(i, j int) bool {
+		if data.Reservations[i].Port != data.Reservations[j].Port {
+			return data.Reservations[i].Port < data.Reservations[j].Port
+		}
+		return data.Reservations[i].Namespace < data.Reservations[j].Namespace
+	})
+	content, err := yaml.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("unable to marshal port registry: %w", err)
+	}
+	// written aside and renamed, so that readers never see a partial file
+	registryFile := path.Join(r.Path, portRegistryFileName)
+	if err = os.WriteFile(registryFile+".tmp", content, 0644); err != nil {
+		return fmt.Errorf("unable to write port registry: %w", err)
+	}
+	if err = os.Rename(registryFile+".tmp", registryFile); err != nil {
+		return fmt.Errorf("unable to write port registry: %w", err)
+	}
+	return nil
+}
+
+// SitePortRequests returns the requests for the host ports bound by the
+// router of a site: its router accesses, its listeners and the health and
+// metrics endpoint. The skupper-local router access is requested when
+// not defined yet.
+func SitePortRequests(siteState *api.SiteState) []PortRequest {
+	var requests []PortRequest
+	if !siteState.HasRouterAccess() {
+		requests = append(requests, PortRequest{
+			Kind:      PortKindRouterAccess,
+			Name:      "skupper-local/normal",
+			Host:      "127.0.0.1",
+			StartPort: DefaultRouterAccessPort,
+		})
+	}
+	for _, name := range sortedNames(siteState.RouterAccesses) {
+		routerAccess := siteState.RouterAccesses[name]
+		for _, role := range routerAccess.Spec.Roles {
+			requests = append(requests, PortRequest{
+				Kind: PortKindRouterAccess,
+				Name: name + "/" + role.Name,
+				Host: routerAccess.Spec.BindHost,
+				Port: int(role.GetPort()),
+			})
+		}
+	}
+	for _, name := range sortedNames(siteState.Listeners) {
+		listener := siteState.Listeners[name]
+		requests = append(requests, PortRequest{
+			Kind:     PortKindListener,
+			Name:     name,
+			Host:     listener.Spec.Host,
+			Protocol: string(listener.Protocol()),
+			Port:     listener.Spec.Port,
+		})
+	}
+	requests = append(requests, PortRequest{
+		Kind:      PortKindMetrics,
+		Name:      "router",
+		StartPort: DefaultMetricsPort,
+	})
+	return requests
+}
+
+// ReservePorts reserves the host ports of a site, creating the
+// skupper-local router access with the port allocated for it. The port
+// allocated for the health and metrics endpoint of the router is
+// returned.
+func ReservePorts(siteState *api.SiteState) (int, error) {
+	return reservePorts(NewPortRegistry(), siteState)
+}
+
+func reservePorts(registry *PortRegistry, siteState *api.SiteState) (int, error) {
+	reservations, err := registry.Reserve(siteState.GetNamespace(), SitePortRequests(siteState))
+	if err != nil {
+		return 0, fmt.Errorf("unable to reserve ports: %w", err)
+	}
+	var metricsPort int
+	for _, reservation := range reservations {
+		switch {
+		case reservation.Kind == PortKindMetrics:
+			metricsPort = reservation.Port
+		case reservation.Kind == PortKindRouterAccess && reservation.Name == "skupper-local/normal" && !siteState.HasRouterAccess():
+			NewLogger().Debug("Creating skupper-local RouterAccess", "port", reservation.Port)
+			siteState.CreateRouterAccess("skupper-local", reservation.Port)
+		}
+	}
+	return metricsPort, nil
+}
+
+func sortedNames[T any](m map[string]T) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
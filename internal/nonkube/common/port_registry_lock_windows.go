@@ -0,0 +1,8 @@
+//go:build windows
+
+package common
+
+// lockFile is a no-op, as non-kubernetes sites are not supported on windows
+func lockFile(name string) (func(), error) {
+	return func() {}, nil
+}
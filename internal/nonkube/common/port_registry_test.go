@@ -0,0 +1,225 @@
+package common
+
+import (
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func newTestPortRegistry(t *testing.T, inUse ...int) *PortRegistry {
+	return &PortRegistry{
+		Path: t.TempDir(),
+		InUse: func(host string, port int) bool {
+			for _, p := range inUse {
+				if p == port {
+					return true
+				}
+			}
+			return false
+		},
+	}
+}
+
+func reservedPorts(reservations []PortReservation) map[string]int {
+	ports := map[string]int{}
+	for _, reservation := range reservations {
+		ports[reservation.Kind+"/"+reservation.Name] = reservation.Port
+	}
+	return ports
+}
+
+func TestPortRegistryReserve(t *testing.T) {
+	tests := []struct {
+		name          string
+		inUse         []int
+		existing      map[string][]PortRequest
+		namespace     string
+		requests      []PortRequest
+		expectedPorts map[string]int
+		expectedError string
+	}{
+		{
+			name:      "fixed and allocated ports",
+			namespace: "west",
+			requests: []PortRequest{
+				{Kind: PortKindListener, Name: "backend", Port: 8080},
+				{Kind: PortKindMetrics, Name: "router", StartPort: 9190},
+			},
+			expectedPorts: map[string]int{
+				"Listener/backend": 8080,
+				"Metrics/router":   9190,
+			},
+		},
+		{
+			name:      "allocated ports skip reserved and in use ports",
+			inUse:     []int{5672},
+			namespace: "west",
+			existing: map[string][]PortRequest{
+				"east": {{Kind: PortKindRouterAccess, Name: "skupper-local/normal", Host: "127.0.0.1", Port: 5671}},
+			},
+			requests: []PortRequest{
+				{Kind: PortKindRouterAccess, Name: "skupper-local/normal", Host: "127.0.0.1", StartPort: 5671},
+				{Kind: PortKindListener, Name: "backend", Port: 5673},
+			},
+			expectedPorts: map[string]int{
+				"RouterAccess/skupper-local/normal": 5674,
+				"Listener/backend":                  5673,
+			},
+		},
+		{
+			name:      "same port on different hosts",
+			namespace: "west",
+			existing: map[string][]PortRequest{
+				"east": {{Kind: PortKindListener, Name: "backend", Host: "10.0.0.1", Port: 8080}},
+			},
+			requests: []PortRequest{
+				{Kind: PortKindListener, Name: "backend", Host: "10.0.0.2", Port: 8080},
+			},
+			expectedPorts: map[string]int{
+				"Listener/backend": 8080,
+			},
+		},
+		{
+			name:      "same port for tcp and udp",
+			namespace: "west",
+			existing: map[string][]PortRequest{
+				"east": {{Kind: PortKindListener, Name: "dns", Protocol: "TCP", Port: 53}},
+			},
+			requests: []PortRequest{
+				{Kind: PortKindListener, Name: "dns", Protocol: "UDP", Port: 53},
+			},
+			expectedPorts: map[string]int{
+				"Listener/dns": 53,
+			},
+		},
+		{
+			name:      "port reserved by another namespace",
+			namespace: "west",
+			existing: map[string][]PortRequest{
+				"east": {{Kind: PortKindListener, Name: "db", Port: 5432}},
+			},
+			requests: []PortRequest{
+				{Kind: PortKindListener, Name: "backend", Host: "10.0.0.1", Port: 5432},
+			},
+			expectedError: `port 5432 requested by Listener "backend" of namespace "west" is already reserved by Listener "db" of namespace "east"`,
+		},
+		{
+			name:      "port requested twice in the same namespace",
+			namespace: "west",
+			requests: []PortRequest{
+				{Kind: PortKindRouterAccess, Name: "skupper-router/inter-router", Port: 55671},
+				{Kind: PortKindListener, Name: "backend", Port: 55671},
+			},
+			expectedError: `port 55671 requested by Listener "backend" of namespace "west" is already reserved by RouterAccess "skupper-router/inter-router" of namespace "west"`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			registry := newTestPortRegistry(t, test.inUse...)
+			for namespace, requests := range test.existing {
+				_, err := registry.Reserve(namespace, requests)
+				assert.Assert(t, err)
+			}
+			before, err := registry.List()
+			assert.Assert(t, err)
+			reservations, err := registry.Reserve(test.namespace, test.requests)
+			if test.expectedError != "" {
+				assert.ErrorContains(t, err, test.expectedError)
+				after, err := registry.List()
+				assert.Assert(t, err)
+				assert.DeepEqual(t, before, after)
+				return
+			}
+			assert.Assert(t, err)
+			assert.DeepEqual(t, reservedPorts(reservations), test.expectedPorts)
+			all, err := registry.List()
+			assert.Assert(t, err)
+			assert.Equal(t, len(all), len(before)+len(test.requests))
+		})
+	}
+}
+
+func TestPortRegistryRestart(t *testing.T) {
+	registry := newTestPortRegistry(t)
+	requests := []PortRequest{
+		{Kind: PortKindRouterAccess, Name: "skupper-local/normal", Host: "127.0.0.1", StartPort: 5671},
+		{Kind: PortKindMetrics, Name: "router", StartPort: 9190},
+	}
+	_, err := registry.Reserve("east", []PortRequest{{Kind: PortKindListener, Name: "amqp", Port: 5671}})
+	assert.Assert(t, err)
+	reservations, err := registry.Reserve("west", requests)
+	assert.Assert(t, err)
+	assert.DeepEqual(t, reservedPorts(reservations), map[string]int{
+		"RouterAccess/skupper-local/normal": 5672,
+		"Metrics/router":                    9190,
+	})
+
+	// ports allocated before are read back and kept, even if lower
+	// ones are now free
+	assert.Assert(t, registry.Release("east"))
+	registry = &PortRegistry{Path: registry.Path}
+	reservations, err = registry.Reserve("west", requests)
+	assert.Assert(t, err)
+	assert.DeepEqual(t, reservedPorts(reservations), map[string]int{
+		"RouterAccess/skupper-local/normal": 5672,
+		"Metrics/router":                    9190,
+	})
+
+	assert.Assert(t, registry.Release("west"))
+	all, err := registry.List()
+	assert.Assert(t, err)
+	assert.Equal(t, len(all), 0)
+}
+
+func TestPortRegistryCheck(t *testing.T) {
+	registry := newTestPortRegistry(t)
+	_, err := registry.Reserve("east", []PortRequest{
+		{Kind: PortKindListener, Name: "db", Port: 5432},
+		{Kind: PortKindMetrics, Name: "router", StartPort: 9190},
+	})
+	assert.Assert(t, err)
+	_, err = registry.Reserve("west", []PortRequest{
+		{Kind: PortKindMetrics, Name: "router", StartPort: 9190},
+	})
+	assert.Assert(t, err)
+
+	conflicts, err := registry.Check("west", []PortRequest{
+		{Kind: PortKindListener, Name: "backend", Port: 8080},
+		{Kind: PortKindListener, Name: "db", Port: 5432},
+		{Kind: PortKindMetrics, Name: "router", StartPort: 9190},
+	})
+	assert.Assert(t, err)
+	assert.Equal(t, len(conflicts), 1)
+	assert.Equal(t, conflicts[0].Requested.Name, "db")
+	assert.Equal(t, conflicts[0].Owner.Namespace, "east")
+	assert.Assert(t, strings.Contains(conflicts[0].Error(), "port 5432"))
+}
+
+func TestReservePorts(t *testing.T) {
+	registry := newTestPortRegistry(t)
+	siteState := fakeSiteState()
+	metricsPort, err := reservePorts(registry, siteState)
+	assert.Assert(t, err)
+	assert.Equal(t, metricsPort, DefaultMetricsPort)
+	assert.Assert(t, siteState.HasRouterAccess())
+	assert.Equal(t, siteState.RouterAccesses["skupper-local"].Spec.Roles[0].Port, DefaultRouterAccessPort)
+
+	reservations, err := registry.List()
+	assert.Assert(t, err)
+	assert.DeepEqual(t, reservedPorts(reservations), map[string]int{
+		"RouterAccess/skupper-local/normal":         DefaultRouterAccessPort,
+		"RouterAccess/link-access-one/inter-router": 55671,
+		"RouterAccess/link-access-one/edge":         45671,
+		"Listener/listener-one":                     1234,
+		"Listener/listener-two":                     1234,
+		"Metrics/router":                            DefaultMetricsPort,
+	})
+
+	// a second site cannot use the same listener port
+	otherSiteState := fakeSiteState()
+	otherSiteState.Site.Namespace = "other"
+	delete(otherSiteState.RouterAccesses, "link-access-one")
+	_, err = reservePorts(registry, otherSiteState)
+	assert.ErrorContains(t, err, `port 1234 requested by Listener "listener-one" of namespace "other" is already reserved by Listener "listener-one" of namespace "default"`)
+}
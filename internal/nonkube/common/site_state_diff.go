@@ -0,0 +1,143 @@
+package common
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+// ResourceChanges holds the names of the resources of a given kind
+// that have been added, updated or removed.
+type ResourceChanges struct {
+	Added   []string
+	Updated []string
+	Removed []string
+}
+
+func (r ResourceChanges) Empty() bool {
+	return len(r.Added) == 0 && len(r.Updated) == 0 && len(r.Removed) == 0
+}
+
+// SiteStateChanges describes the differences between the active and
+// the desired site states. Changes to Listeners, Connectors and Links
+// can be applied to a running router, while changes to any other
+// resource are reported through RestartReasons.
+type SiteStateChanges struct {
+	Listeners      ResourceChanges
+	Connectors     ResourceChanges
+	Links          ResourceChanges
+	RestartReasons []string
+}
+
+func (s *SiteStateChanges) Empty() bool {
+	return s.Listeners.Empty() && s.Connectors.Empty() && s.Links.Empty() && !s.RequiresRestart()
+}
+
+func (s *SiteStateChanges) RequiresRestart() bool {
+	return len(s.RestartReasons) > 0
+}
+
+// DiffSiteStates compares the active site state against the desired one.
+func DiffSiteStates(active *api.SiteState, desired *api.SiteState) *SiteStateChanges {
+	changes := &SiteStateChanges{
+		Listeners: diffResources(active.Listeners, desired.Listeners, func(a, b *v2alpha1.Listener) bool {
+			return equality.Semantic.DeepEqual(a.Spec, b.Spec)
+		}),
+		Connectors: diffResources(active.Connectors, desired.Connectors, func(a, b *v2alpha1.Connector) bool {
+			return equality.Semantic.DeepEqual(a.Spec, b.Spec)
+		}),
+		Links: diffResources(active.Links, desired.Links, func(a, b *v2alpha1.Link) bool {
+			return equality.Semantic.DeepEqual(a.Spec, b.Spec)
+		}),
+	}
+	if active.Site.Name != desired.Site.Name || !equality.Semantic.DeepEqual(active.Site.Spec, desired.Site.Spec) {
+		changes.RestartReasons = append(changes.RestartReasons, "Site has changed")
+	}
+	changes.restartOn("RouterAccess", diffResources(active.RouterAccesses, desired.RouterAccesses, func(a, b *v2alpha1.RouterAccess) bool {
+		return equality.Semantic.DeepEqual(a.Spec, b.Spec)
+	}))
+	changes.restartOn("Certificate", diffResources(active.Certificates, desired.Certificates, func(a, b *v2alpha1.Certificate) bool {
+		return equality.Semantic.DeepEqual(a.Spec, b.Spec)
+	}))
+	changes.restartOn("SecuredAccess", diffResources(active.SecuredAccesses, desired.SecuredAccesses, func(a, b *v2alpha1.SecuredAccess) bool {
+		return equality.Semantic.DeepEqual(a.Spec, b.Spec)
+	}))
+	changes.restartOn("AccessToken", diffResources(active.Claims, desired.Claims, func(a, b *v2alpha1.AccessToken) bool {
+		return equality.Semantic.DeepEqual(a.Spec, b.Spec)
+	}))
+	changes.restartOn("AccessGrant", diffResources(active.Grants, desired.Grants, func(a, b *v2alpha1.AccessGrant) bool {
+		return equality.Semantic.DeepEqual(a.Spec, b.Spec)
+	}))
+	changes.restartOn("Secret", diffResources(active.Secrets, desired.Secrets, func(a, b *corev1.Secret) bool {
+		return a.Type == b.Type && equality.Semantic.DeepEqual(a.Data, b.Data) &&
+			equality.Semantic.DeepEqual(a.StringData, b.StringData)
+	}))
+	changes.restartOn("ConfigMap", diffResources(active.ConfigMaps, desired.ConfigMaps, func(a, b *corev1.ConfigMap) bool {
+		return equality.Semantic.DeepEqual(a.Data, b.Data) && equality.Semantic.DeepEqual(a.BinaryData, b.BinaryData)
+	}))
+	return changes
+}
+
+func (s *SiteStateChanges) restartOn(kind string, changes ResourceChanges) {
+	for _, name := range changes.Added {
+		s.RestartReasons = append(s.RestartReasons, fmt.Sprintf("%s %q has been added", kind, name))
+	}
+	for _, name := range changes.Updated {
+		s.RestartReasons = append(s.RestartReasons, fmt.Sprintf("%s %q has been updated", kind, name))
+	}
+	for _, name := range changes.Removed {
+		s.RestartReasons = append(s.RestartReasons, fmt.Sprintf("%s %q has been removed", kind, name))
+	}
+}
+
+func diffResources[T any](active map[string]T, desired map[string]T, equal func(a, b T) bool) ResourceChanges {
+	changes := ResourceChanges{}
+	for name, d := range desired {
+		a, ok := active[name]
+		if !ok {
+			changes.Added = append(changes.Added, name)
+		} else if !equal(a, d) {
+			changes.Updated = append(changes.Updated, name)
+		}
+	}
+	for name := range active {
+		if _, ok := desired[name]; !ok {
+			changes.Removed = append(changes.Removed, name)
+		}
+	}
+	sort.Strings(changes.Added)
+	sort.Strings(changes.Updated)
+	sort.Strings(changes.Removed)
+	return changes
+}
+
+// ApplySiteStateChanges updates the Listeners, Connectors and Links of
+// the target site state with those from the desired one.
+func ApplySiteStateChanges(target *api.SiteState, desired *api.SiteState, changes *SiteStateChanges) {
+	desiredCopy := CopySiteState(desired)
+	applyResourceChanges(target.Listeners, desiredCopy.Listeners, changes.Listeners)
+	applyResourceChanges(target.Connectors, desiredCopy.Connectors, changes.Connectors)
+	applyResourceChanges(target.Links, desiredCopy.Links, changes.Links)
+}
+
+func applyResourceChanges[T any](target map[string]T, desired map[string]T, changes ResourceChanges) {
+	for _, name := range changes.Removed {
+		delete(target, name)
+	}
+	CopyChangedResources(target, desired, changes)
+}
+
+// CopyChangedResources copies the added and updated resources from the
+// source to the target.
+func CopyChangedResources[T any](target map[string]T, source map[string]T, changes ResourceChanges) {
+	for _, name := range changes.Added {
+		target[name] = source[name]
+	}
+	for _, name := range changes.Updated {
+		target[name] = source[name]
+	}
+}
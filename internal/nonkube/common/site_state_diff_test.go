@@ -0,0 +1,113 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDiffSiteStates(t *testing.T) {
+	tests := []struct {
+		name            string
+		modify          func(siteState *api.SiteState)
+		expected        SiteStateChanges
+		expectedEmpty   bool
+		expectedRestart bool
+	}{
+		{
+			name:          "no-changes",
+			modify:        func(siteState *api.SiteState) {},
+			expectedEmpty: true,
+		},
+		{
+			name: "status-only-changes",
+			modify: func(siteState *api.SiteState) {
+				siteState.Listeners["listener-one"].SetConfigured(nil)
+				siteState.Links["link-one"].SetConfigured(nil)
+			},
+			expectedEmpty: true,
+		},
+		{
+			name: "listeners-changed",
+			modify: func(siteState *api.SiteState) {
+				siteState.Listeners["listener-one"].Spec.Port = 4321
+				delete(siteState.Listeners, "listener-two")
+				siteState.Listeners["listener-three"] = &v2alpha1.Listener{
+					ObjectMeta: metav1.ObjectMeta{Name: "listener-three"},
+					Spec: v2alpha1.ListenerSpec{
+						RoutingKey: "listener-three-key",
+						Host:       "10.0.0.3",
+						Port:       1234,
+					},
+				}
+			},
+			expected: SiteStateChanges{
+				Listeners: ResourceChanges{
+					Added:   []string{"listener-three"},
+					Updated: []string{"listener-one"},
+					Removed: []string{"listener-two"},
+				},
+			},
+		},
+		{
+			name: "connectors-and-links-changed",
+			modify: func(siteState *api.SiteState) {
+				siteState.Connectors["connector-one"].Spec.Host = "connector-one-new-host"
+				siteState.Links["link-one"].Spec.Cost = 5
+			},
+			expected: SiteStateChanges{
+				Connectors: ResourceChanges{
+					Updated: []string{"connector-one"},
+				},
+				Links: ResourceChanges{
+					Updated: []string{"link-one"},
+				},
+			},
+		},
+		{
+			name: "site-changed",
+			modify: func(siteState *api.SiteState) {
+				siteState.Site.Spec.Edge = true
+			},
+			expected: SiteStateChanges{
+				RestartReasons: []string{"Site has changed"},
+			},
+			expectedRestart: true,
+		},
+		{
+			name: "router-access-and-secrets-changed",
+			modify: func(siteState *api.SiteState) {
+				siteState.RouterAccesses["link-access-one"].Spec.BindHost = "0.0.0.0"
+				siteState.Secrets["link-one"].Data["tls.crt"] = []byte("new-tls.crt")
+				siteState.Secrets["link-two"] = &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "link-two"},
+				}
+			},
+			expected: SiteStateChanges{
+				RestartReasons: []string{
+					`RouterAccess "link-access-one" has been updated`,
+					`Secret "link-two" has been added`,
+					`Secret "link-one" has been updated`,
+				},
+			},
+			expectedRestart: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			active := fakeSiteState()
+			desired := CopySiteState(active)
+			test.modify(desired)
+			changes := DiffSiteStates(active, desired)
+			assert.Equal(t, changes.Empty(), test.expectedEmpty)
+			assert.Equal(t, changes.RequiresRestart(), test.expectedRestart)
+			if !test.expectedEmpty {
+				assert.DeepEqual(t, *changes, test.expected)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"net"
 	"regexp"
 
+	"github.com/skupperproject/skupper/internal/site"
 	"github.com/skupperproject/skupper/internal/utils"
 	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
 	"github.com/skupperproject/skupper/pkg/nonkube/api"
@@ -129,8 +130,10 @@ func (s *SiteStateValidator) validateGrants(grants map[string]*v2alpha1.AccessGr
 }
 
 func (s *SiteStateValidator) validateListeners(listeners map[string]*v2alpha1.Listener) error {
+	// tcp and udp ports of the same host do not conflict
 	hostPorts := map[string][]int{}
 	for name, listener := range listeners {
+		hostProtocol := listener.Spec.Host + "/" + string(listener.Protocol())
 		if err := ValidateName(listener.Name); err != nil {
 			return fmt.Errorf("invalid listener name: %w", err)
 		}
@@ -142,13 +145,16 @@ func (s *SiteStateValidator) validateListeners(listeners map[string]*v2alpha1.Li
 		if ip == nil && !validHostname {
 			return fmt.Errorf("invalid listener host: %s - a valid IP address or hostname is expected (listener: %q)", listener.Spec.Host, name)
 		}
-		if utils.IntSliceContains(hostPorts[listener.Spec.Host], listener.Spec.Port) {
+		if utils.IntSliceContains(hostPorts[hostProtocol], listener.Spec.Port) {
 			return fmt.Errorf("port %d is already mapped for host %q (listener: %q)", listener.Spec.Port, listener.Spec.Host, name)
 		}
 		if listener.Spec.RoutingKey == "" {
 			return fmt.Errorf("routingKey is missing for listener: %s", listener.Name)
 		}
-		hostPorts[listener.Spec.Host] = append(hostPorts[listener.Spec.Host], listener.Spec.Port)
+		if err := site.ValidateBindingType(listener.Spec.Type); err != nil {
+			return fmt.Errorf("invalid listener: %s - %w", listener.Name, err)
+		}
+		hostPorts[hostProtocol] = append(hostPorts[hostProtocol], listener.Spec.Port)
 	}
 	return nil
 }
@@ -169,6 +175,9 @@ func (s *SiteStateValidator) validateConnectors(connectors map[string]*v2alpha1.
 		if connector.Spec.RoutingKey == "" {
 			return fmt.Errorf("routingKey is missing for connector: %s", connector.Name)
 		}
+		if err := site.ValidateBindingType(connector.Spec.Type); err != nil {
+			return fmt.Errorf("invalid connector: %s - %w", connector.Name, err)
+		}
 	}
 	return nil
 }
@@ -160,6 +160,53 @@ func TestSiteStateValidator_Validate(t *testing.T) {
 			valid:         false,
 			errorContains: "is already mapped for host",
 		},
+		{
+			info: "valid-listener-same-port-tcp-and-udp",
+			siteState: customize(func(siteState *api.SiteState) {
+				siteState.Listeners["listener-one"].Spec.Host = "1.2.3.4"
+				siteState.Listeners["listener-two"].Spec.Host = "1.2.3.4"
+				siteState.Listeners["listener-two"].Spec.Type = "udp"
+			}),
+			valid: true,
+		},
+		{
+			info: "invalid-listener-type",
+			siteState: customize(func(siteState *api.SiteState) {
+				for _, listener := range siteState.Listeners {
+					listener.Spec.Type = "sctp"
+				}
+			}),
+			valid:         false,
+			errorContains: "invalid type \"sctp\"",
+		},
+		{
+			info: "valid-listener-type-http2",
+			siteState: customize(func(siteState *api.SiteState) {
+				for _, listener := range siteState.Listeners {
+					listener.Spec.Type = "http2"
+				}
+			}),
+			valid: true,
+		},
+		{
+			info: "invalid-connector-type",
+			siteState: customize(func(siteState *api.SiteState) {
+				for _, connector := range siteState.Connectors {
+					connector.Spec.Type = "sctp"
+				}
+			}),
+			valid:         false,
+			errorContains: "invalid type \"sctp\"",
+		},
+		{
+			info: "valid-connector-type-http",
+			siteState: customize(func(siteState *api.SiteState) {
+				for _, connector := range siteState.Connectors {
+					connector.Spec.Type = "http"
+				}
+			}),
+			valid: true,
+		},
 		{
 			info: "invalid-connector-name",
 			siteState: customize(func(siteState *api.SiteState) {
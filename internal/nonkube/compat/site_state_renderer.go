@@ -121,7 +121,8 @@ func (s *SiteStateRenderer) Render(loadedSiteState *api.SiteState, reload bool)
 	if err != nil {
 		return fmt.Errorf("failed to redeem claims: %v", err)
 	}
-	if err = common.CreateRouterAccess(s.siteState); err != nil {
+	metricsPort, err := common.ReservePorts(s.siteState)
+	if err != nil {
 		return err
 	}
 	s.siteState.CreateLinkAccessesCertificates()
@@ -132,7 +133,8 @@ func (s *SiteStateRenderer) Render(loadedSiteState *api.SiteState, reload bool)
 		platform = types.PlatformDocker
 	}
 	s.configRenderer = &common.FileSystemConfigurationRenderer{
-		Platform: string(platform),
+		Platform:    string(platform),
+		MetricsPort: metricsPort,
 	}
 	err = s.configRenderer.Render(s.siteState)
 	if err != nil {
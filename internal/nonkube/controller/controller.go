@@ -7,6 +7,7 @@ import (
 	"sync"
 	"syscall"
 
+	"github.com/skupperproject/skupper/internal/nonkube/grants"
 	"github.com/skupperproject/skupper/pkg/nonkube/api"
 )
 
@@ -15,14 +16,25 @@ const (
 )
 
 type Controller struct {
-	nsHandler *NamespacesHandler
+	nsHandler   *NamespacesHandler
+	grantServer *grants.GrantServer
 }
 
-func NewController() (*Controller, error) {
+func NewController(grantConfig *grants.Config) (*Controller, error) {
 	var err error
 	c := &Controller{}
 	c.nsHandler, err = NewNamespacesHandler()
-	return c, err
+	if err != nil {
+		return c, err
+	}
+	if grantConfig != nil && grantConfig.Enabled {
+		c.grantServer, err = grants.NewGrantServer(grantConfig)
+		if err != nil {
+			return c, err
+		}
+		c.nsHandler.grantServer = c.grantServer
+	}
+	return c, nil
 }
 
 func (c *Controller) Start() (chan struct{}, *sync.WaitGroup) {
@@ -33,6 +45,13 @@ func (c *Controller) Start() (chan struct{}, *sync.WaitGroup) {
 	if err := c.nsHandler.Start(stop, wg); err != nil {
 		log.Fatalf("error starting controller: %v", err)
 	}
+	if c.grantServer != nil {
+		c.grantServer.Start()
+		go func() {
+			<-stop
+			c.grantServer.Stop()
+		}()
+	}
 	log.Println("Controller started")
 	return stop, wg
 }
@@ -0,0 +1,347 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/skupperproject/skupper/api/types"
+	"github.com/skupperproject/skupper/internal/nonkube/bootstrap"
+	"github.com/skupperproject/skupper/internal/nonkube/client/runtime"
+	"github.com/skupperproject/skupper/internal/nonkube/common"
+	"github.com/skupperproject/skupper/internal/qdr"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+)
+
+var (
+	// inputResourcesDelay is the time to wait after the last change to
+	// the input resources before reconciling, so that several files
+	// written together are handled at once.
+	inputResourcesDelay = time.Second
+)
+
+// RouterManagementAgent is the subset of the router management
+// operations used to apply changes to a running router.
+type RouterManagementAgent interface {
+	UpdateLocalBridgeConfig(changes *qdr.BridgeConfigDifference) error
+	UpdateConnectorConfig(changes *qdr.ConnectorDifference) error
+	Close() error
+}
+
+// InputResourcesHandler watches the input resources of a namespace
+// and reconciles the running site with them. Changes to Listeners,
+// Connectors and Links are applied to the running router through its
+// management interface, while any other change causes the site to be
+// reloaded, as done by "skupper system reload".
+type InputResourcesHandler struct {
+	namespace string
+	logger    *slog.Logger
+	mutex     sync.Mutex
+	timer     *time.Timer
+	reconcile sync.Mutex
+	connect   func(namespace string) (RouterManagementAgent, error)
+	reload    func(namespace string) error
+	ports     *common.PortRegistry
+}
+
+func NewInputResourcesHandler(namespace string) *InputResourcesHandler {
+	return &InputResourcesHandler{
+		namespace: namespace,
+		logger: slog.Default().
+			With("component", "input.resources.handler").
+			With("namespace", namespace),
+		connect: connectLocalRouter,
+		reload:  reloadNamespace,
+		ports:   common.NewPortRegistry(),
+	}
+}
+
+func (h *InputResourcesHandler) OnBasePathAdded(basePath string) {
+}
+
+func (h *InputResourcesHandler) OnCreate(name string) {
+	h.schedule()
+}
+
+func (h *InputResourcesHandler) OnUpdate(name string) {
+	h.schedule()
+}
+
+func (h *InputResourcesHandler) OnRemove(name string) {
+	h.schedule()
+}
+
+func (h *InputResourcesHandler) Filter(name string) bool {
+	return strings.HasSuffix(name, ".yaml") || strings.HasSuffix(name, ".yml")
+}
+
+// schedule (re)starts the timer that triggers the reconciliation, as
+// file watcher handlers are expected to return immediately.
+func (h *InputResourcesHandler) schedule() {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if h.timer != nil {
+		h.timer.Stop()
+	}
+	h.timer = time.AfterFunc(inputResourcesDelay, func() {
+		if err := h.Reconcile(); err != nil {
+			h.logger.Error("Unable to reconcile input resources", slog.Any("error", err))
+		}
+	})
+}
+
+// Reconcile compares the input resources against the resources the
+// site is currently running with, applying the differences found.
+func (h *InputResourcesHandler) Reconcile() error {
+	h.reconcile.Lock()
+	defer h.reconcile.Unlock()
+
+	desired, err := h.loadSiteState(api.InputSiteStatePath)
+	if err != nil {
+		return fmt.Errorf("unable to load input resources: %w", err)
+	}
+	active, err := h.loadSiteState(api.LoadedSiteStatePath)
+	if err != nil {
+		h.logger.Debug("Site has not been initialized, ignoring input resources", slog.Any("error", err))
+		return nil
+	}
+	validator := &common.SiteStateValidator{}
+	if err = validator.Validate(desired); err != nil {
+		return fmt.Errorf("invalid input resources: %w", err)
+	}
+	changes := common.DiffSiteStates(active, desired)
+	if changes.Empty() {
+		h.logger.Debug("Input resources are up to date")
+		return nil
+	}
+	if changes.RequiresRestart() {
+		return h.restart(changes.RestartReasons...)
+	}
+
+	runtimeState, err := h.loadSiteState(api.RuntimeSiteStatePath)
+	if err != nil {
+		return fmt.Errorf("unable to load runtime resources: %w", err)
+	}
+	routerConfig, err := common.LoadRouterConfig(h.namespace)
+	if err != nil {
+		return err
+	}
+	common.ApplySiteStateChanges(active, desired, changes)
+	common.ApplySiteStateChanges(runtimeState, desired, changes)
+
+	runtimeState.SiteId = routerConfig.GetSiteMetadata().Id
+	desiredConfig := common.CopySiteState(runtimeState).ToRouterConfig(common.DefaultSslProfileBasePath, "")
+	for name := range desiredConfig.SslProfiles {
+		if _, ok := routerConfig.SslProfiles[name]; !ok {
+			return h.restart(fmt.Sprintf("TLS profile %q is not yet defined", name))
+		}
+	}
+	if !changes.Listeners.Empty() {
+		if err = h.reservePorts(runtimeState); err != nil {
+			return err
+		}
+	}
+	bridgeChanges := routerConfig.Bridges.Difference(&desiredConfig.Bridges)
+	connectorChanges := routerConnectorsDifference(routerConfig.Connectors, desiredConfig.Connectors)
+	routerConfig.Bridges = desiredConfig.Bridges
+	routerConfig.Connectors = desiredConfig.Connectors
+
+	if !bridgeChanges.Empty() || !connectorChanges.Empty() {
+		agent, err := h.connect(h.namespace)
+		if err != nil {
+			h.logger.Info("Router is not reachable, changes will be applied when it starts", slog.Any("error", err))
+		} else {
+			defer agent.Close()
+			if err = agent.UpdateLocalBridgeConfig(bridgeChanges); err != nil {
+				return h.restart(fmt.Sprintf("unable to update listeners and connectors: %s", err))
+			}
+			if err = agent.UpdateConnectorConfig(connectorChanges); err != nil {
+				return h.restart(fmt.Sprintf("unable to update links: %s", err))
+			}
+		}
+	}
+	if err = h.saveRouterConfig(routerConfig); err != nil {
+		return err
+	}
+	if err = h.saveSiteState(api.LoadedSiteStatePath, active, changes); err != nil {
+		return err
+	}
+	if err = h.saveSiteState(api.RuntimeSiteStatePath, runtimeState, changes); err != nil {
+		return err
+	}
+	h.logger.Info("Input resources applied",
+		slog.Any("listeners", changes.Listeners),
+		slog.Any("connectors", changes.Connectors),
+		slog.Any("links", changes.Links))
+	return nil
+}
+
+// reservePorts updates the host ports reserved by the site for the
+// listeners it now defines, refusing the changes if a port is reserved
+// by another site. The ports allocated to the running router are kept.
+func (h *InputResourcesHandler) reservePorts(siteState *api.SiteState) error {
+	requests := common.SitePortRequests(siteState)
+	conflicts, err := h.ports.Check(h.namespace, requests)
+	if err != nil {
+		return err
+	}
+	if len(conflicts) > 0 {
+		var errs []error
+		for _, conflict := range conflicts {
+			errs = append(errs, conflict)
+		}
+		return fmt.Errorf("input resources refused: %w", errors.Join(errs...))
+	}
+	reservations, err := h.ports.List()
+	if err != nil {
+		return err
+	}
+	for i, request := range requests {
+		if request.Port != 0 {
+			continue
+		}
+		for _, reservation := range reservations {
+			if reservation.Namespace == h.namespace && reservation.Kind == request.Kind && reservation.Name == request.Name {
+				requests[i].Port = reservation.Port
+			}
+		}
+	}
+	if _, err = h.ports.Reserve(h.namespace, requests); err != nil {
+		return fmt.Errorf("unable to reserve ports: %w", err)
+	}
+	return nil
+}
+
+func (h *InputResourcesHandler) restart(reasons ...string) error {
+	h.logger.Info("Input resources cannot be applied to the running router, reloading site",
+		slog.Any("reasons", reasons))
+	if err := h.reload(h.namespace); err != nil {
+		return fmt.Errorf("unable to reload site: %w", err)
+	}
+	return nil
+}
+
+func (h *InputResourcesHandler) loadSiteState(internalPath api.InternalPath) (*api.SiteState, error) {
+	loader := &common.FileSystemSiteStateLoader{
+		Path: api.GetInternalOutputPath(h.namespace, internalPath),
+	}
+	return loader.Load()
+}
+
+func (h *InputResourcesHandler) saveRouterConfig(routerConfig *qdr.RouterConfig) error {
+	routerConfigJson, err := qdr.MarshalRouterConfig(*routerConfig)
+	if err != nil {
+		return fmt.Errorf("unable to marshal router config: %w", err)
+	}
+	routerConfigFileName := path.Join(api.GetInternalOutputPath(h.namespace, api.RouterConfigPath), "skrouterd.json")
+	if err = os.WriteFile(routerConfigFileName, []byte(routerConfigJson), 0644); err != nil {
+		return fmt.Errorf("unable to write router config file: %w", err)
+	}
+	return nil
+}
+
+// saveSiteState writes the Listeners, Connectors and Links affected by
+// the given changes, removing the files of those that no longer exist.
+func (h *InputResourcesHandler) saveSiteState(internalPath api.InternalPath, siteState *api.SiteState, changes *common.SiteStateChanges) error {
+	outputPath := api.GetInternalOutputPath(h.namespace, internalPath)
+	changed := api.NewSiteState(siteState.IsBundle())
+	changed.SiteId = siteState.SiteId
+	changed.Site = siteState.Site
+	common.CopyChangedResources(changed.Listeners, siteState.Listeners, changes.Listeners)
+	common.CopyChangedResources(changed.Connectors, siteState.Connectors, changes.Connectors)
+	common.CopyChangedResources(changed.Links, siteState.Links, changes.Links)
+	if err := api.MarshalSiteState(*changed, outputPath); err != nil {
+		return err
+	}
+	removed := map[string][]string{
+		"Listener":  changes.Listeners.Removed,
+		"Connector": changes.Connectors.Removed,
+		"Link":      changes.Links.Removed,
+	}
+	for resourceType, names := range removed {
+		for _, name := range names {
+			fileName := path.Join(outputPath, fmt.Sprintf("%s-%s.yaml", resourceType, name))
+			if err := os.Remove(fileName); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("unable to remove %s: %w", fileName, err)
+			}
+		}
+	}
+	return nil
+}
+
+// routerConnectorsDifference returns the router connectors (used by
+// links) to be deleted and added, a modified connector being deleted
+// and added again.
+func routerConnectorsDifference(actual map[string]qdr.Connector, desired map[string]qdr.Connector) *qdr.ConnectorDifference {
+	result := &qdr.ConnectorDifference{}
+	for name, connector := range desired {
+		current, ok := actual[name]
+		if !ok {
+			result.Added = append(result.Added, connector)
+		} else if current != connector {
+			result.Deleted = append(result.Deleted, current)
+			result.Added = append(result.Added, connector)
+		}
+	}
+	for name, connector := range actual {
+		if _, ok := desired[name]; !ok {
+			result.Deleted = append(result.Deleted, connector)
+		}
+	}
+	return result
+}
+
+func connectLocalRouter(namespace string) (RouterManagementAgent, error) {
+	url, err := runtime.GetLocalRouterAddress(namespace)
+	if err != nil {
+		return nil, err
+	}
+	agent, err := qdr.Connect(url, runtime.GetRuntimeTlsCert(namespace, "skupper-local-client"))
+	if err != nil {
+		return nil, err
+	}
+	return agent, nil
+}
+
+// reloadNamespace reloads the site as "skupper system reload" does,
+// discarding the progress messages meant for the command line.
+func reloadNamespace(namespace string) error {
+	platformLoader := &common.NamespacePlatformLoader{}
+	platform, err := platformLoader.Load(namespace)
+	if err != nil {
+		return err
+	}
+	config := &bootstrap.Config{
+		Namespace: namespace,
+		Platform:  types.Platform(platform),
+		Binary:    platformBinary(types.Platform(platform)),
+		Out:       io.Discard,
+	}
+	if err := bootstrap.PreBootstrap(config); err != nil {
+		return err
+	}
+	siteState, err := bootstrap.Bootstrap(config)
+	if err != nil {
+		return fmt.Errorf("Failed to bootstrap: %s", err)
+	}
+	bootstrap.PostBootstrap(config, siteState)
+	return nil
+}
+
+// platformBinary returns the command the platform requires.
+func platformBinary(platform types.Platform) string {
+	switch platform {
+	case types.PlatformLinux:
+		return "skrouterd"
+	case types.PlatformDocker:
+		return "docker"
+	default:
+		return "podman"
+	}
+}
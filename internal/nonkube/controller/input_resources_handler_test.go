@@ -0,0 +1,309 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/nonkube/common"
+	"github.com/skupperproject/skupper/internal/qdr"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestInputResourcesHandler(t *testing.T) {
+	tempDir := t.TempDir()
+	if os.Getuid() == 0 {
+		api.DefaultRootDataHome = tempDir
+	} else {
+		t.Setenv("XDG_DATA_HOME", tempDir)
+	}
+
+	newListener := func(name string, tlsCredentials string) *v2alpha1.Listener {
+		return &v2alpha1.Listener{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Listener",
+				APIVersion: "skupper.io/v2alpha1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: v2alpha1.ListenerSpec{
+				RoutingKey:     name + "-key",
+				Host:           name + "-host",
+				Port:           8080,
+				TlsCredentials: tlsCredentials,
+				Type:           "tcp",
+			},
+		}
+	}
+
+	tests := []struct {
+		name                    string
+		modify                  func(siteState *api.SiteState)
+		unreachable             bool
+		agentError              error
+		expectReload            bool
+		expectAgent             bool
+		expectedAddedListeners  []string
+		expectedDeletedListener []string
+		expectedAddedLinks      []string
+		expectedDeletedLinks    []string
+		expectedFiles           []string
+		expectedMissingFiles    []string
+		reserved                []common.PortReservation
+		expectedError           string
+	}{
+		{
+			name:   "no-changes",
+			modify: func(siteState *api.SiteState) {},
+		},
+		{
+			name: "listeners-changed",
+			modify: func(siteState *api.SiteState) {
+				siteState.Listeners["listener-three"] = newListener("listener-three", "")
+				delete(siteState.Listeners, "listener-two")
+			},
+			expectAgent:             true,
+			expectedAddedListeners:  []string{"listener-three"},
+			expectedDeletedListener: []string{"listener-two"},
+			expectedFiles:           []string{"Listener-listener-three.yaml"},
+			expectedMissingFiles:    []string{"Listener-listener-two.yaml"},
+		},
+		{
+			name: "link-changed",
+			modify: func(siteState *api.SiteState) {
+				siteState.Links["link-one"].Spec.Cost = 5
+			},
+			expectAgent:          true,
+			expectedAddedLinks:   []string{"link-one"},
+			expectedDeletedLinks: []string{"link-one"},
+			expectedFiles:        []string{"Link-link-one.yaml"},
+		},
+		{
+			name: "site-changed",
+			modify: func(siteState *api.SiteState) {
+				siteState.Site.Spec.LinkAccess = "default"
+			},
+			expectReload: true,
+		},
+		{
+			name: "new-tls-profile",
+			modify: func(siteState *api.SiteState) {
+				siteState.Listeners["listener-three"] = newListener("listener-three", "listener-three-credentials")
+			},
+			expectReload: true,
+		},
+		{
+			name: "management-error",
+			modify: func(siteState *api.SiteState) {
+				siteState.Listeners["listener-three"] = newListener("listener-three", "")
+			},
+			agentError:   errors.New("bad request"),
+			expectAgent:  true,
+			expectReload: true,
+		},
+		{
+			name: "router-unreachable",
+			modify: func(siteState *api.SiteState) {
+				siteState.Listeners["listener-three"] = newListener("listener-three", "")
+			},
+			unreachable:   true,
+			expectedFiles: []string{"Listener-listener-three.yaml"},
+		},
+		{
+			name: "port-conflict",
+			modify: func(siteState *api.SiteState) {
+				siteState.Listeners["listener-three"] = newListener("listener-three", "")
+			},
+			reserved: []common.PortReservation{
+				{Namespace: "other", Kind: common.PortKindListener, Name: "web", Port: 8080},
+			},
+			expectedError:        `input resources refused: port 8080 requested by Listener "listener-three" of namespace "input-resources-port-conflict" is already reserved by Listener "web" of namespace "other"`,
+			expectedMissingFiles: []string{"Listener-listener-three.yaml"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			namespace := "input-resources-" + test.name
+			siteState := fakeSiteState()
+			siteState.Secrets["link-one"] = siteState.Secrets["link-one-profile"].DeepCopy()
+			siteState.Secrets["link-one"].Name = "link-one"
+			siteState.SetNamespace(namespace)
+			for _, dir := range []api.InternalPath{api.InputSiteStatePath, api.LoadedSiteStatePath, api.RuntimeSiteStatePath, api.RouterConfigPath} {
+				assert.Assert(t, os.MkdirAll(api.GetInternalOutputPath(namespace, dir), 0755))
+			}
+			assert.Assert(t, api.MarshalSiteState(*siteState, api.GetInternalOutputPath(namespace, api.RuntimeSiteStatePath)))
+			// the local router access only exists in the runtime state
+			routerConfig := common.CopySiteState(siteState).ToRouterConfig(common.DefaultSslProfileBasePath, "podman")
+			routerConfigJson, err := qdr.MarshalRouterConfig(routerConfig)
+			assert.Assert(t, err)
+			routerConfigFile := path.Join(api.GetInternalOutputPath(namespace, api.RouterConfigPath), "skrouterd.json")
+			assert.Assert(t, os.WriteFile(routerConfigFile, []byte(routerConfigJson), 0644))
+			delete(siteState.RouterAccesses, "skupper-local")
+			assert.Assert(t, api.MarshalSiteState(*siteState, api.GetInternalOutputPath(namespace, api.LoadedSiteStatePath)))
+
+			test.modify(siteState)
+			for _, listener := range siteState.Listeners {
+				listener.Namespace = namespace
+			}
+			assert.Assert(t, api.MarshalSiteState(*siteState, api.GetInternalOutputPath(namespace, api.InputSiteStatePath)))
+
+			agent := &fakeManagementAgent{err: test.agentError}
+			reloaded := false
+			handler := NewInputResourcesHandler(namespace)
+			handler.connect = func(namespace string) (RouterManagementAgent, error) {
+				if test.unreachable {
+					return nil, fmt.Errorf("connection refused")
+				}
+				return agent, nil
+			}
+			handler.reload = func(namespace string) error {
+				reloaded = true
+				return nil
+			}
+			handler.ports = &common.PortRegistry{Path: t.TempDir()}
+			for _, reservation := range test.reserved {
+				_, err := handler.ports.Reserve(reservation.Namespace, []common.PortRequest{
+					{Kind: reservation.Kind, Name: reservation.Name, Port: reservation.Port},
+				})
+				assert.Assert(t, err)
+			}
+			if test.expectedError != "" {
+				assert.Error(t, handler.Reconcile(), test.expectedError)
+			} else {
+				assert.Assert(t, handler.Reconcile())
+			}
+			assert.Equal(t, reloaded, test.expectReload)
+			assert.Equal(t, agent.called, test.expectAgent)
+			if test.expectAgent && test.agentError == nil {
+				assert.Assert(t, agent.closed)
+				assert.DeepEqual(t, agent.addedListeners(), test.expectedAddedListeners)
+				assert.DeepEqual(t, agent.bridges.TcpListeners.Deleted, test.expectedDeletedListener)
+				assert.DeepEqual(t, agent.addedLinks(), test.expectedAddedLinks)
+				assert.DeepEqual(t, agent.deletedLinks(), test.expectedDeletedLinks)
+			}
+			for _, dir := range []api.InternalPath{api.LoadedSiteStatePath, api.RuntimeSiteStatePath} {
+				for _, file := range test.expectedFiles {
+					_, err := os.Stat(path.Join(api.GetInternalOutputPath(namespace, dir), file))
+					assert.Assert(t, err, "%s not found in %s", file, dir)
+				}
+				for _, file := range test.expectedMissingFiles {
+					_, err := os.Stat(path.Join(api.GetInternalOutputPath(namespace, dir), file))
+					assert.Assert(t, os.IsNotExist(err), "%s should have been removed from %s", file, dir)
+				}
+			}
+			savedConfig, err := common.LoadRouterConfig(namespace)
+			assert.Assert(t, err)
+			assert.Equal(t, savedConfig.Metadata.Id, routerConfig.Metadata.Id)
+			for _, name := range test.expectedAddedListeners {
+				_, ok := savedConfig.Bridges.TcpListeners[name]
+				assert.Assert(t, ok, "listener %s not found in router config", name)
+			}
+			for _, name := range test.expectedDeletedListener {
+				_, ok := savedConfig.Bridges.TcpListeners[name]
+				assert.Assert(t, !ok, "listener %s should have been removed from router config", name)
+			}
+			reservations, err := handler.ports.List()
+			assert.Assert(t, err)
+			reserved := map[string]bool{}
+			for _, reservation := range reservations {
+				if reservation.Namespace == namespace && reservation.Kind == common.PortKindListener {
+					reserved[reservation.Name] = true
+				}
+			}
+			for _, name := range test.expectedAddedListeners {
+				assert.Assert(t, reserved[name], "port of listener %s not reserved", name)
+			}
+			for _, name := range test.expectedDeletedListener {
+				assert.Assert(t, !reserved[name], "port of listener %s still reserved", name)
+			}
+		})
+	}
+}
+
+func TestInputResourcesHandlerNotInitialized(t *testing.T) {
+	tempDir := t.TempDir()
+	if os.Getuid() == 0 {
+		api.DefaultRootDataHome = tempDir
+	} else {
+		t.Setenv("XDG_DATA_HOME", tempDir)
+	}
+	namespace := "input-resources-not-initialized"
+	inputPath := api.GetInternalOutputPath(namespace, api.InputSiteStatePath)
+	assert.Assert(t, os.MkdirAll(inputPath, 0755))
+	siteState := fakeSiteState()
+	siteState.Secrets["link-one"] = siteState.Secrets["link-one-profile"].DeepCopy()
+	siteState.Secrets["link-one"].Name = "link-one"
+	siteState.SetNamespace(namespace)
+	delete(siteState.RouterAccesses, "skupper-local")
+	assert.Assert(t, api.MarshalSiteState(*siteState, inputPath))
+
+	handler := NewInputResourcesHandler(namespace)
+	handler.connect = func(namespace string) (RouterManagementAgent, error) {
+		t.Fatal("router must not be contacted")
+		return nil, nil
+	}
+	handler.reload = func(namespace string) error {
+		t.Fatal("site must not be reloaded")
+		return nil
+	}
+	assert.Assert(t, handler.Reconcile())
+}
+
+type fakeManagementAgent struct {
+	err        error
+	called     bool
+	closed     bool
+	bridges    *qdr.BridgeConfigDifference
+	connectors *qdr.ConnectorDifference
+}
+
+func (f *fakeManagementAgent) UpdateLocalBridgeConfig(changes *qdr.BridgeConfigDifference) error {
+	f.called = true
+	f.bridges = changes
+	return f.err
+}
+
+func (f *fakeManagementAgent) UpdateConnectorConfig(changes *qdr.ConnectorDifference) error {
+	f.called = true
+	f.connectors = changes
+	return f.err
+}
+
+func (f *fakeManagementAgent) Close() error {
+	f.closed = true
+	return nil
+}
+
+func (f *fakeManagementAgent) addedListeners() []string {
+	var names []string
+	for _, listener := range f.bridges.TcpListeners.Added {
+		names = append(names, listener.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (f *fakeManagementAgent) addedLinks() []string {
+	var names []string
+	for _, connector := range f.connectors.Added {
+		names = append(names, connector.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (f *fakeManagementAgent) deletedLinks() []string {
+	var names []string
+	for _, connector := range f.connectors.Deleted {
+		names = append(names, connector.Name)
+	}
+	sort.Strings(names)
+	return names
+}
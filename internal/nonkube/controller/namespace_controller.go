@@ -5,6 +5,7 @@ import (
 	"log/slog"
 
 	"github.com/skupperproject/skupper/internal/filesystem"
+	"github.com/skupperproject/skupper/internal/nonkube/grants"
 	"github.com/skupperproject/skupper/pkg/nonkube/api"
 )
 
@@ -13,6 +14,7 @@ type NamespaceController struct {
 	stopCh  chan struct{}
 	logger  *slog.Logger
 	watcher *filesystem.FileWatcher
+	grants  *grants.NamespaceGrants
 	prepare func()
 }
 
@@ -41,6 +43,11 @@ func (w *NamespaceController) Start() {
 		routerStateHandler.SetCallback(collectorLifecycleHandler)
 		w.watcher.Add(api.GetInternalOutputPath(w.ns, api.RouterConfigPath), routerConfigHandler)
 		w.watcher.Add(api.GetInternalOutputPath(w.ns, api.RuntimeSiteStatePath), NewNetworkStatusHandler(w.ns))
+		w.watcher.Add(api.GetInternalOutputPath(w.ns, api.InputSiteStatePath), NewInputResourcesHandler(w.ns))
+		if w.grants != nil {
+			w.watcher.Add(w.grants.LinksPath(), w.grants)
+			w.watcher.Add(w.grants.GrantsPath(), w.grants)
+		}
 	} else {
 		w.prepare()
 	}
@@ -9,15 +9,17 @@ import (
 	"sync"
 
 	"github.com/skupperproject/skupper/internal/filesystem"
+	"github.com/skupperproject/skupper/internal/nonkube/grants"
 	"github.com/skupperproject/skupper/pkg/nonkube/api"
 )
 
 type NamespacesHandler struct {
-	logger     *slog.Logger
-	basePath   string
-	watcher    *filesystem.FileWatcher
-	namespaces map[string]*NamespaceController
-	mutex      sync.Mutex
+	logger      *slog.Logger
+	basePath    string
+	watcher     *filesystem.FileWatcher
+	namespaces  map[string]*NamespaceController
+	grantServer *grants.GrantServer
+	mutex       sync.Mutex
 }
 
 func (n *NamespacesHandler) OnBasePathAdded(basePath string) {
@@ -111,6 +113,9 @@ func (n *NamespacesHandler) OnCreate(name string) {
 				slog.String("namespace", ns),
 				slog.Any("error", err))
 		}
+		if n.grantServer != nil {
+			nsc.grants = n.grantServer.Namespace(ns)
+		}
 		n.namespaces[ns] = nsc
 		nsc.Start()
 	}
@@ -138,6 +143,9 @@ func (n *NamespacesHandler) OnRemove(name string) {
 		n.logger.Info("Stopping namespace controller", slog.Any("namespace", ns))
 		nsc.Stop()
 		delete(n.namespaces, ns)
+		if n.grantServer != nil {
+			n.grantServer.RemoveNamespace(ns)
+		}
 	}
 }
 
@@ -0,0 +1,53 @@
+package grants
+
+import (
+	"flag"
+	"fmt"
+	"path"
+	"strings"
+
+	iflag "github.com/skupperproject/skupper/internal/flag"
+	"github.com/skupperproject/skupper/internal/kube/grants"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+)
+
+const (
+	DefaultGrantServerPort = 9090
+)
+
+type Config struct {
+	Enabled    bool
+	Port       int
+	BaseUrl    string
+	RateLimits grants.RateLimits
+	AuditLog   string
+}
+
+func BoundConfig(flags *flag.FlagSet) (*Config, error) {
+	c := &Config{}
+	var errors []string
+	if err := iflag.BoolVar(flags, &c.Enabled, "enable-grants", "SKUPPER_ENABLE_GRANTS", false, "Enable use of AccessGrants. The grant server listens on all interfaces, so anyone able to reach its port can attempt to redeem them."); err != nil {
+		errors = append(errors, err.Error())
+	}
+	if err := iflag.IntVar(flags, &c.Port, "grant-server-port", "SKUPPER_GRANT_SERVER_PORT", DefaultGrantServerPort, "The port on which the AccessGrant server should listen."); err != nil {
+		errors = append(errors, err.Error())
+	}
+	iflag.StringVar(flags, &c.BaseUrl, "grant-server-base-url", "SKUPPER_GRANT_SERVER_BASE_URL", "", "The base url (host:port) through which the AccessGrant server can be reached. Defaults to the host used by the links generated for each namespace.")
+	errors = append(errors, grants.BoundRateLimits(flags, &c.RateLimits)...)
+	iflag.StringVar(flags, &c.AuditLog, "grant-server-audit-log", "SKUPPER_GRANT_SERVER_AUDIT_LOG", "", "The path of a file to which every AccessGrant redemption attempt is written as a JSON line. Defaults to grant-server/audit.log under the system controller directory.")
+	if len(errors) > 0 {
+		return c, fmt.Errorf("Invalid environment variable(s): %s", strings.Join(errors, ", "))
+	}
+	return c, nil
+}
+
+func (c *Config) auditLog() string {
+	if c.AuditLog != "" {
+		return c.AuditLog
+	}
+	return path.Join(api.GetDefaultOutputSystemControllerPath(), "grant-server", "audit.log")
+}
+
+func (c *Config) addr() string {
+	return fmt.Sprintf(":%d", c.Port)
+}
@@ -0,0 +1,270 @@
+package grants
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubetypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	k8syaml "sigs.k8s.io/yaml"
+
+	"github.com/skupperproject/skupper/internal/certs"
+	"github.com/skupperproject/skupper/internal/kube/grants"
+	"github.com/skupperproject/skupper/internal/nonkube/common"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+)
+
+// NamespaceGrants processes the AccessGrants stored under the
+// runtime/grants directory of a namespace. Redeemed grants are answered
+// with the static links rendered for the site under runtime/links,
+// renamed after the token being redeemed, along with a client
+// certificate issued for that redemption only. It is meant to be added
+// to a FileWatcher for both directories.
+type NamespaceGrants struct {
+	namespace   string
+	server      *GrantServer
+	grants      *grants.Grants
+	grantsPath  string
+	linksPath   string
+	issuersPath string
+	logger      *slog.Logger
+}
+
+func newNamespaceGrants(namespace string, server *GrantServer) *NamespaceGrants {
+	n := &NamespaceGrants{
+		namespace:   namespace,
+		server:      server,
+		grantsPath:  api.GetInternalOutputPath(namespace, api.RuntimeGrantsPath),
+		linksPath:   api.GetInternalOutputPath(namespace, api.RuntimeTokenPath),
+		issuersPath: api.GetInternalOutputPath(namespace, api.IssuersPath),
+		logger: slog.Default().
+			With("component", "grant.server").
+			With("namespace", namespace),
+	}
+	n.grants = grants.NewGrants(n.updateGrantStatus, n.generate, "https", "")
+	n.grants.SetCA(server.caCert())
+	// rate limits apply across all namespaces served on the same port
+	n.grants.SetRateLimiter(server.limiter)
+	n.grants.AddRedemptionAuditor(server.auditLog.Record)
+	return n
+}
+
+func (n *NamespaceGrants) GrantsPath() string {
+	return n.grantsPath
+}
+
+func (n *NamespaceGrants) LinksPath() string {
+	return n.linksPath
+}
+
+func (n *NamespaceGrants) OnBasePathAdded(basePath string) {
+	if basePath == n.linksPath {
+		n.refreshUrl()
+	}
+}
+
+func (n *NamespaceGrants) OnCreate(name string) {
+	n.OnUpdate(name)
+}
+
+func (n *NamespaceGrants) OnUpdate(name string) {
+	switch filepath.Dir(name) {
+	case n.linksPath:
+		n.refreshUrl()
+	case n.grantsPath:
+		n.loadGrant(name)
+	}
+}
+
+func (n *NamespaceGrants) OnRemove(name string) {
+	switch filepath.Dir(name) {
+	case n.linksPath:
+		n.refreshUrl()
+	case n.grantsPath:
+		_ = n.grants.CheckGrant(n.key(name), nil)
+	}
+}
+
+func (n *NamespaceGrants) Filter(name string) bool {
+	return strings.HasSuffix(name, ".yaml")
+}
+
+func (n *NamespaceGrants) key(fileName string) string {
+	return fmt.Sprintf("%s/%s", n.namespace, strings.TrimSuffix(filepath.Base(fileName), ".yaml"))
+}
+
+func (n *NamespaceGrants) loadGrant(fileName string) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		n.logger.Warn("Unable to read AccessGrant", slog.String("path", fileName), slog.Any("error", err))
+		return
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return
+	}
+	grant := &v2alpha1.AccessGrant{}
+	if err = yaml.Unmarshal(data, grant); err != nil {
+		// the file may still be being written
+		n.logger.Debug("Unable to parse AccessGrant", slog.String("path", fileName), slog.Any("error", err))
+		return
+	}
+	if grant.Name != strings.TrimSuffix(filepath.Base(fileName), ".yaml") {
+		n.logger.Warn("Ignoring AccessGrant not matching its file name",
+			slog.String("path", fileName),
+			slog.String("name", grant.Name))
+		return
+	}
+	grant.Namespace = n.namespace
+	if grant.UID == "" {
+		grant.UID = kubetypes.UID(uuid.New().String())
+	}
+	if err = n.grants.CheckGrant(n.key(fileName), grant); err != nil {
+		n.logger.Error("Unable to update AccessGrant", slog.String("name", grant.Name), slog.Any("error", err))
+	}
+}
+
+func (n *NamespaceGrants) updateGrantStatus(grant *v2alpha1.AccessGrant) (*v2alpha1.AccessGrant, error) {
+	data, err := k8syaml.Marshal(grant)
+	if err != nil {
+		return nil, err
+	}
+	fileName := path.Join(n.grantsPath, grant.Name+".yaml")
+	// the status holds the code needed to redeem the grant
+	if err = os.WriteFile(fileName, data, 0600); err != nil {
+		return nil, err
+	}
+	return grant, nil
+}
+
+// refreshUrl sets the url of the AccessGrants in the namespace based
+// on the host used by the static links currently available.
+func (n *NamespaceGrants) refreshUrl() {
+	_, host, err := n.selectLinks()
+	if err != nil {
+		n.logger.Debug("No links available for AccessGrants", slog.Any("error", err))
+	}
+	url := n.server.baseUrl(host)
+	if url != "" {
+		url = fmt.Sprintf("%s/%s", url, n.namespace)
+	}
+	n.grants.SetUrl(url)
+}
+
+// selectLinks returns the static links generated for the namespace,
+// preferring the ones that do not point to a loopback address, along
+// with the host they point to.
+func (n *NamespaceGrants) selectLinks() (*common.LinkDecoder, string, error) {
+	entries, err := os.ReadDir(n.linksPath)
+	if err != nil {
+		return nil, "", err
+	}
+	var selected *common.LinkDecoder
+	var selectedHost string
+	for _, entry := range entries {
+		if entry.IsDir() || !n.Filter(entry.Name()) {
+			continue
+		}
+		data, err := os.ReadFile(path.Join(n.linksPath, entry.Name()))
+		if err != nil {
+			continue
+		}
+		links, err := common.DecodeLinks(bytes.NewReader(data))
+		if err != nil || len(links.Links) == 0 || len(links.Links[0].Spec.Endpoints) == 0 {
+			continue
+		}
+		host := links.Links[0].Spec.Endpoints[0].Host
+		if selected == nil || (isLoopback(selectedHost) && !isLoopback(host)) {
+			selected = links
+			selectedHost = host
+		}
+	}
+	if selected == nil {
+		return nil, "", fmt.Errorf("no links found in %s", n.linksPath)
+	}
+	return selected, selectedHost, nil
+}
+
+// generate writes the static links of the site, along with a client
+// certificate issued by the CA of the router access they point to, so
+// that each redemption gets its own credentials.
+func (n *NamespaceGrants) generate(namespace string, name string, subject string, writer io.Writer) error {
+	links, _, err := n.selectLinks()
+	if err != nil {
+		return err
+	}
+	ca, err := n.issuer(links.Secret.Data["ca.crt"])
+	if err != nil {
+		return err
+	}
+	secret, err := certs.GenerateSecret(name, subject, "", 0, ca)
+	if err != nil {
+		return fmt.Errorf("unable to issue client certificate: %w", err)
+	}
+	token := &api.Token{
+		Secret: secret,
+	}
+	for i := range links.Links {
+		link := &links.Links[i]
+		if len(links.Links) == 1 {
+			link.Name = name
+		} else {
+			link.Name = fmt.Sprintf("%s-%d", name, i+1)
+		}
+		link.Namespace = ""
+		link.Spec.TlsCredentials = name
+		token.Links = append(token.Links, link)
+	}
+	data, err := token.Marshal()
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(data)
+	return err
+}
+
+// issuer returns the CA, among those rendered under runtime/issuers,
+// whose certificate is the one given.
+func (n *NamespaceGrants) issuer(caCert []byte) (*corev1.Secret, error) {
+	entries, err := os.ReadDir(n.issuersPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read issuers: %w", err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		crt, err := os.ReadFile(path.Join(n.issuersPath, entry.Name(), "tls.crt"))
+		if err != nil || !bytes.Equal(bytes.TrimSpace(crt), bytes.TrimSpace(caCert)) {
+			continue
+		}
+		key, err := os.ReadFile(path.Join(n.issuersPath, entry.Name(), "tls.key"))
+		if err != nil {
+			return nil, fmt.Errorf("unable to read key of issuer %s: %w", entry.Name(), err)
+		}
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: entry.Name(),
+			},
+			Data: map[string][]byte{
+				"tls.crt": crt,
+				"tls.key": key,
+			},
+		}, nil
+	}
+	return nil, fmt.Errorf("no issuer found for the links in %s", n.linksPath)
+}
+
+func isLoopback(host string) bool {
+	return slices.Contains([]string{"localhost", "127.0.0.1", "::1"}, host)
+}
@@ -0,0 +1,202 @@
+package grants
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	"github.com/skupperproject/skupper/internal/certs"
+	"github.com/skupperproject/skupper/internal/nonkube/common"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+)
+
+func TestNamespaceGrants(t *testing.T) {
+	if os.Getuid() == 0 {
+		api.DefaultRootDataHome = t.TempDir()
+	} else {
+		t.Setenv("XDG_DATA_HOME", t.TempDir())
+	}
+	server, err := NewGrantServer(&Config{Enabled: true, Port: DefaultGrantServerPort})
+	assert.Assert(t, err)
+	n := server.Namespace("west")
+	assert.Assert(t, os.MkdirAll(n.GrantsPath(), 0755))
+	assert.Assert(t, os.MkdirAll(n.LinksPath(), 0755))
+
+	// grant is pending until the url can be determined
+	grantFile := path.Join(n.GrantsPath(), "my-grant.yaml")
+	writeGrant(t, grantFile, "my-grant", 1)
+	n.OnCreate(grantFile)
+	grant := readGrant(t, grantFile)
+	assert.Equal(t, grant.Status.Ca, server.caCert())
+	assert.Equal(t, grant.Status.Url, "")
+	assert.Assert(t, !grant.IsReady())
+
+	ca, err := certs.GenerateSecret("skupper-site-ca", "skupper-site-ca", "", 0, nil)
+	assert.Assert(t, err)
+	writeIssuer(t, n.issuersPath, ca)
+	writeStaticLink(t, n.LinksPath(), "127.0.0.1", ca)
+	writeStaticLink(t, n.LinksPath(), "10.0.0.1", ca)
+	n.OnCreate(path.Join(n.LinksPath(), "link-skupper-router-10.0.0.1.yaml"))
+	grant = readGrant(t, grantFile)
+	assert.Equal(t, grant.Status.Url, "https://10.0.0.1:9090/west/my-grant-uid")
+	assert.Assert(t, grant.IsReady())
+	assert.Assert(t, grant.Status.Code != "")
+
+	// redeeming the grant
+	redeem := func(code string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, "/west/my-grant-uid", bytes.NewReader([]byte(code)))
+		request.Header.Add("name", "my-token")
+		request.Header.Add("subject", "east")
+		response := httptest.NewRecorder()
+		server.ServeHTTP(response, request)
+		return response
+	}
+	assert.Equal(t, redeem("wrong-code").Code, http.StatusForbidden)
+	response := redeem(grant.Status.Code)
+	assert.Equal(t, response.Code, http.StatusOK)
+	links, err := common.DecodeLinks(response.Body)
+	assert.Assert(t, err)
+	assert.Equal(t, links.Secret.Name, "my-token")
+	// a client certificate is issued for the redemption
+	assert.DeepEqual(t, links.Secret.Data["ca.crt"], ca.Data["tls.crt"])
+	assert.Assert(t, !bytes.Equal(links.Secret.Data["tls.crt"], ca.Data["tls.crt"]))
+	cert, err := certs.DecodeCertificate(links.Secret.Data["tls.crt"])
+	assert.Assert(t, err)
+	assert.Equal(t, cert.Subject.CommonName, "east")
+	assert.Equal(t, len(links.Links), 1)
+	assert.Equal(t, links.Links[0].Name, "my-token")
+	assert.Equal(t, links.Links[0].Spec.TlsCredentials, "my-token")
+	assert.Equal(t, links.Links[0].Spec.Endpoints[0].Host, "10.0.0.1")
+	assert.Equal(t, readGrant(t, grantFile).Status.Redemptions, 1)
+	info, err := os.Stat(grantFile)
+	assert.Assert(t, err)
+	assert.Equal(t, info.Mode().Perm(), os.FileMode(0600))
+
+	// no more redemptions allowed
+	assert.Equal(t, redeem(grant.Status.Code).Code, http.StatusNotFound)
+
+	// unknown namespaces and removed grants are not found
+	request := httptest.NewRequest(http.MethodPost, "/east/my-grant-uid", bytes.NewReader([]byte(grant.Status.Code)))
+	response = httptest.NewRecorder()
+	server.ServeHTTP(response, request)
+	assert.Equal(t, response.Code, http.StatusNotFound)
+
+	writeGrant(t, grantFile, "my-grant", 5)
+	n.OnUpdate(grantFile)
+	assert.Assert(t, os.Remove(grantFile))
+	n.OnRemove(grantFile)
+	assert.Equal(t, redeem(grant.Status.Code).Code, http.StatusNotFound)
+}
+
+func TestGrantServerBaseUrl(t *testing.T) {
+	if os.Getuid() == 0 {
+		api.DefaultRootDataHome = t.TempDir()
+	} else {
+		t.Setenv("XDG_DATA_HOME", t.TempDir())
+	}
+	server, err := NewGrantServer(&Config{Enabled: true, Port: 8443})
+	assert.Assert(t, err)
+	assert.Equal(t, server.baseUrl(""), "")
+	assert.Equal(t, server.baseUrl("my.host"), "my.host:8443")
+	assert.Equal(t, server.baseUrl("fd00::1"), "[fd00::1]:8443")
+	assert.DeepEqual(t, server.hosts, []string{"localhost", "127.0.0.1", "my.host", "fd00::1"})
+
+	// CA is preserved across restarts
+	other, err := NewGrantServer(&Config{Enabled: true, Port: 8443, BaseUrl: "grants.example.com:443/"})
+	assert.Assert(t, err)
+	assert.Equal(t, other.caCert(), server.caCert())
+	assert.Equal(t, other.baseUrl("my.host"), "grants.example.com:443")
+}
+
+func writeGrant(t *testing.T, fileName string, name string, redemptions int) {
+	t.Helper()
+	grant := &v2alpha1.AccessGrant{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "AccessGrant",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			UID:  "my-grant-uid",
+		},
+		Spec: v2alpha1.AccessGrantSpec{
+			RedemptionsAllowed: redemptions,
+			ExpirationWindow:   "15m",
+		},
+	}
+	n := &NamespaceGrants{grantsPath: path.Dir(fileName)}
+	_, err := n.updateGrantStatus(grant)
+	assert.Assert(t, err)
+}
+
+func readGrant(t *testing.T, fileName string) *v2alpha1.AccessGrant {
+	t.Helper()
+	data, err := os.ReadFile(fileName)
+	assert.Assert(t, err)
+	grant := &v2alpha1.AccessGrant{}
+	assert.Assert(t, yaml.Unmarshal(data, grant))
+	return grant
+}
+
+func writeIssuer(t *testing.T, issuersPath string, ca *corev1.Secret) {
+	t.Helper()
+	caPath := path.Join(issuersPath, ca.Name)
+	assert.Assert(t, os.MkdirAll(caPath, 0755))
+	for _, name := range []string{"tls.crt", "tls.key"} {
+		assert.Assert(t, os.WriteFile(path.Join(caPath, name), ca.Data[name], 0640))
+	}
+}
+
+func writeStaticLink(t *testing.T, linksPath string, host string, ca *corev1.Secret) {
+	t.Helper()
+	token := &api.Token{
+		Secret: &corev1.Secret{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "v1",
+				Kind:       "Secret",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "link-skupper-router",
+			},
+			Data: map[string][]byte{
+				"ca.crt":  ca.Data["tls.crt"],
+				"tls.crt": []byte("crt"),
+				"tls.key": []byte("key"),
+			},
+		},
+		Links: []*v2alpha1.Link{
+			{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "skupper.io/v2alpha1",
+					Kind:       "Link",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "link-skupper-router",
+				},
+				Spec: v2alpha1.LinkSpec{
+					TlsCredentials: "link-skupper-router",
+					Cost:           1,
+					Endpoints: []v2alpha1.Endpoint{
+						{
+							Name: "inter-router",
+							Host: host,
+							Port: "55671",
+						},
+					},
+				},
+			},
+		},
+	}
+	data, err := token.Marshal()
+	assert.Assert(t, err)
+	assert.Assert(t, os.WriteFile(path.Join(linksPath, "link-skupper-router-"+host+".yaml"), data, 0644))
+}
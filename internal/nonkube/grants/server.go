@@ -0,0 +1,196 @@
+package grants
+
+import (
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/skupperproject/skupper/internal/certs"
+	"github.com/skupperproject/skupper/internal/kube/grants"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+)
+
+const (
+	caName     = "skupper-grant-server-ca"
+	serverName = "skupper-grant-server"
+)
+
+// GrantServer serves the AccessGrants of all namespaces managed by the
+// system controller through a single port. Each namespace is served
+// under its own path prefix, so the url of an AccessGrant in namespace
+// "west" looks like https://<host>:<port>/west/<uid>.
+type GrantServer struct {
+	config     *Config
+	server     *grants.Server
+	ca         *corev1.Secret
+	limiter    *grants.RateLimiter
+	auditLog   *grants.AuditLog
+	hosts      []string
+	namespaces map[string]*NamespaceGrants
+	lock       sync.Mutex
+	logger     *slog.Logger
+}
+
+func NewGrantServer(config *Config) (*GrantServer, error) {
+	s := &GrantServer{
+		config:     config,
+		limiter:    grants.NewRateLimiter(config.RateLimits),
+		auditLog:   grants.NewAuditLog(config.auditLog(), grants.DefaultAuditLogMaxSize, grants.DefaultAuditLogMaxFiles),
+		hosts:      []string{"localhost", "127.0.0.1"},
+		namespaces: map[string]*NamespaceGrants{},
+		logger:     slog.Default().With("component", "grant.server"),
+	}
+	ca, err := loadOrCreateCA(path.Join(api.GetDefaultOutputSystemControllerPath(), "grant-server"))
+	if err != nil {
+		return nil, fmt.Errorf("unable to load grant server CA: %w", err)
+	}
+	s.ca = ca
+	s.server = grants.NewServer(config.addr(), true, s)
+	if err := s.updateCertificate(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *GrantServer) Start() {
+	s.logger.Info("Starting grant server", slog.Int("port", s.config.Port))
+	s.server.Start()
+}
+
+func (s *GrantServer) Stop() {
+	if err := s.server.Stop(); err != nil {
+		s.logger.Error("Error stopping grant server", slog.Any("error", err))
+	}
+	if err := s.auditLog.Close(); err != nil {
+		s.logger.Error("Error closing grant server audit log", slog.Any("error", err))
+	}
+}
+
+// Namespace returns the NamespaceGrants for the given namespace,
+// registering it with the server if needed.
+func (s *GrantServer) Namespace(namespace string) *NamespaceGrants {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if n, ok := s.namespaces[namespace]; ok {
+		return n
+	}
+	n := newNamespaceGrants(namespace, s)
+	s.namespaces[namespace] = n
+	return n
+}
+
+// RemoveNamespace stops serving the AccessGrants of the given namespace.
+func (s *GrantServer) RemoveNamespace(namespace string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.namespaces, namespace)
+}
+
+func (s *GrantServer) get(namespace string) *NamespaceGrants {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.namespaces[namespace]
+}
+
+func (s *GrantServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	namespace, _, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	n := s.get(namespace)
+	if n == nil {
+		http.Error(w, "No such claim", http.StatusNotFound)
+		return
+	}
+	http.StripPrefix("/"+namespace, n.grants).ServeHTTP(w, r)
+}
+
+func (s *GrantServer) caCert() string {
+	return string(s.ca.Data["tls.crt"])
+}
+
+// baseUrl returns the host:port through which the grant server can be
+// reached when the given host is used, including it in the server
+// certificate if not yet present.
+func (s *GrantServer) baseUrl(host string) string {
+	if s.config.BaseUrl != "" {
+		return strings.TrimSuffix(s.config.BaseUrl, "/")
+	}
+	if host == "" {
+		return ""
+	}
+	if err := s.addHost(host); err != nil {
+		s.logger.Error("Unable to update grant server certificate",
+			slog.String("host", host),
+			slog.Any("error", err))
+	}
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	return fmt.Sprintf("%s:%d", host, s.config.Port)
+}
+
+func (s *GrantServer) addHost(host string) error {
+	s.lock.Lock()
+	if slices.Contains(s.hosts, host) {
+		s.lock.Unlock()
+		return nil
+	}
+	s.hosts = append(s.hosts, host)
+	s.lock.Unlock()
+	return s.updateCertificate()
+}
+
+func (s *GrantServer) updateCertificate() error {
+	s.lock.Lock()
+	hosts := strings.Join(s.hosts, ",")
+	s.lock.Unlock()
+	secret, err := certs.GenerateSecret(serverName, serverName, hosts, 0, s.ca)
+	if err != nil {
+		return fmt.Errorf("unable to generate grant server certificate: %w", err)
+	}
+	cert, err := tls.X509KeyPair(secret.Data["tls.crt"], secret.Data["tls.key"])
+	if err != nil {
+		return fmt.Errorf("invalid grant server certificate: %w", err)
+	}
+	s.server.SetCertificate(&cert)
+	return nil
+}
+
+func loadOrCreateCA(caPath string) (*corev1.Secret, error) {
+	crtFile := path.Join(caPath, "tls.crt")
+	keyFile := path.Join(caPath, "tls.key")
+	crt, crtErr := os.ReadFile(crtFile)
+	key, keyErr := os.ReadFile(keyFile)
+	if crtErr == nil && keyErr == nil {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: caName,
+			},
+			Data: map[string][]byte{
+				"tls.crt": crt,
+				"tls.key": key,
+			},
+		}, nil
+	}
+	ca, err := certs.GenerateSecret(caName, caName, "", 0, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(caPath, 0700); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(crtFile, ca.Data["tls.crt"], 0600); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(keyFile, ca.Data["tls.key"], 0600); err != nil {
+		return nil, err
+	}
+	return ca, nil
+}
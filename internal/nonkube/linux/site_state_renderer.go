@@ -78,7 +78,8 @@ func (s *SiteStateRenderer) Render(loadedSiteState *api.SiteState, reload bool)
 	if err != nil {
 		return fmt.Errorf("failed to redeem claims: %v", err)
 	}
-	if err = common.CreateRouterAccess(s.siteState); err != nil {
+	metricsPort, err := common.ReservePorts(s.siteState)
+	if err != nil {
 		return err
 	}
 	s.siteState.CreateLinkAccessesCertificates()
@@ -88,6 +89,7 @@ func (s *SiteStateRenderer) Render(loadedSiteState *api.SiteState, reload bool)
 	s.configRenderer = &common.FileSystemConfigurationRenderer{
 		SslProfileBasePath: siteHome,
 		Platform:           string(types.PlatformLinux),
+		MetricsPort:        metricsPort,
 	}
 	err = s.configRenderer.Render(s.siteState)
 	if err != nil {
@@ -28,12 +28,50 @@ func (r *RouterNode) IsSelf() bool {
 }
 
 type Connection struct {
-	Container  string `json:"container"`
-	OperStatus string `json:"operStatus"`
-	Host       string `json:"host"`
-	Role       string `json:"role"`
-	Active     bool   `json:"active"`
-	Dir        string `json:"dir"`
+	Identity       string `json:"identity,omitempty"`
+	Container      string `json:"container"`
+	OperStatus     string `json:"operStatus"`
+	Host           string `json:"host"`
+	Role           string `json:"role"`
+	Active         bool   `json:"active"`
+	Dir            string `json:"dir"`
+	Security       string `json:"security,omitempty"`
+	Authentication string `json:"authentication,omitempty"`
+	User           string `json:"user,omitempty"`
+	Uptime         uint64 `json:"uptimeSeconds,omitempty"`
+}
+
+// RouterLink is an AMQP link attached to the router, as reported by
+// the router.link entity.
+type RouterLink struct {
+	Identity         string `json:"identity"`
+	LinkType         string `json:"linkType"`
+	LinkDir          string `json:"linkDir"`
+	ConnectionId     int    `json:"connectionId"`
+	OwningAddr       string `json:"owningAddr,omitempty"`
+	Peer             string `json:"peer,omitempty"`
+	OperStatus       string `json:"operStatus"`
+	Capacity         int    `json:"capacity"`
+	UndeliveredCount int    `json:"undeliveredCount"`
+	UnsettledCount   int    `json:"unsettledCount"`
+	DeliveryCount    int    `json:"deliveryCount"`
+}
+
+// RouterAddress is an address known to the router, as reported by the
+// router.address entity. The class and phase are decoded from the
+// prefix of its name.
+type RouterAddress struct {
+	Name              string `json:"name"`
+	Class             string `json:"class"`
+	Address           string `json:"address"`
+	Phase             string `json:"phase,omitempty"`
+	Distribution      string `json:"distribution"`
+	InProcess         int    `json:"inProcess"`
+	SubscriberCount   int    `json:"subscriberCount"`
+	RemoteCount       int    `json:"remoteCount"`
+	DeliveriesIngress int    `json:"deliveriesIngress"`
+	DeliveriesEgress  int    `json:"deliveriesEgress"`
+	DeliveriesTransit int    `json:"deliveriesTransit"`
 }
 
 type Agent struct {
@@ -140,15 +178,108 @@ func asTcpEndpoint(record Record) TcpEndpoint {
 	return endpoint
 }
 
+func asHttpEndpoint(record Record) HttpEndpoint {
+	endpoint := HttpEndpoint{
+		Name:            record.AsString("name"),
+		Host:            record.AsString("host"),
+		Port:            record.AsString("port"),
+		Address:         record.AsString("address"),
+		SiteId:          record.AsString("siteId"),
+		ProtocolVersion: HttpProtocolVersion(record.AsString("protocolVersion")),
+		SslProfile:      record.AsString("sslProfile"),
+		ProcessID:       record.AsString("processId"),
+	}
+	if value, ok := record["verifyHostname"]; ok {
+		if verify, ok := value.(bool); ok {
+			endpoint.VerifyHostname = &verify
+		}
+	}
+	return endpoint
+}
+
+func asUdpEndpoint(record Record) UdpEndpoint {
+	return UdpEndpoint{
+		Name:      record.AsString("name"),
+		Host:      record.AsString("host"),
+		Port:      record.AsString("port"),
+		Address:   record.AsString("address"),
+		SiteId:    record.AsString("siteId"),
+		ProcessID: record.AsString("processId"),
+	}
+}
+
 func asConnection(record Record) Connection {
 	return Connection{
-		Role:       record.AsString("role"),
-		Container:  record.AsString("container"),
-		Host:       record.AsString("host"),
-		OperStatus: record.AsString("operStatus"),
-		Dir:        record.AsString("dir"),
-		Active:     record.AsBool("active"),
+		Identity:       record.AsString("identity"),
+		Role:           record.AsString("role"),
+		Container:      record.AsString("container"),
+		Host:           record.AsString("host"),
+		OperStatus:     record.AsString("operStatus"),
+		Dir:            record.AsString("dir"),
+		Active:         record.AsBool("active"),
+		Security:       record.AsString("security"),
+		Authentication: record.AsString("authentication"),
+		User:           record.AsString("user"),
+		Uptime:         record.AsUint64("uptimeSeconds"),
+	}
+}
+
+func asRouterLink(record Record) RouterLink {
+	return RouterLink{
+		Identity:         record.AsString("identity"),
+		LinkType:         record.AsString("linkType"),
+		LinkDir:          record.AsString("linkDir"),
+		ConnectionId:     record.AsInt("connectionId"),
+		OwningAddr:       record.AsString("owningAddr"),
+		Peer:             record.AsString("peer"),
+		OperStatus:       record.AsString("operStatus"),
+		Capacity:         record.AsInt("capacity"),
+		UndeliveredCount: record.AsInt("undeliveredCount"),
+		UnsettledCount:   record.AsInt("unsettledCount"),
+		DeliveryCount:    record.AsInt("deliveryCount"),
+	}
+}
+
+var addressClasses = map[byte]string{
+	'L': "local",
+	'M': "mobile",
+	'R': "router",
+	'T': "topo",
+	'E': "edge",
+	'H': "edge",
+	'C': "link-in",
+	'D': "link-out",
+}
+
+func asRouterAddress(record Record) RouterAddress {
+	address := RouterAddress{
+		Name:              record.AsString("name"),
+		Distribution:      record.AsString("distribution"),
+		InProcess:         record.AsInt("inProcess"),
+		SubscriberCount:   record.AsInt("subscriberCount"),
+		RemoteCount:       record.AsInt("remoteCount"),
+		DeliveriesIngress: record.AsInt("deliveriesIngress"),
+		DeliveriesEgress:  record.AsInt("deliveriesEgress"),
+		DeliveriesTransit: record.AsInt("deliveriesTransit"),
+	}
+	address.Class, address.Address, address.Phase = decodeAddressName(address.Name)
+	return address
+}
+
+// decodeAddressName splits the name of a router address into its class,
+// the address itself and, for mobile addresses, the phase.
+func decodeAddressName(name string) (string, string, string) {
+	if name == "" {
+		return "", "", ""
 	}
+	class, ok := addressClasses[name[0]]
+	if !ok {
+		return "unknown", name, ""
+	}
+	if name[0] == 'M' && len(name) > 1 {
+		return class, name[2:], name[1:2]
+	}
+	return class, name[1:], ""
 }
 
 func asRouterNode(record Record) RouterNode {
@@ -333,6 +464,12 @@ func GetRouterAddress(id string, edge bool) string {
 }
 
 func (a *Agent) request(operation string, typename string, name string, attributes map[string]interface{}) error {
+	return a.requestEntity(operation, typename, "name", name, attributes)
+}
+
+// requestEntity sends a management request for the entity whose key
+// attribute, name or identity, has the given value.
+func (a *Agent) requestEntity(operation string, typename string, key string, value string, attributes map[string]interface{}) error {
 	ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Second)
 	defer cancel()
 
@@ -344,7 +481,7 @@ func (a *Agent) request(operation string, typename string, name string, attribut
 	request.ApplicationProperties = make(map[string]interface{})
 	request.ApplicationProperties["operation"] = operation
 	request.ApplicationProperties["type"] = typename
-	request.ApplicationProperties["name"] = name
+	request.ApplicationProperties[key] = value
 	if attributes != nil {
 		request.Value = attributes
 	}
@@ -386,6 +523,14 @@ func (a *Agent) Delete(typename string, name string) error {
 	return a.request("DELETE", typename, name, nil)
 }
 
+// CloseConnection closes the connection with the given identity.
+func (a *Agent) CloseConnection(identity string) error {
+	log.Println("CLOSE CONNECTION", identity)
+	return a.requestEntity("UPDATE", "io.skupper.router.connection", "identity", identity, map[string]interface{}{
+		"adminStatus": "deleted",
+	})
+}
+
 func (a *Agent) Query(typename string, attributes []string) ([]Record, error) {
 	return a.QueryRouterNode(typename, attributes, nil)
 }
@@ -645,6 +790,34 @@ func (a *Agent) GetConnectionsFor(agent string) ([]Connection, error) {
 	return connections, nil
 }
 
+// GetLinks returns the links attached to the router the agent is
+// connected to.
+func (a *Agent) GetLinks() ([]RouterLink, error) {
+	records, err := a.Query("io.skupper.router.router.link", []string{})
+	if err != nil {
+		return nil, err
+	}
+	links := make([]RouterLink, len(records))
+	for i, r := range records {
+		links[i] = asRouterLink(r)
+	}
+	return links, nil
+}
+
+// GetAddresses returns the addresses known to the router the agent is
+// connected to.
+func (a *Agent) GetAddresses() ([]RouterAddress, error) {
+	records, err := a.Query("io.skupper.router.router.address", []string{})
+	if err != nil {
+		return nil, err
+	}
+	addresses := make([]RouterAddress, len(records))
+	for i, r := range records {
+		addresses[i] = asRouterAddress(r)
+	}
+	return addresses, nil
+}
+
 func getAddressesFor(routers []Router) []string {
 	agents := make([]string, len(routers))
 	for i, r := range routers {
@@ -873,6 +1046,38 @@ func (a *Agent) GetLocalBridgeConfig() (*BridgeConfig, error) {
 		config.AddTcpListener(asTcpEndpoint(record))
 	}
 
+	results, err = a.Query("io.skupper.router.httpConnector", []string{})
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range results {
+		config.AddHttpConnector(asHttpEndpoint(record))
+	}
+
+	results, err = a.Query("io.skupper.router.httpListener", []string{})
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range results {
+		config.AddHttpListener(asHttpEndpoint(record))
+	}
+
+	results, err = a.Query("io.skupper.router.udpConnector", []string{})
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range results {
+		config.AddUdpConnector(asUdpEndpoint(record))
+	}
+
+	results, err = a.Query("io.skupper.router.udpListener", []string{})
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range results {
+		config.AddUdpListener(asUdpEndpoint(record))
+	}
+
 	return &config, nil
 }
 
@@ -897,6 +1102,46 @@ func (a *Agent) UpdateLocalBridgeConfig(changes *BridgeConfigDifference) error {
 			return fmt.Errorf("Error adding tcp listeners: %s", err)
 		}
 	}
+	for _, deleted := range changes.HttpConnectors.Deleted {
+		if err := a.Delete("io.skupper.router.httpConnector", deleted); err != nil {
+			return fmt.Errorf("Error deleting http connectors: %s", err)
+		}
+	}
+	for _, deleted := range changes.HttpListeners.Deleted {
+		if err := a.Delete("io.skupper.router.httpListener", deleted); err != nil {
+			return fmt.Errorf("Error deleting http listeners: %s", err)
+		}
+	}
+	for _, added := range changes.HttpConnectors.Added {
+		if err := a.Create("io.skupper.router.httpConnector", added.Name, added); err != nil {
+			return fmt.Errorf("Error adding http connectors: %s", err)
+		}
+	}
+	for _, added := range changes.HttpListeners.Added {
+		if err := a.Create("io.skupper.router.httpListener", added.Name, added); err != nil {
+			return fmt.Errorf("Error adding http listeners: %s", err)
+		}
+	}
+	for _, deleted := range changes.UdpConnectors.Deleted {
+		if err := a.Delete("io.skupper.router.udpConnector", deleted); err != nil {
+			return fmt.Errorf("Error deleting udp connectors: %s", err)
+		}
+	}
+	for _, deleted := range changes.UdpListeners.Deleted {
+		if err := a.Delete("io.skupper.router.udpListener", deleted); err != nil {
+			return fmt.Errorf("Error deleting udp listeners: %s", err)
+		}
+	}
+	for _, added := range changes.UdpConnectors.Added {
+		if err := a.Create("io.skupper.router.udpConnector", added.Name, added); err != nil {
+			return fmt.Errorf("Error adding udp connectors: %s", err)
+		}
+	}
+	for _, added := range changes.UdpListeners.Added {
+		if err := a.Create("io.skupper.router.udpListener", added.Name, added); err != nil {
+			return fmt.Errorf("Error adding udp listeners: %s", err)
+		}
+	}
 	return nil
 }
 
@@ -920,6 +1165,34 @@ func (a *Agent) GetBridges(routers []Router) ([]BridgeConfig, error) {
 		for _, record := range results {
 			config.AddTcpListener(asTcpEndpoint(record))
 		}
+		results, err = a.QueryByAgentAddress("io.skupper.router.httpConnector", []string{}, agent)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range results {
+			config.AddHttpConnector(asHttpEndpoint(record))
+		}
+		results, err = a.QueryByAgentAddress("io.skupper.router.httpListener", []string{}, agent)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range results {
+			config.AddHttpListener(asHttpEndpoint(record))
+		}
+		results, err = a.QueryByAgentAddress("io.skupper.router.udpConnector", []string{}, agent)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range results {
+			config.AddUdpConnector(asUdpEndpoint(record))
+		}
+		results, err = a.QueryByAgentAddress("io.skupper.router.udpListener", []string{}, agent)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range results {
+			config.AddUdpListener(asUdpEndpoint(record))
+		}
 
 		configs = append(configs, config)
 	}
@@ -207,3 +207,35 @@ func TestMarshalUnmarshalRecordsWithIntegers(t *testing.T) {
 	_, ok = AsInt(recordResult["number"])
 	assert.Assert(t, !ok)
 }
+
+func TestAsRouterAddress(t *testing.T) {
+	testTable := []struct {
+		name    string
+		class   string
+		address string
+		phase   string
+	}{
+		{name: "M0backend:8080", class: "mobile", address: "backend:8080", phase: "0"},
+		{name: "L$management", class: "local", address: "$management"},
+		{name: "Rrouter-1", class: "router", address: "router-1"},
+		{name: "Xsomething", class: "unknown", address: "Xsomething"},
+		{name: ""},
+	}
+	for _, test := range testTable {
+		address := asRouterAddress(Record{
+			"name":            test.name,
+			"distribution":    "balanced",
+			"subscriberCount": int64(2),
+		})
+		assert.Equal(t, address.Class, test.class, test.name)
+		assert.Equal(t, address.Address, test.address, test.name)
+		assert.Equal(t, address.Phase, test.phase, test.name)
+		assert.Equal(t, address.Distribution, "balanced")
+		assert.Equal(t, address.SubscriberCount, 2)
+	}
+
+	link := asRouterLink(Record{"identity": "7", "linkType": "endpoint", "connectionId": int64(3), "deliveryCount": uint64(10)})
+	assert.Equal(t, link.ConnectionId, 3)
+	assert.Equal(t, link.DeliveryCount, 10)
+	assert.Equal(t, link.LinkType, "endpoint")
+}
@@ -0,0 +1,226 @@
+package qdr
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	ChangeAdd    = "add"
+	ChangeDelete = "delete"
+	ChangeUpdate = "update"
+)
+
+// ConfigChange describes the addition, deletion or update of a single
+// router entity.
+type ConfigChange struct {
+	Action string `json:"action"`
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Detail string `json:"detail,omitempty"`
+}
+
+func (c ConfigChange) String() string {
+	symbol := map[string]string{ChangeAdd: "+", ChangeDelete: "-", ChangeUpdate: "~"}[c.Action]
+	if c.Detail == "" {
+		return fmt.Sprintf("%s %s %s", symbol, c.Type, c.Name)
+	}
+	return fmt.Sprintf("%s %s %s (%s)", symbol, c.Type, c.Name, c.Detail)
+}
+
+// RouterConfigDifference holds all the differences between an actual
+// and a desired router configuration. As for the differences used to
+// reconcile the router over the management protocol, a modified entity
+// is represented as deleted and then added again.
+type RouterConfigDifference struct {
+	Listeners   *ListenerDifference
+	Connectors  *ConnectorDifference
+	Bridges     *BridgeConfigDifference
+	Addresses   AddressDifference
+	SslProfiles SslProfileDifference
+}
+
+type AddressDifference struct {
+	Deleted []string
+	Added   []Address
+}
+
+type SslProfileDifference struct {
+	Deleted []string
+	Added   []SslProfile
+}
+
+// DiffRouterConfig returns the changes required to turn the actual
+// router configuration into the desired one.
+func DiffRouterConfig(actual *RouterConfig, desired *RouterConfig) *RouterConfigDifference {
+	result := &RouterConfigDifference{
+		Listeners:  ListenersDifference(actual.Listeners, desired.Listeners),
+		Connectors: ConnectorsDifference(actual.Connectors, desired, nil),
+		Bridges:    actual.Bridges.Difference(&desired.Bridges),
+	}
+	for key, connector := range desired.Connectors {
+		if current, ok := actual.Connectors[key]; ok && current != connector {
+			result.Connectors.Deleted = append(result.Connectors.Deleted, current)
+			result.Connectors.Added = append(result.Connectors.Added, connector)
+		}
+	}
+	for key, address := range desired.Addresses {
+		if current, ok := actual.Addresses[key]; !ok || current != address {
+			if ok {
+				result.Addresses.Deleted = append(result.Addresses.Deleted, key)
+			}
+			result.Addresses.Added = append(result.Addresses.Added, address)
+		}
+	}
+	for key := range actual.Addresses {
+		if _, ok := desired.Addresses[key]; !ok {
+			result.Addresses.Deleted = append(result.Addresses.Deleted, key)
+		}
+	}
+	for key, profile := range desired.SslProfiles {
+		if current, ok := actual.SslProfiles[key]; !ok || current != profile {
+			if ok {
+				result.SslProfiles.Deleted = append(result.SslProfiles.Deleted, key)
+			}
+			result.SslProfiles.Added = append(result.SslProfiles.Added, profile)
+		}
+	}
+	for key := range actual.SslProfiles {
+		if _, ok := desired.SslProfiles[key]; !ok {
+			result.SslProfiles.Deleted = append(result.SslProfiles.Deleted, key)
+		}
+	}
+	return result
+}
+
+func (a *RouterConfigDifference) Empty() bool {
+	return a.Listeners.Empty() && a.Connectors.Empty() && a.Bridges.Empty() &&
+		len(a.Addresses.Deleted) == 0 && len(a.Addresses.Added) == 0 &&
+		len(a.SslProfiles.Deleted) == 0 && len(a.SslProfiles.Added) == 0
+}
+
+// Changes lists the differences one entity at a time, sorted by type
+// and name, with an entity both deleted and added reported as updated.
+func (a *RouterConfigDifference) Changes() []ConfigChange {
+	changes := &changeSet{}
+	for _, l := range a.Listeners.Deleted {
+		changes.deleted("listener", l.Name)
+	}
+	for _, l := range a.Listeners.Added {
+		changes.added("listener", l.Name, fmt.Sprintf("role=%s host=%s port=%d", l.Role, l.Host, l.Port))
+	}
+	for _, c := range a.Connectors.Deleted {
+		changes.deleted("connector", c.Name)
+	}
+	for _, c := range a.Connectors.Added {
+		changes.added("connector", c.Name, fmt.Sprintf("role=%s host=%s port=%s cost=%d", c.Role, c.Host, c.Port, c.Cost))
+	}
+	addEndpointChanges(changes, "tcpListener", a.Bridges.TcpListeners.Deleted, a.Bridges.TcpListeners.Added)
+	addEndpointChanges(changes, "tcpConnector", a.Bridges.TcpConnectors.Deleted, a.Bridges.TcpConnectors.Added)
+	addEndpointChanges(changes, "httpListener", a.Bridges.HttpListeners.Deleted, a.Bridges.HttpListeners.Added)
+	addEndpointChanges(changes, "httpConnector", a.Bridges.HttpConnectors.Deleted, a.Bridges.HttpConnectors.Added)
+	addEndpointChanges(changes, "udpListener", a.Bridges.UdpListeners.Deleted, a.Bridges.UdpListeners.Added)
+	addEndpointChanges(changes, "udpConnector", a.Bridges.UdpConnectors.Deleted, a.Bridges.UdpConnectors.Added)
+	for _, key := range a.Addresses.Deleted {
+		changes.deleted("address", key)
+	}
+	for _, address := range a.Addresses.Added {
+		changes.added("address", address.Prefix, "distribution="+address.Distribution)
+	}
+	for _, key := range a.SslProfiles.Deleted {
+		changes.deleted("sslProfile", key)
+	}
+	for _, profile := range a.SslProfiles.Added {
+		changes.added("sslProfile", profile.Name, "")
+	}
+	return changes.list()
+}
+
+type changeSet struct {
+	changes map[string]*ConfigChange
+}
+
+func (s *changeSet) get(entityType string, name string) *ConfigChange {
+	if s.changes == nil {
+		s.changes = map[string]*ConfigChange{}
+	}
+	key := entityType + "/" + name
+	change, ok := s.changes[key]
+	if !ok {
+		change = &ConfigChange{Type: entityType, Name: name}
+		s.changes[key] = change
+	}
+	return change
+}
+
+func (s *changeSet) deleted(entityType string, name string) {
+	change := s.get(entityType, name)
+	if change.Action == ChangeAdd {
+		change.Action = ChangeUpdate
+	} else if change.Action == "" {
+		change.Action = ChangeDelete
+	}
+}
+
+func (s *changeSet) added(entityType string, name string, detail string) {
+	change := s.get(entityType, name)
+	if change.Action == ChangeDelete {
+		change.Action = ChangeUpdate
+	} else if change.Action == "" {
+		change.Action = ChangeAdd
+	}
+	change.Detail = detail
+}
+
+func addEndpointChanges[E TcpEndpoint | HttpEndpoint | UdpEndpoint](s *changeSet, entityType string, deleted []string, added []E) {
+	for _, name := range deleted {
+		s.deleted(entityType, name)
+	}
+	for _, endpoint := range added {
+		name, detail := endpointDetail(endpoint)
+		s.added(entityType, name, detail)
+	}
+}
+
+func endpointDetail(endpoint any) (string, string) {
+	var name, address, host, port string
+	var extra []string
+	switch e := endpoint.(type) {
+	case TcpEndpoint:
+		name, address, host, port = e.Name, e.Address, e.Host, e.Port
+		if e.SslProfile != "" {
+			extra = append(extra, "sslProfile="+e.SslProfile)
+		}
+	case HttpEndpoint:
+		name, address, host, port = e.Name, e.Address, e.Host, e.Port
+		if e.ProtocolVersion != "" {
+			extra = append(extra, "protocolVersion="+string(e.ProtocolVersion))
+		}
+		if e.SslProfile != "" {
+			extra = append(extra, "sslProfile="+e.SslProfile)
+		}
+	case UdpEndpoint:
+		name, address, host, port = e.Name, e.Address, e.Host, e.Port
+	}
+	detail := []string{"address=" + address}
+	if host != "" {
+		detail = append(detail, "host="+host)
+	}
+	detail = append(detail, "port="+port)
+	return name, strings.Join(append(detail, extra...), " ")
+}
+
+func (s *changeSet) list() []ConfigChange {
+	var changes []ConfigChange
+	for _, change := range s.changes {
+		changes = append(changes, *change)
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Type != changes[j].Type {
+			return changes[i].Type < changes[j].Type
+		}
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
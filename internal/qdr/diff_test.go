@@ -0,0 +1,99 @@
+package qdr
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestDiffRouterConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		actual   func(config *RouterConfig)
+		desired  func(config *RouterConfig)
+		expected []ConfigChange
+	}{
+		{
+			name: "no changes",
+		},
+		{
+			name: "added bridges",
+			desired: func(config *RouterConfig) {
+				config.AddTcpListener(TcpEndpoint{Name: "backend", Address: "backend", Port: "1024"})
+				config.AddUdpConnector(UdpEndpoint{Name: "dns", Address: "dns", Host: "10.0.0.1", Port: "53"})
+			},
+			expected: []ConfigChange{
+				{Action: ChangeAdd, Type: "tcpListener", Name: "backend", Detail: "address=backend port=1024"},
+				{Action: ChangeAdd, Type: "udpConnector", Name: "dns", Detail: "address=dns host=10.0.0.1 port=53"},
+			},
+		},
+		{
+			name: "modified and deleted bridges",
+			actual: func(config *RouterConfig) {
+				config.AddTcpConnector(TcpEndpoint{Name: "db", Address: "db", Host: "10.0.0.1", Port: "5432"})
+				config.AddHttpListener(HttpEndpoint{Name: "web", Address: "web", Port: "8080"})
+			},
+			desired: func(config *RouterConfig) {
+				config.AddTcpConnector(TcpEndpoint{Name: "db", Address: "db", Host: "10.0.0.2", Port: "5432"})
+			},
+			expected: []ConfigChange{
+				{Action: ChangeDelete, Type: "httpListener", Name: "web"},
+				{Action: ChangeUpdate, Type: "tcpConnector", Name: "db", Detail: "address=db host=10.0.0.2 port=5432"},
+			},
+		},
+		{
+			name: "listeners, connectors and addresses",
+			actual: func(config *RouterConfig) {
+				config.AddListener(Listener{Name: "inter-router", Role: RoleInterRouter, Port: 55671})
+				config.AddConnector(Connector{Name: "east", Role: RoleInterRouter, Host: "east", Port: "55671", Cost: 1})
+				config.AddAddress(Address{Prefix: "mc", Distribution: "multicast"})
+			},
+			desired: func(config *RouterConfig) {
+				config.AddListener(Listener{Name: "inter-router", Role: RoleInterRouter, Port: 55671})
+				config.AddListener(Listener{Name: "edge", Role: RoleEdge, Port: 45671})
+				config.AddConnector(Connector{Name: "east", Role: RoleInterRouter, Host: "east", Port: "55671", Cost: 5})
+				config.AddAddress(Address{Prefix: "bcast", Distribution: "multicast"})
+			},
+			expected: []ConfigChange{
+				{Action: ChangeAdd, Type: "address", Name: "bcast", Detail: "distribution=multicast"},
+				{Action: ChangeDelete, Type: "address", Name: "mc"},
+				{Action: ChangeUpdate, Type: "connector", Name: "east", Detail: "role=inter-router host=east port=55671 cost=5"},
+				{Action: ChangeAdd, Type: "listener", Name: "edge", Detail: "role=edge host= port=45671"},
+			},
+		},
+		{
+			name: "ssl profiles",
+			actual: func(config *RouterConfig) {
+				config.AddSslProfile(SslProfile{Name: "old", CaCertFile: "/etc/old/ca.crt"})
+				config.AddSslProfile(SslProfile{Name: "site", CaCertFile: "/etc/site/ca.crt"})
+			},
+			desired: func(config *RouterConfig) {
+				config.AddSslProfile(SslProfile{Name: "site", CaCertFile: "/etc/site/ca.crt", Ordinal: 1})
+			},
+			expected: []ConfigChange{
+				{Action: ChangeDelete, Type: "sslProfile", Name: "old"},
+				{Action: ChangeUpdate, Type: "sslProfile", Name: "site"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := InitialConfig("router", "site", "version", false, 3)
+			desired := InitialConfig("router", "site", "version", false, 3)
+			if tt.actual != nil {
+				tt.actual(&actual)
+			}
+			if tt.desired != nil {
+				tt.desired(&desired)
+			}
+			diff := DiffRouterConfig(&actual, &desired)
+			assert.Equal(t, diff.Empty(), len(tt.expected) == 0)
+			assert.DeepEqual(t, diff.Changes(), tt.expected)
+		})
+	}
+}
+
+func TestConfigChangeString(t *testing.T) {
+	assert.Equal(t, ConfigChange{Action: ChangeAdd, Type: "tcpListener", Name: "backend", Detail: "port=1024"}.String(), "+ tcpListener backend (port=1024)")
+	assert.Equal(t, ConfigChange{Action: ChangeDelete, Type: "address", Name: "mc"}.String(), "- address mc")
+}
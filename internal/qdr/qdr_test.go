@@ -252,6 +252,45 @@ func TestMarshalUnmarshalRouterConfig(t *testing.T) {
 					SiteId:  "def",
 				},
 			},
+			HttpConnectors: map[string]HttpEndpoint{
+				"c3": HttpEndpoint{
+					Name:            "c3",
+					Address:         "pears",
+					Host:            "backend.com",
+					Port:            "8080",
+					SiteId:          "abc",
+					ProtocolVersion: HttpVersion1,
+				},
+			},
+			HttpListeners: map[string]HttpEndpoint{
+				"l3": HttpEndpoint{
+					Name:            "l3",
+					Address:         "plums",
+					Host:            "0.0.0.0",
+					Port:            "8443",
+					SiteId:          "def",
+					ProtocolVersion: HttpVersion2,
+					SslProfile:      "two",
+				},
+			},
+			UdpConnectors: map[string]UdpEndpoint{
+				"c4": UdpEndpoint{
+					Name:    "c4",
+					Address: "dns",
+					Host:    "resolver.com",
+					Port:    "53",
+					SiteId:  "abc",
+				},
+			},
+			UdpListeners: map[string]UdpEndpoint{
+				"l4": UdpEndpoint{
+					Name:    "l4",
+					Address: "dns",
+					Host:    "0.0.0.0",
+					Port:    "5353",
+					SiteId:  "def",
+				},
+			},
 		},
 		Addresses: map[string]Address{
 			"happy": Address{
@@ -382,6 +421,34 @@ func TestUnmarshalErrorInvalidTcpListenerValue(t *testing.T) {
 	}
 }
 
+func TestUnmarshalErrorInvalidHttpConnectorValue(t *testing.T) {
+	_, err := UnmarshalRouterConfig(`[["httpConnector", ["wrong"]]]`)
+	if err == nil {
+		t.Errorf("Expected error for invalid httpconnector value")
+	}
+}
+
+func TestUnmarshalErrorInvalidHttpListenerValue(t *testing.T) {
+	_, err := UnmarshalRouterConfig(`[["httpListener", ["wrong"]]]`)
+	if err == nil {
+		t.Errorf("Expected error for invalid httplistener value")
+	}
+}
+
+func TestUnmarshalErrorInvalidUdpConnectorValue(t *testing.T) {
+	_, err := UnmarshalRouterConfig(`[["udpConnector", ["wrong"]]]`)
+	if err == nil {
+		t.Errorf("Expected error for invalid udpconnector value")
+	}
+}
+
+func TestUnmarshalErrorInvalidUdpListenerValue(t *testing.T) {
+	_, err := UnmarshalRouterConfig(`[["udpListener", ["wrong"]]]`)
+	if err == nil {
+		t.Errorf("Expected error for invalid udplistener value")
+	}
+}
+
 func TestUnmarshalErrorInvalidLogValue(t *testing.T) {
 	_, err := UnmarshalRouterConfig(`[["log", ["wrong"]]]`)
 	if err == nil {
@@ -0,0 +1,139 @@
+package site
+
+import (
+	"fmt"
+	"sort"
+
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+)
+
+// AccessPolicies holds the AccessPolicy resources that apply to a
+// site, keyed by name. Something is only permitted if every policy
+// permits it.
+type AccessPolicies map[string]*skupperv2alpha1.AccessPolicy
+
+// Update records the latest definition of the named policy, removing
+// it if the definition is nil. It returns true if the restrictions in
+// effect may have changed.
+func (p AccessPolicies) Update(name string, policy *skupperv2alpha1.AccessPolicy) bool {
+	existing, ok := p[name]
+	if policy == nil {
+		delete(p, name)
+		return ok
+	}
+	p[name] = policy
+	return !ok || existing.ObjectMeta.Generation != policy.ObjectMeta.Generation
+}
+
+func (p AccessPolicies) names() []string {
+	var names []string
+	for name := range p {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CheckListener returns an error if any policy forbids the routing
+// key of the listener.
+func (p AccessPolicies) CheckListener(listener *skupperv2alpha1.Listener) error {
+	return p.CheckListenerRoutingKey(listener.Spec.RoutingKey)
+}
+
+// CheckConnector returns an error if any policy forbids the routing
+// key of the connector.
+func (p AccessPolicies) CheckConnector(connector *skupperv2alpha1.Connector) error {
+	return p.CheckConnectorRoutingKey(connector.Spec.RoutingKey)
+}
+
+func (p AccessPolicies) CheckListenerRoutingKey(routingKey string) error {
+	for _, name := range p.names() {
+		if !p[name].AllowsListener(routingKey) {
+			return fmt.Errorf("Routing key %q is not allowed for listeners by AccessPolicy %s", routingKey, name)
+		}
+	}
+	return nil
+}
+
+func (p AccessPolicies) CheckConnectorRoutingKey(routingKey string) error {
+	for _, name := range p.names() {
+		if !p[name].AllowsConnector(routingKey) {
+			return fmt.Errorf("Routing key %q is not allowed for connectors by AccessPolicy %s", routingKey, name)
+		}
+	}
+	return nil
+}
+
+// CheckLinkOrigin returns an error if any policy forbids a site with
+// the given name and labels from linking in.
+func (p AccessPolicies) CheckLinkOrigin(siteName string, siteLabels map[string]string) error {
+	for _, name := range p.names() {
+		if !p[name].AllowsLinkFrom(siteName, siteLabels) {
+			return fmt.Errorf("Links from site %q are not allowed by AccessPolicy %s", siteName, name)
+		}
+	}
+	return nil
+}
+
+// RestrictsLinkOrigins returns true if any policy limits the sites
+// that may link in.
+func (p AccessPolicies) RestrictsLinkOrigins() bool {
+	for _, policy := range p {
+		if len(policy.Spec.LinkOrigins.SiteNames) > 0 || len(policy.Spec.LinkOrigins.SiteLabels) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowedLinkSubjects returns the subjects of the issued link
+// credentials, keyed by subject in origins, whose recipients the
+// policies allow to link in. It returns nil if link origins are not
+// restricted, in which case any credentials may be used.
+func (p AccessPolicies) AllowedLinkSubjects(origins map[string]skupperv2alpha1.GrantRecipient) []string {
+	if !p.RestrictsLinkOrigins() {
+		return nil
+	}
+	subjects := []string{}
+	for subject, recipient := range origins {
+		if p.CheckLinkOrigin(recipient.SiteName, recipient.SiteLabels) == nil {
+			subjects = append(subjects, subject)
+		}
+	}
+	sort.Strings(subjects)
+	return subjects
+}
+
+// MaxLinks returns the lowest link limit set by any policy, or zero
+// if the number of links is not limited.
+func (p AccessPolicies) MaxLinks() int {
+	max := 0
+	for _, policy := range p {
+		if limit := policy.Spec.MaxLinks; limit > 0 && (max == 0 || limit < max) {
+			max = limit
+		}
+	}
+	return max
+}
+
+// Violations returns a description of each listener and connector
+// that the named policy forbids.
+func (p AccessPolicies) Violations(name string, listeners []*skupperv2alpha1.Listener, connectors []*skupperv2alpha1.Connector) []string {
+	policy, ok := p[name]
+	if !ok {
+		return nil
+	}
+	var violations []string
+	for _, listener := range listeners {
+		if !policy.AllowsListener(listener.Spec.RoutingKey) {
+			violations = append(violations, fmt.Sprintf("Listener %s uses routing key %q", listener.Name, listener.Spec.RoutingKey))
+		}
+	}
+	for _, connector := range connectors {
+		if !policy.AllowsConnector(connector.Spec.RoutingKey) {
+			violations = append(violations, fmt.Sprintf("Connector %s uses routing key %q", connector.Name, connector.Spec.RoutingKey))
+		}
+	}
+	sort.Strings(violations)
+	return violations
+}
@@ -0,0 +1,232 @@
+package site
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/qdr"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newAccessPolicy(name string, spec skupperv2alpha1.AccessPolicySpec) *skupperv2alpha1.AccessPolicy {
+	return &skupperv2alpha1.AccessPolicy{
+		ObjectMeta: v1.ObjectMeta{
+			Name:       name,
+			Namespace:  "test",
+			Generation: 1,
+		},
+		Spec: spec,
+	}
+}
+
+func TestAccessPolicies(t *testing.T) {
+	tests := []struct {
+		name                 string
+		policies             []*skupperv2alpha1.AccessPolicy
+		listenerKeys         map[string]string
+		connectorKeys        map[string]string
+		origins              map[string]map[string]string
+		deniedOrigins        map[string]map[string]string
+		expectedMaxLinks     int
+		expectedListenerErr  string
+		expectedConnectorErr string
+	}{
+		{
+			name:          "no policies",
+			listenerKeys:  map[string]string{"anything": ""},
+			connectorKeys: map[string]string{"anything": ""},
+			origins:       map[string]map[string]string{"anywhere": nil},
+		},
+		{
+			name: "routing key globs",
+			policies: []*skupperv2alpha1.AccessPolicy{
+				newAccessPolicy("keys", skupperv2alpha1.AccessPolicySpec{
+					ListenerRoutingKeys:  []string{"team-a.*", "shared"},
+					ConnectorRoutingKeys: []string{"team-a.?"},
+				}),
+			},
+			listenerKeys: map[string]string{
+				"team-a.db":  "",
+				"shared":     "",
+				"team-b.db":  `Routing key "team-b.db" is not allowed for listeners by AccessPolicy keys`,
+				"shared-two": `Routing key "shared-two" is not allowed for listeners by AccessPolicy keys`,
+			},
+			connectorKeys: map[string]string{
+				"team-a.x":  "",
+				"team-a.db": `Routing key "team-a.db" is not allowed for connectors by AccessPolicy keys`,
+			},
+			origins: map[string]map[string]string{"anywhere": nil},
+		},
+		{
+			name: "every policy must allow",
+			policies: []*skupperv2alpha1.AccessPolicy{
+				newAccessPolicy("a", skupperv2alpha1.AccessPolicySpec{
+					ListenerRoutingKeys: []string{"*"},
+					MaxLinks:            5,
+				}),
+				newAccessPolicy("b", skupperv2alpha1.AccessPolicySpec{
+					ListenerRoutingKeys: []string{"backend"},
+					MaxLinks:            2,
+				}),
+			},
+			listenerKeys: map[string]string{
+				"backend":  "",
+				"frontend": `Routing key "frontend" is not allowed for listeners by AccessPolicy b`,
+			},
+			expectedMaxLinks: 2,
+		},
+		{
+			name: "link origins by name or label",
+			policies: []*skupperv2alpha1.AccessPolicy{
+				newAccessPolicy("origins", skupperv2alpha1.AccessPolicySpec{
+					LinkOrigins: skupperv2alpha1.LinkOrigins{
+						SiteNames:  []string{"east"},
+						SiteLabels: map[string]string{"tenant": "a", "env": "prod"},
+					},
+				}),
+			},
+			origins: map[string]map[string]string{
+				"east":  nil,
+				"north": {"tenant": "a", "env": "prod", "zone": "1"},
+			},
+			deniedOrigins: map[string]map[string]string{
+				"west":  nil,
+				"south": {"tenant": "a"},
+			},
+		},
+		{
+			name: "link origins by name only",
+			policies: []*skupperv2alpha1.AccessPolicy{
+				newAccessPolicy("origins", skupperv2alpha1.AccessPolicySpec{
+					LinkOrigins: skupperv2alpha1.LinkOrigins{
+						SiteNames: []string{"east"},
+					},
+				}),
+			},
+			origins: map[string]map[string]string{
+				"east": nil,
+			},
+			deniedOrigins: map[string]map[string]string{
+				"north": {"tenant": "a"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policies := AccessPolicies{}
+			for _, policy := range tt.policies {
+				assert.Assert(t, policies.Update(policy.Name, policy))
+				assert.Assert(t, !policies.Update(policy.Name, policy))
+			}
+			for key, expected := range tt.listenerKeys {
+				err := policies.CheckListenerRoutingKey(key)
+				if expected == "" {
+					assert.Assert(t, err, key)
+				} else {
+					assert.Error(t, err, expected)
+				}
+			}
+			for key, expected := range tt.connectorKeys {
+				err := policies.CheckConnectorRoutingKey(key)
+				if expected == "" {
+					assert.Assert(t, err, key)
+				} else {
+					assert.Error(t, err, expected)
+				}
+			}
+			for name, labels := range tt.origins {
+				assert.Assert(t, policies.CheckLinkOrigin(name, labels), name)
+			}
+			for name, labels := range tt.deniedOrigins {
+				assert.Assert(t, policies.CheckLinkOrigin(name, labels) != nil, name)
+			}
+			assert.Equal(t, policies.MaxLinks(), tt.expectedMaxLinks)
+			for _, policy := range tt.policies {
+				assert.Assert(t, policies.Update(policy.Name, nil))
+			}
+			assert.Equal(t, len(policies), 0)
+		})
+	}
+}
+
+func TestAccessPolicies_Violations(t *testing.T) {
+	policies := AccessPolicies{}
+	policies.Update("keys", newAccessPolicy("keys", skupperv2alpha1.AccessPolicySpec{
+		ListenerRoutingKeys:  []string{"backend"},
+		ConnectorRoutingKeys: []string{"backend"},
+	}))
+	listeners := []*skupperv2alpha1.Listener{
+		{ObjectMeta: v1.ObjectMeta{Name: "l1"}, Spec: skupperv2alpha1.ListenerSpec{RoutingKey: "backend"}},
+		{ObjectMeta: v1.ObjectMeta{Name: "l2"}, Spec: skupperv2alpha1.ListenerSpec{RoutingKey: "other"}},
+	}
+	connectors := []*skupperv2alpha1.Connector{
+		{ObjectMeta: v1.ObjectMeta{Name: "c1"}, Spec: skupperv2alpha1.ConnectorSpec{RoutingKey: "other"}},
+	}
+	assert.DeepEqual(t, policies.Violations("keys", listeners, connectors), []string{
+		`Connector c1 uses routing key "other"`,
+		`Listener l2 uses routing key "other"`,
+	})
+	assert.Assert(t, policies.Violations("unknown", listeners, connectors) == nil)
+}
+
+func TestAccessPolicies_AllowedLinkSubjects(t *testing.T) {
+	origins := map[string]skupperv2alpha1.GrantRecipient{
+		"tenant-a":   {SiteName: "tenant-a"},
+		"tenant-b":   {SiteName: "tenant-b", SiteLabels: map[string]string{"tenant": "b"}},
+		"unexpected": {SiteName: "unexpected"},
+	}
+	policies := AccessPolicies{}
+	assert.Assert(t, policies.AllowedLinkSubjects(origins) == nil)
+	policies.Update("keys", newAccessPolicy("keys", skupperv2alpha1.AccessPolicySpec{
+		ListenerRoutingKeys: []string{"backend"},
+	}))
+	assert.Assert(t, policies.AllowedLinkSubjects(origins) == nil)
+	policies.Update("origins", newAccessPolicy("origins", skupperv2alpha1.AccessPolicySpec{
+		LinkOrigins: skupperv2alpha1.LinkOrigins{
+			SiteNames:  []string{"tenant-a"},
+			SiteLabels: map[string]string{"tenant": "b"},
+		},
+	}))
+	assert.DeepEqual(t, policies.AllowedLinkSubjects(origins), []string{"tenant-a", "tenant-b"})
+	// no credentials issued to allowed sites
+	assert.DeepEqual(t, policies.AllowedLinkSubjects(nil), []string{})
+}
+
+func TestBindings_ApplyWithPolicy(t *testing.T) {
+	policies := AccessPolicies{}
+	bindings := NewBindings("")
+	bindings.SetBindingPolicy(policies)
+	bindings.UpdateListener("allowed", &skupperv2alpha1.Listener{
+		ObjectMeta: v1.ObjectMeta{Name: "allowed"},
+		Spec:       skupperv2alpha1.ListenerSpec{RoutingKey: "backend", Host: "backend", Port: 8080},
+	})
+	bindings.UpdateListener("denied", &skupperv2alpha1.Listener{
+		ObjectMeta: v1.ObjectMeta{Name: "denied"},
+		Spec:       skupperv2alpha1.ListenerSpec{RoutingKey: "database", Host: "database", Port: 5432},
+	})
+	bindings.UpdateConnector("denied", &skupperv2alpha1.Connector{
+		ObjectMeta: v1.ObjectMeta{Name: "denied"},
+		Spec:       skupperv2alpha1.ConnectorSpec{RoutingKey: "database", Host: "database", Port: 5432},
+	})
+
+	config := bindings.ToBridgeConfig()
+	assert.Equal(t, len(config.TcpListeners), 2)
+	assert.Equal(t, len(config.TcpConnectors), 1)
+
+	policies.Update("keys", newAccessPolicy("keys", skupperv2alpha1.AccessPolicySpec{
+		ListenerRoutingKeys:  []string{"back*"},
+		ConnectorRoutingKeys: []string{"back*"},
+	}))
+	config = bindings.ToBridgeConfig()
+	assert.DeepEqual(t, keys(config.TcpListeners), []string{"allowed"})
+	assert.Equal(t, len(config.TcpConnectors), 0)
+}
+
+func keys(endpoints qdr.TcpEndpointMap) []string {
+	var names []string
+	for name := range endpoints {
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,462 @@
+// Package archive implements the format used to export a site, with the
+// resources and the secret material needed to recreate it elsewhere.
+//
+// An archive is a gzipped tarball holding:
+//
+//   - manifest.yaml: the format version and the identity of the site
+//   - resources.yaml: the Site, RouterAccesses, Certificates, Listeners,
+//     Connectors, Links and AccessGrants, as a multi-document YAML file
+//   - secrets.yaml: the CA secrets (issuers) and the other secrets
+//     referenced by the resources, such as link credentials. When a
+//     passphrase is given, it is stored as secrets.yaml.enc instead,
+//     encrypted with AES-256-GCM using a key derived from the passphrase.
+package archive
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// Version is the version of the archive format written by this package.
+	Version = "v1"
+
+	manifestFile         = "manifest.yaml"
+	resourcesFile        = "resources.yaml"
+	secretsFile          = "secrets.yaml"
+	encryptedSecretsFile = "secrets.yaml.enc"
+)
+
+// Manifest describes the content of an archive.
+type Manifest struct {
+	Version string `json:"version"`
+	// Platform is the platform the site was exported from.
+	Platform string `json:"platform"`
+	SiteName string `json:"siteName"`
+	// SiteId is the identity of the exported site.
+	SiteId     string      `json:"siteId"`
+	Created    time.Time   `json:"created"`
+	Encryption *Encryption `json:"encryption,omitempty"`
+}
+
+// Encryption describes how the secret material of an archive has been
+// encrypted.
+type Encryption struct {
+	Algorithm     string `json:"algorithm"`
+	KeyDerivation string `json:"keyDerivation"`
+	Iterations    int    `json:"iterations"`
+	Salt          []byte `json:"salt"`
+}
+
+// Archive holds the resources and secret material of a site.
+type Archive struct {
+	Manifest       Manifest
+	Site           *v2alpha1.Site
+	RouterAccesses []*v2alpha1.RouterAccess
+	Certificates   []*v2alpha1.Certificate
+	Listeners      []*v2alpha1.Listener
+	Connectors     []*v2alpha1.Connector
+	Links          []*v2alpha1.Link
+	AccessGrants   []*v2alpha1.AccessGrant
+	// Issuers holds the secrets of the certificate authorities of the
+	// site, used to sign the certificates remote sites link with.
+	Issuers []*corev1.Secret
+	// Secrets holds the link credentials and other secrets referenced by
+	// the resources of the site.
+	Secrets []*corev1.Secret
+}
+
+type secretMaterial struct {
+	Issuers []*corev1.Secret `json:"issuers,omitempty"`
+	Secrets []*corev1.Secret `json:"secrets,omitempty"`
+}
+
+// New returns an empty Archive for the given site. The site is stored
+// without its namespace, status or server-populated metadata.
+func New(site *v2alpha1.Site, siteId string, platform string) *Archive {
+	a := &Archive{
+		Manifest: Manifest{
+			Version:  Version,
+			Platform: platform,
+			SiteName: site.Name,
+			SiteId:   siteId,
+			Created:  time.Now().UTC().Truncate(time.Second),
+		},
+	}
+	a.Site = &v2alpha1.Site{
+		TypeMeta:   typeMeta("Site"),
+		ObjectMeta: cleanObjectMeta(site.ObjectMeta),
+		Spec:       site.Spec,
+	}
+	return a
+}
+
+func (a *Archive) AddRouterAccess(ra v2alpha1.RouterAccess) {
+	a.RouterAccesses = append(a.RouterAccesses, &v2alpha1.RouterAccess{
+		TypeMeta:   typeMeta("RouterAccess"),
+		ObjectMeta: cleanObjectMeta(ra.ObjectMeta),
+		Spec:       ra.Spec,
+	})
+}
+
+func (a *Archive) AddCertificate(certificate v2alpha1.Certificate) {
+	a.Certificates = append(a.Certificates, &v2alpha1.Certificate{
+		TypeMeta:   typeMeta("Certificate"),
+		ObjectMeta: cleanObjectMeta(certificate.ObjectMeta),
+		Spec:       certificate.Spec,
+	})
+}
+
+func (a *Archive) AddListener(listener v2alpha1.Listener) {
+	a.Listeners = append(a.Listeners, &v2alpha1.Listener{
+		TypeMeta:   typeMeta("Listener"),
+		ObjectMeta: cleanObjectMeta(listener.ObjectMeta),
+		Spec:       listener.Spec,
+	})
+}
+
+func (a *Archive) AddConnector(connector v2alpha1.Connector) {
+	a.Connectors = append(a.Connectors, &v2alpha1.Connector{
+		TypeMeta:   typeMeta("Connector"),
+		ObjectMeta: cleanObjectMeta(connector.ObjectMeta),
+		Spec:       connector.Spec,
+	})
+}
+
+func (a *Archive) AddLink(link v2alpha1.Link) {
+	a.Links = append(a.Links, &v2alpha1.Link{
+		TypeMeta:   typeMeta("Link"),
+		ObjectMeta: cleanObjectMeta(link.ObjectMeta),
+		Spec:       link.Spec,
+	})
+}
+
+func (a *Archive) AddAccessGrant(grant v2alpha1.AccessGrant) {
+	a.AccessGrants = append(a.AccessGrants, &v2alpha1.AccessGrant{
+		TypeMeta:   typeMeta("AccessGrant"),
+		ObjectMeta: cleanObjectMeta(grant.ObjectMeta),
+		Spec:       grant.Spec,
+	})
+}
+
+func (a *Archive) AddIssuer(secret corev1.Secret) {
+	a.Issuers = append(a.Issuers, cleanSecret(secret))
+}
+
+func (a *Archive) AddSecret(secret corev1.Secret) {
+	a.Secrets = append(a.Secrets, cleanSecret(secret))
+}
+
+// HasSecret returns true if a secret or issuer with the given name has
+// already been added to the archive.
+func (a *Archive) HasSecret(name string) bool {
+	for _, secret := range a.Issuers {
+		if secret.Name == name {
+			return true
+		}
+	}
+	for _, secret := range a.Secrets {
+		if secret.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteFile writes the archive to the named file. The secret material is
+// encrypted when passphrase is not empty.
+func (a *Archive) WriteFile(name string, passphrase string) error {
+	var buf bytes.Buffer
+	if err := a.Write(&buf, passphrase); err != nil {
+		return err
+	}
+	return os.WriteFile(name, buf.Bytes(), 0600)
+}
+
+// Write writes the archive to w. The secret material is encrypted when
+// passphrase is not empty.
+func (a *Archive) Write(w io.Writer, passphrase string) error {
+	if a.Site == nil {
+		return errors.New("archive does not contain a site")
+	}
+	secrets, err := yaml.Marshal(secretMaterial{Issuers: a.Issuers, Secrets: a.Secrets})
+	if err != nil {
+		return fmt.Errorf("unable to encode secrets: %w", err)
+	}
+	manifest := a.Manifest
+	manifest.Version = Version
+	manifest.Encryption = nil
+	secretsName := secretsFile
+	if passphrase != "" {
+		manifest.Encryption, err = newEncryption()
+		if err != nil {
+			return err
+		}
+		secretsName = encryptedSecretsFile
+	}
+	manifestData, err := yaml.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("unable to encode manifest: %w", err)
+	}
+	if manifest.Encryption != nil {
+		// the manifest is authenticated along with the secrets, so that
+		// the encryption parameters cannot be tampered with
+		secrets, err = manifest.Encryption.seal(passphrase, secrets, manifestData)
+		if err != nil {
+			return err
+		}
+	}
+	resources, err := a.encodeResources()
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	files := []struct {
+		name string
+		data []byte
+	}{
+		{manifestFile, manifestData},
+		{resourcesFile, resources},
+		{secretsName, secrets},
+	}
+	for _, file := range files {
+		header := &tar.Header{
+			Name:    file.name,
+			Mode:    0600,
+			Size:    int64(len(file.data)),
+			ModTime: manifest.Created,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := tw.Write(file.data); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	a.Manifest = manifest
+	return nil
+}
+
+func (a *Archive) encodeResources() ([]byte, error) {
+	var resources []interface{}
+	resources = append(resources, a.Site)
+	for _, r := range a.RouterAccesses {
+		resources = append(resources, r)
+	}
+	for _, r := range a.Certificates {
+		resources = append(resources, r)
+	}
+	for _, r := range a.Listeners {
+		resources = append(resources, r)
+	}
+	for _, r := range a.Connectors {
+		resources = append(resources, r)
+	}
+	for _, r := range a.Links {
+		resources = append(resources, r)
+	}
+	for _, r := range a.AccessGrants {
+		resources = append(resources, r)
+	}
+	var buf bytes.Buffer
+	for i, resource := range resources {
+		data, err := yaml.Marshal(resource)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode resources: %w", err)
+		}
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+		buf.Write(data)
+	}
+	return buf.Bytes(), nil
+}
+
+// ReadFile reads an archive from the named file. The passphrase is
+// required if the secret material of the archive is encrypted.
+func ReadFile(name string, passphrase string) (*Archive, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Read(f, passphrase)
+}
+
+// Read reads an archive from r. The passphrase is required if the secret
+// material of the archive is encrypted.
+func Read(r io.Reader, passphrase string) (*Archive, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid site archive: %w", err)
+	}
+	files := map[string][]byte{}
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid site archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid site archive: %w", err)
+		}
+		files[header.Name] = data
+	}
+
+	manifestData, ok := files[manifestFile]
+	if !ok {
+		return nil, fmt.Errorf("invalid site archive: %s not found", manifestFile)
+	}
+	a := &Archive{}
+	if err := yaml.Unmarshal(manifestData, &a.Manifest); err != nil {
+		return nil, fmt.Errorf("invalid site archive manifest: %w", err)
+	}
+	if a.Manifest.Version != Version {
+		return nil, fmt.Errorf("unsupported site archive version %q (expected %q)", a.Manifest.Version, Version)
+	}
+	if err := a.decodeResources(files[resourcesFile]); err != nil {
+		return nil, err
+	}
+	if a.Site == nil {
+		return nil, errors.New("invalid site archive: no site found")
+	}
+
+	var secrets []byte
+	if a.Manifest.Encryption != nil {
+		if passphrase == "" {
+			return nil, errors.New("the secrets of the site archive are encrypted, a passphrase is required")
+		}
+		encrypted, ok := files[encryptedSecretsFile]
+		if !ok {
+			return nil, fmt.Errorf("invalid site archive: %s not found", encryptedSecretsFile)
+		}
+		secrets, err = a.Manifest.Encryption.open(passphrase, encrypted, manifestData)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		secrets, ok = files[secretsFile]
+		if !ok {
+			return nil, fmt.Errorf("invalid site archive: %s not found", secretsFile)
+		}
+	}
+	var material secretMaterial
+	if err := yaml.Unmarshal(secrets, &material); err != nil {
+		return nil, fmt.Errorf("invalid site archive secrets: %w", err)
+	}
+	a.Issuers = material.Issuers
+	a.Secrets = material.Secrets
+	return a, nil
+}
+
+func (a *Archive) decodeResources(data []byte) error {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+	for {
+		var raw map[string]interface{}
+		err := decoder.Decode(&raw)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("invalid site archive resources: %w", err)
+		}
+		if raw == nil {
+			continue
+		}
+		doc, err := yaml.Marshal(raw)
+		if err != nil {
+			return fmt.Errorf("invalid site archive resources: %w", err)
+		}
+		kind, _ := raw["kind"].(string)
+		switch kind {
+		case "Site":
+			if a.Site != nil {
+				return errors.New("invalid site archive: more than one site found")
+			}
+			a.Site = &v2alpha1.Site{}
+			err = yaml.Unmarshal(doc, a.Site)
+		case "RouterAccess":
+			err = decodeInto(doc, &a.RouterAccesses)
+		case "Certificate":
+			err = decodeInto(doc, &a.Certificates)
+		case "Listener":
+			err = decodeInto(doc, &a.Listeners)
+		case "Connector":
+			err = decodeInto(doc, &a.Connectors)
+		case "Link":
+			err = decodeInto(doc, &a.Links)
+		case "AccessGrant":
+			err = decodeInto(doc, &a.AccessGrants)
+		default:
+			err = fmt.Errorf("unexpected resource kind %q", kind)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid site archive resources: %w", err)
+		}
+	}
+}
+
+func decodeInto[T any](doc []byte, list *[]*T) error {
+	var resource T
+	if err := yaml.Unmarshal(doc, &resource); err != nil {
+		return err
+	}
+	*list = append(*list, &resource)
+	return nil
+}
+
+func typeMeta(kind string) metav1.TypeMeta {
+	return metav1.TypeMeta{
+		APIVersion: "skupper.io/v2alpha1",
+		Kind:       kind,
+	}
+}
+
+// cleanObjectMeta keeps only the name, labels and annotations, as the
+// resources are recreated in a different namespace or cluster.
+func cleanObjectMeta(meta metav1.ObjectMeta) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:        meta.Name,
+		Labels:      meta.Labels,
+		Annotations: meta.Annotations,
+	}
+}
+
+func cleanSecret(secret corev1.Secret) *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: cleanObjectMeta(secret.ObjectMeta),
+		Type:       secret.Type,
+		Data:       secret.Data,
+	}
+}
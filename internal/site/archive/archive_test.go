@@ -0,0 +1,212 @@
+package archive
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testArchive() *Archive {
+	site := &v2alpha1.Site{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "site-a",
+			Namespace:       "ns-a",
+			UID:             "00000000-0000-0000-0000-000000000001",
+			ResourceVersion: "10",
+		},
+		Spec: v2alpha1.SiteSpec{LinkAccess: "default"},
+		Status: v2alpha1.SiteStatus{
+			DefaultIssuer: "skupper-site-ca",
+		},
+	}
+	a := New(site, string(site.UID), "kubernetes")
+	a.AddRouterAccess(v2alpha1.RouterAccess{
+		ObjectMeta: metav1.ObjectMeta{Name: "skupper-router", Namespace: "ns-a"},
+		Spec: v2alpha1.RouterAccessSpec{
+			Roles: []v2alpha1.RouterAccessRole{{Name: "inter-router", Port: 55671}},
+		},
+	})
+	a.AddListener(v2alpha1.Listener{
+		ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: "ns-a"},
+		Spec:       v2alpha1.ListenerSpec{RoutingKey: "backend", Host: "backend", Port: 8080},
+	})
+	a.AddConnector(v2alpha1.Connector{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "ns-a"},
+		Spec:       v2alpha1.ConnectorSpec{RoutingKey: "db", Host: "db", Port: 5432},
+	})
+	a.AddLink(v2alpha1.Link{
+		ObjectMeta: metav1.ObjectMeta{Name: "link-b", Namespace: "ns-a"},
+		Spec:       v2alpha1.LinkSpec{TlsCredentials: "link-b", Cost: 1},
+	})
+	a.AddAccessGrant(v2alpha1.AccessGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "grant", Namespace: "ns-a"},
+		Spec:       v2alpha1.AccessGrantSpec{RedemptionsAllowed: 3},
+	})
+	a.AddCertificate(v2alpha1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Name: "custom-ca", Namespace: "ns-a"},
+		Spec:       v2alpha1.CertificateSpec{Subject: "custom", Signing: true},
+	})
+	a.AddIssuer(corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "skupper-site-ca",
+			Namespace:   "ns-a",
+			Annotations: map[string]string{"internal.skupper.io/controlled": "true"},
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: "Certificate", Name: "skupper-site-ca"},
+			},
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{"tls.crt": []byte("ca-cert"), "tls.key": []byte("ca-key")},
+	})
+	a.AddSecret(corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "link-b", Namespace: "ns-a"},
+		Data:       map[string][]byte{"tls.crt": []byte("link-cert"), "tls.key": []byte("link-key")},
+	})
+	return a
+}
+
+func archiveFiles(t *testing.T, data []byte) map[string][]byte {
+	t.Helper()
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	assert.Assert(t, err)
+	tr := tar.NewReader(gz)
+	files := map[string][]byte{}
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return files
+		}
+		assert.Assert(t, err)
+		content, err := io.ReadAll(tr)
+		assert.Assert(t, err)
+		files[header.Name] = content
+	}
+}
+
+func writeArchiveFiles(t *testing.T, files map[string][]byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		assert.Assert(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(content))}))
+		_, err := tw.Write(content)
+		assert.Assert(t, err)
+	}
+	assert.Assert(t, tw.Close())
+	assert.Assert(t, gz.Close())
+	return buf.Bytes()
+}
+
+func TestArchive(t *testing.T) {
+	tests := []struct {
+		name          string
+		passphrase    string
+		readWith      string
+		expectedFiles []string
+		expectedError string
+	}{
+		{
+			name:          "plain",
+			expectedFiles: []string{"manifest.yaml", "resources.yaml", "secrets.yaml"},
+		},
+		{
+			name:          "encrypted",
+			passphrase:    "correct horse",
+			readWith:      "correct horse",
+			expectedFiles: []string{"manifest.yaml", "resources.yaml", "secrets.yaml.enc"},
+		},
+		{
+			name:          "missing-passphrase",
+			passphrase:    "correct horse",
+			expectedError: "the secrets of the site archive are encrypted, a passphrase is required",
+		},
+		{
+			name:          "wrong-passphrase",
+			passphrase:    "correct horse",
+			readWith:      "battery staple",
+			expectedError: "unable to decrypt the secrets of the site archive: invalid passphrase or corrupted archive",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			original := testArchive()
+			var buf bytes.Buffer
+			assert.Assert(t, original.Write(&buf, test.passphrase))
+			data := buf.Bytes()
+
+			files := archiveFiles(t, data)
+			for _, name := range test.expectedFiles {
+				_, ok := files[name]
+				assert.Assert(t, ok, "%s not found in archive", name)
+			}
+			if test.passphrase != "" {
+				// no secret material in clear text
+				for _, content := range files {
+					assert.Assert(t, !bytes.Contains(content, []byte("link-key")))
+				}
+			}
+
+			restored, err := Read(bytes.NewReader(data), test.readWith)
+			if test.expectedError != "" {
+				assert.ErrorContains(t, err, test.expectedError)
+				return
+			}
+			assert.Assert(t, err)
+			assert.Equal(t, restored.Manifest.Version, Version)
+			assert.Equal(t, restored.Manifest.SiteName, "site-a")
+			assert.Equal(t, restored.Manifest.SiteId, "00000000-0000-0000-0000-000000000001")
+			assert.Equal(t, restored.Manifest.Platform, "kubernetes")
+			assert.Equal(t, restored.Manifest.Encryption != nil, test.passphrase != "")
+
+			assert.Equal(t, restored.Site.Name, "site-a")
+			assert.Equal(t, restored.Site.Namespace, "")
+			assert.Equal(t, string(restored.Site.UID), "")
+			assert.Equal(t, restored.Site.ResourceVersion, "")
+			assert.Equal(t, restored.Site.Status.DefaultIssuer, "")
+			assert.DeepEqual(t, restored.Site.Spec, original.Site.Spec)
+			assert.DeepEqual(t, restored.RouterAccesses, original.RouterAccesses)
+			assert.DeepEqual(t, restored.Certificates, original.Certificates)
+			assert.DeepEqual(t, restored.Listeners, original.Listeners)
+			assert.DeepEqual(t, restored.Connectors, original.Connectors)
+			assert.DeepEqual(t, restored.Links, original.Links)
+			assert.DeepEqual(t, restored.AccessGrants, original.AccessGrants)
+			assert.DeepEqual(t, restored.Issuers, original.Issuers)
+			assert.DeepEqual(t, restored.Secrets, original.Secrets)
+			assert.Equal(t, len(restored.Issuers[0].OwnerReferences), 0)
+			assert.Assert(t, restored.HasSecret("skupper-site-ca"))
+			assert.Assert(t, restored.HasSecret("link-b"))
+			assert.Assert(t, !restored.HasSecret("other"))
+		})
+	}
+}
+
+func TestArchiveTamperedManifest(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Assert(t, testArchive().Write(&buf, "secret"))
+	files := archiveFiles(t, buf.Bytes())
+	files["manifest.yaml"] = bytes.Replace(files["manifest.yaml"], []byte("site-a"), []byte("site-x"), 1)
+
+	_, err := Read(bytes.NewReader(writeArchiveFiles(t, files)), "secret")
+	assert.ErrorContains(t, err, "invalid passphrase or corrupted archive")
+}
+
+func TestArchiveInvalid(t *testing.T) {
+	_, err := Read(bytes.NewReader([]byte("not an archive")), "")
+	assert.ErrorContains(t, err, "invalid site archive")
+
+	var buf bytes.Buffer
+	a := testArchive()
+	assert.Assert(t, a.Write(&buf, ""))
+	files := archiveFiles(t, buf.Bytes())
+	files["manifest.yaml"] = bytes.Replace(files["manifest.yaml"], []byte("version: v1"), []byte("version: v9"), 1)
+	_, err = Read(bytes.NewReader(writeArchiveFiles(t, files)), "")
+	assert.ErrorContains(t, err, `unsupported site archive version "v9"`)
+}
@@ -0,0 +1,80 @@
+package archive
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+)
+
+const (
+	encryptionAlgorithm = "AES-256-GCM"
+	keyDerivation       = "PBKDF2-SHA256"
+	keyIterations       = 600000
+	saltLength          = 16
+)
+
+func newEncryption() (*Encryption, error) {
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("unable to generate salt: %w", err)
+	}
+	return &Encryption{
+		Algorithm:     encryptionAlgorithm,
+		KeyDerivation: keyDerivation,
+		Iterations:    keyIterations,
+		Salt:          salt,
+	}, nil
+}
+
+func (e *Encryption) aead(passphrase string) (cipher.AEAD, error) {
+	if e.Algorithm != encryptionAlgorithm || e.KeyDerivation != keyDerivation {
+		return nil, fmt.Errorf("unsupported encryption %s with %s", e.Algorithm, e.KeyDerivation)
+	}
+	if e.Iterations <= 0 || len(e.Salt) == 0 {
+		return nil, errors.New("invalid encryption parameters")
+	}
+	key, err := pbkdf2.Key(sha256.New, passphrase, e.Salt, e.Iterations, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts and authenticates data, along with the additional data
+// which is authenticated but not encrypted. The nonce is prepended to the
+// result.
+func (e *Encryption) seal(passphrase string, data []byte, additional []byte) ([]byte, error) {
+	aead, err := e.aead(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("unable to generate nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, data, additional), nil
+}
+
+func (e *Encryption) open(passphrase string, data []byte, additional []byte) ([]byte, error) {
+	aead, err := e.aead(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < aead.NonceSize() {
+		return nil, errors.New("invalid site archive: encrypted secrets are truncated")
+	}
+	nonce, sealed := data[:aead.NonceSize()], data[aead.NonceSize():]
+	plain, err := aead.Open(nil, nonce, sealed, additional)
+	if err != nil {
+		return nil, errors.New("unable to decrypt the secrets of the site archive: invalid passphrase or corrupted archive")
+	}
+	return plain, nil
+}
@@ -17,6 +17,13 @@ type BindingEventHandler interface {
 	ConnectorDeleted(connector *skupperv2alpha1.Connector)
 }
 
+// BindingPolicy decides whether a listener or connector may be
+// included in the bridge configuration.
+type BindingPolicy interface {
+	CheckListener(listener *skupperv2alpha1.Listener) error
+	CheckConnector(connector *skupperv2alpha1.Connector) error
+}
+
 type ConnectorFunction func(*skupperv2alpha1.Connector) *skupperv2alpha1.Connector
 type ListenerFunction func(*skupperv2alpha1.Listener) *skupperv2alpha1.Listener
 
@@ -26,6 +33,7 @@ type Bindings struct {
 	connectors  map[string]*skupperv2alpha1.Connector
 	listeners   map[string]*skupperv2alpha1.Listener
 	handler     BindingEventHandler
+	policy      BindingPolicy
 	configure   struct {
 		listener  ListenerConfiguration
 		connector ConnectorConfiguration
@@ -55,6 +63,10 @@ func (b *Bindings) SetConnectorConfiguration(configuration ConnectorConfiguratio
 	b.configure.connector = configuration
 }
 
+func (b *Bindings) SetBindingPolicy(policy BindingPolicy) {
+	b.policy = policy
+}
+
 func (b *Bindings) SetBindingEventHandler(handler BindingEventHandler) {
 	b.handler = handler
 	for _, c := range b.connectors {
@@ -161,13 +173,23 @@ func (b *Bindings) deleteListener(name string) qdr.ConfigUpdate {
 
 func (b *Bindings) ToBridgeConfig() qdr.BridgeConfig {
 	config := qdr.BridgeConfig{
-		TcpListeners:  qdr.TcpEndpointMap{},
-		TcpConnectors: qdr.TcpEndpointMap{},
+		TcpListeners:   qdr.TcpEndpointMap{},
+		TcpConnectors:  qdr.TcpEndpointMap{},
+		HttpListeners:  qdr.HttpEndpointMap{},
+		HttpConnectors: qdr.HttpEndpointMap{},
+		UdpListeners:   qdr.UdpEndpointMap{},
+		UdpConnectors:  qdr.UdpEndpointMap{},
 	}
 	for _, c := range b.connectors {
+		if b.policy != nil && b.policy.CheckConnector(c) != nil {
+			continue
+		}
 		b.configure.connector(b.SiteId, c, &config)
 	}
 	for _, l := range b.listeners {
+		if b.policy != nil && b.policy.CheckListener(l) != nil {
+			continue
+		}
 		b.configure.listener(b.SiteId, l, &config)
 	}
 
@@ -21,7 +21,8 @@ func UpdateBridgeConfigForConnectorToPod(siteId string, connector *skupperv2alph
 }
 
 func updateBridgeConfigForConnector(name string, siteId string, connector *skupperv2alpha1.Connector, host string, processID string, address string, config *qdr.BridgeConfig) {
-	if connector.Spec.Type == "tcp" || connector.Spec.Type == "" {
+	switch connector.Spec.Type {
+	case BindingTypeTcp, "":
 		config.AddTcpConnector(qdr.TcpEndpoint{
 			Name:           name,
 			SiteId:         siteId,
@@ -32,6 +33,27 @@ func updateBridgeConfigForConnector(name string, siteId string, connector *skupp
 			ProcessID:      processID,
 			VerifyHostname: getVerifyHostname(connector),
 		})
+	case BindingTypeHttp, BindingTypeHttp2:
+		config.AddHttpConnector(qdr.HttpEndpoint{
+			Name:            name,
+			SiteId:          siteId,
+			Host:            host,
+			Port:            strconv.Itoa(connector.Spec.Port),
+			Address:         address,
+			ProtocolVersion: HttpProtocolVersion(connector.Spec.Type),
+			SslProfile:      GetSslProfileName(connector.Spec.TlsCredentials, connector.Spec.UseClientCert),
+			ProcessID:       processID,
+			VerifyHostname:  getVerifyHostname(connector),
+		})
+	case BindingTypeUdp:
+		config.AddUdpConnector(qdr.UdpEndpoint{
+			Name:      name,
+			SiteId:    siteId,
+			Host:      host,
+			Port:      strconv.Itoa(connector.Spec.Port),
+			Address:   address,
+			ProcessID: processID,
+		})
 	}
 }
 
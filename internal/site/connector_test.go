@@ -20,6 +20,9 @@ func TestUpdateBridgeConfigForConnector(t *testing.T) {
 		args               args
 		expectedTcpAdded   int
 		expectedTcpDeleted int
+		expectedHttpAdded  int
+		expectedVersion    qdr.HttpProtocolVersion
+		expectedUdpAdded   int
 	}{
 		{
 			name: "no spec type",
@@ -63,6 +66,52 @@ func TestUpdateBridgeConfigForConnector(t *testing.T) {
 			expectedTcpAdded:   1,
 			expectedTcpDeleted: 0,
 		},
+		{
+			name: "http spec type",
+			args: args{
+				siteId: "my-site-123",
+				connector: &skupperv2alpha1.Connector{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "echo",
+						Namespace: "test",
+					},
+					Spec: skupperv2alpha1.ConnectorSpec{
+						RoutingKey: "echo:9090",
+						Host:       "10.10.10.1",
+						Port:       9090,
+						Type:       "http",
+					},
+				},
+				config: qdr.NewBridgeConfig(),
+			},
+			expectedTcpAdded:   0,
+			expectedTcpDeleted: 0,
+			expectedHttpAdded:  1,
+			expectedVersion:    qdr.HttpVersion1,
+		},
+		{
+			name: "http2 spec type",
+			args: args{
+				siteId: "my-site-123",
+				connector: &skupperv2alpha1.Connector{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "echo",
+						Namespace: "test",
+					},
+					Spec: skupperv2alpha1.ConnectorSpec{
+						RoutingKey: "echo:9090",
+						Host:       "10.10.10.1",
+						Port:       9090,
+						Type:       "http2",
+					},
+				},
+				config: qdr.NewBridgeConfig(),
+			},
+			expectedTcpAdded:   0,
+			expectedTcpDeleted: 0,
+			expectedHttpAdded:  1,
+			expectedVersion:    qdr.HttpVersion2,
+		},
 		{
 			name: "bad spec type",
 			args: args{
@@ -84,6 +133,28 @@ func TestUpdateBridgeConfigForConnector(t *testing.T) {
 			expectedTcpAdded:   0,
 			expectedTcpDeleted: 0,
 		},
+		{
+			name: "udp spec type",
+			args: args{
+				siteId: "my-site-123",
+				connector: &skupperv2alpha1.Connector{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "dns",
+						Namespace: "test",
+					},
+					Spec: skupperv2alpha1.ConnectorSpec{
+						RoutingKey: "dns",
+						Host:       "10.10.10.1",
+						Port:       53,
+						Type:       "udp",
+					},
+				},
+				config: qdr.NewBridgeConfig(),
+			},
+			expectedTcpAdded:   0,
+			expectedTcpDeleted: 0,
+			expectedUdpAdded:   1,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -92,6 +163,11 @@ func TestUpdateBridgeConfigForConnector(t *testing.T) {
 			result := tt.args.config.Difference(&configToUpdate)
 			assert.Assert(t, len(result.TcpConnectors.Added) == tt.expectedTcpAdded)
 			assert.Assert(t, len(result.TcpConnectors.Deleted) == tt.expectedTcpDeleted)
+			assert.Assert(t, len(result.HttpConnectors.Added) == tt.expectedHttpAdded)
+			for _, added := range result.HttpConnectors.Added {
+				assert.Equal(t, added.ProtocolVersion, tt.expectedVersion)
+			}
+			assert.Assert(t, len(result.UdpConnectors.Added) == tt.expectedUdpAdded)
 		})
 	}
 }
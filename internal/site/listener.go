@@ -13,7 +13,8 @@ func UpdateBridgeConfigForListener(siteId string, listener *skupperv2alpha1.List
 
 func UpdateBridgeConfigForListenerWithHostAndPort(siteId string, listener *skupperv2alpha1.Listener, host string, port int, config *qdr.BridgeConfig) {
 	name := listener.Name
-	if listener.Spec.Type == "tcp" || listener.Spec.Type == "" {
+	switch listener.Spec.Type {
+	case BindingTypeTcp, "":
 		config.AddTcpListener(qdr.TcpEndpoint{
 			Name:       name,
 			SiteId:     siteId,
@@ -22,5 +23,23 @@ func UpdateBridgeConfigForListenerWithHostAndPort(siteId string, listener *skupp
 			Address:    listener.Spec.RoutingKey,
 			SslProfile: listener.Spec.TlsCredentials,
 		})
+	case BindingTypeHttp, BindingTypeHttp2:
+		config.AddHttpListener(qdr.HttpEndpoint{
+			Name:            name,
+			SiteId:          siteId,
+			Host:            host,
+			Port:            strconv.Itoa(port),
+			Address:         listener.Spec.RoutingKey,
+			ProtocolVersion: HttpProtocolVersion(listener.Spec.Type),
+			SslProfile:      listener.Spec.TlsCredentials,
+		})
+	case BindingTypeUdp:
+		config.AddUdpListener(qdr.UdpEndpoint{
+			Name:    name,
+			SiteId:  siteId,
+			Host:    host,
+			Port:    strconv.Itoa(port),
+			Address: listener.Spec.RoutingKey,
+		})
 	}
 }
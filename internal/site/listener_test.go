@@ -22,6 +22,9 @@ func TestUpdateBridgeConfigForListener(t *testing.T) {
 		args               args
 		expectedTcpAdded   int
 		expectedTcpDeleted int
+		expectedHttpAdded  int
+		expectedVersion    qdr.HttpProtocolVersion
+		expectedUdpAdded   int
 	}{
 		{
 			name: "no spec type",
@@ -65,6 +68,74 @@ func TestUpdateBridgeConfigForListener(t *testing.T) {
 			expectedTcpAdded:   1,
 			expectedTcpDeleted: 0,
 		},
+		{
+			name: "http spec type",
+			args: args{
+				siteId: "my-site-123",
+				listener: &skupperv2alpha1.Listener{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "echo",
+						Namespace: "test",
+					},
+					Spec: skupperv2alpha1.ListenerSpec{
+						RoutingKey: "echo:9090",
+						Host:       "10.10.10.1",
+						Port:       9090,
+						Type:       "http",
+					},
+				},
+				config: qdr.NewBridgeConfig(),
+			},
+			expectedTcpAdded:   0,
+			expectedTcpDeleted: 0,
+			expectedHttpAdded:  1,
+			expectedVersion:    qdr.HttpVersion1,
+		},
+		{
+			name: "http2 spec type",
+			args: args{
+				siteId: "my-site-123",
+				listener: &skupperv2alpha1.Listener{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "echo",
+						Namespace: "test",
+					},
+					Spec: skupperv2alpha1.ListenerSpec{
+						RoutingKey: "echo:9090",
+						Host:       "10.10.10.1",
+						Port:       9090,
+						Type:       "http2",
+					},
+				},
+				config: qdr.NewBridgeConfig(),
+			},
+			expectedTcpAdded:   0,
+			expectedTcpDeleted: 0,
+			expectedHttpAdded:  1,
+			expectedVersion:    qdr.HttpVersion2,
+		},
+		{
+			name: "udp spec type",
+			args: args{
+				siteId: "my-site-123",
+				listener: &skupperv2alpha1.Listener{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "dns",
+						Namespace: "test",
+					},
+					Spec: skupperv2alpha1.ListenerSpec{
+						RoutingKey: "dns",
+						Host:       "10.10.10.1",
+						Port:       53,
+						Type:       "udp",
+					},
+				},
+				config: qdr.NewBridgeConfig(),
+			},
+			expectedTcpAdded:   0,
+			expectedTcpDeleted: 0,
+			expectedUdpAdded:   1,
+		},
 		{
 			name: "bad spec type",
 			args: args{
@@ -95,6 +166,11 @@ func TestUpdateBridgeConfigForListener(t *testing.T) {
 			result := tt.args.config.Difference(&configToUpdate)
 			assert.Assert(t, len(result.TcpListeners.Added) == tt.expectedTcpAdded)
 			assert.Assert(t, len(result.TcpListeners.Deleted) == tt.expectedTcpDeleted)
+			assert.Assert(t, len(result.HttpListeners.Added) == tt.expectedHttpAdded)
+			for _, added := range result.HttpListeners.Added {
+				assert.Equal(t, added.ProtocolVersion, tt.expectedVersion)
+			}
+			assert.Assert(t, len(result.UdpListeners.Added) == tt.expectedUdpAdded)
 		})
 	}
 }
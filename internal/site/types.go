@@ -0,0 +1,39 @@
+package site
+
+import (
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/qdr"
+)
+
+const (
+	BindingTypeTcp   string = "tcp"
+	BindingTypeHttp  string = "http"
+	BindingTypeHttp2 string = "http2"
+	BindingTypeUdp   string = "udp"
+)
+
+// BindingTypes lists the values accepted for the type of a listener
+// or connector. An empty type is treated as tcp.
+var BindingTypes = []string{BindingTypeTcp, BindingTypeHttp, BindingTypeHttp2, BindingTypeUdp}
+
+func ValidateBindingType(bindingType string) error {
+	if bindingType == "" {
+		return nil
+	}
+	for _, valid := range BindingTypes {
+		if bindingType == valid {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid type %q (valid types: %v)", bindingType, BindingTypes)
+}
+
+// HttpProtocolVersion returns the router protocol version for the
+// http adaptor matching the supplied listener or connector type.
+func HttpProtocolVersion(bindingType string) qdr.HttpProtocolVersion {
+	if bindingType == BindingTypeHttp2 {
+		return qdr.HttpVersion2
+	}
+	return qdr.HttpVersion1
+}
@@ -1,7 +1,9 @@
 package v2alpha1
 
 import (
+	"errors"
 	"fmt"
+	"path"
 	"reflect"
 	"time"
 
@@ -170,10 +172,28 @@ type Site struct {
 	Status        SiteStatus `json:"status,omitempty"`
 }
 
+// SiteIdAnnotation overrides the identity of a site, which otherwise is
+// the UID of the Site resource. It preserves the identity of a site
+// recreated from an export.
+const SiteIdAnnotation = "skupper.io/site-id"
+
 func (s *Site) GetSiteId() string {
+	if id := s.ObjectMeta.Annotations[SiteIdAnnotation]; id != "" {
+		return id
+	}
 	return string(s.ObjectMeta.UID)
 }
 
+// DryRunAnnotation, when set to "true" on a Site, causes changes to the
+// router configuration to be held as pending instead of being applied,
+// so that they can be reviewed (with skupper site diff) beforehand.
+// Removing the annotation applies the pending changes.
+const DryRunAnnotation = "skupper.io/dry-run"
+
+func (s *Site) IsDryRun() bool {
+	return s.ObjectMeta.Annotations[DryRunAnnotation] == "true"
+}
+
 func (s *Site) DefaultIssuer() string {
 	if s.Spec.DefaultIssuer != "" {
 		return s.Spec.DefaultIssuer
@@ -252,6 +272,19 @@ func (s *Site) SetRunning(state ConditionState) bool {
 	return false
 }
 
+// SetSynchronized records whether the configuration of the running
+// routers matches that held for them. It does not affect readiness, as
+// any drift detected is corrected.
+func (s *Site) SetSynchronized(state ConditionState) bool {
+	return s.Status.SetCondition(CONDITION_TYPE_SYNCHRONIZED, state, s.ObjectMeta.Generation)
+}
+
+// SetUpgraded records the progress of a change requiring the routers
+// of an HA site to be restarted, one router group at a time.
+func (s *Site) SetUpgraded(state ConditionState) bool {
+	return s.Status.SetCondition(CONDITION_TYPE_UPGRADED, state, s.ObjectMeta.Generation)
+}
+
 func (s *Site) resolutionRequired() bool {
 	return s.Spec.LinkAccess != "" && s.Spec.LinkAccess != "none"
 }
@@ -281,6 +314,10 @@ const CONDITION_TYPE_PROCESSED = "Processed"
 const CONDITION_TYPE_REDEEMED = "Redeemed"
 const CONDITION_TYPE_OPERATIONAL = "Operational"
 const CONDITION_TYPE_READY = "Ready"
+const CONDITION_TYPE_RENEWED = "Renewed"
+const CONDITION_TYPE_COMPLIANT = "Compliant"
+const CONDITION_TYPE_SYNCHRONIZED = "Synchronized"
+const CONDITION_TYPE_UPGRADED = "Upgraded"
 
 type SiteStatus struct {
 	Status         `json:",inline"`
@@ -333,6 +370,7 @@ type LinkRecord struct {
 	RemoteSiteId   string `json:"remoteSiteId,omitempty"`
 	RemoteSiteName string `json:"remoteSiteName,omitempty"`
 	Operational    bool   `json:"operational,omitempty"`
+	Router         string `json:"router,omitempty"`
 }
 
 // +genclient
@@ -682,6 +720,23 @@ func (s *AccessGrant) IsReady() bool {
 	return meta.IsStatusConditionTrue(s.Status.Conditions, CONDITION_TYPE_READY)
 }
 
+// MaxRecentRedemptionAttempts is the number of redemption attempts
+// kept in the status of an AccessGrant.
+const MaxRecentRedemptionAttempts = 10
+
+// RecordRedemptionAttempt adds an attempt to the recent attempts in
+// the status of the grant, dropping the oldest ones beyond
+// MaxRecentRedemptionAttempts.
+func (g *AccessGrant) RecordRedemptionAttempt(attempt AccessGrantRedemptionAttempt, failed bool) {
+	if failed {
+		g.Status.FailedRedemptions += 1
+	}
+	g.Status.RecentAttempts = append(g.Status.RecentAttempts, attempt)
+	if excess := len(g.Status.RecentAttempts) - MaxRecentRedemptionAttempts; excess > 0 {
+		g.Status.RecentAttempts = g.Status.RecentAttempts[excess:]
+	}
+}
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // AccessGrantList contains a List of AccessGrant instances
@@ -696,9 +751,19 @@ type AccessGrantSpec struct {
 	ExpirationWindow   string            `json:"expirationWindow,omitempty"`
 	Code               string            `json:"code,omitempty"`
 	Issuer             string            `json:"issuer,omitempty"`
+	Recipient          *GrantRecipient   `json:"recipient,omitempty"`
 	Settings           map[string]string `json:"settings,omitempty"`
 }
 
+// GrantRecipient identifies the site an AccessGrant is issued to. The
+// link credentials issued on redemption are bound to it, and it is
+// what AccessPolicy link origins are checked against, regardless of
+// what the redeeming site claims to be.
+type GrantRecipient struct {
+	SiteName   string            `json:"siteName"`
+	SiteLabels map[string]string `json:"siteLabels,omitempty"`
+}
+
 type AccessGrantStatus struct {
 	Status         `json:",inline"`
 	Url            string `json:"url,omitempty"`
@@ -706,6 +771,18 @@ type AccessGrantStatus struct {
 	Ca             string `json:"ca,omitempty"`
 	Redemptions    int    `json:"redemptions,omitempty"`
 	ExpirationTime string `json:"expirationTime,omitempty"`
+	// FailedRedemptions counts the refused attempts to redeem the grant
+	FailedRedemptions int                            `json:"failedRedemptions,omitempty"`
+	RecentAttempts    []AccessGrantRedemptionAttempt `json:"recentAttempts,omitempty"`
+}
+
+// AccessGrantRedemptionAttempt summarises an attempt to redeem an
+// AccessGrant.
+type AccessGrantRedemptionAttempt struct {
+	Time          string `json:"time"`
+	RemoteAddress string `json:"remoteAddress,omitempty"`
+	Subject       string `json:"subject,omitempty"`
+	Outcome       string `json:"outcome"`
 }
 
 // +genclient
@@ -845,6 +922,19 @@ func (c *Certificate) SetReady(err error) bool {
 	return c.Status.SetCondition(CONDITION_TYPE_READY, ErrorOrReadyCondition(err), c.ObjectMeta.Generation)
 }
 
+func (c *Certificate) SetRenewed(err error) bool {
+	return c.Status.SetCondition(CONDITION_TYPE_RENEWED, ErrorOrReadyCondition(err), c.ObjectMeta.Generation)
+}
+
+func (c *Certificate) SetExpiration(expiration time.Time) bool {
+	value := expiration.UTC().Format(time.RFC3339)
+	if c.Status.Expiration != value {
+		c.Status.Expiration = value
+		return true
+	}
+	return false
+}
+
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -1074,3 +1164,132 @@ type AttachedConnectorBindingSpec struct {
 	ExposePodsByName   bool              `json:"exposePodsByName,omitempty"`
 	Settings           map[string]string `json:"settings,omitempty"`
 }
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AccessPolicy restricts the remote sites that may link to a site and
+// the routing keys its listeners and connectors may use
+type AccessPolicy struct {
+	v1.TypeMeta   `json:",inline"`
+	v1.ObjectMeta `json:"metadata,omitempty"`
+	Spec          AccessPolicySpec   `json:"spec,omitempty"`
+	Status        AccessPolicyStatus `json:"status,omitempty"`
+}
+
+type AccessPolicyStatus struct {
+	Status     `json:",inline"`
+	Violations []string `json:"violations,omitempty"`
+}
+
+func (p *AccessPolicy) SetConfigured(err error) bool {
+	if p.Status.SetCondition(CONDITION_TYPE_CONFIGURED, ErrorOrReadyCondition(err), p.ObjectMeta.Generation) {
+		p.Status.setReady([]string{CONDITION_TYPE_CONFIGURED}, p.ObjectMeta.Generation)
+		return true
+	}
+	return false
+}
+
+func (p *AccessPolicy) SetViolations(violations []string) bool {
+	changed := false
+	if !reflect.DeepEqual(p.Status.Violations, violations) {
+		p.Status.Violations = violations
+		changed = true
+	}
+	state := ReadyCondition()
+	if len(violations) > 0 {
+		state = ErrorCondition(fmt.Errorf("%d resources violate the policy", len(violations)))
+	}
+	if p.Status.SetCondition(CONDITION_TYPE_COMPLIANT, state, p.ObjectMeta.Generation) {
+		changed = true
+	}
+	return changed
+}
+
+func (p *AccessPolicy) IsReady() bool {
+	return meta.IsStatusConditionTrue(p.Status.Conditions, CONDITION_TYPE_READY)
+}
+
+// Validate checks that all the routing key patterns in the policy are
+// well formed.
+func (p *AccessPolicy) Validate() error {
+	var errs []error
+	for _, pattern := range append(append([]string{}, p.Spec.ListenerRoutingKeys...), p.Spec.ConnectorRoutingKeys...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			errs = append(errs, fmt.Errorf("Invalid routing key pattern %q: %s", pattern, err))
+		}
+	}
+	if p.Spec.MaxLinks < 0 {
+		errs = append(errs, fmt.Errorf("Invalid value for maxLinks: %d", p.Spec.MaxLinks))
+	}
+	return errors.Join(errs...)
+}
+
+// AllowsListener returns true if a listener may use the routing key.
+func (p *AccessPolicy) AllowsListener(routingKey string) bool {
+	return matchesAny(p.Spec.ListenerRoutingKeys, routingKey)
+}
+
+// AllowsConnector returns true if a connector may use the routing key.
+func (p *AccessPolicy) AllowsConnector(routingKey string) bool {
+	return matchesAny(p.Spec.ConnectorRoutingKeys, routingKey)
+}
+
+// AllowsLinkFrom returns true if a site with the given name and labels
+// may link to the site the policy applies to.
+func (p *AccessPolicy) AllowsLinkFrom(siteName string, siteLabels map[string]string) bool {
+	if len(p.Spec.LinkOrigins.SiteNames) == 0 && len(p.Spec.LinkOrigins.SiteLabels) == 0 {
+		return true
+	}
+	for _, name := range p.Spec.LinkOrigins.SiteNames {
+		if name == siteName {
+			return true
+		}
+	}
+	if len(p.Spec.LinkOrigins.SiteLabels) == 0 {
+		return false
+	}
+	for key, value := range p.Spec.LinkOrigins.SiteLabels {
+		if actual, ok := siteLabels[key]; !ok || actual != value {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesAny(patterns []string, value string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, value); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AccessPolicyList contains a List of AccessPolicy instances
+type AccessPolicyList struct {
+	v1.TypeMeta `json:",inline"`
+	v1.ListMeta `json:"metadata,omitempty"`
+	Items       []AccessPolicy `json:"items"`
+}
+
+type AccessPolicySpec struct {
+	LinkOrigins          LinkOrigins       `json:"linkOrigins,omitempty"`
+	ListenerRoutingKeys  []string          `json:"listenerRoutingKeys,omitempty"`
+	ConnectorRoutingKeys []string          `json:"connectorRoutingKeys,omitempty"`
+	MaxLinks             int               `json:"maxLinks,omitempty"`
+	Settings             map[string]string `json:"settings,omitempty"`
+}
+
+// LinkOrigins identifies the remote sites allowed to link in, either
+// by name or by having all of the given labels. If neither is set,
+// any site may link in.
+type LinkOrigins struct {
+	SiteNames  []string          `json:"siteNames,omitempty"`
+	SiteLabels map[string]string `json:"siteLabels,omitempty"`
+}
@@ -87,9 +87,30 @@ func (in *AccessGrantList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessGrantRedemptionAttempt) DeepCopyInto(out *AccessGrantRedemptionAttempt) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessGrantRedemptionAttempt.
+func (in *AccessGrantRedemptionAttempt) DeepCopy() *AccessGrantRedemptionAttempt {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessGrantRedemptionAttempt)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AccessGrantSpec) DeepCopyInto(out *AccessGrantSpec) {
 	*out = *in
+	if in.Recipient != nil {
+		in, out := &in.Recipient, &out.Recipient
+		*out = new(GrantRecipient)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Settings != nil {
 		in, out := &in.Settings, &out.Settings
 		*out = make(map[string]string, len(*in))
@@ -114,6 +135,11 @@ func (in *AccessGrantSpec) DeepCopy() *AccessGrantSpec {
 func (in *AccessGrantStatus) DeepCopyInto(out *AccessGrantStatus) {
 	*out = *in
 	in.Status.DeepCopyInto(&out.Status)
+	if in.RecentAttempts != nil {
+		in, out := &in.RecentAttempts, &out.RecentAttempts
+		*out = make([]AccessGrantRedemptionAttempt, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -127,6 +153,123 @@ func (in *AccessGrantStatus) DeepCopy() *AccessGrantStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessPolicy) DeepCopyInto(out *AccessPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessPolicy.
+func (in *AccessPolicy) DeepCopy() *AccessPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AccessPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessPolicyList) DeepCopyInto(out *AccessPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AccessPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessPolicyList.
+func (in *AccessPolicyList) DeepCopy() *AccessPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AccessPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessPolicySpec) DeepCopyInto(out *AccessPolicySpec) {
+	*out = *in
+	in.LinkOrigins.DeepCopyInto(&out.LinkOrigins)
+	if in.ListenerRoutingKeys != nil {
+		in, out := &in.ListenerRoutingKeys, &out.ListenerRoutingKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConnectorRoutingKeys != nil {
+		in, out := &in.ConnectorRoutingKeys, &out.ConnectorRoutingKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessPolicySpec.
+func (in *AccessPolicySpec) DeepCopy() *AccessPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessPolicyStatus) DeepCopyInto(out *AccessPolicyStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	if in.Violations != nil {
+		in, out := &in.Violations, &out.Violations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessPolicyStatus.
+func (in *AccessPolicyStatus) DeepCopy() *AccessPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AccessToken) DeepCopyInto(out *AccessToken) {
 	*out = *in
@@ -695,6 +838,29 @@ func (in *Endpoint) DeepCopy() *Endpoint {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrantRecipient) DeepCopyInto(out *GrantRecipient) {
+	*out = *in
+	if in.SiteLabels != nil {
+		in, out := &in.SiteLabels, &out.SiteLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrantRecipient.
+func (in *GrantRecipient) DeepCopy() *GrantRecipient {
+	if in == nil {
+		return nil
+	}
+	out := new(GrantRecipient)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Link) DeepCopyInto(out *Link) {
 	*out = *in
@@ -756,6 +922,34 @@ func (in *LinkList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinkOrigins) DeepCopyInto(out *LinkOrigins) {
+	*out = *in
+	if in.SiteNames != nil {
+		in, out := &in.SiteNames, &out.SiteNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SiteLabels != nil {
+		in, out := &in.SiteLabels, &out.SiteLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinkOrigins.
+func (in *LinkOrigins) DeepCopy() *LinkOrigins {
+	if in == nil {
+		return nil
+	}
+	out := new(LinkOrigins)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LinkRecord) DeepCopyInto(out *LinkRecord) {
 	*out = *in
@@ -0,0 +1,147 @@
+/*
+Copyright 2021 The Skupper Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeAccessPolicies implements AccessPolicyInterface
+type FakeAccessPolicies struct {
+	Fake *FakeSkupperV2alpha1
+	ns   string
+}
+
+var accesspoliciesResource = v2alpha1.SchemeGroupVersion.WithResource("accesspolicies")
+
+var accesspoliciesKind = v2alpha1.SchemeGroupVersion.WithKind("AccessPolicy")
+
+// Get takes name of the accessPolicy, and returns the corresponding accessPolicy object, and an error if there is any.
+func (c *FakeAccessPolicies) Get(ctx context.Context, name string, options v1.GetOptions) (result *v2alpha1.AccessPolicy, err error) {
+	emptyResult := &v2alpha1.AccessPolicy{}
+	obj, err := c.Fake.
+		Invokes(testing.NewGetActionWithOptions(accesspoliciesResource, c.ns, name, options), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v2alpha1.AccessPolicy), err
+}
+
+// List takes label and field selectors, and returns the list of AccessPolicies that match those selectors.
+func (c *FakeAccessPolicies) List(ctx context.Context, opts v1.ListOptions) (result *v2alpha1.AccessPolicyList, err error) {
+	emptyResult := &v2alpha1.AccessPolicyList{}
+	obj, err := c.Fake.
+		Invokes(testing.NewListActionWithOptions(accesspoliciesResource, accesspoliciesKind, c.ns, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v2alpha1.AccessPolicyList{ListMeta: obj.(*v2alpha1.AccessPolicyList).ListMeta}
+	for _, item := range obj.(*v2alpha1.AccessPolicyList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested accessPolicies.
+func (c *FakeAccessPolicies) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchActionWithOptions(accesspoliciesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a accessPolicy and creates it.  Returns the server's representation of the accessPolicy, and an error, if there is any.
+func (c *FakeAccessPolicies) Create(ctx context.Context, accessPolicy *v2alpha1.AccessPolicy, opts v1.CreateOptions) (result *v2alpha1.AccessPolicy, err error) {
+	emptyResult := &v2alpha1.AccessPolicy{}
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateActionWithOptions(accesspoliciesResource, c.ns, accessPolicy, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v2alpha1.AccessPolicy), err
+}
+
+// Update takes the representation of a accessPolicy and updates it. Returns the server's representation of the accessPolicy, and an error, if there is any.
+func (c *FakeAccessPolicies) Update(ctx context.Context, accessPolicy *v2alpha1.AccessPolicy, opts v1.UpdateOptions) (result *v2alpha1.AccessPolicy, err error) {
+	emptyResult := &v2alpha1.AccessPolicy{}
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateActionWithOptions(accesspoliciesResource, c.ns, accessPolicy, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v2alpha1.AccessPolicy), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeAccessPolicies) UpdateStatus(ctx context.Context, accessPolicy *v2alpha1.AccessPolicy, opts v1.UpdateOptions) (result *v2alpha1.AccessPolicy, err error) {
+	emptyResult := &v2alpha1.AccessPolicy{}
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceActionWithOptions(accesspoliciesResource, "status", c.ns, accessPolicy, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v2alpha1.AccessPolicy), err
+}
+
+// Delete takes name of the accessPolicy and deletes it. Returns an error if one occurs.
+func (c *FakeAccessPolicies) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(accesspoliciesResource, c.ns, name, opts), &v2alpha1.AccessPolicy{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeAccessPolicies) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionActionWithOptions(accesspoliciesResource, c.ns, opts, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v2alpha1.AccessPolicyList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched accessPolicy.
+func (c *FakeAccessPolicies) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2alpha1.AccessPolicy, err error) {
+	emptyResult := &v2alpha1.AccessPolicy{}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceActionWithOptions(accesspoliciesResource, c.ns, name, pt, data, opts, subresources...), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v2alpha1.AccessPolicy), err
+}
@@ -20,6 +20,8 @@ package v2alpha1
 
 type AccessGrantExpansion interface{}
 
+type AccessPolicyExpansion interface{}
+
 type AccessTokenExpansion interface{}
 
 type AttachedConnectorExpansion interface{}
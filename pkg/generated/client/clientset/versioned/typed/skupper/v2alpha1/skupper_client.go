@@ -29,6 +29,7 @@ import (
 type SkupperV2alpha1Interface interface {
 	RESTClient() rest.Interface
 	AccessGrantsGetter
+	AccessPoliciesGetter
 	AccessTokensGetter
 	AttachedConnectorsGetter
 	AttachedConnectorBindingsGetter
@@ -50,6 +51,10 @@ func (c *SkupperV2alpha1Client) AccessGrants(namespace string) AccessGrantInterf
 	return newAccessGrants(c, namespace)
 }
 
+func (c *SkupperV2alpha1Client) AccessPolicies(namespace string) AccessPolicyInterface {
+	return newAccessPolicies(c, namespace)
+}
+
 func (c *SkupperV2alpha1Client) AccessTokens(namespace string) AccessTokenInterface {
 	return newAccessTokens(c, namespace)
 }
@@ -26,6 +26,14 @@ type AccessGrantListerExpansion interface{}
 // AccessGrantNamespaceLister.
 type AccessGrantNamespaceListerExpansion interface{}
 
+// AccessPolicyListerExpansion allows custom methods to be added to
+// AccessPolicyLister.
+type AccessPolicyListerExpansion interface{}
+
+// AccessPolicyNamespaceListerExpansion allows custom methods to be added to
+// AccessPolicyNamespaceLister.
+type AccessPolicyNamespaceListerExpansion interface{}
+
 // AccessTokenListerExpansion allows custom methods to be added to
 // AccessTokenLister.
 type AccessTokenListerExpansion interface{}
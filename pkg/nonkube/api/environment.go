@@ -21,6 +21,7 @@ const (
 	RuntimePath           InternalPath = "runtime"
 	RuntimeSiteStatePath  InternalPath = "runtime/resources"
 	RuntimeTokenPath      InternalPath = "runtime/links"
+	RuntimeGrantsPath     InternalPath = "runtime/grants"
 	InternalBasePath      InternalPath = "internal"
 	LoadedSiteStatePath   InternalPath = "internal/snapshot"
 	ScriptsPath           InternalPath = "internal/scripts"
@@ -181,6 +182,16 @@ func GetDefaultOutputBundlesPath() string {
 	return path.Join(GetDataHome(), "bundles")
 }
 
+func GetDefaultOutputSystemControllerPath() string {
+	if IsRunningInContainer() {
+		outputStat, err := os.Stat("/output")
+		if err == nil && outputStat.IsDir() {
+			return path.Join("/output", "system-controller")
+		}
+	}
+	return path.Join(GetDataHome(), "system-controller")
+}
+
 func GetInternalOutputPath(namespace string, internalPath InternalPath) string {
 	return path.Join(GetDefaultOutputPath(namespace), string(internalPath))
 }
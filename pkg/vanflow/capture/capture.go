@@ -0,0 +1,175 @@
+package capture
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-amqp"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/eventsource"
+	"github.com/skupperproject/skupper/pkg/vanflow/session"
+)
+
+const (
+	beaconAddress = "mc/sfe.all"
+	// how long to wait for a first message from a discovered source before
+	// asking it for its records anyway
+	flushTimeout = 5 * time.Second
+)
+
+// EntryWriter records captured entries, such as a Writer.
+type EntryWriter interface {
+	Write(Entry) error
+}
+
+type CaptureOptions struct {
+	// BeaconAddress defaults to the address vanflow event sources send
+	// their beacons to.
+	BeaconAddress string
+	Logger        *slog.Logger
+}
+
+// Capture writes the vanflow messages of the network the container is
+// connected to until ctx is cancelled: beacons, and the records, logs and
+// heartbeats of every event source discovered through them. Discovered
+// sources are asked to flush, so that the capture starts with the full
+// state of the network.
+func Capture(ctx context.Context, container session.Container, w EntryWriter, opts CaptureOptions) error {
+	if opts.BeaconAddress == "" {
+		opts.BeaconAddress = beaconAddress
+	}
+	if opts.Logger == nil {
+		opts.Logger = slog.New(slog.Default().Handler()).With(
+			slog.String("component", "vanflow.capture"),
+		)
+	}
+	c := &capturer{
+		container: container,
+		writer:    w,
+		logger:    opts.Logger,
+		sources:   make(map[string]struct{}),
+	}
+	defer c.wg.Wait()
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	c.fail = cancel
+
+	receiver := container.NewReceiver(opts.BeaconAddress, session.ReceiverOptions{})
+	defer receiver.Close(context.Background())
+	c.wg.Add(1)
+	go c.receive(ctx, receiver, opts.BeaconAddress, func(msg *amqp.Message) {
+		if msg.Properties == nil || msg.Properties.Subject == nil || *msg.Properties.Subject != "BEACON" {
+			return
+		}
+		c.discovered(ctx, vanflow.DecodeBeacon(msg))
+	})
+	<-ctx.Done()
+	// ctx ending is how a capture normally stops, only report failures
+	if err := context.Cause(ctx); !errors.Is(err, ctx.Err()) {
+		return err
+	}
+	return nil
+}
+
+type capturer struct {
+	container session.Container
+	writer    EntryWriter
+	logger    *slog.Logger
+	fail      context.CancelCauseFunc
+	wg        sync.WaitGroup
+
+	mu      sync.Mutex
+	sources map[string]struct{}
+}
+
+// receive writes the messages received on address until ctx is cancelled,
+// calling handle with each of them.
+func (c *capturer) receive(ctx context.Context, receiver session.Receiver, address string, handle func(*amqp.Message)) {
+	defer c.wg.Done()
+	for {
+		msg, err := receiver.Next(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			c.logger.Error("error receiving message", slog.String("address", address), slog.Any("error", err))
+			continue
+		}
+		if err := receiver.Accept(ctx, msg); err != nil {
+			c.logger.Error("error accepting message", slog.String("address", address), slog.Any("error", err))
+		}
+		if err := c.writer.Write(Entry{Time: time.Now(), Address: address, Message: msg}); err != nil {
+			c.fail(fmt.Errorf("error writing capture: %w", err))
+			return
+		}
+		handle(msg)
+	}
+}
+
+// discovered starts capturing the messages of the event source a beacon
+// was received from, unless it already is.
+func (c *capturer) discovered(ctx context.Context, beacon vanflow.BeaconMessage) {
+	source := eventsource.Info{
+		ID:       beacon.Identity,
+		Version:  int(beacon.Version),
+		Type:     beacon.SourceType,
+		Address:  beacon.Address,
+		Direct:   beacon.Direct,
+		LastSeen: time.Now(),
+	}
+	c.mu.Lock()
+	_, known := c.sources[source.ID]
+	c.sources[source.ID] = struct{}{}
+	c.mu.Unlock()
+	if known {
+		return
+	}
+	c.logger.Info("capturing event source",
+		slog.String("id", source.ID),
+		slog.String("type", source.Type),
+		slog.String("address", source.Address))
+
+	var first sync.Once
+	received := make(chan struct{})
+	for _, provider := range []eventsource.ListenerConfigProvider{
+		eventsource.FromSourceAddress(),
+		eventsource.FromSourceAddressFlows(),
+		eventsource.FromSourceAddressLogs(),
+		eventsource.FromSourceAddressHeartbeats(),
+	} {
+		cfg := provider.Get(source)
+		receiver := c.container.NewReceiver(cfg.Address, session.ReceiverOptions{Credit: cfg.Credit})
+		c.wg.Add(2)
+		go c.receive(ctx, receiver, cfg.Address, func(*amqp.Message) {
+			first.Do(func() { close(received) })
+		})
+		go func() {
+			defer c.wg.Done()
+			<-ctx.Done()
+			receiver.Close(context.Background())
+		}()
+	}
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		wait := time.NewTimer(flushTimeout)
+		defer wait.Stop()
+		select {
+		case <-received:
+		case <-wait.C:
+		case <-ctx.Done():
+			return
+		}
+		flushCtx, cancel := context.WithTimeout(ctx, flushTimeout)
+		defer cancel()
+		client := eventsource.NewClient(c.container, eventsource.ClientOptions{Source: source})
+		if err := client.SendFlush(flushCtx); err != nil {
+			c.logger.Error("error sending flush", slog.String("id", source.ID), slog.Any("error", err))
+		}
+	}()
+}
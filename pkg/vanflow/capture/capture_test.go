@@ -0,0 +1,198 @@
+package capture
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-amqp"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/session"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+	"gotest.tools/v3/assert"
+)
+
+func ptrTo[T any](v T) *T {
+	return &v
+}
+
+func beacon(id string) *amqp.Message {
+	return vanflow.BeaconMessage{
+		Version:    1,
+		SourceType: "ROUTER",
+		Address:    "mc/sfe." + id,
+		Direct:     "sfe." + id,
+		Identity:   id,
+	}.Encode()
+}
+
+func records(t *testing.T, address string, records ...vanflow.Record) *amqp.Message {
+	t.Helper()
+	msg, err := vanflow.RecordMessage{
+		MessageProps: vanflow.MessageProps{To: address},
+		Records:      records,
+	}.Encode()
+	assert.Assert(t, err)
+	return msg
+}
+
+func writeCapture(t *testing.T, entries ...Entry) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, Header{Source: "amqp://test"})
+	assert.Assert(t, err)
+	for _, entry := range entries {
+		assert.Assert(t, w.Write(entry))
+	}
+	assert.Assert(t, w.Close())
+	return &buf
+}
+
+func TestFile(t *testing.T) {
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	site := vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-1"), Name: ptrTo("west")}
+	buf := writeCapture(t,
+		Entry{Time: start, Address: "mc/sfe.all", Message: beacon("r1")},
+		Entry{Time: start.Add(time.Second), Address: "mc/sfe.r1", Message: records(t, "mc/sfe.r1", site)},
+	)
+
+	r, err := NewReader(buf)
+	assert.Assert(t, err)
+	assert.Equal(t, r.Header().Version, FormatVersion)
+	assert.Equal(t, r.Header().Source, "amqp://test")
+	assert.Assert(t, !r.Header().Start.IsZero())
+
+	entry, err := r.Next()
+	assert.Assert(t, err)
+	assert.Equal(t, entry.Address, "mc/sfe.all")
+	assert.Assert(t, entry.Time.Equal(start))
+	assert.DeepEqual(t, vanflow.DecodeBeacon(entry.Message).Identity, "r1")
+
+	entry, err = r.Next()
+	assert.Assert(t, err)
+	assert.Equal(t, entry.Address, "mc/sfe.r1")
+	message, err := vanflow.DecodeRecord(entry.Message)
+	assert.Assert(t, err)
+	assert.DeepEqual(t, message.Records, []vanflow.Record{site})
+
+	_, err = r.Next()
+	assert.Equal(t, err, io.EOF)
+
+	_, err = NewReader(bytes.NewBufferString("not gzip"))
+	assert.ErrorContains(t, err, "not a capture file")
+}
+
+type entryChannel chan Entry
+
+func (c entryChannel) Write(entry Entry) error {
+	c <- entry
+	return nil
+}
+
+func TestCapture(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	router := session.NewMockRouter()
+	source := session.NewMockContainer(router)
+	flushes := source.NewReceiver("sfe.r1", session.ReceiverOptions{})
+
+	entries := make(entryChannel, 64)
+	done := make(chan error, 1)
+	go func() {
+		done <- Capture(ctx, session.NewMockContainer(router), entries, CaptureOptions{})
+	}()
+
+	// the event source is asked to flush once its first message is received
+	beacons := source.NewSender("mc/sfe.all", session.SenderOptions{})
+	assert.Assert(t, beacons.Send(ctx, beacon("r1")))
+	site := vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-1")}
+	assert.Assert(t, source.NewSender("mc/sfe.r1", session.SenderOptions{}).Send(ctx, records(t, "mc/sfe.r1", site)))
+	flush, err := flushes.Next(ctx)
+	assert.Assert(t, err)
+	assert.Equal(t, *flush.Properties.Subject, "FLUSH")
+
+	log := vanflow.LogRecord{BaseRecord: vanflow.NewBase("log-1")}
+	assert.Assert(t, source.NewSender("mc/sfe.r1.logs", session.SenderOptions{}).Send(ctx, records(t, "mc/sfe.r1.logs", log)))
+
+	captured := map[string]int{}
+	for len(captured) < 3 {
+		select {
+		case entry := <-entries:
+			assert.Assert(t, !entry.Time.IsZero())
+			captured[entry.Address]++
+		case <-ctx.Done():
+			t.Fatalf("timed out waiting for entries, captured %v", captured)
+		}
+	}
+	assert.DeepEqual(t, captured, map[string]int{"mc/sfe.all": 1, "mc/sfe.r1": 1, "mc/sfe.r1.logs": 1})
+
+	cancel()
+	assert.Assert(t, <-done)
+}
+
+func TestReplayStore(t *testing.T) {
+	start := time.Now()
+	site := vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-1"), Name: ptrTo("west")}
+	buf := writeCapture(t,
+		Entry{Time: start, Address: "mc/sfe.all", Message: beacon("r1")},
+		Entry{Time: start.Add(time.Hour), Address: "mc/sfe.r1", Message: records(t, "mc/sfe.r1", site)},
+		Entry{Time: start.Add(2 * time.Hour), Address: "mc/sfe.r1", Message: records(t, "mc/sfe.r1",
+			vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-1"), Platform: ptrTo("kubernetes")})},
+	)
+	r, err := NewReader(buf)
+	assert.Assert(t, err)
+
+	stor := store.NewSyncMapStore(store.SyncMapStoreConfig{})
+	assert.Assert(t, Replay(context.Background(), r, NewStoreTarget(stor), ReplayOptions{}))
+
+	entry, ok := stor.Get("site-1")
+	assert.Assert(t, ok)
+	assert.DeepEqual(t, entry.Source, store.SourceRef{ID: "r1", Version: "1"})
+	assert.DeepEqual(t, entry.Record, vanflow.SiteRecord{
+		BaseRecord: vanflow.NewBase("site-1"),
+		Name:       ptrTo("west"),
+		Platform:   ptrTo("kubernetes"),
+	})
+}
+
+func TestReplayContainer(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	start := time.Now()
+	site := vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-1")}
+	buf := writeCapture(t,
+		Entry{Time: start, Address: "mc/sfe.all", Message: beacon("r1")},
+		Entry{Time: start, Address: "mc/sfe.r1.logs", Message: records(t, "mc/sfe.r1.logs", vanflow.LogRecord{BaseRecord: vanflow.NewBase("log-1")})},
+		Entry{Time: start.Add(200 * time.Millisecond), Address: "mc/sfe.r1", Message: records(t, "mc/sfe.r1", site)},
+	)
+	r, err := NewReader(buf)
+	assert.Assert(t, err)
+
+	router := session.NewMockRouter()
+	consumer := session.NewMockContainer(router)
+	beacons := consumer.NewReceiver("mc/sfe.all", session.ReceiverOptions{})
+	received := consumer.NewReceiver("mc/sfe.r1", session.ReceiverOptions{})
+
+	target := NewContainerTarget(session.NewMockContainer(router))
+	defer target.Close()
+	replayStart := time.Now()
+	assert.Assert(t, Replay(ctx, r, target, ReplayOptions{Speed: 2}))
+	assert.Assert(t, time.Since(replayStart) >= 100*time.Millisecond)
+
+	msg, err := beacons.Next(ctx)
+	assert.Assert(t, err)
+	assert.Equal(t, vanflow.DecodeBeacon(msg).Identity, "r1")
+	msg, err = received.Next(ctx)
+	assert.Assert(t, err)
+	message, err := vanflow.DecodeRecord(msg)
+	assert.Assert(t, err)
+	assert.DeepEqual(t, message.Records, []vanflow.Record{site})
+	// no one received the logs
+	assert.Equal(t, target.Dropped(), int64(1))
+
+	// flushes sent to the replayed source do not block
+	flush := vanflow.FlushMessage{MessageProps: vanflow.MessageProps{To: "sfe.r1"}}
+	assert.Assert(t, consumer.NewSender("sfe.r1", session.SenderOptions{}).Send(ctx, flush.Encode()))
+}
@@ -0,0 +1,6 @@
+/*
+The capture package records the vanflow messages of a router network to a
+file, and replays them to consumers such as a collector, either through a
+session.Container or directly into a record store.
+*/
+package capture
@@ -0,0 +1,149 @@
+package capture
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-amqp"
+)
+
+// FormatVersion is the version of the capture file format written by
+// Writer.
+const FormatVersion = 1
+
+// maximum size of a single encoded entry accepted by Reader
+const maxEntrySize = 64 * 1024 * 1024
+
+// Header describes a capture.
+type Header struct {
+	Version int       `json:"version"`
+	Start   time.Time `json:"start"`
+	// Source describes where the messages were captured from, such as the
+	// router endpoint.
+	Source string `json:"source,omitempty"`
+}
+
+// Entry is a vanflow message captured at a point in time.
+type Entry struct {
+	Time time.Time
+	// Address is the address the message was received on.
+	Address string
+	Message *amqp.Message
+}
+
+type entryJSON struct {
+	Time    time.Time `json:"time"`
+	Address string    `json:"address"`
+	Message []byte    `json:"message"`
+}
+
+// Writer writes a capture file: a gzip compressed stream of JSON lines,
+// the header followed by one line per entry holding the AMQP encoding of
+// its message. It is safe for concurrent use.
+type Writer struct {
+	mu      sync.Mutex
+	gz      *gzip.Writer
+	encoder *json.Encoder
+}
+
+// NewWriter starts a capture file on w with the given header. The current
+// format version is set on the header, and its start time when it is zero.
+func NewWriter(w io.Writer, header Header) (*Writer, error) {
+	header.Version = FormatVersion
+	if header.Start.IsZero() {
+		header.Start = time.Now()
+	}
+	gz := gzip.NewWriter(w)
+	writer := &Writer{
+		gz:      gz,
+		encoder: json.NewEncoder(gz),
+	}
+	if err := writer.encoder.Encode(header); err != nil {
+		return nil, fmt.Errorf("error writing capture header: %w", err)
+	}
+	return writer, nil
+}
+
+func (w *Writer) Write(entry Entry) error {
+	message, err := entry.Message.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("error encoding message: %w", err)
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.encoder.Encode(entryJSON{
+		Time:    entry.Time,
+		Address: entry.Address,
+		Message: message,
+	})
+}
+
+// Close flushes the capture. It does not close the underlying writer.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.gz.Close()
+}
+
+// Reader reads the entries of a capture file in the order they were
+// written.
+type Reader struct {
+	header  Header
+	scanner *bufio.Scanner
+}
+
+func NewReader(r io.Reader) (*Reader, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a capture file: %w", err)
+	}
+	scanner := bufio.NewScanner(gz)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxEntrySize)
+	reader := &Reader{scanner: scanner}
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("error reading capture header: %w", err)
+		}
+		return nil, fmt.Errorf("capture file is empty")
+	}
+	if err := json.Unmarshal(scanner.Bytes(), &reader.header); err != nil {
+		return nil, fmt.Errorf("error decoding capture header: %w", err)
+	}
+	if reader.header.Version != FormatVersion {
+		return nil, fmt.Errorf("unsupported capture file version %d", reader.header.Version)
+	}
+	return reader, nil
+}
+
+func (r *Reader) Header() Header {
+	return r.header
+}
+
+// Next returns the next entry of the capture, or io.EOF once all entries
+// have been read.
+func (r *Reader) Next() (Entry, error) {
+	if !r.scanner.Scan() {
+		if err := r.scanner.Err(); err != nil {
+			return Entry{}, fmt.Errorf("error reading capture: %w", err)
+		}
+		return Entry{}, io.EOF
+	}
+	var raw entryJSON
+	if err := json.Unmarshal(r.scanner.Bytes(), &raw); err != nil {
+		return Entry{}, fmt.Errorf("error decoding capture entry: %w", err)
+	}
+	msg := new(amqp.Message)
+	if err := msg.UnmarshalBinary(raw.Message); err != nil {
+		return Entry{}, fmt.Errorf("error decoding captured message: %w", err)
+	}
+	return Entry{
+		Time:    raw.Time,
+		Address: raw.Address,
+		Message: msg,
+	}, nil
+}
@@ -0,0 +1,229 @@
+package capture
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Azure/go-amqp"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/session"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+)
+
+const (
+	// how long a ContainerTarget waits for someone to receive the messages
+	// sent to an address before dropping them
+	sendTimeout = time.Second
+	// how long it waits once no one received the previous message sent to
+	// the address
+	unroutedSendTimeout = 10 * time.Millisecond
+)
+
+// Target receives the messages of a replayed capture.
+type Target interface {
+	// Send delivers a message originally received on address.
+	Send(ctx context.Context, address string, msg *amqp.Message) error
+}
+
+type ReplayOptions struct {
+	// Speed is the factor the capture is replayed faster than it was
+	// recorded by: 1 replays it in real time. Messages are replayed as fast
+	// as possible when it is zero.
+	Speed float64
+}
+
+// Replay sends the entries of a capture to target, preserving the time
+// elapsed between them scaled by the replay speed, until all have been
+// sent or ctx is cancelled.
+func Replay(ctx context.Context, r *Reader, target Target, opts ReplayOptions) error {
+	if opts.Speed < 0 {
+		return fmt.Errorf("invalid replay speed %v", opts.Speed)
+	}
+	var (
+		start    time.Time
+		captured time.Time
+	)
+	for {
+		entry, err := r.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if opts.Speed > 0 {
+			if start.IsZero() {
+				start, captured = time.Now(), entry.Time
+			}
+			elapsed := time.Duration(float64(entry.Time.Sub(captured)) / opts.Speed)
+			if wait := time.Until(start.Add(elapsed)); wait > 0 {
+				timer := time.NewTimer(wait)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return ctx.Err()
+				case <-timer.C:
+				}
+			}
+		}
+		if err := target.Send(ctx, entry.Address, entry.Message); err != nil {
+			return err
+		}
+	}
+}
+
+// ContainerTarget sends replayed messages to their original address
+// through a container, such as one attached to a session.MockRouter
+// standing in for the skupper router. Messages that no one receives are
+// dropped.
+type ContainerTarget struct {
+	container session.Container
+	ctx       context.Context
+	cancel    context.CancelFunc
+	dropped   atomic.Int64
+
+	mu      sync.Mutex
+	senders map[string]session.Sender
+	// addresses no one received from the last time a message was sent
+	unrouted map[string]bool
+	// direct addresses of the replayed event sources
+	direct map[string]bool
+}
+
+func NewContainerTarget(container session.Container) *ContainerTarget {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &ContainerTarget{
+		container: container,
+		ctx:       ctx,
+		cancel:    cancel,
+		senders:   make(map[string]session.Sender),
+		unrouted:  make(map[string]bool),
+		direct:    make(map[string]bool),
+	}
+}
+
+// Send waits for the address to be received from, for consumers to have
+// time to subscribe to the event sources they discover. Once it has not
+// been, messages to it are dropped after a much shorter wait.
+func (t *ContainerTarget) Send(ctx context.Context, address string, msg *amqp.Message) error {
+	if msg.Properties != nil && msg.Properties.Subject != nil && *msg.Properties.Subject == "BEACON" {
+		t.discard(vanflow.DecodeBeacon(msg).Direct)
+	}
+	t.mu.Lock()
+	sender, ok := t.senders[address]
+	if !ok {
+		sender = t.container.NewSender(address, session.SenderOptions{})
+		t.senders[address] = sender
+	}
+	timeout := sendTimeout
+	if t.unrouted[address] {
+		timeout = unroutedSendTimeout
+	}
+	t.mu.Unlock()
+
+	sendCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	err := sender.Send(sendCtx, msg)
+	unrouted := err != nil && ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded)
+	if unrouted {
+		t.dropped.Add(1)
+		err = nil
+	}
+	t.mu.Lock()
+	t.unrouted[address] = unrouted
+	t.mu.Unlock()
+	return err
+}
+
+// Dropped returns the number of messages no one received.
+func (t *ContainerTarget) Dropped() int64 {
+	return t.dropped.Load()
+}
+
+// Close stops receiving the messages sent to the replayed event sources.
+func (t *ContainerTarget) Close() error {
+	t.cancel()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for address, sender := range t.senders {
+		sender.Close(context.Background())
+		delete(t.senders, address)
+	}
+	return nil
+}
+
+// discard receives and drops the messages sent to the direct address of a
+// replayed event source, such as flush requests, that would otherwise not
+// be delivered.
+func (t *ContainerTarget) discard(address string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if address == "" || t.direct[address] {
+		return
+	}
+	t.direct[address] = true
+	receiver := t.container.NewReceiver(address, session.ReceiverOptions{})
+	go func() {
+		defer receiver.Close(context.Background())
+		for {
+			msg, err := receiver.Next(t.ctx)
+			if err != nil {
+				if t.ctx.Err() != nil {
+					return
+				}
+				continue
+			}
+			receiver.Accept(t.ctx, msg)
+		}
+	}()
+}
+
+// StoreTarget decodes replayed messages and adds the records they carry
+// to a store, on behalf of the event source that sent them. Beacons,
+// heartbeats and flushes are skipped.
+type StoreTarget struct {
+	stor store.Interface
+
+	mu sync.Mutex
+	// event sources by the addresses they send records to
+	sources map[string]store.SourceRef
+}
+
+func NewStoreTarget(stor store.Interface) *StoreTarget {
+	return &StoreTarget{
+		stor:    stor,
+		sources: make(map[string]store.SourceRef),
+	}
+}
+
+func (t *StoreTarget) Send(ctx context.Context, address string, msg *amqp.Message) error {
+	decoded, err := vanflow.Decode(msg)
+	if err != nil {
+		return fmt.Errorf("error decoding message sent to %s: %w", address, err)
+	}
+	switch message := decoded.(type) {
+	case vanflow.BeaconMessage:
+		source := store.SourceRef{
+			ID:      message.Identity,
+			Version: fmt.Sprint(message.Version),
+		}
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		for _, suffix := range []string{"", ".flows", ".logs"} {
+			t.sources[message.Address+suffix] = source
+		}
+	case vanflow.RecordMessage:
+		t.mu.Lock()
+		source := t.sources[address]
+		t.mu.Unlock()
+		for _, record := range message.Records {
+			t.stor.Patch(record, source)
+		}
+	}
+	return nil
+}
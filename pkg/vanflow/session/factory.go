@@ -22,6 +22,12 @@ func NewMockContainerFactory() ContainerFactory {
 	return mockFactory{Router: NewMockRouter()}
 }
 
+// NewMockRouterContainerFactory returns a factory of mock containers
+// attached to router.
+func NewMockRouterContainerFactory(router *MockRouter) ContainerFactory {
+	return mockFactory{Router: router}
+}
+
 type mockFactory struct {
 	Router *MockRouter
 }
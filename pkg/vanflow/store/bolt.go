@@ -0,0 +1,499 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	bolt "go.etcd.io/bbolt"
+)
+
+var boltRecordsBucket = []byte("records")
+
+// BoltStoreConfig configures a BoltStore
+type BoltStoreConfig struct {
+	// Path to the database file, created if it does not exist
+	Path     string
+	Indexers map[string]Indexer
+	Handlers EventHandlerFuncs
+	// RecordTypes lists the types of record the store can hold. Records of
+	// other types found in the database are ignored.
+	RecordTypes []vanflow.Record
+	// Retention is how long an entry is kept after its last update. Zero
+	// keeps entries regardless of their age.
+	Retention time.Duration
+	// MaxEntries is the maximum number of entries kept, the least recently
+	// updated ones being removed first. Zero does not limit the number of
+	// entries.
+	MaxEntries int
+}
+
+// BoltStore is an Interface implementation backed by an embedded bbolt
+// database so that its entries survive restarts. Indexes are kept in
+// memory and rebuilt when the database is opened. Retention limits are
+// applied by calling Expire.
+type BoltStore struct {
+	mu      sync.RWMutex
+	db      *bolt.DB
+	updated map[string]time.Time
+
+	types         map[string]reflect.Type
+	index         recordIndex
+	eventHandlers EventHandlerFuncs
+	retention     time.Duration
+	maxEntries    int
+}
+
+type boltEntry struct {
+	Type       string
+	LastUpdate time.Time
+	Source     SourceRef
+	Record     json.RawMessage
+}
+
+func NewBoltStore(cfg BoltStoreConfig) (*BoltStore, error) {
+	if cfg.Indexers == nil {
+		cfg.Indexers = defaultIndexers()
+	}
+	db, err := bolt.Open(cfg.Path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("error opening store database %q: %w", cfg.Path, err)
+	}
+	m := &BoltStore{
+		db:            db,
+		updated:       make(map[string]time.Time),
+		types:         make(map[string]reflect.Type, len(cfg.RecordTypes)),
+		index:         newRecordIndex(cfg.Indexers),
+		eventHandlers: cfg.Handlers,
+		retention:     cfg.Retention,
+		maxEntries:    cfg.MaxEntries,
+	}
+	for _, record := range cfg.RecordTypes {
+		m.types[record.GetTypeMeta().String()] = reflect.TypeOf(record)
+	}
+	if err := m.load(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("error loading store database %q: %w", cfg.Path, err)
+	}
+	return m, nil
+}
+
+// Close releases the underlying database
+func (m *BoltStore) Close() error {
+	return m.db.Close()
+}
+
+func (m *BoltStore) load() error {
+	return m.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(boltRecordsBucket)
+		if err != nil {
+			return err
+		}
+		return bucket.ForEach(func(k, v []byte) error {
+			entry, ok := m.decode(v)
+			if !ok {
+				return nil
+			}
+			key := string(k)
+			m.updated[key] = entry.LastUpdate
+			m.index.reindex(key, nil, entry)
+			return nil
+		})
+	})
+}
+
+func (m *BoltStore) encode(entry Entry) ([]byte, error) {
+	record, err := json.Marshal(entry.Record)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(boltEntry{
+		Type:       entry.Record.GetTypeMeta().String(),
+		LastUpdate: entry.LastUpdate,
+		Source:     entry.Source,
+		Record:     record,
+	})
+}
+
+func (m *BoltStore) decode(data []byte) (Entry, bool) {
+	var (
+		entry Entry
+		be    boltEntry
+	)
+	if err := json.Unmarshal(data, &be); err != nil {
+		return entry, false
+	}
+	typ, ok := m.types[be.Type]
+	if !ok {
+		return entry, false
+	}
+	record := reflect.New(typ)
+	if err := json.Unmarshal(be.Record, record.Interface()); err != nil {
+		return entry, false
+	}
+	entry.LastUpdate = be.LastUpdate
+	entry.Source = be.Source
+	entry.Record = record.Elem().Interface().(vanflow.Record)
+	return entry, true
+}
+
+func (m *BoltStore) get(tx *bolt.Tx, key string) (Entry, bool) {
+	data := tx.Bucket(boltRecordsBucket).Get([]byte(key))
+	if data == nil {
+		return Entry{}, false
+	}
+	return m.decode(data)
+}
+
+func (m *BoltStore) put(tx *bolt.Tx, key string, entry Entry) error {
+	data, err := m.encode(entry)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(boltRecordsBucket).Put([]byte(key), data)
+}
+
+func (m *BoltStore) Add(record vanflow.Record, source SourceRef) bool {
+	key := record.Identity()
+	entry := Entry{
+		Metadata: Metadata{LastUpdate: time.Now(), Source: source},
+		Record:   record,
+	}
+	ok := func() bool {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if _, exists := m.updated[key]; exists {
+			return false
+		}
+		err := m.db.Update(func(tx *bolt.Tx) error {
+			return m.put(tx, key, entry)
+		})
+		if err != nil {
+			return false
+		}
+		m.updated[key] = entry.LastUpdate
+		m.index.reindex(key, nil, entry)
+		return true
+	}()
+
+	if ok && m.eventHandlers.OnAdd != nil {
+		m.eventHandlers.OnAdd(entry)
+	}
+	return ok
+}
+
+// Put adds the entries, or replaces the stored ones with the same
+// identity, in a single transaction.
+func (m *BoltStore) Put(entries ...Entry) error {
+	type change struct {
+		prev   Entry
+		next   Entry
+		exists bool
+	}
+	changes, err := func() ([]change, error) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		changes := make([]change, 0, len(entries))
+		err := m.db.Update(func(tx *bolt.Tx) error {
+			for _, entry := range entries {
+				key := entry.Record.Identity()
+				c := change{next: entry}
+				if _, exists := m.updated[key]; exists {
+					c.prev, c.exists = m.get(tx, key)
+				}
+				if err := m.put(tx, key, entry); err != nil {
+					return err
+				}
+				changes = append(changes, c)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range changes {
+			key := c.next.Record.Identity()
+			m.updated[key] = c.next.LastUpdate
+			if c.exists {
+				m.index.reindex(key, &c.prev, c.next)
+			} else {
+				m.index.reindex(key, nil, c.next)
+			}
+		}
+		return changes, nil
+	}()
+	if err != nil {
+		return err
+	}
+	for _, c := range changes {
+		if c.exists && m.eventHandlers.OnChange != nil {
+			m.eventHandlers.OnChange(c.prev, c.next)
+		} else if !c.exists && m.eventHandlers.OnAdd != nil {
+			m.eventHandlers.OnAdd(c.next)
+		}
+	}
+	return nil
+}
+
+func (m *BoltStore) Update(record vanflow.Record) bool {
+	return m.replace(record.Identity(), func(prev Entry) (Entry, bool, error) {
+		next := prev
+		next.LastUpdate = time.Now()
+		next.Record = record
+		return next, true, nil
+	})
+}
+
+// replace applies the change to the entry stored under key, if any,
+// persisting the result and notifying the OnChange handler.
+func (m *BoltStore) replace(key string, change func(prev Entry) (Entry, bool, error)) bool {
+	prev, next, ok := func() (Entry, Entry, bool) {
+		var prev, next Entry
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if _, exists := m.updated[key]; !exists {
+			return prev, next, false
+		}
+		changed := false
+		err := m.db.Update(func(tx *bolt.Tx) error {
+			var ok bool
+			var err error
+			if prev, ok = m.get(tx, key); !ok {
+				return fmt.Errorf("could not decode entry %q", key)
+			}
+			if next, changed, err = change(prev); err != nil || !changed {
+				return err
+			}
+			return m.put(tx, key, next)
+		})
+		if err != nil || !changed {
+			return prev, next, false
+		}
+		m.updated[key] = next.LastUpdate
+		m.index.reindex(key, &prev, next)
+		return prev, next, true
+	}()
+
+	if ok && m.eventHandlers.OnChange != nil {
+		m.eventHandlers.OnChange(prev, next)
+	}
+	return ok
+}
+
+func (m *BoltStore) Get(id string) (Entry, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var (
+		entry Entry
+		ok    bool
+	)
+	m.db.View(func(tx *bolt.Tx) error {
+		entry, ok = m.get(tx, id)
+		return nil
+	})
+	return entry, ok
+}
+
+func (m *BoltStore) Delete(id string) (Entry, bool) {
+	deleted := func() []Entry {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		return m.delete([]string{id})
+	}()
+	if len(deleted) == 0 {
+		return Entry{}, false
+	}
+	if m.eventHandlers.OnDelete != nil {
+		m.eventHandlers.OnDelete(deleted[0])
+	}
+	return deleted[0], true
+}
+
+// delete removes the entries stored under the given keys in a single
+// transaction, returning the removed entries.
+func (m *BoltStore) delete(keys []string) []Entry {
+	var deleted []Entry
+	err := m.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(boltRecordsBucket)
+		for _, key := range keys {
+			if _, exists := m.updated[key]; !exists {
+				continue
+			}
+			if entry, ok := m.get(tx, key); ok {
+				deleted = append(deleted, entry)
+			}
+			if err := bucket.Delete([]byte(key)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil
+	}
+	for _, key := range keys {
+		delete(m.updated, key)
+	}
+	for _, entry := range deleted {
+		m.index.unindex(entry.Record.Identity(), entry)
+	}
+	return deleted
+}
+
+func (m *BoltStore) Patch(record vanflow.Record, source SourceRef) {
+	key := record.Identity()
+	m.mu.RLock()
+	_, exists := m.updated[key]
+	m.mu.RUnlock()
+	if !exists {
+		m.Add(record, source)
+		return
+	}
+	m.replace(key, func(prev Entry) (Entry, bool, error) {
+		patched, changed, err := mergeRecord(prev.Record, record)
+		if err != nil || !changed {
+			return prev, false, err
+		}
+		next := prev
+		next.Record = patched
+		next.LastUpdate = time.Now()
+		return next, true, nil
+	})
+}
+
+func (m *BoltStore) List() []Entry {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	entries := make([]Entry, 0, len(m.updated))
+	m.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(boltRecordsBucket).ForEach(func(_, v []byte) error {
+			if entry, ok := m.decode(v); ok {
+				entries = append(entries, entry)
+			}
+			return nil
+		})
+	})
+	return entries
+}
+
+func (m *BoltStore) Index(index string, exemplar Entry) []Entry {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	keys := m.index.keys(index, exemplar)
+	if keys == nil {
+		return nil
+	}
+	entries := make([]Entry, 0, len(keys))
+	m.db.View(func(tx *bolt.Tx) error {
+		for key := range keys {
+			if entry, ok := m.get(tx, key); ok {
+				entries = append(entries, entry)
+			}
+		}
+		return nil
+	})
+	return entries
+}
+
+// IndexSince is like Index, but only returns the entries last updated at
+// or after since. Other entries are skipped without being read from the
+// database.
+func (m *BoltStore) IndexSince(index string, exemplar Entry, since time.Time) []Entry {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	keys := m.index.keys(index, exemplar)
+	if keys == nil {
+		return nil
+	}
+	var entries []Entry
+	m.db.View(func(tx *bolt.Tx) error {
+		for key := range keys {
+			if m.updated[key].Before(since) {
+				continue
+			}
+			if entry, ok := m.get(tx, key); ok {
+				entries = append(entries, entry)
+			}
+		}
+		return nil
+	})
+	return entries
+}
+
+func (m *BoltStore) IndexValues(index string) []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.index.values(index)
+}
+
+func (m *BoltStore) Replace(items []Entry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	err := m.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.DeleteBucket(boltRecordsBucket); err != nil {
+			return err
+		}
+		if _, err := tx.CreateBucket(boltRecordsBucket); err != nil {
+			return err
+		}
+		for _, item := range items {
+			if err := m.put(tx, item.Record.Identity(), item); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return
+	}
+	m.updated = make(map[string]time.Time, len(items))
+	m.index.reset()
+	for _, item := range items {
+		key := item.Record.Identity()
+		m.updated[key] = item.LastUpdate
+		m.index.reindex(key, nil, item)
+	}
+}
+
+// Expire removes the entries exceeding the configured retention limits,
+// returning how many were removed.
+func (m *BoltStore) Expire() int {
+	expired := func() []Entry {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		var keys []string
+		if m.retention > 0 {
+			cutoff := time.Now().Add(-m.retention)
+			for key, updated := range m.updated {
+				if updated.Before(cutoff) {
+					keys = append(keys, key)
+				}
+			}
+		}
+		if excess := len(m.updated) - len(keys) - m.maxEntries; m.maxEntries > 0 && excess > 0 {
+			remaining := make([]string, 0, len(m.updated))
+			for key := range m.updated {
+				remaining = append(remaining, key)
+			}
+			sort.Slice(remaining, func(i, j int) bool {
+				return m.updated[remaining[i]].Before(m.updated[remaining[j]])
+			})
+			// entries already past their retention sort first
+			keys = remaining[:len(keys)+excess]
+		}
+		if len(keys) == 0 {
+			return nil
+		}
+		return m.delete(keys)
+	}()
+	if m.eventHandlers.OnDelete != nil {
+		for _, entry := range expired {
+			m.eventHandlers.OnDelete(entry)
+		}
+	}
+	return len(expired)
+}
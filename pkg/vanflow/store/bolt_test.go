@@ -0,0 +1,237 @@
+package store
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+)
+
+var sortStrings = cmpopts.SortSlices(func(a, b string) bool { return a < b })
+
+func newTestBoltStore(t *testing.T, cfg BoltStoreConfig) *BoltStore {
+	t.Helper()
+	if cfg.Path == "" {
+		cfg.Path = filepath.Join(t.TempDir(), "test.db")
+	}
+	if cfg.RecordTypes == nil {
+		cfg.RecordTypes = []vanflow.Record{vanflow.LogRecord{}, vanflow.SiteRecord{}}
+	}
+	stor, err := NewBoltStore(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error opening store: %s", err)
+	}
+	t.Cleanup(func() { stor.Close() })
+	return stor
+}
+
+func TestBoltStore(t *testing.T) {
+	var added, changed, deleted int
+	stor := newTestBoltStore(t, BoltStoreConfig{
+		Handlers: EventHandlerFuncs{
+			OnAdd:    func(Entry) { added++ },
+			OnChange: func(Entry, Entry) { changed++ },
+			OnDelete: func(Entry) { deleted++ },
+		},
+	})
+	source := SourceRef{ID: "test"}
+
+	if !stor.Add(vanflow.LogRecord{BaseRecord: vanflow.NewBase("1"), LogText: ptrTo("one")}, source) {
+		t.Fatal("expected add to succeed")
+	}
+	if stor.Add(vanflow.LogRecord{BaseRecord: vanflow.NewBase("1")}, source) {
+		t.Fatal("expected add of existing record to fail")
+	}
+	if stor.Update(vanflow.LogRecord{BaseRecord: vanflow.NewBase("2")}) {
+		t.Fatal("expected update of missing record to fail")
+	}
+	if !stor.Update(vanflow.LogRecord{BaseRecord: vanflow.NewBase("1"), LogText: ptrTo("uno")}) {
+		t.Fatal("expected update to succeed")
+	}
+	stor.Patch(vanflow.LogRecord{BaseRecord: vanflow.NewBase("1"), SourceLine: ptrTo(uint64(12))}, source)
+	stor.Patch(vanflow.LogRecord{BaseRecord: vanflow.NewBase("2"), LogText: ptrTo("two")}, source)
+	stor.Patch(vanflow.LogRecord{BaseRecord: vanflow.NewBase("2")}, source)
+
+	entry, ok := stor.Get("1")
+	if !ok {
+		t.Fatal("expected record 1 to be found")
+	}
+	expected := vanflow.LogRecord{BaseRecord: vanflow.NewBase("1"), LogText: ptrTo("uno"), SourceLine: ptrTo(uint64(12))}
+	if !cmp.Equal(expected, entry.Record) {
+		t.Errorf("unexpected record: %s", cmp.Diff(expected, entry.Record))
+	}
+	if entry.Source != source {
+		t.Errorf("expected source %v but got %v", source, entry.Source)
+	}
+	if expected, actual := 2, len(stor.List()); expected != actual {
+		t.Errorf("expected %d entries but got %d", expected, actual)
+	}
+
+	if _, ok := stor.Delete("1"); !ok {
+		t.Fatal("expected delete to succeed")
+	}
+	if _, ok := stor.Delete("1"); ok {
+		t.Fatal("expected delete of missing record to fail")
+	}
+	if _, ok := stor.Get("1"); ok {
+		t.Fatal("expected record 1 to be deleted")
+	}
+	if added != 2 || changed != 2 || deleted != 1 {
+		t.Errorf("unexpected event counts: added=%d changed=%d deleted=%d", added, changed, deleted)
+	}
+}
+
+func TestBoltStorePersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+	stor, err := NewBoltStore(BoltStoreConfig{
+		Path:        path,
+		RecordTypes: []vanflow.Record{vanflow.LogRecord{}, vanflow.SiteRecord{}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error opening store: %s", err)
+	}
+	start := time.UnixMicro(time.Now().UnixMicro())
+	for i := 0; i < 4; i++ {
+		stor.Add(vanflow.LogRecord{BaseRecord: vanflow.NewBase(fmt.Sprintf("log-%d", i), start)}, SourceRef{ID: fmt.Sprint(i % 2)})
+	}
+	stor.Add(vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site"), Name: ptrTo("west")}, SourceRef{ID: "0"})
+	expected := stor.List()
+	if err := stor.Close(); err != nil {
+		t.Fatalf("unexpected error closing store: %s", err)
+	}
+
+	reopened := newTestBoltStore(t, BoltStoreConfig{Path: path})
+	if actual := reopened.List(); !cmp.Equal(expected, actual, ignoreLastUpdateAndOrder...) {
+		t.Errorf("expected entries to survive reopening the store: %s", cmp.Diff(expected, actual, ignoreLastUpdateAndOrder...))
+	}
+	if expected, actual := 3, len(reopened.Index(SourceIndex, Entry{Metadata: Metadata{Source: SourceRef{ID: "0"}}})); expected != actual {
+		t.Errorf("expected %d entries for source '0' but got %d", expected, actual)
+	}
+	if expected, actual := 4, len(reopened.Index(TypeIndex, Entry{Record: vanflow.LogRecord{}})); expected != actual {
+		t.Errorf("expected %d log records but got %d", expected, actual)
+	}
+	if expected, actual := []string{"/0", "/1"}, reopened.IndexValues(SourceIndex); !cmp.Equal(expected, actual, sortStrings) {
+		t.Errorf("unexpected index values: %s", cmp.Diff(expected, actual, sortStrings))
+	}
+
+	// records of unknown types are ignored
+	logsPath := filepath.Join(t.TempDir(), "logs.db")
+	logsOnly := newTestBoltStore(t, BoltStoreConfig{
+		Path:        logsPath,
+		RecordTypes: []vanflow.Record{vanflow.LogRecord{}},
+	})
+	logsOnly.Replace(expected)
+	logsOnly.Close()
+	logsOnly = newTestBoltStore(t, BoltStoreConfig{
+		Path:        logsPath,
+		RecordTypes: []vanflow.Record{vanflow.LogRecord{}},
+	})
+	if expected, actual := 4, len(logsOnly.List()); expected != actual {
+		t.Errorf("expected %d entries but got %d", expected, actual)
+	}
+}
+
+func TestBoltStoreExpire(t *testing.T) {
+	testCases := []struct {
+		Name       string
+		Retention  time.Duration
+		MaxEntries int
+		Expected   []string
+	}{
+		{
+			Name:     "no limits",
+			Expected: []string{"0", "1", "2", "3", "4"},
+		}, {
+			Name:      "retention",
+			Retention: 150 * time.Minute,
+			Expected:  []string{"0", "1", "2"},
+		}, {
+			Name:       "max entries",
+			MaxEntries: 2,
+			Expected:   []string{"0", "1"},
+		}, {
+			Name:       "retention and max entries",
+			Retention:  150 * time.Minute,
+			MaxEntries: 4,
+			Expected:   []string{"0", "1", "2"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var deleted []string
+			stor := newTestBoltStore(t, BoltStoreConfig{
+				Retention:  tc.Retention,
+				MaxEntries: tc.MaxEntries,
+				Handlers: EventHandlerFuncs{
+					OnDelete: func(e Entry) { deleted = append(deleted, e.Record.Identity()) },
+				},
+			})
+			var entries []Entry
+			for i := 0; i < 5; i++ {
+				entries = append(entries, Entry{
+					Metadata: Metadata{LastUpdate: time.Now().Add(-time.Duration(i) * time.Hour)},
+					Record:   vanflow.LogRecord{BaseRecord: vanflow.NewBase(fmt.Sprint(i))},
+				})
+			}
+			stor.Replace(entries)
+
+			if expected, actual := 5-len(tc.Expected), stor.Expire(); expected != actual {
+				t.Errorf("expected %d entries expired but got %d", expected, actual)
+			}
+			if expected, actual := 5-len(tc.Expected), len(deleted); expected != actual {
+				t.Errorf("expected %d delete events but got %d", expected, actual)
+			}
+			var remaining []string
+			for _, entry := range stor.List() {
+				remaining = append(remaining, entry.Record.Identity())
+			}
+			if !cmp.Equal(tc.Expected, remaining, sortStrings) {
+				t.Errorf("unexpected remaining entries: %s", cmp.Diff(tc.Expected, remaining, sortStrings))
+			}
+		})
+	}
+}
+
+func TestBoltStorePut(t *testing.T) {
+	var added, changed int
+	stor := newTestBoltStore(t, BoltStoreConfig{
+		Handlers: EventHandlerFuncs{
+			OnAdd:    func(Entry) { added++ },
+			OnChange: func(Entry, Entry) { changed++ },
+		},
+	})
+	now := time.Now()
+	stor.Add(vanflow.LogRecord{BaseRecord: vanflow.NewBase("0"), LogText: ptrTo("zero")}, SourceRef{ID: "a"})
+	var entries []Entry
+	for i := 0; i < 3; i++ {
+		entries = append(entries, Entry{
+			Metadata: Metadata{LastUpdate: now.Add(-time.Duration(i) * time.Hour), Source: SourceRef{ID: "b"}},
+			Record:   vanflow.LogRecord{BaseRecord: vanflow.NewBase(fmt.Sprint(i))},
+		})
+	}
+	if err := stor.Put(entries...); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if added != 3 || changed != 1 {
+		t.Errorf("unexpected event counts: added=%d changed=%d", added, changed)
+	}
+	entry, ok := stor.Get("0")
+	if !ok || entry.Record.(vanflow.LogRecord).LogText != nil || entry.Source.ID != "b" {
+		t.Errorf("expected entry 0 to be replaced: %v", entry)
+	}
+	if expected, actual := 0, len(stor.Index(SourceIndex, Entry{Metadata: Metadata{Source: SourceRef{ID: "a"}}})); expected != actual {
+		t.Errorf("expected %d entries for source 'a' but got %d", expected, actual)
+	}
+
+	var since []string
+	for _, entry := range stor.IndexSince(TypeIndex, Entry{Record: vanflow.LogRecord{}}, now.Add(-90*time.Minute)) {
+		since = append(since, entry.Record.Identity())
+	}
+	if expected := []string{"0", "1"}; !cmp.Equal(expected, since, sortStrings) {
+		t.Errorf("unexpected entries updated since: %s", cmp.Diff(expected, since, sortStrings))
+	}
+}
@@ -19,8 +19,7 @@ type syncMapStore struct {
 	mu    sync.RWMutex
 	items map[string]Entry
 
-	indexers      map[string]Indexer
-	indices       map[string]map[string]keySet
+	index         recordIndex
 	eventHandlers EventHandlerFuncs
 }
 
@@ -34,11 +33,10 @@ func NewSyncMapStore(cfg SyncMapStoreConfig) Interface {
 		cfg.Indexers = defaultIndexers()
 	}
 	return &syncMapStore{
-		indexers:      cfg.Indexers,
+		index:         newRecordIndex(cfg.Indexers),
 		eventHandlers: cfg.Handlers,
 
-		items:   make(map[string]Entry),
-		indices: make(map[string]map[string]keySet),
+		items: make(map[string]Entry),
 	}
 }
 
@@ -58,7 +56,7 @@ func (m *syncMapStore) Add(record vanflow.Record, source SourceRef) bool {
 			return entry, false
 		}
 		m.items[key] = entry
-		m.reindex(key, nil, entry)
+		m.index.reindex(key, nil, entry)
 		return entry, true
 	}()
 
@@ -83,7 +81,7 @@ func (m *syncMapStore) Update(record vanflow.Record) bool {
 		next.LastUpdate = time.Now()
 		next.Record = record
 		m.items[key] = next
-		m.reindex(key, &prev, next)
+		m.index.reindex(key, &prev, next)
 		return prev, next, true
 	}()
 
@@ -110,7 +108,7 @@ func (m *syncMapStore) Delete(id string) (Entry, bool) {
 			return curr, false
 		}
 		delete(m.items, id)
-		m.unindex(id, curr)
+		m.index.unindex(id, curr)
 		return curr, true
 	}()
 	if ok && m.eventHandlers.OnDelete != nil {
@@ -135,35 +133,17 @@ func (m *syncMapStore) Patch(record vanflow.Record, source SourceRef) {
 			return prev, next, dne, nil
 		}
 
-		currAttrs, err := encoding.Encode(curr.Record)
-		if err != nil {
-			err = fmt.Errorf("error encoding current record for comparison: %w", err)
-			return
-		}
-		nextAttrs, err := encoding.Encode(record)
-		if err != nil {
-			err = fmt.Errorf("error encoding incoming record for comparison: %w", err)
-			return
-		}
-		var changed bool
-		for nK, nV := range nextAttrs {
-			cV, ok := currAttrs[nK]
-			if !ok || cV != nV {
-				changed = true
-				currAttrs[nK] = nV
-			}
-		}
-		if !changed {
-			return prev, next, noChange, nil
+		patched, changed, err := mergeRecord(curr.Record, record)
+		if err != nil || !changed {
+			return prev, next, noChange, err
 		}
 
 		prev = curr
 		next = curr
-		patched, err := encoding.Decode(currAttrs)
-		next.Record = patched.(vanflow.Record)
+		next.Record = patched
 		next.LastUpdate = time.Now()
 		m.items[key] = next
-		m.reindex(key, &prev, next)
+		m.index.reindex(key, &prev, next)
 		return
 	}()
 
@@ -197,19 +177,10 @@ func (m *syncMapStore) List() []Entry {
 func (m *syncMapStore) Index(index string, exemplar Entry) []Entry {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
-	indexer, ok := m.indexers[index]
-	if !ok {
+	keys := m.index.keys(index, exemplar)
+	if keys == nil {
 		return nil
 	}
-	idx := m.indices[index]
-	indexVals := indexer(exemplar)
-
-	keys := make(keySet)
-	for _, indexVal := range indexVals {
-		for key := range idx[indexVal] {
-			keys.Add(key)
-		}
-	}
 	entries := make([]Entry, 0, len(keys))
 	for key := range keys {
 		entries = append(entries, m.items[key])
@@ -219,16 +190,7 @@ func (m *syncMapStore) Index(index string, exemplar Entry) []Entry {
 func (m *syncMapStore) IndexValues(index string) []string {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
-
-	idx := m.indices[index]
-	if len(idx) == 0 {
-		return nil
-	}
-	values := make([]string, 0, len(idx))
-	for val := range idx {
-		values = append(values, val)
-	}
-	return values
+	return m.index.values(index)
 }
 
 func (m *syncMapStore) Replace(items []Entry) {
@@ -241,13 +203,91 @@ func (m *syncMapStore) Replace(items []Entry) {
 	}
 	m.items = entries
 
-	m.indices = make(map[string]map[string]keySet)
+	m.index.reset()
 	for key, entry := range m.items {
-		m.reindex(key, nil, entry)
+		m.index.reindex(key, nil, entry)
+	}
+}
+
+// mergeRecord merges the partial state contained in next into curr,
+// reporting whether any attribute changed.
+func mergeRecord(curr, next vanflow.Record) (vanflow.Record, bool, error) {
+	currAttrs, err := encoding.Encode(curr)
+	if err != nil {
+		return nil, false, fmt.Errorf("error encoding current record for comparison: %w", err)
+	}
+	nextAttrs, err := encoding.Encode(next)
+	if err != nil {
+		return nil, false, fmt.Errorf("error encoding incoming record for comparison: %w", err)
 	}
+	var changed bool
+	for nK, nV := range nextAttrs {
+		cV, ok := currAttrs[nK]
+		if !ok || cV != nV {
+			changed = true
+			currAttrs[nK] = nV
+		}
+	}
+	if !changed {
+		return curr, false, nil
+	}
+	patched, err := encoding.Decode(currAttrs)
+	if err != nil {
+		return nil, false, err
+	}
+	return patched.(vanflow.Record), true, nil
+}
+
+// recordIndex maintains the secondary indexes of a store, mapping each
+// value produced by an Indexer to the set of keys it was produced for.
+type recordIndex struct {
+	indexers map[string]Indexer
+	indices  map[string]map[string]keySet
+}
+
+func newRecordIndex(indexers map[string]Indexer) recordIndex {
+	return recordIndex{
+		indexers: indexers,
+		indices:  make(map[string]map[string]keySet),
+	}
+}
+
+func (m *recordIndex) reset() {
+	m.indices = make(map[string]map[string]keySet)
+}
+
+// keys returns the keys matching the exemplar in the named index, or nil
+// when there is no such index.
+func (m *recordIndex) keys(index string, exemplar Entry) keySet {
+	indexer, ok := m.indexers[index]
+	if !ok {
+		return nil
+	}
+	idx := m.indices[index]
+	indexVals := indexer(exemplar)
+
+	keys := make(keySet)
+	for _, indexVal := range indexVals {
+		for key := range idx[indexVal] {
+			keys.Add(key)
+		}
+	}
+	return keys
+}
+
+func (m *recordIndex) values(index string) []string {
+	idx := m.indices[index]
+	if len(idx) == 0 {
+		return nil
+	}
+	values := make([]string, 0, len(idx))
+	for val := range idx {
+		values = append(values, val)
+	}
+	return values
 }
 
-func (m *syncMapStore) unindex(key string, entry Entry) {
+func (m *recordIndex) unindex(key string, entry Entry) {
 	for name, indexer := range m.indexers {
 		indexVals := indexer(entry)
 
@@ -266,7 +306,7 @@ func (m *syncMapStore) unindex(key string, entry Entry) {
 	}
 }
 
-func (m *syncMapStore) reindex(key string, prev *Entry, next Entry) {
+func (m *recordIndex) reindex(key string, prev *Entry, next Entry) {
 	if prev != nil {
 		m.unindex(key, *prev)
 	}
@@ -99,6 +99,10 @@ spec:
         application: skupper-controller
         app.kubernetes.io/name: skupper-controller
         skupper.io/component: controller
+      annotations:
+        prometheus.io/scrape: "true"
+        prometheus.io/port: "9191"
+        prometheus.io/path: /metrics
     spec:
       serviceAccountName: skupper-controller
       # Prevent kubernetes from injecting env vars for grant service
@@ -128,6 +132,21 @@ spec:
               value: ${SKUPPER_ROUTER_IMAGE}
             - name: SKUPPER_ROUTER_IMAGE_PULL_POLICY
               value: Always
+          ports:
+            - name: metrics
+              containerPort: 9191
+          livenessProbe:
+            httpGet:
+              path: /healthz
+              port: metrics
+            initialDelaySeconds: 15
+            periodSeconds: 20
+          readinessProbe:
+            httpGet:
+              path: /readyz
+              port: metrics
+            initialDelaySeconds: 5
+            periodSeconds: 10
           securityContext:
             capabilities:
               drop:
@@ -161,6 +180,10 @@ spec:
         application: skupper-controller
         app.kubernetes.io/name: skupper-controller
         skupper.io/component: controller
+      annotations:
+        prometheus.io/scrape: "true"
+        prometheus.io/port: "9191"
+        prometheus.io/path: /metrics
     spec:
       serviceAccountName: skupper-controller
       # Prevent kubernetes from injecting env vars for grant service
@@ -194,6 +217,21 @@ spec:
               value: ${SKUPPER_ROUTER_IMAGE}
             - name: SKUPPER_ROUTER_IMAGE_PULL_POLICY
               value: Always
+          ports:
+            - name: metrics
+              containerPort: 9191
+          livenessProbe:
+            httpGet:
+              path: /healthz
+              port: metrics
+            initialDelaySeconds: 15
+            periodSeconds: 20
+          readinessProbe:
+            httpGet:
+              path: /readyz
+              port: metrics
+            initialDelaySeconds: 5
+            periodSeconds: 10
           securityContext:
             capabilities:
               drop: